@@ -4,6 +4,8 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -22,17 +24,26 @@ func runLSP(args []string) int {
 	flag := flag.NewFlagSet("lsp", flag.ContinueOnError)
 	stdio := flag.Bool("stdio", false, "use stdio for communication")
 	pprofDir := flag.String("pprofDir", "", "Generate pprof CPU/memory profiles to the given directory.")
-	pipe := flag.String("pipe", "", "use named pipe for communication")
-	_ = pipe
-	socket := flag.String("socket", "", "use socket for communication")
-	_ = socket
+	pipe := flag.String("pipe", "", "use named pipe or Unix domain socket for communication")
+	socket := flag.String("socket", "", "use TCP socket (port or host:port) for communication")
+	listen := flag.Bool("listen", false, "listen on --pipe or --socket for any number of clients instead of connecting to one")
 	if err := flag.Parse(args); err != nil {
 		return 2
 	}
 
-	if !*stdio {
-		fmt.Fprintln(os.Stderr, "only stdio is supported")
-		return 1
+	transports := 0
+	for _, set := range []bool{*stdio, *pipe != "", *socket != ""} {
+		if set {
+			transports++
+		}
+	}
+	if transports != 1 {
+		fmt.Fprintln(os.Stderr, "exactly one of --stdio, --pipe, or --socket must be specified")
+		return 2
+	}
+	if *listen && *stdio {
+		fmt.Fprintln(os.Stderr, "--listen requires --pipe or --socket")
+		return 2
 	}
 
 	if *pprofDir != "" {
@@ -45,9 +56,7 @@ func runLSP(args []string) int {
 	defaultLibraryPath := bundled.LibPath()
 	typingsLocation := getGlobalTypingsCacheLocation()
 
-	s := lsp.NewServer(&lsp.ServerOptions{
-		In:                 lsp.ToReader(os.Stdin),
-		Out:                lsp.ToWriter(os.Stdout),
+	opts := lsp.ServerOptions{
 		Err:                os.Stderr,
 		Cwd:                core.Must(os.Getwd()),
 		FS:                 fs,
@@ -58,11 +67,52 @@ func runLSP(args []string) int {
 			cmd.Dir = cwd
 			return cmd.Output()
 		},
-	})
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	if *listen {
+		var listener net.Listener
+		var err error
+		if *pipe != "" {
+			listener, err = lsp.ListenPipe(*pipe)
+		} else {
+			listener, err = lsp.ListenSocket(*socket)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "listening on %s\n", listener.Addr())
+		if err := lsp.Serve(ctx, listener, opts); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	var in io.Reader = os.Stdin
+	var out io.Writer = os.Stdout
+	if !*stdio {
+		var conn io.ReadWriteCloser
+		var err error
+		if *pipe != "" {
+			conn, err = lsp.DialPipe(*pipe)
+		} else {
+			conn, err = lsp.DialSocket(*socket)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer conn.Close()
+		in, out = conn, conn
+	}
+	opts.In = lsp.ToReader(in)
+	opts.Out = lsp.ToWriter(out)
+
+	s := lsp.NewServer(&opts)
 	if err := s.Run(ctx); err != nil {
 		return 1
 	}
@@ -3109,7 +3109,8 @@ func (node *ForInOrOfStatement) computeSubtreeFacts() SubtreeFacts {
 	return propagateSubtreeFacts(node.Initializer) |
 		propagateSubtreeFacts(node.Expression) |
 		propagateSubtreeFacts(node.Statement) |
-		core.IfElse(node.AwaitModifier != nil, SubtreeContainsForAwaitOrAsyncGenerator, SubtreeFactsNone)
+		core.IfElse(node.AwaitModifier != nil, SubtreeContainsForAwaitOrAsyncGenerator, SubtreeFactsNone) |
+		core.IfElse(node.Kind == KindForOfStatement && node.AwaitModifier == nil, SubtreeContainsIteration, SubtreeFactsNone)
 }
 
 func IsForInStatement(node *Node) bool {
@@ -3869,7 +3870,7 @@ func (node *BindingPattern) computeSubtreeFacts() SubtreeFacts {
 	case KindObjectBindingPattern:
 		return propagateNodeListSubtreeFacts(node.Elements, propagateObjectBindingElementSubtreeFacts)
 	case KindArrayBindingPattern:
-		return propagateNodeListSubtreeFacts(node.Elements, propagateBindingElementSubtreeFacts)
+		return propagateNodeListSubtreeFacts(node.Elements, propagateBindingElementSubtreeFacts) | SubtreeContainsIteration
 	default:
 		return SubtreeFactsNone
 	}
@@ -4115,6 +4116,7 @@ func (node *FunctionDeclaration) computeSubtreeFacts() SubtreeFacts {
 			propagateEraseableSyntaxSubtreeFacts(node.FullSignature) |
 			propagateSubtreeFacts(node.Body) |
 			core.IfElse(isAsync && isGenerator, SubtreeContainsForAwaitOrAsyncGenerator, SubtreeFactsNone) |
+			core.IfElse(isGenerator, SubtreeContainsGenerator, SubtreeFactsNone) |
 			core.IfElse(isAsync && !isGenerator, SubtreeContainsAnyAwait, SubtreeFactsNone)
 	}
 }
@@ -5872,6 +5874,7 @@ func (node *MethodDeclaration) computeSubtreeFacts() SubtreeFacts {
 			propagateEraseableSyntaxSubtreeFacts(node.Type) |
 			propagateEraseableSyntaxSubtreeFacts(node.FullSignature) |
 			core.IfElse(isAsync && isGenerator, SubtreeContainsForAwaitOrAsyncGenerator, SubtreeFactsNone) |
+			core.IfElse(isGenerator, SubtreeContainsGenerator, SubtreeFactsNone) |
 			core.IfElse(isAsync && !isGenerator, SubtreeContainsAnyAwait, SubtreeFactsNone)
 	}
 }
@@ -6048,7 +6051,8 @@ func (node *ClassStaticBlockDeclaration) Clone(f NodeFactoryCoercible) *Node {
 func (node *ClassStaticBlockDeclaration) computeSubtreeFacts() SubtreeFacts {
 	return propagateModifierListSubtreeFacts(node.modifiers) |
 		propagateSubtreeFacts(node.Body) |
-		SubtreeContainsClassFields
+		SubtreeContainsClassFields |
+		SubtreeContainsClassStaticBlocks
 }
 
 func IsClassStaticBlockDeclaration(node *Node) bool {
@@ -6274,7 +6278,8 @@ func (node *BinaryExpression) computeSubtreeFacts() SubtreeFacts {
 		propagateSubtreeFacts(node.Type) |
 		propagateSubtreeFacts(node.OperatorToken) |
 		propagateSubtreeFacts(node.Right) |
-		core.IfElse(node.OperatorToken.Kind == KindInKeyword && IsPrivateIdentifier(node.Left), SubtreeContainsClassFields, SubtreeFactsNone)
+		core.IfElse(node.OperatorToken.Kind == KindInKeyword && IsPrivateIdentifier(node.Left), SubtreeContainsClassFields, SubtreeFactsNone) |
+		core.IfElse(node.OperatorToken.Kind == KindEqualsToken && (IsArrayLiteralExpression(node.Left) || IsObjectLiteralExpression(node.Left)), SubtreeContainsIteration, SubtreeFactsNone)
 }
 
 func (node *BinaryExpression) setModifiers(modifiers *ModifierList) { node.modifiers = modifiers }
@@ -6398,7 +6403,7 @@ func (node *YieldExpression) Clone(f NodeFactoryCoercible) *Node {
 }
 
 func (node *YieldExpression) computeSubtreeFacts() SubtreeFacts {
-	return propagateSubtreeFacts(node.Expression) | SubtreeContainsForAwaitOrAsyncGenerator
+	return propagateSubtreeFacts(node.Expression) | SubtreeContainsForAwaitOrAsyncGenerator | SubtreeContainsYield
 }
 
 func IsYieldExpression(node *Node) bool {
@@ -6533,6 +6538,7 @@ func (node *FunctionExpression) computeSubtreeFacts() SubtreeFacts {
 		propagateEraseableSyntaxSubtreeFacts(node.FullSignature) |
 		propagateSubtreeFacts(node.Body) |
 		core.IfElse(isAsync && isGenerator, SubtreeContainsForAwaitOrAsyncGenerator, SubtreeFactsNone) |
+		core.IfElse(isGenerator, SubtreeContainsGenerator, SubtreeFactsNone) |
 		core.IfElse(isAsync && !isGenerator, SubtreeContainsAnyAwait, SubtreeFactsNone)
 }
 
@@ -7024,7 +7030,7 @@ func (node *SpreadElement) Clone(f NodeFactoryCoercible) *Node {
 }
 
 func (node *SpreadElement) computeSubtreeFacts() SubtreeFacts {
-	return propagateSubtreeFacts(node.Expression) | SubtreeContainsRestOrSpread
+	return propagateSubtreeFacts(node.Expression) | SubtreeContainsRestOrSpread | SubtreeContainsIteration
 }
 
 func IsSpreadElement(node *Node) bool {
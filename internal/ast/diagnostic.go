@@ -7,6 +7,7 @@ import (
 
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"golang.org/x/text/language"
 )
 
 // Diagnostic
@@ -22,6 +23,14 @@ type Diagnostic struct {
 	reportsUnnecessary bool
 	reportsDeprecated  bool
 	skippedOnNoEmit    bool
+
+	// What the diagnostic was created from, kept for translation if localization is enabled.
+	localization *diagnosticLocalization
+}
+
+type diagnosticLocalization struct {
+	message *diagnostics.Message
+	args    []any
 }
 
 func (d *Diagnostic) File() *SourceFile                 { return d.file }
@@ -38,6 +47,16 @@ func (d *Diagnostic) ReportsUnnecessary() bool          { return d.reportsUnnece
 func (d *Diagnostic) ReportsDeprecated() bool           { return d.reportsDeprecated }
 func (d *Diagnostic) SkippedOnNoEmit() bool             { return d.skippedOnNoEmit }
 
+// Localize returns the message of the diagnostic in the given locale. Diagnostics that were not
+// created from a diagnostics.Message, such as those restored from build info, or that were created
+// before localization was enabled, are not translated.
+func (d *Diagnostic) Localize(locale language.Tag) string {
+	if d.localization == nil {
+		return d.message
+	}
+	return d.localization.message.Localize(locale, d.localization.args...)
+}
+
 func (d *Diagnostic) SetFile(file *SourceFile)                  { d.file = file }
 func (d *Diagnostic) SetLocation(loc core.TextRange)            { d.loc = loc }
 func (d *Diagnostic) SetCategory(category diagnostics.Category) { d.category = category }
@@ -99,6 +118,10 @@ func NewDiagnosticWith(
 }
 
 func NewDiagnostic(file *SourceFile, loc core.TextRange, message *diagnostics.Message, args ...any) *Diagnostic {
+	var localization *diagnosticLocalization
+	if diagnostics.LocalizationEnabled() {
+		localization = &diagnosticLocalization{message: message, args: args}
+	}
 	return &Diagnostic{
 		file:               file,
 		loc:                loc,
@@ -107,6 +130,7 @@ func NewDiagnostic(file *SourceFile, loc core.TextRange, message *diagnostics.Me
 		message:            message.Format(args...),
 		reportsUnnecessary: message.ReportsUnnecessary(),
 		reportsDeprecated:  message.ReportsDeprecated(),
+		localization:       localization,
 	}
 }
 
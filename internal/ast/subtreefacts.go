@@ -24,6 +24,8 @@ const (
 	SubtreeContainsForAwaitOrAsyncGenerator
 	SubtreeContainsAnyAwait
 	SubtreeContainsExponentiationOperator
+	SubtreeContainsGenerator
+	SubtreeContainsIteration // for..of, spread elements, and destructuring, which may use the iterator protocol
 
 	// Markers
 	// - Flags used to indicate that a node or subtree contains a particular kind of syntax.
@@ -37,6 +39,7 @@ const (
 	SubtreeContainsClassFields
 	SubtreeContainsDecorators
 	SubtreeContainsIdentifier
+	SubtreeContainsYield
 
 	SubtreeFactsComputed              // NOTE: This should always be last
 	SubtreeFactsNone     SubtreeFacts = 0
@@ -51,6 +54,7 @@ const (
 	SubtreeContainsES2018 = SubtreeContainsESObjectRestOrSpread | SubtreeContainsForAwaitOrAsyncGenerator
 	SubtreeContainsES2017 = SubtreeContainsAnyAwait
 	SubtreeContainsES2016 = SubtreeContainsExponentiationOperator
+	SubtreeContainsES2015 = SubtreeContainsGenerator | SubtreeContainsIteration
 
 	// Scope Exclusions
 	// - Bitmasks that exclude flags from propagating out of a specific context
@@ -62,10 +66,10 @@ const (
 	SubtreeExclusionsPropertyAccess          = SubtreeExclusionsNode
 	SubtreeExclusionsElementAccess           = SubtreeExclusionsNode
 	SubtreeExclusionsArrowFunction           = SubtreeExclusionsNode | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
-	SubtreeExclusionsFunction                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
-	SubtreeExclusionsConstructor             = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
-	SubtreeExclusionsMethod                  = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
-	SubtreeExclusionsAccessor                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
+	SubtreeExclusionsFunction                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread | SubtreeContainsYield
+	SubtreeExclusionsConstructor             = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread | SubtreeContainsYield
+	SubtreeExclusionsMethod                  = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread | SubtreeContainsYield
+	SubtreeExclusionsAccessor                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread | SubtreeContainsYield
 	SubtreeExclusionsProperty                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper
 	SubtreeExclusionsClass                   = SubtreeExclusionsNode
 	SubtreeExclusionsModule                  = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper
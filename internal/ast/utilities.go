@@ -143,6 +143,47 @@ func IsAssignmentOperator(token Kind) bool {
 	return token >= KindFirstAssignment && token <= KindLastAssignment
 }
 
+func IsCompoundAssignment(token Kind) bool {
+	return token >= KindFirstCompoundAssignment && token <= KindLastCompoundAssignment
+}
+
+// Gets the binary operator used to compute the value of a compound assignment, such as `+` for `+=`.
+func GetNonAssignmentOperatorForCompoundAssignment(kind Kind) Kind {
+	switch kind {
+	case KindPlusEqualsToken:
+		return KindPlusToken
+	case KindMinusEqualsToken:
+		return KindMinusToken
+	case KindAsteriskEqualsToken:
+		return KindAsteriskToken
+	case KindAsteriskAsteriskEqualsToken:
+		return KindAsteriskAsteriskToken
+	case KindSlashEqualsToken:
+		return KindSlashToken
+	case KindPercentEqualsToken:
+		return KindPercentToken
+	case KindLessThanLessThanEqualsToken:
+		return KindLessThanLessThanToken
+	case KindGreaterThanGreaterThanEqualsToken:
+		return KindGreaterThanGreaterThanToken
+	case KindGreaterThanGreaterThanGreaterThanEqualsToken:
+		return KindGreaterThanGreaterThanGreaterThanToken
+	case KindAmpersandEqualsToken:
+		return KindAmpersandToken
+	case KindBarEqualsToken:
+		return KindBarToken
+	case KindCaretEqualsToken:
+		return KindCaretToken
+	case KindBarBarEqualsToken:
+		return KindBarBarToken
+	case KindAmpersandAmpersandEqualsToken:
+		return KindAmpersandAmpersandToken
+	case KindQuestionQuestionEqualsToken:
+		return KindQuestionQuestionToken
+	}
+	panic("Unhandled compound assignment operator: " + kind.String())
+}
+
 func IsAssignmentExpression(node *Node, excludeCompoundAssignment bool) bool {
 	if node.Kind == KindBinaryExpression {
 		expr := node.AsBinaryExpression()
@@ -1406,7 +1447,7 @@ func GetNameOfDeclaration(declaration *Node) *Node {
 		return nonAssignedName
 	}
 	if IsFunctionExpression(declaration) || IsArrowFunction(declaration) || IsClassExpression(declaration) {
-		return getAssignedName(declaration)
+		return GetAssignedName(declaration)
 	}
 	return nil
 }
@@ -1445,7 +1486,7 @@ func GetNonAssignedNameOfDeclaration(declaration *Node) *Node {
 	return declaration.Name()
 }
 
-func getAssignedName(node *Node) *Node {
+func GetAssignedName(node *Node) *Node {
 	parent := node.Parent
 	if parent != nil {
 		switch parent.Kind {
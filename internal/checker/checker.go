@@ -25,6 +25,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/modulespecifiers"
 	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/stringutil"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
@@ -543,6 +544,7 @@ type Program interface {
 	IsSourceFromProjectReference(path tspath.Path) bool
 	IsSourceFileDefaultLibrary(path tspath.Path) bool
 	GetProjectReferenceFromOutputDts(path tspath.Path) *tsoptions.SourceOutputAndProjectReference
+	Tracing() *tracing.Tracing
 	GetRedirectForResolution(file ast.HasFileName) *tsoptions.ParsedCommandLine
 	CommonSourceDirectory() string
 }
@@ -558,6 +560,8 @@ var nextCheckerID atomic.Uint32
 type Checker struct {
 	id                                          uint32
 	program                                     Program
+	tracer                                      *tracing.Tracer
+	tracedTypes                                 []*Type
 	compilerOptions                             *core.CompilerOptions
 	files                                       []*ast.SourceFile
 	fileIndexMap                                map[*ast.SourceFile]int
@@ -865,6 +869,10 @@ func NewChecker(program Program) *Checker {
 	c := &Checker{}
 	c.id = nextCheckerID.Add(1)
 	c.program = program
+	if tracer := program.Tracing().NewTracer("Checker"); tracer != nil {
+		c.tracer = tracer
+		c.tracer.SetTypeSource(c.describeTracedTypes)
+	}
 	c.compilerOptions = program.Options()
 	c.files = program.SourceFiles()
 	c.fileIndexMap = createFileIndexMap(c.files)
@@ -2118,6 +2126,10 @@ func (c *Checker) checkSourceFile(ctx context.Context, sourceFile *ast.SourceFil
 	c.checkNotCanceled()
 	links := c.sourceFileLinks.Get(sourceFile)
 	if !links.typeChecked {
+		if c.tracer != nil {
+			c.tracer.Push(tracing.PhaseCheck, "checkSourceFile", tracing.Args{"path": sourceFile.FileName()}, true /*separateBeginAndEnd*/)
+			defer c.tracer.Pop()
+		}
 		c.ctx = ctx
 		// Grammar checking
 		c.checkGrammarSourceFile(sourceFile)
@@ -2335,6 +2347,10 @@ func (c *Checker) checkDeferredNodes(context *ast.SourceFile) {
 }
 
 func (c *Checker) checkDeferredNode(node *ast.Node) {
+	if c.tracer != nil {
+		c.tracer.Push(tracing.PhaseCheck, "checkDeferredNode", c.getNodeTraceArgs(node), false /*separateBeginAndEnd*/)
+		defer c.tracer.Pop()
+	}
 	saveCurrentNode := c.currentNode
 	c.currentNode = node
 	c.instantiationCount = 0
@@ -5512,6 +5528,10 @@ func (c *Checker) checkVariableDeclarationList(node *ast.Node) {
 }
 
 func (c *Checker) checkVariableDeclaration(node *ast.Node) {
+	if c.tracer != nil {
+		c.tracer.Push(tracing.PhaseCheck, "checkVariableDeclaration", c.getNodeTraceArgs(node), false /*separateBeginAndEnd*/)
+		defer c.tracer.Pop()
+	}
 	c.checkGrammarVariableDeclaration(node.AsVariableDeclaration())
 	c.checkVariableLikeDeclaration(node)
 }
@@ -7228,6 +7248,10 @@ func (c *Checker) checkExpression(node *ast.Node) *Type {
 }
 
 func (c *Checker) checkExpressionEx(node *ast.Node, checkMode CheckMode) *Type {
+	if c.tracer != nil {
+		c.tracer.Push(tracing.PhaseCheck, "checkExpression", c.getNodeTraceArgs(node), false /*separateBeginAndEnd*/)
+		defer c.tracer.Pop()
+	}
 	saveCurrentNode := c.currentNode
 	c.currentNode = node
 	c.instantiationCount = 0
@@ -21416,6 +21440,9 @@ func (c *Checker) instantiateTypeWithAlias(t *Type, m *TypeMapper, alias *TypeAl
 		// We have reached 100 recursive type instantiations, or 5M type instantiations caused by the same statement
 		// or expression. There is a very high likelihood we're dealing with a combination of infinite generic types
 		// that perpetually generate new type identities, so we stop the recursion here by yielding the error type.
+		if c.tracer != nil {
+			c.tracer.Instant(tracing.PhaseCheckTypes, "instantiateType_DepthLimit", tracing.Args{"typeId": t.id, "instantiationDepth": c.instantiationDepth, "instantiationCount": c.instantiationCount})
+		}
 		c.error(c.currentNode, diagnostics.Type_instantiation_is_excessively_deep_and_possibly_infinite)
 		return c.errorType
 	}
@@ -24229,6 +24256,9 @@ func (c *Checker) newType(flags TypeFlags, objectFlags ObjectFlags, data TypeDat
 	t.id = TypeId(c.TypeCount)
 	t.checker = c
 	t.data = data
+	if c.tracer != nil {
+		c.tracedTypes = append(c.tracedTypes, t)
+	}
 	return t
 }
 
@@ -27581,7 +27611,120 @@ func (c *Checker) markExportSpecifierAliasReferenced(location *ast.ExportSpecifi
 }
 
 func (c *Checker) markDecoratorAliasReferenced(node *ast.Node /*HasDecorators*/) {
-	// !!! Implement if/when we support emitDecoratorMetadata
+	if !c.compilerOptions.EmitDecoratorMetadata.IsTrue() {
+		return
+	}
+	if core.Find(node.ModifierNodes(), ast.IsDecorator) == nil {
+		return
+	}
+	switch node.Kind {
+	case ast.KindClassDeclaration:
+		if constructor := ast.FindConstructorDeclaration(node); constructor != nil {
+			for _, parameter := range constructor.Parameters() {
+				c.markDecoratorMetadataDataTypeNodeAsReferenced(getParameterTypeNodeForDecoratorCheck(parameter))
+			}
+		}
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		otherKind := core.IfElse(node.Kind == ast.KindGetAccessor, ast.KindSetAccessor, ast.KindGetAccessor)
+		typeNode := c.getAnnotatedAccessorTypeNode(node)
+		if typeNode == nil {
+			typeNode = c.getAnnotatedAccessorTypeNode(ast.GetDeclarationOfKind(c.getSymbolOfDeclaration(node), otherKind))
+		}
+		c.markDecoratorMetadataDataTypeNodeAsReferenced(typeNode)
+	case ast.KindMethodDeclaration:
+		for _, parameter := range node.Parameters() {
+			c.markDecoratorMetadataDataTypeNodeAsReferenced(getParameterTypeNodeForDecoratorCheck(parameter))
+		}
+		c.markDecoratorMetadataDataTypeNodeAsReferenced(node.Type())
+	case ast.KindPropertyDeclaration:
+		c.markDecoratorMetadataDataTypeNodeAsReferenced(node.Type())
+	case ast.KindParameter:
+		c.markDecoratorMetadataDataTypeNodeAsReferenced(getParameterTypeNodeForDecoratorCheck(node))
+		containingSignature := node.Parent
+		for _, parameter := range containingSignature.Parameters() {
+			c.markDecoratorMetadataDataTypeNodeAsReferenced(getParameterTypeNodeForDecoratorCheck(parameter))
+		}
+		c.markDecoratorMetadataDataTypeNodeAsReferenced(containingSignature.Type())
+	}
+}
+
+func (c *Checker) markDecoratorMetadataDataTypeNodeAsReferenced(node *ast.TypeNode) {
+	if entityName := c.getEntityNameForDecoratorMetadata(node); entityName != nil && ast.IsEntityName(entityName) {
+		c.markEntityNameOrEntityExpressionAsReference(entityName, true /*forDecoratorMetadata*/)
+	}
+}
+
+func (c *Checker) getEntityNameForDecoratorMetadata(node *ast.TypeNode) *ast.EntityName {
+	if node != nil {
+		switch node.Kind {
+		case ast.KindIntersectionType:
+			return c.getEntityNameForDecoratorMetadataFromTypeList(node.AsIntersectionTypeNode().Types.Nodes)
+		case ast.KindUnionType:
+			return c.getEntityNameForDecoratorMetadataFromTypeList(node.AsUnionTypeNode().Types.Nodes)
+		case ast.KindConditionalType:
+			n := node.AsConditionalTypeNode()
+			return c.getEntityNameForDecoratorMetadataFromTypeList([]*ast.TypeNode{n.TrueType, n.FalseType})
+		case ast.KindParenthesizedType, ast.KindNamedTupleMember:
+			return c.getEntityNameForDecoratorMetadata(node.Type())
+		case ast.KindTypeReference:
+			return node.AsTypeReferenceNode().TypeName
+		}
+	}
+	return nil
+}
+
+func (c *Checker) getEntityNameForDecoratorMetadataFromTypeList(types []*ast.TypeNode) *ast.EntityName {
+	var commonEntityName *ast.EntityName
+	for _, typeNode := range types {
+		for typeNode.Kind == ast.KindParenthesizedType || typeNode.Kind == ast.KindNamedTupleMember {
+			typeNode = typeNode.Type() // Skip parens if need be
+		}
+		if typeNode.Kind == ast.KindNeverKeyword {
+			continue // Always elide `never` from the union/intersection if possible
+		}
+		if !c.strictNullChecks && (typeNode.Kind == ast.KindLiteralType && typeNode.AsLiteralTypeNode().Literal.Kind == ast.KindNullKeyword || typeNode.Kind == ast.KindUndefinedKeyword) {
+			continue // Elide null and undefined from unions for metadata, just like what we did prior to the implementation of strict null checks
+		}
+		individualEntityName := c.getEntityNameForDecoratorMetadata(typeNode)
+		if individualEntityName == nil {
+			// Individual is something like string number
+			// So it would be serialized to either that type or object
+			// Safe to return here
+			return nil
+		}
+		if commonEntityName != nil {
+			// Note this is in sync with the transformation that happens for type node.
+			// Keep this in sync with serializeUnionOrIntersectionType
+			// Verify if they refer to same entity and is identifier
+			// return undefined if they dont match because we would emit object
+			if !ast.IsIdentifier(commonEntityName) || !ast.IsIdentifier(individualEntityName) || commonEntityName.Text() != individualEntityName.Text() {
+				return nil
+			}
+		} else {
+			commonEntityName = individualEntityName
+		}
+	}
+	return commonEntityName
+}
+
+func getParameterTypeNodeForDecoratorCheck(node *ast.ParameterDeclarationNode) *ast.TypeNode {
+	typeNode := node.Type()
+	if isRestParameter(node) {
+		return getRestParameterElementType(typeNode)
+	}
+	return typeNode
+}
+
+func getRestParameterElementType(node *ast.TypeNode) *ast.TypeNode {
+	if node != nil {
+		switch {
+		case node.Kind == ast.KindArrayType:
+			return node.AsArrayTypeNode().ElementType
+		case node.Kind == ast.KindTypeReference && node.AsTypeReferenceNode().TypeArguments != nil && len(node.AsTypeReferenceNode().TypeArguments.Nodes) == 1:
+			return node.AsTypeReferenceNode().TypeArguments.Nodes[0]
+		}
+	}
+	return nil
 }
 
 func (c *Checker) markAliasReferenced(symbol *ast.Symbol, location *ast.Node) {
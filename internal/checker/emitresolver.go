@@ -536,6 +536,15 @@ func (r *emitResolver) IsDefinitelyReferenceToGlobalSymbolObject(node *ast.Node)
 	return r.checker.getResolvedSymbol(node.Expression().Expression()) == r.checker.globalThisSymbol
 }
 
+func (r *emitResolver) IsUndefinedIdentifierExpression(node *ast.Node) bool {
+	if !ast.IsIdentifier(node) || !ast.IsParseTreeNode(node) {
+		return false
+	}
+	r.checkerMu.Lock()
+	defer r.checkerMu.Unlock()
+	return r.checker.getResolvedSymbol(node) == r.checker.undefinedSymbol
+}
+
 func (r *emitResolver) RequiresAddingImplicitUndefined(declaration *ast.Node, symbol *ast.Symbol, enclosingDeclaration *ast.Node) bool {
 	if !ast.IsParseTreeNode(declaration) {
 		return false
@@ -1097,6 +1106,89 @@ func (r *emitResolver) CreateLateBoundIndexSignatures(emitContext *printer.EmitC
 	return result
 }
 
+func (r *emitResolver) GetTypeReferenceSerializationKind(typeName *ast.Node, location *ast.Node) printer.TypeReferenceSerializationKind {
+	// typeName = emitContext.ParseNode(typeName)
+	if !ast.IsParseTreeNode(typeName) || location != nil && !ast.IsParseTreeNode(location) {
+		return printer.TypeReferenceSerializationKindUnknown
+	}
+	r.checkerMu.Lock()
+	defer r.checkerMu.Unlock()
+	return r.getTypeReferenceSerializationKind(typeName, location)
+}
+
+func (r *emitResolver) getTypeReferenceSerializationKind(typeName *ast.Node, location *ast.Node) printer.TypeReferenceSerializationKind {
+	c := r.checker
+	isTypeOnly := false
+	if ast.IsQualifiedName(typeName) {
+		rootValueSymbol := c.resolveEntityName(ast.GetFirstIdentifier(typeName), ast.SymbolFlagsValue, true /*ignoreErrors*/, true /*dontResolveAlias*/, location)
+		isTypeOnly = rootValueSymbol != nil && len(rootValueSymbol.Declarations) > 0 && core.Every(rootValueSymbol.Declarations, ast.IsTypeOnlyImportOrExportDeclaration)
+	}
+	valueSymbol := c.resolveEntityName(typeName, ast.SymbolFlagsValue, true /*ignoreErrors*/, true /*dontResolveAlias*/, location)
+	resolvedValueSymbol := valueSymbol
+	if valueSymbol != nil && valueSymbol.Flags&ast.SymbolFlagsAlias != 0 {
+		resolvedValueSymbol = c.resolveAlias(valueSymbol)
+	}
+	isTypeOnly = isTypeOnly || valueSymbol != nil && c.getTypeOnlyAliasDeclarationEx(valueSymbol, ast.SymbolFlagsValue) != nil
+	typeSymbol := c.resolveEntityName(typeName, ast.SymbolFlagsType, true /*ignoreErrors*/, true /*dontResolveAlias*/, location)
+	resolvedTypeSymbol := typeSymbol
+	if typeSymbol != nil && typeSymbol.Flags&ast.SymbolFlagsAlias != 0 {
+		resolvedTypeSymbol = c.resolveAlias(typeSymbol)
+	}
+	if valueSymbol == nil {
+		isTypeOnly = isTypeOnly || typeSymbol != nil && c.getTypeOnlyAliasDeclarationEx(typeSymbol, ast.SymbolFlagsType) != nil
+	}
+
+	if resolvedValueSymbol != nil && resolvedValueSymbol == resolvedTypeSymbol {
+		if globalPromiseSymbol := c.getGlobalPromiseConstructorSymbolOrNil(); globalPromiseSymbol != nil && resolvedValueSymbol == globalPromiseSymbol {
+			return printer.TypeReferenceSerializationKindPromise
+		}
+		if constructorType := c.getTypeOfSymbol(resolvedValueSymbol); constructorType != nil && c.isConstructorType(constructorType) {
+			if isTypeOnly {
+				return printer.TypeReferenceSerializationKindTypeWithCallSignature
+			}
+			return printer.TypeReferenceSerializationKindTypeWithConstructSignatureAndValue
+		}
+	}
+
+	// We might not be able to resolve type symbol so use unknown type in that case (eg error case)
+	if resolvedTypeSymbol == nil {
+		if isTypeOnly {
+			return printer.TypeReferenceSerializationKindObjectType
+		}
+		return printer.TypeReferenceSerializationKindUnknown
+	}
+	t := c.getDeclaredTypeOfSymbol(resolvedTypeSymbol)
+	switch {
+	case c.isErrorType(t):
+		if isTypeOnly {
+			return printer.TypeReferenceSerializationKindObjectType
+		}
+		return printer.TypeReferenceSerializationKindUnknown
+	case t.flags&TypeFlagsAnyOrUnknown != 0:
+		return printer.TypeReferenceSerializationKindObjectType
+	case c.isTypeAssignableToKind(t, TypeFlagsVoid|TypeFlagsNullable|TypeFlagsNever):
+		return printer.TypeReferenceSerializationKindVoidNullableOrNeverType
+	case c.isTypeAssignableToKind(t, TypeFlagsBooleanLike):
+		return printer.TypeReferenceSerializationKindBooleanType
+	case c.isTypeAssignableToKind(t, TypeFlagsNumberLike):
+		return printer.TypeReferenceSerializationKindNumberLikeType
+	case c.isTypeAssignableToKind(t, TypeFlagsBigIntLike):
+		return printer.TypeReferenceSerializationKindBigIntLikeType
+	case c.isTypeAssignableToKind(t, TypeFlagsStringLike):
+		return printer.TypeReferenceSerializationKindStringLikeType
+	case isTupleType(t):
+		return printer.TypeReferenceSerializationKindArrayLikeType
+	case c.isTypeAssignableToKind(t, TypeFlagsESSymbolLike):
+		return printer.TypeReferenceSerializationKindESSymbolType
+	case c.isFunctionType(t):
+		return printer.TypeReferenceSerializationKindTypeWithCallSignature
+	case c.isArrayType(t):
+		return printer.TypeReferenceSerializationKindArrayLikeType
+	default:
+		return printer.TypeReferenceSerializationKindObjectType
+	}
+}
+
 func (r *emitResolver) GetEffectiveDeclarationFlags(node *ast.Node, flags ast.ModifierFlags) ast.ModifierFlags {
 	// node = emitContext.ParseNode(node)
 	r.checkerMu.Lock()
@@ -126,6 +126,10 @@ func IsTupleType(t *Type) bool {
 	return isTupleType(t)
 }
 
+func HasContextSensitiveParameters(node *ast.Node) bool {
+	return hasContextSensitiveParameters(node)
+}
+
 func (c *Checker) GetReturnTypeOfSignature(sig *Signature) *Type {
 	return c.getReturnTypeOfSignature(sig)
 }
@@ -170,3 +174,42 @@ func (c *Checker) GetIndexSignaturesAtLocation(node *ast.Node) []*ast.Node {
 func (c *Checker) GetResolvedSymbol(node *ast.Node) *ast.Symbol {
 	return c.getResolvedSymbol(node)
 }
+
+func (c *Checker) GetSymbolFlags(symbol *ast.Symbol) ast.SymbolFlags {
+	return c.getSymbolFlags(symbol)
+}
+
+func (c *Checker) GetWidenedType(t *Type) *Type {
+	return c.getWidenedType(t)
+}
+
+func (c *Checker) GetBaseTypeOfLiteralType(t *Type) *Type {
+	return c.getBaseTypeOfLiteralType(t)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentSymbol(location *ast.Node, name string, meaning ast.SymbolFlags) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentSymbol(location, name, meaning)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentProperty(name *ast.Node, containingType *Type) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentProperty(name, containingType)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentModule(name *ast.Node, targetModule *ast.Symbol) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentModule(name, targetModule)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentJSXAttribute(name string, containingType *Type) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentJSXAttribute(name, containingType)
+}
+
+func (c *Checker) GetJsxNamespace(location *ast.Node) string {
+	return c.getJsxNamespace(location)
+}
+
+func (c *Checker) GetJsxFragmentFactory(location *ast.Node) string {
+	if entity := c.getJsxFragmentFactoryEntity(location); entity != nil {
+		return ast.GetFirstIdentifier(entity).Text()
+	}
+	return ""
+}
@@ -377,3 +377,8 @@ func (c *Checker) TypeToTypeNode(t *Type, enclosingDeclaration *ast.Node, flags
 	nodeBuilder := c.getNodeBuilder()
 	return nodeBuilder.TypeToTypeNode(t, enclosingDeclaration, flags, nodebuilder.InternalFlagsNone, nil)
 }
+
+func (c *Checker) TypePredicateToTypePredicateNode(predicate *TypePredicate, enclosingDeclaration *ast.Node, flags nodebuilder.Flags) *ast.Node {
+	nodeBuilder := c.getNodeBuilder()
+	return nodeBuilder.TypePredicateToTypePredicateNode(predicate, enclosingDeclaration, flags, nodebuilder.InternalFlagsNone, nil)
+}
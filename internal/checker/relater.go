@@ -13,6 +13,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/jsnum"
 	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tracing"
 )
 
 type SignatureCheckMode uint32
@@ -369,6 +370,9 @@ func (c *Checker) checkTypeRelatedToEx(
 	r.relationCount = (16_000_000 - relation.size()) / 8
 	result := r.isRelatedToEx(source, target, RecursionFlagsBoth, errorNode != nil /*reportErrors*/, headMessage, IntersectionStateNone)
 	if r.overflow {
+		if c.tracer != nil {
+			c.tracer.Instant(tracing.PhaseCheckTypes, "checkTypeRelatedTo_DepthLimit", tracing.Args{"sourceId": source.id, "targetId": target.id})
+		}
 		// Record this relation as having failed such that we don't attempt the overflowing operation again.
 		id := getRelationKey(source, target, IntersectionStateNone, relation == c.identityRelation, false /*ignoreConstraints*/)
 		relation.set(id, RelationComparisonResultFailed|core.IfElse(r.relationCount <= 0, RelationComparisonResultComplexityOverflow, RelationComparisonResultStackDepthOverflow))
@@ -3056,6 +3060,9 @@ func (r *Relater) recursiveTypeRelatedTo(source *Type, target *Type, reportError
 		}
 	}
 	if len(r.sourceStack) == 100 || len(r.targetStack) == 100 {
+		if r.c.tracer != nil {
+			r.c.tracer.Instant(tracing.PhaseCheckTypes, "recursiveTypeRelatedTo_DepthLimit", tracing.Args{"sourceId": source.id, "targetId": target.id, "depth": len(r.sourceStack), "targetDepth": len(r.targetStack)})
+		}
 		r.overflow = true
 		return TernaryFalse
 	}
@@ -3139,6 +3146,10 @@ func (r *Relater) restoreErrorState(e errorState) {
 }
 
 func (r *Relater) structuredTypeRelatedTo(source *Type, target *Type, reportErrors bool, intersectionState IntersectionState) Ternary {
+	if r.c.tracer != nil {
+		r.c.tracer.Push(tracing.PhaseCheckTypes, "structuredTypeRelatedTo", tracing.Args{"sourceId": source.id, "targetId": target.id}, false /*separateBeginAndEnd*/)
+		defer r.c.tracer.Pop()
+	}
 	saveErrorState := r.getErrorState()
 	result := r.structuredTypeRelatedToWorker(source, target, reportErrors, intersectionState)
 	if r.relation != r.c.identityRelation {
@@ -809,3 +809,68 @@ func (c *Checker) getTypeOfAssignmentPattern(expr *ast.Node) *Type {
 	elementType := core.OrElse(c.checkIteratedTypeOrElementType(IterationUseDestructuring, typeOfArrayLiteral, c.undefinedType, expr.Parent), c.errorType)
 	return c.checkArrayLiteralDestructuringElementAssignment(node, typeOfArrayLiteral, slices.Index(node.AsArrayLiteralExpression().Elements.Nodes, expr), elementType, CheckModeNormal)
 }
+
+func (c *Checker) GetSignatureFromDeclaration(declaration *ast.Node) *Signature {
+	if !ast.IsFunctionLike(declaration) {
+		return nil
+	}
+	return c.getSignatureFromDeclaration(declaration)
+}
+
+type ParameterIdentifierInfo struct {
+	Parameter       *ast.Node // Identifier
+	ParameterName   string
+	IsRestParameter bool
+}
+
+// GetParameterIdentifierInfoAtPosition returns the identifier naming the parameter that an argument at
+// position `pos` is matched against, or nil if that parameter has no simple name.
+func (c *Checker) GetParameterIdentifierInfoAtPosition(signature *Signature, pos int) *ParameterIdentifierInfo {
+	paramCount := len(signature.parameters) - core.IfElse(signatureHasRestParameter(signature), 1, 0)
+	if pos < paramCount {
+		param := signature.parameters[pos]
+		if paramIdent := getParameterDeclarationIdentifier(param); paramIdent != nil {
+			return &ParameterIdentifierInfo{Parameter: paramIdent, ParameterName: param.Name}
+		}
+		return nil
+	}
+	if paramCount >= len(signature.parameters) {
+		return nil
+	}
+	restParameter := signature.parameters[paramCount]
+	restIdent := getParameterDeclarationIdentifier(restParameter)
+	if restIdent == nil {
+		return nil
+	}
+	restType := c.getTypeOfSymbol(restParameter)
+	if isTupleType(restType) {
+		elementInfos := restType.TargetTupleType().elementInfos
+		index := pos - paramCount
+		if index >= len(elementInfos) {
+			return nil
+		}
+		associatedName := elementInfos[index].labeledDeclaration
+		if associatedName == nil || !ast.IsIdentifier(associatedName.Name()) {
+			return nil
+		}
+		var isRestTupleElement bool
+		switch associatedName.Kind {
+		case ast.KindNamedTupleMember:
+			isRestTupleElement = associatedName.AsNamedTupleMember().DotDotDotToken != nil
+		case ast.KindParameter:
+			isRestTupleElement = associatedName.AsParameterDeclaration().DotDotDotToken != nil
+		}
+		return &ParameterIdentifierInfo{Parameter: associatedName.Name(), ParameterName: associatedName.Name().Text(), IsRestParameter: isRestTupleElement}
+	}
+	if pos == paramCount {
+		return &ParameterIdentifierInfo{Parameter: restIdent, ParameterName: restParameter.Name, IsRestParameter: true}
+	}
+	return nil
+}
+
+func getParameterDeclarationIdentifier(symbol *ast.Symbol) *ast.Node {
+	if symbol.ValueDeclaration != nil && ast.IsParameter(symbol.ValueDeclaration) && ast.IsIdentifier(symbol.ValueDeclaration.Name()) {
+		return symbol.ValueDeclaration.Name()
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tracing"
+)
+
+func (c *Checker) getNodeTraceArgs(node *ast.Node) tracing.Args {
+	args := tracing.Args{"kind": strings.TrimPrefix(node.Kind.String(), "Kind"), "pos": node.Pos(), "end": node.End()}
+	if file := ast.GetSourceFileOfNode(node); file != nil {
+		args["path"] = file.FileName()
+	}
+	return args
+}
+
+// describeTracedTypes describes every type created by the checker for types.json.
+func (c *Checker) describeTracedTypes() []*tracing.TypeDescriptor {
+	// Printing a type may create new types, which are not described.
+	types := c.tracedTypes[:len(c.tracedTypes):len(c.tracedTypes)]
+	return core.Map(types, c.describeTracedType)
+}
+
+func (c *Checker) describeTracedType(t *Type) *tracing.TypeDescriptor {
+	d := &tracing.TypeDescriptor{
+		ID:    int(t.id),
+		Flags: formatTypeFlags(t.flags),
+	}
+	symbol := t.alias.Symbol()
+	if symbol == nil {
+		symbol = t.symbol
+	}
+	if symbol != nil {
+		d.SymbolName = getTraceSymbolName(symbol)
+		if len(symbol.Declarations) != 0 {
+			d.FirstDeclaration = getTraceLocation(symbol.Declarations[0])
+		}
+	}
+	d.AliasTypeArguments = getTypeIds(t.alias.TypeArguments())
+	switch data := t.data.(type) {
+	case *IntrinsicType:
+		d.IntrinsicName = data.intrinsicName
+	case *UnionType:
+		d.UnionTypes = getTypeIds(data.types)
+	case *IntersectionType:
+		d.IntersectionTypes = getTypeIds(data.types)
+	case *IndexType:
+		d.KeyofType = getTypeId(data.target)
+	case *IndexedAccessType:
+		d.IndexedAccessObjectType = getTypeId(data.objectType)
+		d.IndexedAccessIndexType = getTypeId(data.indexType)
+	case *ConditionalType:
+		d.ConditionalCheckType = getTypeId(data.checkType)
+		d.ConditionalExtendsType = getTypeId(data.extendsType)
+		d.ConditionalTrueType = getTypeId(data.resolvedTrueType)
+		d.ConditionalFalseType = getTypeId(data.resolvedFalseType)
+	case *SubstitutionType:
+		d.SubstitutionBaseType = getTypeId(data.baseType)
+		d.ConstraintType = getTypeId(data.constraint)
+	case *ReverseMappedType:
+		d.ReverseMappedSourceType = getTypeId(data.source)
+		d.ReverseMappedMappedType = getTypeId(data.mappedType)
+		d.ReverseMappedConstraintType = getTypeId(data.constraintType)
+	case *EvolvingArrayType:
+		d.EvolvingArrayElementType = getTypeId(data.elementType)
+		d.EvolvingArrayFinalType = getTypeId(data.finalArrayType)
+	}
+	if t.objectFlags&ObjectFlagsReference != 0 {
+		reference := t.AsTypeReference()
+		d.IsTuple = isTupleType(t)
+		d.InstantiatedType = getTypeId(reference.target)
+		d.TypeArguments = getTypeIds(reference.resolvedTypeArguments)
+	}
+	if t.objectFlags&ObjectFlagsAnonymous != 0 || t.flags&TypeFlagsLiteral != 0 {
+		d.Display = c.TypeToString(t)
+	}
+	return d
+}
+
+// getTraceSymbolName returns the name of symbol, with internal names written as in TypeScript (e.g. "__type")
+// because their prefix is not valid UTF-8.
+func getTraceSymbolName(symbol *ast.Symbol) string {
+	if name, ok := strings.CutPrefix(symbol.Name, ast.InternalSymbolNamePrefix); ok {
+		return "__" + name
+	}
+	return symbol.Name
+}
+
+func getTypeId(t *Type) int {
+	if t == nil {
+		return 0
+	}
+	return int(t.id)
+}
+
+func getTypeIds(types []*Type) []int {
+	return core.Map(types, getTypeId)
+}
+
+func getTraceLocation(node *ast.Node) *tracing.Location {
+	file := ast.GetSourceFileOfNode(node)
+	if file == nil {
+		return nil
+	}
+	startLine, startCharacter := scanner.GetECMALineAndCharacterOfPosition(file, node.Pos())
+	endLine, endCharacter := scanner.GetECMALineAndCharacterOfPosition(file, node.End())
+	return &tracing.Location{
+		Path:  file.FileName(),
+		Start: tracing.Position{Line: startLine + 1, Character: startCharacter + 1},
+		End:   tracing.Position{Line: endLine + 1, Character: endCharacter + 1},
+	}
+}
+
+var typeFlagNames = [...]string{
+	"Any",
+	"Unknown",
+	"Undefined",
+	"Null",
+	"Void",
+	"String",
+	"Number",
+	"BigInt",
+	"Boolean",
+	"ESSymbol",
+	"StringLiteral",
+	"NumberLiteral",
+	"BigIntLiteral",
+	"BooleanLiteral",
+	"UniqueESSymbol",
+	"EnumLiteral",
+	"Enum",
+	"NonPrimitive",
+	"Never",
+	"TypeParameter",
+	"Object",
+	"Index",
+	"TemplateLiteral",
+	"StringMapping",
+	"Substitution",
+	"IndexedAccess",
+	"Conditional",
+	"Union",
+	"Intersection",
+	"Reserved1",
+	"Reserved2",
+	"Reserved3",
+}
+
+// formatTypeFlags returns the names of the individual flags set in flags.
+func formatTypeFlags(flags TypeFlags) []string {
+	names := []string{}
+	for i, name := range typeFlagNames {
+		if flags&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -1180,6 +1180,8 @@ type TypePredicate struct {
 	t              *Type
 }
 
+func (p *TypePredicate) Type() *Type { return p.t }
+
 // IndexInfo
 
 type IndexInfo struct {
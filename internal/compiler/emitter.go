@@ -11,6 +11,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/sourcemap"
 	"github.com/microsoft/typescript-go/internal/stringutil"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/transformers"
 	"github.com/microsoft/typescript-go/internal/transformers/declarations"
 	"github.com/microsoft/typescript-go/internal/transformers/estransforms"
@@ -38,14 +39,42 @@ type emitter struct {
 	writer             printer.EmitTextWriter
 	paths              *outputpaths.OutputPaths
 	sourceFile         *ast.SourceFile
+	tracer             *tracing.Tracer
 	emitResult         EmitResult
 	writeFile          func(fileName string, text string, writeByteOrderMark bool, data *WriteFileData) error
+	customTransformers *CustomTransformers
+}
+
+// CustomTransformers holds caller-provided transformer factories that are spliced into the emit pipeline.
+// Each factory is invoked once per emitted file and receives the same [transformers.TransformOptions] as the
+// built-in transformers, so it has access to the file's [printer.EmitContext] (and its [printer.NodeFactory]) and
+// to the [printer.EmitResolver] of the program.
+//
+// Files are emitted concurrently, so factories may be called from several goroutines at once. Each call must
+// return a new transformer, and any state shared between the transformers of different files must be safe for
+// concurrent use.
+type CustomTransformers struct {
+	// Before runs on the TypeScript source, ahead of all built-in script transformers.
+	Before []transformers.TransformerFactory
+	// After runs on the JavaScript output, after all built-in script transformers (including module transforms).
+	After []transformers.TransformerFactory
+	// AfterDeclarations runs on the declaration file output, after the built-in declaration transformer.
+	AfterDeclarations []transformers.TransformerFactory
 }
 
 func (e *emitter) emit() {
-	// !!! tracing
+	if e.tracer != nil {
+		e.tracer.Push(tracing.PhaseEmit, "emitJsFileOrBundle", tracing.Args{"jsFilePath": e.paths.JsFilePath()}, true /*separateBeginAndEnd*/)
+	}
 	e.emitJSFile(e.sourceFile, e.paths.JsFilePath(), e.paths.SourceMapFilePath())
+	if e.tracer != nil {
+		e.tracer.Pop()
+		e.tracer.Push(tracing.PhaseEmit, "emitDeclarationFileOrBundle", tracing.Args{"declarationFilePath": e.paths.DeclarationFilePath()}, true /*separateBeginAndEnd*/)
+	}
 	e.emitDeclarationFile(e.sourceFile, e.paths.DeclarationFilePath(), e.paths.DeclarationMapPath())
+	if e.tracer != nil {
+		e.tracer.Pop()
+	}
 	e.emitResult.Diagnostics = e.emitterDiagnostics.GetDiagnostics()
 }
 
@@ -54,6 +83,24 @@ func (e *emitter) getDeclarationTransformers(emitContext *printer.EmitContext, d
 	return []*declarations.DeclarationTransformer{transform}
 }
 
+func (e *emitter) getCustomDeclarationTransformers(emitContext *printer.EmitContext) []*transformers.Transformer {
+	if e.customTransformers == nil || len(e.customTransformers.AfterDeclarations) == 0 {
+		return nil
+	}
+	options := e.host.Options()
+	emitResolver := e.host.GetEmitResolver()
+	opts := transformers.TransformOptions{
+		Context:                   emitContext,
+		CompilerOptions:           options,
+		Resolver:                  emitResolver,
+		EmitResolver:              emitResolver,
+		GetEmitModuleFormatOfFile: e.host.GetEmitModuleFormatOfFile,
+	}
+	return core.Map(e.customTransformers.AfterDeclarations, func(factory transformers.TransformerFactory) *transformers.Transformer {
+		return factory(&opts)
+	})
+}
+
 func getModuleTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
 	switch opts.CompilerOptions.GetEmitModuleKind() {
 	case core.ModuleKindPreserve:
@@ -76,7 +123,7 @@ func getModuleTransformer(opts *transformers.TransformOptions) *transformers.Tra
 	}
 }
 
-func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHost, sourceFile *ast.SourceFile) []*transformers.Transformer {
+func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHost, sourceFile *ast.SourceFile, customTransformers *CustomTransformers) []*transformers.Transformer {
 	var tx []*transformers.Transformer
 	options := host.Options()
 
@@ -85,7 +132,8 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 
 	var emitResolver printer.EmitResolver
 	var referenceResolver binder.ReferenceResolver
-	if importElisionEnabled || options.GetJSXTransformEnabled() || !options.GetIsolatedModules() { // full emit resolver is needed for import ellision and const enum inlining
+	needsDecoratorMetadata := options.ExperimentalDecorators.IsTrue() && options.EmitDecoratorMetadata.IsTrue()
+	if importElisionEnabled || options.GetJSXTransformEnabled() || !options.GetIsolatedModules() || needsDecoratorMetadata { // full emit resolver is needed for import ellision, const enum inlining, and decorator metadata
 		emitResolver = host.GetEmitResolver()
 		emitResolver.MarkLinkedReferencesRecursively(sourceFile)
 		referenceResolver = emitResolver
@@ -93,6 +141,11 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 		referenceResolver = binder.NewReferenceResolver(options, binder.ReferenceResolverHooks{})
 	}
 
+	// custom transformers are promised an emit resolver, even when none of the built-in transformers need one
+	if emitResolver == nil && customTransformers != nil && (len(customTransformers.Before) > 0 || len(customTransformers.After) > 0) {
+		emitResolver = host.GetEmitResolver()
+	}
+
 	opts := transformers.TransformOptions{
 		Context:                   emitContext,
 		CompilerOptions:           options,
@@ -101,6 +154,13 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 		GetEmitModuleFormatOfFile: host.GetEmitModuleFormatOfFile,
 	}
 
+	// custom transformers that operate on TypeScript syntax
+	if customTransformers != nil {
+		for _, factory := range customTransformers.Before {
+			tx = append(tx, factory(&opts))
+		}
+	}
+
 	// transform TypeScript syntax
 	{
 		// erase types
@@ -115,7 +175,11 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 		tx = append(tx, tstransforms.NewRuntimeSyntaxTransformer(&opts))
 	}
 
-	// !!! transform legacy decorator syntax
+	// transform legacy decorator syntax
+	if options.ExperimentalDecorators.IsTrue() {
+		tx = append(tx, tstransforms.NewLegacyDecoratorsTransformer(&opts))
+	}
+
 	if options.GetJSXTransformEnabled() {
 		tx = append(tx, jsxtransforms.NewJSXTransformer(&opts))
 	}
@@ -132,6 +196,13 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 	if !options.GetIsolatedModules() {
 		tx = append(tx, inliners.NewConstEnumInliningTransformer(&opts))
 	}
+
+	// custom transformers that operate on the final JavaScript output
+	if customTransformers != nil {
+		for _, factory := range customTransformers.After {
+			tx = append(tx, factory(&opts))
+		}
+	}
 	return tx
 }
 
@@ -150,7 +221,7 @@ func (e *emitter) emitJSFile(sourceFile *ast.SourceFile, jsFilePath string, sour
 	emitContext, putEmitContext := printer.GetEmitContext()
 	defer putEmitContext()
 
-	for _, transformer := range getScriptTransformers(emitContext, e.host, sourceFile) {
+	for _, transformer := range getScriptTransformers(emitContext, e.host, sourceFile, e.customTransformers) {
 		sourceFile = transformer.TransformSourceFile(sourceFile)
 	}
 
@@ -191,6 +262,9 @@ func (e *emitter) emitDeclarationFile(sourceFile *ast.SourceFile, declarationFil
 		sourceFile = transformer.TransformSourceFile(sourceFile)
 		diags = append(diags, transformer.GetDiagnostics()...)
 	}
+	for _, transformer := range e.getCustomDeclarationTransformers(emitContext) {
+		sourceFile = transformer.TransformSourceFile(sourceFile)
+	}
 
 	// !!! strada skipped emit if there were diagnostics
 
@@ -0,0 +1,152 @@
+package compiler_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/transformers"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+	"gotest.tools/v3/assert"
+)
+
+// stringRewriter replaces the text of string literals, and records whether it saw any type syntax.
+type stringRewriter struct {
+	transformers.Transformer
+	rewrite      func(text string) string
+	sawTypeNodes *atomic.Bool
+}
+
+// newStringRewriter returns a factory that creates a stringRewriter for each file, as files are emitted
+// concurrently. sawTypeNodes is set if any of them sees type syntax.
+func newStringRewriter(rewrite func(text string) string, sawTypeNodes *atomic.Bool) transformers.TransformerFactory {
+	return func(opts *transformers.TransformOptions) *transformers.Transformer {
+		if opts.EmitResolver == nil {
+			panic("custom transformer was not given an emit resolver")
+		}
+		tx := &stringRewriter{rewrite: rewrite, sawTypeNodes: sawTypeNodes}
+		return tx.NewTransformer(tx.visit, opts.Context)
+	}
+}
+
+func (tx *stringRewriter) visit(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindTypeReference, ast.KindInterfaceDeclaration:
+		tx.sawTypeNodes.Store(true)
+	case ast.KindStringLiteral:
+		if text := tx.rewrite(node.Text()); text != node.Text() {
+			return tx.Factory().NewStringLiteral(text)
+		}
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// interfaceRemover drops top-level interface declarations with the given name.
+type interfaceRemover struct {
+	transformers.Transformer
+	name string
+}
+
+func newInterfaceRemover(name string) transformers.TransformerFactory {
+	return func(opts *transformers.TransformOptions) *transformers.Transformer {
+		tx := &interfaceRemover{name: name}
+		return tx.NewTransformer(tx.visit, opts.Context)
+	}
+}
+
+func (tx *interfaceRemover) visit(node *ast.Node) *ast.Node {
+	if node.Kind != ast.KindSourceFile {
+		return node
+	}
+	file := node.AsSourceFile()
+	statements := core.Filter(file.Statements.Nodes, func(statement *ast.Node) bool {
+		return !ast.IsInterfaceDeclaration(statement) || statement.Name().Text() != tx.name
+	})
+	return tx.Factory().UpdateSourceFile(file, tx.Factory().NewNodeList(statements), file.EndOfFileToken)
+}
+
+func TestEmitCustomTransformers(t *testing.T) {
+	t.Parallel()
+
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	fs := vfstest.FromMap[any](nil, false /*useCaseSensitiveFileNames*/)
+	fs = bundled.WrapFS(fs)
+	fileNames := []string{"c:/dev/src/index.ts", "c:/dev/src/a.ts", "c:/dev/src/b.ts", "c:/dev/src/c.ts"}
+	for _, fileName := range fileNames {
+		_ = fs.WriteFile(fileName, `interface Greeting { text: string }
+export const greeting: Greeting = { text: "hello" };
+export interface Internal {}
+`, false)
+	}
+
+	opts := core.CompilerOptions{
+		Target:      core.ScriptTargetES2020,
+		Module:      core.ModuleKindCommonJS,
+		Declaration: core.TSTrue,
+	}
+	program := compiler.NewProgram(compiler.ProgramOptions{
+		Config: &tsoptions.ParsedCommandLine{
+			ParsedConfig: &core.ParsedOptions{
+				FileNames:       fileNames,
+				CompilerOptions: &opts,
+			},
+		},
+		Host: compiler.NewCompilerHost("c:/dev/src", fs, bundled.LibPath(), nil, nil),
+	})
+
+	var beforeSawTypeNodes, afterSawTypeNodes atomic.Bool
+	before := newStringRewriter(func(text string) string {
+		if text == "hello" {
+			return "bonjour"
+		}
+		return text
+	}, &beforeSawTypeNodes)
+	after := newStringRewriter(func(text string) string {
+		// only matches if the `before` transformer already ran
+		if text == "bonjour" {
+			return "bonjour!"
+		}
+		return text
+	}, &afterSawTypeNodes)
+
+	var mu sync.Mutex
+	outputs := map[string]string{}
+	result := program.Emit(context.Background(), compiler.EmitOptions{
+		WriteFile: func(fileName string, text string, writeByteOrderMark bool, data *compiler.WriteFileData) error {
+			mu.Lock()
+			defer mu.Unlock()
+			outputs[fileName] = text
+			return nil
+		},
+		CustomTransformers: &compiler.CustomTransformers{
+			Before:            []transformers.TransformerFactory{before},
+			After:             []transformers.TransformerFactory{after},
+			AfterDeclarations: []transformers.TransformerFactory{newInterfaceRemover("Internal")},
+		},
+	})
+
+	assert.Assert(t, !result.EmitSkipped)
+	assert.Equal(t, len(result.Diagnostics), 0)
+	assert.Assert(t, beforeSawTypeNodes.Load(), "before transformers should see TypeScript syntax")
+	assert.Assert(t, !afterSawTypeNodes.Load(), "after transformers should not see TypeScript syntax")
+
+	for _, fileName := range fileNames {
+		js := outputs[strings.TrimSuffix(fileName, ".ts")+".js"]
+		assert.Assert(t, strings.Contains(js, `"bonjour!"`), js)
+		assert.Assert(t, strings.Contains(js, "exports.greeting"), js)
+
+		dts := outputs[strings.TrimSuffix(fileName, ".ts")+".d.ts"]
+		assert.Assert(t, strings.Contains(dts, "greeting: Greeting"), dts)
+		assert.Assert(t, !strings.Contains(dts, "Internal"), dts)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/sourcemap"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
@@ -36,6 +37,7 @@ type ProgramOptions struct {
 	TypingsLocation             string
 	ProjectName                 string
 	JSDocParsingMode            ast.JSDocParsingMode
+	Tracing                     *tracing.Tracing
 }
 
 func (p *ProgramOptions) canUseProjectReferenceSource() bool {
@@ -207,6 +209,14 @@ func (p *Program) GetSourceFileFromReference(origin *ast.SourceFile, ref *ast.Fi
 
 func NewProgram(opts ProgramOptions) *Program {
 	p := &Program{opts: opts}
+	if tracer := opts.Tracing.Main(); tracer != nil {
+		args := tracing.Args{"rootDir": opts.Host.GetCurrentDirectory()}
+		if opts.Config.ConfigFile != nil {
+			args["configFilePath"] = opts.Config.ConfigName()
+		}
+		tracer.Push(tracing.PhaseProgram, "createProgram", args, true /*separateBeginAndEnd*/)
+		defer tracer.Pop()
+	}
 	p.initCheckerPool()
 	p.processedFiles = processAllProgramFiles(p.opts, p.SingleThreaded())
 	p.verifyCompilerOptions()
@@ -332,11 +342,21 @@ func (p *Program) SingleThreaded() bool {
 	return p.opts.SingleThreaded.DefaultIfUnknown(p.Options().SingleThreaded).IsTrue()
 }
 
+// Tracing implements checker.Program.
+func (p *Program) Tracing() *tracing.Tracing {
+	return p.opts.Tracing
+}
+
 func (p *Program) BindSourceFiles() {
 	wg := core.NewWorkGroup(p.SingleThreaded())
 	for _, file := range p.files {
 		if !file.IsBound() {
 			wg.Queue(func() {
+				if tracer := p.opts.Tracing.AcquireTracer(); tracer != nil {
+					defer p.opts.Tracing.ReleaseTracer(tracer)
+					tracer.Push(tracing.PhaseBind, "bindSourceFile", tracing.Args{"path": file.FileName()}, true /*separateBeginAndEnd*/)
+					defer tracer.Pop()
+				}
 				binder.BindSourceFile(file)
 			})
 		}
@@ -1343,9 +1363,10 @@ type WriteFileData struct {
 type WriteFile func(fileName string, text string, writeByteOrderMark bool, data *WriteFileData) error
 
 type EmitOptions struct {
-	TargetSourceFile *ast.SourceFile // Single file to emit. If `nil`, emits all files
-	EmitOnly         EmitOnly
-	WriteFile        WriteFile
+	TargetSourceFile   *ast.SourceFile // Single file to emit. If `nil`, emits all files
+	EmitOnly           EmitOnly
+	WriteFile          WriteFile
+	CustomTransformers *CustomTransformers // Additional transformers to run during emit
 }
 
 type EmitResult struct {
@@ -1386,16 +1407,19 @@ func (p *Program) Emit(ctx context.Context, options EmitOptions) *EmitResult {
 
 	for _, sourceFile := range sourceFiles {
 		emitter := &emitter{
-			writer:     nil,
-			sourceFile: sourceFile,
-			emitOnly:   options.EmitOnly,
-			writeFile:  options.WriteFile,
+			writer:             nil,
+			sourceFile:         sourceFile,
+			emitOnly:           options.EmitOnly,
+			writeFile:          options.WriteFile,
+			customTransformers: options.CustomTransformers,
 		}
 		emitters = append(emitters, emitter)
 		wg.Queue(func() {
 			host, done := newEmitHost(ctx, p, sourceFile)
 			defer done()
 			emitter.host = host
+			emitter.tracer = p.opts.Tracing.AcquireTracer()
+			defer p.opts.Tracing.ReleaseTracer(emitter.tracer)
 
 			// take an unused writer
 			writer := writerPool.Get().(printer.EmitTextWriter)
@@ -3,7 +3,7 @@ package diagnostics
 
 import "github.com/microsoft/typescript-go/internal/stringutil"
 
-//go:generate go run generate.go -output ./diagnostics_generated.go
+//go:generate go run generate.go -output ./diagnostics_generated.go -locales ./loc
 //go:generate go tool golang.org/x/tools/cmd/stringer -type=Category -output=stringer_generated.go
 //go:generate go tool mvdan.cc/gofumpt -w diagnostics_generated.go stringer_generated.go
 
@@ -38,6 +38,8 @@ type Message struct {
 	reportsUnnecessary           bool
 	elidedInCompatibilityPyramid bool
 	reportsDeprecated            bool
+	formatted                    bool  // Set by FormatMessage
+	args                         []any // Set by FormatMessage if localization is enabled
 }
 
 func (m *Message) Code() int32                        { return m.code }
@@ -59,5 +61,13 @@ func (m *Message) Format(args ...any) string {
 func FormatMessage(m *Message, args ...any) *Message {
 	result := *m
 	result.text = stringutil.Format(m.text, args)
+	result.formatted = true
+	if localizationEnabled.Load() {
+		// A non-nil slice marks the message as translatable even if it has no arguments.
+		if args == nil {
+			args = []any{}
+		}
+		result.args = args
+	}
 	return &result
 }
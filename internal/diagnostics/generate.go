@@ -5,6 +5,7 @@ package main
 import (
 	"bytes"
 	"cmp"
+	"encoding/xml"
 	"flag"
 	"fmt"
 	"go/format"
@@ -21,6 +22,7 @@ import (
 	"unicode"
 
 	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
 	"github.com/microsoft/typescript-go/internal/repo"
 )
 
@@ -39,6 +41,7 @@ func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
 	output := flag.String("output", "", "path to the output diagnostics_generated.go file")
+	locales := flag.String("locales", "", "path to the directory of translated message bundles")
 	flag.Parse()
 
 	if *output == "" {
@@ -97,6 +100,73 @@ func main() {
 		log.Fatalf("failed to write output: %v", err)
 		return
 	}
+
+	if *locales != "" {
+		keys := make(map[string]bool, len(diagnosticMessages))
+		for _, m := range diagnosticMessages {
+			_, key := convertPropertyName(m.key, m.Code)
+			keys[key] = true
+		}
+		for lcl, locale := range localizedLocales {
+			translations := readLocalizedMessages(filepath.Join(repo.TypeScriptSubmodulePath, "src", "loc", "lcl", lcl, "diagnosticMessages", "diagnosticMessages.generated.json.lcl"), keys)
+			data, err := json.Marshal(translations, json.Deterministic(true), jsontext.WithIndent("    "))
+			if err != nil {
+				log.Fatalf("failed to encode translations: %v", err)
+				return
+			}
+			if err := os.WriteFile(filepath.Join(*locales, locale+".json"), append(data, '\n'), 0o666); err != nil {
+				log.Fatalf("failed to write translations: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// localizedLocales maps the names of the TypeScript localization directories to the locales
+// their bundles are written for. Each locale must also be listed in supportedLocales.
+var localizedLocales = map[string]string{
+	"jpn": "ja",
+	"ptb": "pt-br",
+}
+
+type lclItem struct {
+	ItemID string    `xml:"ItemId,attr"`
+	Items  []lclItem `xml:"Item"`
+	Source string    `xml:"Str>Val"`
+	Target string    `xml:"Str>Tgt>Val"`
+}
+
+// readLocalizedMessages reads the translations of the given message keys from a TypeScript
+// localization file.
+func readLocalizedMessages(p string, keys map[string]bool) map[string]string {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		log.Fatalf("failed to open file: %v", err)
+		return nil
+	}
+
+	var root struct {
+		Items []lclItem `xml:"Item"`
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		log.Fatalf("failed to decode file: %v", err)
+		return nil
+	}
+
+	translations := make(map[string]string)
+	var visit func(items []lclItem)
+	visit = func(items []lclItem) {
+		for _, item := range items {
+			visit(item.Items)
+			key := strings.TrimPrefix(item.ItemID, ";")
+			if !keys[key] {
+				continue
+			}
+			translations[key] = cmp.Or(item.Target, item.Source)
+		}
+	}
+	visit(root.Items)
+	return translations
 }
 
 func readRawMessages(p string) map[int]*diagnosticMessage {
@@ -0,0 +1,128 @@
+package diagnostics
+
+import (
+	"embed"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+	"golang.org/x/text/language"
+)
+
+// Translated messages, keyed by Message.Key. Each bundle is named after the locale it translates
+// to; messages missing from a bundle are written in English.
+//
+// The checked-in bundles only translate a few common messages. Running `go generate` with the
+// TypeScript submodule checked out replaces them with every translation in its src/loc/lcl data.
+//
+//go:embed loc/*.json
+var localizedBundles embed.FS
+
+var supportedLocales = [...]language.Tag{
+	language.English, // The default; it must come first.
+	language.Japanese,
+	language.BrazilianPortuguese,
+}
+
+var localeMatcher = language.NewMatcher(supportedLocales[:])
+
+var catalogs [len(supportedLocales)]struct {
+	once     sync.Once
+	messages map[string]string
+}
+
+// localizationEnabled is set once a locale with translations is in use. Until then, diagnostics and
+// formatted messages do not keep the arguments they were formatted with, which are only needed
+// to translate them.
+var localizationEnabled atomic.Bool
+
+// EnableLocalization makes the diagnostics and formatted messages created from now on
+// translatable, if locale has translations. Those created before are written in English.
+func EnableLocalization(locale language.Tag) {
+	if _, ok := matchLocale(locale); ok {
+		localizationEnabled.Store(true)
+	}
+}
+
+// LocalizationEnabled reports whether diagnostics should keep what is needed to translate them.
+func LocalizationEnabled() bool {
+	return localizationEnabled.Load()
+}
+
+// matchLocale returns the index of the supported locale with translations that best matches
+// locale, or false if messages should be written in English.
+func matchLocale(locale language.Tag) (int, bool) {
+	if locale == language.Und {
+		return 0, false
+	}
+	_, index, confidence := localeMatcher.Match(locale)
+	if index == 0 || confidence == language.No {
+		return 0, false
+	}
+	return index, true
+}
+
+// getCatalog returns the translated messages for the supported locale that best matches locale,
+// or nil if messages should be written in English.
+func getCatalog(locale language.Tag) map[string]string {
+	index, ok := matchLocale(locale)
+	if !ok {
+		return nil
+	}
+	catalog := &catalogs[index]
+	catalog.once.Do(func() {
+		data, err := localizedBundles.ReadFile("loc/" + strings.ToLower(supportedLocales[index].String()) + ".json")
+		if err != nil {
+			panic(err)
+		}
+		if err := json.Unmarshal(data, &catalog.messages); err != nil {
+			panic(err)
+		}
+	})
+	return catalog.messages
+}
+
+// Localize formats the message in the given locale, falling back to English for unsupported
+// locales and untranslated messages. A message created by FormatMessage is formatted with the
+// arguments it was created with, and is only translated if localization was enabled then.
+func (m *Message) Localize(locale language.Tag, args ...any) string {
+	if m.formatted {
+		if m.args == nil {
+			return m.text
+		}
+		args = m.args
+	}
+	text, ok := getCatalog(locale)[m.key]
+	if !ok {
+		if m.formatted {
+			return m.text
+		}
+		return m.Format(args...)
+	}
+	if len(args) != 0 {
+		text = stringutil.Format(text, args)
+	}
+	return text
+}
+
+var localePattern = regexp.MustCompile(`(?i)^([a-z]+)([_-]([a-z]+))?$`)
+
+// ParseLocale parses the value of the `--locale` option. It reports false if the value is not of
+// the form <language> or <language>-<territory>; a well-formed locale that has no translations
+// is accepted, and messages are written in English.
+func ParseLocale(locale string) (language.Tag, bool) {
+	if locale == "" {
+		return language.Und, true
+	}
+	if !localePattern.MatchString(locale) {
+		return language.Und, false
+	}
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil {
+		return language.Und, true
+	}
+	return tag, true
+}
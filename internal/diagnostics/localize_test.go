@@ -0,0 +1,52 @@
+package diagnostics_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"golang.org/x/text/language"
+	"gotest.tools/v3/assert"
+)
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+	diagnostics.EnableLocalization(language.Japanese)
+	tests := []struct {
+		locale   language.Tag
+		message  *diagnostics.Message
+		args     []any
+		expected string
+	}{
+		{language.Und, diagnostics.Cannot_find_name_0, []any{"x"}, "Cannot find name 'x'."},
+		{language.Japanese, diagnostics.Cannot_find_name_0, []any{"x"}, "名前 'x' が見つかりません。"},
+		{language.MustParse("ja-JP"), diagnostics.Cannot_find_name_0, []any{"x"}, "名前 'x' が見つかりません。"},
+		{language.BrazilianPortuguese, diagnostics.Cannot_find_name_0, []any{"x"}, "Não é possível localizar o nome 'x'."},
+		{language.German, diagnostics.Cannot_find_name_0, []any{"x"}, "Cannot find name 'x'."},
+		{language.Japanese, diagnostics.Generates_a_sourcemap_for_each_corresponding_d_ts_file, nil, "Generates a sourcemap for each corresponding '.d.ts' file."},
+		{language.Japanese, diagnostics.FormatMessage(diagnostics.Found_0_errors, 2), nil, "2 件のエラーが見つかりました。"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.message.Localize(test.locale, test.args...), test.expected)
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		locale   string
+		expected language.Tag
+		ok       bool
+	}{
+		{"", language.Und, true},
+		{"ja", language.Japanese, true},
+		{"pt-BR", language.BrazilianPortuguese, true},
+		{"pt_br", language.BrazilianPortuguese, true},
+		{"en-US-x", language.Und, false},
+		{"ja-", language.Und, false},
+	}
+	for _, test := range tests {
+		locale, ok := diagnostics.ParseLocale(test.locale)
+		assert.Equal(t, ok, test.ok, test.locale)
+		assert.Equal(t, locale, test.expected, test.locale)
+	}
+}
@@ -215,6 +215,7 @@ func (t *buildTask) compileAndEmit(orchestrator *Orchestrator, path tspath.Path)
 		oldProgram = incremental.ReadBuildInfoProgram(t.resolved, orchestrator.host, orchestrator.host)
 	}
 	compileTimes.BuildInfoReadTime = orchestrator.opts.Sys.Now().Sub(buildInfoReadStart)
+	trace := tsc.StartTracing(orchestrator.opts.Sys, t.resolved.CompilerOptions().GenerateTrace, t.config, orchestrator.tracingSession)
 	parseStart := orchestrator.opts.Sys.Now()
 	program := compiler.NewProgram(compiler.ProgramOptions{
 		Config: t.resolved,
@@ -223,6 +224,7 @@ func (t *buildTask) compileAndEmit(orchestrator *Orchestrator, path tspath.Path)
 			trace: tsc.GetTraceWithWriterFromSys(&t.result.builder, orchestrator.opts.Testing),
 		},
 		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+		Tracing:          trace,
 	})
 	compileTimes.ParseTime = orchestrator.opts.Sys.Now().Sub(parseStart)
 	changesComputeStart := orchestrator.opts.Sys.Now()
@@ -244,6 +246,7 @@ func (t *buildTask) compileAndEmit(orchestrator *Orchestrator, path tspath.Path)
 		Testing:            orchestrator.opts.Testing,
 		TestingMTimesCache: orchestrator.host.mTimes,
 	})
+	tsc.StopTracing(trace, t.reportDiagnostic)
 	t.result.exitStatus = result.Status
 	t.result.statistics = statistics
 	if (!program.Options().NoEmitOnError.IsTrue() || len(result.Diagnostics) == 0) &&
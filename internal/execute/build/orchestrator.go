@@ -12,6 +12,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/execute/tsc"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs/cachedvfs"
@@ -64,6 +65,7 @@ type Orchestrator struct {
 
 	errorSummaryReporter tsc.DiagnosticsReporter
 	watchStatusReporter  tsc.DiagnosticReporter
+	tracingSession       *tracing.Session
 }
 
 var _ tsc.Watcher = (*Orchestrator)(nil)
@@ -378,7 +380,8 @@ func NewOrchestrator(opts Options) *Orchestrator {
 			CurrentDirectory:          opts.Sys.GetCurrentDirectory(),
 			UseCaseSensitiveFileNames: opts.Sys.FS().UseCaseSensitiveFileNames(),
 		},
-		tasks: &collections.SyncMap[tspath.Path, *buildTask]{},
+		tasks:          &collections.SyncMap[tspath.Path, *buildTask]{},
+		tracingSession: tracing.NewSession(opts.Sys.FS(), opts.Sys.Now),
 	}
 	orchestrator.host = &host{
 		orchestrator: orchestrator,
@@ -122,9 +122,10 @@ func (h *emitFilesHandler) emitFilesIncremental(options compiler.EmitOptions) []
 				var result *compiler.EmitResult
 				if !h.isForDtsErrors {
 					result = h.program.program.Emit(h.ctx, h.getEmitOptions(compiler.EmitOptions{
-						TargetSourceFile: affectedFile,
-						EmitOnly:         emitOnly,
-						WriteFile:        options.WriteFile,
+						TargetSourceFile:   affectedFile,
+						EmitOnly:           emitOnly,
+						WriteFile:          options.WriteFile,
+						CustomTransformers: options.CustomTransformers,
 					}))
 				} else {
 					result = &compiler.EmitResult{
@@ -174,8 +175,9 @@ func (h *emitFilesHandler) getEmitOptions(options compiler.EmitOptions) compiler
 	}
 	canUseIncrementalState := h.program.snapshot.canUseIncrementalState()
 	return compiler.EmitOptions{
-		TargetSourceFile: options.TargetSourceFile,
-		EmitOnly:         options.EmitOnly,
+		TargetSourceFile:   options.TargetSourceFile,
+		EmitOnly:           options.EmitOnly,
+		CustomTransformers: options.CustomTransformers,
 		WriteFile: func(fileName string, text string, writeByteOrderMark bool, data *compiler.WriteFileData) error {
 			var differsOnlyInMap bool
 			if tspath.IsDeclarationFileName(fileName) {
@@ -75,6 +75,11 @@ func (p *Program) GetProgram() *compiler.Program {
 	return p.program
 }
 
+// HasProgram reports whether the program was built, rather than only read from build info.
+func (p *Program) HasProgram() bool {
+	return p.program != nil
+}
+
 func (p *Program) HasChangedDtsFile() bool {
 	return p.snapshot.hasChangedDtsFile
 }
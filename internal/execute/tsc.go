@@ -3,6 +3,7 @@ package execute
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/microsoft/typescript-go/internal/ast"
@@ -63,12 +64,13 @@ func fmtMain(sys tsc.System, input, output string) tsc.ExitStatus {
 func tscBuildCompilation(sys tsc.System, buildCommand *tsoptions.ParsedBuildCommandLine, testing tsc.CommandLineTesting) tsc.CommandLineResult {
 	reportDiagnostic := tsc.CreateDiagnosticReporter(sys, sys.Writer(), buildCommand.CompilerOptions)
 
-	// if (buildOptions.locale) {
-	//     validateLocaleAndSetLanguage(buildOptions.locale, sys, errors);
-	// }
+	errs := buildCommand.Errors
+	if diagnostic := tsc.SetLocale(buildCommand.CompilerOptions); diagnostic != nil {
+		errs = append(slices.Clip(errs), diagnostic)
+	}
 
-	if len(buildCommand.Errors) > 0 {
-		for _, err := range buildCommand.Errors {
+	if len(errs) > 0 {
+		for _, err := range errs {
 			reportDiagnostic(err)
 		}
 		return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsSkipped}
@@ -81,8 +83,8 @@ func tscBuildCompilation(sys tsc.System, buildCommand *tsoptions.ParsedBuildComm
 	}
 
 	if buildCommand.CompilerOptions.Help.IsTrue() {
-		tsc.PrintVersion(sys)
-		tsc.PrintBuildHelp(sys, tsoptions.BuildOpts)
+		tsc.PrintVersion(sys, buildCommand.CompilerOptions)
+		tsc.PrintBuildHelp(sys, buildCommand.CompilerOptions, tsoptions.BuildOpts)
 		return tsc.CommandLineResult{Status: tsc.ExitStatusSuccess}
 	}
 
@@ -97,10 +99,13 @@ func tscBuildCompilation(sys tsc.System, buildCommand *tsoptions.ParsedBuildComm
 func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, testing tsc.CommandLineTesting) tsc.CommandLineResult {
 	configFileName := ""
 	reportDiagnostic := tsc.CreateDiagnosticReporter(sys, sys.Writer(), commandLine.CompilerOptions())
-	// if commandLine.Options().Locale != nil
+	errs := commandLine.Errors
+	if diagnostic := tsc.SetLocale(commandLine.CompilerOptions()); diagnostic != nil {
+		errs = append(slices.Clip(errs), diagnostic)
+	}
 
-	if len(commandLine.Errors) > 0 {
-		for _, e := range commandLine.Errors {
+	if len(errs) > 0 {
+		for _, e := range errs {
 			reportDiagnostic(e)
 		}
 		return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsSkipped}
@@ -113,11 +118,12 @@ func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, te
 	}
 
 	if commandLine.CompilerOptions().Init.IsTrue() {
-		return tsc.CommandLineResult{Status: tsc.ExitStatusNotImplemented}
+		tsc.WriteConfigFile(sys, reportDiagnostic, commandLine.CompilerOptions(), commandLine.FileNames())
+		return tsc.CommandLineResult{Status: tsc.ExitStatusSuccess}
 	}
 
 	if commandLine.CompilerOptions().Version.IsTrue() {
-		tsc.PrintVersion(sys)
+		tsc.PrintVersion(sys, commandLine.CompilerOptions())
 		return tsc.CommandLineResult{Status: tsc.ExitStatusSuccess}
 	}
 
@@ -160,7 +166,7 @@ func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, te
 		if commandLine.CompilerOptions().ShowConfig.IsTrue() {
 			reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Cannot_find_a_tsconfig_json_file_at_the_current_directory_Colon_0, tspath.NormalizePath(sys.GetCurrentDirectory())))
 		} else {
-			tsc.PrintVersion(sys)
+			tsc.PrintVersion(sys, commandLine.CompilerOptions())
 			tsc.PrintHelp(sys, commandLine)
 		}
 		return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsSkipped}
@@ -182,6 +188,7 @@ func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, te
 			}
 			return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsGenerated}
 		}
+		configParseResult.ParsedConfig.WatchOptions = tsoptions.MergeWatchOptions(configParseResult.ParsedConfig.WatchOptions, commandLine.ParsedConfig.WatchOptions)
 		configForCompilation = configParseResult
 		// Updater to reflect pretty
 		reportDiagnostic = tsc.CreateDiagnosticReporter(sys, sys.Writer(), commandLine.CompilerOptions())
@@ -189,11 +196,17 @@ func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, te
 
 	reportErrorSummary := tsc.CreateReportErrorSummary(sys, configForCompilation.CompilerOptions())
 	if compilerOptionsFromCommandLine.ShowConfig.IsTrue() {
-		showConfig(sys, configForCompilation.CompilerOptions())
+		if len(configForCompilation.Errors) != 0 {
+			for _, e := range configForCompilation.Errors {
+				reportDiagnostic(e)
+			}
+			return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsSkipped}
+		}
+		showConfig(sys, configForCompilation, configFileName)
 		return tsc.CommandLineResult{Status: tsc.ExitStatusSuccess}
 	}
 	if configForCompilation.CompilerOptions().Watch.IsTrue() {
-		watcher := createWatcher(sys, configForCompilation, reportDiagnostic, reportErrorSummary, testing)
+		watcher := createWatcher(sys, configForCompilation, commandLine.ParsedConfig.WatchOptions, reportDiagnostic, reportErrorSummary, testing)
 		watcher.start()
 		return tsc.CommandLineResult{Status: tsc.ExitStatusSuccess, Watcher: watcher}
 	} else if configForCompilation.CompilerOptions().IsIncremental() {
@@ -249,12 +262,14 @@ func performIncrementalCompilation(
 	buildInfoReadStart := sys.Now()
 	oldProgram := incremental.ReadBuildInfoProgram(config, incremental.NewBuildInfoReader(host), host)
 	compileTimes.BuildInfoReadTime = sys.Now().Sub(buildInfoReadStart)
-	// todo: cache, statistics, tracing
+	// todo: cache, statistics
+	trace := tsc.StartTracing(sys, config.CompilerOptions().GenerateTrace, config.CompilerOptions().ConfigFilePath, nil /*session*/)
 	parseStart := sys.Now()
 	program := compiler.NewProgram(compiler.ProgramOptions{
 		Config:           config,
 		Host:             host,
 		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+		Tracing:          trace,
 	})
 	compileTimes.ParseTime = sys.Now().Sub(parseStart)
 	changesComputeStart := sys.Now()
@@ -271,6 +286,7 @@ func performIncrementalCompilation(
 		CompileTimes:       compileTimes,
 		Testing:            testing,
 	})
+	tsc.StopTracing(trace, reportDiagnostic)
 	if testing != nil {
 		testing.OnProgram(incrementalProgram)
 	}
@@ -289,12 +305,14 @@ func performCompilation(
 	testing tsc.CommandLineTesting,
 ) tsc.CommandLineResult {
 	host := compiler.NewCachedFSCompilerHost(sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), extendedConfigCache, getTraceFromSys(sys, testing))
-	// todo: cache, statistics, tracing
+	// todo: cache, statistics
+	trace := tsc.StartTracing(sys, config.CompilerOptions().GenerateTrace, config.CompilerOptions().ConfigFilePath, nil /*session*/)
 	parseStart := sys.Now()
 	program := compiler.NewProgram(compiler.ProgramOptions{
 		Config:           config,
 		Host:             host,
 		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+		Tracing:          trace,
 	})
 	compileTimes.ParseTime = sys.Now().Sub(parseStart)
 	result, _ := tsc.EmitAndReportStatistics(tsc.EmitInput{
@@ -308,12 +326,19 @@ func performCompilation(
 		CompileTimes:       compileTimes,
 		Testing:            testing,
 	})
+	tsc.StopTracing(trace, reportDiagnostic)
 	return tsc.CommandLineResult{
 		Status: result.Status,
 	}
 }
 
-func showConfig(sys tsc.System, config *core.CompilerOptions) {
-	// !!!
-	_ = jsonutil.MarshalIndentWrite(sys.Writer(), config, "", "    ")
+func showConfig(sys tsc.System, config *tsoptions.ParsedCommandLine, configFileName string) {
+	if configFileName == "" {
+		configFileName = tspath.CombinePaths(sys.GetCurrentDirectory(), "tsconfig.json")
+	}
+	tsconfig := tsoptions.ConvertToTSConfig(config, configFileName, tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
+		CurrentDirectory:          sys.GetCurrentDirectory(),
+	})
+	_ = jsonutil.MarshalIndentWrite(sys.Writer(), tsconfig, "", "    ")
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/execute/incremental"
 	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs"
@@ -58,6 +59,8 @@ type CommandLineTesting interface {
 	OnWatchStatusReportEnd()
 	GetTrace(w io.Writer) func(msg string)
 	OnProgram(program *incremental.Program)
+	// Reports what watch mode watches for the next cycle, in place of watching it.
+	OnWatch(options *core.WatchOptions, files []string, directories map[string]bool)
 }
 
 type CompileTimes struct {
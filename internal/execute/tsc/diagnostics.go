@@ -7,13 +7,16 @@ import (
 
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
 	"github.com/microsoft/typescript-go/internal/tspath"
+	"golang.org/x/text/language"
 )
 
-func getFormatOptsOfSys(sys System) *diagnosticwriter.FormattingOptions {
+func getFormatOptsOfSys(sys System, options *core.CompilerOptions) *diagnosticwriter.FormattingOptions {
 	return &diagnosticwriter.FormattingOptions{
 		NewLine: "\n",
+		Locale:  getLocale(options),
 		ComparePathsOptions: tspath.ComparePathsOptions{
 			CurrentDirectory:          sys.GetCurrentDirectory(),
 			UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
@@ -21,6 +24,27 @@ func getFormatOptsOfSys(sys System) *diagnosticwriter.FormattingOptions {
 	}
 }
 
+// getLocale returns the locale that messages are written in. A malformed `--locale`, which is
+// reported by SetLocale, falls back to English.
+func getLocale(options *core.CompilerOptions) language.Tag {
+	if options == nil {
+		return language.Und
+	}
+	locale, _ := diagnostics.ParseLocale(options.Locale)
+	return locale
+}
+
+// SetLocale enables translation of the diagnostics created from now on into the locale of the
+// `--locale` option. It returns an error diagnostic if the option is malformed.
+func SetLocale(options *core.CompilerOptions) *ast.Diagnostic {
+	locale, ok := diagnostics.ParseLocale(options.Locale)
+	if !ok {
+		return ast.NewCompilerDiagnostic(diagnostics.Locale_must_be_of_the_form_language_or_language_territory_For_example_0_or_1, "en", "ja-jp")
+	}
+	diagnostics.EnableLocalization(locale)
+	return nil
+}
+
 type DiagnosticReporter = func(*ast.Diagnostic)
 
 func QuietDiagnosticReporter(diagnostic *ast.Diagnostic) {}
@@ -29,7 +53,7 @@ func CreateDiagnosticReporter(sys System, w io.Writer, options *core.CompilerOpt
 	if options.Quiet.IsTrue() {
 		return QuietDiagnosticReporter
 	}
-	formatOpts := getFormatOptsOfSys(sys)
+	formatOpts := getFormatOptsOfSys(sys, options)
 	if shouldBePretty(sys, options) {
 		return func(diagnostic *ast.Diagnostic) {
 			diagnosticwriter.FormatDiagnosticWithColorAndContext(w, diagnostic, formatOpts)
@@ -125,7 +149,7 @@ func QuietDiagnosticsReporter(diagnostics []*ast.Diagnostic) {}
 
 func CreateReportErrorSummary(sys System, options *core.CompilerOptions) DiagnosticsReporter {
 	if shouldBePretty(sys, options) {
-		formatOpts := getFormatOptsOfSys(sys)
+		formatOpts := getFormatOptsOfSys(sys, options)
 		return func(diagnostics []*ast.Diagnostic) {
 			diagnosticwriter.WriteErrorSummaryText(sys.Writer(), diagnostics, formatOpts)
 		}
@@ -138,7 +162,7 @@ func CreateBuilderStatusReporter(sys System, w io.Writer, options *core.Compiler
 		return QuietDiagnosticReporter
 	}
 
-	formatOpts := getFormatOptsOfSys(sys)
+	formatOpts := getFormatOptsOfSys(sys, options)
 	writeStatus := core.IfElse(shouldBePretty(sys, options), diagnosticwriter.FormatDiagnosticsStatusWithColorAndTime, diagnosticwriter.FormatDiagnosticsStatusAndTime)
 	return func(diagnostic *ast.Diagnostic) {
 		if testing != nil {
@@ -151,7 +175,7 @@ func CreateBuilderStatusReporter(sys System, w io.Writer, options *core.Compiler
 }
 
 func CreateWatchStatusReporter(sys System, options *core.CompilerOptions, testing CommandLineTesting) DiagnosticReporter {
-	formatOpts := getFormatOptsOfSys(sys)
+	formatOpts := getFormatOptsOfSys(sys, options)
 	writeStatus := core.IfElse(shouldBePretty(sys, options), diagnosticwriter.FormatDiagnosticsStatusWithColorAndTime, diagnosticwriter.FormatDiagnosticsStatusAndTime)
 	return func(diagnostic *ast.Diagnostic) {
 		writer := sys.Writer()
@@ -4,22 +4,25 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"golang.org/x/text/language"
 )
 
-func PrintVersion(sys System) {
-	fmt.Fprintln(sys.Writer(), diagnostics.Version_0.Format(core.Version()))
+func PrintVersion(sys System, options *core.CompilerOptions) {
+	fmt.Fprintln(sys.Writer(), diagnostics.Version_0.Localize(getLocale(options), core.Version()))
 }
 
 func PrintHelp(sys System, commandLine *tsoptions.ParsedCommandLine) {
+	locale := getLocale(commandLine.CompilerOptions())
 	if commandLine.CompilerOptions().All.IsFalseOrUnknown() {
-		printEasyHelp(sys, getOptionsForHelp(commandLine))
+		printEasyHelp(sys, locale, getOptionsForHelp(commandLine))
 	} else {
-		printAllHelp(sys, getOptionsForHelp(commandLine))
+		printAllHelp(sys, locale, getOptionsForHelp(commandLine))
 	}
 }
 
@@ -51,7 +54,7 @@ func getHeader(sys System, message string) []string {
 	tsIconFirstLine := colors.blueBackground(tsIcon)
 	tsIconSecondLine := colors.blueBackground(colors.brightWhite(tsIconTS))
 	// If we have enough space, print TS icon.
-	if terminalWidth >= len(message)+tsIconLength {
+	if terminalWidth >= utf8.RuneCountInString(message)+tsIconLength {
 		// right align of the icon is 120 at most.
 		rightAlign := core.IfElse(terminalWidth > 120, 120, terminalWidth)
 		leftAlign := rightAlign - tsIconLength
@@ -63,20 +66,20 @@ func getHeader(sys System, message string) []string {
 	return header
 }
 
-func printEasyHelp(sys System, simpleOptions []*tsoptions.CommandLineOption) {
+func printEasyHelp(sys System, locale language.Tag, simpleOptions []*tsoptions.CommandLineOption) {
 	colors := createColors(sys)
 	var output []string
 	example := func(examples []string, desc *diagnostics.Message) {
 		for _, example := range examples {
 			output = append(output, "  ", colors.blue(example), "\n")
 		}
-		output = append(output, "  ", desc.Format(), "\n", "\n")
+		output = append(output, "  ", desc.Localize(locale), "\n", "\n")
 	}
 
-	msg := diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Format() + " - " + diagnostics.Version_0.Format(core.Version())
+	msg := diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Localize(locale) + " - " + diagnostics.Version_0.Localize(locale, core.Version())
 	output = append(output, getHeader(sys, msg)...)
 
-	output = append(output, colors.bold(diagnostics.COMMON_COMMANDS.Format()), "\n", "\n")
+	output = append(output, colors.bold(diagnostics.COMMON_COMMANDS.Localize(locale)), "\n", "\n")
 
 	example([]string{"tsc"}, diagnostics.Compiles_the_current_project_tsconfig_json_in_the_working_directory)
 	example([]string{"tsc app.ts util.ts"}, diagnostics.Ignoring_tsconfig_json_compiles_the_specified_files_with_default_compiler_options)
@@ -96,50 +99,50 @@ func printEasyHelp(sys System, simpleOptions []*tsoptions.CommandLineOption) {
 		}
 	}
 
-	output = append(output, generateSectionOptionsOutput(sys, diagnostics.COMMAND_LINE_FLAGS.Format(), cliCommands /*subCategory*/, false /*beforeOptionsDescription*/, nil /*afterOptionsDescription*/, nil)...)
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.COMMAND_LINE_FLAGS.Localize(locale), cliCommands /*subCategory*/, false /*beforeOptionsDescription*/, nil /*afterOptionsDescription*/, nil)...)
 
-	// !!! locale formatMessage
-	after := diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0.Format("https://aka.ms/tsc")
-	output = append(output, generateSectionOptionsOutput(sys, diagnostics.COMMON_COMPILER_OPTIONS.Format(), configOpts /*subCategory*/, false /*beforeOptionsDescription*/, nil, &after)...)
+	after := diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0.Localize(locale, "https://aka.ms/tsc")
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.COMMON_COMPILER_OPTIONS.Localize(locale), configOpts /*subCategory*/, false /*beforeOptionsDescription*/, nil, &after)...)
 
 	for _, chunk := range output {
 		fmt.Fprint(sys.Writer(), chunk)
 	}
 }
 
-func printAllHelp(sys System, options []*tsoptions.CommandLineOption) {
+func printAllHelp(sys System, locale language.Tag, options []*tsoptions.CommandLineOption) {
 	var output []string
-	msg := diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Format() + " - " + diagnostics.Version_0.Format(core.Version())
+	msg := diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Localize(locale) + " - " + diagnostics.Version_0.Localize(locale, core.Version())
 	output = append(output, getHeader(sys, msg)...)
 
 	// ALL COMPILER OPTIONS section
-	afterCompilerOptions := diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0.Format("https://aka.ms/tsc")
-	output = append(output, generateSectionOptionsOutput(sys, diagnostics.ALL_COMPILER_OPTIONS.Format(), options, true, nil, &afterCompilerOptions)...)
+	afterCompilerOptions := diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0.Localize(locale, "https://aka.ms/tsc")
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.ALL_COMPILER_OPTIONS.Localize(locale), options, true, nil, &afterCompilerOptions)...)
 
 	// WATCH OPTIONS section
-	beforeWatchOptions := diagnostics.Including_watch_w_will_start_watching_the_current_project_for_the_file_changes_Once_set_you_can_config_watch_mode_with_Colon.Format()
-	output = append(output, generateSectionOptionsOutput(sys, diagnostics.WATCH_OPTIONS.Format(), tsoptions.OptionsForWatch, false, &beforeWatchOptions, nil)...)
+	beforeWatchOptions := diagnostics.Including_watch_w_will_start_watching_the_current_project_for_the_file_changes_Once_set_you_can_config_watch_mode_with_Colon.Localize(locale)
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.WATCH_OPTIONS.Localize(locale), tsoptions.OptionsForWatch, false, &beforeWatchOptions, nil)...)
 
 	// BUILD OPTIONS section
-	beforeBuildOptions := diagnostics.Using_build_b_will_make_tsc_behave_more_like_a_build_orchestrator_than_a_compiler_This_is_used_to_trigger_building_composite_projects_which_you_can_learn_more_about_at_0.Format("https://aka.ms/tsc-composite-builds")
+	beforeBuildOptions := diagnostics.Using_build_b_will_make_tsc_behave_more_like_a_build_orchestrator_than_a_compiler_This_is_used_to_trigger_building_composite_projects_which_you_can_learn_more_about_at_0.Localize(locale, "https://aka.ms/tsc-composite-builds")
 	buildOptions := core.Filter(tsoptions.OptionsForBuild, func(option *tsoptions.CommandLineOption) bool {
 		return option != &tsoptions.TscBuildOption
 	})
-	output = append(output, generateSectionOptionsOutput(sys, diagnostics.BUILD_OPTIONS.Format(), buildOptions, false, &beforeBuildOptions, nil)...)
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.BUILD_OPTIONS.Localize(locale), buildOptions, false, &beforeBuildOptions, nil)...)
 
 	for _, chunk := range output {
 		fmt.Fprint(sys.Writer(), chunk)
 	}
 }
 
-func PrintBuildHelp(sys System, buildOptions []*tsoptions.CommandLineOption) {
+func PrintBuildHelp(sys System, options *core.CompilerOptions, buildOptions []*tsoptions.CommandLineOption) {
+	locale := getLocale(options)
 	var output []string
-	output = append(output, getHeader(sys, diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Format()+" - "+diagnostics.Version_0.Format(core.Version()))...)
-	before := diagnostics.Using_build_b_will_make_tsc_behave_more_like_a_build_orchestrator_than_a_compiler_This_is_used_to_trigger_building_composite_projects_which_you_can_learn_more_about_at_0.Format("https://aka.ms/tsc-composite-builds")
-	options := core.Filter(buildOptions, func(option *tsoptions.CommandLineOption) bool {
+	output = append(output, getHeader(sys, diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Localize(locale)+" - "+diagnostics.Version_0.Localize(locale, core.Version()))...)
+	before := diagnostics.Using_build_b_will_make_tsc_behave_more_like_a_build_orchestrator_than_a_compiler_This_is_used_to_trigger_building_composite_projects_which_you_can_learn_more_about_at_0.Localize(locale, "https://aka.ms/tsc-composite-builds")
+	buildOptions = core.Filter(buildOptions, func(option *tsoptions.CommandLineOption) bool {
 		return option != &tsoptions.TscBuildOption
 	})
-	output = append(output, generateSectionOptionsOutput(sys, diagnostics.BUILD_OPTIONS.Format(), options, false, &before, nil)...)
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.BUILD_OPTIONS.Localize(locale), buildOptions, false, &before, nil)...)
 
 	for _, chunk := range output {
 		fmt.Fprint(sys.Writer(), chunk)
@@ -148,6 +151,7 @@ func PrintBuildHelp(sys System, buildOptions []*tsoptions.CommandLineOption) {
 
 func generateSectionOptionsOutput(
 	sys System,
+	locale language.Tag,
 	sectionName string,
 	options []*tsoptions.CommandLineOption,
 	subCategory bool,
@@ -160,7 +164,7 @@ func generateSectionOptionsOutput(
 		output = append(output, *beforeOptionsDescription, "\n", "\n")
 	}
 	if !subCategory {
-		output = append(output, generateGroupOptionOutput(sys, options)...)
+		output = append(output, generateGroupOptionOutput(sys, locale, options)...)
 		if afterOptionsDescription != nil {
 			output = append(output, *afterOptionsDescription, "\n", "\n")
 		}
@@ -172,7 +176,7 @@ func generateSectionOptionsOutput(
 		if option.Category == nil {
 			continue
 		}
-		curCategory := option.Category.Format()
+		curCategory := option.Category.Localize(locale)
 		if _, exists := categoryMap[curCategory]; !exists {
 			categoryOrder = append(categoryOrder, curCategory)
 		}
@@ -181,7 +185,7 @@ func generateSectionOptionsOutput(
 	for _, key := range categoryOrder {
 		value := categoryMap[key]
 		output = append(output, "### ", key, "\n", "\n")
-		output = append(output, generateGroupOptionOutput(sys, value)...)
+		output = append(output, generateGroupOptionOutput(sys, locale, value)...)
 	}
 	if afterOptionsDescription != nil {
 		output = append(output, *afterOptionsDescription, "\n", "\n")
@@ -190,7 +194,7 @@ func generateSectionOptionsOutput(
 	return output
 }
 
-func generateGroupOptionOutput(sys System, optionsList []*tsoptions.CommandLineOption) []string {
+func generateGroupOptionOutput(sys System, locale language.Tag, optionsList []*tsoptions.CommandLineOption) []string {
 	var maxLength int
 	for _, option := range optionsList {
 		curLenght := len(getDisplayNameTextOfOption(option))
@@ -206,7 +210,7 @@ func generateGroupOptionOutput(sys System, optionsList []*tsoptions.CommandLineO
 
 	var lines []string
 	for _, option := range optionsList {
-		tmp := generateOptionOutput(sys, option, rightAlignOfLeftPart, leftAlignOfRightPart)
+		tmp := generateOptionOutput(sys, locale, option, rightAlignOfLeftPart, leftAlignOfRightPart)
 		lines = append(lines, tmp...)
 	}
 
@@ -220,6 +224,7 @@ func generateGroupOptionOutput(sys System, optionsList []*tsoptions.CommandLineO
 
 func generateOptionOutput(
 	sys System,
+	locale language.Tag,
 	option *tsoptions.CommandLineOption,
 	rightAlignOfLeft, leftAlignOfRight int,
 ) []string {
@@ -230,11 +235,11 @@ func generateOptionOutput(
 	name := getDisplayNameTextOfOption(option)
 
 	// value type and possible value
-	valueCandidates := getValueCandidate(option)
+	valueCandidates := getValueCandidate(locale, option)
 
 	var defaultValueDescription string
 	if msg, ok := option.DefaultValueDescription.(*diagnostics.Message); ok && msg != nil {
-		defaultValueDescription = msg.Format()
+		defaultValueDescription = msg.Localize(locale)
 	} else {
 		defaultValueDescription = formatDefaultValue(
 			option.DefaultValueDescription,
@@ -250,7 +255,7 @@ func generateOptionOutput(
 	if terminalWidth >= 80 {
 		description := ""
 		if option.Description != nil {
-			description = option.Description.Format()
+			description = option.Description.Localize(locale)
 		}
 		text = append(text, getPrettyOutput(colors, name, description, rightAlignOfLeft, leftAlignOfRight, terminalWidth, true /*colorLeft*/)...)
 		text = append(text, "\n")
@@ -260,7 +265,7 @@ func generateOptionOutput(
 				text = append(text, "\n")
 			}
 			if defaultValueDescription != "" {
-				text = append(text, getPrettyOutput(colors, diagnostics.X_default_Colon.Format(), defaultValueDescription, rightAlignOfLeft, leftAlignOfRight, terminalWidth, false /*colorLeft*/)...)
+				text = append(text, getPrettyOutput(colors, diagnostics.X_default_Colon.Localize(locale), defaultValueDescription, rightAlignOfLeft, leftAlignOfRight, terminalWidth, false /*colorLeft*/)...)
 				text = append(text, "\n")
 			}
 		}
@@ -268,7 +273,7 @@ func generateOptionOutput(
 	} else {
 		text = append(text, colors.blue(name), "\n")
 		if option.Description != nil {
-			text = append(text, option.Description.Format())
+			text = append(text, option.Description.Localize(locale))
 		}
 		text = append(text, "\n")
 		if showAdditionalInfoOutput(valueCandidates, option) {
@@ -279,7 +284,7 @@ func generateOptionOutput(
 				if valueCandidates != nil {
 					text = append(text, "\n")
 				}
-				text = append(text, diagnostics.X_default_Colon.Format(), " ", defaultValueDescription)
+				text = append(text, diagnostics.X_default_Colon.Localize(locale), " ", defaultValueDescription)
 			}
 
 			text = append(text, "\n")
@@ -327,7 +332,7 @@ func showAdditionalInfoOutput(valueCandidates *valueCandidate, option *tsoptions
 	return true
 }
 
-func getValueCandidate(option *tsoptions.CommandLineOption) *valueCandidate {
+func getValueCandidate(locale language.Tag, option *tsoptions.CommandLineOption) *valueCandidate {
 	// option.type might be "string" | "number" | "boolean" | "object" | "list" | Map<string, number | string>
 	// string -- any of: string
 	// number -- any of: number
@@ -349,11 +354,11 @@ func getValueCandidate(option *tsoptions.CommandLineOption) *valueCandidate {
 	case tsoptions.CommandLineOptionTypeString,
 		tsoptions.CommandLineOptionTypeNumber,
 		tsoptions.CommandLineOptionTypeBoolean:
-		res.valueType = diagnostics.X_type_Colon.Format()
+		res.valueType = diagnostics.X_type_Colon.Localize(locale)
 	case tsoptions.CommandLineOptionTypeList:
-		res.valueType = diagnostics.X_one_or_more_Colon.Format()
+		res.valueType = diagnostics.X_one_or_more_Colon.Localize(locale)
 	default:
-		res.valueType = diagnostics.X_one_of_Colon.Format()
+		res.valueType = diagnostics.X_one_of_Colon.Localize(locale)
 	}
 
 	res.possibleValues = getPossibleValues(option)
@@ -393,10 +398,10 @@ func getPossibleValues(option *tsoptions.CommandLineOption) string {
 }
 
 func getPrettyOutput(colors *colors, left string, right string, rightAlignOfLeft int, leftAlignOfRight int, terminalWidth int, colorLeft bool) []string {
-	// !!! How does terminalWidth interact with UTF-8 encoding? Strada just assumed UTF-16.
+	// !!! Widths are counted in runes, much as Strada counted UTF-16 code units; wide characters are not accounted for.
 	res := make([]string, 0, 4)
 	isFirstLine := true
-	remainRight := right
+	remainRight := []rune(right)
 	rightCharacterNumber := terminalWidth - leftAlignOfRight
 	for len(remainRight) > 0 {
 		curLeft := ""
@@ -413,7 +418,7 @@ func getPrettyOutput(colors *colors, left string, right string, rightAlignOfLeft
 		idx := min(rightCharacterNumber, len(remainRight))
 		curRight := remainRight[:idx]
 		remainRight = remainRight[idx:]
-		res = append(res, curLeft, curRight, "\n")
+		res = append(res, curLeft, string(curRight), "\n")
 		isFirstLine = false
 	}
 	return res
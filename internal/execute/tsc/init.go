@@ -0,0 +1,41 @@
+package tsc
+
+import (
+	"fmt"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// WriteConfigFile implements `tsc --init`: it writes a commented tsconfig.json to the current
+// directory, using the options and files given on the command line, and prints a summary of
+// the options that were set.
+func WriteConfigFile(sys System, reportDiagnostic DiagnosticReporter, options *core.CompilerOptions, fileNames []string) {
+	const newLine = "\n"
+	currentDirectory := sys.GetCurrentDirectory()
+	file := tspath.NormalizePath(tspath.CombinePaths(currentDirectory, "tsconfig.json"))
+	if sys.FS().FileExists(file) {
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.A_tsconfig_json_file_is_already_defined_at_Colon_0, file))
+		return
+	}
+
+	comparePathsOptions := tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
+		CurrentDirectory:          currentDirectory,
+	}
+	if err := sys.FS().WriteFile(file, tsoptions.GenerateTSConfig(options, fileNames, comparePathsOptions, newLine), false); err != nil {
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, file, err.Error()))
+		return
+	}
+
+	output := []string{newLine}
+	output = append(output, getHeader(sys, "Created a new tsconfig.json with:")...)
+	output = append(output, tsoptions.GetCompilerOptionsDiffValue(options, comparePathsOptions, newLine)+newLine+newLine)
+	output = append(output, "You can learn more at https://aka.ms/tsconfig"+newLine)
+	for _, chunk := range output {
+		fmt.Fprint(sys.Writer(), chunk)
+	}
+}
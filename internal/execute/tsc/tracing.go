@@ -0,0 +1,33 @@
+package tsc
+
+import (
+	"errors"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/tracing"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// StartTracing begins tracing a compilation into traceDir, the value of `--generateTrace`, and
+// returns nil if it is empty. The compilations of a `--build` or `--watch` session are traced
+// through session, which numbers their trace files; a single compilation passes a nil session.
+func StartTracing(sys System, traceDir string, configFilePath string, session *tracing.Session) *tracing.Tracing {
+	if traceDir == "" {
+		return nil
+	}
+	traceDir = tspath.GetNormalizedAbsolutePath(traceDir, sys.GetCurrentDirectory())
+	if session == nil {
+		return tracing.Start(sys.FS(), traceDir, sys.Now)
+	}
+	return session.Start(traceDir, configFilePath)
+}
+
+// StopTracing writes the trace started by StartTracing, reporting any file that could not be
+// written.
+func StopTracing(trace *tracing.Tracing, reportDiagnostic DiagnosticReporter) {
+	var writeErr *tracing.WriteError
+	if err := trace.Stop(); errors.As(err, &writeErr) {
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, writeErr.FileName, writeErr.Err.Error()))
+	}
+}
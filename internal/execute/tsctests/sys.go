@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-json-experiment/json"
 	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/core"
@@ -142,6 +143,7 @@ type testSys struct {
 	currentWrite              *strings.Builder
 	programBaselines          strings.Builder
 	programIncludeBaselines   strings.Builder
+	watchBaselines            strings.Builder
 	tracer                    *harnessutil.TracerForBaselining
 	serializedDiff            *snapshot
 	forIncrementalCorrectness bool
@@ -357,6 +359,28 @@ func (s *testSys) OnProgram(program *incremental.Program) {
 	}
 }
 
+func (s *testSys) OnWatch(options *core.WatchOptions, files []string, directories map[string]bool) {
+	s.watchBaselines.Reset()
+	s.watchBaselines.WriteString("Watches::\n")
+	if options != nil {
+		if serialized := tsoptions.SerializeWatchOptions(options, func(path string) string { return path }); serialized.Size() != 0 {
+			data, err := json.Marshal(serialized)
+			if err != nil {
+				panic(err)
+			}
+			s.watchBaselines.WriteString("WatchOptions:: " + string(data) + "\n")
+		}
+	}
+	s.watchBaselines.WriteString("Files::\n")
+	for _, file := range slices.Sorted(slices.Values(files)) {
+		s.watchBaselines.WriteString(file + "\n")
+	}
+	s.watchBaselines.WriteString("Directories::\n")
+	for _, directory := range slices.Sorted(maps.Keys(directories)) {
+		s.watchBaselines.WriteString(directory + core.IfElse(directories[directory], " *recursive*", "") + "\n")
+	}
+}
+
 func (s *testSys) baselinePrograms(baseline *strings.Builder, header string) string {
 	baseline.WriteString(s.programBaselines.String())
 	s.programBaselines.Reset()
@@ -373,8 +397,8 @@ func (s *testSys) baselinePrograms(baseline *strings.Builder, header string) str
 func (s *testSys) serializeState(baseline *strings.Builder) {
 	s.baselineOutput(baseline)
 	s.baselineFSwithDiff(baseline)
-	// todo watch
-	// this.serializeWatches(baseline);
+	baseline.WriteString(s.watchBaselines.String())
+	s.watchBaselines.Reset()
 	// this.timeoutCallbacks.serialize(baseline);
 	// this.immediateCallbacks.serialize(baseline);
 	// this.pendingInstalls.serialize(baseline);
@@ -44,6 +44,132 @@ func TestTscCommandline(t *testing.T) {
 			subScenario:     "help all",
 			commandLineArgs: []string{"--help", "--all"},
 		},
+		{
+			subScenario:     "init",
+			commandLineArgs: []string{"--init"},
+		},
+		{
+			subScenario: "init with options and files",
+			files: FileMap{
+				"/home/src/workspaces/project/src/index.ts": `export const a = 1`,
+			},
+			commandLineArgs: []string{"--init", "--target", "esnext", "--lib", "es2017,dom", "--outDir", "dist", "--noUnusedLocals", "--strict", "false", "--types", "node", "src/index.ts"},
+		},
+		{
+			subScenario: "init when tsconfig exists",
+			files: FileMap{
+				"/home/src/workspaces/project/tsconfig.json": `{}`,
+			},
+			commandLineArgs: []string{"--init"},
+		},
+		{
+			subScenario: "showConfig",
+			files: FileMap{
+				"/home/src/workspaces/project/tsconfig.base.json": stringtestutil.Dedent(`
+				{
+					"compilerOptions": {
+						"target": "es2020",
+						"module": "nodenext",
+						"lib": ["es2020", "dom"],
+						"strict": true,
+						"typeRoots": ["./typings"]
+					},
+					"watchOptions": {
+						"watchFile": "fixedPollingInterval",
+						"excludeDirectories": ["./node_modules"]
+					}
+				}`),
+				"/home/src/workspaces/project/tsconfig.json": stringtestutil.Dedent(`
+				{
+					"extends": "./tsconfig.base.json",
+					"compileOnSave": true,
+					"compilerOptions": {
+						"jsx": "react-jsx",
+						"outDir": "./dist",
+						"rootDir": "./src",
+						"noUnusedLocals": false
+					},
+					"include": ["src"],
+					"exclude": ["src/**/*.test.ts"],
+					"references": [{ "path": "./shared" }]
+				}`),
+				"/home/src/workspaces/project/src/index.ts":         `export const a = 1;`,
+				"/home/src/workspaces/project/src/app.tsx":          `export const b = 2;`,
+				"/home/src/workspaces/project/src/app.test.ts":      `export const c = 3;`,
+				"/home/src/workspaces/project/shared/tsconfig.json": `{ "compilerOptions": { "composite": true } }`,
+				"/home/src/workspaces/project/shared/index.ts":      `export const d = 4;`,
+			},
+			commandLineArgs: []string{"--showConfig", "--listFiles", "--declaration"},
+		},
+		{
+			subScenario: "showConfig with files on command line",
+			files: FileMap{
+				"/home/src/workspaces/project/src/index.ts": `export const a = 1;`,
+			},
+			commandLineArgs: []string{"--showConfig", "--target", "esnext", "--moduleResolution", "bundler", "--module", "preserve", "--outDir", "out", "src/index.ts"},
+		},
+		{
+			subScenario: "showConfig with compileOnSave from extends",
+			files: FileMap{
+				"/home/src/workspaces/project/tsconfig.base.json": `{ "compileOnSave": true, "compilerOptions": { "strict": true } }`,
+				"/home/src/workspaces/project/tsconfig.json":      `{ "extends": "./tsconfig.base.json", "include": ["src"] }`,
+				"/home/src/workspaces/project/src/index.ts":       `export const a = 1;`,
+			},
+			commandLineArgs: []string{"--showConfig"},
+		},
+		{
+			subScenario: "showConfig when config has errors",
+			files: FileMap{
+				"/home/src/workspaces/project/tsconfig.json": `{ "compilerOptions": { "target": "es2020" }, "include": ["src"] }`,
+			},
+			commandLineArgs: []string{"--showConfig"},
+		},
+		{
+			subScenario: "generateTrace",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": stringtestutil.Dedent(`
+				type Box<T> = { value: T };
+				export const box: Box<string> = { value: "hello" };
+				export const value = box.value;`),
+				"/home/src/workspaces/project/tsconfig.json": `{ "compilerOptions": { "declaration": true } }`,
+			},
+			commandLineArgs: []string{"--generateTrace", "trace", "--singleThreaded"},
+		},
+		{
+			subScenario: "locale",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": stringtestutil.Dedent(`
+				const a: number = "hello";
+				missing();`),
+				"/home/src/workspaces/project/tsconfig.json": `{}`,
+			},
+			commandLineArgs: []string{"--locale", "ja"},
+		},
+		{
+			subScenario: "locale without pretty",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": stringtestutil.Dedent(`
+				const a: number = "hello";
+				missing();`),
+				"/home/src/workspaces/project/tsconfig.json": `{}`,
+			},
+			commandLineArgs: []string{"--locale", "pt-BR", "--pretty", "false"},
+		},
+		{
+			subScenario:     "locale with unsupported language",
+			files:           FileMap{"/home/src/workspaces/project/index.ts": `const a: number = "hello";`},
+			commandLineArgs: []string{"--locale", "xx", "index.ts"},
+		},
+		{
+			subScenario:     "locale is invalid",
+			files:           FileMap{"/home/src/workspaces/project/index.ts": `export const a = 1;`},
+			commandLineArgs: []string{"--locale", "en-US-x", "index.ts"},
+		},
+		{
+			subScenario:     "help with locale",
+			files:           FileMap{},
+			commandLineArgs: []string{"--help", "--locale", "ja"},
+		},
 		{
 			subScenario:     "Parse --lib option with file name",
 			files:           FileMap{"/home/src/workspaces/project/first.ts": `export const Key = Symbol()`},
@@ -148,6 +148,21 @@ func TestBuildCommandLine(t *testing.T) {
 				files:           FileMap{},
 				commandLineArgs: []string{"--build", "--help"},
 			},
+			{
+				subScenario: "generateTrace",
+				files: FileMap{
+					"/home/src/workspaces/solution/shared/index.ts":      `export const a = 1;`,
+					"/home/src/workspaces/solution/shared/tsconfig.json": `{ "compilerOptions": { "composite": true } }`,
+					"/home/src/workspaces/solution/app/index.ts":         `import { a } from "../shared/index.js"; export const b = a;`,
+					"/home/src/workspaces/solution/app/tsconfig.json": stringtestutil.Dedent(`
+					{
+						"compilerOptions": { "composite": true },
+						"references": [{ "path": "../shared" }]
+					}`),
+				},
+				cwd:             "/home/src/workspaces/solution",
+				commandLineArgs: []string{"--build", "app", "--generateTrace", "trace", "--singleThreaded"},
+			},
 			{
 				subScenario:     "different options",
 				files:           getBuildCommandLineDifferentOptionsMap("composite"),
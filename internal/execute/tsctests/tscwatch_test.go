@@ -3,6 +3,8 @@ package tsctests
 import (
 	"strings"
 	"testing"
+
+	"github.com/microsoft/typescript-go/internal/testutil/stringtestutil"
 )
 
 func TestWatch(t *testing.T) {
@@ -23,6 +25,52 @@ func TestWatch(t *testing.T) {
 			},
 			commandLineArgs: []string{"--watch", "--incremental"},
 		},
+		{
+			subScenario: "watch options from command line",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": "",
+				"/home/src/workspaces/project/tsconfig.json": stringtestutil.Dedent(`
+				{
+					"watchOptions": { "watchDirectory": "fixedPollingInterval" }
+				}`),
+			},
+			commandLineArgs: []string{"--watch", "--watchFile", "fixedPollingInterval"},
+			edits: []*tscEdit{
+				newTscEdit("change config", func(sys *testSys) {
+					sys.writeFileNoError("/home/src/workspaces/project/tsconfig.json", stringtestutil.Dedent(`
+					{
+						"compilerOptions": { "strict": true },
+						"watchOptions": { "watchDirectory": "fixedPollingInterval" }
+					}`), false)
+				}),
+			},
+		},
+		{
+			subScenario: "watch failed module resolution locations",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts":      `import { pkg } from "pkg";`,
+				"/home/src/workspaces/project/tsconfig.json": `{ "compilerOptions": { "noEmit": true }, "files": ["index.ts"] }`,
+			},
+			commandLineArgs: []string{"--watch"},
+			edits: []*tscEdit{
+				newTscEdit("add package", func(sys *testSys) {
+					sys.writeFileNoError("/home/src/workspaces/project/node_modules/pkg/index.d.ts", `export const pkg: string;`, false)
+				}),
+			},
+		},
+		{
+			subScenario: "watch with generateTrace",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts":      `export const a: number = 1;`,
+				"/home/src/workspaces/project/tsconfig.json": "{}",
+			},
+			commandLineArgs: []string{"--watch", "--generateTrace", "trace", "--singleThreaded"},
+			edits: []*tscEdit{
+				newTscEdit("change file", func(sys *testSys) {
+					sys.writeFileNoError("/home/src/workspaces/project/index.ts", `export const a: string = "";`, false)
+				}),
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -2,45 +2,69 @@ package execute
 
 import (
 	"fmt"
+	"maps"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/execute/incremental"
 	"github.com/microsoft/typescript-go/internal/execute/tsc"
+	"github.com/microsoft/typescript-go/internal/module"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
 )
 
 type Watcher struct {
-	sys                tsc.System
-	configFileName     string
-	config             *tsoptions.ParsedCommandLine
-	reportDiagnostic   tsc.DiagnosticReporter
-	reportErrorSummary tsc.DiagnosticsReporter
-	testing            tsc.CommandLineTesting
+	sys                     tsc.System
+	configFileName          string
+	config                  *tsoptions.ParsedCommandLine
+	commandLineWatchOptions *core.WatchOptions
+	reportDiagnostic        tsc.DiagnosticReporter
+	reportErrorSummary      tsc.DiagnosticsReporter
+	testing                 tsc.CommandLineTesting
 
 	host           compiler.CompilerHost
 	program        *incremental.Program
 	prevModified   map[string]time.Time
 	configModified bool
+
+	fileWatcher  vfswatch.Watcher
+	watchOptions *core.WatchOptions
+	// changedPaths are the paths reported changed since the last cycle, or nil if any file may
+	// have changed.
+	changedPaths *collections.Set[string]
+
+	// traceDir is the `--generateTrace` directory, kept from the initial config since the config
+	// is reparsed without the command line options on each cycle.
+	traceDir       string
+	tracingSession *tracing.Session
 }
 
 var _ tsc.Watcher = (*Watcher)(nil)
 
-func createWatcher(sys tsc.System, configParseResult *tsoptions.ParsedCommandLine, reportDiagnostic tsc.DiagnosticReporter, reportErrorSummary tsc.DiagnosticsReporter, testing tsc.CommandLineTesting) *Watcher {
+func createWatcher(sys tsc.System, configParseResult *tsoptions.ParsedCommandLine, commandLineWatchOptions *core.WatchOptions, reportDiagnostic tsc.DiagnosticReporter, reportErrorSummary tsc.DiagnosticsReporter, testing tsc.CommandLineTesting) *Watcher {
 	w := &Watcher{
-		sys:                sys,
-		config:             configParseResult,
-		reportDiagnostic:   reportDiagnostic,
-		reportErrorSummary: reportErrorSummary,
-		testing:            testing,
+		sys:                     sys,
+		config:                  configParseResult,
+		commandLineWatchOptions: commandLineWatchOptions,
+		reportDiagnostic:        reportDiagnostic,
+		reportErrorSummary:      reportErrorSummary,
+		testing:                 testing,
 		// reportWatchStatus: createWatchStatusReporter(sys, configParseResult.CompilerOptions().Pretty),
 	}
 	if configParseResult.ConfigFile != nil {
 		w.configFileName = configParseResult.ConfigFile.SourceFile.FileName()
 	}
+	if w.traceDir = configParseResult.CompilerOptions().GenerateTrace; w.traceDir != "" {
+		w.tracingSession = tracing.NewSession(sys.FS(), sys.Now)
+	}
 	return w
 }
 
@@ -49,10 +73,9 @@ func (w *Watcher) start() {
 	w.program = incremental.ReadBuildInfoProgram(w.config, incremental.NewBuildInfoReader(w.host), w.host)
 
 	if w.testing == nil {
-		watchInterval := w.config.ParsedConfig.WatchOptions.WatchInterval()
 		for {
 			w.DoCycle()
-			time.Sleep(watchInterval)
+			w.waitForChanges()
 		}
 	} else {
 		// Initial compilation in test mode
@@ -65,13 +88,16 @@ func (w *Watcher) DoCycle() {
 
 	if w.hasErrorsInTsConfig() {
 		// these are unrecoverable errors--report them and do not build
+		w.updateWatches()
 		return
 	}
 	// updateProgram()
+	trace := tsc.StartTracing(w.sys, w.traceDir, w.configFileName, w.tracingSession)
 	w.program = incremental.NewProgram(compiler.NewProgram(compiler.ProgramOptions{
 		Config:           w.config,
 		Host:             w.host,
 		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+		Tracing:          trace,
 	}), w.program, nil, w.testing != nil)
 
 	if w.hasBeenModified(w.program.GetProgram()) {
@@ -83,9 +109,128 @@ func (w *Watcher) DoCycle() {
 		// print something???
 		// fmt.Fprintln(w.sys.Writer(), "no changes detected at ", w.sys.Now())
 	}
+	tsc.StopTracing(trace, w.reportDiagnostic)
 	if w.testing != nil {
 		w.testing.OnProgram(w.program)
 	}
+	w.updateWatches()
+}
+
+// updateWatches watches the files and directories that the program was built from, so that the
+// next cycle starts when one of them changes.
+func (w *Watcher) updateWatches() {
+	watchOptions := w.config.ParsedConfig.WatchOptions
+	files, directories := w.getWatchedPaths()
+	if w.testing != nil {
+		w.testing.OnWatch(watchOptions, files, directories)
+		return
+	}
+	if w.fileWatcher == nil || !reflect.DeepEqual(w.watchOptions, watchOptions) {
+		if w.fileWatcher != nil {
+			_ = w.fileWatcher.Close()
+		}
+		w.fileWatcher = vfswatch.New(w.sys.FS(), watchOptions, w.getProjectDirectory())
+		w.watchOptions = watchOptions
+	}
+	w.fileWatcher.Update(files, directories)
+}
+
+// getWatchedPaths returns the files to watch, and the directories to watch mapped to whether they
+// are watched recursively.
+func (w *Watcher) getWatchedPaths() (files []string, directories map[string]bool) {
+	if w.configFileName != "" {
+		files = append(files, w.configFileName)
+		files = append(files, w.config.ExtendedSourceFiles()...)
+	}
+	// Until a program has been built, such as when the config file has errors from the start, only
+	// the config files are watched, since fixing them is what starts the next cycle.
+	if w.program == nil || !w.program.HasProgram() {
+		return files, nil
+	}
+	program := w.program.GetProgram()
+	for _, sourceFile := range program.SourceFiles() {
+		// Embedded libraries never change.
+		if bundled.Embedded && strings.HasPrefix(sourceFile.FileName(), bundled.LibPath()+"/") {
+			continue
+		}
+		files = append(files, sourceFile.FileName())
+	}
+	if w.configFileName != "" {
+		// Watch the directories matched by "include" so that new files are picked up.
+		directories = maps.Clone(w.config.WildcardDirectories())
+	}
+	if directories == nil {
+		directories = make(map[string]bool)
+	}
+	// Watch where modules that failed to resolve were looked for, so that they are picked up once
+	// they are added.
+	directoryExists := make(map[string]bool)
+	addFailedLookupLocations := func(locations *module.LookupLocations) {
+		for _, location := range locations.FailedLookupLocations {
+			if directory, ok := w.getFailedLookupDirectory(location, directoryExists); ok {
+				if _, ok := directories[directory]; !ok {
+					directories[directory] = false
+				}
+			}
+		}
+	}
+	for _, resolutions := range program.GetResolvedModules() {
+		for _, resolution := range resolutions {
+			addFailedLookupLocations(resolution.GetLookupLocations())
+		}
+	}
+	for _, resolutions := range program.GetResolvedTypeReferenceDirectives() {
+		for _, resolution := range resolutions {
+			addFailedLookupLocations(resolution.GetLookupLocations())
+		}
+	}
+	return files, directories
+}
+
+// getFailedLookupDirectory returns the directory to watch for a location where a module was
+// looked for but not found: the closest existing directory, whose entries change once the module,
+// or the first missing directory on the way to it, is added. As in TypeScript, directories above
+// the project are only watched within node_modules, so that lookups in every parent directory do
+// not watch the root of the file system.
+func (w *Watcher) getFailedLookupDirectory(location string, directoryExists map[string]bool) (string, bool) {
+	comparePathsOptions := tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: w.sys.FS().UseCaseSensitiveFileNames(),
+		CurrentDirectory:          w.sys.GetCurrentDirectory(),
+	}
+	directory := tspath.GetDirectoryPath(location)
+	for {
+		exists, ok := directoryExists[directory]
+		if !ok {
+			exists = w.sys.FS().DirectoryExists(directory)
+			directoryExists[directory] = exists
+		}
+		if exists {
+			return directory, strings.Contains(directory, "/node_modules") || tspath.ContainsPath(w.getProjectDirectory(), directory, comparePathsOptions)
+		}
+		parent := tspath.GetDirectoryPath(directory)
+		if parent == directory {
+			return "", false
+		}
+		directory = parent
+	}
+}
+
+// getProjectDirectory returns the directory of the config file, or the current directory if there
+// is none.
+func (w *Watcher) getProjectDirectory() string {
+	if w.configFileName != "" {
+		return tspath.GetDirectoryPath(w.configFileName)
+	}
+	return w.sys.GetCurrentDirectory()
+}
+
+// waitForChanges blocks until a file or directory that the program was built from changes.
+func (w *Watcher) waitForChanges() {
+	if changed := w.fileWatcher.Wait(); changed != nil {
+		w.changedPaths = collections.NewSetFromItems(changed...)
+	} else {
+		w.changedPaths = nil
+	}
 }
 
 func (w *Watcher) compileAndEmit() {
@@ -120,6 +265,8 @@ func (w *Watcher) hasErrorsInTsConfig() bool {
 			// fmt.Fprintln(w.sys.Writer(), "build triggered due to config change")
 			w.configModified = true
 		}
+		// Watch options given on the command line take precedence over those of the config file.
+		configParseResult.ParsedConfig.WatchOptions = tsoptions.MergeWatchOptions(configParseResult.ParsedConfig.WatchOptions, w.commandLineWatchOptions)
 		w.config = configParseResult
 	}
 	w.host = compiler.NewCompilerHost(w.sys.GetCurrentDirectory(), w.sys.FS(), w.sys.DefaultLibraryPath(), extendedConfigCache, getTraceFromSys(w.sys, w.testing))
@@ -132,13 +279,18 @@ func (w *Watcher) hasBeenModified(program *compiler.Program) bool {
 	filesModified := w.configModified
 	for _, sourceFile := range program.SourceFiles() {
 		fileName := sourceFile.FileName()
-		s := w.sys.FS().Stat(fileName)
-		if s == nil {
-			// do nothing; if file is in program.SourceFiles() but is not found when calling Stat, file has been very recently deleted.
-			// deleted files are handled outside of this loop
-			continue
+		modTime, ok := w.prevModified[fileName]
+		// Only files that are new to the program or were reported changed need to be checked.
+		if !ok || w.changedPaths == nil || w.changedPaths.Has(fileName) {
+			s := w.sys.FS().Stat(fileName)
+			if s == nil {
+				// do nothing; if file is in program.SourceFiles() but is not found when calling Stat, file has been very recently deleted.
+				// deleted files are handled outside of this loop
+				continue
+			}
+			modTime = s.ModTime()
 		}
-		currState[fileName] = s.ModTime()
+		currState[fileName] = modTime
 		if !filesModified {
 			if currState[fileName] != w.prevModified[fileName] {
 				// fmt.Fprint(w.sys.Writer(), "build triggered from ", fileName, ": ", w.prevModified[fileName], " -> ", currState[fileName], "\n")
@@ -156,5 +308,6 @@ func (w *Watcher) hasBeenModified(program *compiler.Program) bool {
 
 	// reset state for next cycle
 	w.configModified = false
+	w.changedPaths = nil
 	return filesModified
 }
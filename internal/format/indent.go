@@ -338,12 +338,15 @@ func getVisualListRange(node *ast.Node, list core.TextRange, sourceFile *ast.Sou
 	} else {
 		priorEnd = prior.End()
 	}
-	next := astnav.FindNextToken(prior, node, sourceFile)
+	var next *ast.Node
+	if lastInList := astnav.FindPrecedingToken(sourceFile, list.End()); lastInList != nil {
+		next = astnav.FindNextToken(lastInList, node, sourceFile)
+	}
 	var nextStart int
 	if next == nil {
 		nextStart = list.End()
 	} else {
-		nextStart = next.Pos()
+		nextStart = scanner.GetTokenPosOfNode(next, sourceFile, false)
 	}
 	return core.NewTextRange(priorEnd, nextStart)
 }
@@ -856,7 +856,7 @@ func isComment(kind ast.Kind) bool {
 }
 
 func (w *formatSpanWorker) insertIndentation(pos int, indentation int, lineAdded bool) {
-	indentationString := getIndentationString(indentation, w.formattingContext.Options)
+	indentationString := GetIndentationString(indentation, w.formattingContext.Options)
 	if lineAdded {
 		// new line is added before the token by the formatting rules
 		// insert indentation string at the very beginning of the token
@@ -958,7 +958,7 @@ func (w *formatSpanWorker) indentMultilineComment(commentRange core.TextRange, i
 		}
 		newIndentation := nonWhitespaceColumn + delta
 		if newIndentation > 0 {
-			indentationString := getIndentationString(newIndentation, w.formattingContext.Options)
+			indentationString := GetIndentationString(newIndentation, w.formattingContext.Options)
 			w.recordReplace(startLinePos, nonWhitespaceCharacter, indentationString)
 		} else {
 			w.recordDelete(startLinePos, nonWhitespaceCharacter)
@@ -968,7 +968,7 @@ func (w *formatSpanWorker) indentMultilineComment(commentRange core.TextRange, i
 	}
 }
 
-func getIndentationString(indentation int, options *FormatCodeSettings) string {
+func GetIndentationString(indentation int, options *FormatCodeSettings) string {
 	// go's `strings.Repeat` already has static, global caching for repeated tabs and spaces, so there's no need to cache here like in strada
 	if !options.ConvertTabsToSpaces {
 		tabs := int(math.Floor(float64(indentation) / float64(options.TabSize)))
@@ -48,6 +48,9 @@ type FourslashTest struct {
 	lastKnownMarkerName  *string
 	activeFilename       string
 	selectionEnd         *lsproto.Position
+
+	// workspaceDiagnosticResultIDs are the result IDs of the last workspace/diagnostic request.
+	workspaceDiagnosticResultIDs map[lsproto.DocumentUri]string
 }
 
 type scriptInfo struct {
@@ -2134,6 +2137,503 @@ func (f *FourslashTest) VerifyRenameFailed(t *testing.T, preferences *ls.UserPre
 	}
 }
 
+func (f *FourslashTest) prepareRename(t *testing.T) *lsproto.ResponseMessage {
+	params := &lsproto.PrepareRenameParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Position: f.currentCaretPosition,
+	}
+	resMsg, _, _ := sendRequest(t, f, lsproto.TextDocumentPrepareRenameInfo, params)
+	if resMsg == nil {
+		t.Fatal(f.getCurrentPositionPrefix() + "Nil response received for prepare rename request")
+	}
+	return resMsg.AsResponse()
+}
+
+// VerifyPrepareRenameSucceeded checks that the element at the caret can be renamed, and that the
+// range offered for renaming contains the given placeholder text.
+func (f *FourslashTest) VerifyPrepareRenameSucceeded(t *testing.T, placeholder string) {
+	prefix := f.getCurrentPositionPrefix()
+	resp := f.prepareRename(t)
+	result, ok := resp.Result.(lsproto.PrepareRenameResponse)
+	if !ok || result.PrepareRenamePlaceholder == nil {
+		t.Fatalf(prefix+"Unexpected prepare rename response: %T (error: %v)", resp.Result, resp.Error)
+	}
+	assert.Equal(t, result.PrepareRenamePlaceholder.Placeholder, placeholder, prefix+"Placeholder did not match")
+	rangeTexts := f.getRangeTexts(ls.FileNameToDocumentURI(f.activeFilename), []lsproto.Range{result.PrepareRenamePlaceholder.Range})
+	assert.Equal(t, rangeTexts[0], placeholder, prefix+"Rename range did not match placeholder")
+}
+
+// VerifyPrepareRenameFailed checks that renaming the element at the caret is rejected with the given message.
+func (f *FourslashTest) VerifyPrepareRenameFailed(t *testing.T, message string) {
+	prefix := f.getCurrentPositionPrefix()
+	resp := f.prepareRename(t)
+	if resp.Error == nil {
+		t.Fatalf(prefix+"Expected prepare rename to fail, but got: %v", resp.Result)
+	}
+	assert.Equal(t, resp.Error.Code, lsproto.ErrRequestFailed.Code, prefix+"Error code did not match")
+	assert.Equal(t, resp.Error.Message, message, prefix+"Error message did not match")
+}
+
+// VerifyWillRenameFiles sends workspace/willRenameFiles for moving oldFileName to newFileName, applies
+// the returned edits, and checks the resulting contents of the given files.
+func (f *FourslashTest) VerifyWillRenameFiles(t *testing.T, oldFileName string, newFileName string, newFileContents map[string]string) {
+	params := &lsproto.RenameFilesParams{
+		Files: []*lsproto.FileRename{{
+			OldUri: string(ls.FileNameToDocumentURI(oldFileName)),
+			NewUri: string(ls.FileNameToDocumentURI(newFileName)),
+		}},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.WorkspaceWillRenameFilesInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for will rename files request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected will rename files response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	if result.WorkspaceEdit != nil && result.WorkspaceEdit.Changes != nil {
+		for uri := range *result.WorkspaceEdit.Changes {
+			if _, ok := newFileContents[uri.FileName()]; !ok {
+				t.Errorf("Unexpected edits to %s", uri.FileName())
+			}
+		}
+		f.applyWorkspaceEdit(t, result.WorkspaceEdit)
+	}
+	for fileName, content := range newFileContents {
+		assert.Equal(t, f.getScriptInfo(fileName).content, content, "File content after renaming "+oldFileName+" did not match for "+fileName)
+	}
+}
+
+type VerifyCodeFixOptions struct {
+	Description    string
+	NewFileContent string
+	// Index of the fix among the fixes with the same description, if there are several.
+	Index int
+}
+
+func (f *FourslashTest) getCodeFixes(t *testing.T) []*lsproto.CodeAction {
+	return f.getCodeActions(t, lsproto.CodeActionKindQuickFix)
+}
+
+func (f *FourslashTest) getCodeActions(t *testing.T, kind lsproto.CodeActionKind) []*lsproto.CodeAction {
+	script := f.getScriptInfo(f.activeFilename)
+	params := &lsproto.CodeActionParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Range: lsproto.Range{
+			Start: lsproto.Position{},
+			End:   f.converters.PositionToLineAndCharacter(script, core.TextPos(len(script.content))),
+		},
+		Context: &lsproto.CodeActionContext{
+			Only: &[]lsproto.CodeActionKind{kind},
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentCodeActionInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for code action request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected code action response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	var fixes []*lsproto.CodeAction
+	if result.CommandOrCodeActionArray != nil {
+		for _, action := range *result.CommandOrCodeActionArray {
+			if action.CodeAction != nil {
+				fixes = append(fixes, action.CodeAction)
+			}
+		}
+	}
+	return fixes
+}
+
+func (f *FourslashTest) VerifyCodeFix(t *testing.T, options VerifyCodeFixOptions) {
+	fixes := core.Filter(f.getCodeFixes(t), func(fix *lsproto.CodeAction) bool {
+		return fix.Title == options.Description
+	})
+	if options.Index >= len(fixes) {
+		t.Fatalf("Code fix '%s' (index %d) not found; available fixes: %v", options.Description, options.Index, core.Map(f.getCodeFixes(t), func(fix *lsproto.CodeAction) string { return fix.Title }))
+	}
+	fix := fixes[options.Index]
+	if fix.Edit == nil || fix.Edit.Changes == nil {
+		t.Fatalf("Code fix '%s' has no edits", options.Description)
+	}
+	f.applyWorkspaceEdit(t, fix.Edit)
+	assert.Equal(t, f.getScriptInfo(f.activeFilename).content, options.NewFileContent, "File content after applying code fix did not match expected content.")
+}
+
+func (f *FourslashTest) applyWorkspaceEdit(t *testing.T, edit *lsproto.WorkspaceEdit) {
+	activeFilename := f.activeFilename
+	for uri, edits := range *edit.Changes {
+		f.ensureActiveFile(t, uri.FileName())
+		f.applyTextEdits(t, edits)
+	}
+	f.ensureActiveFile(t, activeFilename)
+}
+
+// VerifyOrganizeImports applies the "source.organizeImports" code action to the active file,
+// if any, and checks the resulting file content.
+func (f *FourslashTest) VerifyOrganizeImports(t *testing.T, newFileContent string, preferences *ls.UserPreferences) {
+	if preferences != nil {
+		reset := f.ConfigureWithReset(t, preferences)
+		defer reset()
+	}
+	actions := f.getCodeActions(t, lsproto.CodeActionKindSourceOrganizeImports)
+	if len(actions) > 1 {
+		t.Fatalf("Expected at most one organize imports action, got %d", len(actions))
+	}
+	if len(actions) == 1 && actions[0].Edit != nil && actions[0].Edit.Changes != nil {
+		f.applyWorkspaceEdit(t, actions[0].Edit)
+	}
+	assert.Equal(t, f.getScriptInfo(f.activeFilename).content, newFileContent, "File content after organizing imports did not match expected content.")
+}
+
+func (f *FourslashTest) getInlayHints(t *testing.T) []*lsproto.InlayHint {
+	script := f.getScriptInfo(f.activeFilename)
+	params := &lsproto.InlayHintParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Range: lsproto.Range{
+			Start: lsproto.Position{},
+			End:   f.converters.PositionToLineAndCharacter(script, core.TextPos(len(script.content))),
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentInlayHintInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for inlay hint request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected inlay hint response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	if result.InlayHints == nil {
+		return nil
+	}
+	return *result.InlayHints
+}
+
+// VerifyInlayHints checks the inlay hints of the active file by rendering them into the file text,
+// the way an editor would display them, and comparing the result against `expectedContent`.
+func (f *FourslashTest) VerifyInlayHints(t *testing.T, expectedContent string, preferences *ls.UserPreferences) {
+	if preferences != nil {
+		reset := f.ConfigureWithReset(t, preferences)
+		defer reset()
+	}
+	script := f.getScriptInfo(f.activeFilename)
+	hints := f.getInlayHints(t)
+	type renderedHint struct {
+		pos  int
+		text string
+	}
+	rendered := make([]renderedHint, 0, len(hints))
+	for _, hint := range hints {
+		var text strings.Builder
+		if hint.PaddingLeft != nil && *hint.PaddingLeft {
+			text.WriteString(" ")
+		}
+		if hint.Label.String != nil {
+			text.WriteString(*hint.Label.String)
+		} else if hint.Label.InlayHintLabelParts != nil {
+			for _, part := range *hint.Label.InlayHintLabelParts {
+				text.WriteString(part.Value)
+			}
+		}
+		if hint.PaddingRight != nil && *hint.PaddingRight {
+			text.WriteString(" ")
+		}
+		pos := int(f.converters.LineAndCharacterToPosition(script, hint.Position))
+		rendered = append(rendered, renderedHint{pos: pos, text: text.String()})
+	}
+	slices.SortStableFunc(rendered, func(a, b renderedHint) int { return a.pos - b.pos })
+	var actual strings.Builder
+	lastPos := 0
+	for _, hint := range rendered {
+		actual.WriteString(script.content[lastPos:hint.pos])
+		actual.WriteString(hint.text)
+		lastPos = hint.pos
+	}
+	actual.WriteString(script.content[lastPos:])
+	assert.Equal(t, actual.String(), expectedContent, "File content with inlay hints did not match expected content.")
+}
+
+// SemanticToken is a decoded semantic token. Type is the token type followed by its modifiers,
+// separated by dots (e.g. "variable.declaration.readonly").
+type SemanticToken struct {
+	Type string
+	Text string
+}
+
+func (f *FourslashTest) VerifySemanticTokens(t *testing.T, expected []SemanticToken) {
+	params := &lsproto.SemanticTokensParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentSemanticTokensFullInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for semantic tokens request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected semantic tokens response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	assertDeepEqual(t, f.decodeSemanticTokens(t, result.SemanticTokens), expected, "Semantic tokens did not match")
+}
+
+func (f *FourslashTest) VerifySemanticTokensInRange(t *testing.T, r *RangeMarker, expected []SemanticToken) {
+	f.ensureActiveFile(t, r.FileName())
+	params := &lsproto.SemanticTokensRangeParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Range: r.LSRange,
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentSemanticTokensRangeInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for semantic tokens range request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected semantic tokens range response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	assertDeepEqual(t, f.decodeSemanticTokens(t, result.SemanticTokens), expected, "Semantic tokens in range did not match")
+}
+
+func (f *FourslashTest) decodeSemanticTokens(t *testing.T, tokens *lsproto.SemanticTokens) []SemanticToken {
+	if tokens == nil {
+		return nil
+	}
+	if len(tokens.Data)%5 != 0 {
+		t.Fatalf("Semantic tokens data length %d is not a multiple of 5", len(tokens.Data))
+	}
+	legend := ls.SemanticTokensLegend()
+	script := f.getScriptInfo(f.activeFilename)
+	var result []SemanticToken
+	var line, character uint32
+	for i := 0; i < len(tokens.Data); i += 5 {
+		deltaLine, deltaCharacter, length, tokenType, modifiers := tokens.Data[i], tokens.Data[i+1], tokens.Data[i+2], tokens.Data[i+3], tokens.Data[i+4]
+		if deltaLine != 0 {
+			character = 0
+		}
+		line += deltaLine
+		character += deltaCharacter
+		start := f.converters.LineAndCharacterToPosition(script, lsproto.Position{Line: line, Character: character})
+		end := f.converters.LineAndCharacterToPosition(script, lsproto.Position{Line: line, Character: character + length})
+		typeName := legend.TokenTypes[tokenType]
+		for bit, modifier := range legend.TokenModifiers {
+			if modifiers&(1<<bit) != 0 {
+				typeName += "." + modifier
+			}
+		}
+		result = append(result, SemanticToken{Type: typeName, Text: script.content[start:end]})
+	}
+	return result
+}
+
+// CallHierarchyCall describes an incoming or outgoing call by the name of the caller or callee
+// and the source text of each call site.
+type CallHierarchyCall struct {
+	Name       string
+	FromRanges []string
+}
+
+// VerifyCallHierarchy prepares the call hierarchy at the current caret position and checks the name
+// of the resolved item along with its incoming and outgoing calls.
+func (f *FourslashTest) VerifyCallHierarchy(t *testing.T, expectedName string, expectedIncoming []CallHierarchyCall, expectedOutgoing []CallHierarchyCall) {
+	params := &lsproto.CallHierarchyPrepareParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Position: f.currentCaretPosition,
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentPrepareCallHierarchyInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for prepare call hierarchy request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected prepare call hierarchy response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	if result.CallHierarchyItems == nil || len(*result.CallHierarchyItems) == 0 {
+		if expectedName != "" {
+			t.Fatalf("Expected call hierarchy item %q, but got none", expectedName)
+		}
+		return
+	}
+	item := (*result.CallHierarchyItems)[0]
+	assert.Equal(t, item.Name, expectedName, "Call hierarchy item name did not match")
+
+	incomingMsg, incoming, incomingOk := sendRequest(t, f, lsproto.CallHierarchyIncomingCallsInfo, &lsproto.CallHierarchyIncomingCallsParams{Item: item})
+	if incomingMsg == nil {
+		t.Fatal("Nil response received for incoming calls request")
+	}
+	if !incomingOk {
+		t.Fatalf("Unexpected incoming calls response type: %T (error: %v)", incomingMsg.AsResponse().Result, incomingMsg.AsResponse().Error)
+	}
+	var actualIncoming []CallHierarchyCall
+	if incoming.CallHierarchyIncomingCalls != nil {
+		actualIncoming = core.Map(*incoming.CallHierarchyIncomingCalls, func(call *lsproto.CallHierarchyIncomingCall) CallHierarchyCall {
+			return CallHierarchyCall{Name: call.From.Name, FromRanges: f.getRangeTexts(call.From.Uri, call.FromRanges)}
+		})
+	}
+	assertDeepEqual(t, actualIncoming, expectedIncoming, "Incoming calls did not match")
+
+	outgoingMsg, outgoing, outgoingOk := sendRequest(t, f, lsproto.CallHierarchyOutgoingCallsInfo, &lsproto.CallHierarchyOutgoingCallsParams{Item: item})
+	if outgoingMsg == nil {
+		t.Fatal("Nil response received for outgoing calls request")
+	}
+	if !outgoingOk {
+		t.Fatalf("Unexpected outgoing calls response type: %T (error: %v)", outgoingMsg.AsResponse().Result, outgoingMsg.AsResponse().Error)
+	}
+	var actualOutgoing []CallHierarchyCall
+	if outgoing.CallHierarchyOutgoingCalls != nil {
+		actualOutgoing = core.Map(*outgoing.CallHierarchyOutgoingCalls, func(call *lsproto.CallHierarchyOutgoingCall) CallHierarchyCall {
+			return CallHierarchyCall{Name: call.To.Name, FromRanges: f.getRangeTexts(item.Uri, call.FromRanges)}
+		})
+	}
+	assertDeepEqual(t, actualOutgoing, expectedOutgoing, "Outgoing calls did not match")
+}
+
+// VerifyTypeHierarchy prepares the type hierarchy at the current caret position and checks the name
+// of the resolved item along with the names of its direct supertypes and subtypes.
+func (f *FourslashTest) VerifyTypeHierarchy(t *testing.T, expectedName string, expectedSupertypes []string, expectedSubtypes []string) {
+	params := &lsproto.TypeHierarchyPrepareParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Position: f.currentCaretPosition,
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentPrepareTypeHierarchyInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for prepare type hierarchy request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected prepare type hierarchy response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	if result.TypeHierarchyItems == nil || len(*result.TypeHierarchyItems) == 0 {
+		if expectedName != "" {
+			t.Fatalf("Expected type hierarchy item %q, but got none", expectedName)
+		}
+		return
+	}
+	item := (*result.TypeHierarchyItems)[0]
+	assert.Equal(t, item.Name, expectedName, "Type hierarchy item name did not match")
+
+	getName := func(item *lsproto.TypeHierarchyItem) string { return item.Name }
+
+	supertypesMsg, supertypes, supertypesOk := sendRequest(t, f, lsproto.TypeHierarchySupertypesInfo, &lsproto.TypeHierarchySupertypesParams{Item: item})
+	if supertypesMsg == nil {
+		t.Fatal("Nil response received for supertypes request")
+	}
+	if !supertypesOk {
+		t.Fatalf("Unexpected supertypes response type: %T (error: %v)", supertypesMsg.AsResponse().Result, supertypesMsg.AsResponse().Error)
+	}
+	var actualSupertypes []string
+	if supertypes.TypeHierarchyItems != nil && len(*supertypes.TypeHierarchyItems) != 0 {
+		actualSupertypes = core.Map(*supertypes.TypeHierarchyItems, getName)
+	}
+	assertDeepEqual(t, actualSupertypes, expectedSupertypes, "Supertypes did not match")
+
+	subtypesMsg, subtypes, subtypesOk := sendRequest(t, f, lsproto.TypeHierarchySubtypesInfo, &lsproto.TypeHierarchySubtypesParams{Item: item})
+	if subtypesMsg == nil {
+		t.Fatal("Nil response received for subtypes request")
+	}
+	if !subtypesOk {
+		t.Fatalf("Unexpected subtypes response type: %T (error: %v)", subtypesMsg.AsResponse().Result, subtypesMsg.AsResponse().Error)
+	}
+	var actualSubtypes []string
+	if subtypes.TypeHierarchyItems != nil && len(*subtypes.TypeHierarchyItems) != 0 {
+		actualSubtypes = core.Map(*subtypes.TypeHierarchyItems, getName)
+	}
+	assertDeepEqual(t, actualSubtypes, expectedSubtypes, "Subtypes did not match")
+}
+
+// FoldingRange describes an expected folding range by its zero-based lines. Kind is empty for code.
+type FoldingRange struct {
+	StartLine uint32
+	EndLine   uint32
+	Kind      lsproto.FoldingRangeKind
+}
+
+func (f *FourslashTest) VerifyFoldingRanges(t *testing.T, expected []FoldingRange) {
+	params := &lsproto.FoldingRangeParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentFoldingRangeInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for folding range request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected folding range response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	var actual []FoldingRange
+	if result.FoldingRanges != nil {
+		for _, foldingRange := range *result.FoldingRanges {
+			var kind lsproto.FoldingRangeKind
+			if foldingRange.Kind != nil {
+				kind = *foldingRange.Kind
+			}
+			actual = append(actual, FoldingRange{StartLine: foldingRange.StartLine, EndLine: foldingRange.EndLine, Kind: kind})
+		}
+	}
+	assertDeepEqual(t, actual, expected, "Folding ranges did not match")
+}
+
+// WorkspaceDiagnosticReport is the expected workspace/diagnostic report for a file: either the messages of
+// its diagnostics, or Unchanged if they did not change since the previous workspace/diagnostic request.
+type WorkspaceDiagnosticReport struct {
+	Messages  []string
+	Unchanged bool
+}
+
+// VerifyWorkspaceDiagnostics sends workspace/diagnostic with the result IDs of the previous request, if any,
+// and checks the reports of every file in the workspace.
+func (f *FourslashTest) VerifyWorkspaceDiagnostics(t *testing.T, expected map[string]WorkspaceDiagnosticReport) {
+	params := &lsproto.WorkspaceDiagnosticParams{
+		PreviousResultIds: []lsproto.PreviousResultId{},
+	}
+	for uri, value := range f.workspaceDiagnosticResultIDs {
+		params.PreviousResultIds = append(params.PreviousResultIds, lsproto.PreviousResultId{Uri: uri, Value: value})
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.WorkspaceDiagnosticInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for workspace diagnostic request")
+	}
+	if !resultOk || result == nil {
+		t.Fatalf("Unexpected workspace diagnostic response type: %T (error: %v)", resMsg.AsResponse().Result, resMsg.AsResponse().Error)
+	}
+	f.workspaceDiagnosticResultIDs = make(map[lsproto.DocumentUri]string, len(result.Items))
+	actual := make(map[string]WorkspaceDiagnosticReport, len(result.Items))
+	for _, item := range result.Items {
+		if full := item.FullDocumentDiagnosticReport; full != nil {
+			if full.ResultId != nil {
+				f.workspaceDiagnosticResultIDs[full.Uri] = *full.ResultId
+			}
+			actual[full.Uri.FileName()] = WorkspaceDiagnosticReport{
+				Messages: core.Map(full.Items, func(d *lsproto.Diagnostic) string { return d.Message }),
+			}
+		} else if unchanged := item.UnchangedDocumentDiagnosticReport; unchanged != nil {
+			f.workspaceDiagnosticResultIDs[unchanged.Uri] = unchanged.ResultId
+			actual[unchanged.Uri.FileName()] = WorkspaceDiagnosticReport{Unchanged: true}
+		}
+	}
+	assertDeepEqual(t, actual, expected, "Workspace diagnostics did not match")
+}
+
+func (f *FourslashTest) getRangeTexts(uri lsproto.DocumentUri, ranges []lsproto.Range) []string {
+	script := f.getScriptInfo(uri.FileName())
+	return core.Map(ranges, func(r lsproto.Range) string {
+		start := f.converters.LineAndCharacterToPosition(script, r.Start)
+		end := f.converters.LineAndCharacterToPosition(script, r.End)
+		return script.content[start:end]
+	})
+}
+
+func (f *FourslashTest) VerifyCodeFixAvailable(t *testing.T, descriptions []string) {
+	actual := core.Map(f.getCodeFixes(t), func(fix *lsproto.CodeAction) string { return fix.Title })
+	assertDeepEqual(t, actual, descriptions, "Available code fixes did not match")
+}
+
 func (f *FourslashTest) VerifyBaselineRenameAtRangesWithText(
 	t *testing.T,
 	preferences *ls.UserPreferences,
@@ -0,0 +1,34 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	. "github.com/microsoft/typescript-go/internal/fourslash/tests/util"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestAutoImportSpecifierSortDetection(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @module: esnext
+// @Filename: /a.ts
+export const a = 0;
+export const B = 0;
+export const Cat = 0;
+// @Filename: /b.ts
+import { B, a } from "./a";
+
+Ca/**/`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "")
+	f.VerifyApplyCodeActionFromCompletion(t, PtrTo(""), &fourslash.ApplyCodeActionFromCompletionOptions{
+		Name:        "Cat",
+		Source:      "./a",
+		Description: "Update import from \"./a\"",
+		NewFileContent: PtrTo(`import { B, Cat, a } from "./a";
+
+Ca`),
+	})
+}
@@ -0,0 +1,85 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCallHierarchyFunction(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function foo() {
+    bar();
+}
+
+function /*marker*/bar() {
+    baz();
+    quxx();
+    baz();
+}
+
+function baz() {
+}
+
+function quxx() {
+}
+
+bar();`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "marker")
+	f.VerifyCallHierarchy(t, "bar",
+		[]fourslash.CallHierarchyCall{
+			{Name: "foo", FromRanges: []string{"bar"}},
+			{Name: "/callHierarchyFunction.ts", FromRanges: []string{"bar"}},
+		},
+		[]fourslash.CallHierarchyCall{
+			{Name: "baz", FromRanges: []string{"baz", "baz"}},
+			{Name: "quxx", FromRanges: []string{"quxx"}},
+		},
+	)
+}
+
+func TestCallHierarchyClassAndArrowFunction(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export class Widget {
+    render() {
+        return format(this.name());
+    }
+    name() {
+        return "widget";
+    }
+}
+
+export const /*marker*/format = (value: string) => value.trim();
+
+// @Filename: /b.ts
+import { Widget, format } from "./a";
+function /*main*/main() {
+    const w = new Widget();
+    format(w.render());
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "marker")
+	f.VerifyCallHierarchy(t, "format",
+		[]fourslash.CallHierarchyCall{
+			{Name: "render", FromRanges: []string{"format"}},
+			{Name: "main", FromRanges: []string{"format"}},
+		},
+		[]fourslash.CallHierarchyCall{
+			{Name: "trim", FromRanges: []string{"value.trim"}},
+		},
+	)
+	f.GoToMarker(t, "main")
+	f.VerifyCallHierarchy(t, "main",
+		nil,
+		[]fourslash.CallHierarchyCall{
+			{Name: "Widget", FromRanges: []string{"Widget"}},
+			{Name: "format", FromRanges: []string{"format"}},
+			{Name: "render", FromRanges: []string{"w.render"}},
+		},
+	)
+}
@@ -0,0 +1,139 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCodeFixImport(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export const someVar = 10;
+
+// @Filename: /b.ts
+someVar;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/b.ts")
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: `Add import from "./a"`,
+		NewFileContent: `import { someVar } from "./a";
+
+someVar;`,
+	})
+}
+
+func TestCodeFixAddMissingAwait(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `declare function getNumber(): Promise<number>;
+async function f() {
+    const x = getNumber() * 2;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Add 'await'",
+		NewFileContent: `declare function getNumber(): Promise<number>;
+async function f() {
+    const x = await getNumber() * 2;
+}`,
+	})
+}
+
+func TestCodeFixRemoveUnusedImport(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @noUnusedLocals: true
+// @Filename: /a.ts
+export const a = 1;
+export const b = 2;
+
+// @Filename: /b.ts
+import { a, b } from "./a";
+a;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/b.ts")
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Remove unused declaration for: 'b'",
+		NewFileContent: `import { a } from "./a";
+a;`,
+	})
+}
+
+func TestCodeFixRemoveUnusedVariable(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @noUnusedLocals: true
+function f() {
+    const x = 1;
+    return 0;
+}
+f;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Remove unused declaration for: 'x'",
+		NewFileContent: `function f() {
+    return 0;
+}
+f;`,
+	})
+}
+
+func TestCodeFixDeclareProperty(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `class C {
+    method() {
+        this.x = 1;
+    }
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Declare property 'x'",
+		NewFileContent: `class C {
+    x: number;
+    method() {
+        this.x = 1;
+    }
+}`,
+	})
+}
+
+func TestCodeFixAddMissingProperties(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `interface I {
+    a: number;
+    b: string;
+    c?: boolean;
+}
+const x: I = {};`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Add missing properties",
+		NewFileContent: `interface I {
+    a: number;
+    b: string;
+    c?: boolean;
+}
+const x: I = {
+    a: 0,
+    b: ""
+};`,
+	})
+}
+
+func TestCodeFixSpelling(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `const object = { property: 1 };
+object.proprety;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Change spelling to 'property'",
+		NewFileContent: `const object = { property: 1 };
+object.property;`,
+	})
+}
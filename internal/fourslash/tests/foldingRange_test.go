@@ -0,0 +1,80 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestFoldingRangeCodeAndComments(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `import { a } from "a";
+import { b } from "b";
+import {
+    c,
+} from "c";
+
+/**
+ * Docs.
+ */
+function foo(x: number) {
+    if (x) {
+        // first
+        // second
+        return [
+            1,
+            2,
+        ];
+    }
+    return {
+        y: x,
+    };
+}
+
+class C {
+    m() {
+    }
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyFoldingRanges(t, []fourslash.FoldingRange{
+		{StartLine: 0, EndLine: 4, Kind: lsproto.FoldingRangeKindImports},
+		{StartLine: 2, EndLine: 3},
+		{StartLine: 6, EndLine: 8, Kind: lsproto.FoldingRangeKindComment},
+		{StartLine: 9, EndLine: 20},
+		{StartLine: 10, EndLine: 16},
+		{StartLine: 11, EndLine: 12, Kind: lsproto.FoldingRangeKindComment},
+		{StartLine: 13, EndLine: 15},
+		{StartLine: 18, EndLine: 19},
+		{StartLine: 23, EndLine: 25},
+	})
+}
+
+func TestFoldingRangeRegionsAndJsx(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.tsx
+// #region Helpers
+const x = 1;
+/*
+// #region not a region
+*/
+// #endregion
+const el = (
+    <div>
+        <span
+            id="a"
+            title="b" />
+    </div>
+);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyFoldingRanges(t, []fourslash.FoldingRange{
+		{StartLine: 0, EndLine: 5, Kind: lsproto.FoldingRangeKindRegion},
+		{StartLine: 2, EndLine: 4, Kind: lsproto.FoldingRangeKindComment},
+		{StartLine: 6, EndLine: 11},
+		{StartLine: 7, EndLine: 10},
+		{StartLine: 9, EndLine: 10},
+	})
+}
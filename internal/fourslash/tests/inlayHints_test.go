@@ -0,0 +1,151 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestInlayHintsParameterNames(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function foo(a: number, b: string, ...rest: boolean[]) {}
+declare const b: string;
+declare const obj: { a: number };
+foo(1, "x", true, false);
+foo(obj.a, b);
+foo(/* a */ 2, (3 as any));
+new Date(2020);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyInlayHints(t, `function foo(a: number, b: string, ...rest: boolean[]) {}
+declare const b: string;
+declare const obj: { a: number };
+foo(a: 1, b: "x", ...rest: true, false);
+foo(obj.a, b);
+foo(/* a */ 2, b: (3 as any));
+new Date(value: 2020);`, &ls.UserPreferences{
+		IncludeInlayParameterNameHints: ls.IncludeInlayParameterNameHintsAll,
+	})
+}
+
+func TestInlayHintsParameterNamesLiterals(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function foo(a: number, b: string, c: boolean) {}
+declare const x: string;
+foo(1, x, true);
+foo(a, "x", undefined);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyInlayHints(t, `function foo(a: number, b: string, c: boolean) {}
+declare const x: string;
+foo(a: 1, x, c: true);
+foo(a, b: "x", c: undefined);`, &ls.UserPreferences{
+		IncludeInlayParameterNameHints:                        ls.IncludeInlayParameterNameHintsLiterals,
+		IncludeInlayParameterNameHintsWhenArgumentMatchesName: true,
+		InteractiveInlayHints:                                 true,
+	})
+}
+
+func TestInlayHintsVariableTypes(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `declare function make(): { x: number; y: string };
+const a = make();
+let b = [1, 2];
+const c = 123;
+const d: number = 1;
+const { x } = make();
+class Foo {}
+const foo = new Foo();
+const fn = (n: number) => n;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyInlayHints(t, `declare function make(): { x: number; y: string };
+const a : { x: number; y: string; } = make();
+let b : number[] = [1, 2];
+const c = 123;
+const d: number = 1;
+const { x } = make();
+class Foo {}
+const foo = new Foo();
+const fn : (n: number) => number = (n: number) => n;`, &ls.UserPreferences{
+		IncludeInlayVariableTypeHints: true,
+	})
+}
+
+func TestInlayHintsVariableTypesWhenTypeMatchesName(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `class Foo {}
+declare function getFoo(): Foo;
+const foo = getFoo();`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyInlayHints(t, content, &ls.UserPreferences{
+		IncludeInlayVariableTypeHints: true,
+	})
+	f.VerifyInlayHints(t, `class Foo {}
+declare function getFoo(): Foo;
+const foo : Foo = getFoo();`, &ls.UserPreferences{
+		IncludeInlayVariableTypeHints:                    true,
+		IncludeInlayVariableTypeHintsWhenTypeMatchesName: true,
+	})
+}
+
+func TestInlayHintsFunctionTypes(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function add(a: number, b: number) { return a + b; }
+declare function call(cb: (value: string, index?: number) => void): void;
+call((value, index) => {});
+call(v => {});
+function isString(x: unknown) { return typeof x === "string"; }
+class C {
+    get size() { return 1; }
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyInlayHints(t, `function add(a: number, b: number) : number { return a + b; }
+declare function call(cb: (value: string, index?: number) => void): void;
+call((value : string, index : number) : void => {});
+call(v : string => {});
+function isString(x: unknown) : x is string { return typeof x === "string"; }
+class C {
+    get size() : number { return 1; }
+}`, &ls.UserPreferences{
+		IncludeInlayFunctionParameterTypeHints:  true,
+		IncludeInlayFunctionLikeReturnTypeHints: true,
+	})
+}
+
+func TestInlayHintsPropertyDeclarationAndEnumMembers(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `class C {
+    a = 1;
+    b;
+    c: string = "";
+    constructor() { this.b = "x"; }
+}
+enum E {
+    A,
+    B = 5,
+    C,
+    D = "d",
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyInlayHints(t, `class C {
+    a : number = 1;
+    b;
+    c: string = "";
+    constructor() { this.b = "x"; }
+}
+enum E {
+    A = 0,
+    B = 5,
+    C = 6,
+    D = "d",
+}`, &ls.UserPreferences{
+		IncludeInlayPropertyDeclarationTypeHints: true,
+		IncludeInlayEnumMemberValueHints:         true,
+	})
+}
@@ -0,0 +1,123 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestOrganizeImportsSortAndCoalesce(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export const a = 1;
+export const b = 2;
+export default function d() {}
+
+// @Filename: /c.ts
+export const c = 3;
+
+// @Filename: /main.ts
+// Header comment
+import { c } from "./c";
+import { b } from "./a";
+import d, { a } from "./a";
+
+console.log(a, b, c, d);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/main.ts")
+	f.VerifyOrganizeImports(t, `// Header comment
+import d, { a, b } from "./a";
+import { c } from "./c";
+
+console.log(a, b, c, d);`, nil /*preferences*/)
+}
+
+func TestOrganizeImportsRemoveUnused(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export const a = 1;
+export const b = 2;
+
+// @Filename: /c.ts
+export const c = 3;
+
+// @Filename: /main.ts
+import "./polyfill";
+import { c } from "./c";
+import * as ns from "./a";
+import { a, b } from "./a";
+
+export { b };
+console.log(a);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/main.ts")
+	f.VerifyOrganizeImports(t, `import { a, b } from "./a";
+import "./polyfill";
+
+export { b };
+console.log(a);`, nil /*preferences*/)
+}
+
+func TestOrganizeImportsGroups(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export const a = 1;
+export const b = 2;
+
+// @Filename: /main.ts
+import { b } from "./a";
+import { a } from "./a";
+
+import { y } from "y";
+import { x } from "x";
+
+export { y };
+export { b, a } from "./a";
+
+console.log(a, b, x, y);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/main.ts")
+	f.VerifyOrganizeImports(t, `import { a, b } from "./a";
+
+import { x } from "x";
+import { y } from "y";
+
+export { y };
+export { a, b } from "./a";
+
+console.log(a, b, x, y);`, nil /*preferences*/)
+}
+
+func TestOrganizeImportsCollation(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /main.ts
+import { a10, a2, A1, b1 } from "m";
+console.log(a10, a2, A1, b1);`
+
+	// Ordinal collation compares code points, so upper case sorts before lower case.
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/main.ts")
+	f.VerifyOrganizeImports(t, `import { A1, a10, a2, b1 } from "m";
+console.log(a10, a2, A1, b1);`, &ls.UserPreferences{
+		OrganizeImportsIgnoreCase: core.TSFalse,
+	})
+
+	// Unicode collation with numeric ordering and lower case first.
+	f = fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/main.ts")
+	f.VerifyOrganizeImports(t, `import { A1, a2, a10, b1 } from "m";
+console.log(a10, a2, A1, b1);`, &ls.UserPreferences{
+		OrganizeImportsIgnoreCase:       core.TSFalse,
+		OrganizeImportsCollation:        ls.OrganizeImportsCollationUnicode,
+		OrganizeImportsNumericCollation: true,
+		OrganizeImportsAccentCollation:  true,
+		OrganizeImportsCaseFirst:        ls.OrganizeImportsCaseFirstUpper,
+	})
+}
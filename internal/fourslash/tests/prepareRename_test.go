@@ -0,0 +1,56 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestPrepareRename(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `interface Options {
+    "/*property*/mode": "fast" | "slow";
+}
+function /*function*/configure(options: Options) {
+    const mode: Options["mode"] = "/*literal*/fast";
+    /*keyword*/return options./*access*/mode === mode;
+}
+const s = "abc"./*library*/toUpperCase();
+/*label*/outer: for (;;) break outer;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "property")
+	f.VerifyPrepareRenameSucceeded(t, "mode")
+	f.GoToMarker(t, "function")
+	f.VerifyPrepareRenameSucceeded(t, "configure")
+	f.GoToMarker(t, "literal")
+	f.VerifyPrepareRenameSucceeded(t, "fast")
+	f.GoToMarker(t, "access")
+	f.VerifyPrepareRenameSucceeded(t, "mode")
+	f.GoToMarker(t, "label")
+	f.VerifyPrepareRenameSucceeded(t, "outer")
+	f.GoToMarker(t, "keyword")
+	f.VerifyPrepareRenameFailed(t, "You cannot rename this element.")
+	f.GoToMarker(t, "library")
+	f.VerifyPrepareRenameFailed(t, "You cannot rename elements that are defined in the standard TypeScript library.")
+	f.VerifyRenameFailed(t, nil /*preferences*/)
+}
+
+func TestPrepareRenameNodeModules(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /node_modules/foo/package.json
+{ "types": "index.d.ts" }
+// @Filename: /node_modules/foo/index.d.ts
+export declare function foo(): void;
+// @Filename: /a.ts
+import { /*import*/foo } from "/*specifier*/foo";
+/*call*/foo();`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "call")
+	f.VerifyPrepareRenameFailed(t, "You cannot rename elements that are defined in a 'node_modules' folder.")
+	f.VerifyRenameFailed(t, nil /*preferences*/)
+	f.GoToMarker(t, "specifier")
+	f.VerifyPrepareRenameFailed(t, "You cannot rename this element.")
+}
@@ -0,0 +1,78 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestSemanticTokensDeclarations(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `interface Shape<T> { readonly kind: T; }
+class Circle implements Shape<"circle"> {
+    readonly kind = "circle";
+    static count = 0;
+    async area(radius: number) { return Math.PI * radius; }
+}
+enum Color { Red }
+namespace NS { export const x = Color.Red; }
+type Alias = Circle;
+let counter = Circle.count;
+const make = () => new Circle();
+function run({ kind }: Circle) { make().area(1); }`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifySemanticTokens(t, []fourslash.SemanticToken{
+		{Type: "interface.declaration", Text: "Shape"},
+		{Type: "typeParameter.declaration", Text: "T"},
+		{Type: "property.declaration.readonly", Text: "kind"},
+		{Type: "typeParameter", Text: "T"},
+		{Type: "class.declaration", Text: "Circle"},
+		{Type: "interface", Text: "Shape"},
+		{Type: "property.declaration.readonly", Text: "kind"},
+		{Type: "property.declaration.static", Text: "count"},
+		{Type: "method.declaration.async", Text: "area"},
+		{Type: "parameter.declaration", Text: "radius"},
+		{Type: "variable.defaultLibrary", Text: "Math"},
+		{Type: "property.readonly.defaultLibrary", Text: "PI"},
+		{Type: "parameter", Text: "radius"},
+		{Type: "enum.declaration", Text: "Color"},
+		{Type: "enumMember.declaration.readonly", Text: "Red"},
+		{Type: "namespace.declaration", Text: "NS"},
+		{Type: "variable.declaration.readonly", Text: "x"},
+		{Type: "enum", Text: "Color"},
+		{Type: "enumMember.readonly", Text: "Red"},
+		{Type: "type.declaration", Text: "Alias"},
+		{Type: "class", Text: "Circle"},
+		{Type: "variable.declaration", Text: "counter"},
+		{Type: "class", Text: "Circle"},
+		{Type: "property.static", Text: "count"},
+		{Type: "function.declaration.readonly", Text: "make"},
+		{Type: "class", Text: "Circle"},
+		{Type: "function.declaration", Text: "run"},
+		{Type: "parameter.declaration", Text: "kind"},
+		{Type: "class", Text: "Circle"},
+		{Type: "function.readonly", Text: "make"},
+		{Type: "method.async", Text: "area"},
+	})
+}
+
+func TestSemanticTokensRange(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /fs.ts
+export function readFile() { return 1; }
+
+// @Filename: /main.ts
+import { readFile } from "./fs";
+const a = 1;
+[|let b = a + readFile();|]
+let c = b;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifySemanticTokensInRange(t, f.Ranges()[0], []fourslash.SemanticToken{
+		{Type: "variable.declaration", Text: "b"},
+		{Type: "variable.readonly", Text: "a"},
+		{Type: "function", Text: "readFile"},
+	})
+}
@@ -0,0 +1,51 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestTypeHierarchyClassesAndInterfaces(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /base.ts
+export interface Disposable { dispose(): void; }
+export interface Named { name: string; }
+export interface /*component*/Component extends Disposable, Named {}
+export abstract class /*base*/Base implements Component {
+    name = "base";
+    dispose() {}
+}
+
+// @Filename: /widgets.ts
+import { Base, Component } from "./base";
+export class /*button*/Button extends Base {}
+export class Label extends Base implements Component {}
+export class IconButton extends Button {}
+const value: Component = { name: "", dispose() {} };`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "component")
+	f.VerifyTypeHierarchy(t, "Component", []string{"Disposable", "Named"}, []string{"Base", "Label"})
+	f.GoToMarker(t, "base")
+	f.VerifyTypeHierarchy(t, "Base", []string{"Component"}, []string{"Button", "Label"})
+	f.GoToMarker(t, "button")
+	f.VerifyTypeHierarchy(t, "Button", []string{"Base"}, []string{"IconButton"})
+}
+
+func TestTypeHierarchyMergedAndMixinBases(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `class Point { x = 0; }
+function Tagged<T extends new (...args: any[]) => {}>(base: T) { return class extends base { tag = ""; }; }
+class /*tagged*/TaggedPoint extends Tagged(Point) {}
+interface Options { a: number; }
+interface Options { b: number; }
+class Settings implements /*options*/Options { a = 1; b = 2; }`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "tagged")
+	f.VerifyTypeHierarchy(t, "TaggedPoint", []string{"(Anonymous class)", "Point"}, nil)
+	f.GoToMarker(t, "options")
+	f.VerifyTypeHierarchy(t, "Options", nil, []string{"Settings"})
+}
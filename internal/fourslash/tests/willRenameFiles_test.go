@@ -0,0 +1,54 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestWillRenameFiles(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /src/old.ts
+import { helper } from "./helper";
+export const value = helper();
+// @Filename: /src/helper.ts
+export function helper() { return 1; }
+// @Filename: /src/user.ts
+import { value } from "./old";
+export { value as other } from './old';
+// @Filename: /lib/consumer.ts
+/// <reference path="../src/old.ts" />
+import * as old from "../src/old";
+declare module "ambient" {}
+import "ambient";`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyWillRenameFiles(t, "/src/old.ts", "/src/nested/new.ts", map[string]string{
+		"/src/old.ts": `import { helper } from "../helper";
+export const value = helper();`,
+		"/src/user.ts": `import { value } from "./nested/new";
+export { value as other } from './nested/new';`,
+		"/lib/consumer.ts": `/// <reference path="../src/nested/new.ts" />
+import * as old from "../src/nested/new";
+declare module "ambient" {}
+import "ambient";`,
+	})
+}
+
+func TestWillRenameFilesDirectory(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /src/utils/index.ts
+export * from "./strings";
+// @Filename: /src/utils/strings.ts
+export const upper = (s: string) => s.toUpperCase();
+// @Filename: /src/main.ts
+import { upper } from "./utils";
+import { upper as up } from "./utils/strings";`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyWillRenameFiles(t, "/src/utils", "/src/helpers", map[string]string{
+		"/src/main.ts": `import { upper } from "./helpers";
+import { upper as up } from "./helpers/strings";`,
+	})
+}
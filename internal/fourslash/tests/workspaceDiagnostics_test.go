@@ -0,0 +1,42 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestWorkspaceDiagnostics(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export const value: number/*type*/ = 1 as any;
+// @Filename: /b.ts
+import { value } from "./a";
+export const text: string = value;
+// @Filename: /c.ts
+let unused = missing;
+// @Filename: /tsconfig.json
+{ "compilerOptions": { "strict": true } }`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyWorkspaceDiagnostics(t, map[string]fourslash.WorkspaceDiagnosticReport{
+		"/a.ts": {Messages: []string{}},
+		"/b.ts": {Messages: []string{"Type 'number' is not assignable to type 'string'."}},
+		"/c.ts": {Messages: []string{"Cannot find name 'missing'."}},
+	})
+	f.VerifyWorkspaceDiagnostics(t, map[string]fourslash.WorkspaceDiagnosticReport{
+		"/a.ts": {Unchanged: true},
+		"/b.ts": {Unchanged: true},
+		"/c.ts": {Unchanged: true},
+	})
+	// Changing the type of `value` fixes the error in the closed file b.ts.
+	f.GoToMarker(t, "type")
+	f.Backspace(t, len("number"))
+	f.Insert(t, "string")
+	f.VerifyWorkspaceDiagnostics(t, map[string]fourslash.WorkspaceDiagnosticReport{
+		"/a.ts": {Unchanged: true},
+		"/b.ts": {Messages: []string{}},
+		"/c.ts": {Unchanged: true},
+	})
+}
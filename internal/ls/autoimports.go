@@ -1378,7 +1378,7 @@ func (l *LanguageService) codeActionForFixWorker(
 	switch fix.kind {
 	case ImportFixKindUseNamespace:
 		changeTracker.addNamespaceQualifier(sourceFile, fix.qualification())
-		return diagnostics.FormatMessage(diagnostics.Change_0_to_1, symbolName, `${fix.namespacePrefix}.${symbolName}`)
+		return diagnostics.FormatMessage(diagnostics.Change_0_to_1, symbolName, *fix.namespacePrefix+"."+symbolName)
 	case ImportFixKindJsdocTypeImport:
 		// !!! not implemented
 		// changeTracker.addImportType(changeTracker, sourceFile, fix, quotePreference);
@@ -0,0 +1,665 @@
+package ls
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// A call hierarchy declaration is one of: a source file, a module declaration with an identifier name,
+// a function declaration, a class declaration, a class static block, a method declaration or signature,
+// an accessor, a named function or class expression, or a function or class expression assigned to a
+// const variable or a property declaration.
+
+type callSite struct {
+	declaration *ast.Node
+	sourceFile  *ast.SourceFile
+	textRange   core.TextRange
+}
+
+func (l *LanguageService) ProvidePrepareCallHierarchy(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (lsproto.CallHierarchyPrepareResponse, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	node := astnav.GetTouchingPropertyName(file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	declarations := resolveCallHierarchyDeclaration(c, node)
+	if len(declarations) == 0 {
+		return lsproto.CallHierarchyItemsOrNull{}, nil
+	}
+	items := core.Map(declarations, func(declaration *ast.Node) *lsproto.CallHierarchyItem {
+		return l.createCallHierarchyItem(c, declaration)
+	})
+	return lsproto.CallHierarchyItemsOrNull{CallHierarchyItems: &items}, nil
+}
+
+func (l *LanguageService) ProvideCallHierarchyIncomingCalls(ctx context.Context, item *lsproto.CallHierarchyItem) (lsproto.CallHierarchyIncomingCallsResponse, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	declaration := l.resolveCallHierarchyItemDeclaration(c, file, item)
+	// Source files and modules have no incoming calls.
+	if declaration == nil || ast.IsSourceFile(declaration) || ast.IsModuleDeclaration(declaration) || ast.IsClassStaticBlockDeclaration(declaration) {
+		return lsproto.CallHierarchyIncomingCallsOrNull{}, nil
+	}
+
+	location := getCallHierarchyDeclarationReferenceNode(declaration)
+	options := refOptions{use: referenceUseReferences}
+	symbolsAndEntries := l.getReferencedSymbolsForNode(ctx, location.Pos(), location, program, program.GetSourceFiles(), options, nil)
+	if ctx.Err() != nil {
+		return lsproto.CallHierarchyIncomingCallsOrNull{}, ctx.Err()
+	}
+
+	var callSites []*callSite
+	for _, symbolAndEntries := range symbolsAndEntries {
+		for _, entry := range symbolAndEntries.references {
+			if site := convertEntryToCallSite(entry); site != nil {
+				callSites = append(callSites, site)
+			}
+		}
+	}
+	calls := core.Map(groupCallSites(callSites), func(group []*callSite) *lsproto.CallHierarchyIncomingCall {
+		return &lsproto.CallHierarchyIncomingCall{
+			From:       l.createCallHierarchyItem(c, group[0].declaration),
+			FromRanges: l.convertCallSiteRanges(group),
+		}
+	})
+	return lsproto.CallHierarchyIncomingCallsOrNull{CallHierarchyIncomingCalls: &calls}, nil
+}
+
+func (l *LanguageService) ProvideCallHierarchyOutgoingCalls(ctx context.Context, item *lsproto.CallHierarchyItem) (lsproto.CallHierarchyOutgoingCallsResponse, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	declaration := l.resolveCallHierarchyItemDeclaration(c, file, item)
+	if declaration == nil || declaration.Flags&ast.NodeFlagsAmbient != 0 || ast.IsMethodSignatureDeclaration(declaration) {
+		return lsproto.CallHierarchyOutgoingCallsOrNull{}, nil
+	}
+
+	collector := &callSiteCollector{checker: c}
+	collector.collectCallSites(declaration)
+	if ctx.Err() != nil {
+		return lsproto.CallHierarchyOutgoingCallsOrNull{}, ctx.Err()
+	}
+	calls := core.Map(groupCallSites(collector.callSites), func(group []*callSite) *lsproto.CallHierarchyOutgoingCall {
+		return &lsproto.CallHierarchyOutgoingCall{
+			To:         l.createCallHierarchyItem(c, group[0].declaration),
+			FromRanges: l.convertCallSiteRanges(group),
+		}
+	})
+	return lsproto.CallHierarchyOutgoingCallsOrNull{CallHierarchyOutgoingCalls: &calls}, nil
+}
+
+// resolveCallHierarchyItemDeclaration maps an item produced by an earlier request back to its declaration.
+func (l *LanguageService) resolveCallHierarchyItemDeclaration(c *checker.Checker, file *ast.SourceFile, item *lsproto.CallHierarchyItem) *ast.Node {
+	position := int(l.converters.LineAndCharacterToPosition(file, item.SelectionRange.Start))
+	var location *ast.Node
+	if position == 0 {
+		location = file.AsNode()
+	} else {
+		location = astnav.GetTouchingPropertyName(file, position)
+	}
+	declarations := resolveCallHierarchyDeclaration(c, location)
+	if len(declarations) == 0 {
+		return nil
+	}
+	return declarations[0]
+}
+
+func (l *LanguageService) convertCallSiteRanges(group []*callSite) []lsproto.Range {
+	return core.Map(group, func(site *callSite) lsproto.Range {
+		return *l.createLspRangeFromRange(site.textRange, site.sourceFile)
+	})
+}
+
+// groupCallSites groups call sites by their declaration, preserving the order in which each
+// declaration was first seen.
+func groupCallSites(callSites []*callSite) [][]*callSite {
+	var groups [][]*callSite
+	indexByDeclaration := make(map[*ast.Node]int)
+	for _, site := range callSites {
+		if index, ok := indexByDeclaration[site.declaration]; ok {
+			groups[index] = append(groups[index], site)
+		} else {
+			indexByDeclaration[site.declaration] = len(groups)
+			groups = append(groups, []*callSite{site})
+		}
+	}
+	return groups
+}
+
+func isNamedExpression(node *ast.Node) bool {
+	return (ast.IsClassExpression(node) || ast.IsFunctionExpression(node)) && node.Name() != nil
+}
+
+func isVariableLike(node *ast.Node) bool {
+	return ast.IsPropertyDeclaration(node) || ast.IsVariableDeclaration(node)
+}
+
+// isAssignedExpression reports whether node is a class expression, arrow function or function
+// expression assigned to a const variable or a property declaration.
+func isAssignedExpression(node *ast.Node) bool {
+	if !(ast.IsClassExpression(node) || ast.IsArrowFunction(node) || ast.IsFunctionExpression(node)) {
+		return false
+	}
+	parent := node.Parent
+	return isVariableLike(parent) &&
+		node == parent.Initializer() &&
+		ast.IsIdentifier(parent.Name()) &&
+		(ast.GetCombinedNodeFlags(parent)&ast.NodeFlagsConst != 0 || ast.IsPropertyDeclaration(parent))
+}
+
+func isPossibleCallHierarchyDeclaration(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindSourceFile, ast.KindModuleDeclaration, ast.KindFunctionDeclaration, ast.KindFunctionExpression,
+		ast.KindClassDeclaration, ast.KindClassExpression, ast.KindClassStaticBlockDeclaration, ast.KindMethodDeclaration,
+		ast.KindMethodSignature, ast.KindGetAccessor, ast.KindSetAccessor:
+		return true
+	}
+	return false
+}
+
+func isValidCallHierarchyDeclaration(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindSourceFile, ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindClassStaticBlockDeclaration,
+		ast.KindMethodDeclaration, ast.KindMethodSignature, ast.KindGetAccessor, ast.KindSetAccessor:
+		return true
+	case ast.KindModuleDeclaration:
+		return ast.IsIdentifier(node.Name())
+	}
+	return isNamedExpression(node) || isAssignedExpression(node)
+}
+
+// getCallHierarchyDeclarationReferenceNode gets the node that can be used as a reference to a call hierarchy declaration.
+func getCallHierarchyDeclarationReferenceNode(node *ast.Node) *ast.Node {
+	if ast.IsSourceFile(node) {
+		return node
+	}
+	if name := node.Name(); name != nil {
+		return name
+	}
+	if isAssignedExpression(node) {
+		return node.Parent.Name()
+	}
+	return findDefaultModifier(node)
+}
+
+func findDefaultModifier(node *ast.Node) *ast.Node {
+	return core.Find(node.ModifierNodes(), func(modifier *ast.Node) bool {
+		return modifier.Kind == ast.KindDefaultKeyword
+	})
+}
+
+func getSymbolOfCallHierarchyDeclaration(c *checker.Checker, node *ast.Node) *ast.Symbol {
+	if location := getCallHierarchyDeclarationReferenceNode(node); location != nil {
+		return c.GetSymbolAtLocation(location)
+	}
+	return nil
+}
+
+// getCallHierarchyItemName gets the text and range of the name of a call hierarchy declaration.
+func getCallHierarchyItemName(c *checker.Checker, node *ast.Node) (string, core.TextRange) {
+	if ast.IsSourceFile(node) {
+		return node.AsSourceFile().FileName(), core.NewTextRange(0, 0)
+	}
+	sourceFile := ast.GetSourceFileOfNode(node)
+	if (ast.IsFunctionDeclaration(node) || ast.IsClassDeclaration(node)) && node.Name() == nil {
+		if defaultModifier := findDefaultModifier(node); defaultModifier != nil {
+			return "default", createRangeFromNode(defaultModifier, sourceFile)
+		}
+	}
+	if ast.IsClassStaticBlockDeclaration(node) {
+		pos := scanner.SkipTrivia(sourceFile.Text(), node.Pos())
+		end := pos + len("static")
+		prefix := ""
+		if symbol := c.GetSymbolAtLocation(node.Parent); symbol != nil {
+			prefix = c.SymbolToString(symbol) + " "
+		}
+		return prefix + "static {}", core.NewTextRange(pos, end)
+	}
+
+	var declName *ast.Node
+	if isAssignedExpression(node) {
+		declName = node.Parent.Name()
+	} else {
+		declName = ast.GetNameOfDeclaration(node)
+	}
+	if declName == nil {
+		panic("Expected call hierarchy item to have a name")
+	}
+	var text string
+	switch {
+	case ast.IsIdentifier(declName), ast.IsStringOrNumericLiteralLike(declName):
+		text = declName.Text()
+	case ast.IsComputedPropertyName(declName) && ast.IsStringOrNumericLiteralLike(declName.Expression()):
+		text = declName.Expression().Text()
+	default:
+		if symbol := c.GetSymbolAtLocation(declName); symbol != nil {
+			text = c.SymbolToString(symbol)
+		} else {
+			text = scanner.GetTextOfNode(declName)
+		}
+	}
+	return text, createRangeFromNode(declName, sourceFile)
+}
+
+func getCallHierarchyItemContainerName(node *ast.Node) string {
+	if isAssignedExpression(node) {
+		parent := node.Parent
+		if ast.IsPropertyDeclaration(parent) && ast.IsClassLike(parent.Parent) {
+			if ast.IsClassExpression(parent.Parent) {
+				if name := ast.GetAssignedName(parent.Parent); name != nil {
+					return scanner.GetTextOfNode(name)
+				}
+				return ""
+			}
+			if name := parent.Parent.Name(); name != nil {
+				return scanner.GetTextOfNode(name)
+			}
+			return ""
+		}
+		// const x = () => {} inside of `namespace N { ... }`
+		if moduleBlock := parent.Parent.Parent.Parent; moduleBlock != nil && ast.IsModuleBlock(moduleBlock) && ast.IsIdentifier(moduleBlock.Parent.Name()) {
+			return scanner.GetTextOfNode(moduleBlock.Parent.Name())
+		}
+		return ""
+	}
+
+	switch node.Kind {
+	case ast.KindGetAccessor, ast.KindSetAccessor, ast.KindMethodDeclaration:
+		if node.Parent.Kind == ast.KindObjectLiteralExpression {
+			if name := ast.GetAssignedName(node.Parent); name != nil {
+				return scanner.GetTextOfNode(name)
+			}
+			return ""
+		}
+		if name := ast.GetNameOfDeclaration(node.Parent); name != nil {
+			return scanner.GetTextOfNode(name)
+		}
+	case ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindModuleDeclaration:
+		if ast.IsModuleBlock(node.Parent) && ast.IsIdentifier(node.Parent.Parent.Name()) {
+			return scanner.GetTextOfNode(node.Parent.Parent.Name())
+		}
+	}
+	return ""
+}
+
+func findImplementation(c *checker.Checker, node *ast.Node) *ast.Node {
+	if node.Body() != nil {
+		return node
+	}
+	if ast.IsConstructorDeclaration(node) {
+		return core.Find(node.Parent.Members(), func(member *ast.Node) bool {
+			return ast.IsConstructorDeclaration(member) && member.Body() != nil
+		})
+	}
+	if ast.IsFunctionDeclaration(node) || ast.IsMethodDeclaration(node) {
+		symbol := getSymbolOfCallHierarchyDeclaration(c, node)
+		if symbol != nil && symbol.ValueDeclaration != nil && ast.IsFunctionLikeDeclaration(symbol.ValueDeclaration) && symbol.ValueDeclaration.Body() != nil {
+			return symbol.ValueDeclaration
+		}
+		return nil
+	}
+	return node
+}
+
+func findAllInitialDeclarations(c *checker.Checker, node *ast.Node) []*ast.Node {
+	symbol := getSymbolOfCallHierarchyDeclaration(c, node)
+	if symbol == nil || len(symbol.Declarations) == 0 {
+		return nil
+	}
+	sortedDeclarations := slices.Clone(symbol.Declarations)
+	slices.SortStableFunc(sortedDeclarations, func(a, b *ast.Node) int {
+		return cmp.Or(
+			strings.Compare(ast.GetSourceFileOfNode(a).FileName(), ast.GetSourceFileOfNode(b).FileName()),
+			a.Pos()-b.Pos(),
+		)
+	})
+	var declarations []*ast.Node
+	var lastDecl *ast.Node
+	for _, decl := range sortedDeclarations {
+		if isValidCallHierarchyDeclaration(decl) {
+			// Skip overloads that directly follow the previous declaration.
+			if lastDecl == nil || lastDecl.Parent != decl.Parent || lastDecl.End() != decl.Pos() {
+				declarations = append(declarations, decl)
+			}
+			lastDecl = decl
+		}
+	}
+	return declarations
+}
+
+// findImplementationOrAllInitialDeclarations finds the implementation of a function-like declaration,
+// if one exists, or all initial declarations of the declaration's symbol otherwise.
+func findImplementationOrAllInitialDeclarations(c *checker.Checker, node *ast.Node) []*ast.Node {
+	if ast.IsClassStaticBlockDeclaration(node) {
+		return []*ast.Node{node}
+	}
+	if ast.IsFunctionLikeDeclaration(node) {
+		if implementation := findImplementation(c, node); implementation != nil {
+			return []*ast.Node{implementation}
+		}
+	}
+	if declarations := findAllInitialDeclarations(c, node); len(declarations) != 0 {
+		return declarations
+	}
+	return []*ast.Node{node}
+}
+
+// resolveCallHierarchyDeclaration resolves the call hierarchy declarations for a node.
+func resolveCallHierarchyDeclaration(c *checker.Checker, location *ast.Node) []*ast.Node {
+	// A call hierarchy item must refer to either a SourceFile, Module Declaration, Class Static Block, or something intrinsically callable that has a name:
+	// - Class Declarations
+	// - Class Expressions (with a name)
+	// - Function Declarations
+	// - Function Expressions (with a name or assigned to a const variable)
+	// - Arrow Functions (assigned to a const variable)
+	// - Constructors
+	// - Class `static {}` initializer blocks
+	// - Methods
+	// - Accessors
+	//
+	// If a call is contained in a non-named callable Node (function expression, arrow function, etc.), then
+	// its containing `CallHierarchyItem` is a containing function or SourceFile that matches the above list.
+	followingSymbol := false
+	for location != nil {
+		if isValidCallHierarchyDeclaration(location) {
+			return findImplementationOrAllInitialDeclarations(c, location)
+		}
+		if isPossibleCallHierarchyDeclaration(location) {
+			if ancestor := ast.FindAncestor(location, isValidCallHierarchyDeclaration); ancestor != nil {
+				return findImplementationOrAllInitialDeclarations(c, ancestor)
+			}
+			return nil
+		}
+		if ast.IsDeclarationName(location) {
+			if isValidCallHierarchyDeclaration(location.Parent) {
+				return findImplementationOrAllInitialDeclarations(c, location.Parent)
+			}
+			if isPossibleCallHierarchyDeclaration(location.Parent) {
+				if ancestor := ast.FindAncestor(location.Parent, isValidCallHierarchyDeclaration); ancestor != nil {
+					return findImplementationOrAllInitialDeclarations(c, ancestor)
+				}
+				return nil
+			}
+			if ast.IsVariableDeclaration(location.Parent) && location.Parent.Initializer() != nil && isAssignedExpression(location.Parent.Initializer()) {
+				return []*ast.Node{location.Parent.Initializer()}
+			}
+			return nil
+		}
+		if ast.IsConstructorDeclaration(location) {
+			if isValidCallHierarchyDeclaration(location.Parent) {
+				return []*ast.Node{location.Parent}
+			}
+			return nil
+		}
+		if location.Kind == ast.KindStaticKeyword && ast.IsClassStaticBlockDeclaration(location.Parent) {
+			location = location.Parent
+			continue
+		}
+		// #39453
+		if ast.IsVariableDeclaration(location) && location.Initializer() != nil && isAssignedExpression(location.Initializer()) {
+			return []*ast.Node{location.Initializer()}
+		}
+		if !followingSymbol {
+			symbol := c.GetSymbolAtLocation(location)
+			if symbol != nil {
+				if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+					symbol = c.GetAliasedSymbol(symbol)
+				}
+				if symbol.ValueDeclaration != nil {
+					followingSymbol = true
+					location = symbol.ValueDeclaration
+					continue
+				}
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+func (l *LanguageService) createCallHierarchyItem(c *checker.Checker, node *ast.Node) *lsproto.CallHierarchyItem {
+	sourceFile := ast.GetSourceFileOfNode(node)
+	name, nameRange := getCallHierarchyItemName(c, node)
+	var detail *string
+	if containerName := getCallHierarchyItemContainerName(node); containerName != "" {
+		detail = &containerName
+	}
+	start := scanner.SkipTriviaEx(sourceFile.Text(), node.Pos(), &scanner.SkipTriviaOptions{StopAtComments: true})
+	return &lsproto.CallHierarchyItem{
+		Name:           name,
+		Kind:           getCallHierarchyItemKind(node),
+		Detail:         detail,
+		Uri:            FileNameToDocumentURI(sourceFile.FileName()),
+		Range:          *l.createLspRangeFromBounds(start, node.End(), sourceFile),
+		SelectionRange: *l.createLspRangeFromRange(nameRange, sourceFile),
+	}
+}
+
+func getCallHierarchyItemKind(node *ast.Node) lsproto.SymbolKind {
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return lsproto.SymbolKindFile
+	case ast.KindModuleDeclaration:
+		return lsproto.SymbolKindModule
+	case ast.KindArrowFunction:
+		return lsproto.SymbolKindFunction
+	case ast.KindClassStaticBlockDeclaration:
+		return lsproto.SymbolKindMethod
+	}
+	return getSymbolKindFromNode(node)
+}
+
+func convertEntryToCallSite(entry *referenceEntry) *callSite {
+	// References found through the default search are recorded with entryKindNone.
+	if entry.kind != entryKindNone && entry.kind != entryKindNode {
+		return nil
+	}
+	node := entry.node
+	if isCallOrNewExpressionTarget(node, true /*includeElementAccess*/, true /*skipPastOuterExpressions*/) ||
+		isTaggedTemplateTag(node, true /*includeElementAccess*/, true /*skipPastOuterExpressions*/) ||
+		isDecoratorTarget(node, true /*includeElementAccess*/, true /*skipPastOuterExpressions*/) ||
+		isJsxOpeningLikeElementTagName(node, true /*includeElementAccess*/, true /*skipPastOuterExpressions*/) ||
+		isRightSideOfPropertyAccess(node) ||
+		isArgumentExpressionOfElementAccess(node) {
+		sourceFile := ast.GetSourceFileOfNode(node)
+		ancestor := ast.FindAncestor(node, isValidCallHierarchyDeclaration)
+		if ancestor == nil {
+			ancestor = sourceFile.AsNode()
+		}
+		return &callSite{declaration: ancestor, sourceFile: sourceFile, textRange: createRangeFromNode(node, sourceFile)}
+	}
+	return nil
+}
+
+func isCallOrNewExpressionTarget(node *ast.Node, includeElementAccess bool, skipPastOuterExpressions bool) bool {
+	return isCalleeWorker(node, ast.IsCallOrNewExpression, (*ast.Node).Expression, includeElementAccess, skipPastOuterExpressions)
+}
+
+func isTaggedTemplateTag(node *ast.Node, includeElementAccess bool, skipPastOuterExpressions bool) bool {
+	return isCalleeWorker(node, ast.IsTaggedTemplateExpression, func(n *ast.Node) *ast.Node { return n.AsTaggedTemplateExpression().Tag }, includeElementAccess, skipPastOuterExpressions)
+}
+
+func isDecoratorTarget(node *ast.Node, includeElementAccess bool, skipPastOuterExpressions bool) bool {
+	return isCalleeWorker(node, ast.IsDecorator, (*ast.Node).Expression, includeElementAccess, skipPastOuterExpressions)
+}
+
+func isJsxOpeningLikeElementTagName(node *ast.Node, includeElementAccess bool, skipPastOuterExpressions bool) bool {
+	return isCalleeWorker(node, ast.IsJsxOpeningLikeElement, (*ast.Node).TagName, includeElementAccess, skipPastOuterExpressions)
+}
+
+func isCalleeWorker(node *ast.Node, pred func(*ast.Node) bool, calleeSelector func(*ast.Node) *ast.Node, includeElementAccess bool, skipPastOuterExpressions bool) bool {
+	target := node
+	if isRightSideOfPropertyAccess(node) || includeElementAccess && isArgumentExpressionOfElementAccess(node) {
+		target = node.Parent
+	}
+	if skipPastOuterExpressions {
+		target = ast.SkipOuterExpressions(target, ast.OEKAll)
+	}
+	return target != nil && target.Parent != nil && pred(target.Parent) && calleeSelector(target.Parent) == target
+}
+
+func isArgumentExpressionOfElementAccess(node *ast.Node) bool {
+	return node.Parent != nil && ast.IsElementAccessExpression(node.Parent) && node.Parent.AsElementAccessExpression().ArgumentExpression == node
+}
+
+type callSiteKey struct {
+	declaration *ast.Node
+	textRange   core.TextRange
+}
+
+type callSiteCollector struct {
+	checker   *checker.Checker
+	callSites []*callSite
+	seen      collections.Set[callSiteKey]
+}
+
+func (s *callSiteCollector) recordCallSite(node *ast.Node) {
+	var target *ast.Node
+	switch {
+	case ast.IsTaggedTemplateExpression(node):
+		target = node.AsTaggedTemplateExpression().Tag
+	case ast.IsJsxOpeningLikeElement(node):
+		target = node.TagName()
+	case ast.IsAccessExpression(node), ast.IsClassStaticBlockDeclaration(node):
+		target = node
+	default:
+		target = node.Expression()
+	}
+	sourceFile := ast.GetSourceFileOfNode(node)
+	textRange := createRangeFromNode(target, sourceFile)
+	for _, declaration := range resolveCallHierarchyDeclaration(s.checker, target) {
+		// A call through a property access is seen both at the call and at the access.
+		if !s.seen.AddIfAbsent(callSiteKey{declaration: declaration, textRange: textRange}) {
+			continue
+		}
+		s.callSites = append(s.callSites, &callSite{declaration: declaration, sourceFile: sourceFile, textRange: textRange})
+	}
+}
+
+func (s *callSiteCollector) collectAll(nodes []*ast.Node) {
+	for _, node := range nodes {
+		s.collect(node)
+	}
+}
+
+func (s *callSiteCollector) collect(node *ast.Node) {
+	// Do not descend into ambient nodes.
+	if node == nil || node.Flags&ast.NodeFlagsAmbient != 0 || node.Flags&ast.NodeFlagsReparsed != 0 {
+		return
+	}
+
+	if isValidCallHierarchyDeclaration(node) {
+		// Do not descend into other call site declarations, other than class member names.
+		if ast.IsClassLike(node) {
+			for _, member := range node.Members() {
+				if name := member.Name(); name != nil && ast.IsComputedPropertyName(name) {
+					s.collect(name.Expression())
+				}
+			}
+		}
+		return
+	}
+
+	switch node.Kind {
+	case ast.KindIdentifier, ast.KindImportEqualsDeclaration, ast.KindImportDeclaration, ast.KindExportDeclaration,
+		ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration:
+		// Do not descend into nodes that cannot contain callable nodes.
+		return
+	case ast.KindClassStaticBlockDeclaration:
+		s.recordCallSite(node)
+		return
+	case ast.KindTypeAssertionExpression, ast.KindAsExpression, ast.KindSatisfiesExpression:
+		// Do not descend into the type side of an assertion.
+		s.collect(node.Expression())
+		return
+	case ast.KindVariableDeclaration, ast.KindParameter:
+		// Do not descend into the type of a variable or parameter declaration.
+		s.collect(node.Name())
+		s.collect(node.Initializer())
+		return
+	case ast.KindCallExpression, ast.KindNewExpression:
+		// Do not descend into the type arguments of a call expression.
+		s.recordCallSite(node)
+		s.collect(node.Expression())
+		s.collectAll(node.Arguments())
+		return
+	case ast.KindTaggedTemplateExpression:
+		s.recordCallSite(node)
+		s.collect(node.AsTaggedTemplateExpression().Tag)
+		s.collect(node.AsTaggedTemplateExpression().Template)
+		return
+	case ast.KindJsxOpeningElement, ast.KindJsxSelfClosingElement:
+		s.recordCallSite(node)
+		s.collect(node.TagName())
+		s.collect(node.Attributes())
+		return
+	case ast.KindDecorator:
+		s.recordCallSite(node)
+		s.collect(node.Expression())
+		return
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		s.recordCallSite(node)
+		node.ForEachChild(s.visit)
+	}
+
+	if ast.IsPartOfTypeNode(node) {
+		return
+	}
+	node.ForEachChild(s.visit)
+}
+
+func (s *callSiteCollector) visit(node *ast.Node) bool {
+	s.collect(node)
+	return false
+}
+
+func (s *callSiteCollector) collectCallSites(node *ast.Node) {
+	switch node.Kind {
+	case ast.KindSourceFile:
+		s.collectAll(node.Statements())
+	case ast.KindModuleDeclaration:
+		if !ast.HasSyntacticModifier(node, ast.ModifierFlagsAmbient) && node.Body() != nil && ast.IsModuleBlock(node.Body()) {
+			s.collectAll(node.Body().Statements())
+		}
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindArrowFunction, ast.KindMethodDeclaration,
+		ast.KindGetAccessor, ast.KindSetAccessor:
+		if implementation := findImplementation(s.checker, node); implementation != nil {
+			s.collectAll(implementation.Parameters())
+			s.collect(implementation.Body())
+		}
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		s.collectAll(node.ModifierNodes())
+		if heritage := ast.GetClassExtendsHeritageElement(node); heritage != nil {
+			s.collect(heritage.Expression())
+		}
+		for _, member := range node.Members() {
+			if ast.CanHaveModifiers(member) {
+				s.collectAll(member.ModifierNodes())
+			}
+			switch {
+			case ast.IsPropertyDeclaration(member):
+				s.collect(member.Initializer())
+			case ast.IsConstructorDeclaration(member) && member.Body() != nil:
+				s.collectAll(member.Parameters())
+				s.collect(member.Body())
+			case ast.IsClassStaticBlockDeclaration(member):
+				s.collect(member)
+			}
+		}
+	case ast.KindClassStaticBlockDeclaration:
+		s.collect(node.Body())
+	}
+}
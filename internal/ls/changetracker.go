@@ -83,6 +83,8 @@ type changeTracker struct {
 	*ast.NodeFactory
 	changes *collections.MultiMap[*ast.SourceFile, *trackerEdit]
 
+	deletedNodes []deletedNode
+
 	// created during call to getChanges
 	writer *printer.ChangeTrackerWriter
 	// printer
@@ -107,7 +109,7 @@ func (ls *LanguageService) newChangeTracker(ctx context.Context) *changeTracker
 // !!! address strada note
 //   - Note: after calling this, the TextChanges object must be discarded!
 func (ct *changeTracker) getChanges() map[string][]*lsproto.TextEdit {
-	// !!! finishDeleteDeclarations
+	ct.finishDeleteDeclarations()
 	// !!! finishClassesWithNodesInsertedAtStart
 	changes := ct.getTextChangesFromChanges()
 	// !!! changes for new files
@@ -141,6 +143,54 @@ func (ct *changeTracker) replaceRangeWithNodes(sourceFile *ast.SourceFile, lspro
 	ct.changes.Add(sourceFile, &trackerEdit{kind: trackerEditKindReplaceWithMultipleNodes, Range: lsprotoRange, nodes: newNodes, options: options})
 }
 
+func (ct *changeTracker) replaceNodeWithNodes(sourceFile *ast.SourceFile, oldNode *ast.Node, newNodes []*ast.Node, options changeNodeOptions) {
+	ct.replaceRangeWithNodes(sourceFile, ct.getAdjustedRange(sourceFile, oldNode, oldNode, options.leadingTriviaOption, options.trailingTriviaOption), newNodes, options)
+}
+
+func (ct *changeTracker) replaceNodeWithText(sourceFile *ast.SourceFile, oldNode *ast.Node, text string) {
+	ct.replaceRangeWithText(sourceFile, ct.getAdjustedRange(sourceFile, oldNode, oldNode, leadingTriviaOptionExclude, trailingTriviaOptionExclude), text)
+}
+
+func (ct *changeTracker) deleteRange(sourceFile *ast.SourceFile, textRange core.TextRange) {
+	ct.changes.Add(sourceFile, &trackerEdit{kind: trackerEditKindRemove, Range: *ct.ls.createLspRangeFromRange(textRange, sourceFile)})
+}
+
+// delete queues a declaration for removal. The exact range removed (including separators and
+// surrounding trivia) is computed in getChanges, once all deletions are known.
+func (ct *changeTracker) delete(sourceFile *ast.SourceFile, node *ast.Node) {
+	ct.deletedNodes = append(ct.deletedNodes, deletedNode{sourceFile: sourceFile, node: node})
+}
+
+func (ct *changeTracker) deleteNode(sourceFile *ast.SourceFile, node *ast.Node, leadingOption leadingTriviaOption, trailingOption trailingTriviaOption) {
+	ct.deleteRange(sourceFile, core.NewTextRange(
+		ct.getAdjustedStartPosition(sourceFile, node, leadingOption, false),
+		ct.getAdjustedEndPosition(sourceFile, node, trailingOption),
+	))
+}
+
+func (ct *changeTracker) deleteNodeRange(sourceFile *ast.SourceFile, startNode *ast.Node, endNode *ast.Node, leadingOption leadingTriviaOption, trailingOption trailingTriviaOption) {
+	ct.deleteRange(sourceFile, core.NewTextRange(
+		ct.getAdjustedStartPosition(sourceFile, startNode, leadingOption, false),
+		ct.getAdjustedEndPosition(sourceFile, endNode, trailingOption),
+	))
+}
+
+// deleteNodes deletes each of the given nodes. `hasTrailingComment` indicates that the first node follows a
+// node whose multiline trailing comment has already been consumed by a previous edit.
+func (ct *changeTracker) deleteNodes(sourceFile *ast.SourceFile, nodes []*ast.Node, leadingOption leadingTriviaOption, trailingOption trailingTriviaOption, hasTrailingComment bool) {
+	for _, node := range nodes {
+		ct.deleteRange(sourceFile, core.NewTextRange(
+			ct.getAdjustedStartPosition(sourceFile, node, leadingOption, hasTrailingComment),
+			ct.getAdjustedEndPosition(sourceFile, node, trailingOption),
+		))
+		hasTrailingComment = ct.nodeHasTrailingComment(sourceFile, node, trailingOption)
+	}
+}
+
+func (ct *changeTracker) nodeHasTrailingComment(sourceFile *ast.SourceFile, node *ast.Node, trailingOption trailingTriviaOption) bool {
+	return ct.getEndPositionOfMultilineTrailingComment(sourceFile, node, trailingOption) != 0
+}
+
 func (ct *changeTracker) insertText(sourceFile *ast.SourceFile, pos lsproto.Position, text string) {
 	ct.replaceRangeWithText(sourceFile, lsproto.Range{Start: pos, End: pos}, text)
 }
@@ -169,6 +219,28 @@ func (ct *changeTracker) insertNodeBefore(sourceFile *ast.SourceFile, before *as
 	ct.insertNodeAt(sourceFile, core.TextPos(ct.getAdjustedStartPosition(sourceFile, before, leadingTriviaOptionNone, false)), newNode, ct.getOptionsForInsertNodeBefore(before, newNode, blankLineBetween))
 }
 
+// insertMemberAtStart inserts `newElement` as the first member of a class-like or interface declaration.
+func (ct *changeTracker) insertMemberAtStart(sourceFile *ast.SourceFile, node *ast.Node, newElement *ast.Node) {
+	members := node.MemberList()
+	var indentation int
+	if len(members.Nodes) > 0 {
+		firstMemberStart := astnav.GetStartOfNode(members.Nodes[0], sourceFile, false /*includeJSDoc*/)
+		indentation = format.FindFirstNonWhitespaceColumn(format.GetLineStartPositionForPosition(firstMemberStart, sourceFile), firstMemberStart, sourceFile, ct.formatSettings)
+	} else {
+		indentation = format.GetIndentationForNode(node, nil, sourceFile, ct.formatSettings) + ct.formatSettings.IndentSize
+	}
+	options := changeNodeOptions{prefix: ct.newLine + format.GetIndentationString(indentation, ct.formatSettings)}
+	if ast.IsInterfaceDeclaration(node) && len(members.Nodes) == 0 {
+		options.suffix = ";"
+	}
+	if closeBrace := findChildOfKind(node, ast.KindCloseBraceToken, sourceFile); len(members.Nodes) == 0 && closeBrace != nil &&
+		printer.GetLinesBetweenPositions(sourceFile, members.Pos(), astnav.GetStartOfNode(closeBrace, sourceFile, false /*includeJSDoc*/)) == 0 {
+		// `class C {}` becomes `class C {\n    x;\n}`
+		options.suffix += ct.newLine + format.GetIndentationString(indentation-ct.formatSettings.IndentSize, ct.formatSettings)
+	}
+	ct.insertNodeAt(sourceFile, core.TextPos(members.Pos()), newElement, options)
+}
+
 func (ct *changeTracker) endPosForInsertNodeAfter(sourceFile *ast.SourceFile, after *ast.Node, newNode *ast.Node) core.TextPos {
 	if (needSemicolonBetween(after, newNode)) && (rune(sourceFile.Text()[after.End()-1]) != ';') {
 		// check if previous statement ends with semicolon
@@ -8,6 +8,7 @@ import (
 
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/format"
 	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
@@ -122,16 +123,6 @@ func getFormatCodeSettingsForWriting(options *format.FormatCodeSettings, sourceF
 }
 
 func (ct *changeTracker) getNonformattedText(node *ast.Node, sourceFile *ast.SourceFile) (string, *ast.Node) {
-	nodeIn := node
-	eofToken := ct.Factory.NewToken(ast.KindEndOfFile)
-	if ast.IsStatement(node) {
-		nodeIn = ct.Factory.NewSourceFile(
-			ast.SourceFileParseOptions{FileName: sourceFile.FileName(), Path: sourceFile.Path()},
-			"",
-			ct.Factory.NewNodeList([]*ast.Node{node}),
-			ct.Factory.NewToken(ast.KindEndOfFile),
-		)
-	}
 	writer := printer.NewChangeTrackerWriter(ct.newLine)
 	printer.NewPrinter(
 		printer.PrinterOptions{
@@ -142,31 +133,26 @@ func (ct *changeTracker) getNonformattedText(node *ast.Node, sourceFile *ast.Sou
 		},
 		writer.GetPrintHandlers(),
 		ct.EmitContext,
-	).Write(nodeIn, sourceFile, writer, nil)
+	).Write(node, sourceFile, writer, nil)
 
 	text := writer.String()
-	text = strings.TrimSuffix(text, ct.newLine) // Newline artifact from printing a SourceFile instead of a node
-
-	nodeOut := writer.AssignPositionsToNode(nodeIn, ct.NodeFactory)
-	var sourceFileLike *ast.Node
-	if !ast.IsStatement(node) {
-		nodeList := ct.Factory.NewNodeList([]*ast.Node{nodeOut})
-		nodeList.Loc = nodeOut.Loc
-		eofToken.Loc = core.NewTextRange(nodeOut.End(), nodeOut.End())
-		sourceFileLike = ct.Factory.NewSourceFile(
-			ast.SourceFileParseOptions{FileName: sourceFile.FileName(), Path: sourceFile.Path()},
-			text,
-			nodeList,
-			eofToken,
-		)
-		sourceFileLike.ForEachChild(func(child *ast.Node) bool {
-			child.Parent = sourceFileLike
-			return true
-		})
-		sourceFileLike.Loc = nodeOut.Loc
-	} else {
-		sourceFileLike = nodeOut
-	}
+
+	nodeOut := writer.AssignPositionsToNode(node, ct.NodeFactory)
+	nodeList := ct.Factory.NewNodeList([]*ast.Node{nodeOut})
+	nodeList.Loc = nodeOut.Loc
+	eofToken := ct.Factory.NewToken(ast.KindEndOfFile)
+	eofToken.Loc = core.NewTextRange(nodeOut.End(), nodeOut.End())
+	sourceFileLike := ct.Factory.NewSourceFile(
+		ast.SourceFileParseOptions{FileName: sourceFile.FileName(), Path: sourceFile.Path()},
+		text,
+		nodeList,
+		eofToken,
+	)
+	sourceFileLike.ForEachChild(func(child *ast.Node) bool {
+		child.Parent = sourceFileLike
+		return true
+	})
+	sourceFileLike.Loc = nodeOut.Loc
 	return text, sourceFileLike
 }
 
@@ -411,3 +397,222 @@ func (ct *changeTracker) getInsertionPositionAtSourceFileTop(sourceFile *ast.Sou
 	}
 	return position
 }
+
+// ============= deletion =============
+
+type deletedNode struct {
+	sourceFile *ast.SourceFile
+	node       *ast.Node
+}
+
+func (ct *changeTracker) finishDeleteDeclarations() {
+	if len(ct.deletedNodes) == 0 {
+		return
+	}
+	deletedNodesInLists := collections.Set[*ast.Node]{}
+	for _, deleted := range ct.deletedNodes {
+		// Skip nodes that are contained within another deleted node.
+		if slices.ContainsFunc(ct.deletedNodes, func(d deletedNode) bool {
+			return d.sourceFile == deleted.sourceFile && d.node != deleted.node && rangeContainsRangeExclusive(d.node.Loc, deleted.node.Loc)
+		}) {
+			continue
+		}
+		ct.deleteDeclaration(&deletedNodesInLists, deleted.sourceFile, deleted.node)
+	}
+	for node := range deletedNodesInLists.Keys() {
+		sourceFile := ast.GetSourceFileOfNode(node)
+		list := format.GetContainingList(node, sourceFile).Nodes
+		if node != list[len(list)-1] {
+			continue
+		}
+		// The last element of the list was deleted; also delete the separator following the
+		// last element that was not deleted.
+		lastNonDeletedIndex := -1
+		for i := len(list) - 2; i >= 0; i-- {
+			if !deletedNodesInLists.Has(list[i]) {
+				lastNonDeletedIndex = i
+				break
+			}
+		}
+		if lastNonDeletedIndex != -1 {
+			ct.deleteRange(sourceFile, core.NewTextRange(list[lastNonDeletedIndex].End(), ct.startPositionToDeleteNodeInList(sourceFile, list[lastNonDeletedIndex+1])))
+		}
+	}
+}
+
+func (ct *changeTracker) deleteDeclaration(deletedNodesInLists *collections.Set[*ast.Node], sourceFile *ast.SourceFile, node *ast.Node) {
+	switch node.Kind {
+	case ast.KindParameter:
+		oldFunction := node.Parent
+		if ast.IsArrowFunction(oldFunction) && len(oldFunction.Parameters()) == 1 && findChildOfKind(oldFunction, ast.KindOpenParenToken, sourceFile) == nil {
+			// Lambdas with exactly one parameter are special because, after removal, there
+			// must be an empty parameter list (i.e. `()`) and this won't necessarily be the
+			// case if the parameter is simply removed (e.g. in `x => 1`).
+			ct.replaceNodeWithText(sourceFile, node, "()")
+		} else {
+			ct.deleteNodeInList(deletedNodesInLists, sourceFile, node)
+		}
+	case ast.KindImportDeclaration, ast.KindImportEqualsDeclaration:
+		isFirstImport := node == core.Find(sourceFile.Statements.Nodes, ast.IsAnyImportSyntax)
+		// For first import, leave header comment in place, otherwise only delete JSDoc comments
+		leadingOption := leadingTriviaOptionStartLine
+		if isFirstImport {
+			leadingOption = leadingTriviaOptionExclude
+		} else if node.Flags&ast.NodeFlagsHasJSDoc != 0 {
+			leadingOption = leadingTriviaOptionJSDoc
+		}
+		ct.deleteNode(sourceFile, node, leadingOption, trailingTriviaOptionNone)
+	case ast.KindBindingElement:
+		pattern := node.Parent
+		elements := pattern.AsBindingPattern().Elements.Nodes
+		if pattern.Kind == ast.KindArrayBindingPattern && node != elements[len(elements)-1] {
+			// Preserve the comma so that later elements keep their positions.
+			ct.deleteNode(sourceFile, node, leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+		} else {
+			ct.deleteNodeInList(deletedNodesInLists, sourceFile, node)
+		}
+	case ast.KindVariableDeclaration:
+		ct.deleteVariableDeclaration(deletedNodesInLists, sourceFile, node)
+	case ast.KindTypeParameter:
+		ct.deleteNodeInList(deletedNodesInLists, sourceFile, node)
+	case ast.KindImportSpecifier:
+		namedImports := node.Parent
+		if len(namedImports.AsNamedImports().Elements.Nodes) == 1 {
+			ct.deleteImportBinding(sourceFile, namedImports)
+		} else {
+			ct.deleteNodeInList(deletedNodesInLists, sourceFile, node)
+		}
+	case ast.KindNamespaceImport:
+		ct.deleteImportBinding(sourceFile, node)
+	case ast.KindSemicolonToken:
+		ct.deleteNode(sourceFile, node, leadingTriviaOptionIncludeAll, trailingTriviaOptionExclude)
+	case ast.KindFunctionKeyword:
+		ct.deleteNode(sourceFile, node, leadingTriviaOptionExclude, trailingTriviaOptionNone)
+	case ast.KindClassDeclaration, ast.KindFunctionDeclaration:
+		ct.deleteNode(sourceFile, node, core.IfElse(node.Flags&ast.NodeFlagsHasJSDoc != 0, leadingTriviaOptionJSDoc, leadingTriviaOptionStartLine), trailingTriviaOptionNone)
+	default:
+		switch {
+		case node.Parent == nil:
+			ct.deleteNode(sourceFile, node, leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+		case ast.IsImportClause(node.Parent) && node.Parent.Name() == node:
+			ct.deleteDefaultImport(sourceFile, node.Parent)
+		case ast.IsCallExpression(node.Parent) && slices.Contains(node.Parent.Arguments(), node):
+			ct.deleteNodeInList(deletedNodesInLists, sourceFile, node)
+		default:
+			ct.deleteNode(sourceFile, node, leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+		}
+	}
+}
+
+func (ct *changeTracker) deleteDefaultImport(sourceFile *ast.SourceFile, importClause *ast.Node) {
+	if importClause.AsImportClause().NamedBindings == nil {
+		// Delete the whole import
+		ct.deleteNode(sourceFile, importClause.Parent, leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+		return
+	}
+	// import |d,| * as ns from './file'
+	name := importClause.Name()
+	start := astnav.GetStartOfNode(name, sourceFile, false)
+	if nextToken := astnav.GetTokenAtPosition(sourceFile, name.End()); nextToken != nil && nextToken.Kind == ast.KindCommaToken {
+		// shift first non-whitespace position after comma to the start position of the node
+		end := scanner.SkipTriviaEx(sourceFile.Text(), nextToken.End(), &scanner.SkipTriviaOptions{StopAtComments: true})
+		ct.deleteRange(sourceFile, core.NewTextRange(start, end))
+	} else {
+		ct.deleteNode(sourceFile, name, leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+	}
+}
+
+func (ct *changeTracker) deleteImportBinding(sourceFile *ast.SourceFile, node *ast.Node) {
+	if node.Parent.Name() != nil {
+		// Delete named imports while preserving the default import
+		// import d|, * as ns| from './file'
+		// import d|, { a }| from './file'
+		previousToken := astnav.GetTokenAtPosition(sourceFile, node.Pos()-1)
+		ct.deleteRange(sourceFile, core.NewTextRange(astnav.GetStartOfNode(previousToken, sourceFile, false), node.End()))
+		return
+	}
+	// Delete the entire import declaration
+	// |import * as ns from './file'|
+	// |import { a } from './file'|
+	importDecl := ast.FindAncestorKind(node, ast.KindImportDeclaration)
+	ct.deleteNode(sourceFile, importDecl, leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+}
+
+func (ct *changeTracker) deleteVariableDeclaration(deletedNodesInLists *collections.Set[*ast.Node], sourceFile *ast.SourceFile, node *ast.Node) {
+	parent := node.Parent
+	if parent.Kind == ast.KindCatchClause {
+		ct.deleteNodeRange(sourceFile, findChildOfKind(parent, ast.KindOpenParenToken, sourceFile), findChildOfKind(parent, ast.KindCloseParenToken, sourceFile), leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+		return
+	}
+	if len(parent.AsVariableDeclarationList().Declarations.Nodes) != 1 {
+		ct.deleteNodeInList(deletedNodesInLists, sourceFile, node)
+		return
+	}
+	grandParent := parent.Parent
+	switch grandParent.Kind {
+	case ast.KindForOfStatement, ast.KindForInStatement:
+		ct.replaceNode(sourceFile, node, ct.NodeFactory.NewObjectLiteralExpression(ct.NodeFactory.NewNodeList(nil), false), nil)
+	case ast.KindForStatement:
+		ct.deleteNode(sourceFile, parent, leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+	case ast.KindVariableStatement:
+		ct.deleteNode(sourceFile, grandParent, core.IfElse(grandParent.Flags&ast.NodeFlagsHasJSDoc != 0, leadingTriviaOptionJSDoc, leadingTriviaOptionStartLine), trailingTriviaOptionNone)
+	default:
+		panic("Unexpected grandparent kind of variable declaration: " + grandParent.Kind.String())
+	}
+}
+
+// Warning: This deletes comments too. See `copyComments` in `convertFunctionToEs6Class`.
+func (ct *changeTracker) deleteNodeInList(deletedNodesInLists *collections.Set[*ast.Node], sourceFile *ast.SourceFile, node *ast.Node) {
+	containingList := format.GetContainingList(node, sourceFile).Nodes
+	index := slices.Index(containingList, node)
+	if len(containingList) == 1 {
+		ct.deleteNode(sourceFile, node, leadingTriviaOptionIncludeAll, trailingTriviaOptionNone)
+		return
+	}
+	deletedNodesInLists.Add(node)
+	var end int
+	if index == len(containingList)-1 {
+		end = ct.getAdjustedEndPosition(sourceFile, node, trailingTriviaOptionNone)
+	} else {
+		var prevNode *ast.Node
+		if index > 0 {
+			prevNode = containingList[index-1]
+		}
+		end = ct.endPositionToDeleteNodeInList(sourceFile, node, prevNode, containingList[index+1])
+	}
+	ct.deleteRange(sourceFile, core.NewTextRange(ct.startPositionToDeleteNodeInList(sourceFile, node), end))
+}
+
+// First try to see if we can put the '*' on the same line as the previous node; we'd like to
+// delete the leading trivia of the deleted node along with it.
+func (ct *changeTracker) startPositionToDeleteNodeInList(sourceFile *ast.SourceFile, node *ast.Node) int {
+	return scanner.SkipTriviaEx(sourceFile.Text(), ct.getAdjustedStartPosition(sourceFile, node, leadingTriviaOptionIncludeAll, false), &scanner.SkipTriviaOptions{StopAtComments: true})
+}
+
+func (ct *changeTracker) endPositionToDeleteNodeInList(sourceFile *ast.SourceFile, node *ast.Node, prevNode *ast.Node, nextNode *ast.Node) int {
+	end := ct.startPositionToDeleteNodeInList(sourceFile, nextNode)
+	if prevNode == nil || printer.GetLinesBetweenPositions(sourceFile, ct.getAdjustedEndPosition(sourceFile, node, trailingTriviaOptionNone), end) == 0 {
+		return end
+	}
+	token := astnav.FindPrecedingToken(sourceFile, astnav.GetStartOfNode(nextNode, sourceFile, false))
+	if isSeparator(node, token) {
+		prevToken := astnav.FindPrecedingToken(sourceFile, astnav.GetStartOfNode(node, sourceFile, false))
+		if isSeparator(prevNode, prevToken) {
+			pos := scanner.SkipTriviaEx(sourceFile.Text(), token.End(), &scanner.SkipTriviaOptions{StopAfterLineBreak: true, StopAtComments: true})
+			if printer.GetLinesBetweenPositions(sourceFile, astnav.GetStartOfNode(prevToken, sourceFile, false), astnav.GetStartOfNode(nextNode, sourceFile, false)) == 0 {
+				if stringutil.IsLineBreak(rune(sourceFile.Text()[pos-1])) {
+					return pos - 1
+				}
+				return pos
+			}
+			if pos < len(sourceFile.Text()) && stringutil.IsLineBreak(rune(sourceFile.Text()[pos])) {
+				return pos
+			}
+		}
+	}
+	return end
+}
+
+func rangeContainsRangeExclusive(r1 core.TextRange, r2 core.TextRange) bool {
+	return r1.ContainsExclusive(r2.Pos()) && r1.ContainsExclusive(r2.End())
+}
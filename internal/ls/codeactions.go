@@ -0,0 +1,66 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+func (l *LanguageService) ProvideCodeActions(ctx context.Context, params *lsproto.CodeActionParams) (lsproto.CodeActionResponse, error) {
+	program, file := l.getProgramAndFile(params.TextDocument.Uri)
+	var actions []lsproto.CommandOrCodeAction
+	if shouldProvideCodeActionKind(params.Context, lsproto.CodeActionKindQuickFix) {
+		actions = append(actions, l.getQuickFixes(ctx, program, file, params.Range)...)
+	}
+	if shouldProvideSourceCodeActionKind(params.Context, lsproto.CodeActionKindSourceOrganizeImports) {
+		if action := l.getOrganizeImportsCodeAction(ctx, program, file); action != nil {
+			actions = append(actions, *action)
+		}
+	}
+	return lsproto.CommandOrCodeActionArrayOrNull{CommandOrCodeActionArray: &actions}, nil
+}
+
+func shouldProvideCodeActionKind(context *lsproto.CodeActionContext, kind lsproto.CodeActionKind) bool {
+	if context == nil || context.Only == nil {
+		return true
+	}
+	return containsCodeActionKind(*context.Only, kind)
+}
+
+// shouldProvideSourceCodeActionKind is like shouldProvideCodeActionKind, but source actions apply to the whole
+// file and are only computed when the client explicitly asks for them (e.g. on save).
+func shouldProvideSourceCodeActionKind(context *lsproto.CodeActionContext, kind lsproto.CodeActionKind) bool {
+	return context != nil && context.Only != nil && containsCodeActionKind(*context.Only, kind)
+}
+
+func containsCodeActionKind(only []lsproto.CodeActionKind, kind lsproto.CodeActionKind) bool {
+	return slices.ContainsFunc(only, func(only lsproto.CodeActionKind) bool {
+		return only == kind || strings.HasPrefix(string(kind), string(only)+".")
+	})
+}
+
+func (l *LanguageService) getOrganizeImportsCodeAction(ctx context.Context, program *compiler.Program, file *ast.SourceFile) *lsproto.CommandOrCodeAction {
+	changes := l.organizeImports(ctx, program, file)
+	if len(changes) == 0 {
+		return nil
+	}
+	return &lsproto.CommandOrCodeAction{
+		CodeAction: &lsproto.CodeAction{
+			Title: "Organize Imports",
+			Kind:  ptrTo(lsproto.CodeActionKindSourceOrganizeImports),
+			Edit:  toWorkspaceEdit(changes),
+		},
+	}
+}
+
+func toWorkspaceEdit(changes map[string][]*lsproto.TextEdit) *lsproto.WorkspaceEdit {
+	documentChanges := make(map[lsproto.DocumentUri][]*lsproto.TextEdit, len(changes))
+	for fileName, edits := range changes {
+		documentChanges[FileNameToDocumentURI(fileName)] = edits
+	}
+	return &lsproto.WorkspaceEdit{Changes: &documentChanges}
+}
@@ -0,0 +1,121 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const addMissingAwaitFixName = "addMissingAwait"
+
+var addMissingAwaitFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.An_arithmetic_operand_must_be_of_type_any_number_bigint_or_an_enum_type.Code(),
+		diagnostics.The_left_hand_side_of_an_arithmetic_operation_must_be_of_type_any_number_bigint_or_an_enum_type.Code(),
+		diagnostics.The_right_hand_side_of_an_arithmetic_operation_must_be_of_type_any_number_bigint_or_an_enum_type.Code(),
+		diagnostics.Operator_0_cannot_be_applied_to_type_1.Code(),
+		diagnostics.Operator_0_cannot_be_applied_to_types_1_and_2.Code(),
+		diagnostics.This_condition_will_always_return_true_since_this_0_is_always_defined.Code(),
+		diagnostics.Property_0_does_not_exist_on_type_1.Code(),
+	},
+	getCodeActions: getAddMissingAwaitCodeActions,
+}
+
+func getAddMissingAwaitCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	expression := getAwaitErrorSpanExpression(fixContext.sourceFile, fixContext.span)
+	if expression == nil || !isInsideAwaitableBody(expression) {
+		return nil
+	}
+
+	var operands []*ast.Node
+	switch {
+	case ast.IsBinaryExpression(expression):
+		binary := expression.AsBinaryExpression()
+		operands = []*ast.Node{binary.Left, binary.Right}
+	case ast.IsIdentifier(expression) && ast.IsPropertyAccessExpression(expression.Parent) && expression.Parent.Name() == expression:
+		// Only offer the fix if awaiting the receiver would make the property access valid.
+		receiver := expression.Parent.Expression()
+		promisedType := fixContext.checker.GetPromisedTypeOfPromise(fixContext.checker.GetTypeAtLocation(receiver))
+		if promisedType == nil || fixContext.checker.GetPropertyOfType(promisedType, expression.Text()) == nil {
+			return nil
+		}
+		operands = []*ast.Node{receiver}
+	default:
+		operands = []*ast.Node{expression}
+	}
+
+	operands = core.Filter(operands, func(operand *ast.Node) bool {
+		return isMissingAwaitOperand(fixContext.checker, operand)
+	})
+	if len(operands) == 0 {
+		return nil
+	}
+
+	action := fixContext.newCodeFixAction(ctx, addMissingAwaitFixName, diagnostics.Add_await.Message(), func(tracker *changeTracker) {
+		for _, operand := range operands {
+			text := "await " + scanner.GetSourceTextOfNodeFromSourceFile(fixContext.sourceFile, operand, false /*includeTrivia*/)
+			if needsParenthesesForAwait(operand) {
+				text = "(" + text + ")"
+			}
+			tracker.replaceNodeWithText(fixContext.sourceFile, operand, text)
+		}
+	})
+	if action == nil {
+		return nil
+	}
+	return []*codeFixAction{action}
+}
+
+// getAwaitErrorSpanExpression returns the outermost node whose span matches the error span exactly.
+func getAwaitErrorSpanExpression(sourceFile *ast.SourceFile, span core.TextRange) *ast.Node {
+	token := astnav.GetTokenAtPosition(sourceFile, span.Pos())
+	var expression *ast.Node
+	for node := token; node != nil && !ast.IsSourceFile(node); node = node.Parent {
+		start := astnav.GetStartOfNode(node, sourceFile, false /*includeJSDoc*/)
+		if start < span.Pos() || node.End() > span.End() {
+			break
+		}
+		if start == span.Pos() && node.End() == span.End() {
+			expression = node
+		}
+	}
+	return expression
+}
+
+func isMissingAwaitOperand(ch *checker.Checker, node *ast.Node) bool {
+	if !ast.IsExpression(node) || ast.IsAwaitExpression(node) {
+		return false
+	}
+	return ch.GetPromisedTypeOfPromise(ch.GetTypeAtLocation(node)) != nil
+}
+
+func isInsideAwaitableBody(node *ast.Node) bool {
+	if node.Flags&ast.NodeFlagsAwaitContext != 0 {
+		return true
+	}
+	return ast.FindAncestor(node, func(ancestor *ast.Node) bool {
+		if ancestor.Parent != nil && ast.IsArrowFunction(ancestor.Parent) && ancestor.Parent.Body() == ancestor {
+			return ast.HasSyntacticModifier(ancestor.Parent, ast.ModifierFlagsAsync)
+		}
+		return ast.IsBlock(ancestor) && ancestor.Parent != nil && ast.IsFunctionLikeDeclaration(ancestor.Parent) &&
+			ast.HasSyntacticModifier(ancestor.Parent, ast.ModifierFlagsAsync)
+	}) != nil
+}
+
+func needsParenthesesForAwait(node *ast.Node) bool {
+	parent := node.Parent
+	switch {
+	case ast.IsPropertyAccessExpression(parent), ast.IsElementAccessExpression(parent):
+		return parent.Expression() == node
+	case ast.IsCallExpression(parent), ast.IsNewExpression(parent):
+		return parent.Expression() == node
+	case ast.IsTaggedTemplateExpression(parent):
+		return parent.AsTaggedTemplateExpression().Tag == node
+	}
+	return false
+}
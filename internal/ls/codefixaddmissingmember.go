@@ -0,0 +1,142 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/nodebuilder"
+)
+
+const (
+	addMissingMemberFixName     = "addMissingMember"
+	addMissingPropertiesFixName = "fixMissingProperties"
+)
+
+var addMissingMemberFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Property_0_does_not_exist_on_type_1.Code(),
+		diagnostics.Property_0_does_not_exist_on_type_1_Did_you_mean_2.Code(),
+		diagnostics.Property_0_is_missing_in_type_1_but_required_in_type_2.Code(),
+		diagnostics.Type_0_is_missing_the_following_properties_from_type_1_Colon_2.Code(),
+	},
+	getCodeActions: getAddMissingMemberCodeActions,
+}
+
+func getAddMissingMemberCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	token := astnav.GetTokenAtPosition(fixContext.sourceFile, fixContext.span.Pos())
+	if objectLiteral := getObjectLiteralWithMissingProperties(token); objectLiteral != nil {
+		if action := getAddMissingPropertiesAction(ctx, fixContext, objectLiteral); action != nil {
+			return []*codeFixAction{action}
+		}
+		return nil
+	}
+	if action := getDeclarePropertyAction(ctx, fixContext, token); action != nil {
+		return []*codeFixAction{action}
+	}
+	return nil
+}
+
+// getObjectLiteralWithMissingProperties returns the object literal initializer of a declaration whose
+// name is the error location, as in `const x: T = {}`.
+func getObjectLiteralWithMissingProperties(token *ast.Node) *ast.Node {
+	if !ast.IsIdentifier(token) || token.Parent == nil || token.Parent.Name() != token {
+		return nil
+	}
+	parent := token.Parent
+	if !ast.IsVariableDeclaration(parent) && !ast.IsPropertyDeclaration(parent) && !ast.IsParameter(parent) {
+		return nil
+	}
+	if initializer := parent.Initializer(); initializer != nil && ast.IsObjectLiteralExpression(initializer) {
+		return initializer
+	}
+	return nil
+}
+
+func getAddMissingPropertiesAction(ctx context.Context, fixContext *codeFixContext, objectLiteral *ast.Node) *codeFixAction {
+	ch := fixContext.checker
+	targetType := ch.GetContextualType(objectLiteral, checker.ContextFlagsNone)
+	if targetType == nil {
+		return nil
+	}
+	sourceType := ch.GetTypeAtLocation(objectLiteral)
+	var missing []*ast.Symbol
+	for _, property := range ch.GetPropertiesOfType(targetType) {
+		if property.Flags&ast.SymbolFlagsOptional == 0 && ch.GetPropertyOfType(sourceType, property.Name) == nil {
+			missing = append(missing, property)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fixContext.newCodeFixAction(ctx, addMissingPropertiesFixName, diagnostics.Add_missing_properties.Message(), func(tracker *changeTracker) {
+		properties := append([]*ast.Node{}, objectLiteral.AsObjectLiteralExpression().Properties.Nodes...)
+		for _, property := range missing {
+			properties = append(properties, tracker.NewPropertyAssignment(
+				nil, /*modifiers*/
+				tracker.NewIdentifier(property.Name),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				getDefaultValueForType(tracker, ch, ch.GetTypeOfSymbol(property)),
+			))
+		}
+		tracker.replaceNode(fixContext.sourceFile, objectLiteral, tracker.NewObjectLiteralExpression(tracker.NewNodeList(properties), true /*multiLine*/), nil)
+	})
+}
+
+func getDefaultValueForType(tracker *changeTracker, ch *checker.Checker, t *checker.Type) *ast.Node {
+	switch {
+	case t.Flags()&checker.TypeFlagsBooleanLike != 0:
+		return tracker.NewKeywordExpression(ast.KindFalseKeyword)
+	case t.Flags()&checker.TypeFlagsStringLike != 0:
+		return tracker.NewStringLiteral("")
+	case t.Flags()&checker.TypeFlagsNumber != 0:
+		return tracker.NewNumericLiteral("0")
+	case t.Flags()&checker.TypeFlagsBigInt != 0:
+		return tracker.NewBigIntLiteral("0n")
+	case ch.IsArrayLikeType(t):
+		return tracker.NewArrayLiteralExpression(tracker.NewNodeList(nil), false /*multiLine*/)
+	}
+	return tracker.NewIdentifier("undefined")
+}
+
+// getDeclarePropertyAction handles `this.x` or `C.x` where `x` is not declared in class `C`,
+// offering to declare `x` with the type of the value assigned to it, if any.
+func getDeclarePropertyAction(ctx context.Context, fixContext *codeFixContext, token *ast.Node) *codeFixAction {
+	if !ast.IsIdentifier(token) || !ast.IsPropertyAccessExpression(token.Parent) || token.Parent.Name() != token {
+		return nil
+	}
+	ch := fixContext.checker
+	access := token.Parent
+	leftType := ch.GetTypeAtLocation(access.Expression())
+	symbol := leftType.Symbol()
+	if symbol == nil || symbol.Flags&ast.SymbolFlagsClass == 0 || symbol.ValueDeclaration == nil {
+		return nil
+	}
+	classDeclaration := symbol.ValueDeclaration
+	declarationFile := ast.GetSourceFileOfNode(classDeclaration)
+	if !ast.IsClassLike(classDeclaration) || declarationFile.IsDeclarationFile || fixContext.program.IsSourceFileFromExternalLibrary(declarationFile) {
+		return nil
+	}
+	isStatic := leftType == ch.GetTypeOfSymbol(symbol)
+
+	var typeNode *ast.Node
+	if ast.IsBinaryExpression(access.Parent) && access.Parent.AsBinaryExpression().OperatorToken.Kind == ast.KindEqualsToken && access.Parent.AsBinaryExpression().Left == access {
+		widenedType := ch.GetWidenedType(ch.GetBaseTypeOfLiteralType(ch.GetTypeAtLocation(access.Parent.AsBinaryExpression().Right)))
+		typeNode = ch.TypeToTypeNode(widenedType, classDeclaration, nodebuilder.FlagsNoTruncation)
+	}
+
+	return fixContext.newCodeFixAction(ctx, addMissingMemberFixName, diagnostics.FormatMessage(diagnostics.Declare_property_0, token.Text()).Message(), func(tracker *changeTracker) {
+		if typeNode == nil {
+			typeNode = tracker.NewKeywordTypeNode(ast.KindAnyKeyword)
+		}
+		var modifiers *ast.ModifierList
+		if isStatic {
+			modifiers = tracker.NewModifierList([]*ast.Node{tracker.NewModifier(ast.KindStaticKeyword)})
+		}
+		property := tracker.NewPropertyDeclaration(modifiers, tracker.NewIdentifier(token.Text()), nil /*postfixToken*/, typeNode, nil /*initializer*/)
+		tracker.insertMemberAtStart(declarationFile, classDeclaration, property)
+	})
+}
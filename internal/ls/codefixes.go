@@ -0,0 +1,121 @@
+package ls
+
+import (
+	"context"
+	"sync"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+// codeFixProvider computes quick fixes for diagnostics with one of the given error codes.
+type codeFixProvider struct {
+	errorCodes     []int32
+	getCodeActions func(ctx context.Context, fixContext *codeFixContext) []*codeFixAction
+}
+
+type codeFixContext struct {
+	ls         *LanguageService
+	program    *compiler.Program
+	checker    *checker.Checker
+	sourceFile *ast.SourceFile
+	span       core.TextRange
+	errorCode  int32
+}
+
+type codeFixAction struct {
+	// Short name identifying the kind of fix, e.g. "import" or "fixSpelling"
+	fixName string
+	// Description of the code action to display in the UI of the editor
+	description string
+	// Text changes to apply to each file as part of the code action, keyed by file name
+	changes map[string][]*lsproto.TextEdit
+}
+
+var codeFixProviders = []*codeFixProvider{
+	importFixProvider,
+	addMissingAwaitFixProvider,
+	unusedIdentifierFixProvider,
+	addMissingMemberFixProvider,
+	spellingFixProvider,
+}
+
+var codeFixProvidersByErrorCode = sync.OnceValue(func() map[int32][]*codeFixProvider {
+	providers := make(map[int32][]*codeFixProvider)
+	for _, provider := range codeFixProviders {
+		for _, code := range provider.errorCodes {
+			providers[code] = append(providers[code], provider)
+		}
+	}
+	return providers
+})
+
+// newCodeFixAction runs `fn` against a fresh change tracker and packages the resulting edits.
+func (c *codeFixContext) newCodeFixAction(ctx context.Context, fixName string, description string, fn func(tracker *changeTracker)) *codeFixAction {
+	tracker := c.ls.newChangeTracker(ctx)
+	fn(tracker)
+	changes := tracker.getChanges()
+	if len(changes) == 0 {
+		return nil
+	}
+	return &codeFixAction{fixName: fixName, description: description, changes: changes}
+}
+
+func (l *LanguageService) getQuickFixes(ctx context.Context, program *compiler.Program, file *ast.SourceFile, lspRange lsproto.Range) []lsproto.CommandOrCodeAction {
+	start := int(l.converters.LineAndCharacterToPosition(file, lspRange.Start))
+	end := int(l.converters.LineAndCharacterToPosition(file, lspRange.End))
+
+	var fixableDiagnostics []*ast.Diagnostic
+	for _, diags := range [][]*ast.Diagnostic{
+		program.GetSyntacticDiagnostics(ctx, file),
+		program.GetSemanticDiagnostics(ctx, file),
+		program.GetSuggestionDiagnostics(ctx, file),
+	} {
+		for _, diag := range diags {
+			if diag.File() == file && diag.Pos() <= end && diag.End() >= start && len(codeFixProvidersByErrorCode()[diag.Code()]) != 0 {
+				fixableDiagnostics = append(fixableDiagnostics, diag)
+			}
+		}
+	}
+	if len(fixableDiagnostics) == 0 {
+		return nil
+	}
+
+	ch, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	var actions []lsproto.CommandOrCodeAction
+	for _, diag := range fixableDiagnostics {
+		if ctx.Err() != nil {
+			return nil
+		}
+		fixContext := &codeFixContext{
+			ls:         l,
+			program:    program,
+			checker:    ch,
+			sourceFile: file,
+			span:       diag.Loc(),
+			errorCode:  diag.Code(),
+		}
+		lspDiagnostic := toLSPDiagnostic(l.converters, core.GetLocale(ctx), diag)
+		for _, provider := range codeFixProvidersByErrorCode()[diag.Code()] {
+			for _, fix := range provider.getCodeActions(ctx, fixContext) {
+				if fix == nil {
+					continue
+				}
+				actions = append(actions, lsproto.CommandOrCodeAction{
+					CodeAction: &lsproto.CodeAction{
+						Title:       fix.description,
+						Kind:        ptrTo(lsproto.CodeActionKindQuickFix),
+						Diagnostics: &[]*lsproto.Diagnostic{lspDiagnostic},
+						Edit:        toWorkspaceEdit(fix.changes),
+					},
+				})
+			}
+		}
+	}
+	return actions
+}
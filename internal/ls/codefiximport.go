@@ -0,0 +1,174 @@
+package ls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+const importFixName = "import"
+
+var importFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Cannot_find_name_0.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_the_instance_member_this_0.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_the_static_member_1_0.Code(),
+		diagnostics.Cannot_find_namespace_0.Code(),
+		diagnostics.X_0_only_refers_to_a_type_but_is_being_used_as_a_value_here.Code(),
+		diagnostics.No_value_exists_in_scope_for_the_shorthand_property_0_Either_declare_one_or_provide_an_initializer.Code(),
+		diagnostics.X_0_cannot_be_used_as_a_value_because_it_was_imported_using_import_type.Code(),
+		// !!! UMD global imports
+	},
+	getCodeActions: getImportCodeActions,
+}
+
+type importFixInfo struct {
+	fix                 *ImportFix
+	symbolName          string
+	errorIdentifierText string
+}
+
+func getImportCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	infos := getImportFixInfos(ctx, fixContext)
+	actions := make([]*codeFixAction, 0, len(infos))
+	for _, info := range infos {
+		action := fixContext.ls.codeActionForFix(ctx, fixContext.sourceFile, info.symbolName, info.fix, info.symbolName != info.errorIdentifierText /*includeSymbolNameInDescription*/)
+		if len(action.changes) == 0 {
+			continue
+		}
+		actions = append(actions, &codeFixAction{
+			fixName:     importFixName,
+			description: action.description,
+			changes:     map[string][]*lsproto.TextEdit{fixContext.sourceFile.FileName(): action.changes},
+		})
+	}
+	return actions
+}
+
+func getImportFixInfos(ctx context.Context, fixContext *codeFixContext) []*importFixInfo {
+	sourceFile := fixContext.sourceFile
+	symbolToken := astnav.GetTokenAtPosition(sourceFile, fixContext.span.Pos())
+	if !ast.IsIdentifier(symbolToken) {
+		return nil
+	}
+	l := fixContext.ls
+	ch := fixContext.checker
+	program := fixContext.program
+
+	var infos []*importFixInfo
+	// !!! JSX namespace imports
+	symbolName := symbolToken.Text()
+	// "default" is a keyword and not a legal identifier for the import, but appears as an identifier.
+	if symbolName == ast.InternalSymbolNameDefault {
+		return nil
+	}
+	isValidTypeOnlyUseSite := ast.IsValidTypeOnlyAliasUseSite(symbolToken)
+	useRequire := getShouldUseRequire(sourceFile, program)
+	usagePosition := l.converters.PositionToLineAndCharacter(sourceFile, core.TextPos(astnav.GetStartOfNode(symbolToken, sourceFile, false)))
+	exportInfos := l.getExportInfosForSymbolName(ctx, ch, sourceFile, symbolName, ast.IsJsxTagName(symbolToken), getMeaningFromLocation(symbolToken))
+	for infosForSymbol := range exportInfos.Values() {
+		_, fixes := l.getImportFixes(ch, infosForSymbol, &usagePosition, &isValidTypeOnlyUseSite, &useRequire, sourceFile, false /*fromCacheOnly*/)
+		for _, fix := range fixes {
+			// !!! JSDoc type imports and promoting type-only imports are not yet supported
+			if fix.kind == ImportFixKindJsdocTypeImport || fix.kind == ImportFixKindPromoteTypeOnly {
+				continue
+			}
+			infos = append(infos, &importFixInfo{fix: fix, symbolName: symbolName, errorIdentifierText: symbolToken.Text()})
+		}
+	}
+
+	packageJsonImportFilter := l.createPackageJsonImportFilter(sourceFile)
+	toPath := func(fileName string) tspath.Path {
+		return tspath.ToPath(fileName, program.GetCurrentDirectory(), program.UseCaseSensitiveFileNames())
+	}
+	slices.SortStableFunc(infos, func(a, b *importFixInfo) int {
+		if a.fix.kind != b.fix.kind {
+			return int(a.fix.kind) - int(b.fix.kind)
+		}
+		return l.compareModuleSpecifiers(a.fix, b.fix, sourceFile, packageJsonImportFilter.allowsImportingSpecifier, toPath)
+	})
+	return infos
+}
+
+// getExportInfosForSymbolName finds every export named `symbolName` (or default export that would be
+// imported by that name) that is visible to `importingFile`, grouped by the exported symbol.
+func (l *LanguageService) getExportInfosForSymbolName(
+	ctx context.Context,
+	ch *checker.Checker,
+	importingFile *ast.SourceFile,
+	symbolName string,
+	isJsxTagName bool,
+	currentTokenMeaning ast.SemanticMeaning,
+) *collections.OrderedMap[ast.SymbolId, []*SymbolExportInfo] {
+	var result collections.OrderedMap[ast.SymbolId, []*SymbolExportInfo]
+	compilerOptions := l.GetProgram().Options()
+	addSymbol := func(moduleSymbol *ast.Symbol, toFile *ast.SourceFile, exportedSymbol *ast.Symbol, exportKind ExportKind, isFromPackageJson bool) {
+		// !!! isImportableFile / packageJsonFilter.allowsImportingAmbientModule
+		if toFile == importingFile {
+			return
+		}
+		moduleFileName := ""
+		if toFile != nil {
+			moduleFileName = toFile.FileName()
+		}
+		key := ast.GetSymbolId(ch.SkipAlias(exportedSymbol))
+		infos, _ := result.Get(key)
+		result.Set(key, append(infos, &SymbolExportInfo{
+			symbol:            exportedSymbol,
+			moduleSymbol:      moduleSymbol,
+			moduleFileName:    moduleFileName,
+			exportKind:        exportKind,
+			targetFlags:       ch.SkipAlias(exportedSymbol).Flags,
+			isFromPackageJson: isFromPackageJson,
+		}))
+	}
+	moduleCount := 0
+	forEachExternalModuleToImportFrom(
+		ch,
+		l.GetProgram(),
+		func(moduleSymbol *ast.Symbol, moduleFile *ast.SourceFile, ch *checker.Checker, isFromPackageJson bool) {
+			if moduleCount = moduleCount + 1; moduleCount%100 == 0 && ctx.Err() != nil {
+				return
+			}
+			defaultInfo := getDefaultLikeExportInfo(moduleSymbol, ch)
+			if defaultInfo != nil && symbolFlagsHaveMeaning(ch.GetSymbolFlags(defaultInfo.exportingModuleSymbol), currentTokenMeaning) &&
+				forEachNameOfDefaultExport(defaultInfo.exportingModuleSymbol, ch, compilerOptions.GetEmitScriptTarget(), func(name string, capitalizedName string) string {
+					if isJsxTagName && capitalizedName != "" {
+						name = capitalizedName
+					}
+					return core.IfElse(name == symbolName, name, "")
+				}) != "" {
+				addSymbol(moduleSymbol, moduleFile, defaultInfo.exportingModuleSymbol, defaultInfo.exportKind, isFromPackageJson)
+			}
+			// check exports with the same name
+			if exportSymbolWithIdenticalName := ch.TryGetMemberInModuleExportsAndProperties(symbolName, moduleSymbol); exportSymbolWithIdenticalName != nil &&
+				symbolFlagsHaveMeaning(ch.GetSymbolFlags(exportSymbolWithIdenticalName), currentTokenMeaning) {
+				addSymbol(moduleSymbol, moduleFile, exportSymbolWithIdenticalName, ExportKindNamed, isFromPackageJson)
+			}
+		},
+	)
+	return &result
+}
+
+func symbolFlagsHaveMeaning(flags ast.SymbolFlags, meaning ast.SemanticMeaning) bool {
+	switch {
+	case meaning == ast.SemanticMeaningAll:
+		return true
+	case meaning&ast.SemanticMeaningValue != 0:
+		return flags&ast.SymbolFlagsValue != 0
+	case meaning&ast.SemanticMeaningType != 0:
+		return flags&ast.SymbolFlagsType != 0
+	case meaning&ast.SemanticMeaningNamespace != 0:
+		return flags&ast.SymbolFlagsNamespace != 0
+	}
+	return false
+}
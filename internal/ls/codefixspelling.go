@@ -0,0 +1,104 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const spellingFixName = "fixSpelling"
+
+var spellingFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Property_0_does_not_exist_on_type_1_Did_you_mean_2.Code(),
+		diagnostics.Property_0_may_not_exist_on_type_1_Did_you_mean_2.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Could_not_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Cannot_find_namespace_0_Did_you_mean_1.Code(),
+		diagnostics.X_0_has_no_exported_member_named_1_Did_you_mean_2.Code(),
+	},
+	getCodeActions: getSpellingCodeActions,
+}
+
+func getSpellingCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	sourceFile := fixContext.sourceFile
+	node := astnav.GetTokenAtPosition(sourceFile, fixContext.span.Pos())
+	if !ast.IsIdentifier(node) && !ast.IsPrivateIdentifier(node) {
+		return nil
+	}
+	suggestedSymbol := getSpellingSuggestion(fixContext, node)
+	if suggestedSymbol == nil {
+		return nil
+	}
+	suggestion := suggestedSymbol.Name
+	isPropertyAccess := ast.IsPropertyAccessExpression(node.Parent) && node.Parent.Name() == node
+	newText := suggestion
+	if isPropertyAccess && !scanner.IsIdentifierText(suggestion, sourceFile.LanguageVariant) {
+		// `a.foo` must become `a["foo bar"]` when the suggestion is not a valid identifier.
+		newText = ""
+	}
+
+	action := fixContext.newCodeFixAction(ctx, spellingFixName, diagnostics.FormatMessage(diagnostics.Change_spelling_to_0, suggestion).Message(), func(tracker *changeTracker) {
+		if newText != "" {
+			tracker.replaceNodeWithText(sourceFile, node, newText)
+			return
+		}
+		access := node.Parent
+		elementAccess := tracker.NewElementAccessExpression(
+			access.Expression(),
+			access.AsPropertyAccessExpression().QuestionDotToken,
+			tracker.NewStringLiteral(suggestion),
+			access.Flags&ast.NodeFlagsOptionalChain,
+		)
+		tracker.replaceNode(sourceFile, access, elementAccess, nil)
+	})
+	if action == nil {
+		return nil
+	}
+	return []*codeFixAction{action}
+}
+
+func getSpellingSuggestion(fixContext *codeFixContext, node *ast.Node) *ast.Symbol {
+	ch := fixContext.checker
+	parent := node.Parent
+	switch {
+	case ast.IsPropertyAccessExpression(parent) && parent.Name() == node:
+		containingType := ch.GetTypeAtLocation(parent.Expression())
+		if parent.Flags&ast.NodeFlagsOptionalChain != 0 {
+			containingType = ch.GetNonNullableType(containingType)
+		}
+		return ch.GetSuggestedSymbolForNonexistentProperty(node, containingType)
+	case ast.IsImportSpecifier(parent) && parent.Name() == node:
+		importDeclaration := ast.FindAncestorKind(parent, ast.KindImportDeclaration)
+		if importDeclaration == nil {
+			return nil
+		}
+		moduleSymbol := ch.GetSymbolAtLocation(importDeclaration.AsImportDeclaration().ModuleSpecifier)
+		if moduleSymbol == nil {
+			return nil
+		}
+		return ch.GetSuggestedSymbolForNonexistentModule(node, moduleSymbol)
+	case ast.IsIdentifier(node):
+		meaning := getMeaningFromLocation(node)
+		return ch.GetSuggestedSymbolForNonexistentSymbol(node, node.Text(), semanticMeaningToSymbolFlags(meaning))
+	}
+	return nil
+}
+
+func semanticMeaningToSymbolFlags(meaning ast.SemanticMeaning) ast.SymbolFlags {
+	var flags ast.SymbolFlags
+	if meaning&ast.SemanticMeaningNamespace != 0 {
+		flags |= ast.SymbolFlagsNamespace
+	}
+	if meaning&ast.SemanticMeaningType != 0 {
+		flags |= ast.SymbolFlagsType
+	}
+	if meaning&ast.SemanticMeaningValue != 0 {
+		flags |= ast.SymbolFlagsValue
+	}
+	return core.IfElse(flags == 0, ast.SymbolFlagsAll, flags)
+}
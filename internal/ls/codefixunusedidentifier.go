@@ -0,0 +1,168 @@
+package ls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	unusedIdentifierDeleteFixName = "unusedIdentifier_delete"
+	unusedIdentifierPrefixFixName = "unusedIdentifier_prefix"
+)
+
+var unusedIdentifierFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.X_0_is_declared_but_its_value_is_never_read.Code(),
+		diagnostics.X_0_is_declared_but_never_used.Code(),
+		diagnostics.Property_0_is_declared_but_its_value_is_never_read.Code(),
+		diagnostics.All_imports_in_import_declaration_are_unused.Code(),
+		diagnostics.All_destructured_elements_are_unused.Code(),
+		diagnostics.All_variables_are_unused.Code(),
+	},
+	getCodeActions: getUnusedIdentifierCodeActions,
+}
+
+func getUnusedIdentifierCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	sourceFile := fixContext.sourceFile
+	token := astnav.GetTokenAtPosition(sourceFile, fixContext.span.Pos())
+
+	deleteAction := func(description string, nodes ...*ast.Node) []*codeFixAction {
+		action := fixContext.newCodeFixAction(ctx, unusedIdentifierDeleteFixName, description, func(tracker *changeTracker) {
+			for _, node := range nodes {
+				tracker.delete(sourceFile, node)
+			}
+		})
+		if action == nil {
+			return nil
+		}
+		return []*codeFixAction{action}
+	}
+
+	// import { a, b } from "./a";
+	if importDecl := tryGetFullImport(token); importDecl != nil {
+		moduleSpecifier := scanner.GetSourceTextOfNodeFromSourceFile(sourceFile, importDecl.AsImportDeclaration().ModuleSpecifier, false /*includeTrivia*/)
+		return deleteAction(diagnostics.FormatMessage(diagnostics.Remove_import_from_0, moduleSpecifier).Message(), importDecl)
+	}
+
+	// const { a, b } = c;
+	if token.Kind == ast.KindOpenBraceToken && ast.IsObjectBindingPattern(token.Parent) {
+		pattern := token.Parent
+		if ast.IsParameter(pattern.Parent) {
+			return nil
+		}
+		if ast.IsVariableDeclaration(pattern.Parent) && ast.IsVariableDeclarationList(pattern.Parent.Parent) &&
+			len(pattern.Parent.Parent.AsVariableDeclarationList().Declarations.Nodes) == 1 {
+			return deleteAction(diagnostics.Remove_unused_destructuring_declaration.Message(), pattern.Parent)
+		}
+		return deleteAction(diagnostics.Remove_unused_destructuring_declaration.Message(), pattern.AsBindingPattern().Elements.Nodes...)
+	}
+
+	// const a = 1, b = 2;
+	if ast.IsVariableDeclarationList(token.Parent) && (token.Kind == ast.KindConstKeyword || token.Kind == ast.KindLetKeyword || token.Kind == ast.KindVarKeyword) {
+		declarations := token.Parent.AsVariableDeclarationList().Declarations.Nodes
+		if ast.IsVariableStatement(token.Parent.Parent) {
+			return deleteAction(diagnostics.Remove_variable_statement.Message(), declarations...)
+		}
+		return nil
+	}
+
+	if !ast.IsIdentifier(token) && !ast.IsPrivateIdentifier(token) {
+		return nil
+	}
+
+	var actions []*codeFixAction
+	if declaration := getDeletableDeclarationOfName(token); declaration != nil {
+		actions = append(actions, deleteAction(diagnostics.FormatMessage(diagnostics.Remove_unused_declaration_for_Colon_0, token.Text()).Message(), declaration)...)
+	}
+	if canPrefixWithUnderscore(token) {
+		if action := fixContext.newCodeFixAction(ctx, unusedIdentifierPrefixFixName, diagnostics.FormatMessage(diagnostics.Prefix_0_with_an_underscore, token.Text()).Message(), func(tracker *changeTracker) {
+			tracker.replaceNodeWithText(sourceFile, token, "_"+token.Text())
+		}); action != nil {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// tryGetFullImport returns the import declaration when the error is reported on the `import` keyword,
+// which the checker does when every binding of the declaration is unused.
+func tryGetFullImport(token *ast.Node) *ast.Node {
+	if token.Kind == ast.KindImportKeyword && ast.IsImportDeclaration(token.Parent) {
+		return token.Parent
+	}
+	return nil
+}
+
+func getDeletableDeclarationOfName(name *ast.Node) *ast.Node {
+	parent := name.Parent
+	switch parent.Kind {
+	case ast.KindParameter:
+		if !isDeletableParameter(parent) {
+			return nil
+		}
+		return parent
+	case ast.KindBindingElement:
+		if ast.IsParameter(ast.GetRootDeclaration(parent)) {
+			return nil
+		}
+		return parent
+	case ast.KindImportClause, ast.KindImportSpecifier, ast.KindNamespaceImport, ast.KindImportEqualsDeclaration:
+		if parent.Name() != name {
+			return nil
+		}
+		if ast.IsImportClause(parent) {
+			// Delete only the default binding; `deleteDeclaration` deletes the clause when it has no other bindings.
+			return name
+		}
+		return parent
+	case ast.KindVariableDeclaration, ast.KindTypeParameter:
+		if parent.Name() != name {
+			return nil
+		}
+		return parent
+	case ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration,
+		ast.KindEnumDeclaration, ast.KindPropertyDeclaration, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		if parent.Name() != name {
+			return nil
+		}
+		return parent
+	}
+	return nil
+}
+
+// isDeletableParameter reports whether removing `parameter` cannot change which argument binds to
+// any other parameter: only trailing parameters of functions that are not overloads or callbacks.
+func isDeletableParameter(parameter *ast.Node) bool {
+	function := parameter.Parent
+	parameters := function.Parameters()
+	if parameters[len(parameters)-1] != parameter {
+		return false
+	}
+	switch function.Kind {
+	case ast.KindFunctionDeclaration, ast.KindMethodDeclaration, ast.KindConstructor:
+		return function.Body() != nil
+	case ast.KindFunctionExpression, ast.KindArrowFunction:
+		// The function may be a callback whose caller passes arguments positionally.
+		return !ast.IsCallLikeExpression(function.Parent)
+	}
+	return false
+}
+
+func canPrefixWithUnderscore(name *ast.Node) bool {
+	if strings.HasPrefix(name.Text(), "_") {
+		return false
+	}
+	parent := name.Parent
+	switch parent.Kind {
+	case ast.KindParameter:
+		return parent.Name() == name
+	case ast.KindBindingElement:
+		return parent.Name() == name && ast.IsArrayBindingPattern(parent.Parent)
+	}
+	return false
+}
@@ -2,17 +2,81 @@ package ls
 
 import (
 	"context"
+	"encoding/hex"
 	"strings"
 
+	"github.com/go-json-experiment/json"
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
 	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/zeebo/xxh3"
+	"golang.org/x/text/language"
 )
 
 func (l *LanguageService) ProvideDiagnostics(ctx context.Context, uri lsproto.DocumentUri) (lsproto.DocumentDiagnosticResponse, error) {
 	program, file := l.getProgramAndFile(uri)
+	return lsproto.RelatedFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
+		FullDocumentDiagnosticReport: &lsproto.RelatedFullDocumentDiagnosticReport{
+			Items: toLSPDiagnostics(l.converters, core.GetLocale(ctx), getDiagnosticsForFile(ctx, program, file)...),
+		},
+	}, nil
+}
 
+// ProvideWorkspaceDiagnostics reports the diagnostics of every file in the given programs, other than
+// default library, external library, and JSON files. The result ID of a report is a hash of its diagnostics,
+// so files whose diagnostics did not change since the client's previous request are reported as unchanged.
+func ProvideWorkspaceDiagnostics(ctx context.Context, programs []*compiler.Program, converters *Converters, previousResultIds []lsproto.PreviousResultId) (lsproto.WorkspaceDiagnosticResponse, error) {
+	previousResultIdsByURI := make(map[lsproto.DocumentUri]string, len(previousResultIds))
+	for _, previous := range previousResultIds {
+		previousResultIdsByURI[previous.Uri] = previous.Value
+	}
+
+	// A file may belong to several projects; it is reported for the first of them.
+	var seen collections.Set[lsproto.DocumentUri]
+	items := []lsproto.WorkspaceFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{}
+	for _, program := range programs {
+		for _, file := range program.GetSourceFiles() {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if program.IsSourceFileDefaultLibrary(file.Path()) || program.IsSourceFileFromExternalLibrary(file) || ast.IsJsonSourceFile(file) {
+				continue
+			}
+			uri := FileNameToDocumentURI(file.FileName())
+			if !seen.AddIfAbsent(uri) {
+				continue
+			}
+			diagnostics := toLSPDiagnostics(converters, core.GetLocale(ctx), getDiagnosticsForFile(ctx, program, file)...)
+			resultID, err := getDiagnosticsResultID(diagnostics)
+			if err != nil {
+				return nil, err
+			}
+			if previousResultIdsByURI[uri] == resultID {
+				items = append(items, lsproto.WorkspaceFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
+					UnchangedDocumentDiagnosticReport: &lsproto.WorkspaceUnchangedDocumentDiagnosticReport{
+						ResultId: resultID,
+						Uri:      uri,
+					},
+				})
+			} else {
+				items = append(items, lsproto.WorkspaceFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
+					FullDocumentDiagnosticReport: &lsproto.WorkspaceFullDocumentDiagnosticReport{
+						ResultId: &resultID,
+						Items:    diagnostics,
+						Uri:      uri,
+					},
+				})
+			}
+		}
+	}
+	return &lsproto.WorkspaceDiagnosticReport{Items: items}, nil
+}
+
+func getDiagnosticsForFile(ctx context.Context, program *compiler.Program, file *ast.SourceFile) [][]*ast.Diagnostic {
 	diagnostics := make([][]*ast.Diagnostic, 0, 4)
 	diagnostics = append(diagnostics, program.GetSyntacticDiagnostics(ctx, file))
 	diagnostics = append(diagnostics, program.GetSemanticDiagnostics(ctx, file))
@@ -22,15 +86,19 @@ func (l *LanguageService) ProvideDiagnostics(ctx context.Context, uri lsproto.Do
 	if program.Options().GetEmitDeclarations() {
 		diagnostics = append(diagnostics, program.GetDeclarationDiagnostics(ctx, file))
 	}
+	return diagnostics
+}
 
-	return lsproto.RelatedFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
-		FullDocumentDiagnosticReport: &lsproto.RelatedFullDocumentDiagnosticReport{
-			Items: toLSPDiagnostics(l.converters, diagnostics...),
-		},
-	}, nil
+func getDiagnosticsResultID(diagnostics []*lsproto.Diagnostic) (string, error) {
+	data, err := json.Marshal(diagnostics)
+	if err != nil {
+		return "", err
+	}
+	hashBytes := xxh3.Hash128(data).Bytes()
+	return hex.EncodeToString(hashBytes[:]), nil
 }
 
-func toLSPDiagnostics(converters *Converters, diagnostics ...[]*ast.Diagnostic) []*lsproto.Diagnostic {
+func toLSPDiagnostics(converters *Converters, locale language.Tag, diagnostics ...[]*ast.Diagnostic) []*lsproto.Diagnostic {
 	size := 0
 	for _, diagSlice := range diagnostics {
 		size += len(diagSlice)
@@ -38,13 +106,13 @@ func toLSPDiagnostics(converters *Converters, diagnostics ...[]*ast.Diagnostic)
 	lspDiagnostics := make([]*lsproto.Diagnostic, 0, size)
 	for _, diagSlice := range diagnostics {
 		for _, diag := range diagSlice {
-			lspDiagnostics = append(lspDiagnostics, toLSPDiagnostic(converters, diag))
+			lspDiagnostics = append(lspDiagnostics, toLSPDiagnostic(converters, locale, diag))
 		}
 	}
 	return lspDiagnostics
 }
 
-func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsproto.Diagnostic {
+func toLSPDiagnostic(converters *Converters, locale language.Tag, diagnostic *ast.Diagnostic) *lsproto.Diagnostic {
 	var severity lsproto.DiagnosticSeverity
 	switch diagnostic.Category() {
 	case diagnostics.CategorySuggestion:
@@ -64,7 +132,7 @@ func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsprot
 				Uri:   FileNameToDocumentURI(related.File().FileName()),
 				Range: converters.ToLSPRange(related.File(), related.Loc()),
 			},
-			Message: related.Message(),
+			Message: related.Localize(locale),
 		})
 	}
 
@@ -85,19 +153,19 @@ func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsprot
 			Integer: ptrTo(diagnostic.Code()),
 		},
 		Severity:           &severity,
-		Message:            messageChainToString(diagnostic),
+		Message:            messageChainToString(diagnostic, locale),
 		Source:             ptrTo("ts"),
 		RelatedInformation: ptrToSliceIfNonEmpty(relatedInformation),
 		Tags:               ptrToSliceIfNonEmpty(tags),
 	}
 }
 
-func messageChainToString(diagnostic *ast.Diagnostic) string {
+func messageChainToString(diagnostic *ast.Diagnostic, locale language.Tag) string {
 	if len(diagnostic.MessageChain()) == 0 {
-		return diagnostic.Message()
+		return diagnostic.Localize(locale)
 	}
 	var b strings.Builder
-	diagnosticwriter.WriteFlattenedDiagnosticMessage(&b, diagnostic, "\n")
+	diagnosticwriter.WriteFlattenedDiagnosticMessage(&b, diagnostic, "\n", locale)
 	return b.String()
 }
 
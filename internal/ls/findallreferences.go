@@ -459,12 +459,15 @@ func (l *LanguageService) ProvideRename(ctx context.Context, params *lsproto.Ren
 	if node.Kind != ast.KindIdentifier {
 		return lsproto.WorkspaceEditOrNull{}, nil
 	}
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+	if _, message := getRenameLocation(program, checker, sourceFile, position); message != nil {
+		return lsproto.WorkspaceEditOrNull{}, nil
+	}
 	options := refOptions{use: referenceUseRename, useAliasesForRename: true}
 	symbolsAndEntries := l.getReferencedSymbolsForNode(ctx, position, node, program, program.GetSourceFiles(), options, nil)
 	entries := core.FlatMap(symbolsAndEntries, func(s *SymbolAndEntries) []*referenceEntry { return s.references })
 	changes := make(map[lsproto.DocumentUri][]*lsproto.TextEdit)
-	checker, done := program.GetTypeChecker(ctx)
-	defer done()
 	for _, entry := range entries {
 		uri := FileNameToDocumentURI(l.getFileNameOfEntry(entry))
 		textEdit := &lsproto.TextEdit{
@@ -932,6 +935,26 @@ func getPossibleSymbolReferenceNodes(sourceFile *ast.SourceFile, symbolName stri
 	})
 }
 
+// isSymbolReferencedInFile reports whether the symbol declared by `definition` is referenced elsewhere in `sourceFile`.
+func isSymbolReferencedInFile(definition *ast.Node, ch *checker.Checker, sourceFile *ast.SourceFile) bool {
+	symbol := ch.GetSymbolAtLocation(definition)
+	if symbol == nil {
+		return false
+	}
+	for _, token := range getPossibleSymbolReferenceNodes(sourceFile, symbol.Name, sourceFile.AsNode()) {
+		if !ast.IsIdentifier(token) || token == definition || token.Text() != definition.Text() {
+			continue
+		}
+		referenceSymbol := ch.GetSymbolAtLocation(token)
+		if referenceSymbol == symbol ||
+			ch.GetShorthandAssignmentValueSymbol(token.Parent) == symbol ||
+			ast.IsExportSpecifier(token.Parent) && getLocalSymbolForExportSpecifier(token.AsIdentifier(), referenceSymbol, token.Parent.AsExportSpecifier(), ch) == symbol {
+			return true
+		}
+	}
+	return false
+}
+
 func getPossibleSymbolReferencePositions(sourceFile *ast.SourceFile, symbolName string, container *ast.Node) []int {
 	positions := []int{}
 
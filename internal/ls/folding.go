@@ -0,0 +1,419 @@
+package ls
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+)
+
+type outliningSpan struct {
+	textSpan core.TextRange
+	// Nil for code spans.
+	kind       *lsproto.FoldingRangeKind
+	bannerText string
+}
+
+func (l *LanguageService) ProvideFoldingRange(ctx context.Context, documentURI lsproto.DocumentUri) (lsproto.FoldingRangeResponse, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	collector := &outliningSpanCollector{ctx: ctx, file: file, factory: &ast.NodeFactory{}, depthRemaining: maxOutliningDepth}
+	collector.addNodeOutliningSpans()
+	if ctx.Err() != nil {
+		return lsproto.FoldingRangesOrNull{}, ctx.Err()
+	}
+	collector.addRegionOutliningSpans()
+	slices.SortStableFunc(collector.spans, func(a, b *outliningSpan) int {
+		return a.textSpan.Pos() - b.textSpan.Pos()
+	})
+
+	foldingRanges := make([]*lsproto.FoldingRange, 0, len(collector.spans))
+	for _, span := range collector.spans {
+		if foldingRange := l.convertOutliningSpan(file, span); foldingRange != nil {
+			foldingRanges = append(foldingRanges, foldingRange)
+		}
+	}
+	return lsproto.FoldingRangesOrNull{FoldingRanges: &foldingRanges}, nil
+}
+
+// Characters that close a span and should stay visible when the span is folded.
+const foldEndPairCharacters = "}])`>"
+
+func (l *LanguageService) convertOutliningSpan(file *ast.SourceFile, span *outliningSpan) *lsproto.FoldingRange {
+	start := l.converters.PositionToLineAndCharacter(file, core.TextPos(span.textSpan.Pos()))
+	end := l.converters.PositionToLineAndCharacter(file, core.TextPos(span.textSpan.End()))
+	endLine := end.Line
+	if end.Character > 0 && span.textSpan.End() > 0 && strings.IndexByte(foldEndPairCharacters, file.Text()[span.textSpan.End()-1]) >= 0 {
+		endLine = max(endLine-1, start.Line)
+	}
+	if endLine <= start.Line {
+		return nil
+	}
+	foldingRange := &lsproto.FoldingRange{
+		StartLine: start.Line,
+		EndLine:   endLine,
+		Kind:      span.kind,
+	}
+	if span.bannerText != "" {
+		foldingRange.CollapsedText = &span.bannerText
+	}
+	return foldingRange
+}
+
+const maxOutliningDepth = 40
+
+type outliningSpanCollector struct {
+	ctx            context.Context
+	file           *ast.SourceFile
+	factory        *ast.NodeFactory
+	spans          []*outliningSpan
+	depthRemaining int
+}
+
+func (c *outliningSpanCollector) addSpan(span *outliningSpan) {
+	if span != nil {
+		c.spans = append(c.spans, span)
+	}
+}
+
+func (c *outliningSpanCollector) addNodeOutliningSpans() {
+	statements := append(slices.Clip(c.file.Statements.Nodes), c.file.EndOfFileToken)
+	n := len(statements)
+	current := 0
+	for current < n {
+		for current < n && !ast.IsAnyImportSyntax(statements[current]) {
+			c.visitNode(statements[current])
+			current++
+		}
+		if current == n {
+			break
+		}
+		firstImport := current
+		for current < n && ast.IsAnyImportSyntax(statements[current]) {
+			c.visitNode(statements[current])
+			current++
+		}
+		lastImport := current - 1
+		if lastImport != firstImport {
+			if importKeyword := findChildOfKind(statements[firstImport], ast.KindImportKeyword, c.file); importKeyword != nil {
+				start := scanner.GetTokenPosOfNode(importKeyword, c.file, false /*includeJSDoc*/)
+				c.addSpan(&outliningSpan{textSpan: core.NewTextRange(start, statements[lastImport].End()), kind: ptrTo(lsproto.FoldingRangeKindImports)})
+			}
+		}
+	}
+}
+
+func (c *outliningSpanCollector) visit(node *ast.Node) bool {
+	c.visitNode(node)
+	return c.ctx.Err() != nil
+}
+
+func (c *outliningSpanCollector) visitNode(n *ast.Node) {
+	if n == nil || c.depthRemaining == 0 || c.ctx.Err() != nil || n.Flags&ast.NodeFlagsReparsed != 0 {
+		return
+	}
+
+	if ast.IsDeclaration(n) || ast.IsVariableStatement(n) || ast.IsReturnStatement(n) || ast.IsCallOrNewExpression(n) || n.Kind == ast.KindEndOfFile {
+		c.addOutliningForLeadingCommentsForNode(n)
+	}
+	if ast.IsFunctionLike(n) && ast.IsBinaryExpression(n.Parent) && ast.IsPropertyAccessExpression(n.Parent.AsBinaryExpression().Left) {
+		c.addOutliningForLeadingCommentsForNode(n.Parent.AsBinaryExpression().Left)
+	}
+	if ast.IsBlock(n) || ast.IsModuleBlock(n) {
+		c.addOutliningForLeadingCommentsForPos(n.StatementList().End())
+	}
+	if ast.IsClassLike(n) || ast.IsInterfaceDeclaration(n) {
+		c.addOutliningForLeadingCommentsForPos(n.MemberList().End())
+	}
+
+	c.addSpan(c.getOutliningSpanForNode(n))
+
+	c.depthRemaining--
+	switch {
+	case ast.IsCallExpression(n):
+		c.depthRemaining++
+		c.visitNode(n.Expression())
+		c.depthRemaining--
+		for _, argument := range n.Arguments() {
+			c.visitNode(argument)
+		}
+		for _, typeArgument := range n.TypeArguments() {
+			c.visitNode(typeArgument)
+		}
+	case ast.IsIfStatement(n) && n.AsIfStatement().ElseStatement != nil && ast.IsIfStatement(n.AsIfStatement().ElseStatement):
+		// Consider an 'else if' to be on the same depth as the 'if'.
+		ifStatement := n.AsIfStatement()
+		c.visitNode(ifStatement.Expression)
+		c.visitNode(ifStatement.ThenStatement)
+		c.depthRemaining++
+		c.visitNode(ifStatement.ElseStatement)
+		c.depthRemaining--
+	default:
+		n.ForEachChild(c.visit)
+	}
+	c.depthRemaining++
+}
+
+func (c *outliningSpanCollector) addRegionOutliningSpans() {
+	var regions []*outliningSpan
+	text := c.file.Text()
+	for line, lineStart := range c.file.ECMALineMap() {
+		currentLineStart := int(lineStart)
+		lineEnd := scanner.GetECMAEndLinePosition(c.file, line)
+		isStart, name, ok := parseRegionDelimiter(text[currentLineStart:lineEnd])
+		if !ok || isInComment(c.file, currentLineStart, astnav.GetTokenAtPosition(c.file, currentLineStart)) != nil {
+			continue
+		}
+		if isStart {
+			if name == "" {
+				name = "#region"
+			}
+			start := currentLineStart + strings.Index(text[currentLineStart:], "//")
+			regions = append(regions, &outliningSpan{textSpan: core.NewTextRange(start, lineEnd), kind: ptrTo(lsproto.FoldingRangeKindRegion), bannerText: name})
+		} else if len(regions) != 0 {
+			region := regions[len(regions)-1]
+			regions = regions[:len(regions)-1]
+			region.textSpan = core.NewTextRange(region.textSpan.Pos(), lineEnd)
+			c.spans = append(c.spans, region)
+		}
+	}
+}
+
+var regionDelimiterRegExp = regexp.MustCompile(`^#(end)?region(.*)\r?$`)
+
+func parseRegionDelimiter(lineText string) (isStart bool, name string, ok bool) {
+	// We trim the leading whitespace and // without the regex since the
+	// multiple potential whitespace matches can make for some gnarly backtracking behavior
+	lineText = strings.TrimLeftFunc(lineText, stringutil.IsWhiteSpaceLike)
+	if !strings.HasPrefix(lineText, "//") {
+		return false, "", false
+	}
+	lineText = strings.TrimFunc(lineText[2:], stringutil.IsWhiteSpaceLike)
+	result := regionDelimiterRegExp.FindStringSubmatch(lineText)
+	if result == nil {
+		return false, "", false
+	}
+	return result[1] == "", strings.TrimFunc(result[2], stringutil.IsWhiteSpaceLike), true
+}
+
+func (c *outliningSpanCollector) addOutliningForLeadingCommentsForNode(n *ast.Node) {
+	if ast.IsJsxText(n) {
+		return
+	}
+	c.addOutliningForLeadingCommentsForPos(n.Pos())
+}
+
+func (c *outliningSpanCollector) addOutliningForLeadingCommentsForPos(pos int) {
+	firstSingleLineCommentStart := -1
+	lastSingleLineCommentEnd := -1
+	singleLineCommentCount := 0
+	text := c.file.Text()
+
+	combineAndAddMultipleSingleLineComments := func() {
+		// Only outline spans of two or more consecutive single line comments
+		if singleLineCommentCount > 1 {
+			c.addSpan(&outliningSpan{textSpan: core.NewTextRange(firstSingleLineCommentStart, lastSingleLineCommentEnd), kind: ptrTo(lsproto.FoldingRangeKindComment)})
+		}
+	}
+
+	for comment := range scanner.GetLeadingCommentRanges(c.factory, text, pos) {
+		switch comment.Kind {
+		case ast.KindSingleLineCommentTrivia:
+			// never fold region delimiters into single-line comment regions
+			if _, _, ok := parseRegionDelimiter(text[comment.Pos():comment.End()]); ok {
+				combineAndAddMultipleSingleLineComments()
+				singleLineCommentCount = 0
+				break
+			}
+			// For single line comments, combine consecutive ones (2 or more) into
+			// a single span from the start of the first till the end of the last
+			if singleLineCommentCount == 0 {
+				firstSingleLineCommentStart = comment.Pos()
+			}
+			lastSingleLineCommentEnd = comment.End()
+			singleLineCommentCount++
+		case ast.KindMultiLineCommentTrivia:
+			combineAndAddMultipleSingleLineComments()
+			c.addSpan(&outliningSpan{textSpan: comment.TextRange, kind: ptrTo(lsproto.FoldingRangeKindComment)})
+			singleLineCommentCount = 0
+		}
+	}
+	combineAndAddMultipleSingleLineComments()
+}
+
+func (c *outliningSpanCollector) getOutliningSpanForNode(n *ast.Node) *outliningSpan {
+	switch n.Kind {
+	case ast.KindBlock:
+		if ast.IsFunctionLike(n.Parent) {
+			return c.functionSpan(n.Parent, n)
+		}
+		// Check if the block is standalone, or 'attached' to some parent statement.
+		// If the latter, we want to collapse the block, but consider its hint span
+		// to be the entire span of the parent.
+		switch n.Parent.Kind {
+		case ast.KindDoStatement, ast.KindForInStatement, ast.KindForOfStatement, ast.KindForStatement, ast.KindIfStatement,
+			ast.KindWhileStatement, ast.KindWithStatement, ast.KindCatchClause:
+			return c.spanForNode(n, true /*useFullStart*/, ast.KindOpenBraceToken)
+		case ast.KindTryStatement:
+			// Could be the try-block, or the finally-block.
+			tryStatement := n.Parent.AsTryStatement()
+			if tryStatement.TryBlock == n || tryStatement.FinallyBlock == n {
+				return c.spanForNode(n, true /*useFullStart*/, ast.KindOpenBraceToken)
+			}
+		}
+		// Block was a standalone block.  In this case we want to only collapse
+		// the span of the block, independent of any parent span.
+		return &outliningSpan{textSpan: createRangeFromNode(n, c.file)}
+	case ast.KindModuleBlock:
+		return c.spanForNode(n, true /*useFullStart*/, ast.KindOpenBraceToken)
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindInterfaceDeclaration, ast.KindEnumDeclaration,
+		ast.KindCaseBlock, ast.KindTypeLiteral, ast.KindObjectBindingPattern:
+		return c.spanForNode(n, true /*useFullStart*/, ast.KindOpenBraceToken)
+	case ast.KindTupleType:
+		return c.spanForNode(n, !ast.IsTupleTypeNode(n.Parent) /*useFullStart*/, ast.KindOpenBracketToken)
+	case ast.KindCaseClause, ast.KindDefaultClause:
+		statements := n.AsCaseOrDefaultClause().Statements
+		if len(statements.Nodes) == 0 {
+			return nil
+		}
+		return &outliningSpan{textSpan: statements.Loc}
+	case ast.KindObjectLiteralExpression:
+		return c.spanForObjectOrArrayLiteral(n, ast.KindOpenBraceToken)
+	case ast.KindArrayLiteralExpression:
+		return c.spanForObjectOrArrayLiteral(n, ast.KindOpenBracketToken)
+	case ast.KindJsxElement:
+		element := n.AsJsxElement()
+		textSpan := core.NewTextRange(scanner.GetTokenPosOfNode(element.OpeningElement, c.file, false /*includeJSDoc*/), element.ClosingElement.End())
+		tagName := scanner.GetTextOfNode(element.OpeningElement.TagName())
+		return &outliningSpan{textSpan: textSpan, bannerText: "<" + tagName + ">...</" + tagName + ">"}
+	case ast.KindJsxFragment:
+		fragment := n.AsJsxFragment()
+		textSpan := core.NewTextRange(scanner.GetTokenPosOfNode(fragment.OpeningFragment, c.file, false /*includeJSDoc*/), fragment.ClosingFragment.End())
+		return &outliningSpan{textSpan: textSpan, bannerText: "<>...</>"}
+	case ast.KindJsxSelfClosingElement, ast.KindJsxOpeningElement:
+		attributes := n.Attributes()
+		if len(attributes.Properties()) == 0 {
+			return nil
+		}
+		return &outliningSpan{textSpan: createRangeFromNode(attributes, c.file)}
+	case ast.KindTemplateExpression, ast.KindNoSubstitutionTemplateLiteral:
+		if n.Kind == ast.KindNoSubstitutionTemplateLiteral && len(n.Text()) == 0 {
+			return nil
+		}
+		return &outliningSpan{textSpan: createRangeFromNode(n, c.file)}
+	case ast.KindArrayBindingPattern:
+		return c.spanForNode(n, !ast.IsBindingElement(n.Parent) /*useFullStart*/, ast.KindOpenBracketToken)
+	case ast.KindArrowFunction:
+		return c.spanForArrowFunction(n)
+	case ast.KindCallExpression:
+		return c.spanForCallExpression(n)
+	case ast.KindParenthesizedExpression:
+		return c.spanForParenthesizedExpression(n)
+	case ast.KindNamedImports, ast.KindNamedExports, ast.KindImportAttributes:
+		return c.spanForImportExportElements(n)
+	}
+	return nil
+}
+
+func (c *outliningSpanCollector) spanForImportExportElements(n *ast.Node) *outliningSpan {
+	var elements []*ast.Node
+	if ast.IsImportAttributes(n) {
+		elements = n.AsImportAttributes().Attributes.Nodes
+	} else {
+		elements = n.Elements()
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+	openToken := findChildOfKind(n, ast.KindOpenBraceToken, c.file)
+	closeToken := findChildOfKind(n, ast.KindCloseBraceToken, c.file)
+	if openToken == nil || closeToken == nil || c.positionsAreOnSameLine(openToken.Pos(), closeToken.Pos()) {
+		return nil
+	}
+	return c.spanBetweenTokens(openToken, closeToken, false /*useFullStart*/)
+}
+
+func (c *outliningSpanCollector) spanForCallExpression(n *ast.Node) *outliningSpan {
+	if len(n.Arguments()) == 0 {
+		return nil
+	}
+	openToken := findChildOfKind(n, ast.KindOpenParenToken, c.file)
+	closeToken := findChildOfKind(n, ast.KindCloseParenToken, c.file)
+	if openToken == nil || closeToken == nil || c.positionsAreOnSameLine(openToken.Pos(), closeToken.Pos()) {
+		return nil
+	}
+	return c.spanBetweenTokens(openToken, closeToken, true /*useFullStart*/)
+}
+
+func (c *outliningSpanCollector) spanForArrowFunction(n *ast.Node) *outliningSpan {
+	body := n.Body()
+	if ast.IsBlock(body) || ast.IsParenthesizedExpression(body) || c.positionsAreOnSameLine(body.Pos(), body.End()) {
+		return nil
+	}
+	return &outliningSpan{textSpan: body.Loc}
+}
+
+func (c *outliningSpanCollector) spanForParenthesizedExpression(n *ast.Node) *outliningSpan {
+	textSpan := createRangeFromNode(n, c.file)
+	if c.positionsAreOnSameLine(textSpan.Pos(), textSpan.End()) {
+		return nil
+	}
+	return &outliningSpan{textSpan: textSpan}
+}
+
+func (c *outliningSpanCollector) spanForObjectOrArrayLiteral(n *ast.Node, open ast.Kind) *outliningSpan {
+	// If the block has no leading keywords and is inside an array literal or call expression,
+	// we only want to collapse the span of the block.
+	// Otherwise, the collapsed section will include the end of the previous line.
+	return c.spanForNode(n, !ast.IsArrayLiteralExpression(n.Parent) && !ast.IsCallExpression(n.Parent) /*useFullStart*/, open)
+}
+
+func (c *outliningSpanCollector) spanForNode(n *ast.Node, useFullStart bool, open ast.Kind) *outliningSpan {
+	closeKind := ast.KindCloseBraceToken
+	if open == ast.KindOpenBracketToken {
+		closeKind = ast.KindCloseBracketToken
+	}
+	openToken := findChildOfKind(n, open, c.file)
+	closeToken := findChildOfKind(n, closeKind, c.file)
+	if openToken == nil || closeToken == nil {
+		return nil
+	}
+	return c.spanBetweenTokens(openToken, closeToken, useFullStart)
+}
+
+func (c *outliningSpanCollector) functionSpan(n *ast.Node, body *ast.Node) *outliningSpan {
+	openToken := c.tryGetFunctionOpenToken(n, body)
+	closeToken := findChildOfKind(body, ast.KindCloseBraceToken, c.file)
+	if openToken == nil || closeToken == nil {
+		return nil
+	}
+	return c.spanBetweenTokens(openToken, closeToken, true /*useFullStart*/)
+}
+
+func (c *outliningSpanCollector) tryGetFunctionOpenToken(n *ast.Node, body *ast.Node) *ast.Node {
+	if parameters := n.ParameterList(); parameters != nil && !c.positionsAreOnSameLine(scanner.SkipTrivia(c.file.Text(), parameters.Pos()), parameters.End()) {
+		if openParenToken := findChildOfKind(n, ast.KindOpenParenToken, c.file); openParenToken != nil {
+			return openParenToken
+		}
+	}
+	return findChildOfKind(body, ast.KindOpenBraceToken, c.file)
+}
+
+func (c *outliningSpanCollector) spanBetweenTokens(openToken *ast.Node, closeToken *ast.Node, useFullStart bool) *outliningSpan {
+	start := openToken.Pos()
+	if !useFullStart {
+		start = scanner.GetTokenPosOfNode(openToken, c.file, false /*includeJSDoc*/)
+	}
+	return &outliningSpan{textSpan: core.NewTextRange(start, closeToken.End())}
+}
+
+func (c *outliningSpanCollector) positionsAreOnSameLine(pos1 int, pos2 int) bool {
+	lineMap := c.file.ECMALineMap()
+	return scanner.ComputeLineOfPosition(lineMap, pos1) == scanner.ComputeLineOfPosition(lineMap, pos2)
+}
@@ -0,0 +1,399 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/nodebuilder"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const inlayHintTypeFlags = nodebuilder.FlagsIgnoreErrors | nodebuilder.FlagsAllowUniqueESSymbolType | nodebuilder.FlagsUseAliasDefinedOutsideCurrentScope
+
+func (l *LanguageService) ProvideInlayHint(ctx context.Context, params *lsproto.InlayHintParams) (lsproto.InlayHintResponse, error) {
+	preferences := l.UserPreferences()
+	if !shouldProvideInlayHints(preferences) {
+		return lsproto.InlayHintsOrNull{}, nil
+	}
+	program, file := l.getProgramAndFile(params.TextDocument.Uri)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	start := int(l.converters.LineAndCharacterToPosition(file, params.Range.Start))
+	end := int(l.converters.LineAndCharacterToPosition(file, params.Range.End))
+	state := &inlayHintState{
+		ctx:         ctx,
+		ls:          l,
+		file:        file,
+		checker:     c,
+		preferences: preferences,
+		span:        core.NewTextRange(start, end),
+		hints:       []*lsproto.InlayHint{},
+	}
+	state.visit(file.AsNode())
+	if ctx.Err() != nil {
+		return lsproto.InlayHintsOrNull{}, ctx.Err()
+	}
+	return lsproto.InlayHintsOrNull{InlayHints: &state.hints}, nil
+}
+
+func shouldProvideInlayHints(preferences *UserPreferences) bool {
+	return shouldShowParameterNameHints(preferences) ||
+		preferences.IncludeInlayFunctionParameterTypeHints ||
+		preferences.IncludeInlayVariableTypeHints ||
+		preferences.IncludeInlayPropertyDeclarationTypeHints ||
+		preferences.IncludeInlayFunctionLikeReturnTypeHints ||
+		preferences.IncludeInlayEnumMemberValueHints
+}
+
+func shouldShowParameterNameHints(preferences *UserPreferences) bool {
+	return preferences.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsLiterals ||
+		preferences.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsAll
+}
+
+type inlayHintState struct {
+	ctx         context.Context
+	ls          *LanguageService
+	file        *ast.SourceFile
+	checker     *checker.Checker
+	preferences *UserPreferences
+	span        core.TextRange
+	hints       []*lsproto.InlayHint
+}
+
+func (s *inlayHintState) visit(node *ast.Node) bool {
+	if node == nil || node.End()-node.Pos() == 0 || node.Flags&ast.NodeFlagsReparsed != 0 {
+		return false
+	}
+	switch node.Kind {
+	case ast.KindModuleDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindFunctionDeclaration,
+		ast.KindClassExpression, ast.KindFunctionExpression, ast.KindMethodDeclaration, ast.KindArrowFunction:
+		if s.ctx.Err() != nil {
+			return true
+		}
+	}
+	if node.Pos() > s.span.End() || node.End() < s.span.Pos() {
+		return false
+	}
+	if ast.IsTypeNode(node) && !ast.IsExpressionWithTypeArguments(node) {
+		return false
+	}
+
+	switch {
+	case s.preferences.IncludeInlayVariableTypeHints && ast.IsVariableDeclaration(node):
+		s.visitVariableLikeDeclaration(node)
+	case s.preferences.IncludeInlayPropertyDeclarationTypeHints && ast.IsPropertyDeclaration(node):
+		s.visitVariableLikeDeclaration(node)
+	case s.preferences.IncludeInlayEnumMemberValueHints && ast.IsEnumMember(node):
+		s.visitEnumMember(node)
+	case shouldShowParameterNameHints(s.preferences) && (ast.IsCallExpression(node) || ast.IsNewExpression(node)):
+		s.visitCallOrNewExpression(node)
+	default:
+		if s.preferences.IncludeInlayFunctionParameterTypeHints && ast.IsFunctionLikeDeclaration(node) && checker.HasContextSensitiveParameters(node) {
+			s.visitFunctionLikeForParameterType(node)
+		}
+		if s.preferences.IncludeInlayFunctionLikeReturnTypeHints && isSignatureSupportingReturnAnnotation(node) {
+			s.visitFunctionDeclarationLikeForReturnType(node)
+		}
+	}
+	return node.ForEachChild(s.visit)
+}
+
+func isSignatureSupportingReturnAnnotation(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindArrowFunction, ast.KindFunctionExpression, ast.KindFunctionDeclaration, ast.KindMethodDeclaration, ast.KindGetAccessor:
+		return true
+	}
+	return false
+}
+
+func (s *inlayHintState) addParameterHint(text string, parameter *ast.Node, position int, isFirstVariadicArgument bool) {
+	if isFirstVariadicArgument {
+		text = "..." + text
+	}
+	var label lsproto.StringOrInlayHintLabelParts
+	if s.preferences.InteractiveInlayHints {
+		parameterFile := ast.GetSourceFileOfNode(parameter)
+		location := s.ls.getMappedLocation(parameterFile.FileName(), createRangeFromNode(parameter, parameterFile))
+		label.InlayHintLabelParts = &[]*lsproto.InlayHintLabelPart{
+			{Value: text, Location: &location},
+			{Value: ":"},
+		}
+	} else {
+		label.String = ptrTo(text + ":")
+	}
+	s.hints = append(s.hints, &lsproto.InlayHint{
+		Position:     s.ls.createLspPosition(position, s.file),
+		Label:        label,
+		Kind:         ptrTo(lsproto.InlayHintKindParameter),
+		PaddingRight: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) addTypeHint(text string, position int) {
+	s.hints = append(s.hints, &lsproto.InlayHint{
+		Position:    s.ls.createLspPosition(position, s.file),
+		Label:       lsproto.StringOrInlayHintLabelParts{String: ptrTo(": " + text)},
+		Kind:        ptrTo(lsproto.InlayHintKindType),
+		PaddingLeft: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) addEnumMemberValueHint(text string, position int) {
+	s.hints = append(s.hints, &lsproto.InlayHint{
+		Position:    s.ls.createLspPosition(position, s.file),
+		Label:       lsproto.StringOrInlayHintLabelParts{String: ptrTo("= " + text)},
+		PaddingLeft: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) visitEnumMember(member *ast.Node) {
+	if member.Initializer() != nil {
+		return
+	}
+	if value := s.checker.GetConstantValue(member); value != nil {
+		s.addEnumMemberValueHint(fmt.Sprint(value), member.End())
+	}
+}
+
+func isModuleReferenceType(t *checker.Type) bool {
+	return t.Symbol() != nil && t.Symbol().Flags&ast.SymbolFlagsModule != 0
+}
+
+func (s *inlayHintState) visitVariableLikeDeclaration(decl *ast.Node) {
+	if decl.Initializer() == nil && !(ast.IsPropertyDeclaration(decl) && s.checker.GetTypeAtLocation(decl).Flags()&checker.TypeFlagsAny == 0) ||
+		ast.IsBindingPattern(decl.Name()) ||
+		ast.IsVariableDeclaration(decl) && !isHintableDeclaration(decl) {
+		return
+	}
+	if decl.Type() != nil {
+		return
+	}
+	declarationType := s.checker.GetTypeAtLocation(decl)
+	if isModuleReferenceType(declarationType) {
+		return
+	}
+	hintText := s.typeToString(declarationType)
+	if !s.preferences.IncludeInlayVariableTypeHintsWhenTypeMatchesName && strings.EqualFold(scanner.GetTextOfNode(decl.Name()), hintText) {
+		return
+	}
+	s.addTypeHint(hintText, decl.Name().End())
+}
+
+func (s *inlayHintState) visitCallOrNewExpression(expr *ast.Node) {
+	args := expr.Arguments()
+	if len(args) == 0 {
+		return
+	}
+	signature := s.checker.GetResolvedSignature(expr)
+	if signature == nil {
+		return
+	}
+	signatureParamPos := 0
+	for _, originalArg := range args {
+		arg := ast.SkipParentheses(originalArg)
+		if s.preferences.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsLiterals && !isHintableLiteral(arg) {
+			signatureParamPos++
+			continue
+		}
+
+		spreadArgs := 0
+		if ast.IsSpreadElement(arg) {
+			spreadType := s.checker.GetTypeAtLocation(arg.Expression())
+			if checker.IsTupleType(spreadType) {
+				tupleType := spreadType.TargetTupleType()
+				if tupleType.FixedLength() == 0 {
+					continue
+				}
+				firstOptionalIndex := slices.IndexFunc(tupleType.ElementFlags(), func(f checker.ElementFlags) bool {
+					return f&checker.ElementFlagsRequired == 0
+				})
+				spreadArgs = core.IfElse(firstOptionalIndex < 0, tupleType.FixedLength(), firstOptionalIndex)
+			}
+		}
+
+		identifierInfo := s.checker.GetParameterIdentifierInfoAtPosition(signature, signatureParamPos)
+		signatureParamPos += max(spreadArgs, 1)
+		if identifierInfo == nil {
+			continue
+		}
+		if !s.preferences.IncludeInlayParameterNameHintsWhenArgumentMatchesName &&
+			identifierOrAccessExpressionPostfixMatchesParameterName(arg, identifierInfo.ParameterName) &&
+			!identifierInfo.IsRestParameter {
+			continue
+		}
+		if s.leadingCommentsContainsParameterName(arg, identifierInfo.ParameterName) {
+			continue
+		}
+		s.addParameterHint(identifierInfo.ParameterName, identifierInfo.Parameter, scanner.GetTokenPosOfNode(originalArg, s.file, false /*includeJSDoc*/), identifierInfo.IsRestParameter)
+	}
+}
+
+func identifierOrAccessExpressionPostfixMatchesParameterName(expr *ast.Node, parameterName string) bool {
+	switch {
+	case ast.IsIdentifier(expr):
+		return expr.Text() == parameterName
+	case ast.IsPropertyAccessExpression(expr):
+		return expr.Name().Text() == parameterName
+	}
+	return false
+}
+
+// leadingCommentsContainsParameterName reports whether the argument is already labeled with a
+// `/* name */` comment, in which case a parameter name hint would be redundant.
+func (s *inlayHintState) leadingCommentsContainsParameterName(node *ast.Node, name string) bool {
+	if !scanner.IsIdentifierText(name, s.file.LanguageVariant) {
+		return false
+	}
+	text := s.file.Text()
+	factory := &ast.NodeFactory{}
+	// Comments on the same line as the preceding token are not leading comments, so check both.
+	for _, comments := range []iter.Seq[ast.CommentRange]{
+		scanner.GetTrailingCommentRanges(factory, text, node.Pos()),
+		scanner.GetLeadingCommentRanges(factory, text, node.Pos()),
+	} {
+		for comment := range comments {
+			if commentIsParameterName(text[comment.Pos():comment.End()], name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// commentIsParameterName reports whether a comment is of the form `/* name */` or `/** name */`.
+func commentIsParameterName(comment string, name string) bool {
+	comment, ok := strings.CutPrefix(comment, "/*")
+	if !ok {
+		return false
+	}
+	comment, ok = strings.CutSuffix(comment, "*/")
+	if !ok {
+		return false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(comment, "*")) == name
+}
+
+func isHintableLiteral(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindPrefixUnaryExpression:
+		operand := node.AsPrefixUnaryExpression().Operand
+		return ast.IsLiteralExpression(operand) || ast.IsIdentifier(operand) && isInfinityOrNaNString(operand.Text())
+	case ast.KindTrueKeyword, ast.KindFalseKeyword, ast.KindNullKeyword, ast.KindNoSubstitutionTemplateLiteral, ast.KindTemplateExpression:
+		return true
+	case ast.KindIdentifier:
+		name := node.Text()
+		return name == "undefined" || isInfinityOrNaNString(name)
+	}
+	return ast.IsLiteralExpression(node)
+}
+
+func isInfinityOrNaNString(name string) bool {
+	return name == "Infinity" || name == "-Infinity" || name == "NaN"
+}
+
+func (s *inlayHintState) visitFunctionDeclarationLikeForReturnType(decl *ast.Node) {
+	if ast.IsArrowFunction(decl) && findChildOfKind(decl, ast.KindOpenParenToken, s.file) == nil {
+		return
+	}
+	if decl.Type() != nil || decl.Body() == nil {
+		return
+	}
+	signature := s.checker.GetSignatureFromDeclaration(decl)
+	if signature == nil {
+		return
+	}
+	if typePredicate := s.checker.GetTypePredicateOfSignature(signature); typePredicate != nil && typePredicate.Type() != nil {
+		s.addTypeHint(s.typePredicateToString(typePredicate), s.getTypeAnnotationPosition(decl))
+		return
+	}
+	returnType := s.checker.GetReturnTypeOfSignature(signature)
+	if isModuleReferenceType(returnType) {
+		return
+	}
+	s.addTypeHint(s.typeToString(returnType), s.getTypeAnnotationPosition(decl))
+}
+
+func (s *inlayHintState) getTypeAnnotationPosition(decl *ast.Node) int {
+	if closeParenToken := findChildOfKind(decl, ast.KindCloseParenToken, s.file); closeParenToken != nil {
+		return closeParenToken.End()
+	}
+	return decl.ParameterList().End()
+}
+
+func (s *inlayHintState) visitFunctionLikeForParameterType(node *ast.Node) {
+	signature := s.checker.GetSignatureFromDeclaration(node)
+	if signature == nil {
+		return
+	}
+	pos := 0
+	for _, param := range node.Parameters() {
+		isThisParameter := ast.IsThisParameter(param)
+		if isHintableDeclaration(param) {
+			var symbol *ast.Symbol
+			if isThisParameter {
+				symbol = signature.ThisParameter()
+			} else if pos < len(signature.Parameters()) {
+				symbol = signature.Parameters()[pos]
+			}
+			s.addParameterTypeHint(param, symbol)
+		}
+		if !isThisParameter {
+			pos++
+		}
+	}
+}
+
+func (s *inlayHintState) addParameterTypeHint(node *ast.Node, symbol *ast.Symbol) {
+	if node.Type() != nil || symbol == nil {
+		return
+	}
+	valueDeclaration := symbol.ValueDeclaration
+	if valueDeclaration == nil || !ast.IsParameter(valueDeclaration) {
+		return
+	}
+	signatureParamType := s.checker.GetTypeOfSymbolAtLocation(symbol, valueDeclaration)
+	if isModuleReferenceType(signatureParamType) {
+		return
+	}
+	position := node.Name().End()
+	if questionToken := node.QuestionToken(); questionToken != nil {
+		position = questionToken.End()
+	}
+	s.addTypeHint(s.typeToString(signatureParamType), position)
+}
+
+func isHintableDeclaration(node *ast.Node) bool {
+	if (ast.IsPartOfParameterDeclaration(node) || ast.IsVariableDeclaration(node) && ast.IsVarConst(node)) && node.Initializer() != nil {
+		initializer := ast.SkipParentheses(node.Initializer())
+		return !(isHintableLiteral(initializer) || ast.IsNewExpression(initializer) || ast.IsObjectLiteralExpression(initializer) || ast.IsAssertionExpression(initializer))
+	}
+	return true
+}
+
+func (s *inlayHintState) typeToString(t *checker.Type) string {
+	return s.printSingleLine(s.checker.TypeToTypeNode(t, nil /*enclosingDeclaration*/, inlayHintTypeFlags))
+}
+
+func (s *inlayHintState) typePredicateToString(predicate *checker.TypePredicate) string {
+	return s.printSingleLine(s.checker.TypePredicateToTypePredicateNode(predicate, nil /*enclosingDeclaration*/, inlayHintTypeFlags))
+}
+
+func (s *inlayHintState) printSingleLine(node *ast.Node) string {
+	if node == nil {
+		panic("should always get typenode")
+	}
+	writer, putWriter := printer.GetSingleLineStringWriter()
+	defer putWriter()
+	p := printer.NewPrinter(printer.PrinterOptions{RemoveComments: true}, printer.PrintHandlers{}, printer.NewEmitContext())
+	p.Write(node, s.file, writer, nil /*sourceMapGenerator*/)
+	return writer.String()
+}
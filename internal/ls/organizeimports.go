@@ -2,24 +2,25 @@ package ls
 
 import (
 	"cmp"
+	"context"
 	"math"
+	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
 	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
 	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/stringutil"
 	"github.com/microsoft/typescript-go/internal/tspath"
-)
-
-var (
-	caseInsensitiveOrganizeImportsComparer = []func(a, b string) int{getOrganizeImportsOrdinalStringComparer(true)}
-	caseSensitiveOrganizeImportsComparer   = []func(a, b string) int{getOrganizeImportsOrdinalStringComparer(false)}
-	organizeImportsComparers               = []func(a, b string) int{
-		caseInsensitiveOrganizeImportsComparer[0],
-		caseSensitiveOrganizeImportsComparer[0],
-	}
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
 )
 
 // statement = anyImportOrRequireStatement
@@ -143,6 +144,75 @@ func getOrganizeImportsOrdinalStringComparer(ignoreCase bool) func(a, b string)
 	return stringutil.CompareStringsCaseSensitive
 }
 
+// getOrganizeImportsComparer returns the string comparer for the collation selected in the user preferences.
+func getOrganizeImportsComparer(ctx context.Context, preferences *UserPreferences, ignoreCase bool) func(a, b string) int {
+	if preferences != nil && preferences.OrganizeImportsCollation == OrganizeImportsCollationUnicode {
+		return getOrganizeImportsUnicodeStringComparer(ctx, preferences, ignoreCase)
+	}
+	return getOrganizeImportsOrdinalStringComparer(ignoreCase)
+}
+
+func getOrganizeImportsUnicodeStringComparer(ctx context.Context, preferences *UserPreferences, ignoreCase bool) func(a, b string) int {
+	locale := getOrganizeImportsLocale(ctx, preferences)
+	var options []collate.Option
+	if !preferences.OrganizeImportsAccentCollation {
+		options = append(options, collate.IgnoreDiacritics)
+	}
+	if preferences.OrganizeImportsNumericCollation {
+		options = append(options, collate.Numeric)
+	}
+	caseInsensitiveCollator := collate.New(locale, append(options, collate.IgnoreCase)...)
+	if ignoreCase {
+		return caseInsensitiveCollator.CompareString
+	}
+	collator := collate.New(locale, options...)
+	caseFirst := preferences.OrganizeImportsCaseFirst
+	if caseFirst == OrganizeImportsCaseFirstFalse {
+		return collator.CompareString
+	}
+	// The collator always uses the locale's case ordering, so strings that differ
+	// only in case are ordered here according to `caseFirst`.
+	return func(a, b string) int {
+		if result := caseInsensitiveCollator.CompareString(a, b); result != 0 {
+			return result
+		}
+		if result := compareCaseFirst(a, b, caseFirst); result != 0 {
+			return result
+		}
+		return collator.CompareString(a, b)
+	}
+}
+
+func compareCaseFirst(a, b string, caseFirst OrganizeImportsCaseFirst) int {
+	for a != "" && b != "" {
+		ra, sizeA := utf8.DecodeRuneInString(a)
+		rb, sizeB := utf8.DecodeRuneInString(b)
+		if upperA, upperB := unicode.IsUpper(ra), unicode.IsUpper(rb); ra != rb && upperA != upperB {
+			if upperA == (caseFirst == OrganizeImportsCaseFirstUpper) {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	return 0
+}
+
+// getOrganizeImportsLocale resolves the locale used for unicode collation. `"auto"` uses the locale of the
+// client, and unknown locales fall back to `"en"` so that sorting is stable across machines.
+func getOrganizeImportsLocale(ctx context.Context, preferences *UserPreferences) language.Tag {
+	if preferences.OrganizeImportsLocale == "auto" {
+		if locale := core.GetLocale(ctx); locale != language.Und {
+			return locale
+		}
+	} else if preferences.OrganizeImportsLocale != "" {
+		if locale, err := language.Parse(preferences.OrganizeImportsLocale); err == nil {
+			return locale
+		}
+	}
+	return language.English
+}
+
 // getModuleSpecifierExpression returns the module specifier expression from an import/require statement
 func getModuleSpecifierExpression(declaration *ast.Statement) *ast.Expression {
 	switch declaration.Kind {
@@ -248,7 +318,10 @@ func compareImportOrExportSpecifiers(s1 *ast.Node, s2 *ast.Node, comparer func(a
 	if preferences != nil {
 		typeOrder = preferences.OrganizeImportsTypeOrder
 	}
+	return compareImportOrExportSpecifiersWithTypeOrder(s1, s2, comparer, typeOrder)
+}
 
+func compareImportOrExportSpecifiersWithTypeOrder(s1 *ast.Node, s2 *ast.Node, comparer func(a, b string) int, typeOrder OrganizeImportsTypeOrder) int {
 	s1Name := s1.Name().Text()
 	s2Name := s2.Name().Text()
 
@@ -290,22 +363,21 @@ func getImportSpecifierInsertionIndex(sortedImports []*ast.Node, newImport *ast.
 }
 
 // getOrganizeImportsStringComparerWithDetection detects the string comparer to use based on existing imports
-func (l *LanguageService) getOrganizeImportsStringComparerWithDetection(originalImportDecls []*ast.Statement) (comparer func(a, b string) int, isSorted bool) {
-	result := detectModuleSpecifierCaseBySort([][]*ast.Statement{originalImportDecls}, getComparers(l.UserPreferences()))
+func (l *LanguageService) getOrganizeImportsStringComparerWithDetection(ctx context.Context, originalImportDecls []*ast.Statement) (comparer func(a, b string) int, isSorted bool) {
+	result := detectModuleSpecifierCaseBySort([][]*ast.Statement{originalImportDecls}, getComparers(ctx, l.UserPreferences()))
 	return result.comparer, result.isSorted
 }
 
-func getComparers(preferences *UserPreferences) []func(a string, b string) int {
-	if preferences != nil {
-		switch preferences.OrganizeImportsIgnoreCase {
-		case core.TSTrue:
-			return caseInsensitiveOrganizeImportsComparer
-		case core.TSFalse:
-			return caseSensitiveOrganizeImportsComparer
-		}
+// getComparers returns the comparers to choose from when detecting the case sensitivity of existing imports,
+// or only the comparer for the configured case sensitivity when it is not "auto".
+func getComparers(ctx context.Context, preferences *UserPreferences) []func(a string, b string) int {
+	if preferences != nil && !preferences.OrganizeImportsIgnoreCase.IsUnknown() {
+		return []func(a, b string) int{getOrganizeImportsComparer(ctx, preferences, preferences.OrganizeImportsIgnoreCase.IsTrue())}
+	}
+	return []func(a, b string) int{
+		getOrganizeImportsComparer(ctx, preferences, true /*ignoreCase*/),
+		getOrganizeImportsComparer(ctx, preferences, false /*ignoreCase*/),
 	}
-
-	return organizeImportsComparers
 }
 
 type caseSensitivityDetectionResult struct {
@@ -370,17 +442,599 @@ func measureSortedness[T any](arr []T, comparer func(a, b T) int) int {
 	return i
 }
 
-// getNamedImportSpecifierComparerWithDetection detects the appropriate comparer for named imports
-func (l *LanguageService) getNamedImportSpecifierComparerWithDetection(importDecl *ast.Node, sourceFile *ast.SourceFile) (specifierComparer func(s1, s2 *ast.Node) int, isSorted core.Tristate) {
+// getNamedImportSpecifierComparerWithDetection returns the comparer to insert named imports into importDecl with, and
+// whether its existing specifiers are sorted by it. Preferences that are not set explicitly are detected from the
+// existing specifiers of importDecl, or from the other imports of the file if importDecl has too few to tell.
+func (l *LanguageService) getNamedImportSpecifierComparerWithDetection(ctx context.Context, importDecl *ast.Node, sourceFile *ast.SourceFile) (specifierComparer func(s1, s2 *ast.Node) int, isSorted core.Tristate) {
 	preferences := l.UserPreferences()
-	specifierComparer = getNamedImportSpecifierComparer(preferences, getComparers(preferences)[0])
-	// Try to detect from the current import declaration
-	if (preferences == nil || preferences.OrganizeImportsIgnoreCase.IsUnknown() || preferences.OrganizeImportsTypeOrder == OrganizeImportsTypeOrderLast) &&
+	specifierComparer = getNamedImportSpecifierComparer(preferences, getComparers(ctx, preferences)[0])
+	if (preferences == nil || preferences.OrganizeImportsIgnoreCase.IsUnknown() || preferences.OrganizeImportsTypeOrder == OrganizeImportsTypeOrderAuto) &&
 		importDecl.Kind == ast.KindImportDeclaration {
-		// For now, just return the default comparer
-		// Full detection logic would require porting detectNamedImportOrganizationBySort
-		return specifierComparer, core.TSUnknown
+		importDecls := []*ast.Statement{importDecl}
+		if len(getNamedImportSpecifiers(importDecl)) < 2 {
+			importDecls = core.Filter(sourceFile.Statements.Nodes, ast.IsImportDeclaration)
+		}
+		if result := detectNamedImportOrganizationBySort(importDecls, getComparers(ctx, preferences), getTypeOrders(preferences)); result != nil {
+			specifierComparer = func(s1, s2 *ast.Node) int {
+				return compareImportOrExportSpecifiersWithTypeOrder(s1, s2, result.comparer, result.typeOrder)
+			}
+		}
+		return specifierComparer, core.BoolToTristate(measureSortedness(getNamedImportSpecifiers(importDecl), specifierComparer) == 0)
 	}
-
 	return specifierComparer, core.TSUnknown
 }
+
+// getTypeOrders returns the type orders to choose from when detecting how existing named imports are sorted, or only
+// the configured type order when it is not "auto".
+func getTypeOrders(preferences *UserPreferences) []OrganizeImportsTypeOrder {
+	if preferences != nil && preferences.OrganizeImportsTypeOrder != OrganizeImportsTypeOrderAuto {
+		return []OrganizeImportsTypeOrder{preferences.OrganizeImportsTypeOrder}
+	}
+	return []OrganizeImportsTypeOrder{OrganizeImportsTypeOrderLast, OrganizeImportsTypeOrderInline, OrganizeImportsTypeOrderFirst}
+}
+
+func getNamedImportSpecifiers(importDecl *ast.Statement) []*ast.Node {
+	importClause := importDecl.ImportClause()
+	if importClause == nil {
+		return nil
+	}
+	namedBindings := importClause.AsImportClause().NamedBindings
+	if namedBindings == nil || namedBindings.Kind != ast.KindNamedImports {
+		return nil
+	}
+	return namedBindings.Elements()
+}
+
+type namedImportOrganizationResult struct {
+	comparer  func(a, b string) int
+	typeOrder OrganizeImportsTypeOrder
+}
+
+// detectNamedImportOrganizationBySort detects the comparer and type order that the named imports of importDecls are
+// sorted by, or returns nil if none of them have named imports.
+func detectNamedImportOrganizationBySort(importDecls []*ast.Statement, comparersToTest []func(a, b string) int, typeOrdersToTest []OrganizeImportsTypeOrder) *namedImportOrganizationResult {
+	var namedImportsByDecl [][]*ast.Node
+	hasTypeOnlyAndRegularImports := false
+	for _, importDecl := range importDecls {
+		namedImports := getNamedImportSpecifiers(importDecl)
+		if len(namedImports) == 0 {
+			continue
+		}
+		if !hasTypeOnlyAndRegularImports && core.Some(namedImports, (*ast.Node).IsTypeOnly) && !core.Every(namedImports, (*ast.Node).IsTypeOnly) {
+			hasTypeOnlyAndRegularImports = true
+		}
+		namedImportsByDecl = append(namedImportsByDecl, namedImports)
+	}
+	if len(namedImportsByDecl) == 0 {
+		return nil
+	}
+
+	// The type order can only be told apart when type-only and regular imports are mixed.
+	if !hasTypeOnlyAndRegularImports || len(typeOrdersToTest) == 0 {
+		namesByDecl := core.Map(namedImportsByDecl, func(namedImports []*ast.Node) []string {
+			return core.Map(namedImports, func(namedImport *ast.Node) string { return namedImport.Name().Text() })
+		})
+		result := detectCaseSensitivityBySort(namesByDecl, comparersToTest)
+		typeOrder := OrganizeImportsTypeOrderLast
+		if len(typeOrdersToTest) == 1 {
+			typeOrder = typeOrdersToTest[0]
+		}
+		return &namedImportOrganizationResult{comparer: result.comparer, typeOrder: typeOrder}
+	}
+
+	bestDiff := make([]int, len(typeOrdersToTest))
+	bestComparer := make([]func(a, b string) int, len(typeOrdersToTest))
+	for i := range typeOrdersToTest {
+		bestDiff[i] = math.MaxInt
+		bestComparer[i] = comparersToTest[0]
+	}
+	for _, curComparer := range comparersToTest {
+		for i, typeOrder := range typeOrdersToTest {
+			diff := 0
+			for _, namedImports := range namedImportsByDecl {
+				diff += measureSortedness(namedImports, func(s1, s2 *ast.Node) int {
+					return compareImportOrExportSpecifiersWithTypeOrder(s1, s2, curComparer, typeOrder)
+				})
+			}
+			if diff < bestDiff[i] {
+				bestDiff[i] = diff
+				bestComparer[i] = curComparer
+			}
+		}
+	}
+
+	// Ties go to the type order tested first.
+	best := 0
+	for i := range typeOrdersToTest {
+		if bestDiff[i] < bestDiff[best] {
+			best = i
+		}
+	}
+	return &namedImportOrganizationResult{comparer: bestComparer[best], typeOrder: typeOrdersToTest[best]}
+}
+
+// organizeImports removes unused imports, then coalesces and sorts the imports and exports of the file.
+// Declarations separated by a blank line are organized independently of each other.
+func (l *LanguageService) organizeImports(ctx context.Context, program *compiler.Program, sourceFile *ast.SourceFile) map[string][]*lsproto.TextEdit {
+	ch, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+	defer done()
+
+	preferences := l.UserPreferences()
+	topLevelImportGroups := groupByNewlineContiguous(sourceFile, core.Filter(sourceFile.Statements.Nodes, ast.IsImportDeclaration))
+	comparer := detectModuleSpecifierCaseBySort(topLevelImportGroups, getComparers(ctx, preferences)).comparer
+	organizer := &importOrganizer{
+		tracker:           l.newChangeTracker(ctx),
+		program:           program,
+		checker:           ch,
+		sourceFile:        sourceFile,
+		comparer:          comparer,
+		specifierComparer: getNamedImportSpecifierComparer(preferences, comparer),
+	}
+
+	for _, importGroup := range topLevelImportGroups {
+		organizer.organizeImports(importGroup)
+	}
+	for _, exportGroup := range getTopLevelExportGroups(sourceFile) {
+		organizer.organizeExports(exportGroup)
+	}
+	for _, statement := range sourceFile.Statements.Nodes {
+		if !ast.IsAmbientModule(statement) || statement.Body() == nil {
+			continue
+		}
+		statements := statement.Body().Statements()
+		for _, importGroup := range groupByNewlineContiguous(sourceFile, core.Filter(statements, ast.IsImportDeclaration)) {
+			organizer.organizeImports(importGroup)
+		}
+		organizer.organizeExports(core.Filter(statements, ast.IsExportDeclaration))
+	}
+	return organizer.tracker.getChanges()
+}
+
+type importOrganizer struct {
+	tracker           *changeTracker
+	program           *compiler.Program
+	checker           *checker.Checker
+	sourceFile        *ast.SourceFile
+	comparer          func(a, b string) int
+	specifierComparer func(s1, s2 *ast.Node) int
+}
+
+func (o *importOrganizer) organizeImports(oldImportDecls []*ast.Statement) {
+	o.organizeDeclarations(oldImportDecls, func(importGroup []*ast.Statement) []*ast.Statement {
+		importGroup = o.coalesceImports(o.removeUnusedImports(importGroup))
+		slices.SortStableFunc(importGroup, func(s1, s2 *ast.Statement) int {
+			return compareImportsOrRequireStatements(s1, s2, o.comparer)
+		})
+		return importGroup
+	})
+}
+
+func (o *importOrganizer) organizeExports(oldExportDecls []*ast.Statement) {
+	o.organizeDeclarations(oldExportDecls, o.coalesceExports)
+}
+
+// organizeDeclarations replaces `oldDecls` with the declarations produced by coalescing the declarations
+// of each module specifier, ordered by module specifier.
+func (o *importOrganizer) organizeDeclarations(oldDecls []*ast.Statement, coalesce func(group []*ast.Statement) []*ast.Statement) {
+	if len(oldDecls) == 0 {
+		return
+	}
+
+	// The leading comments of the first declaration (e.g. a file header) stay where they are.
+	o.tracker.AddEmitFlags(oldDecls[0], printer.EFNoLeadingComments)
+
+	var groups [][]*ast.Statement
+	groupIndexByModuleName := make(map[string]int)
+	for _, decl := range oldDecls {
+		moduleName := getExternalModuleName(decl.ModuleSpecifier())
+		if index, ok := groupIndexByModuleName[moduleName]; ok {
+			groups[index] = append(groups[index], decl)
+		} else {
+			groupIndexByModuleName[moduleName] = len(groups)
+			groups = append(groups, []*ast.Statement{decl})
+		}
+	}
+	slices.SortStableFunc(groups, func(g1, g2 []*ast.Statement) int {
+		return compareModuleSpecifiersWorker(g1[0].ModuleSpecifier(), g2[0].ModuleSpecifier(), o.comparer)
+	})
+
+	var newDecls []*ast.Statement
+	for _, group := range groups {
+		if getExternalModuleName(group[0].ModuleSpecifier()) != "" || group[0].ModuleSpecifier() == nil {
+			newDecls = append(newDecls, coalesce(group)...)
+		} else {
+			newDecls = append(newDecls, group...)
+		}
+	}
+
+	if len(newDecls) == 0 {
+		o.tracker.deleteNodes(o.sourceFile, oldDecls, leadingTriviaOptionExclude, trailingTriviaOptionInclude, true /*hasTrailingComment*/)
+		return
+	}
+	o.tracker.replaceNodeWithNodes(o.sourceFile, oldDecls[0], newDecls, changeNodeOptions{
+		leadingTriviaOption:  leadingTriviaOptionExclude,
+		trailingTriviaOption: trailingTriviaOptionInclude,
+		suffix:               o.tracker.newLine,
+	})
+	hasTrailingComment := o.tracker.nodeHasTrailingComment(o.sourceFile, oldDecls[0], trailingTriviaOptionInclude)
+	o.tracker.deleteNodes(o.sourceFile, oldDecls[1:], leadingTriviaOptionNone, trailingTriviaOptionInclude, hasTrailingComment)
+}
+
+func (o *importOrganizer) removeUnusedImports(oldImports []*ast.Statement) []*ast.Statement {
+	jsxNamespace := o.checker.GetJsxNamespace(o.sourceFile.AsNode())
+	jsxFragmentFactory := o.checker.GetJsxFragmentFactory(o.sourceFile.AsNode())
+	jsx := o.program.Options().Jsx
+	jsxElementsPresent := o.sourceFile.AsNode().SubtreeFacts()&ast.SubtreeContainsJsx != 0
+
+	isDeclarationUsed := func(identifier *ast.Node) bool {
+		// The JSX factory symbol is always used if JSX elements are present - even if they are not allowed.
+		if jsxElementsPresent && (jsx == core.JsxEmitReact || jsx == core.JsxEmitReactNative) &&
+			(identifier.Text() == jsxNamespace || jsxFragmentFactory != "" && identifier.Text() == jsxFragmentFactory) {
+			return true
+		}
+		return isSymbolReferencedInFile(identifier, o.checker, o.sourceFile)
+	}
+
+	var usedImports []*ast.Statement
+	for _, importDecl := range oldImports {
+		importClause := importDecl.ImportClause()
+		if importClause == nil {
+			// Imports without an import clause are assumed to be included for their side effects and are not removed.
+			usedImports = append(usedImports, importDecl)
+			continue
+		}
+
+		name := importClause.Name()
+		if name != nil && !isDeclarationUsed(name) {
+			name = nil
+		}
+		namedBindings := importClause.AsImportClause().NamedBindings
+		if namedBindings != nil {
+			if ast.IsNamespaceImport(namedBindings) {
+				if !isDeclarationUsed(namedBindings.Name()) {
+					namedBindings = nil
+				}
+			} else {
+				elements := namedBindings.Elements()
+				newElements := core.Filter(elements, func(element *ast.Node) bool {
+					return isDeclarationUsed(element.Name())
+				})
+				if len(newElements) == 0 {
+					namedBindings = nil
+				} else if len(newElements) < len(elements) {
+					namedBindings = o.tracker.UpdateNamedImports(namedBindings.AsNamedImports(), o.tracker.NewNodeList(newElements))
+				}
+			}
+		}
+
+		if name != nil || namedBindings != nil {
+			usedImports = append(usedImports, o.updateImportDeclarationAndClause(importDecl, name, namedBindings))
+		} else if hasModuleDeclarationMatchingSpecifier(o.sourceFile, importDecl.ModuleSpecifier()) {
+			// The import is needed for the module augmentation of the same module in this file. A declaration
+			// file only needs the module to be loaded, so the import clause can still be dropped.
+			if o.sourceFile.IsDeclarationFile {
+				usedImports = append(usedImports, o.tracker.NewImportDeclaration(importDecl.Modifiers(), nil /*importClause*/, importDecl.ModuleSpecifier(), nil /*attributes*/))
+			} else {
+				usedImports = append(usedImports, importDecl)
+			}
+		}
+	}
+	return usedImports
+}
+
+func hasModuleDeclarationMatchingSpecifier(sourceFile *ast.SourceFile, moduleSpecifier *ast.Expression) bool {
+	if !ast.IsStringLiteral(moduleSpecifier) {
+		return false
+	}
+	return core.Some(sourceFile.ModuleAugmentations, func(moduleName *ast.ModuleName) bool {
+		return ast.IsStringLiteral(moduleName) && moduleName.Text() == moduleSpecifier.Text()
+	})
+}
+
+type categorizedImports struct {
+	defaultImports   []*ast.Statement
+	namespaceImports []*ast.Statement
+	namedImports     []*ast.Statement
+}
+
+// coalesceImports merges the imports of a single module specifier into as few declarations as possible.
+func (o *importOrganizer) coalesceImports(importGroup []*ast.Statement) []*ast.Statement {
+	if len(importGroup) == 0 {
+		return importGroup
+	}
+
+	// Imports with different attributes cannot be merged.
+	var attributesKeys []string
+	importGroupsByAttributes := make(map[string][]*ast.Statement)
+	for _, importDecl := range importGroup {
+		key := getImportAttributesKey(o.sourceFile, importDecl.AsImportDeclaration().Attributes)
+		if _, ok := importGroupsByAttributes[key]; !ok {
+			attributesKeys = append(attributesKeys, key)
+		}
+		importGroupsByAttributes[key] = append(importGroupsByAttributes[key], importDecl)
+	}
+
+	var coalescedImports []*ast.Statement
+	for _, key := range attributesKeys {
+		var importWithoutClause *ast.Statement
+		var regularImports, typeOnlyImports categorizedImports
+		for _, importDecl := range importGroupsByAttributes[key] {
+			importClause := importDecl.ImportClause()
+			if importClause == nil {
+				// Only the first such import is interesting - the others are redundant.
+				if importWithoutClause == nil {
+					importWithoutClause = importDecl
+				}
+				continue
+			}
+			group := core.IfElse(importClause.IsTypeOnly(), &typeOnlyImports, &regularImports)
+			if importClause.Name() != nil {
+				group.defaultImports = append(group.defaultImports, importDecl)
+			}
+			if namedBindings := importClause.AsImportClause().NamedBindings; namedBindings != nil {
+				if ast.IsNamespaceImport(namedBindings) {
+					group.namespaceImports = append(group.namespaceImports, importDecl)
+				} else {
+					group.namedImports = append(group.namedImports, importDecl)
+				}
+			}
+		}
+
+		if importWithoutClause != nil {
+			coalescedImports = append(coalescedImports, importWithoutClause)
+		}
+
+		for _, group := range []*categorizedImports{&regularImports, &typeOnlyImports} {
+			isTypeOnly := group == &typeOnlyImports
+
+			// Normally, we don't combine default and namespace imports, but it would be silly to
+			// produce two import declarations in this special case.
+			if !isTypeOnly && len(group.defaultImports) == 1 && len(group.namespaceImports) == 1 && len(group.namedImports) == 0 {
+				defaultImport := group.defaultImports[0]
+				coalescedImports = append(coalescedImports, o.updateImportDeclarationAndClause(
+					defaultImport,
+					defaultImport.ImportClause().Name(),
+					group.namespaceImports[0].ImportClause().AsImportClause().NamedBindings,
+				))
+				continue
+			}
+
+			namespaceImports := slices.Clone(group.namespaceImports)
+			slices.SortStableFunc(namespaceImports, func(i1, i2 *ast.Statement) int {
+				return o.comparer(i1.ImportClause().AsImportClause().NamedBindings.Name().Text(), i2.ImportClause().AsImportClause().NamedBindings.Name().Text())
+			})
+			for _, namespaceImport := range namespaceImports {
+				// Drop the default import, if any; it is kept by the declaration below.
+				coalescedImports = append(coalescedImports, o.updateImportDeclarationAndClause(namespaceImport, nil /*name*/, namespaceImport.ImportClause().AsImportClause().NamedBindings))
+			}
+
+			var firstNamedImport *ast.Statement
+			if len(group.namedImports) != 0 {
+				firstNamedImport = group.namedImports[0]
+			}
+			importDecl := firstNamedImport
+			if len(group.defaultImports) != 0 {
+				importDecl = group.defaultImports[0]
+			}
+			if importDecl == nil {
+				continue
+			}
+
+			var newDefaultImport *ast.Node
+			var newImportSpecifiers []*ast.Node
+			if len(group.defaultImports) == 1 {
+				newDefaultImport = group.defaultImports[0].ImportClause().Name()
+			} else {
+				for _, defaultImport := range group.defaultImports {
+					newImportSpecifiers = append(newImportSpecifiers, o.tracker.NewImportSpecifier(false /*isTypeOnly*/, o.tracker.NewIdentifier("default"), defaultImport.ImportClause().Name()))
+				}
+			}
+			newImportSpecifiers = append(newImportSpecifiers, o.getNewImportSpecifiers(group.namedImports)...)
+			slices.SortStableFunc(newImportSpecifiers, o.specifierComparer)
+
+			var newNamedImports *ast.Node
+			switch {
+			case len(newImportSpecifiers) == 0:
+				if newDefaultImport == nil {
+					newNamedImports = o.tracker.NewNamedImports(o.tracker.NewNodeList(nil))
+				}
+			case firstNamedImport != nil:
+				namedBindings := firstNamedImport.ImportClause().AsImportClause().NamedBindings
+				newNamedImports = o.tracker.UpdateNamedImports(namedBindings.AsNamedImports(), o.tracker.NewNodeList(newImportSpecifiers))
+				if !rangeIsOnSingleLine(namedBindings, o.sourceFile) {
+					o.tracker.AddEmitFlags(newNamedImports, printer.EFMultiLine)
+				}
+			default:
+				newNamedImports = o.tracker.NewNamedImports(o.tracker.NewNodeList(newImportSpecifiers))
+			}
+
+			// Type-only imports are not allowed to mix default, namespace, and named imports in any combination.
+			// We could rewrite a default import as a named import (`import { default as name }`), but we currently
+			// choose not to as a stylistic preference.
+			if isTypeOnly && newDefaultImport != nil && newNamedImports != nil {
+				coalescedImports = append(coalescedImports, o.updateImportDeclarationAndClause(importDecl, newDefaultImport, nil /*namedBindings*/))
+				coalescedImports = append(coalescedImports, o.updateImportDeclarationAndClause(core.OrElse(firstNamedImport, importDecl), nil /*name*/, newNamedImports))
+			} else {
+				coalescedImports = append(coalescedImports, o.updateImportDeclarationAndClause(importDecl, newDefaultImport, newNamedImports))
+			}
+		}
+	}
+	return coalescedImports
+}
+
+// getNewImportSpecifiers collects the specifiers of the given named imports, dropping redundant renames like `{ a as a }`.
+func (o *importOrganizer) getNewImportSpecifiers(namedImports []*ast.Statement) []*ast.Node {
+	var specifiers []*ast.Node
+	for _, namedImport := range namedImports {
+		for _, specifier := range namedImport.ImportClause().AsImportClause().NamedBindings.Elements() {
+			if propertyName := specifier.PropertyName(); propertyName != nil && propertyName.Text() == specifier.Name().Text() {
+				specifier = o.tracker.UpdateImportSpecifier(specifier.AsImportSpecifier(), specifier.IsTypeOnly(), nil /*propertyName*/, specifier.Name())
+			}
+			specifiers = append(specifiers, specifier)
+		}
+	}
+	return specifiers
+}
+
+func (o *importOrganizer) updateImportDeclarationAndClause(importDecl *ast.Statement, name *ast.Node, namedBindings *ast.Node) *ast.Statement {
+	importClause := importDecl.ImportClause().AsImportClause()
+	return o.tracker.UpdateImportDeclaration(
+		importDecl.AsImportDeclaration(),
+		importDecl.Modifiers(),
+		o.tracker.UpdateImportClause(importClause, importClause.PhaseModifier, name, namedBindings),
+		importDecl.ModuleSpecifier(),
+		importDecl.AsImportDeclaration().Attributes,
+	)
+}
+
+// getImportAttributesKey returns a key that is equal for import attributes with the same entries, in any order.
+func getImportAttributesKey(sourceFile *ast.SourceFile, attributes *ast.Node) string {
+	if attributes == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(scanner.TokenToString(attributes.AsImportAttributes().Token))
+	b.WriteString(" ")
+	elements := slices.Clone(attributes.AsImportAttributes().Attributes.Nodes)
+	slices.SortFunc(elements, func(x, y *ast.Node) int {
+		return stringutil.CompareStringsCaseSensitive(x.Name().Text(), y.Name().Text())
+	})
+	for _, element := range elements {
+		b.WriteString(element.Name().Text())
+		b.WriteString(":")
+		if value := element.AsImportAttribute().Value; ast.IsStringLiteralLike(value) {
+			b.WriteString(`"` + value.Text() + `"`)
+		} else {
+			b.WriteString(scanner.GetSourceTextOfNodeFromSourceFile(sourceFile, value, false /*includeTrivia*/) + " ")
+		}
+	}
+	return b.String()
+}
+
+// coalesceExports merges the exports of a single module specifier (or the local exports) into as few
+// declarations as possible.
+func (o *importOrganizer) coalesceExports(exportGroup []*ast.Statement) []*ast.Statement {
+	if len(exportGroup) == 0 {
+		return exportGroup
+	}
+
+	var exportWithoutClause *ast.Statement
+	var namespaceExports, namedExports, typeOnlyExports []*ast.Statement
+	for _, exportDecl := range exportGroup {
+		exportClause := exportDecl.AsExportDeclaration().ExportClause
+		switch {
+		case exportClause == nil:
+			// Only the first such export is interesting - the others are redundant.
+			if exportWithoutClause == nil {
+				exportWithoutClause = exportDecl
+			}
+		case !ast.IsNamedExports(exportClause):
+			namespaceExports = append(namespaceExports, exportDecl)
+		case exportDecl.IsTypeOnly():
+			typeOnlyExports = append(typeOnlyExports, exportDecl)
+		default:
+			namedExports = append(namedExports, exportDecl)
+		}
+	}
+
+	var coalescedExports []*ast.Statement
+	if exportWithoutClause != nil {
+		coalescedExports = append(coalescedExports, exportWithoutClause)
+	}
+	coalescedExports = append(coalescedExports, namespaceExports...)
+	for _, group := range [][]*ast.Statement{namedExports, typeOnlyExports} {
+		if len(group) == 0 {
+			continue
+		}
+		var newExportSpecifiers []*ast.Node
+		for _, exportDecl := range group {
+			newExportSpecifiers = append(newExportSpecifiers, exportDecl.AsExportDeclaration().ExportClause.Elements()...)
+		}
+		slices.SortStableFunc(newExportSpecifiers, o.specifierComparer)
+
+		exportDecl := group[0].AsExportDeclaration()
+		exportClause := o.tracker.UpdateNamedExports(exportDecl.ExportClause.AsNamedExports(), o.tracker.NewNodeList(newExportSpecifiers))
+		if !rangeIsOnSingleLine(exportDecl.ExportClause, o.sourceFile) {
+			o.tracker.AddEmitFlags(exportClause, printer.EFMultiLine)
+		}
+		coalescedExports = append(coalescedExports, o.tracker.UpdateExportDeclaration(
+			exportDecl,
+			exportDecl.Modifiers(),
+			exportDecl.IsTypeOnly,
+			exportClause,
+			exportDecl.ModuleSpecifier,
+			exportDecl.Attributes,
+		))
+	}
+	return coalescedExports
+}
+
+// getTopLevelExportGroups groups the top-level export declarations of the file. Re-exports from a module
+// are collected together, while a run of local exports forms its own group.
+func getTopLevelExportGroups(sourceFile *ast.SourceFile) [][]*ast.Statement {
+	var topLevelExportGroups [][]*ast.Statement
+	statements := sourceFile.Statements.Nodes
+	groupIndex := 0
+	for i := 0; i < len(statements); {
+		if !ast.IsExportDeclaration(statements[i]) {
+			i++
+			continue
+		}
+		if groupIndex == len(topLevelExportGroups) {
+			topLevelExportGroups = append(topLevelExportGroups, nil)
+		}
+		if statements[i].ModuleSpecifier() != nil {
+			topLevelExportGroups[groupIndex] = append(topLevelExportGroups[groupIndex], statements[i])
+			i++
+		} else {
+			for i < len(statements) && ast.IsExportDeclaration(statements[i]) {
+				topLevelExportGroups[groupIndex] = append(topLevelExportGroups[groupIndex], statements[i])
+				i++
+			}
+			groupIndex++
+		}
+	}
+	var result [][]*ast.Statement
+	for _, exportGroup := range topLevelExportGroups {
+		result = append(result, groupByNewlineContiguous(sourceFile, exportGroup)...)
+	}
+	return result
+}
+
+func groupByNewlineContiguous(sourceFile *ast.SourceFile, decls []*ast.Statement) [][]*ast.Statement {
+	var groups [][]*ast.Statement
+	for _, decl := range decls {
+		if len(groups) == 0 || isNewGroup(sourceFile, decl) {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], decl)
+	}
+	return groups
+}
+
+// isNewGroup reports whether the leading trivia of `decl` contains at least two line breaks, i.e. whether
+// `decl` is separated from the previous statement by a blank line (or a comment on its own line).
+// Line breaks inside comments are not counted.
+func isNewGroup(sourceFile *ast.SourceFile, decl *ast.Statement) bool {
+	text := sourceFile.Text()
+	pos := decl.Pos()
+	numberOfNewLines := 0
+	countLineBreaks := func(end int) {
+		for ; pos < end; pos++ {
+			if text[pos] == '\n' || text[pos] == '\r' && (pos+1 == len(text) || text[pos+1] != '\n') {
+				numberOfNewLines++
+			}
+		}
+	}
+	for comment := range scanner.GetLeadingCommentRanges(&ast.NodeFactory{}, text, decl.Pos()) {
+		countLineBreaks(comment.Pos())
+		pos = comment.End()
+	}
+	countLineBreaks(scanner.GetTokenPosOfNode(decl, sourceFile, false /*includeJSDoc*/))
+	return numberOfNewLines >= 2
+}
+
+func rangeIsOnSingleLine(node *ast.Node, sourceFile *ast.SourceFile) bool {
+	lineMap := sourceFile.ECMALineMap()
+	return scanner.ComputeLineOfPosition(lineMap, scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/)) == scanner.ComputeLineOfPosition(lineMap, node.End())
+}
@@ -0,0 +1,335 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// renameError is returned when the element at a position cannot be renamed.
+// It is reported to the client as a failed request carrying the localized reason.
+type renameError struct {
+	message *diagnostics.Message
+}
+
+func (e *renameError) Error() string {
+	return e.message.Message()
+}
+
+func (e *renameError) Unwrap() error {
+	return lsproto.ErrRequestFailed
+}
+
+func (l *LanguageService) ProvidePrepareRename(ctx context.Context, params *lsproto.PrepareRenameParams) (lsproto.PrepareRenameResponse, error) {
+	program, sourceFile := l.getProgramAndFile(params.TextDocument.Uri)
+	position := int(l.converters.LineAndCharacterToPosition(sourceFile, params.Position))
+	c, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+	defer done()
+
+	node, message := getRenameLocation(program, c, sourceFile, position)
+	if message != nil {
+		return lsproto.PrepareRenameResponse{}, &renameError{message}
+	}
+	start, end := getRenameTriggerSpan(node, sourceFile)
+	return lsproto.PrepareRenameResponse{
+		PrepareRenamePlaceholder: &lsproto.PrepareRenamePlaceholder{
+			Range:       *l.createLspRangeFromBounds(start, end, sourceFile),
+			Placeholder: sourceFile.Text()[start:end],
+		},
+	}, nil
+}
+
+// getRenameLocation returns the node to rename at the given position, or the reason the element
+// there cannot be renamed.
+func getRenameLocation(program *compiler.Program, c *checker.Checker, sourceFile *ast.SourceFile, position int) (*ast.Node, *diagnostics.Message) {
+	node := getAdjustedLocation(astnav.GetTouchingPropertyName(sourceFile, position), true /*forRename*/, sourceFile)
+	if nodeIsEligibleForRename(node) {
+		if ok, message := canRenameNode(program, c, sourceFile, node); ok {
+			return node, nil
+		} else if message != nil {
+			return nil, message
+		}
+	}
+	return nil, diagnostics.You_cannot_rename_this_element
+}
+
+func nodeIsEligibleForRename(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindIdentifier, ast.KindPrivateIdentifier, ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral, ast.KindThisKeyword:
+		return true
+	case ast.KindNumericLiteral:
+		return isLiteralNameOfPropertyDeclarationOrIndexAccess(node)
+	default:
+		return false
+	}
+}
+
+// canRenameNode reports whether the symbol at node may be renamed. When it may not, a specific
+// reason is returned if there is one.
+func canRenameNode(program *compiler.Program, c *checker.Checker, sourceFile *ast.SourceFile, node *ast.Node) (bool, *diagnostics.Message) {
+	symbol := c.GetSymbolAtLocation(node)
+	if symbol == nil {
+		if ast.IsStringLiteralLike(node) {
+			t := getContextualTypeFromParentOrAncestorTypeNode(node, c)
+			return t != nil && (t.IsStringLiteral() || t.IsUnion() && core.Every(t.Types(), (*checker.Type).IsStringLiteral)), nil
+		}
+		return ast.IsLabelName(node), nil
+	}
+	if len(symbol.Declarations) == 0 {
+		return false, nil
+	}
+	if core.Some(symbol.Declarations, func(declaration *ast.Node) bool { return isDefinedInLibraryFile(program, declaration) }) {
+		return false, diagnostics.You_cannot_rename_elements_that_are_defined_in_the_standard_TypeScript_library
+	}
+	if ast.IsIdentifier(node) && node.Text() == "default" && symbol.Parent != nil && symbol.Parent.Flags&ast.SymbolFlagsModule != 0 {
+		return false, nil
+	}
+	if ast.IsStringLiteralLike(node) && tryGetImportFromModuleSpecifier(node) != nil {
+		// !!! allowRenameOfImportPath
+		return false, nil
+	}
+	if message := wouldRenameInOtherNodeModules(sourceFile, symbol, c); message != nil {
+		return false, message
+	}
+	return true, nil
+}
+
+func isDefinedInLibraryFile(program *compiler.Program, declaration *ast.Node) bool {
+	sourceFile := ast.GetSourceFileOfNode(declaration)
+	return program.IsSourceFileDefaultLibrary(sourceFile.Path()) && tspath.FileExtensionIs(sourceFile.FileName(), tspath.ExtensionDts)
+}
+
+func wouldRenameInOtherNodeModules(originalFile *ast.SourceFile, symbol *ast.Symbol, c *checker.Checker) *diagnostics.Message {
+	// !!! providePrefixAndSuffixTextForRename
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		importSpecifier := core.Find(symbol.Declarations, ast.IsImportSpecifier)
+		if importSpecifier != nil && importSpecifier.PropertyName() == nil {
+			symbol = c.GetAliasedSymbol(symbol)
+		}
+	}
+	originalPackage := getPackagePathComponents(string(originalFile.Path()))
+	if originalPackage == nil {
+		// The original file is not in node_modules.
+		if core.Some(symbol.Declarations, func(declaration *ast.Node) bool {
+			return getPackagePathComponents(string(ast.GetSourceFileOfNode(declaration).Path())) != nil
+		}) {
+			return diagnostics.You_cannot_rename_elements_that_are_defined_in_a_node_modules_folder
+		}
+		return nil
+	}
+	for _, declaration := range symbol.Declarations {
+		declarationPackage := getPackagePathComponents(string(ast.GetSourceFileOfNode(declaration).Path()))
+		if declarationPackage != nil && !slices.Equal(originalPackage, declarationPackage) {
+			return diagnostics.You_cannot_rename_elements_that_are_defined_in_another_node_modules_folder
+		}
+	}
+	return nil
+}
+
+// getPackagePathComponents returns the path components up to and including the innermost package
+// directory under node_modules, or nil if the path is not in node_modules.
+func getPackagePathComponents(path string) []string {
+	components := tspath.GetPathComponents(path, "")
+	for i := len(components) - 1; i >= 0; i-- {
+		if components[i] == "node_modules" {
+			return components[:min(i+2, len(components))]
+		}
+	}
+	return nil
+}
+
+func getContextualTypeFromParentOrAncestorTypeNode(node *ast.Node, c *checker.Checker) *checker.Type {
+	if node.Flags&ast.NodeFlagsJSDoc != 0 && !ast.IsInJSFile(node) {
+		return nil
+	}
+	if contextualType := getContextualTypeFromParent(node, c, checker.ContextFlagsNone); contextualType != nil {
+		return contextualType
+	}
+	var ancestorTypeNode *ast.Node
+	ast.FindAncestor(node, func(n *ast.Node) bool {
+		if ast.IsTypeNode(n) {
+			ancestorTypeNode = n
+		}
+		return !ast.IsQualifiedName(n.Parent) && !ast.IsTypeNode(n.Parent) && !ast.IsTypeElement(n.Parent)
+	})
+	if ancestorTypeNode == nil {
+		return nil
+	}
+	return c.GetTypeAtLocation(ancestorTypeNode)
+}
+
+// getRenameTriggerSpan returns the span of text to be replaced by a rename, which excludes the quotes
+// of string literals.
+func getRenameTriggerSpan(node *ast.Node, sourceFile *ast.SourceFile) (int, int) {
+	start := scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/)
+	end := node.End()
+	if ast.IsStringLiteralLike(node) {
+		start++
+		end--
+	}
+	return start, end
+}
+
+// ProvideEditsForFileRename computes the edits that keep imports and references working when a file
+// or directory is moved from oldURI to newURI. The files are expected to still be at their old
+// locations in the program, as is the case for workspace/willRenameFiles.
+func (l *LanguageService) ProvideEditsForFileRename(ctx context.Context, oldURI lsproto.DocumentUri, newURI lsproto.DocumentUri) map[lsproto.DocumentUri][]*lsproto.TextEdit {
+	program := l.GetProgram()
+	c, done := program.GetTypeChecker(ctx)
+	defer done()
+
+	compareOptions := tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: program.UseCaseSensitiveFileNames(),
+		CurrentDirectory:          program.GetCurrentDirectory(),
+	}
+	oldToNew := getPathUpdater(oldURI.FileName(), newURI.FileName(), compareOptions.UseCaseSensitiveFileNames)
+	sourceFiles := program.GetSourceFiles()
+	changes := make(map[lsproto.DocumentUri][]*lsproto.TextEdit)
+	// !!! updateTsconfigFiles
+	for _, sourceFile := range sourceFiles {
+		if ctx.Err() != nil {
+			return nil
+		}
+		newFromOld, importingSourceFileMoved := oldToNew(sourceFile.FileName())
+		newImportFromPath := sourceFile.FileName()
+		if importingSourceFileMoved {
+			newImportFromPath = newFromOld
+		}
+		oldImportFromDirectory := tspath.GetDirectoryPath(sourceFile.FileName())
+		newImportFromDirectory := tspath.GetDirectoryPath(newImportFromPath)
+
+		addEdit := func(start int, end int, text string) {
+			uri := FileNameToDocumentURI(sourceFile.FileName())
+			changes[uri] = append(changes[uri], &lsproto.TextEdit{
+				Range:   *l.createLspRangeFromBounds(start, end, sourceFile),
+				NewText: text,
+			})
+		}
+
+		for _, ref := range sourceFile.ReferencedFiles {
+			if !tspath.PathIsRelative(ref.FileName) {
+				continue
+			}
+			newAbsolute, ok := oldToNew(tspath.GetNormalizedAbsolutePath(ref.FileName, oldImportFromDirectory))
+			if !ok {
+				continue
+			}
+			updated := tspath.EnsurePathIsNonModuleName(tspath.GetRelativePathFromDirectory(newImportFromDirectory, newAbsolute, compareOptions))
+			if updated != sourceFile.Text()[ref.Pos():ref.End()] {
+				addEdit(ref.Pos(), ref.End(), updated)
+			}
+		}
+
+		for _, importLiteral := range sourceFile.Imports() {
+			importedModuleSymbol := c.GetSymbolAtLocation(importLiteral)
+			// No need to update if it's an ambient module.
+			if importedModuleSymbol != nil && core.Some(importedModuleSymbol.Declarations, ast.IsAmbientModule) {
+				continue
+			}
+			toImport := getSourceFileToImport(program, importedModuleSymbol, importLiteral, sourceFile, oldToNew)
+			// An update is needed if the imported file moved, or the importing file moved and used a relative path.
+			if toImport == nil || !(toImport.updated || importingSourceFileMoved && tspath.PathIsRelative(importLiteral.Text())) {
+				continue
+			}
+			updated := modulespecifiers.GetModuleSpecifier(
+				program.Options(),
+				program,
+				sourceFile,
+				newImportFromPath,
+				importLiteral.Text(),
+				toImport.newFileName,
+				modulespecifiers.ModuleSpecifierOptions{},
+			)
+			if updated != "" && updated != importLiteral.Text() {
+				start, end := getRenameTriggerSpan(importLiteral, sourceFile)
+				addEdit(start, end, updated)
+			}
+		}
+	}
+	return changes
+}
+
+// pathUpdater maps a path to its location after a rename, reporting false for paths that do not move.
+type pathUpdater func(path string) (string, bool)
+
+func getPathUpdater(oldFileOrDirPath string, newFileOrDirPath string, useCaseSensitiveFileNames bool) pathUpdater {
+	canonicalOldPath := tspath.GetCanonicalFileName(oldFileOrDirPath, useCaseSensitiveFileNames)
+	return func(path string) (string, bool) {
+		canonicalPath := tspath.GetCanonicalFileName(path, useCaseSensitiveFileNames)
+		if canonicalPath == canonicalOldPath {
+			return newFileOrDirPath, true
+		}
+		if suffix, ok := strings.CutPrefix(canonicalPath, tspath.EnsureTrailingDirectorySeparator(canonicalOldPath)); ok {
+			return newFileOrDirPath + "/" + path[len(path)-len(suffix):], true
+		}
+		return "", false
+	}
+}
+
+type fileToImport struct {
+	newFileName string
+	updated     bool
+}
+
+func getSourceFileToImport(program *compiler.Program, importedModuleSymbol *ast.Symbol, importLiteral *ast.Node, importingSourceFile *ast.SourceFile, oldToNew pathUpdater) *fileToImport {
+	if importedModuleSymbol != nil {
+		// The module is not ambient, so it must be declared by a source file.
+		if declaration := core.Find(importedModuleSymbol.Declarations, ast.IsSourceFile); declaration != nil {
+			oldFileName := declaration.AsSourceFile().FileName()
+			if newFileName, ok := oldToNew(oldFileName); ok {
+				return &fileToImport{newFileName: newFileName, updated: true}
+			}
+			return &fileToImport{newFileName: oldFileName}
+		}
+	}
+
+	resolved := program.GetResolvedModuleFromModuleSpecifier(importingSourceFile, importLiteral)
+	if resolved == nil {
+		return nil
+	}
+	if resolved.IsResolved() {
+		if newFileName, ok := oldToNew(resolved.ResolvedFileName); ok {
+			return &fileToImport{newFileName: newFileName, updated: true}
+		}
+	}
+	// The import may not resolve yet, such as when it already refers to the new location of the file.
+	// Look for a failed lookup location that is being renamed, preferring ones that end up at a file
+	// in the program.
+	tryChange := func(oldFileName string, mustExist bool) *fileToImport {
+		if strings.HasSuffix(oldFileName, "/package.json") {
+			return nil
+		}
+		newFileName, ok := oldToNew(oldFileName)
+		if !ok || mustExist && program.GetSourceFile(newFileName) == nil {
+			return nil
+		}
+		return &fileToImport{newFileName: newFileName, updated: true}
+	}
+	for _, location := range resolved.FailedLookupLocations {
+		if result := tryChange(location, true /*mustExist*/); result != nil {
+			return result
+		}
+	}
+	if tspath.PathIsRelative(importLiteral.Text()) {
+		for _, location := range resolved.FailedLookupLocations {
+			if result := tryChange(location, false /*mustExist*/); result != nil {
+				return result
+			}
+		}
+	}
+	if resolved.IsResolved() {
+		return &fileToImport{newFileName: resolved.ResolvedFileName}
+	}
+	return nil
+}
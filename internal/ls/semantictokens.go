@@ -0,0 +1,330 @@
+package ls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+type semanticTokenType uint32
+
+// Token types, in the order they appear in the legend.
+const (
+	semanticTokenTypeNamespace semanticTokenType = iota
+	semanticTokenTypeClass
+	semanticTokenTypeEnum
+	semanticTokenTypeInterface
+	semanticTokenTypeTypeParameter
+	semanticTokenTypeType
+	semanticTokenTypeParameter
+	semanticTokenTypeVariable
+	semanticTokenTypeEnumMember
+	semanticTokenTypeProperty
+	semanticTokenTypeFunction
+	semanticTokenTypeMethod
+)
+
+type semanticTokenModifier uint32
+
+// Token modifiers, as bit positions in the order they appear in the legend.
+const (
+	semanticTokenModifierDeclaration semanticTokenModifier = iota
+	semanticTokenModifierStatic
+	semanticTokenModifierAsync
+	semanticTokenModifierReadonly
+	semanticTokenModifierDefaultLibrary
+)
+
+// SemanticTokensLegend returns the legend that token type and modifier indices produced by
+// ProvideSemanticTokens refer to.
+func SemanticTokensLegend() *lsproto.SemanticTokensLegend {
+	return &lsproto.SemanticTokensLegend{
+		TokenTypes: []string{
+			string(lsproto.SemanticTokenTypesnamespace),
+			string(lsproto.SemanticTokenTypesclass),
+			string(lsproto.SemanticTokenTypesenum),
+			string(lsproto.SemanticTokenTypesinterface),
+			string(lsproto.SemanticTokenTypestypeParameter),
+			string(lsproto.SemanticTokenTypestype),
+			string(lsproto.SemanticTokenTypesparameter),
+			string(lsproto.SemanticTokenTypesvariable),
+			string(lsproto.SemanticTokenTypesenumMember),
+			string(lsproto.SemanticTokenTypesproperty),
+			string(lsproto.SemanticTokenTypesfunction),
+			string(lsproto.SemanticTokenTypesmethod),
+		},
+		TokenModifiers: []string{
+			string(lsproto.SemanticTokenModifiersdeclaration),
+			string(lsproto.SemanticTokenModifiersstatic),
+			string(lsproto.SemanticTokenModifiersasync),
+			string(lsproto.SemanticTokenModifiersreadonly),
+			string(lsproto.SemanticTokenModifiersdefaultLibrary),
+		},
+	}
+}
+
+var tokenFromDeclarationMapping = map[ast.Kind]semanticTokenType{
+	ast.KindVariableDeclaration:         semanticTokenTypeVariable,
+	ast.KindParameter:                   semanticTokenTypeParameter,
+	ast.KindPropertyDeclaration:         semanticTokenTypeProperty,
+	ast.KindModuleDeclaration:           semanticTokenTypeNamespace,
+	ast.KindEnumDeclaration:             semanticTokenTypeEnum,
+	ast.KindEnumMember:                  semanticTokenTypeEnumMember,
+	ast.KindClassDeclaration:            semanticTokenTypeClass,
+	ast.KindMethodDeclaration:           semanticTokenTypeMethod,
+	ast.KindFunctionDeclaration:         semanticTokenTypeFunction,
+	ast.KindFunctionExpression:          semanticTokenTypeFunction,
+	ast.KindMethodSignature:             semanticTokenTypeMethod,
+	ast.KindGetAccessor:                 semanticTokenTypeProperty,
+	ast.KindSetAccessor:                 semanticTokenTypeProperty,
+	ast.KindPropertySignature:           semanticTokenTypeProperty,
+	ast.KindInterfaceDeclaration:        semanticTokenTypeInterface,
+	ast.KindTypeAliasDeclaration:        semanticTokenTypeType,
+	ast.KindJSTypeAliasDeclaration:      semanticTokenTypeType,
+	ast.KindTypeParameter:               semanticTokenTypeTypeParameter,
+	ast.KindPropertyAssignment:          semanticTokenTypeProperty,
+	ast.KindShorthandPropertyAssignment: semanticTokenTypeProperty,
+}
+
+func (l *LanguageService) ProvideSemanticTokens(ctx context.Context, documentURI lsproto.DocumentUri) (lsproto.SemanticTokensResponse, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	return l.provideSemanticTokensInRange(ctx, documentURI, core.NewTextRange(0, file.End()))
+}
+
+func (l *LanguageService) ProvideSemanticTokensRange(ctx context.Context, documentURI lsproto.DocumentUri, lspRange lsproto.Range) (lsproto.SemanticTokensRangeResponse, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	start := int(l.converters.LineAndCharacterToPosition(file, lspRange.Start))
+	end := int(l.converters.LineAndCharacterToPosition(file, lspRange.End))
+	return l.provideSemanticTokensInRange(ctx, documentURI, core.NewTextRange(start, end))
+}
+
+func (l *LanguageService) provideSemanticTokensInRange(ctx context.Context, documentURI lsproto.DocumentUri, span core.TextRange) (lsproto.SemanticTokensOrNull, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	collector := &semanticTokensCollector{
+		ctx:     ctx,
+		ls:      l,
+		program: program,
+		file:    file,
+		checker: c,
+		span:    span,
+		data:    []uint32{},
+	}
+	collector.visit(file.AsNode())
+	if ctx.Err() != nil {
+		return lsproto.SemanticTokensOrNull{}, ctx.Err()
+	}
+	return lsproto.SemanticTokensOrNull{SemanticTokens: &lsproto.SemanticTokens{Data: collector.data}}, nil
+}
+
+type semanticTokensCollector struct {
+	ctx          context.Context
+	ls           *LanguageService
+	program      *compiler.Program
+	file         *ast.SourceFile
+	checker      *checker.Checker
+	span         core.TextRange
+	inJSXElement bool
+
+	// Tokens are encoded relative to the previous token, as the protocol requires.
+	data          []uint32
+	lastLine      uint32
+	lastCharacter uint32
+}
+
+func (s *semanticTokensCollector) visit(node *ast.Node) bool {
+	if node == nil || node.Flags&ast.NodeFlagsReparsed != 0 {
+		return false
+	}
+	switch node.Kind {
+	case ast.KindModuleDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindFunctionDeclaration,
+		ast.KindClassExpression, ast.KindFunctionExpression, ast.KindArrowFunction:
+		if s.ctx.Err() != nil {
+			return true
+		}
+	}
+	if node.End()-node.Pos() == 0 || node.Pos() > s.span.End() || node.End() < s.span.Pos() {
+		return false
+	}
+
+	prevInJSXElement := s.inJSXElement
+	if ast.IsJsxElement(node) || ast.IsJsxSelfClosingElement(node) {
+		s.inJSXElement = true
+	}
+	if ast.IsJsxExpression(node) {
+		s.inJSXElement = false
+	}
+
+	if ast.IsIdentifier(node) && !s.inJSXElement && !inImportClause(node) && !isInfinityOrNaNString(node.Text()) {
+		s.classifyIdentifier(node)
+	}
+
+	stop := node.ForEachChild(s.visit)
+	s.inJSXElement = prevInJSXElement
+	return stop
+}
+
+func (s *semanticTokensCollector) classifyIdentifier(node *ast.Node) {
+	symbol := s.checker.GetSymbolAtLocation(node)
+	if symbol == nil {
+		return
+	}
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		symbol = s.checker.GetAliasedSymbol(symbol)
+	}
+	tokenType, ok := classifySymbol(symbol, getMeaningFromLocation(node))
+	if !ok {
+		return
+	}
+
+	var modifiers uint32
+	if parent := node.Parent; parent != nil {
+		parentType, parentIsDeclaration := tokenFromDeclarationMapping[parent.Kind]
+		if (ast.IsBindingElement(parent) || parentIsDeclaration && parentType == tokenType) && parent.Name() == node {
+			modifiers |= 1 << semanticTokenModifierDeclaration
+		}
+	}
+
+	// Parameter properties are accessed as properties outside of the constructor.
+	if tokenType == semanticTokenTypeParameter && isRightSideOfQualifiedNameOrPropertyAccess(node) {
+		tokenType = semanticTokenTypeProperty
+	}
+	tokenType = s.reclassifyByType(node, tokenType)
+
+	if decl := symbol.ValueDeclaration; decl != nil {
+		modifierFlags := ast.GetCombinedModifierFlags(decl)
+		nodeFlags := ast.GetCombinedNodeFlags(decl)
+		if modifierFlags&ast.ModifierFlagsStatic != 0 {
+			modifiers |= 1 << semanticTokenModifierStatic
+		}
+		if modifierFlags&ast.ModifierFlagsAsync != 0 {
+			modifiers |= 1 << semanticTokenModifierAsync
+		}
+		if tokenType != semanticTokenTypeClass && tokenType != semanticTokenTypeInterface {
+			if modifierFlags&ast.ModifierFlagsReadonly != 0 || nodeFlags&ast.NodeFlagsConst != 0 || symbol.Flags&ast.SymbolFlagsEnumMember != 0 {
+				modifiers |= 1 << semanticTokenModifierReadonly
+			}
+		}
+		if s.isDefaultLibraryDeclaration(decl) {
+			modifiers |= 1 << semanticTokenModifierDefaultLibrary
+		}
+	} else if slices.ContainsFunc(symbol.Declarations, s.isDefaultLibraryDeclaration) {
+		modifiers |= 1 << semanticTokenModifierDefaultLibrary
+	}
+
+	s.addToken(node, tokenType, modifiers)
+}
+
+func (s *semanticTokensCollector) isDefaultLibraryDeclaration(decl *ast.Node) bool {
+	sourceFile := ast.GetSourceFileOfNode(decl)
+	return sourceFile != nil && s.program.IsSourceFileDefaultLibrary(sourceFile.Path())
+}
+
+func (s *semanticTokensCollector) addToken(node *ast.Node, tokenType semanticTokenType, modifiers uint32) {
+	start := s.ls.createLspPosition(scanner.GetTokenPosOfNode(node, s.file, false /*includeJSDoc*/), s.file)
+	end := s.ls.createLspPosition(node.End(), s.file)
+	if start.Line != end.Line {
+		return
+	}
+	deltaLine := start.Line - s.lastLine
+	deltaCharacter := start.Character
+	if deltaLine == 0 {
+		deltaCharacter -= s.lastCharacter
+	}
+	s.data = append(s.data, deltaLine, deltaCharacter, end.Character-start.Character, uint32(tokenType), modifiers)
+	s.lastLine = start.Line
+	s.lastCharacter = start.Character
+}
+
+func classifySymbol(symbol *ast.Symbol, meaning ast.SemanticMeaning) (semanticTokenType, bool) {
+	flags := symbol.Flags
+	switch {
+	case flags&ast.SymbolFlagsClass != 0:
+		return semanticTokenTypeClass, true
+	case flags&ast.SymbolFlagsEnum != 0:
+		return semanticTokenTypeEnum, true
+	case flags&ast.SymbolFlagsTypeAlias != 0:
+		return semanticTokenTypeType, true
+	case flags&ast.SymbolFlagsInterface != 0:
+		if meaning&ast.SemanticMeaningType != 0 {
+			return semanticTokenTypeInterface, true
+		}
+	case flags&ast.SymbolFlagsTypeParameter != 0:
+		return semanticTokenTypeTypeParameter, true
+	}
+	decl := symbol.ValueDeclaration
+	if decl == nil && len(symbol.Declarations) > 0 {
+		decl = symbol.Declarations[0]
+	}
+	if decl != nil && ast.IsBindingElement(decl) {
+		decl = getDeclarationForBindingElement(decl)
+	}
+	if decl == nil {
+		return 0, false
+	}
+	tokenType, ok := tokenFromDeclarationMapping[decl.Kind]
+	return tokenType, ok
+}
+
+// reclassifyByType refines the classification of variables, properties and parameters by their type,
+// so that e.g. a variable holding a function is colored like a function.
+func (s *semanticTokensCollector) reclassifyByType(node *ast.Node, tokenType semanticTokenType) semanticTokenType {
+	if tokenType != semanticTokenTypeVariable && tokenType != semanticTokenTypeProperty && tokenType != semanticTokenTypeParameter {
+		return tokenType
+	}
+	t := s.checker.GetTypeAtLocation(node)
+	if t == nil {
+		return tokenType
+	}
+	test := func(condition func(t *checker.Type) bool) bool {
+		return condition(t) || t.IsUnion() && slices.ContainsFunc(t.Types(), condition)
+	}
+	if tokenType != semanticTokenTypeParameter && test(func(t *checker.Type) bool { return len(s.checker.GetConstructSignatures(t)) > 0 }) {
+		return semanticTokenTypeClass
+	}
+	if test(func(t *checker.Type) bool { return len(s.checker.GetCallSignatures(t)) > 0 }) &&
+		!test(func(t *checker.Type) bool { return len(s.checker.GetPropertiesOfType(t)) > 0 }) ||
+		isExpressionInCallExpression(node) {
+		if tokenType == semanticTokenTypeProperty {
+			return semanticTokenTypeMethod
+		}
+		return semanticTokenTypeFunction
+	}
+	return tokenType
+}
+
+func getDeclarationForBindingElement(element *ast.Node) *ast.Node {
+	for {
+		if ast.IsBindingElement(element.Parent.Parent) {
+			element = element.Parent.Parent
+		} else {
+			return element.Parent.Parent
+		}
+	}
+}
+
+func inImportClause(node *ast.Node) bool {
+	parent := node.Parent
+	return parent != nil && (ast.IsImportClause(parent) || ast.IsImportSpecifier(parent) || ast.IsNamespaceImport(parent))
+}
+
+func isExpressionInCallExpression(node *ast.Node) bool {
+	for isRightSideOfQualifiedNameOrPropertyAccess(node) {
+		node = node.Parent
+	}
+	return ast.IsCallExpression(node.Parent) && node.Parent.Expression() == node
+}
+
+func isRightSideOfQualifiedNameOrPropertyAccess(node *ast.Node) bool {
+	parent := node.Parent
+	return ast.IsQualifiedName(parent) && parent.AsQualifiedName().Right == node ||
+		ast.IsPropertyAccessExpression(parent) && parent.Name() == node
+}
@@ -0,0 +1,217 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// A type hierarchy declaration is a class declaration, a class expression, or an interface declaration.
+
+func (l *LanguageService) ProvidePrepareTypeHierarchy(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (lsproto.TypeHierarchyPrepareResponse, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	node := astnav.GetTouchingPropertyName(file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	declarations := resolveTypeHierarchyDeclarations(c, node)
+	if len(declarations) == 0 {
+		return lsproto.TypeHierarchyItemsOrNull{}, nil
+	}
+	items := core.Map(declarations, l.createTypeHierarchyItem)
+	return lsproto.TypeHierarchyItemsOrNull{TypeHierarchyItems: &items}, nil
+}
+
+func (l *LanguageService) ProvideTypeHierarchySupertypes(ctx context.Context, item *lsproto.TypeHierarchyItem) (lsproto.TypeHierarchySupertypesResponse, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	declaration := l.resolveTypeHierarchyItemDeclaration(c, file, item)
+	if declaration == nil {
+		return lsproto.TypeHierarchyItemsOrNull{}, nil
+	}
+
+	var seen collections.Set[*ast.Node]
+	items := []*lsproto.TypeHierarchyItem{}
+	for _, element := range getHeritageElements(declaration) {
+		for _, supertype := range resolveHeritageElementDeclarations(c, element) {
+			if seen.AddIfAbsent(supertype) {
+				items = append(items, l.createTypeHierarchyItem(supertype))
+			}
+		}
+	}
+	return lsproto.TypeHierarchyItemsOrNull{TypeHierarchyItems: &items}, nil
+}
+
+func (l *LanguageService) ProvideTypeHierarchySubtypes(ctx context.Context, item *lsproto.TypeHierarchyItem) (lsproto.TypeHierarchySubtypesResponse, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	declaration := l.resolveTypeHierarchyItemDeclaration(c, file, item)
+	if declaration == nil {
+		return lsproto.TypeHierarchyItemsOrNull{}, nil
+	}
+	name := declaration.Name()
+	if name == nil {
+		// Anonymous classes cannot be referenced from a heritage clause.
+		return lsproto.TypeHierarchyItemsOrNull{}, nil
+	}
+
+	// Implementation references of a class or interface include every class or interface
+	// that names it in an `extends` or `implements` clause.
+	entries := l.getImplementationReferenceEntries(ctx, program, name, name.Pos())
+	if ctx.Err() != nil {
+		return lsproto.TypeHierarchyItemsOrNull{}, ctx.Err()
+	}
+	var seen collections.Set[*ast.Node]
+	items := []*lsproto.TypeHierarchyItem{}
+	for _, entry := range entries {
+		subtype := entry.node
+		if subtype == nil {
+			continue
+		}
+		if subtype.Parent != nil && isTypeHierarchyDeclaration(subtype.Parent) && subtype.Parent.Name() == subtype {
+			subtype = subtype.Parent
+		}
+		if !isTypeHierarchyDeclaration(subtype) || !isDirectSubtype(c, subtype, declaration) {
+			continue
+		}
+		if seen.AddIfAbsent(subtype) {
+			items = append(items, l.createTypeHierarchyItem(subtype))
+		}
+	}
+	return lsproto.TypeHierarchyItemsOrNull{TypeHierarchyItems: &items}, nil
+}
+
+// resolveTypeHierarchyItemDeclaration maps an item produced by an earlier request back to its declaration.
+func (l *LanguageService) resolveTypeHierarchyItemDeclaration(c *checker.Checker, file *ast.SourceFile, item *lsproto.TypeHierarchyItem) *ast.Node {
+	position := int(l.converters.LineAndCharacterToPosition(file, item.SelectionRange.Start))
+	declarations := resolveTypeHierarchyDeclarations(c, astnav.GetTouchingPropertyName(file, position))
+	if len(declarations) == 0 {
+		return nil
+	}
+	return declarations[0]
+}
+
+func isTypeHierarchyDeclaration(node *ast.Node) bool {
+	return ast.IsClassLike(node) || ast.IsInterfaceDeclaration(node)
+}
+
+// resolveTypeHierarchyDeclarations finds the class or interface declarations referred to by a location,
+// which may be a declaration itself, its name or keyword, or a reference to it.
+func resolveTypeHierarchyDeclarations(c *checker.Checker, location *ast.Node) []*ast.Node {
+	if location == nil {
+		return nil
+	}
+	if isTypeHierarchyDeclaration(location) {
+		return []*ast.Node{location}
+	}
+	if parent := location.Parent; parent != nil && isTypeHierarchyDeclaration(parent) {
+		if parent.Name() == location || ast.IsModifier(location) || location.Kind == ast.KindClassKeyword || location.Kind == ast.KindInterfaceKeyword {
+			return []*ast.Node{parent}
+		}
+	}
+	return getTypeHierarchyDeclarationsOfSymbol(c, c.GetSymbolAtLocation(location))
+}
+
+func getTypeHierarchyDeclarationsOfSymbol(c *checker.Checker, symbol *ast.Symbol) []*ast.Node {
+	if symbol == nil {
+		return nil
+	}
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		symbol = c.GetAliasedSymbol(symbol)
+	}
+	return core.Filter(symbol.Declarations, isTypeHierarchyDeclaration)
+}
+
+func getHeritageElements(declaration *ast.Node) []*ast.Node {
+	return core.Concatenate(ast.GetExtendsHeritageClauseElements(declaration), ast.GetImplementsHeritageClauseElements(declaration))
+}
+
+// resolveHeritageElementDeclarations resolves the class or interface declarations named by an
+// `extends` or `implements` clause element.
+func resolveHeritageElementDeclarations(c *checker.Checker, element *ast.Node) []*ast.Node {
+	if declarations := getTypeHierarchyDeclarationsOfSymbol(c, c.GetSymbolAtLocation(element.Expression())); len(declarations) != 0 {
+		return declarations
+	}
+	// The base of a class may be an arbitrary expression, such as a mixin call, so fall back to its
+	// instance type, which for mixins is an intersection of the classes involved.
+	t := c.GetTypeAtLocation(element)
+	if t == nil {
+		return nil
+	}
+	types := []*checker.Type{t}
+	if t.IsIntersection() {
+		types = t.Types()
+	}
+	return core.FlatMap(types, func(t *checker.Type) []*ast.Node {
+		return getTypeHierarchyDeclarationsOfSymbol(c, t.Symbol())
+	})
+}
+
+func isDirectSubtype(c *checker.Checker, subtype *ast.Node, supertype *ast.Node) bool {
+	for _, element := range getHeritageElements(subtype) {
+		for _, declaration := range resolveHeritageElementDeclarations(c, element) {
+			if declaration == supertype {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getTypeHierarchyItemName(node *ast.Node) (string, *ast.Node) {
+	if name := node.Name(); name != nil {
+		return name.Text(), name
+	}
+	if defaultModifier := findDefaultModifier(node); defaultModifier != nil {
+		return "default", defaultModifier
+	}
+	if name := ast.GetAssignedName(node); name != nil {
+		return scanner.GetTextOfNode(name), nil
+	}
+	return "(Anonymous class)", nil
+}
+
+func getTypeHierarchyItemContainerName(node *ast.Node) string {
+	if ast.IsModuleBlock(node.Parent) && ast.IsIdentifier(node.Parent.Parent.Name()) {
+		return scanner.GetTextOfNode(node.Parent.Parent.Name())
+	}
+	return ""
+}
+
+func (l *LanguageService) createTypeHierarchyItem(node *ast.Node) *lsproto.TypeHierarchyItem {
+	sourceFile := ast.GetSourceFileOfNode(node)
+	name, nameNode := getTypeHierarchyItemName(node)
+	var detail *string
+	if containerName := getTypeHierarchyItemContainerName(node); containerName != "" {
+		detail = &containerName
+	}
+	start := scanner.SkipTriviaEx(sourceFile.Text(), node.Pos(), &scanner.SkipTriviaOptions{StopAtComments: true})
+	var selectionRange *lsproto.Range
+	if nameNode != nil {
+		selectionRange = l.createLspRangeFromNode(nameNode, sourceFile)
+	} else {
+		selectionRange = l.createLspRangeFromNode(node, sourceFile)
+		// Select the `class` keyword of anonymous classes so the item can be resolved again.
+		if classKeyword := findChildOfKind(node, ast.KindClassKeyword, sourceFile); classKeyword != nil {
+			selectionRange = l.createLspRangeFromNode(classKeyword, sourceFile)
+		}
+	}
+	return &lsproto.TypeHierarchyItem{
+		Name:           name,
+		Kind:           getSymbolKindFromNode(node),
+		Detail:         detail,
+		Uri:            FileNameToDocumentURI(sourceFile.FileName()),
+		Range:          *l.createLspRangeFromBounds(start, node.End(), sourceFile),
+		SelectionRange: *selectionRange,
+	}
+}
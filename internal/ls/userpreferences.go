@@ -20,6 +20,8 @@ func NewDefaultUserPreferences() *UserPreferences {
 		DisplayPartsForJSDoc:               true,
 		DisableLineTextInReferences:        true,
 		InteractiveInlayHints:              true,
+
+		OrganizeImportsAccentCollation: true,
 	}
 }
 
@@ -73,28 +75,28 @@ type UserPreferences struct {
 	// Indicates whether imports should be organized in a case-insensitive manner.
 	//
 	// Default: TSUnknown ("auto" in strada), will perform detection
-	OrganizeImportsIgnoreCase core.Tristate // !!!
+	OrganizeImportsIgnoreCase core.Tristate
 	// Indicates whether imports should be organized via an "ordinal" (binary) comparison using the numeric value of their
 	// code points, or via "unicode" collation (via the Unicode Collation Algorithm (https://unicode.org/reports/tr10/#Scope))
 	//
 	// using rules associated with the locale specified in organizeImportsCollationLocale.
 	//
 	// Default: Ordinal
-	OrganizeImportsCollation OrganizeImportsCollation // !!!
+	OrganizeImportsCollation OrganizeImportsCollation
 	// Indicates the locale to use for "unicode" collation. If not specified, the locale `"en"` is used as an invariant
 	// for the sake of consistent sorting. Use `"auto"` to use the detected UI locale.
 	//
 	// This preference is ignored if organizeImportsCollation is not `unicode`.
 	//
 	// Default: `"en"`
-	OrganizeImportsLocale string // !!!
+	OrganizeImportsLocale string
 	// Indicates whether numeric collation should be used for digit sequences in strings. When `true`, will collate
 	// strings such that `a1z < a2z < a100z`. When `false`, will collate strings such that `a1z < a100z < a2z`.
 	//
 	// This preference is ignored if organizeImportsCollation is not `unicode`.
 	//
 	// Default: `false`
-	OrganizeImportsNumericCollation bool // !!!
+	OrganizeImportsNumericCollation bool
 	// Indicates whether accents and other diacritic marks are considered unequal for the purpose of collation. When
 	// `true`, characters with accents and other diacritics will be collated in the order defined by the locale specified
 	// in organizeImportsCollationLocale.
@@ -102,7 +104,7 @@ type UserPreferences struct {
 	// This preference is ignored if organizeImportsCollation is not `unicode`.
 	//
 	// Default: `true`
-	OrganizeImportsAccentCollation bool // !!!
+	OrganizeImportsAccentCollation bool
 	// Indicates whether upper case or lower case should sort first. When `false`, the default order for the locale
 	// specified in organizeImportsCollationLocale is used.
 	//
@@ -112,11 +114,11 @@ type UserPreferences struct {
 	// 		- organizeImportsIgnoreCase is `auto` and the auto-detected case sensitivity is case-insensitive.
 	//
 	// Default: `false`
-	OrganizeImportsCaseFirst OrganizeImportsCaseFirst // !!!
+	OrganizeImportsCaseFirst OrganizeImportsCaseFirst
 	// Indicates where named type-only imports should sort. "inline" sorts named imports without regard to if the import is type-only.
 	//
 	// Default: `auto`, which defaults to `last`
-	OrganizeImportsTypeOrder OrganizeImportsTypeOrder // !!!
+	OrganizeImportsTypeOrder OrganizeImportsTypeOrder
 
 	// ------- MoveToFile -------
 
@@ -417,12 +419,12 @@ func (p *UserPreferences) parseInlayHints(prefs any) {
 				if enabled, ok := v["enabled"]; ok {
 					p.set("includeInlayParameterNameHints", enabled)
 				}
-				p.IncludeInlayParameterNameHintsWhenArgumentMatchesName = parseSupress(v, "supressWhenArgumentMatchesName")
+				p.IncludeInlayParameterNameHintsWhenArgumentMatchesName = parseSupress(v, "suppressWhenArgumentMatchesName")
 			case "parameterTypes":
 				p.IncludeInlayFunctionParameterTypeHints = parseEnabledBool(v)
 			case "variableTypes":
 				p.IncludeInlayVariableTypeHints = parseEnabledBool(v)
-				p.IncludeInlayVariableTypeHintsWhenTypeMatchesName = parseSupress(v, "supressWhenTypeMatchesName")
+				p.IncludeInlayVariableTypeHintsWhenTypeMatchesName = parseSupress(v, "suppressWhenTypeMatchesName")
 			case "propertyDeclarationTypes":
 				p.IncludeInlayPropertyDeclarationTypeHints = parseEnabledBool(v)
 			case "functionLikeReturnTypes":
@@ -432,7 +434,7 @@ func (p *UserPreferences) parseInlayHints(prefs any) {
 			}
 		} else {
 			// non-vscode case
-			p.set(name, v)
+			p.set(name, value)
 		}
 	}
 }
@@ -610,7 +612,7 @@ func (p *UserPreferences) set(name string, value any) {
 		p.IncludeInlayParameterNameHints = parseInlayParameterNameHints(value)
 	case "includeinlayparameternamehintswhenargumentmatchesname":
 		p.IncludeInlayParameterNameHintsWhenArgumentMatchesName = parseBoolWithDefault(value, false)
-	case "includeinlayfunctionparametertypeHints":
+	case "includeinlayfunctionparametertypehints":
 		p.IncludeInlayFunctionParameterTypeHints = parseBoolWithDefault(value, false)
 	case "includeinlayvariabletypehints":
 		p.IncludeInlayVariableTypeHints = parseBoolWithDefault(value, false)
@@ -58,6 +58,24 @@ type HasTextDocumentURI interface {
 	TextDocumentURI() DocumentUri
 }
 
+// Call and type hierarchy requests after the prepare request only carry the item being expanded.
+
+func (p *CallHierarchyIncomingCallsParams) TextDocumentURI() DocumentUri {
+	return p.Item.Uri
+}
+
+func (p *CallHierarchyOutgoingCallsParams) TextDocumentURI() DocumentUri {
+	return p.Item.Uri
+}
+
+func (p *TypeHierarchySupertypesParams) TextDocumentURI() DocumentUri {
+	return p.Item.Uri
+}
+
+func (p *TypeHierarchySubtypesParams) TextDocumentURI() DocumentUri {
+	return p.Item.Uri
+}
+
 type URI string // !!!
 
 type Method string
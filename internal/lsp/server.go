@@ -14,6 +14,7 @@ import (
 	"github.com/go-json-experiment/json"
 	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/ls"
 	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
 	"github.com/microsoft/typescript-go/internal/project"
@@ -486,9 +487,23 @@ var handlers = sync.OnceValue(func() handlerMap {
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentOnTypeFormattingInfo, (*Server).handleDocumentOnTypeFormat)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentDocumentSymbolInfo, (*Server).handleDocumentSymbol)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentRenameInfo, (*Server).handleRename)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentPrepareRenameInfo, (*Server).handlePrepareRename)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentDocumentHighlightInfo, (*Server).handleDocumentHighlight)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentSelectionRangeInfo, (*Server).handleSelectionRange)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentCodeActionInfo, (*Server).handleCodeAction)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentInlayHintInfo, (*Server).handleInlayHint)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentSemanticTokensFullInfo, (*Server).handleSemanticTokensFull)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentSemanticTokensRangeInfo, (*Server).handleSemanticTokensRange)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentPrepareCallHierarchyInfo, (*Server).handlePrepareCallHierarchy)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.CallHierarchyIncomingCallsInfo, (*Server).handleCallHierarchyIncomingCalls)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.CallHierarchyOutgoingCallsInfo, (*Server).handleCallHierarchyOutgoingCalls)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentPrepareTypeHierarchyInfo, (*Server).handlePrepareTypeHierarchy)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TypeHierarchySupertypesInfo, (*Server).handleTypeHierarchySupertypes)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TypeHierarchySubtypesInfo, (*Server).handleTypeHierarchySubtypes)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentFoldingRangeInfo, (*Server).handleFoldingRange)
 	registerRequestHandler(handlers, lsproto.WorkspaceSymbolInfo, (*Server).handleWorkspaceSymbol)
+	registerRequestHandler(handlers, lsproto.WorkspaceDiagnosticInfo, (*Server).handleWorkspaceDiagnostic)
+	registerRequestHandler(handlers, lsproto.WorkspaceWillRenameFilesInfo, (*Server).handleWillRenameFiles)
 	registerRequestHandler(handlers, lsproto.CompletionItemResolveInfo, (*Server).handleCompletionItemResolve)
 
 	return handlers
@@ -595,6 +610,7 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 			return nil, err
 		}
 		s.locale = locale
+		diagnostics.EnableLocalization(locale)
 	}
 
 	if s.initializeParams.Trace != nil && *s.initializeParams.Trace == "verbose" {
@@ -635,6 +651,7 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 			DiagnosticProvider: &lsproto.DiagnosticOptionsOrRegistrationOptions{
 				Options: &lsproto.DiagnosticOptions{
 					InterFileDependencies: true,
+					WorkspaceDiagnostics:  true,
 				},
 			},
 			CompletionProvider: &lsproto.CompletionOptions{
@@ -662,7 +679,9 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 				Boolean: ptrTo(true),
 			},
 			RenameProvider: &lsproto.BooleanOrRenameOptions{
-				Boolean: ptrTo(true),
+				RenameOptions: &lsproto.RenameOptions{
+					PrepareProvider: ptrTo(true),
+				},
 			},
 			DocumentHighlightProvider: &lsproto.BooleanOrDocumentHighlightOptions{
 				Boolean: ptrTo(true),
@@ -670,6 +689,59 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 			SelectionRangeProvider: &lsproto.BooleanOrSelectionRangeOptionsOrSelectionRangeRegistrationOptions{
 				Boolean: ptrTo(true),
 			},
+			CodeActionProvider: &lsproto.BooleanOrCodeActionOptions{
+				CodeActionOptions: &lsproto.CodeActionOptions{
+					CodeActionKinds: &[]lsproto.CodeActionKind{
+						lsproto.CodeActionKindQuickFix,
+						lsproto.CodeActionKindSourceOrganizeImports,
+					},
+				},
+			},
+			InlayHintProvider: &lsproto.BooleanOrInlayHintOptionsOrInlayHintRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			SemanticTokensProvider: &lsproto.SemanticTokensOptionsOrRegistrationOptions{
+				Options: &lsproto.SemanticTokensOptions{
+					Legend: ls.SemanticTokensLegend(),
+					Range: &lsproto.BooleanOrEmptyObject{
+						Boolean: ptrTo(true),
+					},
+					Full: &lsproto.BooleanOrSemanticTokensFullDelta{
+						Boolean: ptrTo(true),
+					},
+				},
+			},
+			CallHierarchyProvider: &lsproto.BooleanOrCallHierarchyOptionsOrCallHierarchyRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			TypeHierarchyProvider: &lsproto.BooleanOrTypeHierarchyOptionsOrTypeHierarchyRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			FoldingRangeProvider: &lsproto.BooleanOrFoldingRangeOptionsOrFoldingRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			Workspace: &lsproto.WorkspaceOptions{
+				FileOperations: &lsproto.FileOperationOptions{
+					WillRename: &lsproto.FileOperationRegistrationOptions{
+						Filters: []*lsproto.FileOperationFilter{
+							{
+								Scheme: ptrTo("file"),
+								Pattern: &lsproto.FileOperationPattern{
+									Glob:    "**/*.{ts,tsx,mts,cts,js,jsx,mjs,cjs}",
+									Matches: ptrTo(lsproto.FileOperationPatternKindfile),
+								},
+							},
+							{
+								Scheme: ptrTo("file"),
+								Pattern: &lsproto.FileOperationPattern{
+									Glob:    "**",
+									Matches: ptrTo(lsproto.FileOperationPatternKindfolder),
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -896,6 +968,14 @@ func (s *Server) handleWorkspaceSymbol(ctx context.Context, params *lsproto.Work
 	return ls.ProvideWorkspaceSymbols(ctx, programs, snapshot.Converters(), params.Query)
 }
 
+func (s *Server) handleWorkspaceDiagnostic(ctx context.Context, params *lsproto.WorkspaceDiagnosticParams, reqMsg *lsproto.RequestMessage) (lsproto.WorkspaceDiagnosticResponse, error) {
+	snapshot, release := s.session.GetWorkspaceSnapshot(ctx)
+	defer release()
+	defer s.recover(reqMsg)
+	programs := core.Map(snapshot.ProjectCollection.Projects(), (*project.Project).GetProgram)
+	return ls.ProvideWorkspaceDiagnostics(ctx, programs, snapshot.Converters(), params.PreviousResultIds)
+}
+
 func (s *Server) handleDocumentSymbol(ctx context.Context, ls *ls.LanguageService, params *lsproto.DocumentSymbolParams) (lsproto.DocumentSymbolResponse, error) {
 	return ls.ProvideDocumentSymbols(ctx, params.TextDocument.Uri)
 }
@@ -904,6 +984,47 @@ func (s *Server) handleRename(ctx context.Context, ls *ls.LanguageService, param
 	return ls.ProvideRename(ctx, params)
 }
 
+func (s *Server) handlePrepareRename(ctx context.Context, ls *ls.LanguageService, params *lsproto.PrepareRenameParams) (lsproto.PrepareRenameResponse, error) {
+	return ls.ProvidePrepareRename(ctx, params)
+}
+
+func (s *Server) handleWillRenameFiles(ctx context.Context, params *lsproto.RenameFilesParams, reqMsg *lsproto.RequestMessage) (lsproto.WillRenameFilesResponse, error) {
+	snapshot, release := s.session.Snapshot()
+	defer release()
+	defer s.recover(reqMsg)
+
+	// A file may belong to several projects, so identical edits are only reported once.
+	type edit struct {
+		uri      lsproto.DocumentUri
+		textEdit lsproto.TextEdit
+	}
+	var seen collections.Set[edit]
+	changes := make(map[lsproto.DocumentUri][]*lsproto.TextEdit)
+	for _, project := range snapshot.ProjectCollection.Projects() {
+		languageService := ls.NewLanguageService(project.GetProgram(), snapshot)
+		for _, file := range params.Files {
+			for uri, textEdits := range languageService.ProvideEditsForFileRename(ctx, lsproto.DocumentUri(file.OldUri), lsproto.DocumentUri(file.NewUri)) {
+				for _, textEdit := range textEdits {
+					if seen.AddIfAbsent(edit{uri, *textEdit}) {
+						changes[uri] = append(changes[uri], textEdit)
+					}
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return lsproto.WorkspaceEditOrNull{}, ctx.Err()
+		}
+	}
+	if len(changes) == 0 {
+		return lsproto.WorkspaceEditOrNull{}, nil
+	}
+	return lsproto.WorkspaceEditOrNull{
+		WorkspaceEdit: &lsproto.WorkspaceEdit{
+			Changes: &changes,
+		},
+	}, nil
+}
+
 func (s *Server) handleDocumentHighlight(ctx context.Context, ls *ls.LanguageService, params *lsproto.DocumentHighlightParams) (lsproto.DocumentHighlightResponse, error) {
 	return ls.ProvideDocumentHighlights(ctx, params.TextDocument.Uri, params.Position)
 }
@@ -912,6 +1033,50 @@ func (s *Server) handleSelectionRange(ctx context.Context, ls *ls.LanguageServic
 	return ls.ProvideSelectionRanges(ctx, params)
 }
 
+func (s *Server) handleCodeAction(ctx context.Context, ls *ls.LanguageService, params *lsproto.CodeActionParams) (lsproto.CodeActionResponse, error) {
+	return ls.ProvideCodeActions(ctx, params)
+}
+
+func (s *Server) handleInlayHint(ctx context.Context, ls *ls.LanguageService, params *lsproto.InlayHintParams) (lsproto.InlayHintResponse, error) {
+	return ls.ProvideInlayHint(ctx, params)
+}
+
+func (s *Server) handleSemanticTokensFull(ctx context.Context, ls *ls.LanguageService, params *lsproto.SemanticTokensParams) (lsproto.SemanticTokensResponse, error) {
+	return ls.ProvideSemanticTokens(ctx, params.TextDocument.Uri)
+}
+
+func (s *Server) handleSemanticTokensRange(ctx context.Context, ls *ls.LanguageService, params *lsproto.SemanticTokensRangeParams) (lsproto.SemanticTokensRangeResponse, error) {
+	return ls.ProvideSemanticTokensRange(ctx, params.TextDocument.Uri, params.Range)
+}
+
+func (s *Server) handlePrepareCallHierarchy(ctx context.Context, ls *ls.LanguageService, params *lsproto.CallHierarchyPrepareParams) (lsproto.CallHierarchyPrepareResponse, error) {
+	return ls.ProvidePrepareCallHierarchy(ctx, params.TextDocument.Uri, params.Position)
+}
+
+func (s *Server) handleCallHierarchyIncomingCalls(ctx context.Context, ls *ls.LanguageService, params *lsproto.CallHierarchyIncomingCallsParams) (lsproto.CallHierarchyIncomingCallsResponse, error) {
+	return ls.ProvideCallHierarchyIncomingCalls(ctx, params.Item)
+}
+
+func (s *Server) handleCallHierarchyOutgoingCalls(ctx context.Context, ls *ls.LanguageService, params *lsproto.CallHierarchyOutgoingCallsParams) (lsproto.CallHierarchyOutgoingCallsResponse, error) {
+	return ls.ProvideCallHierarchyOutgoingCalls(ctx, params.Item)
+}
+
+func (s *Server) handlePrepareTypeHierarchy(ctx context.Context, ls *ls.LanguageService, params *lsproto.TypeHierarchyPrepareParams) (lsproto.TypeHierarchyPrepareResponse, error) {
+	return ls.ProvidePrepareTypeHierarchy(ctx, params.TextDocument.Uri, params.Position)
+}
+
+func (s *Server) handleTypeHierarchySupertypes(ctx context.Context, ls *ls.LanguageService, params *lsproto.TypeHierarchySupertypesParams) (lsproto.TypeHierarchySupertypesResponse, error) {
+	return ls.ProvideTypeHierarchySupertypes(ctx, params.Item)
+}
+
+func (s *Server) handleTypeHierarchySubtypes(ctx context.Context, ls *ls.LanguageService, params *lsproto.TypeHierarchySubtypesParams) (lsproto.TypeHierarchySubtypesResponse, error) {
+	return ls.ProvideTypeHierarchySubtypes(ctx, params.Item)
+}
+
+func (s *Server) handleFoldingRange(ctx context.Context, ls *ls.LanguageService, params *lsproto.FoldingRangeParams) (lsproto.FoldingRangeResponse, error) {
+	return ls.ProvideFoldingRange(ctx, params.TextDocument.Uri)
+}
+
 func (s *Server) Log(msg ...any) {
 	fmt.Fprintln(s.stderr, msg...)
 }
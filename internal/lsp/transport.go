@@ -0,0 +1,77 @@
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/microsoft/typescript-go/internal/project"
+)
+
+// DialSocket connects to a client listening for the server on a TCP address, given either as
+// "host:port" or as a bare port on the loopback interface.
+func DialSocket(address string) (net.Conn, error) {
+	return net.Dial("tcp", socketAddress(address))
+}
+
+// ListenSocket listens for clients on a TCP address, given either as "host:port" or as a bare port
+// on the loopback interface.
+func ListenSocket(address string) (net.Listener, error) {
+	return net.Listen("tcp", socketAddress(address))
+}
+
+func socketAddress(address string) string {
+	if _, err := strconv.ParseUint(address, 10, 16); err == nil {
+		return net.JoinHostPort("127.0.0.1", address)
+	}
+	return address
+}
+
+// DialPipe connects to a client listening for the server on a Unix domain socket or, on Windows,
+// a named pipe.
+func DialPipe(path string) (io.ReadWriteCloser, error) {
+	return dialPipe(path)
+}
+
+// ListenPipe listens for clients on a Unix domain socket.
+func ListenPipe(path string) (net.Listener, error) {
+	return listenPipe(path)
+}
+
+// Serve accepts connections from listener until ctx is done, running a separate server for each one.
+// The servers share a parse cache, so files parsed for one client are reused by the others.
+// The In and Out options are ignored in favor of each connection.
+func Serve(ctx context.Context, listener net.Listener, opts ServerOptions) error {
+	if opts.ParseCache == nil {
+		opts.ParseCache = &project.ParseCache{}
+	}
+	stop := context.AfterFunc(ctx, func() { listener.Close() })
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			connOpts := opts
+			connOpts.In = ToReader(conn)
+			connOpts.Out = ToWriter(conn)
+			if err := NewServer(&connOpts).Run(ctx); err != nil && !errors.Is(err, context.Canceled) && opts.Err != nil {
+				fmt.Fprintf(opts.Err, "error serving %s: %v\n", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
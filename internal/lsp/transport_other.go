@@ -0,0 +1,34 @@
+//go:build !windows
+
+package lsp
+
+import (
+	"io"
+	"io/fs"
+	"net"
+	"os"
+)
+
+func dialPipe(path string) (io.ReadWriteCloser, error) {
+	return net.Dial("unix", path)
+}
+
+func listenPipe(path string) (net.Listener, error) {
+	removeStaleSocket(path)
+	return net.Listen("unix", path)
+}
+
+// removeStaleSocket removes the socket at path if no server is listening on it, as is left behind
+// by a server that crashed, since listening on a path that exists fails.
+func removeStaleSocket(path string) {
+	info, err := os.Lstat(path) //nolint:forbidigo
+	if err != nil || info.Mode().Type() != fs.ModeSocket {
+		return
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		// Another server is listening; leave it be, and let listening fail.
+		_ = conn.Close()
+		return
+	}
+	_ = os.Remove(path) //nolint:forbidigo
+}
@@ -0,0 +1,148 @@
+package lsp_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/lsp"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+	"gotest.tools/v3/assert"
+)
+
+func newServerOptions() lsp.ServerOptions {
+	return lsp.ServerOptions{
+		Err:                io.Discard,
+		Cwd:                "/",
+		FS:                 bundled.WrapFS(vfstest.FromMap(map[string]string{}, true /*useCaseSensitiveFileNames*/)),
+		DefaultLibraryPath: bundled.LibPath(),
+	}
+}
+
+// initialize sends an initialize request over conn and checks that the server responds to it.
+func initialize(t *testing.T, conn io.ReadWriter) {
+	t.Helper()
+	one := int32(1)
+	id := lsproto.NewID(lsproto.IntegerOrString{Integer: &one})
+	req := lsproto.NewRequestMessage(lsproto.MethodInitialize, id, &lsproto.InitializeParams{
+		Capabilities: &lsproto.ClientCapabilities{},
+	})
+	assert.NilError(t, lsp.ToWriter(conn).Write(req.Message()))
+	msg, err := lsp.ToReader(conn).Read()
+	assert.NilError(t, err)
+	assert.Equal(t, msg.Kind, lsproto.MessageKindResponse)
+	resp := msg.AsResponse()
+	assert.Equal(t, resp.ID.String(), id.String())
+	assert.Assert(t, resp.Error == nil, "unexpected error: %v", resp.Error)
+}
+
+// serve runs lsp.Serve on listener until the test ends.
+func serve(t *testing.T, listener net.Listener) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- lsp.Serve(ctx, listener, newServerOptions()) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NilError(t, <-done)
+	})
+}
+
+func TestServeSocket(t *testing.T) {
+	t.Parallel()
+	listener, err := lsp.ListenSocket("127.0.0.1:0")
+	assert.NilError(t, err)
+	serve(t, listener)
+
+	// Each client gets its own server.
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() {
+			conn, err := net.Dial("tcp", listener.Addr().String())
+			assert.NilError(t, err)
+			defer conn.Close()
+			initialize(t, conn)
+		})
+	}
+	wg.Wait()
+}
+
+func TestServePipe(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets in temporary directories are not reliable on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "tsgo.sock")
+	listener, err := lsp.ListenPipe(path)
+	assert.NilError(t, err)
+	serve(t, listener)
+
+	conn, err := lsp.DialPipe(path)
+	assert.NilError(t, err)
+	defer conn.Close()
+	initialize(t, conn)
+}
+
+func TestServePipeStaleSocket(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets in temporary directories are not reliable on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "tsgo.sock")
+	// A server that crashed leaves its socket behind.
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	assert.NilError(t, err)
+	stale.SetUnlinkOnClose(false)
+	assert.NilError(t, stale.Close())
+
+	listener, err := lsp.ListenPipe(path)
+	assert.NilError(t, err)
+	serve(t, listener)
+
+	conn, err := lsp.DialPipe(path)
+	assert.NilError(t, err)
+	defer conn.Close()
+	initialize(t, conn)
+
+	// A socket that a server is listening on is left alone.
+	_, err = lsp.ListenPipe(path)
+	assert.ErrorContains(t, err, "address already in use")
+}
+
+func TestDialSocket(t *testing.T) {
+	t.Parallel()
+	// The client listens, and the server connects to it.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.NilError(t, err)
+
+	serverConn, err := lsp.DialSocket(port)
+	assert.NilError(t, err)
+	opts := newServerOptions()
+	opts.In = lsp.ToReader(serverConn)
+	opts.Out = lsp.ToWriter(serverConn)
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = lsp.NewServer(&opts).Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		serverConn.Close()
+		<-done
+	}()
+
+	clientConn, err := listener.Accept()
+	assert.NilError(t, err)
+	defer clientConn.Close()
+	initialize(t, clientConn)
+}
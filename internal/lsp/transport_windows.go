@@ -0,0 +1,26 @@
+package lsp
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+)
+
+const namedPipePrefix = `\\.\pipe\`
+
+func dialPipe(path string) (io.ReadWriteCloser, error) {
+	if strings.HasPrefix(path, namedPipePrefix) {
+		// The client end of a named pipe can be opened like a file.
+		return os.OpenFile(path, os.O_RDWR, 0)
+	}
+	return net.Dial("unix", path)
+}
+
+func listenPipe(path string) (net.Listener, error) {
+	if strings.HasPrefix(path, namedPipePrefix) {
+		return nil, errors.New("listening on named pipes is not supported; use a Unix domain socket path or --socket")
+	}
+	return net.Listen("unix", path)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/sourcemap"
 )
 
 // Stores side-table information used during transformation that can be read by the printer to customize emit
@@ -461,6 +462,12 @@ func (c *EmitContext) SetOriginalEx(node *ast.Node, original *ast.Node, allowOve
 		if emitNode := c.emitNodes.TryGet(original); emitNode != nil {
 			c.emitNodes.Get(node).copyFrom(emitNode)
 		}
+		if assignedName, ok := c.assignedName[original]; ok {
+			c.SetAssignedName(node, assignedName)
+		}
+		if classThis, ok := c.classThis[original]; ok {
+			c.SetClassThis(node, classThis)
+		}
 	} else if !allowOverwrite && existing != original {
 		panic("Original node already set.")
 	} else if allowOverwrite {
@@ -525,6 +532,7 @@ type emitNode struct {
 	emitFlags                 EmitFlags
 	commentRange              core.TextRange
 	sourceMapRange            core.TextRange
+	sourceMapSource           sourcemap.Source
 	tokenSourceMapRanges      map[ast.Kind]core.TextRange
 	helpers                   []*EmitHelper
 	externalHelpersModuleName *ast.IdentifierNode
@@ -532,15 +540,43 @@ type emitNode struct {
 	trailingComments          []SynthesizedComment
 }
 
+// Merges the emit-related data of `source` into this node. Synthetic comments and helpers are combined, while ranges and
+// flags present on `source` replace those of this node.
+//
+// NOTE: This is the equivalent to `mergeEmitNode` in Strada.
 // NOTE: This method is not guaranteed to be thread-safe
 func (e *emitNode) copyFrom(source *emitNode) {
-	e.flags = source.flags
-	e.emitFlags = source.emitFlags
-	e.commentRange = source.commentRange
-	e.sourceMapRange = source.sourceMapRange
-	e.tokenSourceMapRanges = maps.Clone(source.tokenSourceMapRanges)
-	e.helpers = slices.Clone(source.helpers)
-	e.externalHelpersModuleName = source.externalHelpersModuleName
+	if source.leadingComments != nil {
+		e.leadingComments = append(slices.Clone(source.leadingComments), e.leadingComments...)
+	}
+	if source.trailingComments != nil {
+		e.trailingComments = append(slices.Clone(source.trailingComments), e.trailingComments...)
+	}
+	if source.emitFlags != EFNone {
+		e.emitFlags = source.emitFlags
+	}
+	if source.flags&hasCommentRange != 0 {
+		e.commentRange = source.commentRange
+		e.flags |= hasCommentRange
+	}
+	if source.flags&hasSourceMapRange != 0 {
+		e.sourceMapRange = source.sourceMapRange
+		e.sourceMapSource = source.sourceMapSource
+		e.flags |= hasSourceMapRange
+	}
+	if source.tokenSourceMapRanges != nil {
+		if e.tokenSourceMapRanges == nil {
+			e.tokenSourceMapRanges = maps.Clone(source.tokenSourceMapRanges)
+		} else {
+			maps.Copy(e.tokenSourceMapRanges, source.tokenSourceMapRanges)
+		}
+	}
+	if source.helpers != nil {
+		e.helpers = append(e.helpers, source.helpers...)
+	}
+	if source.externalHelpersModuleName != nil {
+		e.externalHelpersModuleName = source.externalHelpersModuleName
+	}
 }
 
 func (c *EmitContext) EmitFlags(node *ast.Node) EmitFlags {
@@ -590,12 +626,31 @@ func (c *EmitContext) SourceMapRange(node *ast.Node) core.TextRange {
 func (c *EmitContext) SetSourceMapRange(node *ast.Node, loc core.TextRange) {
 	emitNode := c.emitNodes.Get(node)
 	emitNode.sourceMapRange = loc
+	emitNode.sourceMapSource = nil
+	emitNode.flags |= hasSourceMapRange
+}
+
+// Gets the source to which the source map range of a node refers, or `nil` if the range refers to the source file
+// currently being printed.
+func (c *EmitContext) SourceMapSource(node *ast.Node) sourcemap.Source {
+	if emitNode := c.emitNodes.TryGet(node); emitNode != nil && emitNode.flags&hasSourceMapRange != 0 {
+		return emitNode.sourceMapSource
+	}
+	return nil
+}
+
+// Sets the range to use for a node when emitting source maps, where the range refers to a position in `source` rather
+// than in the source file currently being printed.
+func (c *EmitContext) SetSourceMapRangeWithSource(node *ast.Node, loc core.TextRange, source sourcemap.Source) {
+	emitNode := c.emitNodes.Get(node)
+	emitNode.sourceMapRange = loc
+	emitNode.sourceMapSource = source
 	emitNode.flags |= hasSourceMapRange
 }
 
 // Sets the range to use for a node when emitting source maps.
 func (c *EmitContext) AssignSourceMapRange(to *ast.Node, from *ast.Node) {
-	c.SetSourceMapRange(to, c.SourceMapRange(from))
+	c.SetSourceMapRangeWithSource(to, c.SourceMapRange(from), c.SourceMapSource(from))
 }
 
 // Sets the range to use for a node when emitting comments and source maps.
@@ -605,6 +660,7 @@ func (c *EmitContext) AssignCommentAndSourceMapRanges(to *ast.Node, from *ast.No
 	sourceMapRange := c.SourceMapRange(from)
 	emitNode.commentRange = commentRange
 	emitNode.sourceMapRange = sourceMapRange
+	emitNode.sourceMapSource = c.SourceMapSource(from)
 	emitNode.flags |= hasCommentRange | hasSourceMapRange
 }
 
@@ -667,7 +723,9 @@ func (c *EmitContext) ReadEmitHelpers() []*EmitHelper {
 
 func (c *EmitContext) AddEmitHelper(node *ast.Node, helper ...*EmitHelper) {
 	emitNode := c.emitNodes.Get(node)
-	emitNode.helpers = append(emitNode.helpers, helper...)
+	for _, h := range helper {
+		emitNode.helpers = core.AppendIfUnique(emitNode.helpers, h)
+	}
 }
 
 func (c *EmitContext) MoveEmitHelpers(source *ast.Node, target *ast.Node, predicate func(helper *EmitHelper) bool) {
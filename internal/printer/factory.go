@@ -217,7 +217,10 @@ func (f *NodeFactory) NewLogicalORExpression(left *ast.Expression, right *ast.Ex
 	return f.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, f.NewToken(ast.KindBarBarToken), right)
 }
 
-// func (f *NodeFactory) NewLogicalANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
+func (f *NodeFactory) NewLogicalANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression {
+	return f.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, f.NewToken(ast.KindAmpersandAmpersandToken), right)
+}
+
 // func (f *NodeFactory) NewBitwiseORExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
 // func (f *NodeFactory) NewBitwiseXORExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
 // func (f *NodeFactory) NewBitwiseANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
@@ -318,6 +321,80 @@ func (f *NodeFactory) NewFunctionCallCall(target *ast.Expression, thisArg *ast.E
 	return f.NewMethodCall(target, f.NewIdentifier("call"), args)
 }
 
+func (f *NodeFactory) NewFunctionApplyCall(target *ast.Expression, thisArg *ast.Expression, argumentsExpression *ast.Expression) *ast.Node {
+	if thisArg == nil {
+		panic("Attempted to construct function apply call without this argument expression")
+	}
+	return f.NewMethodCall(target, f.NewIdentifier("apply"), []*ast.Expression{thisArg, argumentsExpression})
+}
+
+// Gets the target and `this` argument to use when rewriting a call to `expression` as a call to `Function.prototype.call`
+// or `Function.prototype.apply`. When the receiver of a property or element access must only be evaluated once, it is
+// captured in a temporary variable that is passed to `recordTempVariable`.
+func (f *NodeFactory) NewCallBinding(expression *ast.Expression, recordTempVariable func(temp *ast.IdentifierNode), cacheIdentifiers bool) (target *ast.Expression, thisArg *ast.Expression) {
+	callee := ast.SkipOuterExpressions(expression, ast.OEKAll)
+	switch {
+	case (ast.IsPropertyAccessExpression(callee) || ast.IsElementAccessExpression(callee)) && callee.Expression().Kind == ast.KindSuperKeyword:
+		thisArg = f.NewThisExpression()
+		target = callee
+	case callee.Kind == ast.KindSuperKeyword:
+		thisArg = f.NewThisExpression()
+		target = callee
+	case f.emitContext.EmitFlags(callee)&EFHelperName != 0:
+		thisArg = f.NewVoidZeroExpression()
+		target = callee
+	case ast.IsPropertyAccessExpression(callee):
+		if shouldBeCapturedInTempVariable(callee.Expression(), cacheIdentifiers) {
+			// for `a.b()` target is `(_a = a).b` and thisArg is `_a`
+			temp := f.NewTempVariable()
+			recordTempVariable(temp)
+			receiver := f.NewAssignmentExpression(temp, callee.Expression())
+			receiver.Loc = callee.Expression().Loc
+			thisArg = temp
+			target = f.NewPropertyAccessExpression(receiver, nil /*questionDotToken*/, callee.Name(), ast.NodeFlagsNone)
+			target.Loc = callee.Loc
+		} else {
+			thisArg = callee.Expression()
+			target = callee
+		}
+	case ast.IsElementAccessExpression(callee):
+		if shouldBeCapturedInTempVariable(callee.Expression(), cacheIdentifiers) {
+			// for `a[b]()` target is `(_a = a)[b]` and thisArg is `_a`
+			temp := f.NewTempVariable()
+			recordTempVariable(temp)
+			receiver := f.NewAssignmentExpression(temp, callee.Expression())
+			receiver.Loc = callee.Expression().Loc
+			thisArg = temp
+			target = f.NewElementAccessExpression(receiver, nil /*questionDotToken*/, callee.AsElementAccessExpression().ArgumentExpression, ast.NodeFlagsNone)
+			target.Loc = callee.Loc
+		} else {
+			thisArg = callee.Expression()
+			target = callee
+		}
+	default:
+		// for `a()` target is `a` and thisArg is `void 0`
+		thisArg = f.NewVoidZeroExpression()
+		target = expression
+	}
+	return target, thisArg
+}
+
+func shouldBeCapturedInTempVariable(node *ast.Expression, cacheIdentifiers bool) bool {
+	target := ast.SkipParentheses(node)
+	switch target.Kind {
+	case ast.KindIdentifier:
+		return cacheIdentifiers
+	case ast.KindThisKeyword, ast.KindNumericLiteral, ast.KindBigIntLiteral, ast.KindStringLiteral:
+		return false
+	case ast.KindArrayLiteralExpression:
+		return len(target.AsArrayLiteralExpression().Elements.Nodes) > 0
+	case ast.KindObjectLiteralExpression:
+		return len(target.AsObjectLiteralExpression().Properties.Nodes) > 0
+	default:
+		return true
+	}
+}
+
 func (f *NodeFactory) NewArraySliceCall(array *ast.Expression, start int) *ast.Node {
 	var args []*ast.Node
 	if start != 0 {
@@ -494,7 +571,9 @@ func (f *NodeFactory) GetDeclarationNameEx(node *ast.Declaration, opts NameOptio
 
 func (f *NodeFactory) GetNamespaceMemberName(ns *ast.IdentifierNode, name *ast.IdentifierNode, opts NameOptions) *ast.IdentifierNode {
 	if !f.emitContext.HasAutoGenerateInfo(name) {
+		// comments and source maps for the name are emitted by the qualified name, if allowed
 		name = name.Clone(f)
+		f.emitContext.AddEmitFlags(name, EFNoComments|EFNoSourceMap)
 	}
 	qualifiedName := f.NewPropertyAccessExpression(ns, nil /*questionDotToken*/, name, ast.NodeFlagsNone)
 	f.emitContext.AssignCommentAndSourceMapRanges(qualifiedName, name)
@@ -544,7 +623,240 @@ func (f *NodeFactory) NewDisposeResourcesHelper(envBinding *ast.Expression) *ast
 	)
 }
 
-// !!! Class Fields Helpers
+// Legacy Decorators Helpers
+
+// Allocates a new Call expression to the `__decorate` helper. `memberName` and `descriptor` may be nil when decorating a
+// class constructor, and `descriptor` may be nil when decorating a property.
+func (f *NodeFactory) NewDecorateHelper(decoratorExpressions []*ast.Expression, target *ast.Expression, memberName *ast.Expression, descriptor *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(decorateHelper)
+	arguments := []*ast.Expression{
+		f.NewArrayLiteralExpression(f.NewNodeList(decoratorExpressions), true /*multiLine*/),
+		target,
+	}
+	if memberName != nil {
+		arguments = append(arguments, memberName)
+		if descriptor != nil {
+			arguments = append(arguments, descriptor)
+		}
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__decorate"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__metadata` helper.
+func (f *NodeFactory) NewMetadataHelper(metadataKey string, metadataValue *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(metadataHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__metadata"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{f.NewStringLiteral(metadataKey), metadataValue}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__param` helper.
+func (f *NodeFactory) NewParamHelper(expression *ast.Expression, parameterOffset int, location core.TextRange) *ast.Expression {
+	f.emitContext.RequestEmitHelper(paramHelper)
+	helper := f.NewCallExpression(
+		f.NewUnscopedHelperName("__param"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{f.NewNumericLiteral(strconv.Itoa(parameterOffset)), expression}),
+		ast.NodeFlagsNone,
+	)
+	helper.Loc = location
+	return helper
+}
+
+// ES Decorators Helpers
+
+// Describes the name of a decorated class element, as passed to the `__esDecorate` helper.
+type ESDecorateName struct {
+	Computed bool      // indicates whether `Name` is an expression that evaluates to the property key
+	Name     *ast.Node // an Identifier or PrivateIdentifier, or an expression when `Computed` is set
+}
+
+// Describes the `context` argument passed to the `__esDecorate` helper.
+type ESDecorateContext struct {
+	Kind     string // one of "class", "method", "getter", "setter", "accessor", or "field"
+	Name     ESDecorateName
+	Static   bool
+	Private  bool
+	Get      bool // indicates whether the `access` object has a `get` method
+	Set      bool // indicates whether the `access` object has a `set` method
+	Metadata *ast.Expression
+}
+
+// Allocates a new Call expression to the `__esDecorate` helper.
+func (f *NodeFactory) NewESDecorateHelper(ctor *ast.Expression, descriptorIn *ast.Expression, decorators *ast.Expression, contextIn ESDecorateContext, initializers *ast.Expression, extraInitializers *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(esDecorateHelper)
+	var context *ast.Expression
+	if contextIn.Kind == "class" {
+		context = f.newESDecorateClassContextObject(contextIn)
+	} else {
+		context = f.newESDecorateClassElementContextObject(contextIn)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__esDecorate"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{ctor, descriptorIn, decorators, context, initializers, extraInitializers}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// { kind: "class", name: _classThis.name, metadata: _metadata }
+func (f *NodeFactory) newESDecorateClassContextObject(contextIn ESDecorateContext) *ast.Expression {
+	return f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+		f.NewPropertyAssignment(nil, f.NewIdentifier("kind"), nil, nil, f.NewStringLiteral(contextIn.Kind)),
+		f.NewPropertyAssignment(nil, f.NewIdentifier("name"), nil, nil, contextIn.Name.Name),
+		f.NewPropertyAssignment(nil, f.NewIdentifier("metadata"), nil, nil, contextIn.Metadata),
+	}), false /*multiLine*/)
+}
+
+// { kind: "method", name: "m", static: false, private: false, access: { ... }, metadata: _metadata }
+func (f *NodeFactory) newESDecorateClassElementContextObject(contextIn ESDecorateContext) *ast.Expression {
+	var name *ast.Expression
+	if contextIn.Name.Computed {
+		name = contextIn.Name.Name
+	} else {
+		name = f.NewStringLiteralFromNode(contextIn.Name.Name)
+	}
+	return f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+		f.NewPropertyAssignment(nil, f.NewIdentifier("kind"), nil, nil, f.NewStringLiteral(contextIn.Kind)),
+		f.NewPropertyAssignment(nil, f.NewIdentifier("name"), nil, nil, name),
+		f.NewPropertyAssignment(nil, f.NewIdentifier("static"), nil, nil, core.IfElse(contextIn.Static, f.NewTrueExpression(), f.NewFalseExpression())),
+		f.NewPropertyAssignment(nil, f.NewIdentifier("private"), nil, nil, core.IfElse(contextIn.Private, f.NewTrueExpression(), f.NewFalseExpression())),
+		f.NewPropertyAssignment(nil, f.NewIdentifier("access"), nil, nil, f.newESDecorateClassElementAccessObject(contextIn)),
+		f.NewPropertyAssignment(nil, f.NewIdentifier("metadata"), nil, nil, contextIn.Metadata),
+	}), false /*multiLine*/)
+}
+
+// { has: obj => "x" in obj, get: obj => obj.x, set: (obj, value) => { obj.x = value; } }
+func (f *NodeFactory) newESDecorateClassElementAccessObject(contextIn ESDecorateContext) *ast.Expression {
+	elementName := contextIn.Name
+	newAccessor := func() *ast.Expression {
+		if elementName.Computed {
+			return f.NewElementAccessExpression(f.NewIdentifier("obj"), nil /*questionDotToken*/, f.cloneESDecorateName(elementName.Name), ast.NodeFlagsNone)
+		}
+		return f.NewPropertyAccessExpression(f.NewIdentifier("obj"), nil /*questionDotToken*/, elementName.Name.Clone(f), ast.NodeFlagsNone)
+	}
+	newArrow := func(parameterNames []string, body *ast.Node) *ast.Expression {
+		parameters := make([]*ast.Node, len(parameterNames))
+		for i, name := range parameterNames {
+			parameters[i] = f.NewParameterDeclaration(nil, nil, f.NewIdentifier(name), nil, nil, nil)
+		}
+		return f.NewArrowFunction(nil, nil, f.NewNodeList(parameters), nil, nil, f.NewToken(ast.KindEqualsGreaterThanToken), body)
+	}
+
+	var propertyName *ast.Expression
+	switch {
+	case elementName.Computed:
+		propertyName = f.cloneESDecorateName(elementName.Name)
+	case ast.IsIdentifier(elementName.Name):
+		propertyName = f.NewStringLiteralFromNode(elementName.Name)
+	default:
+		propertyName = elementName.Name.Clone(f)
+	}
+	properties := []*ast.Node{
+		f.NewPropertyAssignment(nil, f.NewIdentifier("has"), nil, nil, newArrow([]string{"obj"},
+			f.NewBinaryExpression(nil, propertyName, nil, f.NewToken(ast.KindInKeyword), f.NewIdentifier("obj")),
+		)),
+	}
+	if contextIn.Get {
+		properties = append(properties, f.NewPropertyAssignment(nil, f.NewIdentifier("get"), nil, nil, newArrow([]string{"obj"}, newAccessor())))
+	}
+	if contextIn.Set {
+		properties = append(properties, f.NewPropertyAssignment(nil, f.NewIdentifier("set"), nil, nil, newArrow([]string{"obj", "value"},
+			f.NewBlock(f.NewNodeList([]*ast.Node{
+				f.NewExpressionStatement(f.NewAssignmentExpression(newAccessor(), f.NewIdentifier("value"))),
+			}), false /*multiLine*/),
+		)))
+	}
+	return f.NewObjectLiteralExpression(f.NewNodeList(properties), false /*multiLine*/)
+}
+
+// Clones a decorated element name, preserving the text source of a string literal created by `NewStringLiteralFromNode`.
+func (f *NodeFactory) cloneESDecorateName(name *ast.Node) *ast.Node {
+	if ast.IsStringLiteral(name) {
+		if textSourceNode := f.emitContext.textSource[name]; textSourceNode != nil {
+			return f.NewStringLiteralFromNode(textSourceNode)
+		}
+	}
+	return name.Clone(f)
+}
+
+// Allocates a new Call expression to the `__runInitializers` helper. `value` may be nil when the initializers are
+// extra initializers that do not produce a value.
+func (f *NodeFactory) NewRunInitializersHelper(thisArg *ast.Expression, initializers *ast.Expression, value *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(runInitializersHelper)
+	arguments := []*ast.Expression{thisArg, initializers}
+	if value != nil {
+		arguments = append(arguments, value)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__runInitializers"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Class Fields Helpers
+
+// Allocates a new Call expression to the `__classPrivateFieldGet` helper. `kind` is one of `"f"` (field), `"m"` (method), or
+// `"a"` (accessor). `fn` may be nil when the value is read directly from a `WeakMap`-backed field.
+func (f *NodeFactory) NewClassPrivateFieldGetHelper(receiver *ast.Expression, state *ast.Expression, kind string, fn *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldGetHelper)
+	args := []*ast.Expression{receiver, state, f.NewStringLiteral(kind)}
+	if fn != nil {
+		args = append(args, fn)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldGet"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(args),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__classPrivateFieldSet` helper. `kind` is one of `"f"` (field), `"m"` (method), or
+// `"a"` (accessor). `fn` may be nil when the value is written directly to a `WeakMap`-backed field.
+func (f *NodeFactory) NewClassPrivateFieldSetHelper(receiver *ast.Expression, state *ast.Expression, value *ast.Expression, kind string, fn *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldSetHelper)
+	args := []*ast.Expression{receiver, state, value, f.NewStringLiteral(kind)}
+	if fn != nil {
+		args = append(args, fn)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldSet"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(args),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__classPrivateFieldIn` helper, used to downlevel `#x in obj`.
+func (f *NodeFactory) NewClassPrivateFieldInHelper(state *ast.Expression, receiver *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldInHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldIn"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{state, receiver}),
+		ast.NodeFlagsNone,
+	)
+}
+
 // !!! ES2018 Helpers
 // Chains a sequence of expressions using the __assign helper or Object.assign if available in the target
 func (f *NodeFactory) NewAssignHelper(attributesSegments []*ast.Expression, scriptTarget core.ScriptTarget) *ast.Expression {
@@ -604,7 +916,134 @@ func (f *NodeFactory) NewRestHelper(value *ast.Expression, elements []*ast.Node,
 	)
 }
 
-// !!! ES2017 Helpers
+// ES2018 Async Iteration Helpers
+
+// Allocates a new Call expression to the `__await` helper, which marks a value yielded from an async generator
+// as one that should be awaited rather than produced by the iterator.
+func (f *NodeFactory) NewAwaitHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(awaitHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__await"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncGenerator` helper that runs `generatorFunc` as the body of an
+// async generator.
+func (f *NodeFactory) NewAsyncGeneratorHelper(generatorFunc *ast.Expression, hasLexicalThis bool) *ast.Expression {
+	f.emitContext.RequestEmitHelper(asyncGeneratorHelper)
+	f.emitContext.AddEmitFlags(generatorFunc, EFReuseTempVariableScope)
+
+	var thisArg *ast.Expression
+	if hasLexicalThis {
+		thisArg = f.NewThisExpression()
+	} else {
+		thisArg = f.NewVoidZeroExpression()
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncGenerator"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{thisArg, f.NewIdentifier("arguments"), generatorFunc}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncDelegator` helper, which forwards `next`, `throw`, and `return`
+// from a `yield*` in an async generator to an async iterator.
+func (f *NodeFactory) NewAsyncDelegatorHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(asyncDelegatorHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncDelegator"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncValues` helper, which gets an async iterator for a value,
+// adapting a sync iterator if the value is not async iterable.
+func (f *NodeFactory) NewAsyncValuesHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(asyncValuesHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncValues"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2017 Helpers
+
+// Allocates a new Call expression to the `__awaiter` helper that runs `body` as a generator function.
+func (f *NodeFactory) NewAwaiterHelper(hasLexicalThis bool, argumentsExpression *ast.Expression, promiseConstructor *ast.Expression, parameters *ast.ParameterList, body *ast.BlockNode) *ast.Expression {
+	f.emitContext.RequestEmitHelper(awaiterHelper)
+
+	if parameters == nil {
+		parameters = f.NewNodeList([]*ast.Node{})
+	}
+	generatorFunc := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		f.NewToken(ast.KindAsteriskToken),
+		nil, /*name*/
+		nil, /*typeParameters*/
+		parameters,
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		body,
+	)
+	f.emitContext.AddEmitFlags(generatorFunc, EFReuseTempVariableScope)
+
+	var thisArg *ast.Expression
+	if hasLexicalThis {
+		thisArg = f.NewThisExpression()
+	} else {
+		thisArg = f.NewVoidZeroExpression()
+	}
+	if argumentsExpression == nil {
+		argumentsExpression = f.NewVoidZeroExpression()
+	}
+	if promiseConstructor == nil {
+		promiseConstructor = f.NewVoidZeroExpression()
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__awaiter"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{thisArg, argumentsExpression, promiseConstructor, generatorFunc}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the scoped `_superIndex` helper. When `hasBinding` is true the result
+// is a `.value` property access that may also be used as an assignment target.
+func (f *NodeFactory) NewAsyncSuperIndexHelper(argumentExpression *ast.Expression, hasBinding bool) *ast.Expression {
+	call := f.NewCallExpression(
+		f.NewUniqueNameEx("_superIndex", AutoGenerateOptions{Flags: GeneratedIdentifierFlagsOptimistic | GeneratedIdentifierFlagsFileLevel}),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{argumentExpression}),
+		ast.NodeFlagsNone,
+	)
+	if hasBinding {
+		return f.NewPropertyAccessExpression(call, nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)
+	}
+	return call
+}
+
+// Adds the scoped `_superIndex` helper to a function body.
+func (f *NodeFactory) AddAsyncSuperHelper(body *ast.BlockNode, hasBinding bool) {
+	if hasBinding {
+		f.emitContext.AddEmitHelper(body, advancedAsyncSuperHelper)
+	} else {
+		f.emitContext.AddEmitHelper(body, asyncSuperHelper)
+	}
+}
 
 // ES2015 Helpers
 
@@ -636,6 +1075,71 @@ func (f *NodeFactory) NewSetFunctionNameHelper(fn *ast.Expression, name *ast.Exp
 	)
 }
 
+// Allocates a new Call expression to the `__spreadArray` helper, which appends the elements of `from` to `to`. When
+// `packFrom` is true, holes in `from` are replaced with `undefined`.
+func (f *NodeFactory) NewSpreadArrayHelper(to *ast.Expression, from *ast.Expression, packFrom bool) *ast.Expression {
+	f.emitContext.RequestEmitHelper(spreadArrayHelper)
+	var pack *ast.Expression
+	if packFrom {
+		pack = f.NewTrueExpression()
+	} else {
+		pack = f.NewFalseExpression()
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__spreadArray"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{to, from, pack}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2015 Destructuring Helpers
+
+// Allocates a new Call expression to the `__values` helper, which gets an iterator for a value.
+func (f *NodeFactory) NewValuesHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(valuesHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__values"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__read` helper, which reads up to `count` values from an iterable into an
+// array. A negative `count` reads every value.
+func (f *NodeFactory) NewReadHelper(iteratorRecord *ast.Expression, count int) *ast.Expression {
+	f.emitContext.RequestEmitHelper(readHelper)
+	arguments := []*ast.Expression{iteratorRecord}
+	if count >= 0 {
+		arguments = append(arguments, f.NewNumericLiteral(strconv.Itoa(count)))
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__read"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2015 Generator Helpers
+
+// Allocates a new Call expression to the `__generator` helper that steps through `body`, the state machine for a
+// generator function.
+func (f *NodeFactory) NewGeneratorHelper(body *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(generatorHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__generator"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{f.NewThisExpression(), body}),
+		ast.NodeFlagsNone,
+	)
+}
+
 // ES Module Helpers
 
 // Allocates a new Call expression to the `__importDefault` helper.
@@ -98,7 +98,126 @@ var disposeResourcesHelper = &EmitHelper{
 });`,
 }
 
-// !!! Class Fields Helpers
+// Legacy Decorators Helpers
+
+var decorateHelper = &EmitHelper{
+	Name:       "typescript:decorate",
+	ImportName: "__decorate",
+	Scoped:     false,
+	Priority:   &Priority{2},
+	Text: `var __decorate = (this && this.__decorate) || function (decorators, target, key, desc) {
+    var c = arguments.length, r = c < 3 ? target : desc === null ? desc = Object.getOwnPropertyDescriptor(target, key) : desc, d;
+    if (typeof Reflect === "object" && typeof Reflect.decorate === "function") r = Reflect.decorate(decorators, target, key, desc);
+    else for (var i = decorators.length - 1; i >= 0; i--) if (d = decorators[i]) r = (c < 3 ? d(r) : c > 3 ? d(target, key, r) : d(target, key)) || r;
+    return c > 3 && r && Object.defineProperty(target, key, r), r;
+};`,
+}
+
+var metadataHelper = &EmitHelper{
+	Name:       "typescript:metadata",
+	ImportName: "__metadata",
+	Scoped:     false,
+	Priority:   &Priority{3},
+	Text: `var __metadata = (this && this.__metadata) || function (k, v) {
+    if (typeof Reflect === "object" && typeof Reflect.metadata === "function") return Reflect.metadata(k, v);
+};`,
+}
+
+var paramHelper = &EmitHelper{
+	Name:       "typescript:param",
+	ImportName: "__param",
+	Scoped:     false,
+	Priority:   &Priority{4},
+	Text: `var __param = (this && this.__param) || function (paramIndex, decorator) {
+    return function (target, key) { decorator(target, key, paramIndex); }
+};`,
+}
+
+// ES Decorators Helpers
+
+var esDecorateHelper = &EmitHelper{
+	Name:       "typescript:esDecorate",
+	ImportName: "__esDecorate",
+	Scoped:     false,
+	Priority:   &Priority{2},
+	Text: `var __esDecorate = (this && this.__esDecorate) || function (ctor, descriptorIn, decorators, contextIn, initializers, extraInitializers) {
+    function accept(f) { if (f !== void 0 && typeof f !== "function") throw new TypeError("Function expected"); return f; }
+    var kind = contextIn.kind, key = kind === "getter" ? "get" : kind === "setter" ? "set" : "value";
+    var target = !descriptorIn && ctor ? contextIn["static"] ? ctor : ctor.prototype : null;
+    var descriptor = descriptorIn || (target ? Object.getOwnPropertyDescriptor(target, contextIn.name) : {});
+    var _, done = false;
+    for (var i = decorators.length - 1; i >= 0; i--) {
+        var context = {};
+        for (var p in contextIn) context[p] = p === "access" ? {} : contextIn[p];
+        for (var p in contextIn.access) context.access[p] = contextIn.access[p];
+        context.addInitializer = function (f) { if (done) throw new TypeError("Cannot add initializers after decoration has completed"); extraInitializers.push(accept(f || null)); };
+        var result = (0, decorators[i])(kind === "accessor" ? { get: descriptor.get, set: descriptor.set } : descriptor[key], context);
+        if (kind === "accessor") {
+            if (result === void 0) continue;
+            if (result === null || typeof result !== "object") throw new TypeError("Object expected");
+            if (_ = accept(result.get)) descriptor.get = _;
+            if (_ = accept(result.set)) descriptor.set = _;
+            if (_ = accept(result.init)) initializers.unshift(_);
+        }
+        else if (_ = accept(result)) {
+            if (kind === "field") initializers.unshift(_);
+            else descriptor[key] = _;
+        }
+    }
+    if (target) Object.defineProperty(target, contextIn.name, descriptor);
+    done = true;
+};`,
+}
+
+var runInitializersHelper = &EmitHelper{
+	Name:       "typescript:runInitializers",
+	ImportName: "__runInitializers",
+	Scoped:     false,
+	Priority:   &Priority{2},
+	Text: `var __runInitializers = (this && this.__runInitializers) || function (thisArg, initializers, value) {
+    var useValue = arguments.length > 2;
+    for (var i = 0; i < initializers.length; i++) {
+        value = useValue ? initializers[i].call(thisArg, value) : initializers[i].call(thisArg);
+    }
+    return useValue ? value : void 0;
+};`,
+}
+
+// Class Fields Helpers
+
+var classPrivateFieldGetHelper = &EmitHelper{
+	Name:       "typescript:classPrivateFieldGet",
+	ImportName: "__classPrivateFieldGet",
+	Scoped:     false,
+	Text: `var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};`,
+}
+
+var classPrivateFieldSetHelper = &EmitHelper{
+	Name:       "typescript:classPrivateFieldSet",
+	ImportName: "__classPrivateFieldSet",
+	Scoped:     false,
+	Text: `var __classPrivateFieldSet = (this && this.__classPrivateFieldSet) || function (receiver, state, value, kind, f) {
+    if (kind === "m") throw new TypeError("Private method is not writable");
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a setter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot write private member to an object whose class did not declare it");
+    return (kind === "a" ? f.call(receiver, value) : f ? f.value = value : state.set(receiver, value)), value;
+};`,
+}
+
+var classPrivateFieldInHelper = &EmitHelper{
+	Name:       "typescript:classPrivateFieldIn",
+	ImportName: "__classPrivateFieldIn",
+	Scoped:     false,
+	Text: `var __classPrivateFieldIn = (this && this.__classPrivateFieldIn) || function(state, receiver) {
+    if (receiver === null || (typeof receiver !== "object" && typeof receiver !== "function")) throw new TypeError("Cannot use 'in' operator on non-object");
+    return typeof state === "function" ? receiver === state : state.has(receiver);
+};`,
+}
+
 // !!! ES2018 Helpers
 var assignHelper = &EmitHelper{
 	Name:       "typescript:assign",
@@ -136,7 +255,97 @@ var restHelper = &EmitHelper{
 };`,
 }
 
-// !!! ES2017 Helpers
+// ES2018 Async Iteration Helpers
+
+var awaitHelper = &EmitHelper{
+	Name:       "typescript:await",
+	ImportName: "__await",
+	Scoped:     false,
+	Text:       `var __await = (this && this.__await) || function (v) { return this instanceof __await ? (this.v = v, this) : new __await(v); }`,
+}
+
+var asyncGeneratorHelper = &EmitHelper{
+	Name:         "typescript:asyncGenerator",
+	ImportName:   "__asyncGenerator",
+	Scoped:       false,
+	Dependencies: []*EmitHelper{awaitHelper},
+	Text: `var __asyncGenerator = (this && this.__asyncGenerator) || function (thisArg, _arguments, generator) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var g = generator.apply(thisArg, _arguments || []), i, q = [];
+    return i = Object.create((typeof AsyncIterator === "function" ? AsyncIterator : Object).prototype), verb("next"), verb("throw"), verb("return", awaitReturn), i[Symbol.asyncIterator] = function () { return this; }, i;
+    function awaitReturn(f) { return function (v) { return Promise.resolve(v).then(f, reject); }; }
+    function verb(n, f) { if (g[n]) { i[n] = function (v) { return new Promise(function (a, b) { q.push([n, v, a, b]) > 1 || resume(n, v); }); }; if (f) i[n] = f(i[n]); } }
+    function resume(n, v) { try { step(g[n](v)); } catch (e) { settle(q[0][3], e); } }
+    function step(r) { r.value instanceof __await ? Promise.resolve(r.value.v).then(fulfill, reject) : settle(q[0][2], r); }
+    function fulfill(value) { resume("next", value); }
+    function reject(value) { resume("throw", value); }
+    function settle(f, v) { if (f(v), q.shift(), q.length) resume(q[0][0], q[0][1]); }
+};`,
+}
+
+var asyncDelegatorHelper = &EmitHelper{
+	Name:         "typescript:asyncDelegator",
+	ImportName:   "__asyncDelegator",
+	Scoped:       false,
+	Dependencies: []*EmitHelper{awaitHelper},
+	Text: `var __asyncDelegator = (this && this.__asyncDelegator) || function (o) {
+    var i, p;
+    return i = {}, verb("next"), verb("throw", function (e) { throw e; }), verb("return"), i[Symbol.iterator] = function () { return this; }, i;
+    function verb(n, f) { i[n] = o[n] ? function (v) { return (p = !p) ? { value: __await(o[n](v)), done: false } : f ? f(v) : v; } : f; }
+};`,
+}
+
+var asyncValuesHelper = &EmitHelper{
+	Name:       "typescript:asyncValues",
+	ImportName: "__asyncValues",
+	Scoped:     false,
+	Text: `var __asyncValues = (this && this.__asyncValues) || function (o) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var m = o[Symbol.asyncIterator], i;
+    return m ? m.call(o) : (o = typeof __values === "function" ? __values(o) : o[Symbol.iterator](), i = {}, verb("next"), verb("throw"), verb("return"), i[Symbol.asyncIterator] = function () { return this; }, i);
+    function verb(n) { i[n] = o[n] && function (v) { return new Promise(function (resolve, reject) { v = o[n](v), settle(resolve, reject, v.done, v.value); }); }; }
+    function settle(resolve, reject, d, v) { Promise.resolve(v).then(function(v) { resolve({ value: v, done: d }); }, reject); }
+};`,
+}
+
+// ES2017 Helpers
+
+var awaiterHelper = &EmitHelper{
+	Name:       "typescript:awaiter",
+	ImportName: "__awaiter",
+	Scoped:     false,
+	Priority:   &Priority{5},
+	Text: `var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};`,
+}
+
+// Forwards `super[name]` reads from an async method body that was moved into a generator.
+var asyncSuperHelper = &EmitHelper{
+	Name:   "typescript:async-super",
+	Scoped: true,
+	TextCallback: func(makeUniqueName func(string) string) string {
+		return "const " + makeUniqueName("_superIndex") + " = name => super[name];"
+	},
+}
+
+// Forwards `super[name]` reads and writes from an async method body that was moved into a generator.
+var advancedAsyncSuperHelper = &EmitHelper{
+	Name:   "typescript:advanced-async-super",
+	Scoped: true,
+	TextCallback: func(makeUniqueName func(string) string) string {
+		return "const " + makeUniqueName("_superIndex") + ` = (function (geti, seti) {
+    const cache = Object.create(null);
+    return name => cache[name] || (cache[name] = { get value() { return geti(name); }, set value(v) { seti(name, v); } });
+})(name => super[name], (name, value) => super[name] = value);`
+	},
+}
 
 // ES2015 Helpers
 
@@ -160,6 +369,103 @@ var setFunctionNameHelper = &EmitHelper{
 };`,
 }
 
+var spreadArrayHelper = &EmitHelper{
+	Name:       "typescript:spreadArray",
+	ImportName: "__spreadArray",
+	Scoped:     false,
+	Text: `var __spreadArray = (this && this.__spreadArray) || function (to, from, pack) {
+    if (pack || arguments.length === 2) for (var i = 0, l = from.length, ar; i < l; i++) {
+        if (ar || !(i in from)) {
+            if (!ar) ar = Array.prototype.slice.call(from, 0, i);
+            ar[i] = from[i];
+        }
+    }
+    return to.concat(ar || Array.prototype.slice.call(from));
+};`,
+}
+
+// ES2015 Destructuring Helpers
+
+var valuesHelper = &EmitHelper{
+	Name:       "typescript:values",
+	ImportName: "__values",
+	Scoped:     false,
+	Text: `var __values = (this && this.__values) || function(o) {
+    var s = typeof Symbol === "function" && Symbol.iterator, m = s && o[s], i = 0;
+    if (m) return m.call(o);
+    if (o && typeof o.length === "number") return {
+        next: function () {
+            if (o && i >= o.length) o = void 0;
+            return { value: o && o[i++], done: !o };
+        }
+    };
+    throw new TypeError(s ? "Object is not iterable." : "Symbol.iterator is not defined.");
+};`,
+}
+
+var readHelper = &EmitHelper{
+	Name:       "typescript:read",
+	ImportName: "__read",
+	Scoped:     false,
+	Text: `var __read = (this && this.__read) || function (o, n) {
+    var m = typeof Symbol === "function" && o[Symbol.iterator];
+    if (!m) return o;
+    var i = m.call(o), r, ar = [], e;
+    try {
+        while ((n === void 0 || n-- > 0) && !(r = i.next()).done) ar.push(r.value);
+    }
+    catch (error) { e = { error: error }; }
+    finally {
+        try {
+            if (r && !r.done && (m = i["return"])) m.call(i);
+        }
+        finally { if (e) throw e.error; }
+    }
+    return ar;
+};`,
+}
+
+// ES2015 Generator Helpers
+
+// Emulates an ES2015 generator by stepping a state machine produced by the generator transform. The body
+// function returns instructions of the form `[opcode, value]`:
+//
+//	0: next(value?)   1: throw(error)    2: return(value?)   3: break(label)
+//	4: yield(value?)  5: yield*(value)   6: catch(error)     7: endfinally
+var generatorHelper = &EmitHelper{
+	Name:       "typescript:generator",
+	ImportName: "__generator",
+	Scoped:     false,
+	Priority:   &Priority{6},
+	Text: `var __generator = (this && this.__generator) || function (thisArg, body) {
+    var _ = { label: 0, sent: function() { if (t[0] & 1) throw t[1]; return t[1]; }, trys: [], ops: [] }, f, y, t, g = Object.create((typeof Iterator === "function" ? Iterator : Object).prototype);
+    return g.next = verb(0), g["throw"] = verb(1), g["return"] = verb(2), typeof Symbol === "function" && (g[Symbol.iterator] = function() { return this; }), g;
+    function verb(n) { return function (v) { return step([n, v]); }; }
+    function step(op) {
+        if (f) throw new TypeError("Generator is already executing.");
+        while (g && (g = 0, op[0] && (_ = 0)), _) try {
+            if (f = 1, y && (t = op[0] & 2 ? y["return"] : op[0] ? y["throw"] || ((t = y["return"]) && t.call(y), 0) : y.next) && !(t = t.call(y, op[1])).done) return t;
+            if (y = 0, t) op = [op[0] & 2, t.value];
+            switch (op[0]) {
+                case 0: case 1: t = op; break;
+                case 4: _.label++; return { value: op[1], done: false };
+                case 5: _.label++; y = op[1]; op = [0]; continue;
+                case 7: op = _.ops.pop(); _.trys.pop(); continue;
+                default:
+                    if (!(t = _.trys, t = t.length > 0 && t[t.length - 1]) && (op[0] === 6 || op[0] === 2)) { _ = 0; continue; }
+                    if (op[0] === 3 && (!t || (op[1] > t[0] && op[1] < t[3]))) { _.label = op[1]; break; }
+                    if (op[0] === 6 && _.label < t[1]) { _.label = t[1]; t = op; break; }
+                    if (t && _.label < t[2]) { _.label = t[2]; _.ops.push(op); break; }
+                    if (t[2]) _.ops.pop();
+                    _.trys.pop(); continue;
+            }
+            op = body.call(thisArg, _);
+        } catch (e) { op = [6, e]; y = 0; } finally { f = t = 0; }
+        if (op[0] & 5) throw op[1]; return { value: op[0] ? op[1] : void 0, done: true };
+    }
+};`,
+}
+
 // ES Module Helpers
 
 var createBindingHelper = &EmitHelper{
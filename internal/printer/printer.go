@@ -153,9 +153,10 @@ type commentState struct {
 }
 
 type sourceMapState struct {
-	emitFlags              EmitFlags      // holds the emit flags for the current node
-	sourceMapRange         core.TextRange // holds the source map range calculated for the current node
-	hasTokenSourceMapRange bool           // captures whether the source map range was set for the current node
+	emitFlags              EmitFlags        // holds the emit flags for the current node
+	sourceMapRange         core.TextRange   // holds the source map range calculated for the current node
+	sourceMapSource        sourcemap.Source // holds the source to which the source map range refers
+	hasTokenSourceMapRange bool             // captures whether the source map range was set for the current node
 }
 
 type printerState struct {
@@ -176,6 +177,9 @@ func NewPrinter(options PrinterOptions, handlers PrintHandlers, emitContext *Emi
 	printer.nameGenerator.Context = printer.emitContext
 	printer.nameGenerator.GetTextOfNode = func(node *ast.Node) string { return printer.getTextOfNode(node, false) }
 	printer.nameGenerator.IsFileLevelUniqueNameInCurrentFile = printer.isFileLevelUniqueNameInCurrentFile
+	printer.makeFileLevelOptimisticUniqueName = func(name string) string {
+		return printer.nameGenerator.makeUniqueName(name, printer.isFileLevelUniqueNameInCurrentFile, true /*optimistic*/, false /*scoped*/, false /*privateName*/, "" /*prefix*/, "" /*suffix*/)
+	}
 	printer.containerPos = -1
 	printer.containerEnd = -1
 	printer.declarationListContainerEnd = -1
@@ -832,8 +836,19 @@ func (p *Printer) shouldEmitDetachedComments(node *ast.Node) bool {
 		ast.NodeIsSynthesized(file.Statements.Nodes[0])
 }
 
+// Determines whether there are any comments immediately following the token at `pos`.
 func (p *Printer) hasCommentsAtPosition(pos int) bool {
-	// !!!
+	if p.currentSourceFile == nil {
+		return false
+	}
+	text := p.currentSourceFile.Text()
+	factory := p.emitContext.Factory.AsNodeFactory()
+	for range scanner.GetTrailingCommentRanges(factory, text, pos+1) {
+		return true
+	}
+	for range scanner.GetLeadingCommentRanges(factory, text, pos+1) {
+		return true
+	}
 	return false
 }
 
@@ -890,8 +905,6 @@ func (p *Printer) shouldAllowTrailingComma(node *ast.Node, list *ast.NodeList) b
 //
 
 func (p *Printer) writeTokenText(token ast.Kind, writeKind WriteKind, pos int) int {
-	// !!! emit leading and trailing comments
-	// !!! emit leading and trailing source maps
 	tokenString := scanner.TokenToString(token)
 	p.writeAs(tokenString, writeKind)
 	if ast.PositionIsSynthesized(pos) {
@@ -1561,7 +1574,12 @@ func (p *Printer) emitFunctionBody(body *ast.Block) {
 		p.emitList((*Printer).emitStatement, body.AsNode(), body.Statements, LFSingleLineFunctionBodyStatements)
 		p.increaseIndent()
 	} else {
-		p.emitList((*Printer).emitStatement, body.AsNode(), body.Statements, LFMultiLineFunctionBodyStatements)
+		format := LFMultiLineFunctionBodyStatements
+		if p.shouldEmitOnMultipleLines(body.AsNode()) {
+			format |= LFPreferNewLine
+		}
+		// prologue directives have already been emitted
+		p.emitListRange((*Printer).emitStatement, body.AsNode(), body.Statements, format, statementOffset, -1 /*count*/)
 	}
 
 	p.emitDetachedCommentsAfterStatementList(body.AsNode(), body.Statements.Loc, detachedState)
@@ -2435,7 +2453,12 @@ func (p *Printer) emitPropertyAccessExpression(node *ast.PropertyAccessExpressio
 	token := node.QuestionDotToken
 	if token == nil {
 		token = p.emitContext.Factory.NewToken(ast.KindDotToken)
-		token.Loc = core.NewTextRange(node.Expression.End(), node.Name().Pos())
+		namePos := node.Name().Pos()
+		if p.emitContext.EmitFlags(node.Name())&EFNoLeadingComments != 0 {
+			// the name's leading comments were suppressed, so the dot must not pick them up as trailing comments
+			namePos = -1
+		}
+		token.Loc = core.NewTextRange(node.Expression.End(), namePos)
 		p.emitContext.AddEmitFlags(token, EFNoSourceMap)
 	}
 	linesBeforeDot := p.getLinesBetweenNodes(node.AsNode(), node.Expression, token)
@@ -2946,12 +2969,56 @@ func (p *Printer) emitPartiallyEmittedExpression(node *ast.PartiallyEmittedExpre
 	}
 }
 
+func commentWillEmitNewLine(kind ast.Kind, hasTrailingNewLine bool) bool {
+	return kind == ast.KindSingleLineCommentTrivia || hasTrailingNewLine
+}
+
+// Determines whether emitting the leading comments of `node` would introduce a line break before the node's text.
 func (p *Printer) willEmitLeadingNewLine(node *ast.Expression) bool {
-	return false // !!! check if node will emit a leading comment that contains a trailing newline
+	if p.currentSourceFile == nil {
+		return false
+	}
+
+	text := p.currentSourceFile.Text()
+	factory := p.emitContext.Factory.AsNodeFactory()
+	hasLeadingComments := false
+	for comment := range scanner.GetLeadingCommentRanges(factory, text, node.Pos()) {
+		hasLeadingComments = true
+		if commentWillEmitNewLine(comment.Kind, comment.HasTrailingNewLine) {
+			return true
+		}
+	}
+
+	if hasLeadingComments {
+		// A comment inside of parentheses in the original source always begins on a new line after the `(`.
+		if parseNode := p.emitContext.ParseNode(node); parseNode != nil && parseNode.Parent != nil && ast.IsParenthesizedExpression(parseNode.Parent) {
+			return true
+		}
+	}
+
+	for _, comment := range p.emitContext.GetSyntheticLeadingComments(node) {
+		if commentWillEmitNewLine(comment.Kind, comment.HasTrailingNewLine) {
+			return true
+		}
+	}
+
+	if ast.IsPartiallyEmittedExpression(node) {
+		expression := node.AsPartiallyEmittedExpression().Expression
+		if node.Pos() != expression.Pos() {
+			for comment := range scanner.GetTrailingCommentRanges(factory, text, expression.Pos()) {
+				if commentWillEmitNewLine(comment.Kind, comment.HasTrailingNewLine) {
+					return true
+				}
+			}
+		}
+		return p.willEmitLeadingNewLine(expression)
+	}
+
+	return false
 }
 
 func (p *Printer) emitExpressionNoASI(node *ast.Expression, precedence ast.OperatorPrecedence) {
-	// !!! restore parens when necessary to ensure a leading single-line comment doesn't introduce ASI:
+	// Restore parens when necessary to ensure a leading single-line comment doesn't introduce ASI:
 	//	function f() {
 	//	  return (// comment
 	//	    a as T
@@ -2963,12 +3030,18 @@ func (p *Printer) emitExpressionNoASI(node *ast.Expression, precedence ast.Opera
 	//	    a;
 	//	}
 	// Due to ASI, this would result in a `return` with no value followed by an unreachable expression statement.
-	if !p.commentsDisabled && node.Kind == ast.KindPartiallyEmittedExpression && p.willEmitLeadingNewLine(node) {
-		// !!! if there is an original parse tree node, restore it with location to preserve comments and source maps.
-		p.emitExpression(node, ast.OperatorPrecedenceParentheses)
-	} else {
-		p.emitExpression(node, precedence)
+	if !p.commentsDisabled && ast.IsPartiallyEmittedExpression(node) && p.willEmitLeadingNewLine(node) {
+		if parseNode := p.emitContext.ParseNode(node); parseNode != nil && ast.IsParenthesizedExpression(parseNode) {
+			// If the original node was a parenthesized expression, restore it to preserve comments and source maps
+			parens := p.emitContext.Factory.NewParenthesizedExpression(node.AsPartiallyEmittedExpression().Expression)
+			p.emitContext.SetOriginal(parens, node)
+			parens.Loc = parseNode.Loc
+			node = parens
+		} else {
+			node = p.emitContext.Factory.NewParenthesizedExpression(node)
+		}
 	}
+	p.emitExpression(node, precedence)
 }
 
 func (p *Printer) emitExpression(node *ast.Expression, precedence ast.OperatorPrecedence) {
@@ -3803,7 +3876,8 @@ func (p *Printer) emitImportAttribute(node *ast.ImportAttribute) {
 	p.emitImportAttributeName(node.Name())
 	p.writePunctuation(":")
 	p.writeSpace()
-	/// !!! emit trailing comments of value
+	// see comment in emitPropertyAssignment
+	p.emitTrailingCommentsOfLeadingPosition(node.Value)
 	p.emitExpression(node.Value, ast.OperatorPrecedenceDisallowComma)
 	p.exitNode(node.AsNode(), state)
 }
@@ -4102,7 +4176,9 @@ func (p *Printer) emitJsxExpression(node *ast.JsxExpression) {
 		p.increaseIndentIf(indented)
 		end := p.emitToken(ast.KindOpenBraceToken, node.Pos(), WriteKindPunctuation, node.AsNode())
 		p.emitTokenNode(node.DotDotDotToken)
-		p.emitExpression(node.Expression, ast.OperatorPrecedenceDisallowComma)
+		if node.Expression != nil {
+			p.emitExpression(node.Expression, ast.OperatorPrecedenceDisallowComma)
+		}
 		p.emitToken(ast.KindCloseBraceToken, greatestEnd(end, node.Expression, node.DotDotDotToken), WriteKindPunctuation, node.AsNode())
 		p.decreaseIndentIf(indented)
 	}
@@ -4273,9 +4349,7 @@ func (p *Printer) emitPropertyAssignment(node *ast.PropertyAssignment) {
 	// "comment1" is not considered to be leading comment for node.initializer
 	// but rather a trailing comment on the previous node.
 	initializer := node.Initializer
-
-	// !!! emit trailing comments of initializer
-
+	p.emitTrailingCommentsOfLeadingPosition(initializer)
 	p.emitExpression(initializer, ast.OperatorPrecedenceDisallowComma)
 	p.exitNode(node.AsNode(), state)
 }
@@ -4404,7 +4478,6 @@ func (p *Printer) emitSourceFile(node *ast.SourceFile) {
 		state = p.emitDetachedCommentsBeforeStatementList(node.AsNode(), node.Statements.Loc)
 	}
 
-	// !!! Emit triple-slash directives
 	p.emitListRange(
 		(*Printer).emitStatement,
 		node.AsNode(),
@@ -5311,6 +5384,19 @@ func (p *Printer) emitTrailingComments(pos int, commentSeparator commentSeparato
 	p.emitComments(comments, commentSeparator)
 }
 
+// Emits any comments that trail the token preceding `node` (i.e., comments on the same line as the start of the
+// node's comment range), as these are not considered leading comments of `node`.
+func (p *Printer) emitTrailingCommentsOfLeadingPosition(node *ast.Node) {
+	if p.commentsDisabled || p.emitContext.EmitFlags(node)&EFNoLeadingComments != 0 {
+		return
+	}
+	pos := p.emitContext.CommentRange(node).Pos()
+	if ast.PositionIsSynthesized(pos) {
+		return
+	}
+	p.emitTrailingComments(pos, commentSeparatorAfter)
+}
+
 func (p *Printer) emitDetachedCommentsAndUpdateCommentsInfo(textRange core.TextRange) {
 	if p.currentSourceFile == nil {
 		return
@@ -5538,6 +5624,18 @@ func (p *Printer) emitSourcePos(source sourcemap.Source, pos int) {
 ////	}
 ////}
 
+func (p *Printer) skipSourceTrivia(source sourcemap.Source, pos int) int {
+	return scanner.SkipTrivia(source.Text(), pos)
+}
+
+// Gets the source to which the source map range of a node refers.
+func (p *Printer) getSourceMapSource(node *ast.Node) sourcemap.Source {
+	if source := p.emitContext.SourceMapSource(node); source != nil {
+		return source
+	}
+	return p.sourceMapSource
+}
+
 func (p *Printer) emitSourceMapsBeforeNode(node *ast.Node) *sourceMapState {
 	if !p.shouldEmitSourceMaps(node) {
 		return nil
@@ -5545,11 +5643,12 @@ func (p *Printer) emitSourceMapsBeforeNode(node *ast.Node) *sourceMapState {
 
 	emitFlags := p.emitContext.EmitFlags(node)
 	loc := p.emitContext.SourceMapRange(node)
+	source := p.getSourceMapSource(node)
 
 	if !ast.IsNotEmittedStatement(node) &&
 		emitFlags&EFNoLeadingSourceMap == 0 &&
 		!ast.PositionIsSynthesized(loc.Pos()) {
-		p.emitSourcePos(p.sourceMapSource, scanner.SkipTrivia(p.currentSourceFile.Text(), loc.Pos())) // !!! support SourceMapRange from Strada?
+		p.emitSourcePos(source, p.skipSourceTrivia(source, loc.Pos()))
 	}
 
 	if emitFlags&EFNoNestedSourceMaps != 0 {
@@ -5557,7 +5656,7 @@ func (p *Printer) emitSourceMapsBeforeNode(node *ast.Node) *sourceMapState {
 	}
 
 	state := p.sourceMapStatePool.New()
-	*state = sourceMapState{emitFlags, loc, false}
+	*state = sourceMapState{emitFlags, loc, source, false}
 	return state
 }
 
@@ -5576,29 +5675,37 @@ func (p *Printer) emitSourceMapsAfterNode(node *ast.Node, previousState *sourceM
 	if !ast.IsNotEmittedStatement(node) &&
 		emitFlags&EFNoTrailingSourceMap == 0 &&
 		!ast.PositionIsSynthesized(loc.End()) {
-		p.emitSourcePos(p.sourceMapSource, loc.End()) // !!! support SourceMapRange from Strada?
+		p.emitSourcePos(previousState.sourceMapSource, loc.End())
 	}
 }
 
-func (p *Printer) emitSourceMapsBeforeToken(token ast.Kind, pos int, contextNode *ast.Node, flags tokenEmitFlags) *sourceMapState {
+// Emits the source map position for the start of a token. As with nodes, leading trivia is skipped so that the mapping
+// points at the token itself. Returns the (possibly adjusted) position of the token.
+func (p *Printer) emitSourceMapsBeforeToken(token ast.Kind, pos int, contextNode *ast.Node, flags tokenEmitFlags) (*sourceMapState, int) {
 	if !p.shouldEmitTokenSourceMaps(token, pos, contextNode, flags) {
-		return nil
+		return nil, pos
 	}
 
 	emitFlags := p.emitContext.EmitFlags(contextNode)
 	loc, hasLoc := p.emitContext.TokenSourceMapRange(contextNode, token)
-	if emitFlags&EFNoTokenLeadingSourceMaps == 0 {
-		if hasLoc {
-			pos = loc.Pos()
+	source := p.sourceMapSource
+	tokenPos := pos
+	if hasLoc {
+		tokenPos = loc.Pos()
+	}
+	if tokenPos >= 0 {
+		tokenPos = p.skipSourceTrivia(source, tokenPos)
+		if !hasLoc {
+			pos = tokenPos
 		}
-		if pos >= 0 {
-			p.emitSourcePos(p.sourceMapSource, pos) // !!! support SourceMapRange from Strada?
+		if emitFlags&EFNoTokenLeadingSourceMaps == 0 {
+			p.emitSourcePos(source, tokenPos)
 		}
 	}
 
 	state := p.sourceMapStatePool.New()
-	*state = sourceMapState{emitFlags, loc, hasLoc}
-	return state
+	*state = sourceMapState{emitFlags, loc, source, hasLoc}
+	return state, pos
 }
 
 func (p *Printer) emitSourceMapsAfterToken(token ast.Kind, pos int, contextNode *ast.Node, previousState *sourceMapState) {
@@ -5614,7 +5721,7 @@ func (p *Printer) emitSourceMapsAfterToken(token ast.Kind, pos int, contextNode
 			pos = loc.End()
 		}
 		if pos >= 0 {
-			p.emitSourcePos(p.sourceMapSource, pos) // !!! support SourceMapRange from Strada?
+			p.emitSourcePos(previousState.sourceMapSource, pos)
 		}
 	}
 }
@@ -5828,7 +5935,7 @@ const (
 func (p *Printer) enterToken(token ast.Kind, pos int, contextNode *ast.Node, flags tokenEmitFlags) (printerState, int) {
 	state := printerState{}
 	state.commentState, pos = p.emitCommentsBeforeToken(token, pos, contextNode, flags)
-	state.sourceMapState = p.emitSourceMapsBeforeToken(token, pos, contextNode, flags)
+	state.sourceMapState, pos = p.emitSourceMapsBeforeToken(token, pos, contextNode, flags)
 	return state, pos
 }
 
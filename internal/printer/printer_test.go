@@ -2517,3 +2517,22 @@ func TestPartiallyEmittedExpression(t *testing.T) {
     .expression
     .expression;`)
 }
+
+func TestPartiallyEmittedExpressionWithLeadingComment(t *testing.T) {
+	t.Parallel()
+
+	compilerOptions := &core.CompilerOptions{}
+
+	file := parsetestutil.ParseTypeScript(`function f() {
+    return (// comment
+        a as number
+    );
+}`, false /*jsx*/)
+
+	emitContext := printer.NewEmitContext()
+	file = tstransforms.NewTypeEraserTransformer(&transformers.TransformOptions{CompilerOptions: compilerOptions, Context: emitContext}).TransformSourceFile(file)
+	emittestutil.CheckEmit(t, emitContext, file.AsSourceFile(), `function f() {
+    return ( // comment
+    a);
+}`)
+}
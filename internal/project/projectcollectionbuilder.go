@@ -287,6 +287,19 @@ func logChangeFileResult(result changeFileResult, logger *logging.LogTree) {
 	}
 }
 
+// DidRequestAllProjects ensures the programs of all existing projects are up to date.
+func (b *projectCollectionBuilder) DidRequestAllProjects(logger *logging.LogTree) {
+	startTime := time.Now()
+	b.configuredProjects.Range(func(entry *dirty.SyncMapEntry[tspath.Path, *Project]) bool {
+		b.updateProgram(entry, logger)
+		return true
+	})
+	if b.inferredProject.Value() != nil {
+		b.updateProgram(b.inferredProject, logger)
+	}
+	logger.Logf("Updated all projects in %v", time.Since(startTime))
+}
+
 func (b *projectCollectionBuilder) DidRequestFile(uri lsproto.DocumentUri, logger *logging.LogTree) {
 	startTime := time.Now()
 	fileName := uri.FileName()
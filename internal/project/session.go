@@ -31,6 +31,7 @@ const (
 	UpdateReasonRequestedLanguageServicePendingChanges
 	UpdateReasonRequestedLanguageServiceProjectNotLoaded
 	UpdateReasonRequestedLanguageServiceProjectDirty
+	UpdateReasonRequestedWorkspace
 )
 
 // SessionOptions are the immutable initialization options for a session.
@@ -370,6 +371,26 @@ func (s *Session) Snapshot() (*Snapshot, func()) {
 	}
 }
 
+// GetWorkspaceSnapshot returns a snapshot in which the programs of all projects are up to date,
+// for requests that span the whole workspace rather than a single document.
+func (s *Session) GetWorkspaceSnapshot(ctx context.Context) (*Snapshot, func()) {
+	fileChanges, overlays, ataChanges, newConfig := s.flushChanges(ctx)
+	snapshot, release := s.Snapshot()
+	if fileChanges.IsEmpty() && len(ataChanges) == 0 && newConfig == nil &&
+		!core.Some(snapshot.ProjectCollection.Projects(), func(p *Project) bool { return p.dirty }) {
+		return snapshot, release
+	}
+	release()
+	s.UpdateSnapshot(ctx, overlays, SnapshotChange{
+		reason:            UpdateReasonRequestedWorkspace,
+		fileChanges:       fileChanges,
+		ataChanges:        ataChanges,
+		newConfig:         newConfig,
+		updateAllProjects: true,
+	})
+	return s.Snapshot()
+}
+
 func (s *Session) GetLanguageService(ctx context.Context, uri lsproto.DocumentUri) (*ls.LanguageService, error) {
 	var snapshot *Snapshot
 	fileChanges, overlays, ataChanges, newConfig := s.flushChanges(ctx)
@@ -134,6 +134,8 @@ type SnapshotChange struct {
 	// requestedURIs are URIs that were requested by the client.
 	// The new snapshot should ensure projects for these URIs have loaded programs.
 	requestedURIs []lsproto.DocumentUri
+	// updateAllProjects indicates that the new snapshot should have up to date programs for all projects.
+	updateAllProjects bool
 	// compilerOptionsForInferredProjects is the compiler options to use for inferred projects.
 	// It should only be set the value in the next snapshot should be changed. If nil, the
 	// value from the previous snapshot will be copied to the new snapshot.
@@ -189,6 +191,8 @@ func (s *Snapshot) Clone(ctx context.Context, change SnapshotChange, overlays ma
 			logger.Logf("Reason: RequestedLanguageService (project not loaded) - %v", change.requestedURIs)
 		case UpdateReasonRequestedLanguageServiceProjectDirty:
 			logger.Logf("Reason: RequestedLanguageService (project dirty) - %v", change.requestedURIs)
+		case UpdateReasonRequestedWorkspace:
+			logger.Logf("Reason: RequestedWorkspace")
 		}
 	}
 
@@ -247,6 +251,10 @@ func (s *Snapshot) Clone(ctx context.Context, change SnapshotChange, overlays ma
 		projectCollectionBuilder.DidRequestFile(uri, logger.Fork("DidRequestFile"))
 	}
 
+	if change.updateAllProjects {
+		projectCollectionBuilder.DidRequestAllProjects(logger.Fork("DidRequestAllProjects"))
+	}
+
 	projectCollection, configFileRegistry := projectCollectionBuilder.Finalize(logger)
 
 	// Clean cached disk files not touched by any open project. It's not important that we do this on
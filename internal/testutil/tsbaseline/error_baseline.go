@@ -91,7 +91,7 @@ func iterateErrorBaseline(t *testing.T, inputFiles []*harnessutil.TestFile, inpu
 	var result []string
 
 	outputErrorText := func(diag *ast.Diagnostic) {
-		message := diagnosticwriter.FlattenDiagnosticMessage(diag, harnessNewLine)
+		message := diagnosticwriter.FlattenDiagnosticMessage(diag, harnessNewLine, formatOpts.Locale)
 
 		var errLines []string
 		for _, line := range strings.Split(removeTestPathPrefixes(message, false), "\n") {
@@ -112,7 +112,7 @@ func iterateErrorBaseline(t *testing.T, inputFiles []*harnessutil.TestFile, inpu
 			if len(location) > 0 && isDefaultLibraryFile(info.File().FileName()) {
 				location = diagnosticsLocationPattern.ReplaceAllString(location, "$1:--:--")
 			}
-			errLines = append(errLines, fmt.Sprintf("!!! related TS%d%s: %s", info.Code(), location, diagnosticwriter.FlattenDiagnosticMessage(info, harnessNewLine)))
+			errLines = append(errLines, fmt.Sprintf("!!! related TS%d%s: %s", info.Code(), location, diagnosticwriter.FlattenDiagnosticMessage(info, harnessNewLine, formatOpts.Locale)))
 		}
 
 		for _, e := range errLines {
@@ -0,0 +1,404 @@
+// Package tracing implements `--generateTrace`: it records what the compiler spends its time on
+// as Chrome trace events (viewable in about://tracing or https://ui.perfetto.dev) and dumps the
+// types created by the checkers, so that slow projects can be diagnosed.
+package tracing
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
+)
+
+type Phase string
+
+const (
+	PhaseParse      Phase = "parse"
+	PhaseProgram    Phase = "program"
+	PhaseBind       Phase = "bind"
+	PhaseCheck      Phase = "check"      // Before we get into checking types (e.g. checkSourceFile)
+	PhaseCheckTypes Phase = "checkTypes" // Type relations, instantiation, etc.
+	PhaseEmit       Phase = "emit"
+)
+
+// Args are the arguments attached to a trace event. They are written with sorted keys.
+type Args map[string]any
+
+// Events that are not separated into begin and end events are only written if they span a
+// sampling boundary, which keeps the trace small while still showing anything that is slow.
+const sampleInterval = 10 * time.Millisecond
+
+const (
+	traceFileName  = "trace.json"
+	typesFileName  = "types.json"
+	legendFileName = "legend.json"
+)
+
+// Tracing collects the trace events of one compilation. It is safe for concurrent use; each
+// goroutine that records events does so through its own Tracer.
+type Tracing struct {
+	fs        vfs.FS
+	traceDir  string
+	tracePath string
+	typesPath string
+	session   *Session
+	now       func() time.Time
+	start     time.Time
+
+	mu          sync.Mutex
+	events      []*traceEvent
+	tracers     []*Tracer
+	idleTracers []*Tracer
+	main        *Tracer
+}
+
+// Start begins tracing a compilation. The trace is written to traceDir by Stop. The now
+// function is used for every timestamp, so that tests can supply a deterministic clock.
+func Start(fs vfs.FS, traceDir string, now func() time.Time) *Tracing {
+	return start(fs, traceDir, tspath.CombinePaths(traceDir, traceFileName), tspath.CombinePaths(traceDir, typesFileName), now)
+}
+
+func start(fs vfs.FS, traceDir string, tracePath string, typesPath string, now func() time.Time) *Tracing {
+	t := &Tracing{
+		fs:        fs,
+		traceDir:  traceDir,
+		tracePath: tracePath,
+		typesPath: typesPath,
+		now:       now,
+	}
+	t.start = now()
+	t.addEvent(&traceEvent{Ph: "M", Cat: "__metadata", Name: "process_name", TID: 1, Args: Args{"name": "tsc"}})
+	t.addEvent(&traceEvent{Ph: "M", Cat: "disabled-by-default-devtools.timeline", Name: "TracingStartedInBrowser", TID: 1, Args: Args{"data": Args{"sessionId": "-1"}}})
+	t.main = t.newTracer("Main")
+	return t
+}
+
+// Main returns the tracer of the goroutine that started tracing.
+func (t *Tracing) Main() *Tracer {
+	if t == nil {
+		return nil
+	}
+	return t.main
+}
+
+// NewTracer returns a tracer with its own thread in the trace, for a long-lived owner such as
+// a checker. The owner must not use the tracer from more than one goroutine at a time.
+func (t *Tracing) NewTracer(name string) *Tracer {
+	if t == nil {
+		return nil
+	}
+	return t.newTracer(name)
+}
+
+// AcquireTracer returns an idle worker tracer for a short task running on its own goroutine,
+// such as parsing or emitting a single file. The tracer must be returned with ReleaseTracer.
+func (t *Tracing) AcquireTracer() *Tracer {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	if n := len(t.idleTracers); n != 0 {
+		tracer := t.idleTracers[n-1]
+		t.idleTracers = t.idleTracers[:n-1]
+		t.mu.Unlock()
+		return tracer
+	}
+	t.mu.Unlock()
+	return t.newTracer("Worker")
+}
+
+// ReleaseTracer returns a tracer obtained from AcquireTracer.
+func (t *Tracing) ReleaseTracer(tracer *Tracer) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.idleTracers = append(t.idleTracers, tracer)
+}
+
+// Stop ends any events that are still open and writes the trace and types files, along with
+// legend.json if the compilation belongs to a Session. A file that cannot be written is reported
+// as a *WriteError, the only error Stop returns. No tracer may be used once Stop has been called.
+func (t *Tracing) Stop() error {
+	if t == nil {
+		return nil
+	}
+	endTime := t.timestamp()
+	t.mu.Lock()
+	tracers := slices.Clone(t.tracers)
+	t.mu.Unlock()
+	for _, tracer := range tracers {
+		for len(tracer.stack) != 0 {
+			tracer.popAt(endTime)
+		}
+	}
+
+	t.mu.Lock()
+	events := t.events
+	t.mu.Unlock()
+	if err := writeJSONArray(t.fs, t.tracePath, events); err != nil {
+		return err
+	}
+
+	var types []*TypeDescriptor
+	for _, tracer := range tracers {
+		if tracer.typeSource == nil {
+			continue
+		}
+		for _, descriptor := range tracer.typeSource() {
+			descriptor.TID = tracer.tid
+			types = append(types, descriptor)
+		}
+	}
+	slices.SortStableFunc(types, func(a, b *TypeDescriptor) int {
+		return cmp.Or(cmp.Compare(a.TID, b.TID), cmp.Compare(a.ID, b.ID))
+	})
+	if err := writeJSONArray(t.fs, t.typesPath, types); err != nil {
+		return err
+	}
+	if t.session != nil {
+		return t.session.writeLegend(t.traceDir)
+	}
+	return nil
+}
+
+// WriteError reports a trace file that could not be written.
+type WriteError struct {
+	FileName string
+	Err      error
+}
+
+func (e *WriteError) Error() string {
+	return e.FileName + ": " + e.Err.Error()
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// writeJSONArray writes values as a JSON array with one element per line, which keeps large
+// traces readable and easy to process line by line.
+func writeJSONArray[T any](fs vfs.FS, fileName string, values []T) error {
+	var b bytes.Buffer
+	b.WriteString("[")
+	for i, value := range values {
+		if i != 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+		if err := json.MarshalWrite(&b, value, json.Deterministic(true)); err != nil {
+			return &WriteError{FileName: fileName, Err: err}
+		}
+	}
+	b.WriteString("\n]\n")
+	if err := fs.WriteFile(fileName, b.String(), false); err != nil {
+		return &WriteError{FileName: fileName, Err: err}
+	}
+	return nil
+}
+
+// Session traces the compilations of a `--build` or `--watch` session, which may compile several
+// projects, or the same project several times. Each compilation is numbered and written to its
+// own trace.<n>.json and types.<n>.json, and legend.json in the trace directory lists the project
+// of each. It is safe for concurrent use.
+type Session struct {
+	fs  vfs.FS
+	now func() time.Time
+
+	mu      sync.Mutex
+	count   int
+	legends map[string][]*legendEntry // by trace directory
+}
+
+type legendEntry struct {
+	ConfigFilePath string `json:"configFilePath"`
+	TracePath      string `json:"tracePath"`
+	TypesPath      string `json:"typesPath"`
+}
+
+// NewSession returns a session that writes traces to fs, timestamped by now.
+func NewSession(fs vfs.FS, now func() time.Time) *Session {
+	return &Session{
+		fs:      fs,
+		now:     now,
+		legends: make(map[string][]*legendEntry),
+	}
+}
+
+// Start begins tracing a compilation of the project with the given config file.
+func (s *Session) Start(traceDir string, configFilePath string) *Tracing {
+	s.mu.Lock()
+	s.count++
+	suffix := "." + strconv.Itoa(s.count) + ".json"
+	entry := &legendEntry{
+		ConfigFilePath: configFilePath,
+		TracePath:      tspath.CombinePaths(traceDir, "trace"+suffix),
+		TypesPath:      tspath.CombinePaths(traceDir, "types"+suffix),
+	}
+	s.legends[traceDir] = append(s.legends[traceDir], entry)
+	s.mu.Unlock()
+
+	t := start(s.fs, traceDir, entry.TracePath, entry.TypesPath, s.now)
+	t.session = s
+	return t
+}
+
+func (s *Session) writeLegend(traceDir string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeJSONArray(s.fs, tspath.CombinePaths(traceDir, legendFileName), s.legends[traceDir])
+}
+
+func (t *Tracing) newTracer(name string) *Tracer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tracer := &Tracer{tracing: t, tid: len(t.tracers) + 1}
+	t.tracers = append(t.tracers, tracer)
+	t.events = append(t.events, &traceEvent{PID: 1, Ph: "M", Cat: "__metadata", Name: "thread_name", TID: tracer.tid, Args: Args{"name": name}})
+	return tracer
+}
+
+// timestamp returns the number of microseconds since tracing started.
+func (t *Tracing) timestamp() int64 {
+	return t.now().Sub(t.start).Microseconds()
+}
+
+func (t *Tracing) addEvent(event *traceEvent) {
+	event.PID = 1
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+type traceEvent struct {
+	PID  int    `json:"pid"`
+	TID  int    `json:"tid"`
+	Ph   string `json:"ph"`
+	Cat  Phase  `json:"cat"`
+	TS   int64  `json:"ts"`
+	Name string `json:"name"`
+	Dur  *int64 `json:"dur,omitzero"`
+	S    string `json:"s,omitzero"`
+	Args Args   `json:"args,omitzero"`
+}
+
+type stackEntry struct {
+	phase               Phase
+	name                string
+	args                Args
+	time                int64
+	separateBeginAndEnd bool
+}
+
+// Tracer records the events of one thread of the trace. A nil *Tracer records nothing, so
+// callers only need to check for nil before doing work to compute event arguments.
+type Tracer struct {
+	tracing    *Tracing
+	tid        int
+	stack      []stackEntry
+	typeSource func() []*TypeDescriptor
+}
+
+// Push begins an event, which is ended by the matching call to Pop. If separateBeginAndEnd is
+// set, the event is always written, as a begin and an end event; otherwise it is written as a
+// single complete event, and only if it spans a sampling boundary.
+func (t *Tracer) Push(phase Phase, name string, args Args, separateBeginAndEnd bool) {
+	if t == nil {
+		return
+	}
+	time := t.tracing.timestamp()
+	if separateBeginAndEnd {
+		t.tracing.addEvent(&traceEvent{Ph: "B", Cat: phase, TS: time, Name: name, TID: t.tid, Args: args})
+	}
+	t.stack = append(t.stack, stackEntry{phase: phase, name: name, args: args, time: time, separateBeginAndEnd: separateBeginAndEnd})
+}
+
+// Pop ends the event begun by the most recent call to Push.
+func (t *Tracer) Pop() {
+	if t == nil {
+		return
+	}
+	t.popAt(t.tracing.timestamp())
+}
+
+func (t *Tracer) popAt(endTime int64) {
+	entry := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+	if entry.separateBeginAndEnd {
+		t.tracing.addEvent(&traceEvent{Ph: "E", Cat: entry.phase, TS: endTime, Name: entry.name, TID: t.tid, Args: entry.args})
+		return
+	}
+	interval := sampleInterval.Microseconds()
+	if dur := endTime - entry.time; interval-entry.time%interval <= dur {
+		t.tracing.addEvent(&traceEvent{Ph: "X", Cat: entry.phase, TS: entry.time, Name: entry.name, TID: t.tid, Dur: &dur, Args: entry.args})
+	}
+}
+
+// Instant records an event that has no duration, such as hitting a recursion limit.
+func (t *Tracer) Instant(phase Phase, name string, args Args) {
+	if t == nil {
+		return
+	}
+	t.tracing.addEvent(&traceEvent{Ph: "I", Cat: phase, TS: t.tracing.timestamp(), Name: name, TID: t.tid, S: "g", Args: args})
+}
+
+// SetTypeSource registers the function that describes the types created on this thread. It is
+// called by Tracing.Stop to produce types.json.
+func (t *Tracer) SetTypeSource(source func() []*TypeDescriptor) {
+	if t == nil {
+		return
+	}
+	t.typeSource = source
+}
+
+// TypeDescriptor describes a type in types.json. Type IDs are only unique within a checker, so
+// each type also records the thread of the checker that created it.
+type TypeDescriptor struct {
+	ID                          int       `json:"id"`
+	TID                         int       `json:"tid"`
+	IntrinsicName               string    `json:"intrinsicName,omitzero"`
+	SymbolName                  string    `json:"symbolName,omitzero"`
+	IsTuple                     bool      `json:"isTuple,omitzero"`
+	UnionTypes                  []int     `json:"unionTypes,omitzero"`
+	IntersectionTypes           []int     `json:"intersectionTypes,omitzero"`
+	AliasTypeArguments          []int     `json:"aliasTypeArguments,omitzero"`
+	KeyofType                   int       `json:"keyofType,omitzero"`
+	IndexedAccessObjectType     int       `json:"indexedAccessObjectType,omitzero"`
+	IndexedAccessIndexType      int       `json:"indexedAccessIndexType,omitzero"`
+	InstantiatedType            int       `json:"instantiatedType,omitzero"`
+	TypeArguments               []int     `json:"typeArguments,omitzero"`
+	ConditionalCheckType        int       `json:"conditionalCheckType,omitzero"`
+	ConditionalExtendsType      int       `json:"conditionalExtendsType,omitzero"`
+	ConditionalTrueType         int       `json:"conditionalTrueType,omitzero"`
+	ConditionalFalseType        int       `json:"conditionalFalseType,omitzero"`
+	SubstitutionBaseType        int       `json:"substitutionBaseType,omitzero"`
+	ConstraintType              int       `json:"constraintType,omitzero"`
+	EvolvingArrayElementType    int       `json:"evolvingArrayElementType,omitzero"`
+	EvolvingArrayFinalType      int       `json:"evolvingArrayFinalType,omitzero"`
+	ReverseMappedSourceType     int       `json:"reverseMappedSourceType,omitzero"`
+	ReverseMappedMappedType     int       `json:"reverseMappedMappedType,omitzero"`
+	ReverseMappedConstraintType int       `json:"reverseMappedConstraintType,omitzero"`
+	FirstDeclaration            *Location `json:"firstDeclaration,omitzero"`
+	Flags                       []string  `json:"flags"`
+	Display                     string    `json:"display,omitzero"`
+}
+
+// Location is a span of text in a file.
+type Location struct {
+	Path  string   `json:"path"`
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Position is a one-based line and character.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
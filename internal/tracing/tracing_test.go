@@ -0,0 +1,127 @@
+package tracing_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/tracing"
+	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+	"gotest.tools/v3/assert"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestSampling(t *testing.T) {
+	t.Parallel()
+	fs := vfstest.FromMap(map[string]string{}, true)
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	trace := tracing.Start(fs, "/trace", clock.Now)
+	tracer := trace.Main()
+
+	tracer.Push(tracing.PhaseCheck, "fast", nil, false)
+	clock.advance(time.Millisecond)
+	tracer.Pop()
+	tracer.Push(tracing.PhaseCheck, "slow", nil, false)
+	clock.advance(20 * time.Millisecond)
+	tracer.Pop()
+	tracer.Push(tracing.PhaseCheck, "separate", tracing.Args{"path": "/a.ts"}, true)
+	tracer.Instant(tracing.PhaseCheckTypes, "instant", nil)
+	// Left open; ended by Stop.
+	tracer.Push(tracing.PhaseCheck, "open", nil, true)
+	assert.NilError(t, trace.Stop())
+
+	contents, ok := fs.ReadFile("/trace/trace.json")
+	assert.Assert(t, ok)
+	assert.Assert(t, !strings.Contains(contents, `"name":"fast"`))
+	assert.Assert(t, strings.Contains(contents, `{"pid":1,"tid":1,"ph":"X","cat":"check","ts":1000,"name":"slow","dur":20000}`))
+	assert.Assert(t, strings.Contains(contents, `{"pid":1,"tid":1,"ph":"B","cat":"check","ts":21000,"name":"separate","args":{"path":"/a.ts"}}`))
+	assert.Assert(t, strings.Contains(contents, `{"pid":1,"tid":1,"ph":"I","cat":"checkTypes","ts":21000,"name":"instant","s":"g"}`))
+	assert.Assert(t, strings.Contains(contents, `{"pid":1,"tid":1,"ph":"E","cat":"check","ts":21000,"name":"open"}`))
+	assert.Assert(t, strings.Contains(contents, `{"pid":1,"tid":1,"ph":"E","cat":"check","ts":21000,"name":"separate","args":{"path":"/a.ts"}}`))
+
+	types, ok := fs.ReadFile("/trace/types.json")
+	assert.Assert(t, ok)
+	assert.Equal(t, types, "[\n]\n")
+}
+
+func TestConcurrentTracers(t *testing.T) {
+	t.Parallel()
+	fs := vfstest.FromMap(map[string]string{}, true)
+	trace := tracing.Start(fs, "/trace", time.Now)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tracer := trace.NewTracer("Checker")
+			tracer.SetTypeSource(func() []*tracing.TypeDescriptor {
+				return []*tracing.TypeDescriptor{{ID: i + 1, Flags: []string{"Any"}}}
+			})
+			for range 100 {
+				tracer.Push(tracing.PhaseCheck, "checkSourceFile", nil, true)
+				worker := trace.AcquireTracer()
+				worker.Push(tracing.PhaseEmit, "emitJsFileOrBundle", nil, true)
+				worker.Pop()
+				trace.ReleaseTracer(worker)
+				tracer.Pop()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.NilError(t, trace.Stop())
+
+	contents, ok := fs.ReadFile("/trace/trace.json")
+	assert.Assert(t, ok)
+	assert.Equal(t, strings.Count(contents, `"name":"checkSourceFile"`), 8*100*2)
+	assert.Equal(t, strings.Count(contents, `"name":"emitJsFileOrBundle"`), 8*100*2)
+	types, ok := fs.ReadFile("/trace/types.json")
+	assert.Assert(t, ok)
+	assert.Equal(t, strings.Count(types, `"flags":["Any"]`), 8)
+}
+
+func TestSession(t *testing.T) {
+	t.Parallel()
+	fs := vfstest.FromMap(map[string]string{}, true)
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	session := tracing.NewSession(fs, clock.Now)
+	assert.NilError(t, session.Start("/trace", "/a/tsconfig.json").Stop())
+	assert.NilError(t, session.Start("/trace", "/b/tsconfig.json").Stop())
+
+	for _, fileName := range []string{"/trace/trace.1.json", "/trace/types.1.json", "/trace/trace.2.json", "/trace/types.2.json"} {
+		assert.Assert(t, fs.FileExists(fileName), fileName)
+	}
+	legend, ok := fs.ReadFile("/trace/legend.json")
+	assert.Assert(t, ok)
+	assert.Equal(t, legend, "[\n"+
+		`{"configFilePath":"/a/tsconfig.json","tracePath":"/trace/trace.1.json","typesPath":"/trace/types.1.json"},`+"\n"+
+		`{"configFilePath":"/b/tsconfig.json","tracePath":"/trace/trace.2.json","typesPath":"/trace/types.2.json"}`+"\n"+
+		"]\n")
+}
+
+func TestStopReportsFailedFile(t *testing.T) {
+	t.Parallel()
+	fs := vfstest.FromMap(map[string]string{"/trace": ""}, true)
+	err := tracing.Start(fs, "/trace", time.Now).Stop()
+	var writeErr *tracing.WriteError
+	assert.Assert(t, errors.As(err, &writeErr))
+	assert.Equal(t, writeErr.FileName, "/trace/trace.json")
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
 )
 
 type GetSymbolAccessibilityDiagnostic = func(symbolAccessibilityResult printer.SymbolAccessibilityResult) *SymbolAccessibilityDiagnostic
@@ -448,4 +449,195 @@ func getTypeParameterConstraintVisibilityDiagnosticMessage(node *ast.Node, symbo
 	}
 }
 
-// !!! TODO isolatedDeclarations createGetIsolatedDeclarationErrors
+var relatedSuggestionByDeclarationKind = map[ast.Kind]*diagnostics.Message{
+	ast.KindArrowFunction:       diagnostics.Add_a_return_type_to_the_function_expression,
+	ast.KindFunctionExpression:  diagnostics.Add_a_return_type_to_the_function_expression,
+	ast.KindMethodDeclaration:   diagnostics.Add_a_return_type_to_the_method,
+	ast.KindGetAccessor:         diagnostics.Add_a_return_type_to_the_get_accessor_declaration,
+	ast.KindSetAccessor:         diagnostics.Add_a_type_to_parameter_of_the_set_accessor_declaration,
+	ast.KindFunctionDeclaration: diagnostics.Add_a_return_type_to_the_function_declaration,
+	ast.KindConstructSignature:  diagnostics.Add_a_return_type_to_the_function_declaration,
+	ast.KindParameter:           diagnostics.Add_a_type_annotation_to_the_parameter_0,
+	ast.KindVariableDeclaration: diagnostics.Add_a_type_annotation_to_the_variable_0,
+	ast.KindPropertyDeclaration: diagnostics.Add_a_type_annotation_to_the_property_0,
+	ast.KindPropertySignature:   diagnostics.Add_a_type_annotation_to_the_property_0,
+	ast.KindExportAssignment:    diagnostics.Move_the_expression_in_default_export_to_a_variable_and_add_a_type_annotation_to_it,
+	ast.KindJSExportAssignment:  diagnostics.Move_the_expression_in_default_export_to_a_variable_and_add_a_type_annotation_to_it,
+}
+
+var errorByDeclarationKind = map[ast.Kind]*diagnostics.Message{
+	ast.KindFunctionExpression:          diagnostics.Function_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations,
+	ast.KindFunctionDeclaration:         diagnostics.Function_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations,
+	ast.KindArrowFunction:               diagnostics.Function_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations,
+	ast.KindMethodDeclaration:           diagnostics.Method_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations,
+	ast.KindConstructSignature:          diagnostics.Method_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations,
+	ast.KindGetAccessor:                 diagnostics.At_least_one_accessor_must_have_an_explicit_type_annotation_with_isolatedDeclarations,
+	ast.KindSetAccessor:                 diagnostics.At_least_one_accessor_must_have_an_explicit_type_annotation_with_isolatedDeclarations,
+	ast.KindParameter:                   diagnostics.Parameter_must_have_an_explicit_type_annotation_with_isolatedDeclarations,
+	ast.KindVariableDeclaration:         diagnostics.Variable_must_have_an_explicit_type_annotation_with_isolatedDeclarations,
+	ast.KindPropertyDeclaration:         diagnostics.Property_must_have_an_explicit_type_annotation_with_isolatedDeclarations,
+	ast.KindPropertySignature:           diagnostics.Property_must_have_an_explicit_type_annotation_with_isolatedDeclarations,
+	ast.KindComputedPropertyName:        diagnostics.Computed_property_names_on_class_or_object_literals_cannot_be_inferred_with_isolatedDeclarations,
+	ast.KindSpreadAssignment:            diagnostics.Objects_that_contain_spread_assignments_can_t_be_inferred_with_isolatedDeclarations,
+	ast.KindShorthandPropertyAssignment: diagnostics.Objects_that_contain_shorthand_properties_can_t_be_inferred_with_isolatedDeclarations,
+	ast.KindArrayLiteralExpression:      diagnostics.Only_const_arrays_can_be_inferred_with_isolatedDeclarations,
+	ast.KindExportAssignment:            diagnostics.Default_exports_can_t_be_inferred_with_isolatedDeclarations,
+	ast.KindJSExportAssignment:          diagnostics.Default_exports_can_t_be_inferred_with_isolatedDeclarations,
+	ast.KindSpreadElement:               diagnostics.Arrays_with_spread_elements_can_t_inferred_with_isolatedDeclarations,
+}
+
+// Creates the diagnostic reported when the type of `node` cannot be produced without the checker under `isolatedDeclarations`
+func getIsolatedDeclarationError(resolver printer.EmitResolver, node *ast.Node) *ast.Diagnostic {
+	if ast.FindAncestor(node, ast.IsHeritageClause) != nil {
+		return createDiagnosticForNode(node, diagnostics.Extends_clause_can_t_contain_an_expression_with_isolatedDeclarations)
+	}
+	if (ast.IsPartOfTypeNode(node) || ast.IsTypeQueryNode(node.Parent)) && (ast.IsEntityName(node) || ast.IsEntityNameExpression(node)) {
+		return createEntityInTypeNodeError(node)
+	}
+	switch node.Kind {
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		return createAccessorTypeError(node)
+	case ast.KindComputedPropertyName, ast.KindShorthandPropertyAssignment, ast.KindSpreadAssignment, ast.KindArrayLiteralExpression, ast.KindSpreadElement:
+		return createObjectOrArrayLiteralError(node)
+	case ast.KindMethodDeclaration, ast.KindConstructSignature, ast.KindFunctionExpression, ast.KindArrowFunction, ast.KindFunctionDeclaration:
+		return createReturnTypeError(node)
+	case ast.KindBindingElement:
+		return createDiagnosticForNode(node, diagnostics.Binding_elements_can_t_be_exported_directly_with_isolatedDeclarations)
+	case ast.KindPropertyDeclaration, ast.KindVariableDeclaration:
+		return createVariableOrPropertyError(node)
+	case ast.KindParameter:
+		return createParameterError(resolver, node)
+	case ast.KindPropertyAssignment:
+		return createExpressionError(node.Initializer(), nil)
+	case ast.KindClassExpression:
+		return createExpressionError(node, diagnostics.Inference_from_class_expressions_is_not_supported_with_isolatedDeclarations)
+	default:
+		return createExpressionError(node, nil)
+	}
+}
+
+func findNearestDeclaration(node *ast.Node) *ast.Node {
+	result := ast.FindAncestor(node, func(n *ast.Node) bool {
+		return ast.IsExportAssignment(n) || ast.IsStatement(n) || ast.IsVariableDeclaration(n) || ast.IsPropertyDeclaration(n) || ast.IsParameter(n)
+	})
+	if result == nil || ast.IsExportAssignment(result) {
+		return result
+	}
+	if ast.IsReturnStatement(result) {
+		return ast.FindAncestor(result, func(n *ast.Node) bool {
+			return ast.IsFunctionLikeDeclaration(n) && !ast.IsConstructorDeclaration(n)
+		})
+	}
+	if ast.IsStatement(result) {
+		return nil
+	}
+	return result
+}
+
+func createRelatedDeclarationSuggestion(declaration *ast.Node) *ast.Diagnostic {
+	targetStr := ""
+	if !ast.IsExportAssignment(declaration) && declaration.Name() != nil {
+		targetStr = scanner.GetTextOfNode(declaration.Name())
+	}
+	return createDiagnosticForNode(declaration, relatedSuggestionByDeclarationKind[declaration.Kind], targetStr)
+}
+
+func addParentDeclarationRelatedInfo(node *ast.Node, diag *ast.Diagnostic) {
+	parentDeclaration := findNearestDeclaration(node)
+	if parentDeclaration != nil && relatedSuggestionByDeclarationKind[parentDeclaration.Kind] != nil {
+		diag.AddRelatedInfo(createRelatedDeclarationSuggestion(parentDeclaration))
+	}
+}
+
+func createAccessorTypeError(node *ast.Node) *ast.Diagnostic {
+	accessors := getAllAccessorDeclarations(node)
+	targetNode := node
+	if ast.IsSetAccessorDeclaration(node) && len(node.Parameters()) > 0 {
+		targetNode = node.Parameters()[0]
+	}
+	diag := createDiagnosticForNode(targetNode, errorByDeclarationKind[node.Kind])
+	if accessors.setAccessor != nil {
+		diag.AddRelatedInfo(createDiagnosticForNode(accessors.setAccessor, relatedSuggestionByDeclarationKind[ast.KindSetAccessor]))
+	}
+	if accessors.getAccessor != nil {
+		diag.AddRelatedInfo(createDiagnosticForNode(accessors.getAccessor, relatedSuggestionByDeclarationKind[ast.KindGetAccessor]))
+	}
+	return diag
+}
+
+func createObjectOrArrayLiteralError(node *ast.Node) *ast.Diagnostic {
+	diag := createDiagnosticForNode(node, errorByDeclarationKind[node.Kind])
+	addParentDeclarationRelatedInfo(node, diag)
+	return diag
+}
+
+func createReturnTypeError(node *ast.Node) *ast.Diagnostic {
+	diag := createDiagnosticForNode(node, errorByDeclarationKind[node.Kind])
+	addParentDeclarationRelatedInfo(node, diag)
+	diag.AddRelatedInfo(createDiagnosticForNode(node, relatedSuggestionByDeclarationKind[node.Kind]))
+	return diag
+}
+
+func createVariableOrPropertyError(node *ast.Node) *ast.Diagnostic {
+	diag := createDiagnosticForNode(node, errorByDeclarationKind[node.Kind])
+	diag.AddRelatedInfo(createDiagnosticForNode(node, relatedSuggestionByDeclarationKind[node.Kind], scanner.GetTextOfNode(node.Name())))
+	return diag
+}
+
+func createParameterError(resolver printer.EmitResolver, node *ast.Node) *ast.Diagnostic {
+	if ast.IsSetAccessorDeclaration(node.Parent) {
+		return createAccessorTypeError(node.Parent)
+	}
+	addUndefined := resolver.RequiresAddingImplicitUndefined(node, nil, node.Parent)
+	if !addUndefined && node.Initializer() != nil {
+		return createExpressionError(node.Initializer(), nil)
+	}
+	message := errorByDeclarationKind[node.Kind]
+	if addUndefined {
+		message = diagnostics.Declaration_emit_for_this_parameter_requires_implicitly_adding_undefined_to_its_type_This_is_not_supported_with_isolatedDeclarations
+	}
+	diag := createDiagnosticForNode(node, message)
+	diag.AddRelatedInfo(createDiagnosticForNode(node, relatedSuggestionByDeclarationKind[node.Kind], scanner.GetTextOfNode(node.Name())))
+	return diag
+}
+
+func createEntityInTypeNodeError(node *ast.Node) *ast.Diagnostic {
+	diag := createDiagnosticForNode(node, diagnostics.Type_containing_private_name_0_can_t_be_used_with_isolatedDeclarations, scanner.GetTextOfNode(node))
+	addParentDeclarationRelatedInfo(node, diag)
+	return diag
+}
+
+func createExpressionError(node *ast.Node, message *diagnostics.Message) *ast.Diagnostic {
+	parentDeclaration := findNearestDeclaration(node)
+	if parentDeclaration == nil || relatedSuggestionByDeclarationKind[parentDeclaration.Kind] == nil {
+		if message == nil {
+			message = diagnostics.Expression_type_can_t_be_inferred_with_isolatedDeclarations
+		}
+		return createDiagnosticForNode(node, message)
+	}
+	parent := ast.FindAncestorOrQuit(node.Parent, func(n *ast.Node) ast.FindAncestorResult {
+		if ast.IsExportAssignment(n) {
+			return ast.FindAncestorTrue
+		}
+		if ast.IsStatement(n) {
+			return ast.FindAncestorQuit
+		}
+		return ast.ToFindAncestorResult(!ast.IsParenthesizedExpression(n) && n.Kind != ast.KindTypeAssertionExpression && n.Kind != ast.KindAsExpression)
+	})
+	var diag *ast.Diagnostic
+	if parentDeclaration == parent {
+		if message == nil {
+			message = errorByDeclarationKind[parentDeclaration.Kind]
+		}
+		diag = createDiagnosticForNode(node, message)
+		diag.AddRelatedInfo(createRelatedDeclarationSuggestion(parentDeclaration))
+	} else {
+		if message == nil {
+			message = diagnostics.Expression_type_can_t_be_inferred_with_isolatedDeclarations
+		}
+		diag = createDiagnosticForNode(node, message)
+		diag.AddRelatedInfo(createRelatedDeclarationSuggestion(parentDeclaration))
+		diag.AddRelatedInfo(createDiagnosticForNode(node, diagnostics.Add_satisfies_and_a_type_assertion_to_this_expression_satisfies_T_as_T_to_make_the_type_explicit))
+	}
+	return diag
+}
@@ -0,0 +1,378 @@
+package declarations
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+)
+
+// Under `isolatedDeclarations`, every type written to a declaration file must be derivable from the syntax of the
+// file alone. The checks below follow the inference rules of the syntactic type node builder and report each
+// declaration that would need the checker; the emitted types themselves are still produced by the resolver.
+
+type syntacticResult int
+
+const (
+	// The type can't be inferred from syntax, and the containing declaration should be reported
+	syntacticResultFailed syntacticResult = iota
+	// The type can't be inferred from syntax, but a more specific error was already reported on a nested node
+	syntacticResultReported
+	// The type can be inferred from syntax alone
+	syntacticResultInferred
+)
+
+func (tx *DeclarationTransformer) reportInferenceFallback(node *ast.Node) {
+	tx.tracker.ReportInferenceFallback(node)
+}
+
+// Reports an error if the type of a declaration without a (usable) type annotation can't be inferred from its syntax
+func (tx *DeclarationTransformer) checkTypeOfDeclaration(node *ast.Node) {
+	node = tx.EmitContext().ParseNode(node)
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case ast.KindParameter:
+		tx.checkTypeOfParameter(node)
+	case ast.KindVariableDeclaration:
+		tx.checkTypeOfVariable(node)
+	case ast.KindPropertySignature, ast.KindPropertyDeclaration:
+		tx.checkTypeOfProperty(node)
+	case ast.KindBindingElement:
+		tx.reportInferenceFallback(node)
+	case ast.KindExportAssignment, ast.KindJSExportAssignment:
+		tx.checkTypeOfSerializedExpression(node.Expression(), true /*preserveLiterals*/)
+	}
+}
+
+// Reports an error if the type of an expression that is serialized on its own can't be inferred from its syntax
+func (tx *DeclarationTransformer) checkTypeOfSerializedExpression(expression *ast.Node, preserveLiterals bool) {
+	expression = tx.EmitContext().ParseNode(expression)
+	if expression == nil {
+		return
+	}
+	if tx.checkTypeOfExpression(expression, false /*isConstContext*/, false /*requiresAddingUndefined*/, preserveLiterals) == syntacticResultFailed {
+		tx.reportInferenceFallback(expression)
+	}
+}
+
+// Reports an error if the return type of a signature without a return type annotation can't be inferred from its syntax
+func (tx *DeclarationTransformer) checkReturnTypeOfSignature(node *ast.Node) {
+	node = tx.EmitContext().ParseNode(node)
+	if node == nil {
+		return
+	}
+	if ast.IsGetAccessorDeclaration(node) {
+		tx.checkTypeOfAccessor(node)
+		return
+	}
+	tx.checkReturnTypeFromSignature(node)
+}
+
+func (tx *DeclarationTransformer) checkTypeOfParameter(node *ast.Node) {
+	if ast.IsSetAccessorDeclaration(node.Parent) {
+		tx.checkTypeOfAccessor(node.Parent)
+		return
+	}
+	requiresAddingUndefined := tx.resolver.RequiresAddingImplicitUndefined(node, nil, tx.enclosingDeclaration)
+	result := syntacticResultFailed
+	if declaredType := node.Type(); declaredType != nil {
+		if !requiresAddingUndefined || tx.canAddUndefined(declaredType) {
+			result = syntacticResultInferred
+		}
+	} else if node.Initializer() != nil && ast.IsIdentifier(node.Name()) && !isContextuallyTyped(node) {
+		result = tx.checkTypeOfExpression(node.Initializer(), false /*isConstContext*/, requiresAddingUndefined, false /*preserveLiterals*/)
+	}
+	if result == syntacticResultFailed {
+		tx.reportInferenceFallback(node)
+	}
+}
+
+func (tx *DeclarationTransformer) checkTypeOfVariable(node *ast.Node) {
+	if node.Type() != nil {
+		return
+	}
+	result := syntacticResultFailed
+	if node.Initializer() != nil && isOnlyVariableDeclarationOfSymbol(node) {
+		if !tx.resolver.IsExpandoFunctionDeclaration(node) && !isContextuallyTyped(node) {
+			result = tx.checkTypeOfExpression(node.Initializer(), false /*isConstContext*/, false /*requiresAddingUndefined*/, ast.IsVarConstLike(node))
+		}
+	}
+	if result == syntacticResultFailed {
+		tx.reportInferenceFallback(node)
+	}
+}
+
+func (tx *DeclarationTransformer) checkTypeOfProperty(node *ast.Node) {
+	if node.Type() != nil {
+		return
+	}
+	result := syntacticResultFailed
+	if ast.IsPropertyDeclaration(node) && node.Initializer() != nil && !isContextuallyTyped(node) {
+		requiresAddingUndefined := tx.resolver.RequiresAddingImplicitUndefined(node, nil, tx.enclosingDeclaration)
+		isReadonly := ast.GetCombinedModifierFlags(node)&ast.ModifierFlagsReadonly != 0
+		result = tx.checkTypeOfExpression(node.Initializer(), false /*isConstContext*/, requiresAddingUndefined, isReadonly)
+	}
+	if result == syntacticResultFailed {
+		tx.reportInferenceFallback(node)
+	}
+}
+
+func (tx *DeclarationTransformer) checkTypeOfAccessor(accessor *ast.Node) {
+	accessors := getAllAccessorDeclarations(accessor)
+	accessorType := getTypeAnnotationFromAccessor(accessor)
+	if accessorType == nil {
+		accessorType = getTypeAnnotationFromAccessor(accessors.getAccessor)
+	}
+	if accessorType == nil {
+		accessorType = getTypeAnnotationFromAccessor(accessors.setAccessor)
+	}
+	if accessorType != nil && !ast.IsTypePredicateNode(accessorType) {
+		return
+	}
+	if accessors.getAccessor != nil {
+		tx.checkReturnTypeFromSignature(accessors.getAccessor)
+		return
+	}
+	tx.reportInferenceFallback(accessor)
+}
+
+func (tx *DeclarationTransformer) checkReturnTypeFromSignature(fn *ast.Node) {
+	if fn.Type() != nil {
+		return
+	}
+	result := syntacticResultFailed
+	if isValueSignatureDeclaration(fn) {
+		result = tx.checkTypeFromSingleReturnExpression(fn)
+	}
+	if result == syntacticResultFailed {
+		tx.reportInferenceFallback(fn)
+	}
+}
+
+func (tx *DeclarationTransformer) checkTypeFromSingleReturnExpression(fn *ast.Node) syntacticResult {
+	body := fn.Body()
+	if body == nil || ast.HasSyntacticModifier(fn, ast.ModifierFlagsAsync) || fn.BodyData().AsteriskToken != nil {
+		return syntacticResultFailed
+	}
+	var candidate *ast.Node
+	if ast.IsBlock(body) {
+		ast.ForEachReturnStatement(body, func(stmt *ast.Node) bool {
+			if stmt.Parent != body || candidate != nil {
+				candidate = nil
+				return true
+			}
+			candidate = stmt.Expression()
+			return false
+		})
+	} else {
+		candidate = body
+	}
+	if candidate == nil {
+		return syntacticResultFailed
+	}
+	if isContextuallyTyped(candidate) {
+		if candidate.Kind == ast.KindAsExpression || candidate.Kind == ast.KindTypeAssertionExpression {
+			if assertedType := candidate.Type(); !ast.IsConstTypeReference(assertedType) {
+				return syntacticResultInferred
+			}
+		}
+		return syntacticResultFailed
+	}
+	return tx.checkTypeOfExpression(candidate, false /*isConstContext*/, false /*requiresAddingUndefined*/, false /*preserveLiterals*/)
+}
+
+func (tx *DeclarationTransformer) checkTypeOfExpression(node *ast.Node, isConstContext bool, requiresAddingUndefined bool, preserveLiterals bool) syntacticResult {
+	switch node.Kind {
+	case ast.KindParenthesizedExpression:
+		return tx.checkTypeOfExpression(node.Expression(), isConstContext, requiresAddingUndefined, false /*preserveLiterals*/)
+	case ast.KindIdentifier:
+		if tx.resolver.IsUndefinedIdentifierExpression(node) {
+			return syntacticResultInferred
+		}
+	case ast.KindNullKeyword:
+		return syntacticResultInferred
+	case ast.KindArrowFunction, ast.KindFunctionExpression:
+		tx.checkFunctionLikeExpression(node)
+		return syntacticResultInferred
+	case ast.KindTypeAssertionExpression, ast.KindAsExpression:
+		assertedType := node.Type()
+		if ast.IsConstTypeReference(assertedType) {
+			return tx.checkTypeOfExpression(node.Expression(), true /*isConstContext*/, requiresAddingUndefined, false /*preserveLiterals*/)
+		}
+		if !requiresAddingUndefined || tx.canAddUndefined(assertedType) {
+			return syntacticResultInferred
+		}
+	case ast.KindPrefixUnaryExpression:
+		if isPrimitiveLiteralValue(node, true /*includeBigInt*/) {
+			return syntacticResultInferred
+		}
+	case ast.KindArrayLiteralExpression:
+		return tx.checkTypeOfArrayLiteral(node, isConstContext)
+	case ast.KindObjectLiteralExpression:
+		return tx.checkTypeOfObjectLiteral(node, isConstContext)
+	case ast.KindClassExpression:
+		tx.reportInferenceFallback(node)
+		return syntacticResultReported
+	case ast.KindTemplateExpression:
+		if !isConstContext && !preserveLiterals {
+			return syntacticResultInferred
+		}
+	case ast.KindNumericLiteral,
+		ast.KindBigIntLiteral,
+		ast.KindStringLiteral,
+		ast.KindNoSubstitutionTemplateLiteral,
+		ast.KindTrueKeyword,
+		ast.KindFalseKeyword:
+		return syntacticResultInferred
+	}
+	return syntacticResultFailed
+}
+
+func (tx *DeclarationTransformer) checkFunctionLikeExpression(fn *ast.Node) {
+	tx.checkReturnTypeFromSignature(fn)
+	for _, parameter := range fn.Parameters() {
+		tx.checkTypeOfParameter(parameter)
+	}
+}
+
+func (tx *DeclarationTransformer) checkTypeOfArrayLiteral(arrayLiteral *ast.Node, isConstContext bool) syntacticResult {
+	if !isConstContext {
+		tx.reportInferenceFallback(arrayLiteral)
+		return syntacticResultReported
+	}
+	elements := arrayLiteral.AsArrayLiteralExpression().Elements.Nodes
+	for _, element := range elements {
+		if ast.IsSpreadElement(element) {
+			tx.reportInferenceFallback(element)
+			return syntacticResultReported
+		}
+	}
+	for _, element := range elements {
+		if ast.IsOmittedExpression(element) {
+			continue
+		}
+		if tx.checkTypeOfExpression(element, isConstContext, false /*requiresAddingUndefined*/, false /*preserveLiterals*/) == syntacticResultFailed {
+			tx.reportInferenceFallback(element)
+		}
+	}
+	return syntacticResultInferred
+}
+
+func (tx *DeclarationTransformer) checkTypeOfObjectLiteral(objectLiteral *ast.Node, isConstContext bool) syntacticResult {
+	properties := objectLiteral.AsObjectLiteralExpression().Properties.Nodes
+	if !tx.canGetTypeFromObjectLiteral(properties) {
+		return syntacticResultReported
+	}
+	for _, property := range properties {
+		switch property.Kind {
+		case ast.KindMethodDeclaration:
+			tx.checkFunctionLikeExpression(property)
+		case ast.KindPropertyAssignment:
+			if tx.checkTypeOfExpression(property.Initializer(), isConstContext, false /*requiresAddingUndefined*/, false /*preserveLiterals*/) == syntacticResultFailed {
+				tx.reportInferenceFallback(property)
+			}
+		case ast.KindGetAccessor, ast.KindSetAccessor:
+			tx.checkTypeOfObjectLiteralAccessor(property)
+		}
+	}
+	return syntacticResultInferred
+}
+
+func (tx *DeclarationTransformer) canGetTypeFromObjectLiteral(properties []*ast.Node) bool {
+	result := true
+	for _, property := range properties {
+		if property.Flags&ast.NodeFlagsThisNodeHasError != 0 {
+			return false
+		}
+		if property.Kind == ast.KindShorthandPropertyAssignment || property.Kind == ast.KindSpreadAssignment {
+			tx.reportInferenceFallback(property)
+			result = false
+			continue
+		}
+		name := property.Name()
+		if name.Flags&ast.NodeFlagsThisNodeHasError != 0 {
+			return false
+		}
+		if ast.IsPrivateIdentifier(name) {
+			result = false
+		} else if ast.IsComputedPropertyName(name) {
+			expression := name.Expression()
+			if !isPrimitiveLiteralValue(expression, false /*includeBigInt*/) && !tx.resolver.IsDefinitelyReferenceToGlobalSymbolObject(expression) {
+				tx.reportInferenceFallback(name)
+				result = false
+			}
+		}
+	}
+	return result
+}
+
+func (tx *DeclarationTransformer) checkTypeOfObjectLiteralAccessor(accessor *ast.Node) {
+	accessors := getAllAccessorDeclarations(accessor)
+	getAccessorType := getTypeAnnotationFromAccessor(accessors.getAccessor)
+	setAccessorType := getTypeAnnotationFromAccessor(accessors.setAccessor)
+	if getAccessorType != nil && setAccessorType != nil {
+		for _, parameter := range accessor.Parameters() {
+			tx.checkTypeOfParameter(parameter)
+		}
+		return
+	}
+	if accessors.firstAccessor != accessor || getAccessorType != nil || setAccessorType != nil {
+		return
+	}
+	if ast.IsGetAccessorDeclaration(accessor) {
+		tx.checkReturnTypeFromSignature(accessor)
+		return
+	}
+	tx.reportInferenceFallback(accessor)
+	if accessors.getAccessor != nil {
+		tx.checkReturnTypeFromSignature(accessors.getAccessor)
+	}
+}
+
+// Whether a `T | undefined` type can be written for the type node without resolving any references in it
+func (tx *DeclarationTransformer) canAddUndefined(node *ast.Node) bool {
+	if !tx.compilerOptions.GetStrictOptionValue(tx.compilerOptions.StrictNullChecks) {
+		return true
+	}
+	switch node.Kind {
+	case ast.KindLiteralType,
+		ast.KindFunctionType,
+		ast.KindConstructorType,
+		ast.KindArrayType,
+		ast.KindTupleType,
+		ast.KindTypeLiteral,
+		ast.KindTemplateLiteralType,
+		ast.KindThisType:
+		return true
+	case ast.KindParenthesizedType:
+		return tx.canAddUndefined(node.Type())
+	case ast.KindUnionType:
+		return core.Every(node.AsUnionTypeNode().Types.Nodes, tx.canAddUndefined)
+	case ast.KindIntersectionType:
+		return core.Every(node.AsIntersectionTypeNode().Types.Nodes, tx.canAddUndefined)
+	}
+	return ast.IsKeywordKind(node.Kind)
+}
+
+func isValueSignatureDeclaration(node *ast.Node) bool {
+	return ast.IsFunctionExpressionOrArrowFunction(node) || ast.IsMethodOrAccessor(node) || ast.IsFunctionDeclaration(node) || ast.IsConstructorDeclaration(node)
+}
+
+func isOnlyVariableDeclarationOfSymbol(node *ast.Node) bool {
+	symbol := node.Symbol()
+	if symbol == nil {
+		return false
+	}
+	return len(symbol.Declarations) == 1 || core.CountWhere(symbol.Declarations, ast.IsVariableDeclaration) == 1
+}
+
+// An expression is contextually typed when an enclosing call, annotated declaration, type assertion, or JSX
+// element may influence its type, in which case its own syntax does not determine the emitted type.
+func isContextuallyTyped(node *ast.Node) bool {
+	return ast.FindAncestor(node.Parent, func(n *ast.Node) bool {
+		return ast.IsCallExpression(n) ||
+			(!ast.IsFunctionLikeDeclaration(n) && n.Type() != nil) ||
+			ast.IsJsxElement(n) ||
+			ast.IsJsxExpression(n)
+	}) != nil
+}
@@ -58,7 +58,7 @@ func (s *SymbolTrackerImpl) ReportInaccessibleUniqueSymbolError() {
 
 // ReportInferenceFallback implements checker.SymbolTracker.
 func (s *SymbolTrackerImpl) ReportInferenceFallback(node *ast.Node) {
-	if s.state.isolatedDeclarations || ast.IsSourceFileJS(s.state.currentSourceFile) {
+	if !s.state.isolatedDeclarations || ast.IsSourceFileJS(s.state.currentSourceFile) {
 		return
 	}
 	if ast.GetSourceFileOfNode(node) != s.state.currentSourceFile {
@@ -67,8 +67,7 @@ func (s *SymbolTrackerImpl) ReportInferenceFallback(node *ast.Node) {
 	if ast.IsVariableDeclaration(node) && s.state.resolver.IsExpandoFunctionDeclaration(node) {
 		s.state.reportExpandoFunctionErrors(node)
 	} else {
-		// !!! isolatedDeclaration support
-		// s.state.addDiagnostic(getIsolatedDeclarationError(node))
+		s.state.addDiagnostic(getIsolatedDeclarationError(s.resolver, node))
 	}
 }
 
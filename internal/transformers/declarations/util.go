@@ -1,9 +1,13 @@
 package declarations
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
 )
 
 func needsScopeMarker(result *ast.Node) bool {
@@ -234,3 +238,99 @@ func hasScopeMarker(statements *ast.StatementList) bool {
 	}
 	return core.Some(statements.Nodes, isScopeMarker)
 }
+
+func hasInternalAnnotation(comment ast.CommentRange, sourceFile *ast.SourceFile) bool {
+	return strings.Contains(sourceFile.Text()[comment.Pos():comment.End()], "@internal")
+}
+
+// Determines whether the original declaration of `node` is annotated with an `@internal` comment.
+func isInternalDeclaration(emitContext *printer.EmitContext, node *ast.Node, sourceFile *ast.SourceFile) bool {
+	parseTreeNode := emitContext.ParseNode(node)
+	if parseTreeNode == nil {
+		return false
+	}
+	if sourceFile == nil {
+		sourceFile = ast.GetSourceFileOfNode(parseTreeNode)
+	}
+	factory := emitContext.Factory.AsNodeFactory()
+	text := sourceFile.Text()
+	if parseTreeNode.Kind == ast.KindParameter {
+		parameters := parseTreeNode.Parent.Parameters()
+		paramIndex := slices.Index(parameters, parseTreeNode)
+		var commentRanges []ast.CommentRange
+		if paramIndex > 0 {
+			// to handle
+			// ... parameters, /** @internal */
+			// public param: string
+			previousSibling := parameters[paramIndex-1]
+			for comment := range scanner.GetTrailingCommentRanges(factory, text, scanner.SkipTriviaEx(text, previousSibling.End()+1, &scanner.SkipTriviaOptions{StopAtComments: true})) {
+				commentRanges = append(commentRanges, comment)
+			}
+			for comment := range scanner.GetLeadingCommentRanges(factory, text, parseTreeNode.Pos()) {
+				commentRanges = append(commentRanges, comment)
+			}
+		} else {
+			for comment := range scanner.GetTrailingCommentRanges(factory, text, scanner.SkipTriviaEx(text, parseTreeNode.Pos(), &scanner.SkipTriviaOptions{StopAtComments: true})) {
+				commentRanges = append(commentRanges, comment)
+			}
+		}
+		return len(commentRanges) > 0 && hasInternalAnnotation(commentRanges[len(commentRanges)-1], sourceFile)
+	}
+	if parseTreeNode.Kind == ast.KindJsxText {
+		return false
+	}
+	for comment := range scanner.GetLeadingCommentRanges(factory, text, parseTreeNode.Pos()) {
+		if hasInternalAnnotation(comment, sourceFile) {
+			return true
+		}
+	}
+	return false
+}
+
+type allAccessorDeclarations struct {
+	firstAccessor *ast.Node
+	getAccessor   *ast.Node
+	setAccessor   *ast.Node
+}
+
+// Gets the accessors that declare the same member as the provided accessor. An accessor with a dynamic name is
+// never paired with another accessor.
+func getAllAccessorDeclarations(accessor *ast.Node) allAccessorDeclarations {
+	declarations := []*ast.Node{accessor}
+	if symbol := accessor.Symbol(); symbol != nil && !ast.HasDynamicName(accessor) {
+		declarations = symbol.Declarations
+	}
+	var result allAccessorDeclarations
+	for _, declaration := range declarations {
+		if !ast.IsAccessor(declaration) {
+			continue
+		}
+		if result.firstAccessor == nil {
+			result.firstAccessor = declaration
+		}
+		if declaration.Kind == ast.KindGetAccessor && result.getAccessor == nil {
+			result.getAccessor = declaration
+		}
+		if declaration.Kind == ast.KindSetAccessor && result.setAccessor == nil {
+			result.setAccessor = declaration
+		}
+	}
+	return result
+}
+
+func getTypeAnnotationFromAccessor(accessor *ast.Node) *ast.Node {
+	if accessor == nil {
+		return nil
+	}
+	if accessor.Kind == ast.KindGetAccessor {
+		return accessor.Type()
+	}
+	parameters := accessor.Parameters()
+	if len(parameters) == 0 {
+		return nil
+	}
+	if len(parameters) == 2 && ast.IsThisParameter(parameters[0]) {
+		return parameters[1].Type()
+	}
+	return parameters[0].Type()
+}
@@ -2,18 +2,959 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/binder"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
+// Tracks the variable used to capture the `arguments` object of the nearest non-arrow function for use within
+// the generator function that replaces an async function body.
+type lexicalArgumentsBinding struct {
+	name *ast.IdentifierNode
+	used bool
+}
+
+// Tracks `super` property and element accesses within a method, accessor, or constructor that contains an async
+// context. Such accesses are rewritten, as `super` is not permitted in the generator functions we emit.
+type superAccessInfo struct {
+	names            []string
+	hasElementAccess bool
+	hasBinding       bool // whether some `super` access is the target of an assignment
+}
+
+type asyncFunctionScope struct {
+	inAsyncFunction                 bool
+	hasLexicalThis                  bool
+	lexicalArguments                *lexicalArgumentsBinding
+	enclosingFunctionParameterNames *collections.Set[string]
+	superAccess                     *superAccessInfo
+}
+
 type asyncTransformer struct {
 	transformers.Transformer
-}
+	compilerOptions *core.CompilerOptions
+	resolver        binder.ReferenceResolver
+
+	asyncBodyVisitor *ast.NodeVisitor // visits the statements of an async function body, hoisting variables that collide with parameters
 
-func (ch *asyncTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	parentNode  *ast.Node
+	currentNode *ast.Node
+
+	inAsyncFunction                 bool
+	hasLexicalThis                  bool
+	lexicalArguments                *lexicalArgumentsBinding
+	enclosingFunctionParameterNames *collections.Set[string]
+	superAccess                     *superAccessInfo
 }
 
 func newAsyncTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
-	tx := &asyncTransformer{}
+	resolver := opts.Resolver
+	if resolver == nil {
+		resolver = binder.NewReferenceResolver(opts.CompilerOptions, binder.ReferenceResolverHooks{})
+	}
+	tx := &asyncTransformer{compilerOptions: opts.CompilerOptions, resolver: resolver}
+	tx.asyncBodyVisitor = opts.Context.NewNodeVisitor(tx.visitAsyncBody)
 	return tx.NewTransformer(tx.visit, opts.Context)
 }
+
+// Pushes a new child node onto the ancestor tracking stack, returning the grandparent node to be restored later via `popNode`.
+func (tx *asyncTransformer) pushNode(node *ast.Node) (grandparentNode *ast.Node) {
+	grandparentNode = tx.parentNode
+	tx.parentNode = tx.currentNode
+	tx.currentNode = node
+	return grandparentNode
+}
+
+// Pops the last child node off the ancestor tracking stack, restoring the grandparent node.
+func (tx *asyncTransformer) popNode(grandparentNode *ast.Node) {
+	tx.currentNode = tx.parentNode
+	tx.parentNode = grandparentNode
+}
+
+func (tx *asyncTransformer) saveScope() asyncFunctionScope {
+	return asyncFunctionScope{
+		inAsyncFunction:                 tx.inAsyncFunction,
+		hasLexicalThis:                  tx.hasLexicalThis,
+		lexicalArguments:                tx.lexicalArguments,
+		enclosingFunctionParameterNames: tx.enclosingFunctionParameterNames,
+		superAccess:                     tx.superAccess,
+	}
+}
+
+func (tx *asyncTransformer) restoreScope(scope asyncFunctionScope) {
+	tx.inAsyncFunction = scope.inAsyncFunction
+	tx.hasLexicalThis = scope.hasLexicalThis
+	tx.lexicalArguments = scope.lexicalArguments
+	tx.enclosingFunctionParameterNames = scope.enclosingFunctionParameterNames
+	tx.superAccess = scope.superAccess
+}
+
+// Enters the scope of a non-arrow function, which has its own `this` and `arguments`.
+func (tx *asyncTransformer) enterFunction(node *ast.Node) asyncFunctionScope {
+	scope := tx.saveScope()
+	tx.inAsyncFunction = isAsyncFunction(node)
+	tx.hasLexicalThis = true
+	tx.lexicalArguments = nil
+	switch node.Kind {
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor:
+		tx.superAccess = tx.getSuperAccessInfo(node)
+	default:
+		tx.superAccess = nil
+	}
+	return scope
+}
+
+func (tx *asyncTransformer) visit(node *ast.Node) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsAnyAwait == 0 && tx.lexicalArguments == nil && tx.superAccess == nil {
+		return node
+	}
+
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindAwaitExpression:
+		return tx.visitAwaitExpression(node.AsAwaitExpression())
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(node.AsMethodDeclaration())
+	case ast.KindFunctionDeclaration:
+		return tx.visitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindFunctionExpression:
+		return tx.visitFunctionExpression(node.AsFunctionExpression())
+	case ast.KindArrowFunction:
+		return tx.visitArrowFunction(node.AsArrowFunction())
+	case ast.KindGetAccessor:
+		return tx.visitGetAccessorDeclaration(node.AsGetAccessorDeclaration())
+	case ast.KindSetAccessor:
+		return tx.visitSetAccessorDeclaration(node.AsSetAccessorDeclaration())
+	case ast.KindConstructor:
+		return tx.visitConstructorDeclaration(node.AsConstructorDeclaration())
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		return tx.visitClassLike(node)
+	case ast.KindIdentifier:
+		return tx.visitIdentifier(node)
+	case ast.KindShorthandPropertyAssignment:
+		return tx.visitShorthandPropertyAssignment(node.AsShorthandPropertyAssignment())
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		if tx.superAccess != nil && isSuperProperty(node) {
+			return tx.visitSuperAccess(node)
+		}
+		return tx.Visitor().VisitEachChild(node)
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *asyncTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	tx.hasLexicalThis = !tx.isEffectiveStrictModeSourceFile(node)
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited.AsNode(), tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+func (tx *asyncTransformer) isEffectiveStrictModeSourceFile(node *ast.SourceFile) bool {
+	if tx.compilerOptions.AlwaysStrict.DefaultIfUnknown(tx.compilerOptions.Strict).IsTrue() ||
+		ast.IsExternalModule(node) ||
+		tx.compilerOptions.GetIsolatedModules() {
+		return true
+	}
+	for _, statement := range node.Statements.Nodes {
+		if !ast.IsPrologueDirective(statement) {
+			break
+		}
+		if statement.Expression().Text() == "use strict" {
+			return true
+		}
+	}
+	return false
+}
+
+func (tx *asyncTransformer) visitAwaitExpression(node *ast.AwaitExpression) *ast.Node {
+	if !tx.inAsyncFunction {
+		// top-level `await` and `await` in an async generator are not transformed here
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	updated := tx.Factory().NewYieldExpression(nil /*asteriskToken*/, tx.Visitor().VisitNode(node.Expression))
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+func (tx *asyncTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	name := tx.Visitor().VisitNode(node.Name())
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	if isAsyncFunction(node.AsNode()) {
+		parameters := tx.transformAsyncFunctionParameterList(node.AsNode())
+		return tx.Factory().UpdateMethodDeclaration(
+			node,
+			tx.visitAsyncModifiers(node.Modifiers()),
+			node.AsteriskToken,
+			name,
+			node.PostfixToken,
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.transformAsyncFunctionBody(node.AsNode(), parameters),
+		)
+	}
+	return tx.Factory().UpdateMethodDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.AsteriskToken,
+		name,
+		node.PostfixToken,
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.transformMethodBody(node.Body),
+	)
+}
+
+func (tx *asyncTransformer) visitGetAccessorDeclaration(node *ast.GetAccessorDeclaration) *ast.Node {
+	name := tx.Visitor().VisitNode(node.Name())
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	return tx.Factory().UpdateGetAccessorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		name,
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.transformMethodBody(node.Body),
+	)
+}
+
+func (tx *asyncTransformer) visitSetAccessorDeclaration(node *ast.SetAccessorDeclaration) *ast.Node {
+	name := tx.Visitor().VisitNode(node.Name())
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	return tx.Factory().UpdateSetAccessorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		name,
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.transformMethodBody(node.Body),
+	)
+}
+
+func (tx *asyncTransformer) visitConstructorDeclaration(node *ast.ConstructorDeclaration) *ast.Node {
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	return tx.Factory().UpdateConstructorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.transformMethodBody(node.Body),
+	)
+}
+
+func (tx *asyncTransformer) visitFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	if isAsyncFunction(node.AsNode()) {
+		parameters := tx.transformAsyncFunctionParameterList(node.AsNode())
+		return tx.Factory().UpdateFunctionDeclaration(
+			node,
+			tx.visitAsyncModifiers(node.Modifiers()),
+			node.AsteriskToken,
+			node.Name(),
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.transformAsyncFunctionBody(node.AsNode(), parameters),
+		)
+	}
+	return tx.Factory().UpdateFunctionDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.AsteriskToken,
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *asyncTransformer) visitFunctionExpression(node *ast.FunctionExpression) *ast.Node {
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	if isAsyncFunction(node.AsNode()) {
+		parameters := tx.transformAsyncFunctionParameterList(node.AsNode())
+		return tx.Factory().UpdateFunctionExpression(
+			node,
+			tx.visitAsyncModifiers(node.Modifiers()),
+			node.AsteriskToken,
+			node.Name(),
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.transformAsyncFunctionBody(node.AsNode(), parameters),
+		)
+	}
+	return tx.Factory().UpdateFunctionExpression(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.AsteriskToken,
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *asyncTransformer) visitArrowFunction(node *ast.ArrowFunction) *ast.Node {
+	// arrow functions share `this`, `arguments`, and `super` with their container, so only
+	// the async context and parameter names are scoped to the arrow function itself.
+	scope := tx.saveScope()
+	defer tx.restoreScope(scope)
+	tx.inAsyncFunction = isAsyncFunction(node.AsNode())
+	if tx.inAsyncFunction {
+		parameters := tx.transformAsyncFunctionParameterList(node.AsNode())
+		return tx.Factory().UpdateArrowFunction(
+			node,
+			tx.visitAsyncModifiers(node.Modifiers()),
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			node.EqualsGreaterThanToken,
+			tx.transformAsyncFunctionBody(node.AsNode(), parameters),
+		)
+	}
+	return tx.Factory().UpdateArrowFunction(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		node.EqualsGreaterThanToken,
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *asyncTransformer) visitClassLike(node *ast.Node) *ast.Node {
+	scope := tx.saveScope()
+	defer tx.restoreScope(scope)
+	tx.inAsyncFunction = false
+	tx.hasLexicalThis = true
+	tx.superAccess = nil
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *asyncTransformer) visitAsyncModifiers(modifiers *ast.ModifierList) *ast.ModifierList {
+	return tx.Visitor().VisitModifiers(transformers.ExtractModifiers(tx.EmitContext(), modifiers, ^ast.ModifierFlagsAsync))
+}
+
+func (tx *asyncTransformer) isArgumentsReference(node *ast.IdentifierNode) bool {
+	return node.Text() == "arguments" &&
+		!tx.EmitContext().HasAutoGenerateInfo(node) &&
+		tx.parentNode != nil &&
+		transformers.IsIdentifierReference(node, tx.parentNode) &&
+		tx.resolver.GetReferencedValueDeclaration(tx.EmitContext().MostOriginal(node)) == nil
+}
+
+func (tx *asyncTransformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if tx.lexicalArguments != nil && tx.isArgumentsReference(node) {
+		tx.lexicalArguments.used = true
+		name := tx.lexicalArguments.name.Clone(tx.Factory())
+		name.Loc = node.Loc
+		return name
+	}
+	return node
+}
+
+func (tx *asyncTransformer) visitShorthandPropertyAssignment(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	if tx.lexicalArguments != nil && node.ObjectAssignmentInitializer == nil && node.Name().Text() == "arguments" &&
+		tx.resolver.GetReferencedValueDeclaration(tx.EmitContext().MostOriginal(node.Name())) == nil {
+		tx.lexicalArguments.used = true
+		updated := tx.Factory().NewPropertyAssignment(
+			nil, /*modifiers*/
+			node.Name().Clone(tx.Factory()),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			tx.lexicalArguments.name.Clone(tx.Factory()),
+		)
+		tx.EmitContext().SetOriginal(updated, node.AsNode())
+		updated.Loc = node.Loc
+		return updated
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if tx.superAccess != nil && isSuperProperty(node.Expression) {
+		// `super.x(...)` becomes `_super.x.call(this, ...)`
+		grandparentNode := tx.pushNode(node.Expression)
+		target := tx.visitSuperAccess(node.Expression)
+		tx.popNode(grandparentNode)
+		updated := tx.Factory().NewFunctionCallCall(target, tx.Factory().NewThisExpression(), tx.Visitor().VisitNodes(node.Arguments).Nodes)
+		tx.EmitContext().SetOriginal(updated, node.AsNode())
+		updated.Loc = node.Loc
+		return updated
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Rewrites `super.x` to `_super.x` and `super[x]` to `_superIndex(x)` (or `_superIndex(x).value` when some
+// `super` access in the method is an assignment target).
+func (tx *asyncTransformer) visitSuperAccess(node *ast.Node) *ast.Node {
+	return newSuperAccessExpression(tx.EmitContext(), node, tx.superAccess, tx.Visitor())
+}
+
+// Determines whether the body of a method, accessor, or constructor accesses `super` from an async context,
+// either directly in an async method or within an async arrow function.
+func (tx *asyncTransformer) getSuperAccessInfo(node *ast.Node) *superAccessInfo {
+	if node.Body() == nil || node.ModifierFlags()&ast.ModifierFlagsAsync != 0 && node.BodyData().AsteriskToken != nil {
+		// async generators are handled by the ES2018 transform
+		return nil
+	}
+	return collectSuperAccessInfo(tx.EmitContext(), node.Body(), isAsyncFunction(node))
+}
+
+// Visits the body of a non-async method, accessor, or constructor, declaring `_super` if needed.
+func (tx *asyncTransformer) transformMethodBody(body *ast.BlockNode) *ast.BlockNode {
+	updated := tx.EmitContext().VisitFunctionBody(body, tx.Visitor())
+	if updated == nil || tx.superAccess == nil {
+		return updated
+	}
+	if len(tx.superAccess.names) > 0 {
+		prologue, rest := tx.Factory().SplitStandardPrologue(updated.AsBlock().Statements.Nodes)
+		statements := make([]*ast.Statement, 0, len(prologue)+len(rest)+1)
+		statements = append(statements, prologue...)
+		statements = append(statements, newSuperAccessVariableStatement(tx.Factory(), tx.superAccess))
+		statements = append(statements, rest...)
+		statementList := tx.Factory().NewNodeList(statements)
+		statementList.Loc = updated.AsBlock().Statements.Loc
+		updated = tx.Factory().UpdateBlock(updated.AsBlock(), statementList)
+	}
+	if tx.superAccess.hasElementAccess {
+		tx.Factory().AddAsyncSuperHelper(updated, tx.superAccess.hasBinding)
+	}
+	return updated
+}
+
+// Creates the outer parameter list of an async function. Parameters with initializers or binding patterns are
+// evaluated by the inner generator function so that errors they throw reject the returned promise, so the outer
+// function only declares placeholders up to the first initializer or rest parameter to preserve its `length`.
+func (tx *asyncTransformer) transformAsyncFunctionParameterList(node *ast.Node) *ast.ParameterList {
+	parameterList := node.ParameterList()
+	if isSimpleParameterList(parameterList) {
+		return tx.Visitor().VisitNodes(parameterList)
+	}
+	var parameters []*ast.Node
+	for _, parameter := range parameterList.Nodes {
+		if parameter.Initializer() != nil || parameter.AsParameterDeclaration().DotDotDotToken != nil {
+			if ast.IsArrowFunction(node) {
+				// arrow functions have no `arguments`, so capture the remaining arguments in a rest parameter
+				restParameter := tx.Factory().NewParameterDeclaration(
+					nil, /*modifiers*/
+					tx.Factory().NewToken(ast.KindDotDotDotToken),
+					tx.Factory().NewUniqueNameEx("args", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes}),
+					nil, /*questionToken*/
+					nil, /*typeNode*/
+					nil, /*initializer*/
+				)
+				parameters = append(parameters, restParameter)
+			}
+			break
+		}
+		newParameter := tx.Factory().NewParameterDeclaration(
+			nil, /*modifiers*/
+			nil, /*dotDotDotToken*/
+			tx.Factory().NewGeneratedNameForNodeEx(parameter.Name(), printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes}),
+			nil, /*questionToken*/
+			nil, /*typeNode*/
+			nil, /*initializer*/
+		)
+		parameters = append(parameters, newParameter)
+	}
+	newParameterList := tx.Factory().NewNodeList(parameters)
+	newParameterList.Loc = parameterList.Loc
+	return newParameterList
+}
+
+// Transforms the body of an async function into a call to the `__awaiter` helper with a generator function,
+// where each `await` has been replaced with a `yield`.
+func (tx *asyncTransformer) transformAsyncFunctionBody(node *ast.Node, outerParameters *ast.ParameterList) *ast.Node {
+	isArrowFunction := ast.IsArrowFunction(node)
+	parameterList := node.ParameterList()
+	simpleParameterList := isSimpleParameterList(parameterList)
+
+	// an async function's body runs in a generator function with its own `arguments`, so references to the
+	// `arguments` of the containing non-arrow function must be captured in the enclosing scope.
+	captureLexicalArguments := tx.lexicalArguments == nil
+	if captureLexicalArguments {
+		tx.lexicalArguments = &lexicalArgumentsBinding{name: tx.Factory().NewUniqueName("arguments")}
+	}
+
+	var innerParameters *ast.ParameterList
+	if simpleParameterList {
+		tx.EmitContext().StartVariableEnvironment()
+	} else {
+		innerParameters = tx.EmitContext().VisitParameters(parameterList, tx.Visitor())
+	}
+
+	var argumentsExpression *ast.Expression
+	if !simpleParameterList {
+		if isArrowFunction {
+			// forward the placeholders introduced in `transformAsyncFunctionParameterList` to the inner parameters
+			parameterBindings := make([]*ast.Node, 0, len(outerParameters.Nodes))
+			for _, outerParameter := range outerParameters.Nodes {
+				name := outerParameter.Name().Clone(tx.Factory())
+				if outerParameter.AsParameterDeclaration().DotDotDotToken != nil {
+					parameterBindings = append(parameterBindings, tx.Factory().NewSpreadElement(name))
+				} else {
+					parameterBindings = append(parameterBindings, name)
+				}
+			}
+			argumentsExpression = tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(parameterBindings), false /*multiLine*/)
+		} else {
+			argumentsExpression = tx.Factory().NewIdentifier("arguments")
+		}
+	}
+
+	tx.enclosingFunctionParameterNames = &collections.Set[string]{}
+	for _, parameter := range parameterList.Nodes {
+		recordDeclarationName(parameter.Name(), tx.enclosingFunctionParameterNames)
+	}
+
+	hasLexicalThis := tx.hasLexicalThis
+	f := tx.Factory()
+
+	var prologue []*ast.Statement
+	var asyncBodyStatements *ast.StatementList
+	body := node.Body()
+	if ast.IsBlock(body) {
+		statements := body.AsBlock().Statements
+		if !isArrowFunction {
+			var rest []*ast.Statement
+			prologue, rest = f.SplitStandardPrologue(statements.Nodes)
+			statements = f.NewNodeList(rest)
+			statements.Loc = body.AsBlock().Statements.Loc
+		}
+		asyncBodyStatements = tx.asyncBodyVisitor.VisitNodes(statements)
+	} else {
+		returnStatement := f.NewReturnStatement(tx.asyncBodyVisitor.VisitNode(body))
+		returnStatement.Loc = body.Loc
+		asyncBodyStatements = f.NewNodeList([]*ast.Statement{returnStatement})
+		asyncBodyStatements.Loc = body.Loc
+	}
+	asyncBody := f.NewBlock(tx.EmitContext().EndAndMergeVariableEnvironmentList(asyncBodyStatements), true /*multiLine*/)
+	asyncBody.Loc = body.Loc
+
+	// !!! ES5 targets with a return type annotation should pass its entity name as the promise constructor
+	awaiterCall := f.NewAwaiterHelper(hasLexicalThis, argumentsExpression, nil /*promiseConstructor*/, innerParameters, asyncBody)
+
+	var captureArgumentsStatement *ast.Statement
+	if captureLexicalArguments && tx.lexicalArguments.used {
+		captureArgumentsStatement = tx.createCaptureArgumentsStatement()
+	}
+
+	if isArrowFunction {
+		if captureArgumentsStatement == nil {
+			return awaiterCall
+		}
+		returnStatement := f.NewReturnStatement(awaiterCall)
+		returnStatement.Loc = body.Loc
+		block := f.NewBlock(f.NewNodeList([]*ast.Statement{captureArgumentsStatement, returnStatement}), true /*multiLine*/)
+		block.Loc = body.Loc
+		return block
+	}
+
+	statements := make([]*ast.Statement, 0, len(prologue)+3)
+	statements = append(statements, prologue...)
+	if captureArgumentsStatement != nil {
+		statements = append(statements, captureArgumentsStatement)
+	}
+	if tx.superAccess != nil && len(tx.superAccess.names) > 0 {
+		statements = append(statements, newSuperAccessVariableStatement(tx.Factory(), tx.superAccess))
+	}
+	statements = append(statements, f.NewReturnStatement(awaiterCall))
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = body.AsBlock().Statements.Loc
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	block.Loc = body.Loc
+	if tx.superAccess != nil && tx.superAccess.hasElementAccess {
+		f.AddAsyncSuperHelper(block, tx.superAccess.hasBinding)
+	}
+	return block
+}
+
+func (tx *asyncTransformer) createCaptureArgumentsStatement() *ast.Statement {
+	statement := tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(
+			ast.NodeFlagsNone,
+			tx.Factory().NewNodeList([]*ast.Node{
+				tx.Factory().NewVariableDeclaration(tx.lexicalArguments.name, nil, nil, tx.Factory().NewIdentifier("arguments")),
+			}),
+		),
+	)
+	tx.EmitContext().AddEmitFlags(statement, printer.EFCustomPrologue|printer.EFStartOnNewLine)
+	return statement
+}
+
+// Visits a node in the body of an async function. `var` declarations whose names collide with a parameter of
+// the async function are hoisted to the generator function and converted into assignments, since a `var`
+// declared in the generator would otherwise shadow the parameter passed to the outer function.
+func (tx *asyncTransformer) visitAsyncBody(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindVariableStatement,
+		ast.KindForStatement,
+		ast.KindForInStatement,
+		ast.KindForOfStatement,
+		ast.KindCatchClause,
+		ast.KindBlock,
+		ast.KindSwitchStatement,
+		ast.KindCaseBlock,
+		ast.KindCaseClause,
+		ast.KindDefaultClause,
+		ast.KindTryStatement,
+		ast.KindDoStatement,
+		ast.KindWhileStatement,
+		ast.KindIfStatement,
+		ast.KindWithStatement,
+		ast.KindLabeledStatement:
+		grandparentNode := tx.pushNode(node)
+		defer tx.popNode(grandparentNode)
+		switch node.Kind {
+		case ast.KindVariableStatement:
+			return tx.visitVariableStatementInAsyncBody(node.AsVariableStatement())
+		case ast.KindForStatement:
+			return tx.visitForStatementInAsyncBody(node.AsForStatement())
+		case ast.KindForInStatement, ast.KindForOfStatement:
+			return tx.visitForInOrOfStatementInAsyncBody(node.AsForInOrOfStatement())
+		case ast.KindCatchClause:
+			return tx.visitCatchClauseInAsyncBody(node.AsCatchClause())
+		default:
+			return tx.asyncBodyVisitor.VisitEachChild(node)
+		}
+	default:
+		return tx.visit(node)
+	}
+}
+
+func (tx *asyncTransformer) visitVariableStatementInAsyncBody(node *ast.VariableStatement) *ast.Node {
+	if tx.isVariableDeclarationListWithCollidingName(node.DeclarationList) {
+		expression := tx.visitVariableDeclarationListWithCollidingNames(node.DeclarationList.AsVariableDeclarationList(), false /*hasReceiver*/)
+		if expression == nil {
+			return nil
+		}
+		statement := tx.Factory().NewExpressionStatement(expression)
+		tx.EmitContext().SetOriginal(statement, node.AsNode())
+		statement.Loc = node.Loc
+		return statement
+	}
+	return tx.asyncBodyVisitor.VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) visitForStatementInAsyncBody(node *ast.ForStatement) *ast.Node {
+	initializer := node.Initializer
+	if tx.isVariableDeclarationListWithCollidingName(initializer) {
+		initializer = tx.visitVariableDeclarationListWithCollidingNames(initializer.AsVariableDeclarationList(), false /*hasReceiver*/)
+	} else {
+		initializer = tx.asyncBodyVisitor.VisitNode(initializer)
+	}
+	return tx.Factory().UpdateForStatement(
+		node,
+		initializer,
+		tx.Visitor().VisitNode(node.Condition),
+		tx.Visitor().VisitNode(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.asyncBodyVisitor),
+	)
+}
+
+func (tx *asyncTransformer) visitForInOrOfStatementInAsyncBody(node *ast.ForInOrOfStatement) *ast.Node {
+	initializer := node.Initializer
+	if tx.isVariableDeclarationListWithCollidingName(initializer) {
+		initializer = tx.visitVariableDeclarationListWithCollidingNames(initializer.AsVariableDeclarationList(), true /*hasReceiver*/)
+	} else {
+		initializer = tx.asyncBodyVisitor.VisitNode(initializer)
+	}
+	return tx.Factory().UpdateForInOrOfStatement(
+		node,
+		node.AwaitModifier,
+		initializer,
+		tx.Visitor().VisitNode(node.Expression),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.asyncBodyVisitor),
+	)
+}
+
+func (tx *asyncTransformer) visitCatchClauseInAsyncBody(node *ast.CatchClause) *ast.Node {
+	catchClauseNames := &collections.Set[string]{}
+	if node.VariableDeclaration != nil {
+		recordDeclarationName(node.VariableDeclaration.Name(), catchClauseNames)
+	}
+
+	// names declared in a catch variable are block scoped, so a `var` of the same name in the catch
+	// block refers to the catch variable rather than to a parameter
+	savedEnclosingFunctionParameterNames := tx.enclosingFunctionParameterNames
+	for name := range catchClauseNames.Keys() {
+		if savedEnclosingFunctionParameterNames.Has(name) {
+			if tx.enclosingFunctionParameterNames == savedEnclosingFunctionParameterNames {
+				tx.enclosingFunctionParameterNames = savedEnclosingFunctionParameterNames.Clone()
+			}
+			tx.enclosingFunctionParameterNames.Delete(name)
+		}
+	}
+	defer func() { tx.enclosingFunctionParameterNames = savedEnclosingFunctionParameterNames }()
+	return tx.asyncBodyVisitor.VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) isVariableDeclarationListWithCollidingName(node *ast.Node) bool {
+	return node != nil &&
+		ast.IsVariableDeclarationList(node) &&
+		node.Flags&ast.NodeFlagsBlockScoped == 0 &&
+		core.Some(node.AsVariableDeclarationList().Declarations.Nodes, func(declaration *ast.Node) bool {
+			return tx.collidesWithParameterName(declaration.Name())
+		})
+}
+
+func (tx *asyncTransformer) collidesWithParameterName(name *ast.Node) bool {
+	if ast.IsIdentifier(name) {
+		return tx.enclosingFunctionParameterNames.Has(name.Text())
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if !ast.IsOmittedExpression(element) && tx.collidesWithParameterName(element.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tx *asyncTransformer) visitVariableDeclarationListWithCollidingNames(node *ast.VariableDeclarationList, hasReceiver bool) *ast.Expression {
+	for _, declaration := range node.Declarations.Nodes {
+		tx.hoistVariable(declaration.Name())
+	}
+
+	var expressions []*ast.Expression
+	for _, declaration := range node.Declarations.Nodes {
+		if declaration.Initializer() == nil {
+			continue
+		}
+		assignment := transformers.ConvertVariableDeclarationToAssignmentExpression(tx.EmitContext(), declaration.AsVariableDeclaration())
+		expressions = append(expressions, tx.asyncBodyVisitor.VisitNode(assignment))
+	}
+	if len(expressions) == 0 {
+		if hasReceiver {
+			return tx.asyncBodyVisitor.VisitNode(convertBindingNameToAssignmentTarget(tx.EmitContext(), node.Declarations.Nodes[0].Name()))
+		}
+		return nil
+	}
+	return tx.Factory().InlineExpressions(expressions)
+}
+
+func (tx *asyncTransformer) hoistVariable(name *ast.Node) {
+	if ast.IsIdentifier(name) {
+		tx.EmitContext().AddVariableDeclaration(name.Clone(tx.Factory()))
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if !ast.IsOmittedExpression(element) {
+			tx.hoistVariable(element.Name())
+		}
+	}
+}
+
+func convertBindingNameToAssignmentTarget(emitContext *printer.EmitContext, name *ast.Node) *ast.Expression {
+	if ast.IsBindingPattern(name) {
+		return transformers.ConvertBindingPatternToAssignmentPattern(emitContext, name.AsBindingPattern())
+	}
+	return name.Clone(emitContext.Factory)
+}
+
+func recordDeclarationName(name *ast.Node, names *collections.Set[string]) {
+	if ast.IsIdentifier(name) {
+		names.Add(name.Text())
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if !ast.IsOmittedExpression(element) {
+			recordDeclarationName(element.Name(), names)
+		}
+	}
+}
+
+// Rewrites `super.x` to `_super.x` and `super[x]` to `_superIndex(x)` (or `_superIndex(x).value` when some
+// `super` access in the method is an assignment target).
+func newSuperAccessExpression(emitContext *printer.EmitContext, node *ast.Node, superAccess *superAccessInfo, visitor *ast.NodeVisitor) *ast.Node {
+	var updated *ast.Node
+	if ast.IsPropertyAccessExpression(node) {
+		name := node.Name()
+		if !core.Some(superAccess.names, func(n string) bool { return n == name.Text() }) {
+			superAccess.names = append(superAccess.names, name.Text())
+		}
+		updated = emitContext.Factory.NewPropertyAccessExpression(
+			newSuperName(emitContext.Factory),
+			nil, /*questionDotToken*/
+			name,
+			ast.NodeFlagsNone,
+		)
+	} else {
+		superAccess.hasElementAccess = true
+		updated = emitContext.Factory.NewAsyncSuperIndexHelper(
+			visitor.VisitNode(node.AsElementAccessExpression().ArgumentExpression),
+			superAccess.hasBinding,
+		)
+	}
+	emitContext.SetOriginal(updated, node)
+	updated.Loc = node.Loc
+	return updated
+}
+
+func newSuperName(f *printer.NodeFactory) *ast.IdentifierNode {
+	return f.NewUniqueNameEx("_super", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+}
+
+// Collects the `super` property and element accesses within a function body that occur in an async context.
+// Accesses in nested non-arrow functions and classes are excluded, as they have their own `super`.
+func collectSuperAccessInfo(emitContext *printer.EmitContext, body *ast.Node, inAsync bool) *superAccessInfo {
+	var info *superAccessInfo
+	var visit func(node *ast.Node, inAsync bool)
+	visit = func(node *ast.Node, inAsync bool) {
+		node.ForEachChild(func(child *ast.Node) bool {
+			switch child.Kind {
+			case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindMethodDeclaration,
+				ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor,
+				ast.KindClassDeclaration, ast.KindClassExpression:
+				return false
+			case ast.KindArrowFunction:
+				visit(child, inAsync || isAsyncFunction(child))
+				return false
+			case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+				if inAsync && isSuperProperty(child) {
+					if info == nil {
+						info = &superAccessInfo{}
+					}
+					if original := emitContext.MostOriginal(child); original.Parent == nil || ast.IsAssignmentTarget(original) {
+						info.hasBinding = true
+					}
+				}
+			}
+			visit(child, inAsync)
+			return false
+		})
+	}
+	visit(body, inAsync)
+	return info
+}
+
+// Creates the `_super` variable that provides access to the `super` properties of a method:
+//
+//	const _super = Object.create(null, {
+//	    x: { get: () => super.x, set: v => super.x = v }
+//	});
+func newSuperAccessVariableStatement(f *printer.NodeFactory, superAccess *superAccessInfo) *ast.Statement {
+	accessors := make([]*ast.Node, 0, len(superAccess.names))
+	for _, name := range superAccess.names {
+		getterAndSetter := []*ast.Node{
+			f.NewPropertyAssignment(
+				nil, /*modifiers*/
+				f.NewIdentifier("get"),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				f.NewArrowFunction(
+					nil, /*modifiers*/
+					nil, /*typeParameters*/
+					f.NewNodeList([]*ast.Node{}),
+					nil, /*returnType*/
+					nil, /*fullSignature*/
+					f.NewToken(ast.KindEqualsGreaterThanToken),
+					f.NewPropertyAccessExpression(f.NewKeywordExpression(ast.KindSuperKeyword), nil /*questionDotToken*/, f.NewIdentifier(name), ast.NodeFlagsNone),
+				),
+			),
+		}
+		if superAccess.hasBinding {
+			getterAndSetter = append(getterAndSetter, f.NewPropertyAssignment(
+				nil, /*modifiers*/
+				f.NewIdentifier("set"),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				f.NewArrowFunction(
+					nil, /*modifiers*/
+					nil, /*typeParameters*/
+					f.NewNodeList([]*ast.Node{f.NewParameterDeclaration(nil, nil, f.NewIdentifier("v"), nil, nil, nil)}),
+					nil, /*returnType*/
+					nil, /*fullSignature*/
+					f.NewToken(ast.KindEqualsGreaterThanToken),
+					f.NewAssignmentExpression(
+						f.NewPropertyAccessExpression(f.NewKeywordExpression(ast.KindSuperKeyword), nil /*questionDotToken*/, f.NewIdentifier(name), ast.NodeFlagsNone),
+						f.NewIdentifier("v"),
+					),
+				),
+			))
+		}
+		accessors = append(accessors, f.NewPropertyAssignment(
+			nil, /*modifiers*/
+			f.NewIdentifier(name),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			f.NewObjectLiteralExpression(f.NewNodeList(getterAndSetter), false /*multiLine*/),
+		))
+	}
+	return f.NewVariableStatement(
+		nil, /*modifiers*/
+		f.NewVariableDeclarationList(
+			ast.NodeFlagsConst,
+			f.NewNodeList([]*ast.Node{
+				f.NewVariableDeclaration(
+					newSuperName(f),
+					nil, /*exclamationToken*/
+					nil, /*typeNode*/
+					f.NewCallExpression(
+						f.NewPropertyAccessExpression(f.NewIdentifier("Object"), nil /*questionDotToken*/, f.NewIdentifier("create"), ast.NodeFlagsNone),
+						nil, /*questionDotToken*/
+						nil, /*typeArguments*/
+						f.NewNodeList([]*ast.Node{
+							f.NewKeywordExpression(ast.KindNullKeyword),
+							f.NewObjectLiteralExpression(f.NewNodeList(accessors), true /*multiLine*/),
+						}),
+						ast.NodeFlagsNone,
+					),
+				),
+			}),
+		),
+	)
+}
+
+func isAsyncFunction(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindMethodDeclaration, ast.KindArrowFunction:
+		return node.ModifierFlags()&ast.ModifierFlagsAsync != 0 && node.BodyData().AsteriskToken == nil && node.Body() != nil
+	}
+	return false
+}
+
+func isSuperProperty(node *ast.Node) bool {
+	return (ast.IsPropertyAccessExpression(node) || ast.IsElementAccessExpression(node)) && node.Expression().Kind == ast.KindSuperKeyword
+}
+
+func isSimpleParameterList(parameters *ast.ParameterList) bool {
+	return core.Every(parameters.Nodes, func(parameter *ast.Node) bool {
+		return parameter.Initializer() == nil && ast.IsIdentifier(parameter.Name())
+	})
+}
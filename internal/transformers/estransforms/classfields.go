@@ -1,19 +1,1268 @@
 package estransforms
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
+type privateIdentifierKind int
+
+const (
+	privateIdentifierKindField privateIdentifierKind = iota
+	privateIdentifierKindMethod
+	privateIdentifierKindAccessor
+)
+
+// Describes how a private class element is accessed once private names are lowered.
+type privateIdentifierInfo struct {
+	kind     privateIdentifierKind
+	isStatic bool
+
+	// The object used to check whether a receiver has the private element. This is the `WeakMap` for an instance
+	// field, the `WeakSet` for instance methods and accessors, or an alias for the class constructor for a static
+	// element.
+	brandCheckIdentifier *ast.IdentifierNode
+
+	variableName *ast.IdentifierNode // the `{ value }` box of a static field, or the function for a method
+	getterName   *ast.IdentifierNode // the function for a `get` accessor
+	setterName   *ast.IdentifierNode // the function for a `set` accessor
+}
+
+// Tracks the private names declared by a class whose private elements are being lowered.
+type privateEnvironment struct {
+	previous       *privateEnvironment
+	classPrefix    string              // prefix for hoisted variables, such as `_C_` for a class named `C`
+	classThis      *ast.IdentifierNode // alias for the class constructor, assigned in a leading `static {}` block
+	weakSetName    *ast.IdentifierNode // `WeakSet` used to brand instances of a class with private methods or accessors
+	initializers   []*ast.Expression   // creates each `WeakMap` and `WeakSet`, and assigns each private method
+	names          map[string]*privateIdentifierInfo
+	generatedNames map[printer.AutoGenerateId]*privateIdentifierInfo
+}
+
+func (env *privateEnvironment) lookup(emitContext *printer.EmitContext, name *ast.PrivateIdentifierNode) *privateIdentifierInfo {
+	if autoGenerate := emitContext.GetAutoGenerateInfo(name); autoGenerate != nil {
+		return env.generatedNames[autoGenerate.Id]
+	}
+	return env.names[name.Text()]
+}
+
+func (env *privateEnvironment) set(emitContext *printer.EmitContext, name *ast.PrivateIdentifierNode, info *privateIdentifierInfo) {
+	if autoGenerate := emitContext.GetAutoGenerateInfo(name); autoGenerate != nil {
+		if env.generatedNames == nil {
+			env.generatedNames = make(map[printer.AutoGenerateId]*privateIdentifierInfo)
+		}
+		env.generatedNames[autoGenerate.Id] = info
+		return
+	}
+	if env.names == nil {
+		env.names = make(map[string]*privateIdentifierInfo)
+	}
+	env.names[name.Text()] = info
+}
+
+// Lowers class fields, auto-accessors, and private names. Depending on the target and `useDefineForClassFields`:
+//
+//   - Public field initializers are moved into the constructor (or into `static {}` blocks for static fields) using
+//     either assignment ([[Set]]) or `Object.defineProperty` ([[Define]]) semantics.
+//   - Private fields, methods, and accessors are replaced by `WeakMap`, `WeakSet`, and hoisted functions that are
+//     accessed through the `__classPrivateField*` helpers.
+//   - `accessor` fields are replaced by a private backing field along with a `get` and `set` accessor.
+//
+// Any `static {}` blocks introduced here are moved out of the class by the class static block transform, if needed.
 type classFieldsTransformer struct {
 	transformers.Transformer
-}
 
-func (ch *classFieldsTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	shouldTransformInitializersUsingSet    bool
+	shouldTransformInitializersUsingDefine bool
+	shouldTransformInitializers            bool
+	shouldTransformPrivateElements         bool
+	shouldTransformAutoAccessors           bool
+
+	privateEnvironment *privateEnvironment
+	pendingExpressions []*ast.Expression // hoisted computed property names that have yet to be evaluated
+	classElementName   *ast.Node         // the computed property name that will receive any pending expressions
 }
 
 func newClassFieldsTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	options := opts.CompilerOptions
+	languageVersion := options.GetEmitScriptTarget()
+	useDefineForClassFields := options.GetUseDefineForClassFields()
 	tx := &classFieldsTransformer{}
+	tx.shouldTransformInitializersUsingSet = !useDefineForClassFields
+	tx.shouldTransformInitializersUsingDefine = useDefineForClassFields && languageVersion < core.ScriptTargetES2022
+	tx.shouldTransformInitializers = tx.shouldTransformInitializersUsingSet || tx.shouldTransformInitializersUsingDefine
+	tx.shouldTransformPrivateElements = languageVersion < core.ScriptTargetES2022
+	tx.shouldTransformAutoAccessors = languageVersion < core.ScriptTargetESNext
 	return tx.NewTransformer(tx.visit, opts.Context)
 }
+
+func (tx *classFieldsTransformer) visit(node *ast.Node) *ast.Node {
+	if node == tx.classElementName {
+		return tx.visitClassElementName(node.AsComputedPropertyName())
+	}
+	if node.SubtreeFacts()&ast.SubtreeContainsClassFields == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindExpressionStatement:
+		return tx.Factory().UpdateExpressionStatement(node.AsExpressionStatement(), tx.visitDiscardedValue(node.Expression()))
+	case ast.KindForStatement:
+		return tx.visitForStatement(node.AsForStatement())
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression(), false /*discarded*/)
+	case ast.KindPrefixUnaryExpression, ast.KindPostfixUnaryExpression:
+		return tx.visitPreOrPostfixUnaryExpression(node, false /*discarded*/)
+	case ast.KindPropertyAccessExpression:
+		return tx.visitPropertyAccessExpression(node.AsPropertyAccessExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindTaggedTemplateExpression:
+		return tx.visitTaggedTemplateExpression(node.AsTaggedTemplateExpression())
+	case ast.KindVariableDeclaration,
+		ast.KindParameter,
+		ast.KindBindingElement,
+		ast.KindPropertyAssignment,
+		ast.KindShorthandPropertyAssignment,
+		ast.KindExportAssignment:
+		return tx.visitNamedEvaluationSource(node)
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *classFieldsTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited.AsNode(), tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Visits an expression whose result is not observed, such as the expression of an `ExpressionStatement`.
+func (tx *classFieldsTransformer) visitDiscardedValue(node *ast.Expression) *ast.Expression {
+	if node == nil || node.SubtreeFacts()&ast.SubtreeContainsClassFields == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindPrefixUnaryExpression, ast.KindPostfixUnaryExpression:
+		return tx.visitPreOrPostfixUnaryExpression(node, true /*discarded*/)
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression(), true /*discarded*/)
+	case ast.KindParenthesizedExpression:
+		return tx.Factory().UpdateParenthesizedExpression(node.AsParenthesizedExpression(), tx.visitDiscardedValue(node.Expression()))
+	default:
+		return tx.Visitor().VisitNode(node)
+	}
+}
+
+func (tx *classFieldsTransformer) visitForStatement(node *ast.ForStatement) *ast.Node {
+	initializer := node.Initializer
+	if initializer != nil && ast.IsVariableDeclarationList(initializer) {
+		initializer = tx.Visitor().VisitNode(initializer)
+	} else {
+		initializer = tx.visitDiscardedValue(initializer)
+	}
+	return tx.Factory().UpdateForStatement(
+		node,
+		initializer,
+		tx.Visitor().VisitNode(node.Condition),
+		tx.visitDiscardedValue(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.Visitor()),
+	)
+}
+
+func (tx *classFieldsTransformer) visitNamedEvaluationSource(node *ast.Node) *ast.Node {
+	if tx.shouldTransformPrivateElements && isNamedEvaluationAnd(tx.EmitContext(), node, tx.isAnonymousClassNeedingAssignedName) {
+		node = transformNamedEvaluation(tx.EmitContext(), node, false /*ignoreEmptyStringLiteral*/, "" /*assignedName*/)
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// Gets whether a node is an anonymous class expression that would lose its inferred name once its static elements
+// are moved out of the class body.
+func (tx *classFieldsTransformer) isAnonymousClassNeedingAssignedName(node *ast.Node) bool {
+	if !ast.IsClassExpression(node) || node.Name() != nil {
+		return false
+	}
+	return core.Some(node.Members(), func(member *ast.Node) bool {
+		return ast.IsClassStaticBlockDeclaration(member) ||
+			ast.IsPrivateIdentifierClassElementDeclaration(member) ||
+			ast.IsPropertyDeclaration(member) && (ast.HasStaticModifier(member) || ast.IsComputedPropertyName(member.Name()))
+	})
+}
+
+//
+// Classes
+//
+
+func (tx *classFieldsTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if !tx.classNeedsTransform(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	updated, prologue := tx.transformClassLike(node.AsNode())
+	if prologue != nil {
+		statement := tx.Factory().NewExpressionStatement(prologue)
+		return tx.Factory().NewSyntaxList([]*ast.Node{statement, updated})
+	}
+	return updated
+}
+
+func (tx *classFieldsTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	if !tx.classNeedsTransform(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	updated, prologue := tx.transformClassLike(node.AsNode())
+	if prologue != nil {
+		return tx.Factory().NewCommaExpression(prologue, updated)
+	}
+	return updated
+}
+
+func (tx *classFieldsTransformer) classNeedsTransform(node *ast.Node) bool {
+	for _, member := range node.Members() {
+		switch member.Kind {
+		case ast.KindPropertyDeclaration:
+			if ast.IsAutoAccessorPropertyDeclaration(member) {
+				if tx.shouldTransformAutoAccessors {
+					return true
+				}
+			} else if ast.IsPrivateIdentifier(member.Name()) {
+				if tx.shouldTransformPrivateElements {
+					return true
+				}
+			} else if tx.shouldTransformInitializers {
+				return true
+			}
+		case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+			if tx.shouldTransformPrivateElements && ast.IsPrivateIdentifier(member.Name()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Transforms the members of a class. Returns the updated class along with an optional expression that must be
+// evaluated before the class is defined.
+func (tx *classFieldsTransformer) transformClassLike(node *ast.Node) (*ast.Node, *ast.Expression) {
+	savedPrivateEnvironment := tx.privateEnvironment
+	savedPendingExpressions := tx.pendingExpressions
+	savedClassElementName := tx.classElementName
+	tx.pendingExpressions = nil
+	tx.classElementName = nil
+	defer func() {
+		tx.privateEnvironment = savedPrivateEnvironment
+		tx.pendingExpressions = savedPendingExpressions
+		tx.classElementName = savedClassElementName
+	}()
+
+	// heritage clauses are evaluated outside of the class body, so they cannot observe its private names
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	heritageClauses := tx.Visitor().VisitNodes(node.ClassLikeData().HeritageClauses)
+
+	if tx.shouldTransformPrivateElements {
+		tx.privateEnvironment = tx.newPrivateEnvironment(node, savedPrivateEnvironment)
+	}
+
+	members := node.Members()
+	if tx.shouldTransformAutoAccessors {
+		members = tx.transformAutoAccessors(node, members)
+	}
+	if tx.privateEnvironment != nil {
+		tx.addPrivateIdentifiersToEnvironment(members)
+	}
+
+	memberList, prologue := tx.transformClassMembers(node, members)
+	if ast.IsClassDeclaration(node) {
+		return tx.Factory().UpdateClassDeclaration(node.AsClassDeclaration(), modifiers, node.Name(), nil /*typeParameters*/, heritageClauses, memberList), prologue
+	}
+	return tx.Factory().UpdateClassExpression(node.AsClassExpression(), modifiers, node.Name(), nil /*typeParameters*/, heritageClauses, memberList), prologue
+}
+
+func (tx *classFieldsTransformer) newPrivateEnvironment(node *ast.Node, previous *privateEnvironment) *privateEnvironment {
+	env := &privateEnvironment{previous: previous, classPrefix: "_"}
+	if name := node.Name(); name != nil && ast.IsIdentifier(name) && !tx.EmitContext().HasAutoGenerateInfo(name) {
+		env.classPrefix = "_" + name.Text() + "_"
+	}
+	for _, member := range node.Members() {
+		if isClassThisAssignmentBlock(tx.EmitContext(), member) {
+			// reuse the alias assigned by an earlier transform, such as for a class with class decorators
+			env.classThis = tx.EmitContext().ClassThis(member)
+			break
+		}
+	}
+	return env
+}
+
+// Gets the alias used to refer to the class constructor from within the class body.
+func (tx *classFieldsTransformer) getClassThis(env *privateEnvironment) *ast.IdentifierNode {
+	if env.classThis == nil {
+		env.classThis = tx.Factory().NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		tx.EmitContext().AddVariableDeclaration(env.classThis)
+	}
+	return env.classThis
+}
+
+// Gets the object used to brand instances (or the class itself) for private methods and accessors.
+func (tx *classFieldsTransformer) getPrivateBrand(env *privateEnvironment, isStatic bool) *ast.IdentifierNode {
+	if isStatic {
+		return tx.getClassThis(env)
+	}
+	if env.weakSetName == nil {
+		f := tx.Factory()
+		env.weakSetName = f.NewUniqueNameEx("instances", printer.AutoGenerateOptions{
+			Flags:  printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes,
+			Prefix: env.classPrefix,
+		})
+		tx.EmitContext().AddVariableDeclaration(env.weakSetName)
+		env.initializers = append(env.initializers, f.NewAssignmentExpression(env.weakSetName, tx.newWeakCollection("WeakSet")))
+	}
+	return env.weakSetName
+}
+
+func (tx *classFieldsTransformer) newWeakCollection(name string) *ast.Expression {
+	f := tx.Factory()
+	return f.NewNewExpression(f.NewIdentifier(name), nil /*typeArguments*/, f.NewNodeList([]*ast.Node{}))
+}
+
+// Creates a hoisted variable for a private name, such as `_C_x` for `#x` in class `C`.
+func (tx *classFieldsTransformer) createHoistedVariableForPrivateName(env *privateEnvironment, name *ast.PrivateIdentifierNode, suffix string) *ast.IdentifierNode {
+	f := tx.Factory()
+	text := name.Text()
+	if autoGenerate := tx.EmitContext().GetAutoGenerateInfo(name); autoGenerate != nil {
+		if autoGenerate.Node == nil || !(ast.IsIdentifier(autoGenerate.Node) || ast.IsPrivateIdentifier(autoGenerate.Node)) {
+			variable := f.NewTempVariableEx(printer.AutoGenerateOptions{
+				Flags:  printer.GeneratedIdentifierFlagsReservedInNestedScopes,
+				Prefix: env.classPrefix + autoGenerate.Prefix,
+				Suffix: autoGenerate.Suffix + suffix,
+			})
+			tx.EmitContext().AddVariableDeclaration(variable)
+			return variable
+		}
+		text = autoGenerate.Prefix + strings.TrimPrefix(autoGenerate.Node.Text(), "#") + autoGenerate.Suffix
+	}
+	variable := f.NewUniqueNameEx(strings.TrimPrefix(text, "#")+suffix, printer.AutoGenerateOptions{
+		Flags:  printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes,
+		Prefix: env.classPrefix,
+	})
+	tx.EmitContext().AddVariableDeclaration(variable)
+	return variable
+}
+
+func (tx *classFieldsTransformer) addPrivateIdentifiersToEnvironment(members []*ast.Node) {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	env := tx.privateEnvironment
+	for _, member := range members {
+		if !ast.IsPrivateIdentifierClassElementDeclaration(member) {
+			continue
+		}
+		name := member.Name()
+		isStatic := ast.HasStaticModifier(member)
+		switch member.Kind {
+		case ast.KindPropertyDeclaration:
+			info := &privateIdentifierInfo{kind: privateIdentifierKindField, isStatic: isStatic}
+			if isStatic {
+				info.brandCheckIdentifier = tx.getClassThis(env)
+				info.variableName = tx.createHoistedVariableForPrivateName(env, name, "")
+			} else {
+				info.brandCheckIdentifier = tx.createHoistedVariableForPrivateName(env, name, "")
+				env.initializers = append(env.initializers, f.NewAssignmentExpression(info.brandCheckIdentifier, tx.newWeakCollection("WeakMap")))
+			}
+			env.set(ctx, name, info)
+		case ast.KindMethodDeclaration:
+			info := &privateIdentifierInfo{kind: privateIdentifierKindMethod, isStatic: isStatic}
+			info.brandCheckIdentifier = tx.getPrivateBrand(env, isStatic)
+			info.variableName = tx.createHoistedVariableForPrivateName(env, name, "")
+			env.set(ctx, name, info)
+		case ast.KindGetAccessor, ast.KindSetAccessor:
+			info := env.lookup(ctx, name)
+			if info == nil || info.kind != privateIdentifierKindAccessor || info.isStatic != isStatic {
+				info = &privateIdentifierInfo{kind: privateIdentifierKindAccessor, isStatic: isStatic}
+				info.brandCheckIdentifier = tx.getPrivateBrand(env, isStatic)
+				env.set(ctx, name, info)
+			}
+			if member.Kind == ast.KindGetAccessor {
+				info.getterName = tx.createHoistedVariableForPrivateName(env, name, "_get")
+			} else {
+				info.setterName = tx.createHoistedVariableForPrivateName(env, name, "_set")
+			}
+		}
+	}
+}
+
+func (tx *classFieldsTransformer) getPrivateIdentifierInfo(name *ast.PrivateIdentifierNode) *privateIdentifierInfo {
+	for env := tx.privateEnvironment; env != nil; env = env.previous {
+		if info := env.lookup(tx.EmitContext(), name); info != nil {
+			return info
+		}
+	}
+	return nil
+}
+
+// Replaces each `accessor` field with a private backing field along with a `get` and `set` accessor.
+func (tx *classFieldsTransformer) transformAutoAccessors(node *ast.Node, members []*ast.Node) []*ast.Node {
+	var result []*ast.Node
+	for i, member := range members {
+		if !ast.IsAutoAccessorPropertyDeclaration(member) {
+			if result != nil {
+				result = append(result, member)
+			}
+			continue
+		}
+		if result == nil {
+			result = slices.Clone(members[:i])
+		}
+		result = append(result, tx.transformAutoAccessor(node, member)...)
+	}
+	if result == nil {
+		return members
+	}
+	return result
+}
+
+// Transforms this:
+//
+//	accessor x = 1;
+//
+// Into this:
+//
+//	#x_accessor_storage = 1;
+//	get x() { return this.#x_accessor_storage; }
+//	set x(value) { this.#x_accessor_storage = value; }
+func (tx *classFieldsTransformer) transformAutoAccessor(classNode *ast.Node, member *ast.Node) []*ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	name := member.Name()
+	getterName := name
+	setterName := name.Clone(f)
+	if ast.IsComputedPropertyName(name) && !transformers.IsSimpleInlineableExpression(name.Expression()) {
+		// evaluate the key once and reuse it for the `set` accessor
+		temp := f.NewGeneratedNameForNode(name)
+		ctx.AddVariableDeclaration(temp)
+		getterName = f.UpdateComputedPropertyName(name.AsComputedPropertyName(), f.NewAssignmentExpression(temp, name.Expression()))
+		setterName = f.NewComputedPropertyName(temp)
+	}
+
+	isStatic := ast.HasStaticModifier(member)
+	storageName := f.NewGeneratedPrivateNameForNodeEx(name, printer.AutoGenerateOptions{Suffix: "_accessor_storage"})
+	storage := f.NewPropertyDeclaration(
+		transformers.ExtractModifiers(ctx, member.Modifiers(), ast.ModifierFlagsStatic),
+		storageName,
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		member.Initializer(),
+	)
+	ctx.SetOriginal(storage, member)
+
+	receiver := func() *ast.Expression {
+		switch {
+		case !isStatic:
+			return f.NewThisExpression()
+		case tx.privateEnvironment != nil:
+			return tx.getClassThis(tx.privateEnvironment)
+		case classNode.Name() != nil:
+			return f.GetDeclarationName(classNode)
+		default:
+			return f.NewThisExpression()
+		}
+	}
+
+	getter := f.NewGetAccessorDeclaration(
+		transformers.ExtractModifiers(ctx, member.Modifiers(), ^ast.ModifierFlagsAccessor),
+		getterName,
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList([]*ast.Node{
+			f.NewReturnStatement(f.NewPropertyAccessExpression(receiver(), nil /*questionDotToken*/, storageName.Clone(f), ast.NodeFlagsNone)),
+		}), false /*multiLine*/),
+	)
+	ctx.SetOriginal(getter, member)
+	ctx.AssignCommentAndSourceMapRanges(getter, member)
+
+	value := f.NewIdentifier("value")
+	setter := f.NewSetAccessorDeclaration(
+		transformers.ExtractModifiers(ctx, member.Modifiers(), ^ast.ModifierFlagsAccessor),
+		setterName,
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{f.NewParameterDeclaration(nil, nil, value, nil, nil, nil)}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList([]*ast.Node{
+			f.NewExpressionStatement(f.NewAssignmentExpression(
+				f.NewPropertyAccessExpression(receiver(), nil /*questionDotToken*/, storageName.Clone(f), ast.NodeFlagsNone),
+				value.Clone(f),
+			)),
+		}), false /*multiLine*/),
+	)
+	ctx.SetOriginal(setter, member)
+
+	return []*ast.Node{storage, getter, setter}
+}
+
+func (tx *classFieldsTransformer) transformClassMembers(node *ast.Node, members []*ast.Node) (*ast.NodeList, *ast.Expression) {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	env := tx.privateEnvironment
+
+	var instanceInitializers []*ast.Statement
+	if env != nil && env.weakSetName != nil {
+		// _C_instances.add(this);
+		instanceInitializers = append(instanceInitializers, f.NewExpressionStatement(
+			f.NewMethodCall(env.weakSetName, f.NewIdentifier("add"), []*ast.Node{f.NewThisExpression()}),
+		))
+	}
+
+	var constructor *ast.Node
+	constructorIndex := -1
+	hasParameterProperties := false
+	var leadingMembers []*ast.Node
+	var newMembers []*ast.Node
+	for _, member := range members {
+		switch {
+		case isClassThisAssignmentBlock(ctx, member) || isClassNamedEvaluationHelperBlock(ctx, member):
+			// these blocks must remain at the start of the class
+			leadingMembers = append(leadingMembers, member)
+		case ast.IsConstructorDeclaration(member):
+			constructor = member
+			constructorIndex = len(newMembers)
+			newMembers = append(newMembers, member)
+		case ast.IsPropertyDeclaration(member):
+			if ast.IsParameter(ctx.MostOriginal(member)) {
+				hasParameterProperties = true
+			}
+			if updated := tx.transformPropertyDeclaration(node, member, &instanceInitializers); updated != nil {
+				newMembers = append(newMembers, updated)
+			}
+		case env != nil && ast.IsMethodOrAccessor(member) && ast.IsPrivateIdentifier(member.Name()):
+			tx.transformPrivateMethodOrAccessor(member)
+		default:
+			if updated := tx.visitClassElement(member); updated != nil {
+				newMembers = append(newMembers, updated)
+			}
+		}
+	}
+
+	isDerived := isDerivedClass(node)
+	var syntheticConstructor *ast.Node
+	if constructor != nil {
+		removeParameterPropertyAssignments := tx.shouldTransformInitializersUsingDefine && hasParameterProperties
+		newMembers[constructorIndex] = tx.transformConstructor(constructor.AsConstructorDeclaration(), isDerived, instanceInitializers, removeParameterPropertyAssignments)
+	} else if len(instanceInitializers) > 0 {
+		syntheticConstructor = tx.createSyntheticConstructor(isDerived, instanceInitializers)
+	}
+
+	// Any remaining initializers are evaluated once the class is defined.
+	var prologue *ast.Expression
+	var syntheticStaticBlock *ast.Node
+	var initializers []*ast.Expression
+	if env != nil {
+		initializers = slices.Concat(env.initializers, tx.pendingExpressions)
+	} else {
+		initializers = tx.pendingExpressions
+	}
+	tx.pendingExpressions = nil
+	if len(initializers) > 0 {
+		statement := f.NewExpressionStatement(f.InlineExpressions(initializers))
+		if statement.SubtreeFacts()&ast.SubtreeContainsLexicalThisOrSuper != 0 {
+			// A computed property name observes the `this` and `super` of the scope containing the class, so we
+			// capture them in an arrow function that is defined prior to the class:
+			//
+			//  _a = () => { _b = this.x; };
+			//  class C { static { _a(); } }
+			//
+			temp := f.NewTempVariable()
+			ctx.AddVariableDeclaration(temp)
+			prologue = f.NewAssignmentExpression(temp, f.NewArrowFunction(
+				nil, /*modifiers*/
+				nil, /*typeParameters*/
+				f.NewNodeList([]*ast.Node{}),
+				nil, /*returnType*/
+				nil, /*fullSignature*/
+				f.NewToken(ast.KindEqualsGreaterThanToken),
+				f.NewBlock(f.NewNodeList([]*ast.Node{statement}), false /*multiLine*/),
+			))
+			statement = f.NewExpressionStatement(f.NewCallExpression(temp, nil, nil, f.NewNodeList([]*ast.Node{}), ast.NodeFlagsNone))
+		}
+		syntheticStaticBlock = f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList([]*ast.Node{statement}), false /*multiLine*/))
+	}
+
+	result := make([]*ast.Node, 0, len(newMembers)+len(leadingMembers)+3)
+	if env != nil && env.classThis != nil && !core.Some(leadingMembers, func(member *ast.Node) bool { return isClassThisAssignmentBlock(ctx, member) }) {
+		result = append(result, createClassThisAssignmentBlock(ctx, env.classThis))
+	}
+	result = append(result, leadingMembers...)
+	if syntheticConstructor != nil {
+		result = append(result, syntheticConstructor)
+	}
+	if syntheticStaticBlock != nil {
+		result = append(result, syntheticStaticBlock)
+	}
+	result = append(result, newMembers...)
+
+	memberList := f.NewNodeList(result)
+	memberList.Loc = node.MemberList().Loc
+	return memberList, prologue
+}
+
+func isDerivedClass(node *ast.Node) bool {
+	heritage := ast.GetExtendsHeritageClauseElement(node)
+	return heritage != nil && ast.SkipOuterExpressions(heritage.Expression(), ast.OEKAll).Kind != ast.KindNullKeyword
+}
+
+func (tx *classFieldsTransformer) visitClassElement(member *ast.Node) *ast.Node {
+	name := member.Name()
+	if name == nil || !ast.IsComputedPropertyName(name) || len(tx.pendingExpressions) == 0 {
+		return tx.Visitor().VisitNode(member)
+	}
+	// the pending expressions must be evaluated before this member's name
+	savedClassElementName := tx.classElementName
+	tx.classElementName = name
+	updated := tx.Visitor().VisitEachChild(member)
+	tx.classElementName = savedClassElementName
+	return updated
+}
+
+func (tx *classFieldsTransformer) visitClassElementName(node *ast.ComputedPropertyName) *ast.Node {
+	f := tx.Factory()
+	tx.classElementName = nil
+	expression := tx.Visitor().VisitNode(node.Expression)
+	if len(tx.pendingExpressions) > 0 {
+		expression = f.NewParenthesizedExpression(f.InlineExpressions(append(tx.pendingExpressions, expression)))
+		tx.pendingExpressions = nil
+	}
+	return f.UpdateComputedPropertyName(node, expression)
+}
+
+// Transforms a property declaration. Initializers for instance fields are added to `instanceInitializers` and the
+// field is removed. Static fields are replaced with a `static {}` block.
+func (tx *classFieldsTransformer) transformPropertyDeclaration(classNode *ast.Node, member *ast.Node, instanceInitializers *[]*ast.Statement) *ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	if tx.shouldTransformPrivateElements && isNamedEvaluationAnd(ctx, member, tx.isAnonymousClassNeedingAssignedName) {
+		member = transformNamedEvaluation(ctx, member, false /*ignoreEmptyStringLiteral*/, "" /*assignedName*/)
+	}
+
+	name := member.Name()
+	isStatic := ast.HasStaticModifier(member)
+	if ast.IsPrivateIdentifier(name) {
+		info := tx.getPrivateIdentifierInfo(name)
+		if info == nil {
+			return tx.visitClassElement(member)
+		}
+		initializer := tx.visitFieldInitializer(member)
+		if isStatic {
+			// _C_x = { value: initializer };
+			expression := f.NewAssignmentExpression(info.variableName, f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+				f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, initializer),
+			}), false /*multiLine*/))
+			return tx.createFieldInitializerBlock(expression, member)
+		}
+		// _C_x.set(this, initializer);
+		expression := f.NewMethodCall(info.brandCheckIdentifier, f.NewIdentifier("set"), []*ast.Node{f.NewThisExpression(), initializer})
+		*instanceInitializers = append(*instanceInitializers, tx.createFieldInitializerStatement(expression, member))
+		return nil
+	}
+
+	if !tx.shouldTransformInitializers {
+		return tx.visitClassElement(member)
+	}
+
+	var receiver *ast.Expression
+	if isStatic {
+		receiver = tx.getStaticReceiver(classNode)
+	} else {
+		receiver = f.NewThisExpression()
+	}
+	expression := tx.transformPublicFieldInitializer(member, receiver)
+	if expression == nil {
+		return nil
+	}
+	if isStatic {
+		return tx.createFieldInitializerBlock(expression, member)
+	}
+	*instanceInitializers = append(*instanceInitializers, tx.createFieldInitializerStatement(expression, member))
+	return nil
+}
+
+// Finds the assignment of a computed property name to a generated variable by an earlier transform, such as the
+// `_a = __propKey(x)` in `[(_b = [dec], _a = __propKey(x))]`.
+func findComputedPropertyNameCacheAssignment(emitContext *printer.EmitContext, expression *ast.Expression) *ast.Expression {
+	for {
+		expression = ast.SkipOuterExpressions(expression, ast.OEKParentheses|ast.OEKPartiallyEmittedExpressions)
+		if ast.IsBinaryExpression(expression) && expression.AsBinaryExpression().OperatorToken.Kind == ast.KindCommaToken {
+			expression = expression.AsBinaryExpression().Right
+			continue
+		}
+		if ast.IsAssignmentExpression(expression, true /*excludeCompoundAssignment*/) &&
+			ast.IsIdentifier(expression.AsBinaryExpression().Left) &&
+			transformers.IsGeneratedIdentifier(emitContext, expression.AsBinaryExpression().Left) {
+			return expression
+		}
+		return nil
+	}
+}
+
+func (tx *classFieldsTransformer) getStaticReceiver(classNode *ast.Node) *ast.Expression {
+	if tx.shouldTransformPrivateElements && ast.IsClassDeclaration(classNode) && classNode.Name() != nil {
+		// the initializer is moved out of the class body, where `this` no longer refers to the class
+		return tx.Factory().GetDeclarationName(classNode)
+	}
+	return tx.Factory().NewThisExpression()
+}
+
+func (tx *classFieldsTransformer) visitFieldInitializer(member *ast.Node) *ast.Expression {
+	if initializer := member.Initializer(); initializer != nil {
+		return tx.Visitor().VisitNode(initializer)
+	}
+	return tx.Factory().NewVoidZeroExpression()
+}
+
+// Creates the assignment (or `Object.defineProperty` call) that initializes a public field, or returns nil if the
+// field has no initializer and is only a declaration.
+func (tx *classFieldsTransformer) transformPublicFieldInitializer(property *ast.Node, receiver *ast.Expression) *ast.Expression {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	name := property.Name()
+	hasInitializer := property.Initializer() != nil || tx.shouldTransformInitializersUsingDefine
+
+	var key *ast.Expression
+	if ast.IsComputedPropertyName(name) {
+		expression := tx.Visitor().VisitNode(name.Expression())
+		inner := ast.SkipPartiallyEmittedExpressions(expression)
+		switch cacheAssignment := findComputedPropertyNameCacheAssignment(ctx, inner); {
+		case cacheAssignment != nil:
+			// the key was already hoisted by an earlier transform
+			tx.pendingExpressions = append(tx.pendingExpressions, expression)
+			key = cacheAssignment.AsBinaryExpression().Left
+		case transformers.IsSimpleInlineableExpression(inner):
+			key = expression
+		case hasInitializer:
+			temp := f.NewGeneratedNameForNode(name)
+			ctx.AddVariableDeclaration(temp)
+			tx.pendingExpressions = append(tx.pendingExpressions, f.NewAssignmentExpression(temp, expression))
+			key = temp
+		default:
+			// the field is removed, but evaluating its name may still have side effects
+			if !ast.IsIdentifier(inner) {
+				tx.pendingExpressions = append(tx.pendingExpressions, expression)
+			}
+			return nil
+		}
+	}
+	if !hasInitializer {
+		return nil
+	}
+
+	var initializer *ast.Expression
+	if original := ctx.MostOriginal(property); ast.IsParameter(original) {
+		initializer = original.Name().Clone(f)
+		ctx.AddEmitFlags(initializer, printer.EFNoComments)
+	} else {
+		initializer = tx.visitFieldInitializer(property)
+	}
+
+	var expression *ast.Expression
+	if tx.shouldTransformInitializersUsingDefine {
+		var propertyName *ast.Expression
+		switch {
+		case key != nil:
+			propertyName = key
+		case ast.IsIdentifier(name):
+			propertyName = f.NewStringLiteralFromNode(name)
+		default:
+			propertyName = name.Clone(f)
+		}
+		// Object.defineProperty(receiver, "x", { enumerable: true, configurable: true, writable: true, value: initializer })
+		descriptor := f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+			f.NewPropertyAssignment(nil, f.NewIdentifier("enumerable"), nil, nil, f.NewTrueExpression()),
+			f.NewPropertyAssignment(nil, f.NewIdentifier("configurable"), nil, nil, f.NewTrueExpression()),
+			f.NewPropertyAssignment(nil, f.NewIdentifier("writable"), nil, nil, f.NewTrueExpression()),
+			f.NewPropertyAssignment(nil, f.NewIdentifier("value"), nil, nil, initializer),
+		}), true /*multiLine*/)
+		expression = f.NewGlobalMethodCall("Object", "defineProperty", []*ast.Node{receiver, propertyName, descriptor})
+	} else {
+		var target *ast.Expression
+		switch {
+		case key != nil:
+			target = f.NewElementAccessExpression(receiver, nil /*questionDotToken*/, key, ast.NodeFlagsNone)
+		case ast.IsIdentifier(name):
+			target = f.NewPropertyAccessExpression(receiver, nil /*questionDotToken*/, name.Clone(f), ast.NodeFlagsNone)
+		default:
+			target = f.NewElementAccessExpression(receiver, nil /*questionDotToken*/, name.Clone(f), ast.NodeFlagsNone)
+		}
+		expression = f.NewAssignmentExpression(target, initializer)
+	}
+	ctx.SetOriginal(expression, property)
+	return expression
+}
+
+func (tx *classFieldsTransformer) createFieldInitializerStatement(expression *ast.Expression, member *ast.Node) *ast.Statement {
+	ctx := tx.EmitContext()
+	statement := tx.Factory().NewExpressionStatement(expression)
+	ctx.SetOriginal(statement, member)
+	if !ast.IsParameter(ctx.MostOriginal(member)) {
+		ctx.AssignCommentAndSourceMapRanges(statement, member)
+	}
+	return statement
+}
+
+func (tx *classFieldsTransformer) createFieldInitializerBlock(expression *ast.Expression, member *ast.Node) *ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	statement := f.NewExpressionStatement(expression)
+	block := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList([]*ast.Node{statement}), false /*multiLine*/))
+	ctx.SetOriginal(block, member)
+	ctx.AssignCommentAndSourceMapRanges(block, member)
+	return block
+}
+
+// Hoists a private method or accessor into a function that is assigned once the class is defined.
+func (tx *classFieldsTransformer) transformPrivateMethodOrAccessor(member *ast.Node) {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	info := tx.privateEnvironment.lookup(ctx, member.Name())
+	if info == nil || member.Body() == nil {
+		return
+	}
+
+	var functionName *ast.IdentifierNode
+	var asteriskToken *ast.TokenNode
+	switch member.Kind {
+	case ast.KindMethodDeclaration:
+		functionName = info.variableName
+		asteriskToken = member.AsMethodDeclaration().AsteriskToken
+	case ast.KindGetAccessor:
+		functionName = info.getterName
+	case ast.KindSetAccessor:
+		functionName = info.setterName
+	}
+
+	parameters := ctx.VisitParameters(member.ParameterList(), tx.Visitor())
+	body := ctx.VisitFunctionBody(member.Body(), tx.Visitor())
+	fn := f.NewFunctionExpression(
+		transformers.ExtractModifiers(ctx, member.Modifiers(), ast.ModifierFlagsAsync),
+		asteriskToken,
+		functionName.Clone(f),
+		nil, /*typeParameters*/
+		parameters,
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		body,
+	)
+	ctx.SetOriginal(fn, member)
+	ctx.SetSourceMapRange(fn, member.Loc)
+	tx.privateEnvironment.initializers = append(tx.privateEnvironment.initializers, f.NewAssignmentExpression(functionName, fn))
+}
+
+func (tx *classFieldsTransformer) transformConstructor(node *ast.ConstructorDeclaration, isDerived bool, initializers []*ast.Statement, removeParameterPropertyAssignments bool) *ast.Node {
+	if node.Body == nil || len(initializers) == 0 && !removeParameterPropertyAssignments {
+		return tx.Visitor().VisitNode(node.AsNode())
+	}
+
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	parameters := ctx.VisitParameters(node.Parameters, tx.Visitor())
+	body := node.Body.AsBlock()
+	prologue, rest := f.SplitStandardPrologue(body.Statements.Nodes)
+	statements := slices.Clone(prologue)
+	rest = core.FirstResult(tx.Visitor().VisitSlice(rest))
+	if removeParameterPropertyAssignments {
+		// parameter properties are defined along with the other fields
+		rest = slices.DeleteFunc(slices.Clone(rest), tx.isParameterPropertyAssignment)
+	}
+
+	var superPath []int
+	if isDerived {
+		superPath = transformers.FindSuperStatementIndexPath(rest, 0)
+	}
+	statements = append(statements, insertStatementsAfterSuperCall(f, rest, superPath, initializers, tx.isParameterPropertyAssignment)...)
+	statements = ctx.EndAndMergeVariableEnvironment(statements)
+
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = body.Statements.Loc
+	updatedBody := f.NewBlock(statementList, true /*multiLine*/)
+	ctx.SetOriginal(updatedBody, body.AsNode())
+	updatedBody.Loc = body.Loc
+	return f.UpdateConstructorDeclaration(node, node.Modifiers(), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, updatedBody)
+}
+
+func (tx *classFieldsTransformer) isParameterPropertyAssignment(statement *ast.Statement) bool {
+	return ast.IsExpressionStatement(statement) && ast.IsParameter(tx.EmitContext().MostOriginal(statement))
+}
+
+// Creates a constructor for a class that has instance fields but no explicit constructor:
+//
+//	constructor() {
+//	    super(...arguments);
+//	    this.x = 1;
+//	}
+func (tx *classFieldsTransformer) createSyntheticConstructor(isDerived bool, initializers []*ast.Statement) *ast.Node {
+	f := tx.Factory()
+	var statements []*ast.Statement
+	if isDerived {
+		superCall := f.NewCallExpression(
+			f.NewKeywordExpression(ast.KindSuperKeyword),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			f.NewNodeList([]*ast.Node{f.NewSpreadElement(f.NewIdentifier("arguments"))}),
+			ast.NodeFlagsNone,
+		)
+		statements = append(statements, f.NewExpressionStatement(superCall))
+	}
+	statements = append(statements, initializers...)
+	return f.NewConstructorDeclaration(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList(statements), true /*multiLine*/),
+	)
+}
+
+//
+// Private names
+//
+
+func (tx *classFieldsTransformer) lookupPrivateAccess(node *ast.Node) *privateIdentifierInfo {
+	if tx.privateEnvironment == nil || !ast.IsPropertyAccessExpression(node) || !ast.IsPrivateIdentifier(node.Name()) {
+		return nil
+	}
+	return tx.getPrivateIdentifierInfo(node.Name())
+}
+
+func (tx *classFieldsTransformer) createPrivateIdentifierAccess(info *privateIdentifierInfo, receiver *ast.Expression) *ast.Expression {
+	f := tx.Factory()
+	switch info.kind {
+	case privateIdentifierKindMethod:
+		return f.NewClassPrivateFieldGetHelper(receiver, info.brandCheckIdentifier, "m", info.variableName)
+	case privateIdentifierKindAccessor:
+		return f.NewClassPrivateFieldGetHelper(receiver, info.brandCheckIdentifier, "a", info.getterName)
+	default:
+		return f.NewClassPrivateFieldGetHelper(receiver, info.brandCheckIdentifier, "f", info.variableName)
+	}
+}
+
+func (tx *classFieldsTransformer) createPrivateIdentifierAssignment(info *privateIdentifierInfo, receiver *ast.Expression, right *ast.Expression, operator ast.Kind) *ast.Expression {
+	f := tx.Factory()
+	if ast.IsCompoundAssignment(operator) {
+		readExpression, initializeExpression := tx.createCopiableReceiverExpression(receiver)
+		receiver = readExpression
+		if initializeExpression != nil {
+			receiver = initializeExpression
+		}
+		right = f.NewBinaryExpression(
+			nil, /*modifiers*/
+			tx.createPrivateIdentifierAccess(info, readExpression),
+			nil, /*typeNode*/
+			f.NewToken(ast.GetNonAssignmentOperatorForCompoundAssignment(operator)),
+			right,
+		)
+	}
+	switch info.kind {
+	case privateIdentifierKindMethod:
+		return f.NewClassPrivateFieldSetHelper(receiver, info.brandCheckIdentifier, right, "m", nil)
+	case privateIdentifierKindAccessor:
+		return f.NewClassPrivateFieldSetHelper(receiver, info.brandCheckIdentifier, right, "a", info.setterName)
+	default:
+		return f.NewClassPrivateFieldSetHelper(receiver, info.brandCheckIdentifier, right, "f", info.variableName)
+	}
+}
+
+// Gets an expression that can be read more than once, along with an optional assignment that must be evaluated first.
+func (tx *classFieldsTransformer) createCopiableReceiverExpression(receiver *ast.Expression) (*ast.Expression, *ast.Expression) {
+	if transformers.IsSimpleInlineableExpression(receiver) {
+		return receiver, nil
+	}
+	f := tx.Factory()
+	temp := f.NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	return temp, f.NewAssignmentExpression(temp, receiver)
+}
+
+func (tx *classFieldsTransformer) visitPropertyAccessExpression(node *ast.PropertyAccessExpression) *ast.Node {
+	if info := tx.lookupPrivateAccess(node.AsNode()); info != nil {
+		result := tx.createPrivateIdentifierAccess(info, tx.Visitor().VisitNode(node.Expression))
+		tx.EmitContext().SetOriginal(result, node.AsNode())
+		tx.EmitContext().AssignCommentAndSourceMapRanges(result, node.AsNode())
+		return result
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *classFieldsTransformer) visitBinaryExpression(node *ast.BinaryExpression, discarded bool) *ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	switch {
+	case ast.IsDestructuringAssignment(node.AsNode()):
+		if tx.privateEnvironment == nil || node.Left.SubtreeFacts()&ast.SubtreeContainsClassFields == 0 {
+			break
+		}
+		// receivers of private names in the pattern are captured before the assignment
+		savedPendingExpressions := tx.pendingExpressions
+		tx.pendingExpressions = nil
+		var result *ast.Expression = f.UpdateBinaryExpression(
+			node,
+			nil, /*modifiers*/
+			tx.visitDestructuringAssignmentTarget(node.Left),
+			nil, /*typeNode*/
+			node.OperatorToken,
+			tx.Visitor().VisitNode(node.Right),
+		)
+		if len(tx.pendingExpressions) > 0 {
+			result = f.InlineExpressions(append(tx.pendingExpressions, result))
+		}
+		tx.pendingExpressions = savedPendingExpressions
+		return result
+	case ast.IsAssignmentExpression(node.AsNode(), false /*excludeCompoundAssignment*/):
+		if tx.shouldTransformPrivateElements && isNamedEvaluationAnd(ctx, node.AsNode(), tx.isAnonymousClassNeedingAssignedName) {
+			return tx.Visitor().VisitEachChild(transformNamedEvaluation(ctx, node.AsNode(), false /*ignoreEmptyStringLiteral*/, "" /*assignedName*/))
+		}
+		if info := tx.lookupPrivateAccess(node.Left); info != nil {
+			receiver := tx.Visitor().VisitNode(node.Left.Expression())
+			right := tx.Visitor().VisitNode(node.Right)
+			result := tx.createPrivateIdentifierAssignment(info, receiver, right, node.OperatorToken.Kind)
+			ctx.SetOriginal(result, node.AsNode())
+			ctx.AssignCommentAndSourceMapRanges(result, node.AsNode())
+			return result
+		}
+	case node.OperatorToken.Kind == ast.KindCommaToken:
+		left := tx.visitDiscardedValue(node.Left)
+		var right *ast.Expression
+		if discarded {
+			right = tx.visitDiscardedValue(node.Right)
+		} else {
+			right = tx.Visitor().VisitNode(node.Right)
+		}
+		return f.UpdateBinaryExpression(node, nil /*modifiers*/, left, nil /*typeNode*/, node.OperatorToken, right)
+	case node.OperatorToken.Kind == ast.KindInKeyword && ast.IsPrivateIdentifier(node.Left) && tx.privateEnvironment != nil:
+		// #x in obj
+		if info := tx.getPrivateIdentifierInfo(node.Left); info != nil {
+			result := f.NewClassPrivateFieldInHelper(info.brandCheckIdentifier, tx.Visitor().VisitNode(node.Right))
+			ctx.SetOriginal(result, node.AsNode())
+			ctx.AssignCommentAndSourceMapRanges(result, node.AsNode())
+			return result
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *classFieldsTransformer) visitPreOrPostfixUnaryExpression(node *ast.Node, discarded bool) *ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	var operator ast.Kind
+	var operand *ast.Expression
+	if ast.IsPrefixUnaryExpression(node) {
+		operator = node.AsPrefixUnaryExpression().Operator
+		operand = node.AsPrefixUnaryExpression().Operand
+	} else {
+		operator = node.AsPostfixUnaryExpression().Operator
+		operand = node.AsPostfixUnaryExpression().Operand
+	}
+	if operator == ast.KindPlusPlusToken || operator == ast.KindMinusMinusToken {
+		operand = ast.SkipParentheses(operand)
+		if info := tx.lookupPrivateAccess(operand); info != nil {
+			readExpression, initializeExpression := tx.createCopiableReceiverExpression(tx.Visitor().VisitNode(operand.Expression()))
+			var resultVariable *ast.IdentifierNode
+			if node.Kind == ast.KindPostfixUnaryExpression && !discarded {
+				resultVariable = f.NewTempVariable()
+				ctx.AddVariableDeclaration(resultVariable)
+			}
+			expression := tx.expandPreOrPostfixIncrementOrDecrementExpression(node, operator, tx.createPrivateIdentifierAccess(info, readExpression), resultVariable)
+			receiver := readExpression
+			if initializeExpression != nil {
+				receiver = initializeExpression
+			}
+			expression = tx.createPrivateIdentifierAssignment(info, receiver, expression, ast.KindEqualsToken)
+			ctx.SetOriginal(expression, node)
+			ctx.AssignCommentAndSourceMapRanges(expression, node)
+			if resultVariable != nil {
+				expression = f.NewCommaExpression(expression, resultVariable)
+				ctx.AssignCommentAndSourceMapRanges(expression, node)
+			}
+			return expression
+		}
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// Expands `++x` into `(_a = x, ++_a)` and `x++` into `(_a = x, _a++, _a)`, optionally storing the result of the
+// operation in `resultVariable`.
+func (tx *classFieldsTransformer) expandPreOrPostfixIncrementOrDecrementExpression(node *ast.Node, operator ast.Kind, expression *ast.Expression, resultVariable *ast.IdentifierNode) *ast.Expression {
+	f := tx.Factory()
+	temp := f.NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	expression = f.NewAssignmentExpression(temp, expression)
+	var operation *ast.Expression
+	if ast.IsPrefixUnaryExpression(node) {
+		operation = f.NewPrefixUnaryExpression(operator, temp)
+	} else {
+		operation = f.NewPostfixUnaryExpression(temp, operator)
+	}
+	if resultVariable != nil {
+		operation = f.NewAssignmentExpression(resultVariable, operation)
+	}
+	expression = f.NewCommaExpression(expression, operation)
+	if node.Kind == ast.KindPostfixUnaryExpression {
+		expression = f.NewCommaExpression(expression, temp)
+	}
+	return expression
+}
+
+// Gets the callee and `this` argument for a call to a private method, such that `a.b.#m()` becomes
+// `__classPrivateFieldGet(_a = a.b, ...).call(_a)`.
+func (tx *classFieldsTransformer) createPrivateCallBinding(info *privateIdentifierInfo, node *ast.PropertyAccessExpression) (*ast.Expression, *ast.Expression) {
+	f := tx.Factory()
+	receiver := tx.Visitor().VisitNode(node.Expression)
+	thisArg := receiver
+	if !transformers.IsSimpleCopiableExpression(receiver) {
+		thisArg = f.NewTempVariable()
+		tx.EmitContext().AddVariableDeclaration(thisArg)
+		receiver = f.NewAssignmentExpression(thisArg, receiver)
+	}
+	return tx.createPrivateIdentifierAccess(info, receiver), thisArg
+}
+
+func (tx *classFieldsTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if info := tx.lookupPrivateAccess(node.Expression); info != nil {
+		// obj.#m(a) -> __classPrivateFieldGet(obj, _C_instances, "m", _C_m).call(obj, a)
+		target, thisArg := tx.createPrivateCallBinding(info, node.Expression.AsPropertyAccessExpression())
+		arguments := tx.Visitor().VisitNodes(node.Arguments)
+		return tx.Factory().UpdateCallExpression(
+			node,
+			tx.Factory().NewPropertyAccessExpression(target, nil /*questionDotToken*/, tx.Factory().NewIdentifier("call"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList(append([]*ast.Node{thisArg}, arguments.Nodes...)),
+		)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *classFieldsTransformer) visitTaggedTemplateExpression(node *ast.TaggedTemplateExpression) *ast.Node {
+	if info := tx.lookupPrivateAccess(node.Tag); info != nil {
+		// obj.#m`x` -> __classPrivateFieldGet(obj, _C_instances, "m", _C_m).bind(obj)`x`
+		target, thisArg := tx.createPrivateCallBinding(info, node.Tag.AsPropertyAccessExpression())
+		return tx.Factory().UpdateTaggedTemplateExpression(
+			node,
+			tx.Factory().NewMethodCall(target, tx.Factory().NewIdentifier("bind"), []*ast.Node{thisArg}),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Visitor().VisitNode(node.Template),
+		)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+//
+// Destructuring assignment targets
+//
+
+func (tx *classFieldsTransformer) visitDestructuringAssignmentTarget(node *ast.Node) *ast.Node {
+	f := tx.Factory()
+	switch node.Kind {
+	case ast.KindArrayLiteralExpression:
+		array := node.AsArrayLiteralExpression()
+		elements := core.Map(array.Elements.Nodes, tx.visitAssignmentElement)
+		elementList := f.NewNodeList(elements)
+		elementList.Loc = array.Elements.Loc
+		return f.UpdateArrayLiteralExpression(array, elementList)
+	case ast.KindObjectLiteralExpression:
+		object := node.AsObjectLiteralExpression()
+		properties := core.Map(object.Properties.Nodes, tx.visitAssignmentProperty)
+		propertyList := f.NewNodeList(properties)
+		propertyList.Loc = object.Properties.Loc
+		return f.UpdateObjectLiteralExpression(object, propertyList)
+	case ast.KindParenthesizedExpression:
+		return f.UpdateParenthesizedExpression(node.AsParenthesizedExpression(), tx.visitDestructuringAssignmentTarget(node.Expression()))
+	case ast.KindPartiallyEmittedExpression:
+		return f.UpdatePartiallyEmittedExpression(node.AsPartiallyEmittedExpression(), tx.visitDestructuringAssignmentTarget(node.Expression()))
+	case ast.KindPropertyAccessExpression:
+		if info := tx.lookupPrivateAccess(node); info != nil {
+			return tx.wrapPrivateIdentifierForDestructuringTarget(info, node.AsPropertyAccessExpression())
+		}
+	}
+	return tx.Visitor().VisitNode(node)
+}
+
+func (tx *classFieldsTransformer) visitAssignmentElement(node *ast.Node) *ast.Node {
+	f := tx.Factory()
+	switch {
+	case ast.IsSpreadElement(node):
+		return f.UpdateSpreadElement(node.AsSpreadElement(), tx.visitDestructuringAssignmentTarget(node.Expression()))
+	case ast.IsAssignmentExpression(node, true /*excludeCompoundAssignment*/):
+		binary := node.AsBinaryExpression()
+		return f.UpdateBinaryExpression(
+			binary,
+			nil, /*modifiers*/
+			tx.visitDestructuringAssignmentTarget(binary.Left),
+			nil, /*typeNode*/
+			binary.OperatorToken,
+			tx.Visitor().VisitNode(binary.Right),
+		)
+	default:
+		return tx.visitDestructuringAssignmentTarget(node)
+	}
+}
+
+func (tx *classFieldsTransformer) visitAssignmentProperty(node *ast.Node) *ast.Node {
+	f := tx.Factory()
+	switch node.Kind {
+	case ast.KindPropertyAssignment:
+		property := node.AsPropertyAssignment()
+		return f.UpdatePropertyAssignment(
+			property,
+			nil, /*modifiers*/
+			tx.Visitor().VisitNode(property.Name()),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			tx.visitAssignmentElement(property.Initializer),
+		)
+	case ast.KindSpreadAssignment:
+		return f.UpdateSpreadAssignment(node.AsSpreadAssignment(), tx.visitDestructuringAssignmentTarget(node.Expression()))
+	default:
+		return tx.Visitor().VisitNode(node)
+	}
+}
+
+// Transforms a private name in a destructuring target into a setter on a temporary object:
+//
+//	({ set value(_a) { __classPrivateFieldSet(obj, _C_x, _a, "f"); } }).value
+func (tx *classFieldsTransformer) wrapPrivateIdentifierForDestructuringTarget(info *privateIdentifierInfo, node *ast.PropertyAccessExpression) *ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	parameter := f.NewGeneratedNameForNode(node.AsNode())
+	receiver := node.Expression
+	if receiver.Kind == ast.KindThisKeyword || !transformers.IsSimpleCopiableExpression(receiver) {
+		// `this` would refer to the temporary object, so the receiver is evaluated ahead of the assignment
+		temp := f.NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		ctx.AddVariableDeclaration(temp)
+		tx.pendingExpressions = append(tx.pendingExpressions, f.NewAssignmentExpression(temp, tx.Visitor().VisitNode(receiver)))
+		receiver = temp
+	}
+	setter := f.NewSetAccessorDeclaration(
+		nil, /*modifiers*/
+		f.NewIdentifier("value"),
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{f.NewParameterDeclaration(nil, nil, parameter, nil, nil, nil)}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList([]*ast.Node{
+			f.NewExpressionStatement(tx.createPrivateIdentifierAssignment(info, receiver, parameter.Clone(f), ast.KindEqualsToken)),
+		}), false /*multiLine*/),
+	)
+	wrapper := f.NewParenthesizedExpression(f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{setter}), false /*multiLine*/))
+	return f.NewPropertyAccessExpression(wrapper, nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)
+}
@@ -2,18 +2,359 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
+// Tracks the aliases used for `this` and `super` within the `static {}` blocks of a class being lowered.
+type classStaticBlockState struct {
+	classThis      *ast.IdentifierNode // alias for the class constructor
+	classSuper     *ast.IdentifierNode // alias for the superclass, assigned in the `extends` clause
+	hasSuperClass  bool
+	pendingAliases []*ast.IdentifierNode // aliases that must be declared in the scope containing the class
+}
+
+// Moves class `static {}` blocks out of the class body for targets that do not support them. Each block is
+// evaluated immediately after the class is defined:
+//
+//	class C { static { this.x = 1; } }
+//
+// becomes:
+//
+//	var _a;
+//	class C {}
+//	_a = C;
+//	(() => { _a.x = 1; })();
+//
+// Blocks synthesized by earlier transforms that contain a single expression, such as the field initializers produced
+// by the class fields transform, are evaluated directly rather than in an arrow function.
 type classStaticBlockTransformer struct {
 	transformers.Transformer
-}
 
-func (ch *classStaticBlockTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	state *classStaticBlockState // set while visiting the body of a `static {}` block
 }
 
 func newClassStaticBlockTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
 	tx := &classStaticBlockTransformer{}
 	return tx.NewTransformer(tx.visit, opts.Context)
 }
+
+func (tx *classStaticBlockTransformer) visit(node *ast.Node) *ast.Node {
+	facts := ast.SubtreeContainsClassStaticBlocks
+	if tx.state != nil {
+		facts |= ast.SubtreeContainsLexicalThisOrSuper
+	}
+	if node.SubtreeFacts()&facts == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	}
+	if tx.state == nil {
+		return tx.Visitor().VisitEachChild(node)
+	}
+	switch node.Kind {
+	case ast.KindThisKeyword:
+		return tx.getClassThis().Clone(tx.Factory())
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		if isSuperProperty(node) && tx.state.hasSuperClass {
+			return tx.createSuperPropertyAccess(node)
+		}
+	case ast.KindCallExpression:
+		if call := node.AsCallExpression(); isSuperProperty(call.Expression) && tx.state.hasSuperClass {
+			// super.m(a) -> Reflect.get(_b, "m", _a).call(_a, a)
+			arguments := tx.Visitor().VisitNodes(call.Arguments)
+			return tx.Factory().NewFunctionCallCall(tx.createSuperPropertyAccess(call.Expression), tx.getClassThis().Clone(tx.Factory()), arguments.Nodes)
+		}
+	case ast.KindTaggedTemplateExpression:
+		if tag := node.AsTaggedTemplateExpression(); isSuperProperty(tag.Tag) && tx.state.hasSuperClass {
+			// super.m`x` -> Reflect.get(_b, "m", _a).bind(_a)`x`
+			return tx.Factory().UpdateTaggedTemplateExpression(
+				tag,
+				tx.Factory().NewMethodCall(tx.createSuperPropertyAccess(tag.Tag), tx.Factory().NewIdentifier("bind"), []*ast.Node{tx.getClassThis().Clone(tx.Factory())}),
+				nil, /*questionDotToken*/
+				nil, /*typeArguments*/
+				tx.Visitor().VisitNode(tag.Template),
+			)
+		}
+	case ast.KindExpressionStatement:
+		if expression := node.Expression(); ast.IsAssignmentExpression(expression, false /*excludeCompoundAssignment*/) && isSuperProperty(expression.AsBinaryExpression().Left) && tx.state.hasSuperClass {
+			return tx.Factory().UpdateExpressionStatement(node.AsExpressionStatement(), tx.createSuperPropertyAssignment(expression.AsBinaryExpression(), true /*discarded*/))
+		}
+	case ast.KindBinaryExpression:
+		if binary := node.AsBinaryExpression(); ast.IsAssignmentExpression(node, false /*excludeCompoundAssignment*/) && isSuperProperty(binary.Left) && tx.state.hasSuperClass {
+			return tx.createSuperPropertyAssignment(binary, false /*discarded*/)
+		}
+	case ast.KindFunctionDeclaration,
+		ast.KindFunctionExpression,
+		ast.KindMethodDeclaration,
+		ast.KindGetAccessor,
+		ast.KindSetAccessor,
+		ast.KindConstructor:
+		// these have their own `this` and `super`
+		savedState := tx.state
+		tx.state = nil
+		updated := tx.Visitor().VisitEachChild(node)
+		tx.state = savedState
+		return updated
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *classStaticBlockTransformer) getClassThis() *ast.IdentifierNode {
+	if tx.state.classThis == nil {
+		tx.state.classThis = tx.Factory().NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		tx.state.pendingAliases = append(tx.state.pendingAliases, tx.state.classThis)
+	}
+	return tx.state.classThis
+}
+
+func (tx *classStaticBlockTransformer) getClassSuper() *ast.IdentifierNode {
+	if tx.state.classSuper == nil {
+		tx.state.classSuper = tx.Factory().NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		tx.state.pendingAliases = append(tx.state.pendingAliases, tx.state.classSuper)
+	}
+	return tx.state.classSuper
+}
+
+func (tx *classStaticBlockTransformer) getSuperPropertyKey(node *ast.Node) *ast.Expression {
+	if ast.IsPropertyAccessExpression(node) {
+		return tx.Factory().NewStringLiteralFromNode(node.Name())
+	}
+	return tx.Visitor().VisitNode(node.AsElementAccessExpression().ArgumentExpression)
+}
+
+// Transforms `super.x` into `Reflect.get(_b, "x", _a)`.
+func (tx *classStaticBlockTransformer) createSuperPropertyAccess(node *ast.Node) *ast.Expression {
+	f := tx.Factory()
+	result := f.NewGlobalMethodCall("Reflect", "get", []*ast.Node{
+		tx.getClassSuper().Clone(f),
+		tx.getSuperPropertyKey(node),
+		tx.getClassThis().Clone(f),
+	})
+	tx.EmitContext().SetOriginal(result, node)
+	tx.EmitContext().AssignCommentAndSourceMapRanges(result, node)
+	return result
+}
+
+// Transforms `super.x = v` into `Reflect.set(_b, "x", _c = v, _a), _c`, omitting the temporary variable when the
+// result is discarded.
+func (tx *classStaticBlockTransformer) createSuperPropertyAssignment(node *ast.BinaryExpression, discarded bool) *ast.Expression {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	key := tx.getSuperPropertyKey(node.Left)
+	value := tx.Visitor().VisitNode(node.Right)
+	if ast.IsCompoundAssignment(node.OperatorToken.Kind) {
+		// super[k] += v -> Reflect.set(_b, _c = k, Reflect.get(_b, _c, _a) + v, _a)
+		getterKey := key
+		if !transformers.IsSimpleCopiableExpression(key) {
+			temp := f.NewTempVariable()
+			ctx.AddVariableDeclaration(temp)
+			key = f.NewAssignmentExpression(temp, key)
+			getterKey = temp
+		}
+		value = f.NewBinaryExpression(
+			nil, /*modifiers*/
+			f.NewGlobalMethodCall("Reflect", "get", []*ast.Node{tx.getClassSuper().Clone(f), getterKey, tx.getClassThis().Clone(f)}),
+			nil, /*typeNode*/
+			f.NewToken(ast.GetNonAssignmentOperatorForCompoundAssignment(node.OperatorToken.Kind)),
+			value,
+		)
+	}
+	var temp *ast.IdentifierNode
+	if !discarded {
+		temp = f.NewTempVariable()
+		ctx.AddVariableDeclaration(temp)
+		value = f.NewAssignmentExpression(temp, value)
+	}
+	var result *ast.Expression = f.NewGlobalMethodCall("Reflect", "set", []*ast.Node{tx.getClassSuper().Clone(f), key, value, tx.getClassThis().Clone(f)})
+	ctx.SetOriginal(result, node.AsNode())
+	ctx.AssignCommentAndSourceMapRanges(result, node.AsNode())
+	if temp != nil {
+		result = f.NewCommaExpression(result, temp)
+	}
+	return result
+}
+
+func (tx *classStaticBlockTransformer) newClassStaticBlockState(node *ast.Node) *classStaticBlockState {
+	state := &classStaticBlockState{hasSuperClass: isDerivedClass(node)}
+	for _, member := range node.Members() {
+		if isClassThisAssignmentBlock(tx.EmitContext(), member) {
+			// an earlier transform needs an alias for the class, which we can reuse
+			state.classThis = tx.EmitContext().ClassThis(member)
+			break
+		}
+	}
+	return state
+}
+
+func classHasStaticBlocks(node *ast.Node) bool {
+	return core.Some(node.Members(), ast.IsClassStaticBlockDeclaration)
+}
+
+// Whether a block was synthesized by an earlier transform, rather than written in the source.
+func (tx *classStaticBlockTransformer) isSynthesizedStaticBlock(node *ast.Node) bool {
+	return ast.NodeIsSynthesized(node) || !ast.IsClassStaticBlockDeclaration(tx.EmitContext().MostOriginal(node))
+}
+
+// Transforms the members of a class, returning the remaining members along with an expression for each removed
+// `static {}` block.
+func (tx *classStaticBlockTransformer) transformClassMembers(node *ast.Node, state *classStaticBlockState) (*ast.NodeList, []*ast.Expression) {
+	savedState := tx.state
+	defer func() { tx.state = savedState }()
+
+	var members []*ast.Node
+	var expressions []*ast.Expression
+	for _, member := range node.Members() {
+		if !ast.IsClassStaticBlockDeclaration(member) {
+			// members have their own `this` and `super`
+			tx.state = nil
+			members = append(members, tx.Visitor().VisitNode(member))
+			continue
+		}
+		if isClassThisAssignmentBlock(tx.EmitContext(), member) {
+			// the alias is assigned once the class is defined
+			continue
+		}
+		tx.state = state
+		expressions = append(expressions, tx.transformStaticBlock(member.AsClassStaticBlockDeclaration()))
+	}
+
+	memberList := tx.Factory().NewNodeList(members)
+	memberList.Loc = node.MemberList().Loc
+	return memberList, expressions
+}
+
+func (tx *classStaticBlockTransformer) transformStaticBlock(node *ast.ClassStaticBlockDeclaration) *ast.Expression {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	body := node.Body.AsBlock()
+	ctx.StartVariableEnvironment()
+	statements := core.FirstResult(tx.Visitor().VisitSlice(body.Statements.Nodes))
+	statements = ctx.EndAndMergeVariableEnvironment(statements)
+
+	if tx.isSynthesizedStaticBlock(node.AsNode()) && len(statements) == 1 && ast.IsExpressionStatement(statements[0]) {
+		expression := statements[0].Expression()
+		ctx.AssignCommentAndSourceMapRanges(expression, node.AsNode())
+		return expression
+	}
+
+	// (() => { ... })()
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = body.Statements.Loc
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	block.Loc = body.Loc
+	arrow := f.NewArrowFunction(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewToken(ast.KindEqualsGreaterThanToken),
+		block,
+	)
+	call := f.NewCallExpression(f.NewParenthesizedExpression(arrow), nil /*questionDotToken*/, nil /*typeArguments*/, f.NewNodeList([]*ast.Node{}), ast.NodeFlagsNone)
+	ctx.SetOriginal(call, node.AsNode())
+	ctx.AssignCommentAndSourceMapRanges(call, node.AsNode())
+	return call
+}
+
+// Assigns the superclass alias in the `extends` clause, if one was needed: `class C extends (_b = B) {}`.
+func (tx *classStaticBlockTransformer) transformHeritageClauses(heritageClauses *ast.NodeList, state *classStaticBlockState) *ast.NodeList {
+	if state.classSuper == nil {
+		return heritageClauses
+	}
+	f := tx.Factory()
+	clauses := make([]*ast.Node, len(heritageClauses.Nodes))
+	for i, clause := range heritageClauses.Nodes {
+		clauses[i] = clause
+		if clause.AsHeritageClause().Token != ast.KindExtendsKeyword {
+			continue
+		}
+		types := clause.AsHeritageClause().Types
+		heritage := types.Nodes[0].AsExpressionWithTypeArguments()
+		expression := f.NewParenthesizedExpression(f.NewAssignmentExpression(state.classSuper, heritage.Expression))
+		typeList := f.NewNodeList(append([]*ast.Node{f.UpdateExpressionWithTypeArguments(heritage, expression, heritage.TypeArguments)}, types.Nodes[1:]...))
+		typeList.Loc = types.Loc
+		clauses[i] = f.UpdateHeritageClause(clause.AsHeritageClause(), typeList)
+	}
+	clauseList := f.NewNodeList(clauses)
+	clauseList.Loc = heritageClauses.Loc
+	return clauseList
+}
+
+func (tx *classStaticBlockTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if !classHasStaticBlocks(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+	state := tx.newClassStaticBlockState(node.AsNode())
+	members, expressions := tx.transformClassMembers(node.AsNode(), state)
+	heritageClauses = tx.transformHeritageClauses(heritageClauses, state)
+	for _, alias := range state.pendingAliases {
+		ctx.AddVariableDeclaration(alias)
+	}
+
+	name := node.Name()
+	if name == nil {
+		// the class needs a name so that it can be referenced by the statements that follow it
+		name = f.NewGeneratedNameForNode(node.AsNode())
+	}
+	updated := f.UpdateClassDeclaration(node, modifiers, name, nil /*typeParameters*/, heritageClauses, members)
+
+	statements := make([]*ast.Node, 0, len(expressions)+2)
+	statements = append(statements, updated)
+	if state.classThis != nil {
+		// _a = C;
+		statements = append(statements, f.NewExpressionStatement(f.NewAssignmentExpression(state.classThis, f.GetLocalName(updated))))
+	}
+	for _, expression := range expressions {
+		statement := f.NewExpressionStatement(expression)
+		ctx.AssignCommentAndSourceMapRanges(statement, expression)
+		statements = append(statements, statement)
+	}
+	return f.NewSyntaxList(statements)
+}
+
+func (tx *classStaticBlockTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	if !classHasStaticBlocks(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+	state := tx.newClassStaticBlockState(node.AsNode())
+	if state.classThis == nil {
+		// the class alias also holds the result: (_a = class {}, _a.x = 1, _a)
+		state.classThis = f.NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		ctx.AddVariableDeclaration(state.classThis)
+	}
+	temp := state.classThis
+	members, expressions := tx.transformClassMembers(node.AsNode(), state)
+	heritageClauses = tx.transformHeritageClauses(heritageClauses, state)
+	for _, alias := range state.pendingAliases {
+		ctx.AddVariableDeclaration(alias)
+	}
+	updated := f.UpdateClassExpression(node, modifiers, node.Name(), nil /*typeParameters*/, heritageClauses, members)
+
+	elements := make([]*ast.Expression, 0, len(expressions)+2)
+	elements = append(elements, f.NewAssignmentExpression(temp, updated))
+	for _, expression := range expressions {
+		ctx.AddEmitFlags(expression, printer.EFStartOnNewLine)
+		elements = append(elements, expression)
+	}
+	result := temp.Clone(f)
+	ctx.AddEmitFlags(result, printer.EFStartOnNewLine)
+	elements = append(elements, result)
+	return f.InlineExpressions(elements)
+}
@@ -2,6 +2,7 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/printer"
 )
 
@@ -26,3 +27,54 @@ func isClassThisAssignmentBlock(emitContext *printer.EmitContext, node *ast.Node
 	}
 	return false
 }
+
+// Creates a `static {}` block that assigns the static `this` to `classThis`:
+//
+//	static { _classThis = this; }
+func createClassThisAssignmentBlock(emitContext *printer.EmitContext, classThis *ast.IdentifierNode) *ast.Node {
+	f := emitContext.Factory
+	expression := f.NewAssignmentExpression(classThis, f.NewThisExpression())
+	statement := f.NewExpressionStatement(expression)
+	block := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList([]*ast.Node{statement}), false /*multiLine*/))
+	emitContext.SetClassThis(block, classThis)
+	return block
+}
+
+// Gets whether a class has a `static {}` block that assigns the static `this` to a `_classThis` (or similar) variable.
+func classHasClassThisAssignment(emitContext *printer.EmitContext, node *ast.ClassLikeDeclaration) bool {
+	return emitContext.ClassThis(node) != nil && core.Some(node.Members(), func(member *ast.Node) bool {
+		return isClassThisAssignmentBlock(emitContext, member)
+	})
+}
+
+// Injects a `static {}` block that assigns the static `this` to `classThis` at the start of a class, if one does not
+// already exist:
+//
+//	class {
+//	    static { _classThis = this; }
+//	}
+func injectClassThisAssignmentIfMissing(emitContext *printer.EmitContext, node *ast.ClassLikeDeclaration, classThis *ast.IdentifierNode) *ast.ClassLikeDeclaration {
+	if classHasClassThisAssignment(emitContext, node) {
+		return node
+	}
+
+	f := emitContext.Factory
+	staticBlock := createClassThisAssignmentBlock(emitContext, classThis)
+	if node.Name() != nil {
+		emitContext.SetSourceMapRange(staticBlock.Body().AsBlock().Statements.Nodes[0], node.Name().Loc)
+	}
+
+	members := make([]*ast.ClassElement, 0, len(node.Members())+1)
+	members = append(members, staticBlock)
+	members = append(members, node.Members()...)
+	membersList := f.NewNodeList(members)
+	membersList.Loc = node.MemberList().Loc
+
+	if ast.IsClassDeclaration(node) {
+		node = f.UpdateClassDeclaration(node.AsClassDeclaration(), node.Modifiers(), node.Name(), node.TypeParameterList(), node.AsClassDeclaration().HeritageClauses, membersList)
+	} else {
+		node = f.UpdateClassExpression(node.AsClassExpression(), node.Modifiers(), node.Name(), node.TypeParameterList(), node.AsClassExpression().HeritageClauses, membersList)
+	}
+	emitContext.SetClassThis(node, classThis)
+	return node
+}
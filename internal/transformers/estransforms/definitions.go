@@ -12,22 +12,28 @@ var (
 	// 2025: only module system syntax (import attributes, json modules), untransformed regex modifiers
 	// 2024: no new downlevel syntax
 	// 2023: no new downlevel syntax
-	NewES2022Transformer = transformers.Chain(NewESNextTransformer, newClassStaticBlockTransformer, newClassFieldsTransformer)    // !!! top level await? not transformed, just errored on at lower targets - also more of a module system feature anyway
+	NewES2022Transformer = transformers.Chain(NewESNextTransformer, newClassFieldsTransformer, newClassStaticBlockTransformer)    // !!! top level await? not transformed, just errored on at lower targets - also more of a module system feature anyway
 	NewES2021Transformer = transformers.Chain(NewES2022Transformer, newLogicalAssignmentTransformer)                              // !!! numeric seperators? always elided by printer?
 	NewES2020Transformer = transformers.Chain(NewES2021Transformer, newNullishCoalescingTransformer, newOptionalChainTransformer) // also dynamic import - module system feature
 	NewES2019Transformer = transformers.Chain(NewES2020Transformer, newOptionalCatchTransformer)
 	NewES2018Transformer = transformers.Chain(NewES2019Transformer, newObjectRestSpreadTransformer, newforawaitTransformer)
 	NewES2017Transformer = transformers.Chain(NewES2018Transformer, newAsyncTransformer)
 	NewES2016Transformer = transformers.Chain(NewES2017Transformer, newExponentiationTransformer)
+	NewES2015Transformer = transformers.Chain(NewES2016Transformer, newIterationTransformer, newGeneratorTransformer) // !!! block scoping, classes, arrow functions, and other ES2015 syntax are not lowered
 )
 
 func GetESTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
 	options := opts.CompilerOptions
 	switch options.GetEmitScriptTarget() {
 	case core.ScriptTargetESNext:
-		return nil // no transforms needed
+		if !options.GetUseDefineForClassFields() {
+			// field initializers still need to be moved into the constructor for [[Set]] semantics
+			return transformers.Chain(newESDecoratorTransformer, newClassFieldsTransformer)(opts)
+		}
+		return newESDecoratorTransformer(opts) // decorators are not yet supported natively
 	case /*core.ScriptTargetES2025,*/ core.ScriptTargetES2024, core.ScriptTargetES2023, core.ScriptTargetES2022:
-		return NewESNextTransformer(opts)
+		// class fields are supported natively, but auto-accessors and [[Set]] semantics still need to be lowered
+		return transformers.Chain(NewESNextTransformer, newClassFieldsTransformer)(opts)
 	case core.ScriptTargetES2021:
 		return NewES2022Transformer(opts)
 	case core.ScriptTargetES2020:
@@ -40,7 +46,9 @@ func GetESTransformer(opts *transformers.TransformOptions) *transformers.Transfo
 		return NewES2018Transformer(opts)
 	case core.ScriptTargetES2016:
 		return NewES2017Transformer(opts)
-	default: // other, older, option, transform maximally
+	case core.ScriptTargetES2015:
 		return NewES2016Transformer(opts)
+	default: // other, older, option, transform maximally
+		return NewES2015Transformer(opts)
 	}
 }
@@ -0,0 +1,562 @@
+package estransforms
+
+import (
+	"strconv"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/debug"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/transformers"
+)
+
+// Flattens binding and assignment patterns into a series of declarations or assignments. This is shared between
+// the transforms that need to take apart destructuring, each of which supplies the visitor used for nested
+// initializers and computed property names.
+type destructuringFlattener struct {
+	emitContext        *printer.EmitContext
+	factory            *printer.NodeFactory
+	visitor            *ast.NodeVisitor
+	downlevelIteration bool // whether array patterns read their values through the iterator protocol using `__read`
+
+	ctx flattenContext
+}
+
+func newDestructuringFlattener(emitContext *printer.EmitContext, visitor *ast.NodeVisitor, downlevelIteration bool) *destructuringFlattener {
+	return &destructuringFlattener{
+		emitContext:        emitContext,
+		factory:            emitContext.Factory,
+		visitor:            visitor,
+		downlevelIteration: downlevelIteration,
+	}
+}
+
+type pendingDecl struct {
+	pendingExpressions []*ast.Node
+	name               *ast.Node
+	value              *ast.Node
+	location           core.TextRange
+	original           *ast.Node
+}
+
+type flattenLevel int
+
+const (
+	flattenLevelAll flattenLevel = iota
+	flattenLevelObjectRest
+)
+
+type flattenContext struct {
+	level                                  flattenLevel
+	currentExpressions                     []*ast.Node
+	currentDeclarations                    []pendingDecl
+	hasTransformedPriorElement             bool
+	emitBindingOrAssignment                func(fl *destructuringFlattener, target *ast.Node, value *ast.Node, location core.TextRange, original *ast.Node)
+	createArrayBindingOrAssignmentPattern  func(fl *destructuringFlattener, elements []*ast.Node) *ast.Node
+	createObjectBindingOrAssignmentPattern func(fl *destructuringFlattener, elements []*ast.Node) *ast.Node
+	createArrayBindingOrAssignmentElement  func(fl *destructuringFlattener, expr *ast.Node) *ast.Node
+	hoistTempVariables                     bool
+}
+
+type oldFlattenContext flattenContext
+
+func (fl *destructuringFlattener) enterFlattenContext(
+	level flattenLevel,
+	emitBindingOrAssignment func(fl *destructuringFlattener, target *ast.Node, value *ast.Node, location core.TextRange, original *ast.Node),
+	createArrayBindingOrAssignmentPattern func(fl *destructuringFlattener, elements []*ast.Node) *ast.Node,
+	createObjectBindingOrAssignmentPattern func(fl *destructuringFlattener, elements []*ast.Node) *ast.Node,
+	createArrayBindingOrAssignmentElement func(fl *destructuringFlattener, expr *ast.Node) *ast.Node,
+	hoistTempVariables bool,
+) oldFlattenContext {
+	old := fl.ctx
+	fl.ctx = flattenContext{
+		level:                                  level,
+		emitBindingOrAssignment:                emitBindingOrAssignment,
+		createArrayBindingOrAssignmentPattern:  createArrayBindingOrAssignmentPattern,
+		createObjectBindingOrAssignmentPattern: createObjectBindingOrAssignmentPattern,
+		createArrayBindingOrAssignmentElement:  createArrayBindingOrAssignmentElement,
+		hoistTempVariables:                     hoistTempVariables,
+	}
+	return oldFlattenContext(old)
+}
+
+func (fl *destructuringFlattener) exitFlattenContext(old oldFlattenContext) {
+	fl.ctx = flattenContext(old)
+}
+
+func (fl *destructuringFlattener) flattenDestructuringBinding(level flattenLevel, node *ast.Node, rvalue *ast.Node, hoist bool, skipInitializer bool) *ast.Node {
+	old := fl.enterFlattenContext(level, (*destructuringFlattener).emitBinding, (*destructuringFlattener).createArrayBindingPattern, (*destructuringFlattener).createObjectBindingPattern, (*destructuringFlattener).createArrayBindingElement, hoist)
+	defer fl.exitFlattenContext(old)
+
+	if ast.IsVariableDeclaration(node) {
+		initializer := getInitializerOfBindingOrAssignmentElement(node)
+		if initializer != nil && (ast.IsIdentifier(initializer) && bindingOrAssignmentElementAssignsToName(node, initializer.AsIdentifier().Text) || bindingOrAssignmentElementContainsNonLiteralComputedName(node)) {
+			// If the right-hand value of the assignment is also an assignment target then
+			// we need to cache the right-hand value.
+			initializer = fl.ensureIdentifier(fl.visitor.VisitNode(initializer), false, initializer.Loc)
+			node = fl.factory.UpdateVariableDeclaration(node.AsVariableDeclaration(), node.Name(), nil, nil, initializer)
+		}
+	}
+
+	fl.flattenBindingOrAssignmentElement(node, rvalue, node.Loc, skipInitializer)
+
+	if len(fl.ctx.currentExpressions) > 0 {
+		temp := fl.factory.NewTempVariable()
+		fl.emitContext.AddVariableDeclaration(temp)
+		last := &fl.ctx.currentDeclarations[len(fl.ctx.currentDeclarations)-1]
+		last.pendingExpressions = append(last.pendingExpressions, fl.factory.NewAssignmentExpression(temp, last.value))
+		last.pendingExpressions = append(last.pendingExpressions, fl.ctx.currentExpressions...)
+		last.value = temp
+	}
+	decls := make([]*ast.Node, 0, len(fl.ctx.currentDeclarations))
+	for _, pending := range fl.ctx.currentDeclarations {
+		expr := pending.value
+		if len(pending.pendingExpressions) > 0 {
+			expr = fl.factory.InlineExpressions(append(pending.pendingExpressions, pending.value))
+		}
+		decl := fl.factory.NewVariableDeclaration(
+			pending.name,
+			nil,
+			nil,
+			expr,
+		)
+		decl.Loc = pending.location
+		if pending.original != nil {
+			fl.emitContext.SetOriginal(decl, pending.original)
+		}
+		decls = append(decls, decl)
+	}
+	if len(decls) == 1 {
+		return decls[0]
+	}
+	if len(decls) == 0 {
+		return nil
+	}
+	return fl.factory.NewSyntaxList(decls)
+}
+
+// Flattens a destructuring assignment into a comma-separated series of assignments. When `needsValue` is set, the
+// result ends with the value of the right-hand side so that the expression can be used where its value is observed.
+func (fl *destructuringFlattener) flattenDestructuringAssignment(level flattenLevel, node *ast.BinaryExpression, needsValue bool) *ast.Node {
+	location := node.Loc
+	var value *ast.Node
+	if ast.IsDestructuringAssignment(node.AsNode()) {
+		value = node.Right
+		for ast.IsEmptyArrayLiteral(node.Left) || ast.IsEmptyObjectLiteral(node.Left) {
+			if ast.IsDestructuringAssignment(value) {
+				node = value.AsBinaryExpression()
+				location = node.Loc
+				value = node.Right
+			} else {
+				return fl.visitor.VisitNode(value)
+			}
+		}
+	}
+	old := fl.enterFlattenContext(level, (*destructuringFlattener).emitAssignment, (*destructuringFlattener).createArrayAssignmentPattern, (*destructuringFlattener).createObjectAssignmentPattern, (*destructuringFlattener).createArrayAssignmentElement, true)
+	defer fl.exitFlattenContext(old)
+
+	if value != nil {
+		value = fl.visitor.VisitNode(value)
+
+		if ast.IsIdentifier(value) && bindingOrAssignmentElementAssignsToName(node.AsNode(), value.AsIdentifier().Text) || bindingOrAssignmentElementContainsNonLiteralComputedName(node.AsNode()) {
+			// If the right-hand value of the assignment is also an assignment target then
+			// we need to cache the right-hand value.
+			value = fl.ensureIdentifier(value, false, location)
+		} else if needsValue {
+			value = fl.ensureIdentifier(value, true, location)
+		} else if ast.NodeIsSynthesized(node.AsNode()) {
+			// Generally, the source map location for a destructuring assignment is the root
+			// expression.
+			//
+			// However, if the root expression is synthesized (as in the case
+			// of the initializer when transforming a ForOfStatement), then the source map
+			// location should point to the right-hand value of the expression.
+			location = value.Loc
+		}
+	}
+
+	fl.flattenBindingOrAssignmentElement(node.AsNode(), value, location, ast.IsDestructuringAssignment(node.AsNode()))
+
+	if value != nil && needsValue {
+		if len(fl.ctx.currentExpressions) == 0 {
+			return value
+		}
+		fl.emitExpression(value)
+	}
+
+	res := fl.factory.InlineExpressions(fl.ctx.currentExpressions)
+	if res != nil {
+		return res
+	}
+	return fl.factory.NewOmittedExpression()
+}
+
+func (fl *destructuringFlattener) flattenBindingOrAssignmentElement(element *ast.Node, value *ast.Node, location core.TextRange, skipInitializer bool) {
+	bindingTarget := ast.GetTargetOfBindingOrAssignmentElement(element)
+	if !skipInitializer {
+		initializer := fl.visitor.VisitNode(getInitializerOfBindingOrAssignmentElement(element))
+		if initializer != nil {
+			// Combine value and initializer
+			if value != nil {
+				value = fl.createDefaultValueCheck(value, initializer, location)
+				// If 'value' is not a simple expression, it could contain side-effecting code that should evaluate before an object or array binding pattern.
+				if !transformers.IsSimpleCopiableExpression(initializer) && (ast.IsBindingPattern(bindingTarget) || ast.IsAssignmentPattern(bindingTarget)) {
+					value = fl.ensureIdentifier(value, true, location)
+				}
+			} else {
+				value = initializer
+			}
+		} else if value == nil {
+			// Use 'void 0' in absence of value and initializer
+			value = fl.factory.NewVoidZeroExpression()
+		}
+	}
+
+	if isObjectBindingOrAssignmentPattern(bindingTarget) {
+		fl.flattenObjectBindingOrAssignmentPattern(element, bindingTarget, value, location)
+	} else if isArrayBindingOrAssignmentPattern(bindingTarget) {
+		fl.flattenArrayBindingOrAssignmentPattern(element, bindingTarget, value, location)
+	} else {
+		fl.ctx.emitBindingOrAssignment(fl, bindingTarget, value, location, element)
+	}
+}
+
+func (fl *destructuringFlattener) flattenObjectBindingOrAssignmentPattern(parent *ast.Node, pattern *ast.Node, value *ast.Node, location core.TextRange) {
+	elements := ast.GetElementsOfBindingOrAssignmentPattern(pattern)
+	numElements := len(elements)
+	if numElements != 1 {
+		// For anything other than a single-element destructuring we need to generate a temporary
+		// to ensure value is evaluated exactly once. Additionally, if we have zero elements
+		// we need to emit *something* to ensure that in case a 'var' keyword was already emitted,
+		// so in that case, we'll intentionally create that temporary.
+		reuseIdentifierExpressions := !ast.IsDeclarationBindingElement(parent) || numElements != 0
+		value = fl.ensureIdentifier(value, reuseIdentifierExpressions, location)
+	}
+	var bindingElements []*ast.Node
+	var computedTempVariables []*ast.Node
+	for i, element := range elements {
+		if ast.GetRestIndicatorOfBindingOrAssignmentElement(element) == nil {
+			propertyName := ast.TryGetPropertyNameOfBindingOrAssignmentElement(element)
+			if fl.ctx.level >= flattenLevelObjectRest && element.SubtreeFacts()&(ast.SubtreeContainsRestOrSpread|ast.SubtreeContainsObjectRestOrSpread) == 0 && ast.GetTargetOfBindingOrAssignmentElement(element).SubtreeFacts()&(ast.SubtreeContainsRestOrSpread|ast.SubtreeContainsObjectRestOrSpread) == 0 && !ast.IsComputedPropertyName(propertyName) {
+				bindingElements = append(bindingElements, fl.visitor.VisitNode(element))
+			} else {
+				if len(bindingElements) > 0 {
+					fl.ctx.emitBindingOrAssignment(fl, fl.ctx.createObjectBindingOrAssignmentPattern(fl, bindingElements), value, location, pattern)
+					bindingElements = nil
+				}
+				rhsValue := fl.createDestructuringPropertyAccess(value, propertyName)
+				if ast.IsComputedPropertyName(propertyName) {
+					computedTempVariables = append(computedTempVariables, rhsValue.AsElementAccessExpression().ArgumentExpression)
+				}
+				fl.flattenBindingOrAssignmentElement(element, rhsValue, element.Loc, false)
+			}
+		} else if i == numElements-1 {
+			if len(bindingElements) > 0 {
+				fl.ctx.emitBindingOrAssignment(fl, fl.ctx.createObjectBindingOrAssignmentPattern(fl, bindingElements), value, location, pattern)
+				bindingElements = nil
+			}
+			rhsValue := fl.factory.NewRestHelper(value, elements, computedTempVariables, pattern.Loc)
+			fl.flattenBindingOrAssignmentElement(element, rhsValue, element.Loc, false)
+		}
+	}
+	if len(bindingElements) > 0 {
+		fl.ctx.emitBindingOrAssignment(fl, fl.ctx.createObjectBindingOrAssignmentPattern(fl, bindingElements), value, location, pattern)
+	}
+}
+
+type restIdElemPair struct {
+	id      *ast.Node
+	element *ast.Node
+}
+
+func (fl *destructuringFlattener) flattenArrayBindingOrAssignmentPattern(parent *ast.Node, pattern *ast.Node, value *ast.Node, location core.TextRange) {
+	elements := ast.GetElementsOfBindingOrAssignmentPattern(pattern)
+	numElements := len(elements)
+	if fl.ctx.level < flattenLevelObjectRest && fl.downlevelIteration {
+		// Read the elements of the iterable into an array
+		count := numElements
+		if numElements > 0 && ast.GetRestIndicatorOfBindingOrAssignmentElement(elements[numElements-1]) != nil {
+			count = -1
+		}
+		readHelper := fl.factory.NewReadHelper(value, count)
+		readHelper.Loc = location
+		value = fl.ensureIdentifier(readHelper, false /*reuseIdentifierExpressions*/, location)
+	} else if numElements != 1 && (fl.ctx.level < flattenLevelObjectRest || numElements == 0) || core.Every(elements, ast.IsOmittedExpression) {
+		// For anything other than a single-element destructuring we need to generate a temporary
+		// to ensure value is evaluated exactly once. Additionally, if we have zero elements
+		// we need to emit *something* to ensure that in case a 'var' keyword was already emitted,
+		// so in that case, we'll intentionally create that temporary.
+		// Or all the elements of the binding pattern are omitted expression such as "var [,] = [1,2]",
+		// then we will create temporary variable.
+		reuseIdentifierExpressions := !ast.IsDeclarationBindingElement(parent) || numElements != 0
+		value = fl.ensureIdentifier(value, reuseIdentifierExpressions, location)
+	}
+	var bindingElements []*ast.Node
+	var restContainingElements []restIdElemPair
+	for i, element := range elements {
+		if fl.ctx.level >= flattenLevelObjectRest {
+			// If an array pattern contains an ObjectRest, we must cache the result so that we
+			// can perform the ObjectRest destructuring in a different declaration
+			if element.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread != 0 || fl.ctx.hasTransformedPriorElement && !isSimpleBindingOrAssignmentElement(element) {
+				fl.ctx.hasTransformedPriorElement = true
+				temp := fl.factory.NewTempVariable()
+				if fl.ctx.hoistTempVariables {
+					fl.emitContext.AddVariableDeclaration(temp)
+				}
+
+				restContainingElements = append(restContainingElements, restIdElemPair{temp, element})
+				bindingElements = append(bindingElements, fl.ctx.createArrayBindingOrAssignmentElement(fl, temp))
+			} else {
+				bindingElements = append(bindingElements, element)
+			}
+		} else if ast.IsOmittedExpression(element) {
+			continue
+		} else if ast.GetRestIndicatorOfBindingOrAssignmentElement(element) == nil {
+			rhsValue := fl.factory.NewElementAccessExpression(value, nil, fl.factory.NewNumericLiteral(strconv.Itoa(i)), ast.NodeFlagsNone)
+			fl.flattenBindingOrAssignmentElement(element, rhsValue, element.Loc, false)
+		} else if i == numElements-1 {
+			rhsValue := fl.factory.NewArraySliceCall(value, i)
+			fl.flattenBindingOrAssignmentElement(element, rhsValue, element.Loc, false)
+		}
+	}
+	if len(bindingElements) > 0 {
+		fl.ctx.emitBindingOrAssignment(fl, fl.ctx.createArrayBindingOrAssignmentPattern(fl, bindingElements), value, location, pattern)
+	}
+	if len(restContainingElements) > 0 {
+		for _, pair := range restContainingElements {
+			fl.flattenBindingOrAssignmentElement(pair.element, pair.id, pair.element.Loc, false)
+		}
+	}
+}
+
+/**
+ * Creates either a PropertyAccessExpression or an ElementAccessExpression for the
+ * right-hand side of a transformed destructuring assignment.
+ *
+ * @link https://tc39.github.io/ecma262/#sec-runtime-semantics-keyeddestructuringassignmentevaluation
+ *
+ * @param flattenContext Options used to control flattening.
+ * @param value The RHS value that is the source of the property.
+ * @param propertyName The destructuring property name.
+ */
+func (fl *destructuringFlattener) createDestructuringPropertyAccess(value *ast.Node, propertyName *ast.Node) *ast.Node {
+	if ast.IsComputedPropertyName(propertyName) {
+		argumentExpression := fl.ensureIdentifier(fl.visitor.VisitNode(propertyName.AsComputedPropertyName().Expression), false, propertyName.Loc)
+		return fl.factory.NewElementAccessExpression(
+			value,
+			nil,
+			argumentExpression,
+			ast.NodeFlagsNone,
+		)
+	} else if ast.IsStringOrNumericLiteralLike(propertyName) || ast.IsBigIntLiteral(propertyName) {
+		argumentExpression := propertyName.Clone(fl.factory)
+		return fl.factory.NewElementAccessExpression(
+			value,
+			nil,
+			argumentExpression,
+			ast.NodeFlagsNone,
+		)
+	} else {
+		name := fl.factory.NewIdentifier(propertyName.AsIdentifier().Text)
+		return fl.factory.NewPropertyAccessExpression(
+			value,
+			nil,
+			name,
+			ast.NodeFlagsNone,
+		)
+	}
+}
+
+func (fl *destructuringFlattener) createObjectBindingPattern(elements []*ast.Node) *ast.Node {
+	return fl.factory.NewBindingPattern(ast.KindObjectBindingPattern, fl.factory.NewNodeList(elements))
+}
+
+func (fl *destructuringFlattener) createArrayBindingPattern(elements []*ast.Node) *ast.Node {
+	return fl.factory.NewBindingPattern(ast.KindArrayBindingPattern, fl.factory.NewNodeList(elements))
+}
+
+func (fl *destructuringFlattener) createObjectAssignmentPattern(elements []*ast.Node) *ast.Node {
+	return fl.factory.NewObjectLiteralExpression(fl.factory.NewNodeList(elements), false)
+}
+
+func (fl *destructuringFlattener) createArrayAssignmentPattern(elements []*ast.Node) *ast.Node {
+	return fl.factory.NewArrayLiteralExpression(fl.factory.NewNodeList(elements), false)
+}
+
+func (fl *destructuringFlattener) createArrayAssignmentElement(expr *ast.Node) *ast.Node {
+	return expr
+}
+
+func (fl *destructuringFlattener) createArrayBindingElement(expr *ast.Node) *ast.Node {
+	return fl.factory.NewBindingElement(nil, nil, expr, nil)
+}
+
+func (fl *destructuringFlattener) emitExpression(node *ast.Node) {
+	fl.ctx.currentExpressions = append(fl.ctx.currentExpressions, node)
+}
+
+func (fl *destructuringFlattener) emitAssignment(target *ast.Node, value *ast.Node, location core.TextRange, original *ast.Node) {
+	debug.AssertNode(target, ast.IsExpression)
+	expr := fl.factory.NewAssignmentExpression(fl.visitor.VisitNode(target), value)
+	expr.Loc = location
+	fl.emitContext.SetOriginal(expr, original)
+	fl.emitExpression(expr)
+}
+
+func isBindingName(node *ast.Node) bool {
+	return node.Kind == ast.KindIdentifier || node.Kind == ast.KindArrayBindingPattern || node.Kind == ast.KindObjectBindingPattern
+}
+
+func (fl *destructuringFlattener) emitBinding(target *ast.Node, value *ast.Node, location core.TextRange, original *ast.Node) {
+	debug.AssertNode(target, isBindingName)
+	if len(fl.ctx.currentExpressions) > 0 {
+		value = fl.factory.InlineExpressions(append(fl.ctx.currentExpressions, value))
+		fl.ctx.currentExpressions = nil
+	}
+	fl.ctx.currentDeclarations = append(fl.ctx.currentDeclarations, pendingDecl{
+		fl.ctx.currentExpressions,
+		target,
+		value,
+		location,
+		original,
+	})
+}
+
+func (fl *destructuringFlattener) ensureIdentifier(value *ast.Node, reuseIdentifierExpressions bool, location core.TextRange) *ast.Node {
+	if reuseIdentifierExpressions && ast.IsIdentifier(value) {
+		return value
+	}
+
+	temp := fl.factory.NewTempVariable()
+	if fl.ctx.hoistTempVariables {
+		fl.emitContext.AddVariableDeclaration(temp)
+		assign := fl.factory.NewAssignmentExpression(temp, value)
+		assign.Loc = location
+		fl.emitExpression(assign)
+	} else {
+		fl.ctx.emitBindingOrAssignment(fl, temp, value, location, nil)
+	}
+	return temp
+}
+
+func (fl *destructuringFlattener) createDefaultValueCheck(value *ast.Expression, defaultValue *ast.Expression, location core.TextRange) *ast.Node {
+	value = fl.ensureIdentifier(value, true, location)
+	return fl.factory.NewConditionalExpression(
+		fl.factory.NewTypeCheck(value, "undefined"),
+		fl.factory.NewToken(ast.KindQuestionToken),
+		defaultValue,
+		fl.factory.NewToken(ast.KindColonToken),
+		value,
+	)
+}
+
+func bindingOrAssignmentElementAssignsToName(element *ast.Node, name string) bool {
+	target := ast.GetTargetOfBindingOrAssignmentElement(element)
+	if target == nil {
+		return false
+	}
+	if ast.IsBindingPattern(target) || ast.IsAssignmentPattern(target) {
+		return bindingOrAssignmentPatternAssignsToName(target, name)
+	} else if ast.IsIdentifier(target) {
+		return target.AsIdentifier().Text == name
+	}
+	return false
+}
+
+func bindingOrAssignmentPatternAssignsToName(pattern *ast.Node, name string) bool {
+	elements := ast.GetElementsOfBindingOrAssignmentPattern(pattern)
+	for _, element := range elements {
+		if bindingOrAssignmentElementAssignsToName(element, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func bindingOrAssignmentElementContainsNonLiteralComputedName(element *ast.Node) bool {
+	propertyName := ast.TryGetPropertyNameOfBindingOrAssignmentElement(element)
+	if propertyName != nil && ast.IsComputedPropertyName(propertyName) && !ast.IsLiteralExpression(propertyName.AsComputedPropertyName().Expression) {
+		return true
+	}
+	target := ast.GetTargetOfBindingOrAssignmentElement(element)
+	return target != nil && (ast.IsBindingPattern(target) || ast.IsAssignmentPattern(target)) && bindingOrAssignmentPatternContainsNonLiteralComputedName(target)
+}
+
+func bindingOrAssignmentPatternContainsNonLiteralComputedName(pattern *ast.Node) bool {
+	elements := ast.GetElementsOfBindingOrAssignmentPattern(pattern)
+	for _, element := range elements {
+		if bindingOrAssignmentElementContainsNonLiteralComputedName(element) {
+			return true
+		}
+	}
+	return false
+}
+
+func getInitializerOfBindingOrAssignmentElement(bindingElement *ast.Node) *ast.Node {
+	if ast.IsDeclarationBindingElement(bindingElement) {
+		// `1` in `let { a = 1 } = ...`
+		// `1` in `let { a: b = 1 } = ...`
+		// `1` in `let { a: {b} = 1 } = ...`
+		// `1` in `let { a: [b] = 1 } = ...`
+		// `1` in `let [a = 1] = ...`
+		// `1` in `let [{a} = 1] = ...`
+		// `1` in `let [[a] = 1] = ...`
+		return bindingElement.Initializer()
+	}
+
+	if ast.IsPropertyAssignment(bindingElement) {
+		// `1` in `({ a: b = 1 } = ...)`
+		// `1` in `({ a: {b} = 1 } = ...)`
+		// `1` in `({ a: [b] = 1 } = ...)`
+		initializer := bindingElement.Initializer()
+		if ast.IsAssignmentExpression(initializer, true) {
+			return initializer.AsBinaryExpression().Right
+		}
+		return nil
+	}
+
+	if ast.IsShorthandPropertyAssignment(bindingElement) {
+		// `1` in `({ a = 1 } = ...)`
+		return bindingElement.AsShorthandPropertyAssignment().ObjectAssignmentInitializer
+	}
+
+	if ast.IsAssignmentExpression(bindingElement, true) {
+		// `1` in `[a = 1] = ...`
+		// `1` in `[{a} = 1] = ...`
+		// `1` in `[[a] = 1] = ...`
+		return bindingElement.AsBinaryExpression().Right
+	}
+
+	if ast.IsSpreadElement(bindingElement) {
+		// Recovery consistent with existing emit.
+		return getInitializerOfBindingOrAssignmentElement(bindingElement.Expression())
+	}
+	return nil
+}
+
+func isObjectBindingOrAssignmentPattern(node *ast.Node) bool {
+	return node.Kind == ast.KindObjectBindingPattern || node.Kind == ast.KindObjectLiteralExpression
+}
+
+func isArrayBindingOrAssignmentPattern(node *ast.Node) bool {
+	return node.Kind == ast.KindArrayBindingPattern || node.Kind == ast.KindArrayLiteralExpression
+}
+
+func isSimpleBindingOrAssignmentElement(element *ast.Node) bool {
+	target := ast.GetTargetOfBindingOrAssignmentElement(element)
+	if target == nil || ast.IsOmittedExpression(target) {
+		return true
+	}
+	propertyName := ast.TryGetPropertyNameOfBindingOrAssignmentElement(element)
+	if propertyName != nil && !ast.IsPropertyNameLiteral(propertyName) {
+		return false
+	}
+	initializer := getInitializerOfBindingOrAssignmentElement(element)
+	if initializer != nil && !transformers.IsSimpleInlineableExpression(initializer) {
+		return false
+	}
+	if ast.IsBindingPattern(target) || ast.IsAssignmentPattern(target) {
+		return core.Every(ast.GetElementsOfBindingOrAssignmentPattern(target), isSimpleBindingOrAssignmentElement)
+	}
+	return ast.IsIdentifier(target)
+}
@@ -1,19 +1,1214 @@
 package estransforms
 
 import (
+	"strings"
+
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
+// Transforms TC39 stage-3 decorators into calls to the `__esDecorate` and `__runInitializers` helpers. A decorated
+// class is evaluated inside of an IIFE so that the helper variables holding its decorators and initializers are
+// scoped to the class definition:
+//
+//	let C = (() => {
+//	    let _classDecorators = [dec];
+//	    ...
+//	    var C = class { static { ... } };
+//	    return C = _classThis;
+//	})();
 type esDecoratorTransformer struct {
 	transformers.Transformer
+
+	legacyDecorators bool // decorators are transformed by the legacy decorator transform when `--experimentalDecorators` is set
+
+	classInfo          *decoratedClassInfo
+	classThis          *ast.IdentifierNode // the alias for `this` in the static initializers of a class with class decorators
+	pendingExpressions []*ast.Expression   // decorator evaluations and hoisted property names that have yet to be evaluated
+}
+
+type decoratedMemberInfo struct {
+	isStatic              bool
+	decoratorsName        *ast.IdentifierNode // the variable holding the member's decorators
+	initializersName      *ast.IdentifierNode // the variable holding the field's initializers, if any
+	extraInitializersName *ast.IdentifierNode // the variable holding the field's extra initializers, if any
+	descriptorName        *ast.IdentifierNode // the variable holding the descriptor of a private member, if any
 }
 
-func (ch *esDecoratorTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+type decoratedClassInfo struct {
+	class *ast.Node
+
+	classDecoratorsName        *ast.IdentifierNode // the variable holding the class's decorators, if any
+	classDescriptorName        *ast.IdentifierNode // the variable holding the class's descriptor, if any
+	classExtraInitializersName *ast.IdentifierNode // the variable holding the class's extra initializers, if any
+	classThis                  *ast.IdentifierNode // the alias for the final class constructor, if the class is decorated
+	classSuper                 *ast.IdentifierNode // the variable holding the evaluated `extends` clause, if any
+	metadataReference          *ast.IdentifierNode // the variable holding the class's `Symbol.metadata` object
+
+	memberInfos []*decoratedMemberInfo
+
+	instanceMethodExtraInitializersName *ast.IdentifierNode
+	staticMethodExtraInitializersName   *ast.IdentifierNode
+
+	staticNonFieldDecorationStatements    []*ast.Statement
+	nonStaticNonFieldDecorationStatements []*ast.Statement
+	staticFieldDecorationStatements       []*ast.Statement
+	nonStaticFieldDecorationStatements    []*ast.Statement
+
+	hasStaticInitializers       bool
+	pendingStaticInitializers   []*ast.Expression // initializers to run before the next static field or block
+	pendingInstanceInitializers []*ast.Expression // initializers to run before the next instance field or in the constructor
+}
+
+// The parts of a class element produced by `partialTransformClassElement`.
+type decoratedClassElementParts struct {
+	modifiers             *ast.ModifierList
+	name                  *ast.PropertyName
+	initializersName      *ast.IdentifierNode
+	extraInitializersName *ast.IdentifierNode
+	descriptorName        *ast.IdentifierNode
+	thisArg               *ast.IdentifierNode
 }
 
 func newESDecoratorTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
-	tx := &esDecoratorTransformer{}
+	tx := &esDecoratorTransformer{legacyDecorators: opts.CompilerOptions.ExperimentalDecorators.IsTrue()}
 	return tx.NewTransformer(tx.visit, opts.Context)
 }
+
+func (tx *esDecoratorTransformer) visit(node *ast.Node) *ast.Node {
+	if tx.legacyDecorators {
+		return node
+	}
+	facts := ast.SubtreeContainsDecorators
+	if tx.classThis != nil {
+		facts |= ast.SubtreeContainsLexicalThis
+	}
+	if node.SubtreeFacts()&facts == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindDecorator:
+		// decorators in any other position (such as on parameters) are not supported and are removed
+		return nil
+	case ast.KindThisKeyword:
+		if tx.classThis != nil {
+			classThis := tx.classThis.Clone(tx.Factory())
+			tx.EmitContext().AssignCommentAndSourceMapRanges(classThis, node)
+			return classThis
+		}
+		return node
+	case ast.KindFunctionDeclaration,
+		ast.KindFunctionExpression,
+		ast.KindMethodDeclaration,
+		ast.KindGetAccessor,
+		ast.KindSetAccessor,
+		ast.KindConstructor:
+		// these have their own `this`
+		savedClassThis := tx.classThis
+		tx.classThis = nil
+		updated := tx.Visitor().VisitEachChild(node)
+		tx.classThis = savedClassThis
+		return updated
+	case ast.KindVariableDeclaration,
+		ast.KindParameter,
+		ast.KindBindingElement,
+		ast.KindPropertyAssignment,
+		ast.KindShorthandPropertyAssignment,
+		ast.KindPropertyDeclaration,
+		ast.KindExportAssignment,
+		ast.KindBinaryExpression:
+		return tx.visitNamedEvaluationSource(node)
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *esDecoratorTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited.AsNode(), tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+func (tx *esDecoratorTransformer) visitNamedEvaluationSource(node *ast.Node) *ast.Node {
+	if isNamedEvaluationAnd(tx.EmitContext(), node, isAnonymousDecoratedClass) {
+		node = transformNamedEvaluation(tx.EmitContext(), node, false /*ignoreEmptyStringLiteral*/, "" /*assignedName*/)
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// Gets whether a node is an anonymous class expression that would lose its inferred name once it is moved into an
+// IIFE.
+func isAnonymousDecoratedClass(node *ast.Node) bool {
+	return ast.IsClassExpression(node) && node.Name() == nil && isDecoratedClassLike(node)
+}
+
+// Gets whether a class or any of its members are decorated.
+func isDecoratedClassLike(node *ast.Node) bool {
+	return ast.HasDecorators(node) || core.Some(node.Members(), ast.HasDecorators)
+}
+
+//
+// Classes
+//
+
+func (tx *esDecoratorTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if !isDecoratedClassLike(node.AsNode()) {
+		return tx.visitUndecoratedClass(node.AsNode())
+	}
+
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	classNode := node.AsNode()
+	isExport := ast.HasSyntacticModifier(classNode, ast.ModifierFlagsExport)
+	isDefault := ast.HasSyntacticModifier(classNode, ast.ModifierFlagsDefault)
+
+	if isExport && isDefault && node.Name() == nil {
+		// export default (() => { ... })();
+		classNode = injectClassNamedEvaluationHelperBlockIfMissing(ctx, classNode, f.NewStringLiteral("default"), nil /*thisExpression*/)
+		iife := tx.transformClassLike(classNode)
+		statement := f.NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, iife)
+		ctx.SetOriginal(statement, node.AsNode())
+		ctx.AssignCommentAndSourceMapRanges(statement, node.AsNode())
+		return statement
+	}
+
+	// let C = (() => { ... })();
+	iife := tx.transformClassLike(classNode)
+	declarationName := f.GetLocalNameEx(classNode, printer.AssignedNameOptions{AllowSourceMaps: true})
+	declaration := f.NewVariableDeclaration(declarationName, nil /*exclamationToken*/, nil /*typeNode*/, iife)
+	ctx.SetOriginal(declaration, node.AsNode())
+	statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsLet, f.NewNodeList([]*ast.Node{declaration})))
+	ctx.SetOriginal(statement, node.AsNode())
+	ctx.AssignCommentRange(statement, node.AsNode())
+	if !isExport {
+		return statement
+	}
+
+	var exportStatement *ast.Statement
+	if isDefault {
+		// export default C;
+		exportStatement = f.NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, f.GetDeclarationName(classNode))
+	} else {
+		// export { C };
+		exportStatement = f.NewExportDeclaration(
+			nil,   /*modifiers*/
+			false, /*isTypeOnly*/
+			f.NewNamedExports(f.NewNodeList([]*ast.Node{
+				f.NewExportSpecifier(false /*isTypeOnly*/, nil /*propertyName*/, f.GetDeclarationName(classNode)),
+			})),
+			nil, /*moduleSpecifier*/
+			nil, /*attributes*/
+		)
+	}
+	ctx.SetOriginal(exportStatement, node.AsNode())
+	return f.NewSyntaxList([]*ast.Node{statement, exportStatement})
+}
+
+func (tx *esDecoratorTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	if !isDecoratedClassLike(node.AsNode()) {
+		return tx.visitUndecoratedClass(node.AsNode())
+	}
+	iife := tx.transformClassLike(node.AsNode())
+	tx.EmitContext().SetOriginal(iife, node.AsNode())
+	return iife
+}
+
+func (tx *esDecoratorTransformer) visitUndecoratedClass(node *ast.Node) *ast.Node {
+	// the class has its own `this`, though it may still contain decorated classes
+	savedClassThis := tx.classThis
+	tx.classThis = nil
+	updated := tx.Visitor().VisitEachChild(node)
+	tx.classThis = savedClassThis
+	return updated
+}
+
+func (tx *esDecoratorTransformer) createClassInfo(node *ast.Node) *decoratedClassInfo {
+	f := tx.Factory()
+	classInfo := &decoratedClassInfo{
+		class:             node,
+		metadataReference: f.NewUniqueNameEx("_metadata", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel}),
+	}
+
+	if ast.HasDecorators(node) {
+		// A static private or auto-accessor member may need to refer to the class from within a nested class, so
+		// the alias must not be shadowed.
+		var flags printer.GeneratedIdentifierFlags = printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel
+		if core.Some(node.Members(), func(member *ast.Node) bool {
+			return (ast.IsPrivateIdentifierClassElementDeclaration(member) || ast.IsAutoAccessorPropertyDeclaration(member)) && ast.HasStaticModifier(member)
+		}) {
+			flags = printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes
+		}
+		classInfo.classThis = f.NewUniqueNameEx("_classThis", printer.AutoGenerateOptions{Flags: flags})
+	}
+
+	for _, member := range node.Members() {
+		if ast.IsMethodOrAccessor(member) && ast.HasDecorators(member) {
+			if ast.HasStaticModifier(member) {
+				if classInfo.staticMethodExtraInitializersName == nil {
+					classInfo.staticMethodExtraInitializersName = f.NewUniqueNameEx("_staticExtraInitializers", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic})
+					thisArg := core.IfElse(classInfo.classThis != nil, classInfo.classThis, f.NewThisExpression())
+					classInfo.pendingStaticInitializers = append(classInfo.pendingStaticInitializers, f.NewRunInitializersHelper(thisArg, classInfo.staticMethodExtraInitializersName, nil /*value*/))
+				}
+			} else {
+				if classInfo.instanceMethodExtraInitializersName == nil {
+					classInfo.instanceMethodExtraInitializersName = f.NewUniqueNameEx("_instanceExtraInitializers", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic})
+					classInfo.pendingInstanceInitializers = append(classInfo.pendingInstanceInitializers, f.NewRunInitializersHelper(f.NewThisExpression(), classInfo.instanceMethodExtraInitializersName, nil /*value*/))
+				}
+			}
+		}
+		if ast.IsClassStaticBlockDeclaration(member) {
+			if !isClassNamedEvaluationHelperBlock(tx.EmitContext(), member) {
+				classInfo.hasStaticInitializers = true
+			}
+		} else if ast.IsPropertyDeclaration(member) && ast.HasStaticModifier(member) {
+			if member.Initializer() != nil || ast.HasDecorators(member) {
+				classInfo.hasStaticInitializers = true
+			}
+		}
+	}
+	return classInfo
+}
+
+// Transforms a decorated class into an IIFE that evaluates to the decorated class constructor.
+func (tx *esDecoratorTransformer) transformClassLike(node *ast.Node) *ast.Expression {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	ctx.StartVariableEnvironment()
+
+	// If the class has no name and is not otherwise assigned a name, it would be named after the variable we use to
+	// refer to it within the IIFE, so we explicitly give it an empty name instead.
+	if !classHasDeclaredOrExplicitlyAssignedName(ctx, node) && ast.HasDecorators(node) {
+		node = injectClassNamedEvaluationHelperBlockIfMissing(ctx, node, f.NewStringLiteral(""), nil /*thisExpression*/)
+	}
+
+	classReference := f.GetLocalNameEx(node, printer.AssignedNameOptions{IgnoreAssignedName: true})
+	classInfo := tx.createClassInfo(node)
+	var classDefinitionStatements []*ast.Statement
+	var leadingBlockStatements []*ast.Statement
+	var trailingBlockStatements []*ast.Statement
+
+	// class decorators and the `extends` clause are evaluated outside of the class body
+	classDecorators := tx.transformDecorators(node)
+	if classDecorators != nil {
+		// let _classDecorators = [dec];
+		// let _classDescriptor;
+		// let _classExtraInitializers = [];
+		// let _classThis;
+		classInfo.classDecoratorsName = f.NewUniqueNameEx("_classDecorators", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+		classInfo.classDescriptorName = f.NewUniqueNameEx("_classDescriptor", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+		classInfo.classExtraInitializersName = f.NewUniqueNameEx("_classExtraInitializers", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+		classDefinitionStatements = append(classDefinitionStatements,
+			tx.createLet(classInfo.classDecoratorsName, f.NewArrayLiteralExpression(f.NewNodeList(classDecorators), false /*multiLine*/)),
+			tx.createLet(classInfo.classDescriptorName, nil),
+			tx.createLet(classInfo.classExtraInitializersName, f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Node{}), false /*multiLine*/)),
+			tx.createLet(classInfo.classThis, nil),
+		)
+	}
+
+	heritageClauses := node.ClassLikeData().HeritageClauses
+	if extendsElement := ast.GetExtendsHeritageClauseElement(node); extendsElement != nil {
+		// let _classSuper = Base;
+		extendsExpression := tx.Visitor().VisitNode(extendsElement.Expression())
+		unwrapped := ast.SkipOuterExpressions(extendsExpression, ast.OEKAll)
+		if (ast.IsClassExpression(unwrapped) || ast.IsFunctionExpression(unwrapped)) && unwrapped.Name() == nil || ast.IsArrowFunction(unwrapped) {
+			// prevent the anonymous base class from being named after the `_classSuper` variable
+			extendsExpression = f.NewCommaExpression(f.NewNumericLiteral("0"), extendsExpression)
+		}
+		classInfo.classSuper = f.NewUniqueNameEx("_classSuper", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(classInfo.classSuper, extendsExpression))
+
+		extendsClause := ast.GetHeritageClause(node, ast.KindExtendsKeyword)
+		updatedExtendsElement := f.NewExpressionWithTypeArguments(classInfo.classSuper, nil /*typeArguments*/)
+		updatedExtendsClause := f.UpdateHeritageClause(extendsClause.AsHeritageClause(), f.NewNodeList([]*ast.Node{updatedExtendsElement}))
+		heritageClauses = f.NewNodeList([]*ast.Node{updatedExtendsClause})
+	}
+
+	renamedClassThis := core.IfElse(classInfo.classThis != nil, classInfo.classThis, f.NewThisExpression())
+
+	// class elements are evaluated in the scope of the class body
+	savedClassInfo := tx.classInfo
+	savedClassThis := tx.classThis
+	savedPendingExpressions := tx.pendingExpressions
+	tx.classInfo = classInfo
+	tx.classThis = nil
+	tx.pendingExpressions = nil
+
+	// const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+	leadingBlockStatements = append(leadingBlockStatements, tx.createMetadata(classInfo.metadataReference, classInfo.classSuper))
+
+	// the constructor is visited last so that it receives any remaining instance initializers
+	var members []*ast.Node
+	var constructor *ast.Node
+	constructorIndex := -1
+	for _, member := range node.Members() {
+		if ast.IsConstructorDeclaration(member) {
+			constructor = member
+			constructorIndex = len(members)
+			members = append(members, member)
+			continue
+		}
+		members = append(members, tx.visitClassElement(member)...)
+	}
+	if constructor != nil {
+		members[constructorIndex] = tx.visitConstructorDeclaration(constructor.AsConstructorDeclaration())
+	}
+
+	if len(tx.pendingExpressions) > 0 {
+		// Decorators observe the `this` of the scope containing the class, so any lexical `this` must be captured
+		// before it is evaluated in the class `static {}` block.
+		var outerThis *ast.IdentifierNode
+		var thisVisitor *ast.NodeVisitor
+		thisVisitor = ctx.NewNodeVisitor(func(node *ast.Node) *ast.Node {
+			if node.SubtreeFacts()&ast.SubtreeContainsLexicalThis == 0 {
+				return node
+			}
+			if node.Kind == ast.KindThisKeyword {
+				if outerThis == nil {
+					outerThis = f.NewUniqueNameEx("_outerThis", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic})
+				}
+				return outerThis
+			}
+			return thisVisitor.VisitEachChild(node)
+		})
+		for _, expression := range tx.pendingExpressions {
+			leadingBlockStatements = append(leadingBlockStatements, f.NewExpressionStatement(thisVisitor.VisitNode(expression)))
+		}
+		if outerThis != nil {
+			classDefinitionStatements = append([]*ast.Statement{tx.createLet(outerThis, f.NewThisExpression())}, classDefinitionStatements...)
+		}
+	}
+
+	tx.classInfo = savedClassInfo
+	tx.classThis = savedClassThis
+	tx.pendingExpressions = savedPendingExpressions
+
+	var syntheticConstructor *ast.Node
+	if len(classInfo.pendingInstanceInitializers) > 0 && (constructor == nil || constructor.Body() == nil) {
+		// constructor() {
+		//     super(...arguments);
+		//     __runInitializers(this, _instanceExtraInitializers);
+		// }
+		var statements []*ast.Statement
+		if isDerivedClass(node) {
+			superCall := f.NewCallExpression(
+				f.NewKeywordExpression(ast.KindSuperKeyword),
+				nil, /*questionDotToken*/
+				nil, /*typeArguments*/
+				f.NewNodeList([]*ast.Node{f.NewSpreadElement(f.NewIdentifier("arguments"))}),
+				ast.NodeFlagsNone,
+			)
+			statements = append(statements, f.NewExpressionStatement(superCall))
+		}
+		statements = append(statements, tx.prepareConstructor(classInfo)...)
+		syntheticConstructor = f.NewConstructorDeclaration(
+			nil, /*modifiers*/
+			nil, /*typeParameters*/
+			f.NewNodeList([]*ast.Node{}),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			f.NewBlock(f.NewNodeList(statements), true /*multiLine*/),
+		)
+	}
+
+	// let _staticExtraInitializers = [];
+	// let _instanceExtraInitializers = [];
+	// let _static_m_decorators;
+	// ...
+	if classInfo.staticMethodExtraInitializersName != nil {
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(classInfo.staticMethodExtraInitializersName, f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Node{}), false /*multiLine*/)))
+	}
+	if classInfo.instanceMethodExtraInitializersName != nil {
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(classInfo.instanceMethodExtraInitializersName, f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Node{}), false /*multiLine*/)))
+	}
+	for _, isStatic := range []bool{true, false} {
+		for _, memberInfo := range classInfo.memberInfos {
+			if memberInfo.isStatic != isStatic {
+				continue
+			}
+			classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.decoratorsName, nil))
+			if memberInfo.initializersName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.initializersName, f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Node{}), false /*multiLine*/)))
+			}
+			if memberInfo.extraInitializersName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.extraInitializersName, f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Node{}), false /*multiLine*/)))
+			}
+			if memberInfo.descriptorName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.descriptorName, nil))
+			}
+		}
+	}
+
+	// methods and accessors are decorated before fields, and static members before instance members
+	leadingBlockStatements = append(leadingBlockStatements, classInfo.staticNonFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, classInfo.nonStaticNonFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, classInfo.staticFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, classInfo.nonStaticFieldDecorationStatements...)
+
+	if classDecorators != nil {
+		// __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+		classDescriptor := f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, renamedClassThis),
+		}), false /*multiLine*/)
+		esDecorateStatement := f.NewExpressionStatement(f.NewESDecorateHelper(
+			f.NewKeywordExpression(ast.KindNullKeyword),
+			f.NewAssignmentExpression(classInfo.classDescriptorName, classDescriptor),
+			classInfo.classDecoratorsName,
+			printer.ESDecorateContext{
+				Kind:     "class",
+				Name:     printer.ESDecorateName{Computed: true, Name: f.NewPropertyAccessExpression(renamedClassThis, nil /*questionDotToken*/, f.NewIdentifier("name"), ast.NodeFlagsNone)},
+				Metadata: classInfo.metadataReference,
+			},
+			f.NewKeywordExpression(ast.KindNullKeyword),
+			classInfo.classExtraInitializersName,
+		))
+		ctx.SetSourceMapRange(esDecorateStatement, node.Loc)
+		leadingBlockStatements = append(leadingBlockStatements, esDecorateStatement)
+
+		// C = _classThis = _classDescriptor.value;
+		classDescriptorValue := f.NewPropertyAccessExpression(classInfo.classDescriptorName, nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)
+		leadingBlockStatements = append(leadingBlockStatements, f.NewExpressionStatement(
+			f.NewAssignmentExpression(classReference, f.NewAssignmentExpression(classInfo.classThis, classDescriptorValue)),
+		))
+	}
+
+	// if (_metadata) Object.defineProperty(C, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+	leadingBlockStatements = append(leadingBlockStatements, tx.createSymbolMetadata(renamedClassThis, classInfo.metadataReference))
+
+	// static extra initializers run once all static members have been defined, followed by the class extra initializers
+	for _, initializer := range classInfo.pendingStaticInitializers {
+		trailingBlockStatements = append(trailingBlockStatements, f.NewExpressionStatement(initializer))
+	}
+	classInfo.pendingStaticInitializers = nil
+	if classInfo.classExtraInitializersName != nil {
+		trailingBlockStatements = append(trailingBlockStatements, f.NewExpressionStatement(
+			f.NewRunInitializersHelper(renamedClassThis, classInfo.classExtraInitializersName, nil /*value*/),
+		))
+	}
+
+	// if there are no other static initializers, the leading and trailing blocks can be combined
+	if len(trailingBlockStatements) > 0 && !classInfo.hasStaticInitializers {
+		leadingBlockStatements = append(leadingBlockStatements, trailingBlockStatements...)
+		trailingBlockStatements = nil
+	}
+
+	leadingStaticBlock := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList(leadingBlockStatements), true /*multiLine*/))
+
+	// the leading `static {}` block follows any NamedEvaluation helper block
+	insertionIndex := 0
+	for i, member := range members {
+		if isClassNamedEvaluationHelperBlock(ctx, member) {
+			insertionIndex = i + 1
+			break
+		}
+	}
+	newMembers := make([]*ast.Node, 0, len(members)+3)
+	newMembers = append(newMembers, members[:insertionIndex]...)
+	newMembers = append(newMembers, leadingStaticBlock)
+	newMembers = append(newMembers, members[insertionIndex:]...)
+	if syntheticConstructor != nil {
+		newMembers = append(newMembers, syntheticConstructor)
+	}
+	if len(trailingBlockStatements) > 0 {
+		newMembers = append(newMembers, f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList(trailingBlockStatements), true /*multiLine*/)))
+	}
+	memberList := f.NewNodeList(newMembers)
+	memberList.Loc = node.MemberList().Loc
+
+	if classDecorators != nil {
+		// var C = class {
+		//     static { _classThis = this; }
+		//     ...
+		// };
+		// return C = _classThis;
+		classExpression := f.NewClassExpression(nil /*modifiers*/, nil /*name*/, nil /*typeParameters*/, heritageClauses, memberList)
+		ctx.SetOriginal(classExpression, node)
+		classExpression = injectClassThisAssignmentIfMissing(ctx, classExpression, classInfo.classThis)
+		classDefinitionStatements = append(classDefinitionStatements,
+			f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+				f.NewVariableDeclaration(classReference, nil /*exclamationToken*/, nil /*typeNode*/, classExpression),
+			}))),
+			f.NewReturnStatement(f.NewAssignmentExpression(classReference.Clone(f), classInfo.classThis)),
+		)
+	} else {
+		// return class C { ... };
+		classExpression := f.NewClassExpression(nil /*modifiers*/, node.Name(), nil /*typeParameters*/, heritageClauses, memberList)
+		ctx.SetOriginal(classExpression, node)
+		classDefinitionStatements = append(classDefinitionStatements, f.NewReturnStatement(classExpression))
+	}
+
+	classDefinitionStatements = ctx.EndAndMergeVariableEnvironment(classDefinitionStatements)
+
+	// (() => { ... })()
+	arrow := f.NewArrowFunction(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewToken(ast.KindEqualsGreaterThanToken),
+		f.NewBlock(f.NewNodeList(classDefinitionStatements), true /*multiLine*/),
+	)
+	return f.NewCallExpression(f.NewParenthesizedExpression(arrow), nil /*questionDotToken*/, nil /*typeArguments*/, f.NewNodeList([]*ast.Node{}), ast.NodeFlagsNone)
+}
+
+func (tx *esDecoratorTransformer) createLet(name *ast.IdentifierNode, initializer *ast.Expression) *ast.Statement {
+	f := tx.Factory()
+	return f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsLet, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(name, nil /*exclamationToken*/, nil /*typeNode*/, initializer),
+	})))
+}
+
+// Creates the `Symbol.metadata` object for a class:
+//
+//	const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(_classSuper[Symbol.metadata] ?? null) : void 0;
+func (tx *esDecoratorTransformer) createMetadata(name *ast.IdentifierNode, classSuper *ast.IdentifierNode) *ast.Statement {
+	f := tx.Factory()
+	var parent *ast.Expression
+	if classSuper != nil {
+		parent = f.NewBinaryExpression(
+			nil, /*modifiers*/
+			f.NewElementAccessExpression(classSuper, nil /*questionDotToken*/, tx.newSymbolMetadata(), ast.NodeFlagsNone),
+			nil, /*typeNode*/
+			f.NewToken(ast.KindQuestionQuestionToken),
+			f.NewKeywordExpression(ast.KindNullKeyword),
+		)
+	} else {
+		parent = f.NewKeywordExpression(ast.KindNullKeyword)
+	}
+	condition := f.NewBinaryExpression(
+		nil, /*modifiers*/
+		f.NewTypeCheck(f.NewIdentifier("Symbol"), "function"),
+		nil, /*typeNode*/
+		f.NewToken(ast.KindAmpersandAmpersandToken),
+		tx.newSymbolMetadata(),
+	)
+	initializer := f.NewConditionalExpression(
+		condition,
+		f.NewToken(ast.KindQuestionToken),
+		f.NewGlobalMethodCall("Object", "create", []*ast.Node{parent}),
+		f.NewToken(ast.KindColonToken),
+		f.NewVoidZeroExpression(),
+	)
+	return f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsConst, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(name, nil /*exclamationToken*/, nil /*typeNode*/, initializer),
+	})))
+}
+
+// Defines the `Symbol.metadata` property of a class:
+//
+//	if (_metadata) Object.defineProperty(C, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+func (tx *esDecoratorTransformer) createSymbolMetadata(target *ast.Expression, value *ast.IdentifierNode) *ast.Statement {
+	f := tx.Factory()
+	newProperty := func(name string, initializer *ast.Expression) *ast.Node {
+		return f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier(name), nil /*postfixToken*/, nil /*typeNode*/, initializer)
+	}
+	descriptor := f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+		newProperty("enumerable", f.NewTrueExpression()),
+		newProperty("configurable", f.NewTrueExpression()),
+		newProperty("writable", f.NewTrueExpression()),
+		newProperty("value", value),
+	}), false /*multiLine*/)
+	defineProperty := f.NewGlobalMethodCall("Object", "defineProperty", []*ast.Node{target, tx.newSymbolMetadata(), descriptor})
+	statement := f.NewIfStatement(value, f.NewExpressionStatement(defineProperty), nil /*elseStatement*/)
+	tx.EmitContext().SetEmitFlags(statement, printer.EFSingleLine)
+	return statement
+}
+
+func (tx *esDecoratorTransformer) newSymbolMetadata() *ast.Expression {
+	f := tx.Factory()
+	return f.NewPropertyAccessExpression(f.NewIdentifier("Symbol"), nil /*questionDotToken*/, f.NewIdentifier("metadata"), ast.NodeFlagsNone)
+}
+
+// Visits the decorators of a declaration, returning the evaluated decorator expressions.
+func (tx *esDecoratorTransformer) transformDecorators(node *ast.Node) []*ast.Expression {
+	var decorators []*ast.Expression
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) {
+				decorators = append(decorators, tx.transformDecorator(modifier))
+			}
+		}
+	}
+	return decorators
+}
+
+func (tx *esDecoratorTransformer) transformDecorator(decorator *ast.Node) *ast.Expression {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	expression := tx.Visitor().VisitNode(decorator.Expression())
+	ctx.AddEmitFlags(expression, printer.EFNoComments)
+
+	// a decorator such as `@a.b` is called with `a` as its `this`:
+	//
+	//  (_a = a).b.bind(_a)
+	innerExpression := ast.SkipOuterExpressions(expression, ast.OEKAll)
+	if !ast.IsAccessExpression(innerExpression) {
+		return expression
+	}
+	target := innerExpression
+	var thisArg *ast.Expression
+	object := innerExpression.Expression()
+	switch object.Kind {
+	case ast.KindThisKeyword, ast.KindSuperKeyword:
+		thisArg = f.NewThisExpression()
+	default:
+		temp := f.NewTempVariable()
+		ctx.AddVariableDeclaration(temp)
+		thisArg = temp
+		assignment := f.NewParenthesizedExpression(f.NewAssignmentExpression(temp, object))
+		if ast.IsPropertyAccessExpression(innerExpression) {
+			target = f.UpdatePropertyAccessExpression(innerExpression.AsPropertyAccessExpression(), assignment, nil /*questionDotToken*/, innerExpression.Name())
+		} else {
+			target = f.UpdateElementAccessExpression(innerExpression.AsElementAccessExpression(), assignment, nil /*questionDotToken*/, innerExpression.AsElementAccessExpression().ArgumentExpression)
+		}
+	}
+	bound := f.NewMethodCall(target, f.NewIdentifier("bind"), []*ast.Node{thisArg})
+	return f.RestoreOuterExpressions(expression, bound, ast.OEKAll)
+}
+
+//
+// Class elements
+//
+
+func (tx *esDecoratorTransformer) visitClassElement(member *ast.Node) []*ast.Node {
+	var updated *ast.Node
+	switch member.Kind {
+	case ast.KindMethodDeclaration:
+		updated = tx.visitMethodDeclaration(member.AsMethodDeclaration())
+	case ast.KindGetAccessor:
+		updated = tx.visitGetAccessorDeclaration(member.AsGetAccessorDeclaration())
+	case ast.KindSetAccessor:
+		updated = tx.visitSetAccessorDeclaration(member.AsSetAccessorDeclaration())
+	case ast.KindPropertyDeclaration:
+		return tx.visitPropertyDeclaration(member.AsPropertyDeclaration())
+	case ast.KindClassStaticBlockDeclaration:
+		return tx.visitClassStaticBlockDeclaration(member.AsClassStaticBlockDeclaration())
+	default:
+		updated = tx.Visitor().VisitNode(member)
+	}
+	if updated == nil {
+		return nil
+	}
+	return []*ast.Node{updated}
+}
+
+// Gets the base name for the helper variables of a class element, such as `_static_private_get_x`.
+func getHelperVariableName(node *ast.Node) string {
+	var declarationName string
+	name := node.Name()
+	switch {
+	case name != nil && ast.IsIdentifier(name):
+		declarationName = name.Text()
+	case name != nil && ast.IsPrivateIdentifier(name):
+		declarationName = strings.TrimPrefix(name.Text(), "#")
+	case name != nil && ast.IsStringLiteral(name) && scanner.IsIdentifierText(name.Text(), core.LanguageVariantStandard):
+		declarationName = name.Text()
+	case ast.IsClassLike(node):
+		declarationName = "class"
+	default:
+		declarationName = "member"
+	}
+	if ast.IsGetAccessorDeclaration(node) {
+		declarationName = "get_" + declarationName
+	}
+	if ast.IsSetAccessorDeclaration(node) {
+		declarationName = "set_" + declarationName
+	}
+	if name != nil && ast.IsPrivateIdentifier(name) {
+		declarationName = "private_" + declarationName
+	}
+	if ast.HasStaticModifier(node) {
+		declarationName = "static_" + declarationName
+	}
+	return "_" + declarationName
+}
+
+func (tx *esDecoratorTransformer) createHelperVariable(node *ast.Node, suffix string) *ast.IdentifierNode {
+	return tx.Factory().NewUniqueNameEx(getHelperVariableName(node)+"_"+suffix, printer.AutoGenerateOptions{
+		Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes,
+	})
+}
+
+// Transforms the decorators and name of a class element, adding the statements that apply its decorators to the
+// class.
+func (tx *esDecoratorTransformer) partialTransformClassElement(
+	member *ast.Node,
+	createDescriptor func(member *ast.Node, modifiers *ast.ModifierList) *ast.Expression,
+) decoratedClassElementParts {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	classInfo := tx.classInfo
+	var parts decoratedClassElementParts
+
+	decorators := tx.transformDecorators(member)
+	parts.modifiers = transformers.ExtractModifiers(ctx, member.Modifiers(), ^ast.ModifierFlagsDecorator)
+	if decorators == nil {
+		parts.name = tx.visitPropertyName(member.Name())
+		return parts
+	}
+
+	isStatic := ast.HasStaticModifier(member)
+	memberInfo := &decoratedMemberInfo{isStatic: isStatic, decoratorsName: tx.createHelperVariable(member, "decorators")}
+	classInfo.memberInfos = append(classInfo.memberInfos, memberInfo)
+
+	// _m_decorators = [dec];
+	tx.pendingExpressions = append(tx.pendingExpressions, f.NewAssignmentExpression(
+		memberInfo.decoratorsName,
+		f.NewArrayLiteralExpression(f.NewNodeList(decorators), false /*multiLine*/),
+	))
+
+	var statements *[]*ast.Statement
+	switch {
+	case (ast.IsMethodOrAccessor(member) || ast.IsAutoAccessorPropertyDeclaration(member)) && isStatic:
+		statements = &classInfo.staticNonFieldDecorationStatements
+	case ast.IsMethodOrAccessor(member) || ast.IsAutoAccessorPropertyDeclaration(member):
+		statements = &classInfo.nonStaticNonFieldDecorationStatements
+	case isStatic:
+		statements = &classInfo.staticFieldDecorationStatements
+	default:
+		statements = &classInfo.nonStaticFieldDecorationStatements
+	}
+
+	var kind string
+	switch {
+	case ast.IsGetAccessorDeclaration(member):
+		kind = "getter"
+	case ast.IsSetAccessorDeclaration(member):
+		kind = "setter"
+	case ast.IsMethodDeclaration(member):
+		kind = "method"
+	case ast.IsAutoAccessorPropertyDeclaration(member):
+		kind = "accessor"
+	default:
+		kind = "field"
+	}
+
+	var propertyName printer.ESDecorateName
+	name := member.Name()
+	switch {
+	case ast.IsIdentifier(name) || ast.IsPrivateIdentifier(name):
+		propertyName = printer.ESDecorateName{Name: name}
+		parts.name = tx.Visitor().VisitNode(name)
+	case ast.IsPropertyNameLiteral(name):
+		propertyName = printer.ESDecorateName{Computed: true, Name: f.NewStringLiteralFromNode(name)}
+		parts.name = tx.Visitor().VisitNode(name)
+	default:
+		var referencedName *ast.Expression
+		referencedName, parts.name = tx.visitReferencedPropertyName(name.AsComputedPropertyName())
+		propertyName = printer.ESDecorateName{Computed: true, Name: referencedName}
+	}
+
+	context := printer.ESDecorateContext{
+		Kind:     kind,
+		Name:     propertyName,
+		Static:   isStatic,
+		Private:  ast.IsPrivateIdentifier(name),
+		Get:      ast.IsPropertyDeclaration(member) || ast.IsGetAccessorDeclaration(member) || ast.IsMethodDeclaration(member),
+		Set:      ast.IsPropertyDeclaration(member) || ast.IsSetAccessorDeclaration(member),
+		Metadata: classInfo.metadataReference,
+	}
+
+	var descriptor *ast.Expression
+	if createDescriptor != nil && ast.IsPrivateIdentifier(name) {
+		// private members are defined by a descriptor that the decorators may replace
+		memberInfo.descriptorName = tx.createHelperVariable(member, "descriptor")
+		parts.descriptorName = memberInfo.descriptorName
+		descriptor = f.NewAssignmentExpression(parts.descriptorName, createDescriptor(member, transformers.ExtractModifiers(ctx, parts.modifiers, ast.ModifierFlagsAsync)))
+	} else {
+		descriptor = f.NewKeywordExpression(ast.KindNullKeyword)
+	}
+
+	var esDecorateExpression *ast.Expression
+	if ast.IsMethodOrAccessor(member) {
+		// __esDecorate(this, null, _m_decorators, { kind: "method", ... }, null, _instanceExtraInitializers);
+		extraInitializersName := core.IfElse(isStatic, classInfo.staticMethodExtraInitializersName, classInfo.instanceMethodExtraInitializersName)
+		esDecorateExpression = f.NewESDecorateHelper(
+			f.NewThisExpression(),
+			descriptor,
+			memberInfo.decoratorsName,
+			context,
+			f.NewKeywordExpression(ast.KindNullKeyword),
+			extraInitializersName,
+		)
+	} else {
+		// __esDecorate(null, null, _x_decorators, { kind: "field", ... }, _x_initializers, _x_extraInitializers);
+		memberInfo.initializersName = tx.createHelperVariable(member, "initializers")
+		memberInfo.extraInitializersName = tx.createHelperVariable(member, "extraInitializers")
+		parts.initializersName = memberInfo.initializersName
+		parts.extraInitializersName = memberInfo.extraInitializersName
+		if isStatic {
+			parts.thisArg = classInfo.classThis
+		}
+		esDecorateExpression = f.NewESDecorateHelper(
+			core.IfElse(ast.IsAutoAccessorPropertyDeclaration(member), f.NewThisExpression(), f.NewKeywordExpression(ast.KindNullKeyword)),
+			descriptor,
+			memberInfo.decoratorsName,
+			context,
+			memberInfo.initializersName,
+			memberInfo.extraInitializersName,
+		)
+	}
+	esDecorateStatement := f.NewExpressionStatement(esDecorateExpression)
+	ctx.SetSourceMapRange(esDecorateStatement, member.Loc)
+	*statements = append(*statements, esDecorateStatement)
+	return parts
+}
+
+func (tx *esDecoratorTransformer) visitPropertyName(node *ast.PropertyName) *ast.PropertyName {
+	if !ast.IsComputedPropertyName(node) {
+		return tx.Visitor().VisitNode(node)
+	}
+	// any pending decorators must be evaluated before this property name
+	expression := tx.Visitor().VisitNode(node.Expression())
+	if !transformers.IsSimpleInlineableExpression(expression) {
+		expression = tx.injectPendingExpressions(expression)
+	}
+	return tx.Factory().UpdateComputedPropertyName(node.AsComputedPropertyName(), expression)
+}
+
+// Visits a computed property name whose value is needed by the decorator context, producing a reference to the
+// evaluated property key and the updated name:
+//
+//	[_a = __propKey(x)]
+func (tx *esDecoratorTransformer) visitReferencedPropertyName(node *ast.ComputedPropertyName) (*ast.Expression, *ast.PropertyName) {
+	f := tx.Factory()
+	if ast.IsPropertyNameLiteral(node.Expression) && !ast.IsIdentifier(node.Expression) {
+		return f.NewStringLiteralFromNode(node.Expression), tx.Visitor().VisitNode(node.AsNode())
+	}
+	referencedName := f.NewGeneratedNameForNode(node.AsNode())
+	tx.EmitContext().AddVariableDeclaration(referencedName)
+	key := f.NewPropKeyHelper(tx.Visitor().VisitNode(node.Expression))
+	assignment := f.NewAssignmentExpression(referencedName, key)
+	return referencedName, f.UpdateComputedPropertyName(node, tx.injectPendingExpressions(assignment))
+}
+
+func (tx *esDecoratorTransformer) injectPendingExpressions(expression *ast.Expression) *ast.Expression {
+	if len(tx.pendingExpressions) == 0 {
+		return expression
+	}
+	f := tx.Factory()
+	if ast.IsParenthesizedExpression(expression) {
+		expression = f.UpdateParenthesizedExpression(expression.AsParenthesizedExpression(), f.InlineExpressions(append(tx.pendingExpressions, expression.Expression())))
+	} else {
+		expression = f.NewParenthesizedExpression(f.InlineExpressions(append(tx.pendingExpressions, expression)))
+	}
+	tx.pendingExpressions = nil
+	return expression
+}
+
+// Injects any pending static or instance initializers before the evaluation of `expression`.
+func (tx *esDecoratorTransformer) injectPendingInitializers(isStatic bool, expression *ast.Expression) *ast.Expression {
+	pendingInitializers := &tx.classInfo.pendingInstanceInitializers
+	if isStatic {
+		pendingInitializers = &tx.classInfo.pendingStaticInitializers
+	}
+	if len(*pendingInitializers) == 0 {
+		return expression
+	}
+	expressions := *pendingInitializers
+	if expression != nil {
+		expressions = append(expressions, expression)
+	}
+	*pendingInitializers = nil
+	return tx.Factory().InlineExpressions(expressions)
+}
+
+func (tx *esDecoratorTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	parts := tx.partialTransformClassElement(node.AsNode(), tx.createMethodDescriptorObject)
+	if parts.descriptorName != nil {
+		return tx.createMethodDescriptorForwarder(node.AsNode(), parts.modifiers, parts.name, parts.descriptorName)
+	}
+	ctx := tx.EmitContext()
+	parameters := ctx.VisitParameters(node.Parameters, tx.Visitor())
+	body := ctx.VisitFunctionBody(node.Body, tx.Visitor())
+	return tx.Factory().UpdateMethodDeclaration(node, parts.modifiers, node.AsteriskToken, parts.name, nil /*postfixToken*/, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+}
+
+func (tx *esDecoratorTransformer) visitGetAccessorDeclaration(node *ast.GetAccessorDeclaration) *ast.Node {
+	parts := tx.partialTransformClassElement(node.AsNode(), tx.createGetAccessorDescriptorObject)
+	if parts.descriptorName != nil {
+		return tx.createGetAccessorDescriptorForwarder(node.AsNode(), parts.modifiers, parts.name, parts.descriptorName)
+	}
+	ctx := tx.EmitContext()
+	parameters := ctx.VisitParameters(node.Parameters, tx.Visitor())
+	body := ctx.VisitFunctionBody(node.Body, tx.Visitor())
+	return tx.Factory().UpdateGetAccessorDeclaration(node, parts.modifiers, parts.name, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+}
+
+func (tx *esDecoratorTransformer) visitSetAccessorDeclaration(node *ast.SetAccessorDeclaration) *ast.Node {
+	parts := tx.partialTransformClassElement(node.AsNode(), tx.createSetAccessorDescriptorObject)
+	if parts.descriptorName != nil {
+		return tx.createSetAccessorDescriptorForwarder(node.AsNode(), parts.modifiers, parts.name, parts.descriptorName)
+	}
+	ctx := tx.EmitContext()
+	parameters := ctx.VisitParameters(node.Parameters, tx.Visitor())
+	body := ctx.VisitFunctionBody(node.Body, tx.Visitor())
+	return tx.Factory().UpdateSetAccessorDeclaration(node, parts.modifiers, parts.name, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+}
+
+func (tx *esDecoratorTransformer) visitPropertyDeclaration(node *ast.PropertyDeclaration) []*ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	classInfo := tx.classInfo
+	if isNamedEvaluationAnd(ctx, node.AsNode(), isAnonymousDecoratedClass) {
+		node = transformNamedEvaluation(ctx, node.AsNode(), false /*ignoreEmptyStringLiteral*/, "" /*assignedName*/).AsPropertyDeclaration()
+	}
+
+	// a private `accessor` field is replaced by a private backing field along with a `get` and `set` accessor
+	// that forward to the (possibly decorated) descriptor
+	isAutoAccessor := ast.IsAutoAccessorPropertyDeclaration(node.AsNode())
+	var storageName *ast.PrivateIdentifierNode
+	var createDescriptor func(*ast.Node, *ast.ModifierList) *ast.Expression
+	if isAutoAccessor && ast.IsPrivateIdentifier(node.Name()) {
+		storageName = f.NewGeneratedPrivateNameForNodeEx(node.Name(), printer.AutoGenerateOptions{Suffix: "_accessor_storage"})
+		createDescriptor = func(member *ast.Node, _ *ast.ModifierList) *ast.Expression {
+			return tx.createAccessorPropertyDescriptorObject(member, storageName)
+		}
+	}
+
+	parts := tx.partialTransformClassElement(node.AsNode(), createDescriptor)
+	isStatic := ast.HasStaticModifier(node.AsNode())
+
+	savedClassThis := tx.classThis
+	if isStatic {
+		tx.classThis = classInfo.classThis
+	}
+	initializer := tx.Visitor().VisitNode(node.Initializer)
+	tx.classThis = savedClassThis
+
+	if parts.initializersName != nil {
+		// __runInitializers(this, _x_initializers, 1)
+		thisArg := core.IfElse(parts.thisArg != nil, parts.thisArg, f.NewThisExpression())
+		if initializer == nil {
+			initializer = f.NewVoidZeroExpression()
+		}
+		initializer = f.NewRunInitializersHelper(thisArg, parts.initializersName, initializer)
+	}
+
+	initializer = tx.injectPendingInitializers(isStatic, initializer)
+	if parts.extraInitializersName != nil {
+		// the extra initializers of a field run once the field is defined
+		var extraInitializersThisArg *ast.Expression
+		if isStatic && classInfo.classThis != nil {
+			extraInitializersThisArg = classInfo.classThis
+		} else {
+			extraInitializersThisArg = f.NewThisExpression()
+		}
+		extraInitializers := f.NewRunInitializersHelper(extraInitializersThisArg, parts.extraInitializersName, nil /*value*/)
+		if isStatic {
+			classInfo.pendingStaticInitializers = append(classInfo.pendingStaticInitializers, extraInitializers)
+		} else {
+			classInfo.pendingInstanceInitializers = append(classInfo.pendingInstanceInitializers, extraInitializers)
+		}
+	}
+
+	if storageName != nil && parts.descriptorName != nil {
+		modifiers := transformers.ExtractModifiers(ctx, parts.modifiers, ^ast.ModifierFlagsAccessor)
+		storage := f.NewPropertyDeclaration(modifiers, storageName, nil /*postfixToken*/, nil /*typeNode*/, initializer)
+		ctx.SetOriginal(storage, node.AsNode())
+		ctx.AssignCommentAndSourceMapRanges(storage, node.AsNode())
+		getter := tx.createGetAccessorDescriptorForwarder(node.AsNode(), modifiers, parts.name, parts.descriptorName)
+		setter := tx.createSetAccessorDescriptorForwarder(node.AsNode(), modifiers, parts.name.Clone(f), parts.descriptorName)
+		return []*ast.Node{storage, getter, setter}
+	}
+
+	return []*ast.Node{f.UpdatePropertyDeclaration(node, parts.modifiers, parts.name, nil /*postfixToken*/, nil /*typeNode*/, initializer)}
+}
+
+func (tx *esDecoratorTransformer) visitClassStaticBlockDeclaration(node *ast.ClassStaticBlockDeclaration) []*ast.Node {
+	if isClassNamedEvaluationHelperBlock(tx.EmitContext(), node.AsNode()) {
+		return []*ast.Node{tx.Visitor().VisitEachChild(node.AsNode())}
+	}
+
+	classInfo := tx.classInfo
+	savedClassThis := tx.classThis
+	tx.classThis = classInfo.classThis
+	updated := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.classThis = savedClassThis
+
+	if len(classInfo.pendingStaticInitializers) == 0 {
+		return []*ast.Node{updated}
+	}
+
+	// The pending initializers are placed in a separate block preceding this one so that they cannot collide with
+	// the declarations of this block.
+	f := tx.Factory()
+	statements := make([]*ast.Statement, 0, len(classInfo.pendingStaticInitializers))
+	for _, initializer := range classInfo.pendingStaticInitializers {
+		statements = append(statements, f.NewExpressionStatement(initializer))
+	}
+	classInfo.pendingStaticInitializers = nil
+	staticBlock := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList(statements), true /*multiLine*/))
+	return []*ast.Node{staticBlock, updated}
+}
+
+func (tx *esDecoratorTransformer) visitConstructorDeclaration(node *ast.ConstructorDeclaration) *ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	if node.Body == nil || len(tx.classInfo.pendingInstanceInitializers) == 0 {
+		return tx.Visitor().VisitNode(node.AsNode())
+	}
+
+	// the pending instance initializers run once `this` is initialized
+	parameters := ctx.VisitParameters(node.Parameters, tx.Visitor())
+	body := node.Body.AsBlock()
+	prologue, rest := f.SplitStandardPrologue(body.Statements.Nodes)
+	rest = core.FirstResult(tx.Visitor().VisitSlice(rest))
+	superPath := transformers.FindSuperStatementIndexPath(rest, 0)
+	statements := append(prologue[:len(prologue):len(prologue)], insertStatementsAfterSuperCall(f, rest, superPath, tx.prepareConstructor(tx.classInfo), nil /*skip*/)...)
+
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = body.Statements.Loc
+	updatedBody := f.NewBlock(statementList, true /*multiLine*/)
+	ctx.SetOriginal(updatedBody, body.AsNode())
+	updatedBody.Loc = body.Loc
+	return f.UpdateConstructorDeclaration(node, node.Modifiers(), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, updatedBody)
+}
+
+// Produces the statement that runs any pending instance initializers in the constructor.
+func (tx *esDecoratorTransformer) prepareConstructor(classInfo *decoratedClassInfo) []*ast.Statement {
+	if len(classInfo.pendingInstanceInitializers) == 0 {
+		return nil
+	}
+	statement := tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(classInfo.pendingInstanceInitializers))
+	classInfo.pendingInstanceInitializers = nil
+	return []*ast.Statement{statement}
+}
+
+//
+// Private member descriptors
+//
+
+// Creates a property of a descriptor object for a private member:
+//
+//	value: __setFunctionName(function () { ... }, "#m")
+func (tx *esDecoratorTransformer) createDescriptorMethod(original *ast.Node, name *ast.PrivateIdentifierNode, modifiers *ast.ModifierList, asteriskToken *ast.TokenNode, kind string, parameters *ast.ParameterList, body *ast.BlockNode) *ast.Node {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	if body == nil {
+		body = f.NewBlock(f.NewNodeList([]*ast.Node{}), false /*multiLine*/)
+	}
+	fn := f.NewFunctionExpression(modifiers, asteriskToken, nil /*name*/, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	ctx.SetOriginal(fn, original)
+	ctx.SetSourceMapRange(fn, original.Loc)
+	ctx.AddEmitFlags(fn, printer.EFNoComments)
+	prefix := ""
+	if kind == "get" || kind == "set" {
+		prefix = kind
+	}
+	namedFunction := f.NewSetFunctionNameHelper(fn, f.NewStringLiteralFromNode(name), prefix)
+	method := f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier(kind), nil /*postfixToken*/, nil /*typeNode*/, namedFunction)
+	ctx.SetOriginal(method, original)
+	ctx.AddEmitFlags(method, printer.EFNoComments)
+	return method
+}
+
+// { value: __setFunctionName(function () { ... }, "#m") }
+func (tx *esDecoratorTransformer) createMethodDescriptorObject(node *ast.Node, modifiers *ast.ModifierList) *ast.Expression {
+	ctx := tx.EmitContext()
+	method := node.AsMethodDeclaration()
+	return tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+		tx.createDescriptorMethod(node, node.Name(), modifiers, method.AsteriskToken, "value", ctx.VisitParameters(method.Parameters, tx.Visitor()), ctx.VisitFunctionBody(method.Body, tx.Visitor())),
+	}), false /*multiLine*/)
+}
+
+// { get: __setFunctionName(function () { ... }, "#x", "get") }
+func (tx *esDecoratorTransformer) createGetAccessorDescriptorObject(node *ast.Node, modifiers *ast.ModifierList) *ast.Expression {
+	ctx := tx.EmitContext()
+	accessor := node.AsGetAccessorDeclaration()
+	return tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+		tx.createDescriptorMethod(node, node.Name(), modifiers, nil /*asteriskToken*/, "get", ctx.VisitParameters(accessor.Parameters, tx.Visitor()), ctx.VisitFunctionBody(accessor.Body, tx.Visitor())),
+	}), false /*multiLine*/)
+}
+
+// { set: __setFunctionName(function (value) { ... }, "#x", "set") }
+func (tx *esDecoratorTransformer) createSetAccessorDescriptorObject(node *ast.Node, modifiers *ast.ModifierList) *ast.Expression {
+	ctx := tx.EmitContext()
+	accessor := node.AsSetAccessorDeclaration()
+	return tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+		tx.createDescriptorMethod(node, node.Name(), modifiers, nil /*asteriskToken*/, "set", ctx.VisitParameters(accessor.Parameters, tx.Visitor()), ctx.VisitFunctionBody(accessor.Body, tx.Visitor())),
+	}), false /*multiLine*/)
+}
+
+// { get: __setFunctionName(function () { return this.#x_accessor_storage; }, "#x", "get"), set: __setFunctionName(function (value) { this.#x_accessor_storage = value; }, "#x", "set") }
+func (tx *esDecoratorTransformer) createAccessorPropertyDescriptorObject(node *ast.Node, storageName *ast.PrivateIdentifierNode) *ast.Expression {
+	f := tx.Factory()
+	newStorageAccess := func() *ast.Expression {
+		return f.NewPropertyAccessExpression(f.NewThisExpression(), nil /*questionDotToken*/, storageName.Clone(f), ast.NodeFlagsNone)
+	}
+	value := f.NewIdentifier("value")
+	getter := tx.createDescriptorMethod(node, node.Name(), nil /*modifiers*/, nil /*asteriskToken*/, "get", f.NewNodeList([]*ast.Node{}),
+		f.NewBlock(f.NewNodeList([]*ast.Node{f.NewReturnStatement(newStorageAccess())}), false /*multiLine*/),
+	)
+	setter := tx.createDescriptorMethod(node, node.Name(), nil /*modifiers*/, nil /*asteriskToken*/, "set",
+		f.NewNodeList([]*ast.Node{f.NewParameterDeclaration(nil, nil, value, nil, nil, nil)}),
+		f.NewBlock(f.NewNodeList([]*ast.Node{f.NewExpressionStatement(f.NewAssignmentExpression(newStorageAccess(), value.Clone(f)))}), false /*multiLine*/),
+	)
+	return f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{getter, setter}), false /*multiLine*/)
+}
+
+// get #m() { return _private_m_descriptor.value; }
+func (tx *esDecoratorTransformer) createMethodDescriptorForwarder(original *ast.Node, modifiers *ast.ModifierList, name *ast.PropertyName, descriptorName *ast.IdentifierNode) *ast.Node {
+	f := tx.Factory()
+	forwarder := f.NewGetAccessorDeclaration(
+		transformers.ExtractModifiers(tx.EmitContext(), modifiers, ast.ModifierFlagsStatic),
+		name,
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList([]*ast.Node{
+			f.NewReturnStatement(f.NewPropertyAccessExpression(descriptorName, nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)),
+		}), false /*multiLine*/),
+	)
+	tx.EmitContext().SetOriginal(forwarder, original)
+	return forwarder
+}
+
+// get #x() { return _private_x_descriptor.get.call(this); }
+func (tx *esDecoratorTransformer) createGetAccessorDescriptorForwarder(original *ast.Node, modifiers *ast.ModifierList, name *ast.PropertyName, descriptorName *ast.IdentifierNode) *ast.Node {
+	f := tx.Factory()
+	target := f.NewPropertyAccessExpression(descriptorName, nil /*questionDotToken*/, f.NewIdentifier("get"), ast.NodeFlagsNone)
+	forwarder := f.NewGetAccessorDeclaration(
+		transformers.ExtractModifiers(tx.EmitContext(), modifiers, ast.ModifierFlagsStatic),
+		name,
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList([]*ast.Node{
+			f.NewReturnStatement(f.NewFunctionCallCall(target, f.NewThisExpression(), nil /*argumentsList*/)),
+		}), false /*multiLine*/),
+	)
+	tx.EmitContext().SetOriginal(forwarder, original)
+	return forwarder
+}
+
+// set #x(value) { return _private_x_descriptor.set.call(this, value); }
+func (tx *esDecoratorTransformer) createSetAccessorDescriptorForwarder(original *ast.Node, modifiers *ast.ModifierList, name *ast.PropertyName, descriptorName *ast.IdentifierNode) *ast.Node {
+	f := tx.Factory()
+	value := f.NewIdentifier("value")
+	target := f.NewPropertyAccessExpression(descriptorName, nil /*questionDotToken*/, f.NewIdentifier("set"), ast.NodeFlagsNone)
+	forwarder := f.NewSetAccessorDeclaration(
+		transformers.ExtractModifiers(tx.EmitContext(), modifiers, ast.ModifierFlagsStatic),
+		name,
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{f.NewParameterDeclaration(nil, nil, value, nil, nil, nil)}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList([]*ast.Node{
+			f.NewReturnStatement(f.NewFunctionCallCall(target, f.NewThisExpression(), []*ast.Node{value.Clone(f)})),
+		}), false /*multiLine*/),
+	)
+	tx.EmitContext().SetOriginal(forwarder, original)
+	return forwarder
+}
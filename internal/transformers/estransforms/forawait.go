@@ -2,18 +2,572 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
+type forawaitFunctionScope struct {
+	inAsyncFunction      bool
+	inGenerator          bool
+	inIterationStatement bool
+	superAccess          *superAccessInfo
+}
+
+// Transforms `for await..of` statements and async generator functions for targets that lack native support
+// for async iteration. The `await` expressions that this transform introduces outside of async generators
+// are lowered by the async function transform.
 type forawaitTransformer struct {
 	transformers.Transformer
-}
 
-func (ch *forawaitTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	inAsyncFunction      bool             // whether the enclosing function is an async function or async generator
+	inGenerator          bool             // whether the enclosing function is a generator
+	inIterationStatement bool             // whether the node being visited is within an iteration statement of the enclosing function
+	superAccess          *superAccessInfo // `super` accesses within the body of an async generator method
 }
 
 func newforawaitTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
 	tx := &forawaitTransformer{}
 	return tx.NewTransformer(tx.visit, opts.Context)
 }
+
+func (tx *forawaitTransformer) inAsyncGenerator() bool {
+	return tx.inAsyncFunction && tx.inGenerator
+}
+
+func (tx *forawaitTransformer) saveScope() forawaitFunctionScope {
+	return forawaitFunctionScope{
+		inAsyncFunction:      tx.inAsyncFunction,
+		inGenerator:          tx.inGenerator,
+		inIterationStatement: tx.inIterationStatement,
+		superAccess:          tx.superAccess,
+	}
+}
+
+func (tx *forawaitTransformer) restoreScope(scope forawaitFunctionScope) {
+	tx.inAsyncFunction = scope.inAsyncFunction
+	tx.inGenerator = scope.inGenerator
+	tx.inIterationStatement = scope.inIterationStatement
+	tx.superAccess = scope.superAccess
+}
+
+// Enters the scope of a function-like declaration. Arrow functions share `super` with their container.
+func (tx *forawaitTransformer) enterFunction(node *ast.Node) forawaitFunctionScope {
+	scope := tx.saveScope()
+	tx.inAsyncFunction = node.ModifierFlags()&ast.ModifierFlagsAsync != 0
+	tx.inGenerator = node.BodyData().AsteriskToken != nil
+	tx.inIterationStatement = false
+	if !ast.IsArrowFunction(node) {
+		tx.superAccess = nil
+		if ast.IsMethodDeclaration(node) && tx.inAsyncGenerator() && node.Body() != nil {
+			// every `super` access in an async generator method is moved into the generator function we emit
+			tx.superAccess = collectSuperAccessInfo(tx.EmitContext(), node.Body(), true /*inAsync*/)
+		}
+	}
+	return scope
+}
+
+func (tx *forawaitTransformer) visit(node *ast.Node) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsForAwaitOrAsyncGenerator == 0 && !tx.inAsyncGenerator() && tx.superAccess == nil {
+		return node
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindAwaitExpression:
+		return tx.visitAwaitExpression(node.AsAwaitExpression())
+	case ast.KindYieldExpression:
+		return tx.visitYieldExpression(node.AsYieldExpression())
+	case ast.KindReturnStatement:
+		return tx.visitReturnStatement(node.AsReturnStatement())
+	case ast.KindLabeledStatement:
+		return tx.visitLabeledStatement(node.AsLabeledStatement())
+	case ast.KindForOfStatement:
+		return tx.visitForOfStatement(node.AsForInOrOfStatement(), nil /*outermostLabeledStatement*/)
+	case ast.KindForStatement, ast.KindForInStatement, ast.KindWhileStatement, ast.KindDoStatement:
+		return tx.visitIterationStatement(node)
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(node.AsMethodDeclaration())
+	case ast.KindFunctionDeclaration:
+		return tx.visitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindFunctionExpression:
+		return tx.visitFunctionExpression(node.AsFunctionExpression())
+	case ast.KindArrowFunction, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor:
+		scope := tx.enterFunction(node)
+		defer tx.restoreScope(scope)
+		return tx.Visitor().VisitEachChild(node)
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		return tx.visitClassLike(node)
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		if tx.superAccess != nil && isSuperProperty(node) {
+			return newSuperAccessExpression(tx.EmitContext(), node, tx.superAccess, tx.Visitor())
+		}
+		return tx.Visitor().VisitEachChild(node)
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *forawaitTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited.AsNode(), tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Visits an `await` expression in an async generator, which becomes `yield __await(x)`.
+func (tx *forawaitTransformer) visitAwaitExpression(node *ast.AwaitExpression) *ast.Node {
+	if !tx.inAsyncGenerator() {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	updated := tx.Factory().NewYieldExpression(nil /*asteriskToken*/, tx.Factory().NewAwaitHelper(tx.Visitor().VisitNode(node.Expression)))
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+// Visits a `yield` expression in an async generator. The yielded value is awaited before it is produced, so
+// `yield x` becomes `yield yield __await(x)` and `yield* x` delegates to an async iterator for `x`.
+func (tx *forawaitTransformer) visitYieldExpression(node *ast.YieldExpression) *ast.Node {
+	if !tx.inAsyncGenerator() {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	f := tx.Factory()
+	var updated *ast.Node
+	if node.AsteriskToken != nil {
+		// `yield* x` becomes `yield __await(yield* __asyncDelegator(__asyncValues(x)))`
+		expression := tx.Visitor().VisitNode(node.Expression)
+		asyncValues := f.NewAsyncValuesHelper(expression)
+		asyncValues.Loc = expression.Loc
+		asyncDelegator := f.NewAsyncDelegatorHelper(asyncValues)
+		asyncDelegator.Loc = expression.Loc
+		updated = f.NewYieldExpression(
+			nil, /*asteriskToken*/
+			f.NewAwaitHelper(f.UpdateYieldExpression(node, node.AsteriskToken, asyncDelegator)),
+		)
+	} else {
+		expression := tx.Visitor().VisitNode(node.Expression)
+		if expression == nil {
+			expression = f.NewVoidZeroExpression()
+		}
+		updated = f.NewYieldExpression(nil /*asteriskToken*/, tx.createDownlevelAwait(expression))
+	}
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+// Visits a `return` statement in an async generator, whose operand is awaited before the generator completes.
+func (tx *forawaitTransformer) visitReturnStatement(node *ast.ReturnStatement) *ast.Node {
+	if !tx.inAsyncGenerator() {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	expression := tx.Visitor().VisitNode(node.Expression)
+	if expression == nil {
+		expression = tx.Factory().NewVoidZeroExpression()
+	}
+	return tx.Factory().UpdateReturnStatement(node, tx.createDownlevelAwait(expression))
+}
+
+// Awaits an expression, using `yield __await(x)` within an async generator and `await x` otherwise.
+func (tx *forawaitTransformer) createDownlevelAwait(expression *ast.Expression) *ast.Expression {
+	if tx.inGenerator {
+		return tx.Factory().NewYieldExpression(nil /*asteriskToken*/, tx.Factory().NewAwaitHelper(expression))
+	}
+	return tx.Factory().NewAwaitExpression(expression)
+}
+
+func (tx *forawaitTransformer) visitLabeledStatement(node *ast.LabeledStatement) *ast.Node {
+	if tx.inAsyncFunction {
+		statement := node.Statement
+		for ast.IsLabeledStatement(statement) {
+			statement = statement.AsLabeledStatement().Statement
+		}
+		if ast.IsForOfStatement(statement) && statement.AsForInOrOfStatement().AwaitModifier != nil {
+			// the labels must remain on the loop that replaces the `for await` statement so that `continue` still works
+			return tx.visitForOfStatement(statement.AsForInOrOfStatement(), node)
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *forawaitTransformer) visitIterationStatement(node *ast.Node) *ast.Node {
+	inIterationStatement := tx.inIterationStatement
+	tx.inIterationStatement = true
+	defer func() { tx.inIterationStatement = inIterationStatement }()
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *forawaitTransformer) visitForOfStatement(node *ast.ForInOrOfStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	if node.AwaitModifier == nil {
+		return tx.visitIterationStatement(node.AsNode())
+	}
+	return tx.transformForAwaitOfStatement(node, outermostLabeledStatement)
+}
+
+// Transforms a `for await..of` statement into a `for` statement that steps an async iterator, closing the
+// iterator if the loop exits early:
+//
+//	try {
+//	    for (var _d = true, y_1 = __asyncValues(y), y_1_1; y_1_1 = await y_1.next(), _a = y_1_1.done, !_a; _d = true) {
+//	        _c = y_1_1.value;
+//	        _d = false;
+//	        const x = _c;
+//	    }
+//	}
+//	catch (e_1_1) { e_1 = { error: e_1_1 }; }
+//	finally {
+//	    try {
+//	        if (!_d && !_a && (_b = y_1.return)) await _b.call(y_1);
+//	    }
+//	    finally { if (e_1) throw e_1.error; }
+//	}
+func (tx *forawaitTransformer) transformForAwaitOfStatement(node *ast.ForInOrOfStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	inIterationStatement := tx.inIterationStatement
+	tx.inIterationStatement = true
+	defer func() { tx.inIterationStatement = inIterationStatement }()
+
+	f := tx.Factory()
+	expression := tx.Visitor().VisitNode(node.Expression)
+	var iterator, result *ast.IdentifierNode
+	if ast.IsIdentifier(expression) {
+		iterator = f.NewGeneratedNameForNode(expression)
+		result = f.NewGeneratedNameForNode(iterator)
+	} else {
+		iterator = f.NewTempVariable()
+		result = f.NewTempVariable()
+	}
+	nonUserCode := f.NewTempVariable()
+	done := f.NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(done)
+	errorRecord := f.NewUniqueName("e")
+	catchVariable := f.NewGeneratedNameForNode(errorRecord)
+	returnMethod := f.NewTempVariable()
+	callValues := f.NewAsyncValuesHelper(expression)
+	callValues.Loc = node.Expression.Loc
+	callNext := f.NewCallExpression(
+		f.NewPropertyAccessExpression(iterator, nil /*questionDotToken*/, f.NewIdentifier("next"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{}),
+		ast.NodeFlagsNone,
+	)
+	getDone := f.NewPropertyAccessExpression(result, nil /*questionDotToken*/, f.NewIdentifier("done"), ast.NodeFlagsNone)
+	getValue := f.NewPropertyAccessExpression(result, nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)
+	callReturn := f.NewFunctionCallCall(returnMethod, iterator, nil /*argumentsList*/)
+
+	tx.EmitContext().AddVariableDeclaration(errorRecord)
+	tx.EmitContext().AddVariableDeclaration(returnMethod)
+
+	// if we are enclosed in an outer loop, ensure we reset the error record for each iteration
+	initializer := callValues
+	if inIterationStatement {
+		initializer = f.InlineExpressions([]*ast.Expression{
+			f.NewAssignmentExpression(errorRecord, f.NewVoidZeroExpression()),
+			callValues,
+		})
+	}
+
+	iteratorDeclaration := f.NewVariableDeclaration(iterator, nil /*exclamationToken*/, nil /*typeNode*/, initializer)
+	iteratorDeclaration.Loc = node.Expression.Loc
+	declarationList := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(nonUserCode, nil /*exclamationToken*/, nil /*typeNode*/, f.NewTrueExpression()),
+		iteratorDeclaration,
+		f.NewVariableDeclaration(result, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/),
+	}))
+	declarationList.Loc = node.Expression.Loc
+	tx.EmitContext().AddEmitFlags(declarationList, printer.EFNoHoisting)
+
+	forStatement := f.NewForStatement(
+		declarationList,
+		f.InlineExpressions([]*ast.Expression{
+			f.NewAssignmentExpression(result, tx.createDownlevelAwait(callNext)),
+			f.NewAssignmentExpression(done, getDone),
+			f.NewPrefixUnaryExpression(ast.KindExclamationToken, done),
+		}),
+		f.NewAssignmentExpression(nonUserCode, f.NewTrueExpression()),
+		tx.convertForOfStatementHead(node, getValue, nonUserCode),
+	)
+	forStatement.Loc = node.Loc
+	tx.EmitContext().AddEmitFlags(forStatement, printer.EFNoTokenTrailingSourceMaps)
+	tx.EmitContext().SetOriginal(forStatement, node.AsNode())
+
+	catchBlock := f.NewBlock(f.NewNodeList([]*ast.Statement{
+		f.NewExpressionStatement(f.NewAssignmentExpression(
+			errorRecord,
+			f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+				f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("error"), nil /*postfixToken*/, nil /*typeNode*/, catchVariable),
+			}), false /*multiLine*/),
+		)),
+	}), false /*multiLine*/)
+	tx.EmitContext().AddEmitFlags(catchBlock, printer.EFSingleLine)
+
+	closeIterator := f.NewIfStatement(
+		f.NewLogicalANDExpression(
+			f.NewLogicalANDExpression(
+				f.NewPrefixUnaryExpression(ast.KindExclamationToken, nonUserCode),
+				f.NewPrefixUnaryExpression(ast.KindExclamationToken, done),
+			),
+			f.NewAssignmentExpression(
+				returnMethod,
+				f.NewPropertyAccessExpression(iterator, nil /*questionDotToken*/, f.NewIdentifier("return"), ast.NodeFlagsNone),
+			),
+		),
+		f.NewExpressionStatement(tx.createDownlevelAwait(callReturn)),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().AddEmitFlags(closeIterator, printer.EFSingleLine)
+
+	rethrowError := f.NewIfStatement(
+		errorRecord,
+		f.NewThrowStatement(f.NewPropertyAccessExpression(errorRecord, nil /*questionDotToken*/, f.NewIdentifier("error"), ast.NodeFlagsNone)),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().AddEmitFlags(rethrowError, printer.EFSingleLine)
+	finallyBlock := f.NewBlock(f.NewNodeList([]*ast.Statement{rethrowError}), false /*multiLine*/)
+	tx.EmitContext().AddEmitFlags(finallyBlock, printer.EFSingleLine)
+
+	return f.NewTryStatement(
+		f.NewBlock(f.NewNodeList([]*ast.Statement{restoreEnclosingLabel(f, forStatement, outermostLabeledStatement)}), true /*multiLine*/),
+		f.NewCatchClause(f.NewVariableDeclaration(catchVariable, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/), catchBlock),
+		f.NewBlock(f.NewNodeList([]*ast.Statement{
+			f.NewTryStatement(
+				f.NewBlock(f.NewNodeList([]*ast.Statement{closeIterator}), true /*multiLine*/),
+				nil, /*catchClause*/
+				finallyBlock,
+			),
+		}), true /*multiLine*/),
+	)
+}
+
+// Creates the body of the loop that replaces a `for await..of` statement, which binds the value of the current
+// iteration to the initializer before running the original body.
+func (tx *forawaitTransformer) convertForOfStatementHead(node *ast.ForInOrOfStatement, boundValue *ast.Expression, nonUserCode *ast.IdentifierNode) *ast.BlockNode {
+	f := tx.Factory()
+	value := f.NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(value)
+
+	iteratorValueStatement := f.NewExpressionStatement(f.NewAssignmentExpression(value, boundValue))
+	tx.EmitContext().SetSourceMapRange(iteratorValueStatement, node.Expression.Loc)
+
+	exitNonUserCodeStatement := f.NewExpressionStatement(f.NewAssignmentExpression(nonUserCode, f.NewFalseExpression()))
+	tx.EmitContext().SetSourceMapRange(exitNonUserCodeStatement, node.Expression.Loc)
+
+	statements := []*ast.Statement{iteratorValueStatement, exitNonUserCodeStatement}
+	if binding := tx.Visitor().VisitNode(createForOfBindingStatement(f, node.Initializer, value)); binding != nil {
+		statements = append(statements, binding)
+	}
+
+	bodyLocation := core.UndefinedTextRange()
+	statementsLocation := core.UndefinedTextRange()
+	statement := tx.EmitContext().VisitEmbeddedStatement(node.Statement, tx.Visitor())
+	if ast.IsBlock(statement) {
+		statements = append(statements, statement.AsBlock().Statements.Nodes...)
+		bodyLocation = statement.Loc
+		statementsLocation = statement.AsBlock().Statements.Loc
+	} else if statement != nil {
+		statements = append(statements, statement)
+	}
+
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = statementsLocation
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	block.Loc = bodyLocation
+	return block
+}
+
+func (tx *forawaitTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	name := tx.Visitor().VisitNode(node.Name())
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	if tx.inAsyncGenerator() && node.Body != nil {
+		return tx.Factory().UpdateMethodDeclaration(
+			node,
+			tx.visitAsyncGeneratorModifiers(node.Modifiers()),
+			nil, /*asteriskToken*/
+			name,
+			node.PostfixToken,
+			nil, /*typeParameters*/
+			tx.transformAsyncGeneratorFunctionParameterList(node.ParameterList()),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.transformAsyncGeneratorFunctionBody(node.AsNode()),
+		)
+	}
+	return tx.Factory().UpdateMethodDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.AsteriskToken,
+		name,
+		node.PostfixToken,
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *forawaitTransformer) visitFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	if tx.inAsyncGenerator() && node.Body != nil {
+		return tx.Factory().UpdateFunctionDeclaration(
+			node,
+			tx.visitAsyncGeneratorModifiers(node.Modifiers()),
+			nil, /*asteriskToken*/
+			node.Name(),
+			nil, /*typeParameters*/
+			tx.transformAsyncGeneratorFunctionParameterList(node.ParameterList()),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.transformAsyncGeneratorFunctionBody(node.AsNode()),
+		)
+	}
+	return tx.Factory().UpdateFunctionDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.AsteriskToken,
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *forawaitTransformer) visitFunctionExpression(node *ast.FunctionExpression) *ast.Node {
+	scope := tx.enterFunction(node.AsNode())
+	defer tx.restoreScope(scope)
+	if tx.inAsyncGenerator() {
+		return tx.Factory().UpdateFunctionExpression(
+			node,
+			tx.visitAsyncGeneratorModifiers(node.Modifiers()),
+			nil, /*asteriskToken*/
+			node.Name(),
+			nil, /*typeParameters*/
+			tx.transformAsyncGeneratorFunctionParameterList(node.ParameterList()),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.transformAsyncGeneratorFunctionBody(node.AsNode()),
+		)
+	}
+	return tx.Factory().UpdateFunctionExpression(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.AsteriskToken,
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *forawaitTransformer) visitClassLike(node *ast.Node) *ast.Node {
+	// class members have their own `super`, and a loop in the enclosing function does not repeat them
+	scope := tx.saveScope()
+	defer tx.restoreScope(scope)
+	tx.inIterationStatement = false
+	tx.superAccess = nil
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *forawaitTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if tx.superAccess != nil && isSuperProperty(node.Expression) {
+		// `super.x(...)` becomes `_super.x.call(this, ...)`
+		target := newSuperAccessExpression(tx.EmitContext(), node.Expression, tx.superAccess, tx.Visitor())
+		updated := tx.Factory().NewFunctionCallCall(target, tx.Factory().NewThisExpression(), tx.Visitor().VisitNodes(node.Arguments).Nodes)
+		tx.EmitContext().SetOriginal(updated, node.AsNode())
+		updated.Loc = node.Loc
+		return updated
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *forawaitTransformer) visitAsyncGeneratorModifiers(modifiers *ast.ModifierList) *ast.ModifierList {
+	return tx.Visitor().VisitModifiers(transformers.ExtractModifiers(tx.EmitContext(), modifiers, ^ast.ModifierFlagsAsync))
+}
+
+// Creates the outer parameter list of an async generator. Parameters with initializers or binding patterns are
+// evaluated by the inner generator function when the async generator is first resumed, so the outer function
+// only declares placeholders up to the first initializer or rest parameter to preserve its `length`.
+func (tx *forawaitTransformer) transformAsyncGeneratorFunctionParameterList(parameterList *ast.ParameterList) *ast.ParameterList {
+	if isSimpleParameterList(parameterList) {
+		return tx.Visitor().VisitNodes(parameterList)
+	}
+	var parameters []*ast.Node
+	for _, parameter := range parameterList.Nodes {
+		if parameter.Initializer() != nil || parameter.AsParameterDeclaration().DotDotDotToken != nil {
+			break
+		}
+		parameters = append(parameters, tx.Factory().NewParameterDeclaration(
+			nil, /*modifiers*/
+			nil, /*dotDotDotToken*/
+			tx.Factory().NewGeneratedNameForNodeEx(parameter.Name(), printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes}),
+			nil, /*questionToken*/
+			nil, /*typeNode*/
+			nil, /*initializer*/
+		))
+	}
+	newParameterList := tx.Factory().NewNodeList(parameters)
+	newParameterList.Loc = parameterList.Loc
+	return newParameterList
+}
+
+// Transforms the body of an async generator into a call to the `__asyncGenerator` helper with a generator function
+// whose `await` expressions, `yield` expressions, and `return` statements have been rewritten.
+func (tx *forawaitTransformer) transformAsyncGeneratorFunctionBody(node *ast.Node) *ast.BlockNode {
+	f := tx.Factory()
+	parameterList := node.ParameterList()
+
+	var innerParameters *ast.ParameterList
+	if isSimpleParameterList(parameterList) {
+		tx.EmitContext().StartVariableEnvironment()
+		innerParameters = f.NewNodeList([]*ast.Node{})
+	} else {
+		innerParameters = tx.EmitContext().VisitParameters(parameterList, tx.Visitor())
+	}
+
+	body := node.Body().AsBlock()
+	prologue, rest := f.SplitStandardPrologue(body.Statements.Nodes)
+	statements := f.NewNodeList(rest)
+	statements.Loc = body.Statements.Loc
+	generatorBody := f.UpdateBlock(body, tx.EmitContext().EndAndMergeVariableEnvironmentList(tx.Visitor().VisitNodes(statements)))
+
+	var name *ast.IdentifierNode
+	if node.Name() != nil {
+		name = f.NewGeneratedNameForNode(node.Name())
+	}
+	generatorFunc := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		f.NewToken(ast.KindAsteriskToken),
+		name,
+		nil, /*typeParameters*/
+		innerParameters,
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		generatorBody,
+	)
+
+	// async generators are never arrow functions, so they always have their own `this`
+	returnStatement := f.NewReturnStatement(f.NewAsyncGeneratorHelper(generatorFunc, true /*hasLexicalThis*/))
+
+	outerStatements := make([]*ast.Statement, 0, len(prologue)+2)
+	outerStatements = append(outerStatements, prologue...)
+	if tx.superAccess != nil && len(tx.superAccess.names) > 0 {
+		outerStatements = append(outerStatements, newSuperAccessVariableStatement(f, tx.superAccess))
+	}
+	outerStatements = append(outerStatements, returnStatement)
+	outerStatementList := f.NewNodeList(outerStatements)
+	outerStatementList.Loc = body.Statements.Loc
+	block := f.UpdateBlock(body, outerStatementList)
+	if tx.superAccess != nil && tx.superAccess.hasElementAccess {
+		f.AddAsyncSuperHelper(block, tx.superAccess.hasBinding)
+	}
+	return block
+}
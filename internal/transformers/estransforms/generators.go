@@ -0,0 +1,2450 @@
+package estransforms
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/binder"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/debug"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/transformers"
+)
+
+// Transforms generator functions into state machines that are driven by the `__generator` helper.
+//
+// The body of a generator function is rewritten as a series of operations (statements, assignments, breaks,
+// and completions) separated by labels. Each label becomes a `case` of a `switch` over the current state of
+// the generator, and each `yield` suspends the state machine by returning an instruction to the helper:
+//
+//	function* f() {                     function f() {
+//	    const x = yield 1;                  var x;
+//	    return x;                =>         return __generator(this, function (_a) {
+//	}                                           switch (_a.label) {
+//	                                                case 0: return [4 /*yield*/, 1];
+//	                                                case 1:
+//	                                                    x = _a.sent();
+//	                                                    return [2 /*return*/, x];
+//	                                            }
+//	                                        });
+//	                                    }
+//
+// Declarations are hoisted to the enclosing function so they remain visible from every state. Block-scoped
+// declarations that would then collide with another binding of the same name are renamed.
+type generatorTransformer struct {
+	transformers.Transformer
+	resolver binder.ReferenceResolver
+
+	inGeneratorFunctionBody    bool                              // whether the node being visited is within the body of a generator function
+	inStatementContainingYield bool                              // whether the node being visited is within a statement that contains a `yield`
+	renamedBindings            map[*ast.Node]*ast.IdentifierNode // the unique names of the identifiers that declare or refer to renamed bindings
+
+	generatorBody
+}
+
+// The state used to build the state machine of a single generator function body.
+type generatorBody struct {
+	// The following state is collected while visiting the body of the generator.
+	blocks           []*codeBlock                   // the code blocks, in the order they were opened or closed
+	blockOffsets     []int                          // the operation offset at which each block action occurs
+	blockActions     []blockAction                  // whether each entry in `blocks` was opened or closed
+	blockStack       []*codeBlock                   // the currently open code blocks
+	labelOffsets     []int                          // the operation offset of each label, or -1 if it is not yet marked
+	labelExpressions map[generatorLabel][]*ast.Node // the numeric literals that reference each label
+	nextLabelID      generatorLabel                 // the next label to define
+	operations       []*generatorOperation          // the operations of the state machine
+	state            *ast.IdentifierNode            // the name of the `state` parameter of the generator body
+
+	// The following state is used while building the state machine.
+	blockIndex                 int                // the index of the next block action to process
+	labelNumber                int                // the number of the `case` clause currently being written
+	labelNumbers               [][]generatorLabel // the labels marked at each `case` clause
+	lastOperationWasAbrupt     bool               // whether the last operation written was a break, return, yield, or throw
+	lastOperationWasCompletion bool               // whether the last operation written was a return or throw
+	clauses                    []*ast.Node        // the `case` clauses written so far
+	statements                 []*ast.Statement   // the statements of the `case` clause currently being written
+	exceptionBlockStack        []*codeBlock       // the exception blocks that enclose the current exception block
+	currentExceptionBlock      *codeBlock         // the exception block whose region is being written
+	withBlockStack             []*codeBlock       // the `with` blocks that enclose the current operation
+}
+
+type generatorLabel int
+
+type generatorOpCode int
+
+const (
+	opCodeNop            generatorOpCode = iota // no operation, used to force a new case in the state machine
+	opCodeStatement                             // a regular statement
+	opCodeAssign                                // an assignment
+	opCodeBreak                                 // a break instruction used to jump to a label
+	opCodeBreakWhenTrue                         // a break instruction used to jump to a label if a condition evaluates to true
+	opCodeBreakWhenFalse                        // a break instruction used to jump to a label if a condition evaluates to false
+	opCodeYield                                 // a completion instruction for the `yield` keyword
+	opCodeYieldStar                             // a completion instruction for the `yield*` keyword
+	opCodeReturn                                // a completion instruction for the `return` keyword
+	opCodeThrow                                 // a completion instruction for the `throw` keyword
+	opCodeEndfinally                            // marks the end of a `finally` block
+)
+
+type generatorOperation struct {
+	code       generatorOpCode
+	statement  *ast.Statement  // the statement written by opCodeStatement
+	target     *ast.Expression // the left-hand side of an assignment
+	expression *ast.Expression // the value of an assignment or completion, or the condition of a conditional break
+	label      generatorLabel  // the target of a break
+	location   core.TextRange
+}
+
+// The instructions understood by the `__generator` helper.
+type generatorInstruction int
+
+const (
+	instructionNext generatorInstruction = iota
+	instructionThrow
+	instructionReturn
+	instructionBreak
+	instructionYield
+	instructionYieldStar
+	instructionCatch
+	instructionEndfinally
+)
+
+func (instruction generatorInstruction) name() string {
+	switch instruction {
+	case instructionReturn:
+		return "return"
+	case instructionBreak:
+		return "break"
+	case instructionYield:
+		return "yield"
+	case instructionYieldStar:
+		return "yield*"
+	case instructionEndfinally:
+		return "endfinally"
+	}
+	return ""
+}
+
+type blockAction int
+
+const (
+	blockActionOpen blockAction = iota
+	blockActionClose
+)
+
+type codeBlockKind int
+
+const (
+	codeBlockKindException codeBlockKind = iota
+	codeBlockKindWith
+	codeBlockKindSwitch
+	codeBlockKindLoop
+	codeBlockKindLabeled
+)
+
+type exceptionBlockState int
+
+const (
+	exceptionBlockStateTry exceptionBlockState = iota
+	exceptionBlockStateCatch
+	exceptionBlockStateFinally
+	exceptionBlockStateDone
+)
+
+// A region of the generator body that affects how operations are written or where breaks are directed. Script
+// blocks are statements without a `yield` that are written as-is, so `break` and `continue` statements that
+// target them are left alone.
+type codeBlock struct {
+	kind codeBlockKind
+
+	// exception blocks
+	exceptionState exceptionBlockState
+	startLabel     generatorLabel
+	catchLabel     generatorLabel
+	finallyLabel   generatorLabel
+	endLabel       generatorLabel
+
+	// with blocks
+	expression *ast.IdentifierNode
+
+	// switch, loop, and labeled blocks
+	isScript      bool
+	labelText     string
+	breakLabel    generatorLabel
+	continueLabel generatorLabel
+}
+
+func newGeneratorTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	resolver := opts.Resolver
+	if resolver == nil {
+		resolver = binder.NewReferenceResolver(opts.CompilerOptions, binder.ReferenceResolverHooks{})
+	}
+	tx := &generatorTransformer{resolver: resolver}
+	return tx.NewTransformer(tx.visit, opts.Context)
+}
+
+func containsYield(node *ast.Node) bool {
+	return node != nil && node.SubtreeFacts()&ast.SubtreeContainsYield != 0
+}
+
+func (tx *generatorTransformer) visit(node *ast.Node) *ast.Node {
+	if tx.renamedBindings != nil {
+		switch node.Kind {
+		case ast.KindIdentifier:
+			return tx.visitIdentifier(node)
+		case ast.KindShorthandPropertyAssignment:
+			return tx.visitShorthandPropertyAssignment(node.AsShorthandPropertyAssignment())
+		}
+	}
+	switch {
+	case tx.inStatementContainingYield:
+		return tx.visitInStatementContainingYield(node)
+	case tx.inGeneratorFunctionBody:
+		return tx.visitInGeneratorFunctionBody(node)
+	case node.SubtreeFacts()&ast.SubtreeContainsGenerator == 0 && tx.renamedBindings == nil:
+		return node
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindFunctionDeclaration:
+		return tx.visitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindFunctionExpression:
+		return tx.visitFunctionExpression(node.AsFunctionExpression())
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(node.AsMethodDeclaration())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *generatorTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited.AsNode(), tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Visits a node within a statement that contains a `yield`. Statements without a `yield` are written as-is,
+// but they still form the targets of any `break` or `continue` statements they contain.
+func (tx *generatorTransformer) visitInStatementContainingYield(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindDoStatement, ast.KindWhileStatement:
+		return tx.visitScriptLoop(node)
+	case ast.KindSwitchStatement:
+		return tx.visitSwitchStatement(node.AsSwitchStatement())
+	case ast.KindLabeledStatement:
+		return tx.visitLabeledStatement(node.AsLabeledStatement())
+	default:
+		return tx.visitInGeneratorFunctionBody(node)
+	}
+}
+
+// Visits a node within the body of a generator function.
+func (tx *generatorTransformer) visitInGeneratorFunctionBody(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindFunctionDeclaration:
+		return tx.visitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindFunctionExpression:
+		return tx.visitFunctionExpression(node.AsFunctionExpression())
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(node.AsMethodDeclaration())
+	case ast.KindArrowFunction, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor,
+		ast.KindClassStaticBlockDeclaration, ast.KindPropertyDeclaration:
+		return tx.visitOutsideGeneratorFunctionBody(node)
+	case ast.KindVariableStatement:
+		return tx.visitVariableStatement(node.AsVariableStatement())
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindForStatement:
+		return tx.visitForStatement(node.AsForStatement())
+	case ast.KindForInStatement, ast.KindForOfStatement:
+		return tx.visitForInOrOfStatement(node.AsForInOrOfStatement())
+	case ast.KindBreakStatement:
+		return tx.visitBreakStatement(node.AsBreakStatement())
+	case ast.KindContinueStatement:
+		return tx.visitContinueStatement(node.AsContinueStatement())
+	case ast.KindReturnStatement:
+		return tx.visitReturnStatement(node.AsReturnStatement())
+	}
+	if containsYield(node) {
+		return tx.visitContainingYield(node)
+	}
+	if ast.IsExpression(node) && node.SubtreeFacts()&ast.SubtreeContainsGenerator == 0 && tx.renamedBindings == nil {
+		return node
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// Visits an expression that contains a `yield`.
+func (tx *generatorTransformer) visitContainingYield(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression())
+	case ast.KindConditionalExpression:
+		return tx.visitConditionalExpression(node.AsConditionalExpression())
+	case ast.KindYieldExpression:
+		return tx.visitYieldExpression(node.AsYieldExpression())
+	case ast.KindArrayLiteralExpression:
+		return tx.visitArrayLiteralExpression(node.AsArrayLiteralExpression())
+	case ast.KindObjectLiteralExpression:
+		return tx.visitObjectLiteralExpression(node.AsObjectLiteralExpression())
+	case ast.KindElementAccessExpression:
+		return tx.visitElementAccessExpression(node.AsElementAccessExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindNewExpression:
+		return tx.visitNewExpression(node.AsNewExpression())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+// Visits a function-like declaration or class element that is not a generator. Its body is not part of the
+// state machine of an enclosing generator.
+func (tx *generatorTransformer) visitOutsideGeneratorFunctionBody(node *ast.Node) *ast.Node {
+	savedInGeneratorFunctionBody := tx.inGeneratorFunctionBody
+	savedInStatementContainingYield := tx.inStatementContainingYield
+	defer func() {
+		tx.inGeneratorFunctionBody = savedInGeneratorFunctionBody
+		tx.inStatementContainingYield = savedInStatementContainingYield
+	}()
+	tx.inGeneratorFunctionBody = false
+	tx.inStatementContainingYield = false
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *generatorTransformer) visitFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	var updated *ast.Node
+	if node.AsteriskToken != nil && node.Body != nil {
+		updated = tx.Factory().UpdateFunctionDeclaration(
+			node,
+			tx.Visitor().VisitModifiers(node.Modifiers()),
+			nil, /*asteriskToken*/
+			node.Name(),
+			nil, /*typeParameters*/
+			tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.transformGeneratorFunctionBody(node.AsNode()),
+		)
+	} else {
+		updated = tx.visitOutsideGeneratorFunctionBody(node.AsNode())
+	}
+	if tx.inGeneratorFunctionBody {
+		// function declarations in the body of a generator are hoisted out of the state machine
+		tx.EmitContext().AddHoistedFunctionDeclaration(updated)
+		return nil
+	}
+	return updated
+}
+
+func (tx *generatorTransformer) visitFunctionExpression(node *ast.FunctionExpression) *ast.Node {
+	if node.AsteriskToken == nil {
+		return tx.visitOutsideGeneratorFunctionBody(node.AsNode())
+	}
+	return tx.Factory().UpdateFunctionExpression(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		nil, /*asteriskToken*/
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.transformGeneratorFunctionBody(node.AsNode()),
+	)
+}
+
+func (tx *generatorTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	// a computed property name is evaluated by the enclosing function
+	name := tx.Visitor().VisitNode(node.Name())
+	if node.AsteriskToken == nil || node.Body == nil {
+		savedInGeneratorFunctionBody := tx.inGeneratorFunctionBody
+		savedInStatementContainingYield := tx.inStatementContainingYield
+		defer func() {
+			tx.inGeneratorFunctionBody = savedInGeneratorFunctionBody
+			tx.inStatementContainingYield = savedInStatementContainingYield
+		}()
+		tx.inGeneratorFunctionBody = false
+		tx.inStatementContainingYield = false
+		return tx.Factory().UpdateMethodDeclaration(
+			node,
+			tx.Visitor().VisitModifiers(node.Modifiers()),
+			node.AsteriskToken,
+			name,
+			node.PostfixToken,
+			nil, /*typeParameters*/
+			tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+		)
+	}
+	return tx.Factory().UpdateMethodDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		nil, /*asteriskToken*/
+		name,
+		node.PostfixToken,
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.transformGeneratorFunctionBody(node.AsNode()),
+	)
+}
+
+// Transforms the body of a generator function into a call to the `__generator` helper. The variable environment
+// started when the parameters of the function were visited is ended here, so the declarations hoisted out of
+// the state machine are added to the outer body.
+func (tx *generatorTransformer) transformGeneratorFunctionBody(node *ast.Node) *ast.BlockNode {
+	f := tx.Factory()
+	body := node.Body().AsBlock()
+
+	savedInGeneratorFunctionBody := tx.inGeneratorFunctionBody
+	savedInStatementContainingYield := tx.inStatementContainingYield
+	savedRenamedBindings := tx.renamedBindings
+	savedGeneratorBody := tx.generatorBody
+	defer func() {
+		tx.inGeneratorFunctionBody = savedInGeneratorFunctionBody
+		tx.inStatementContainingYield = savedInStatementContainingYield
+		tx.renamedBindings = savedRenamedBindings
+		tx.generatorBody = savedGeneratorBody
+	}()
+	tx.inGeneratorFunctionBody = true
+	tx.inStatementContainingYield = false
+	tx.renameCollidingBindings(node)
+	tx.generatorBody = generatorBody{
+		nextLabelID: 1,
+		state:       f.NewTempVariable(),
+	}
+
+	// directives and custom prologues are evaluated when the generator is created
+	prologue, rest := f.SplitStandardPrologue(body.Statements.Nodes)
+	customPrologue, rest := f.SplitCustomPrologue(rest)
+	statements := append([]*ast.Statement{}, prologue...)
+	visitedCustomPrologue, _ := tx.Visitor().VisitSlice(customPrologue)
+	statements = append(statements, visitedCustomPrologue...)
+
+	tx.transformAndEmitStatements(rest)
+
+	statements = append(statements, f.NewReturnStatement(tx.build(body.AsNode().SubtreeFacts()&ast.SubtreeContainsLexicalSuper != 0)))
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+	updated := f.NewBlock(f.NewNodeList(statements), body.Multiline)
+	updated.Loc = body.Loc
+	return updated
+}
+
+// Renames the block-scoped declarations of a generator function that would collide with another binding once
+// they are hoisted, such as a `const` in a nested block that shadows a `const` of the same name in the function
+// body, or a `catch` variable that shadows a variable of the enclosing scope. The identifiers that declare or
+// refer to a renamed declaration, including those in nested functions, are substituted with its unique name.
+func (tx *generatorTransformer) renameCollidingBindings(node *ast.Node) {
+	function := tx.EmitContext().MostOriginal(node)
+	declarationsByName := make(map[string][]*ast.Node) // the bindings each name declares or refers to, nil if unresolved
+	identifiers := make(map[*ast.Node][]*ast.IdentifierNode)
+	var visit func(node *ast.Node, parent *ast.Node)
+	visit = func(node *ast.Node, parent *ast.Node) {
+		if ast.IsIdentifier(node) {
+			if declaration, ok := tx.getBindingOfIdentifier(node, parent); ok {
+				if declarations := declarationsByName[node.Text()]; !slices.Contains(declarations, declaration) {
+					declarationsByName[node.Text()] = append(declarations, declaration)
+				}
+				if declaration != nil {
+					identifiers[declaration] = append(identifiers[declaration], node)
+				}
+			}
+			return
+		}
+		node.ForEachChild(func(child *ast.Node) bool {
+			visit(child, node)
+			return false
+		})
+	}
+	for _, parameter := range node.Parameters() {
+		visit(parameter, node)
+	}
+	visit(node.Body(), node)
+
+	var renamed map[*ast.Node]*ast.IdentifierNode
+	for name, declarations := range declarationsByName {
+		if len(declarations) < 2 {
+			continue
+		}
+		for _, declaration := range declarations {
+			if declaration == nil || !isNestedBlockScopedDeclaration(declaration, function) {
+				continue
+			}
+			if renamed == nil {
+				renamed = maps.Clone(tx.renamedBindings)
+				if renamed == nil {
+					renamed = make(map[*ast.Node]*ast.IdentifierNode)
+				}
+			}
+			uniqueName := tx.Factory().NewUniqueName(name)
+			for _, identifier := range identifiers[declaration] {
+				renamed[identifier] = uniqueName
+			}
+		}
+	}
+	if renamed != nil {
+		tx.renamedBindings = renamed
+	}
+}
+
+// Returns the declaration of the binding that an identifier declares or refers to, which is nil if the
+// reference is unresolved, or false if the identifier is neither a binding name nor a reference.
+func (tx *generatorTransformer) getBindingOfIdentifier(node *ast.IdentifierNode, parent *ast.Node) (*ast.Node, bool) {
+	if tx.EmitContext().HasAutoGenerateInfo(node) {
+		return nil, false
+	}
+	switch {
+	case isBindingDeclaration(parent) && parent.Name() == node:
+		return tx.EmitContext().MostOriginal(parent), true
+	case ast.IsShorthandPropertyAssignment(parent) && parent.Name() == node, transformers.IsIdentifierReference(node, parent):
+		return tx.resolver.GetReferencedValueDeclaration(tx.EmitContext().MostOriginal(node)), true
+	}
+	return nil, false
+}
+
+func isBindingDeclaration(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindVariableDeclaration, ast.KindParameter, ast.KindBindingElement, ast.KindFunctionDeclaration,
+		ast.KindFunctionExpression, ast.KindClassDeclaration, ast.KindClassExpression, ast.KindEnumDeclaration,
+		ast.KindModuleDeclaration:
+		return true
+	}
+	return false
+}
+
+// Reports whether a declaration of the given generator function is block-scoped to a block nested in its body,
+// where it may be hoisted to the function body along with the other declarations of the state machine.
+func isNestedBlockScopedDeclaration(declaration *ast.Node, function *ast.Node) bool {
+	if ast.GetContainingFunction(declaration) != function {
+		return false
+	}
+	switch declaration.Kind {
+	case ast.KindBindingElement:
+		return isNestedBlockScopedDeclaration(ast.GetRootDeclaration(declaration), function)
+	case ast.KindVariableDeclaration:
+		if ast.IsCatchClause(declaration.Parent) {
+			return true
+		}
+		list := declaration.Parent
+		return list.Flags&ast.NodeFlagsBlockScoped != 0 && !(ast.IsVariableStatement(list.Parent) && ast.IsFunctionBlock(list.Parent.Parent))
+	case ast.KindClassDeclaration:
+		return !ast.IsFunctionBlock(declaration.Parent)
+	}
+	return false
+}
+
+// Returns the name of a binding declared by the generator function, which is a unique name if it was renamed.
+func (tx *generatorTransformer) getBindingName(name *ast.IdentifierNode) *ast.IdentifierNode {
+	if uniqueName := tx.renamedBindings[name]; uniqueName != nil {
+		updated := uniqueName.Clone(tx.Factory())
+		tx.EmitContext().AssignCommentAndSourceMapRanges(updated, name)
+		return updated
+	}
+	return name.Clone(tx.Factory())
+}
+
+// Converts the name of a variable declaration into the target of an assignment to the variables it declares.
+func (tx *generatorTransformer) transformBindingNameToAssignmentTarget(name *ast.Node) *ast.Expression {
+	if ast.IsIdentifier(name) {
+		return tx.getBindingName(name)
+	}
+	return tx.Visitor().VisitNode(convertBindingNameToAssignmentTarget(tx.EmitContext(), name))
+}
+
+func (tx *generatorTransformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if tx.renamedBindings[node] == nil {
+		return node
+	}
+	return tx.getBindingName(node)
+}
+
+func (tx *generatorTransformer) visitShorthandPropertyAssignment(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	if tx.renamedBindings[node.Name()] == nil {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	// `{ x }` refers to a renamed binding, so it is written as `{ x: x_1 }`
+	f := tx.Factory()
+	var initializer *ast.Expression = tx.getBindingName(node.Name())
+	if node.ObjectAssignmentInitializer != nil {
+		initializer = f.NewAssignmentExpression(initializer, tx.Visitor().VisitNode(node.ObjectAssignmentInitializer))
+	}
+	updated := f.NewPropertyAssignment(nil /*modifiers*/, node.Name().Clone(f), nil /*postfixToken*/, nil /*typeNode*/, initializer)
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+//
+// Visitors for nodes in the body of a generator function
+//
+
+func (tx *generatorTransformer) visitScriptLoop(node *ast.Node) *ast.Node {
+	tx.beginScriptLoopBlock()
+	defer tx.endLoopBlock()
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *generatorTransformer) visitSwitchStatement(node *ast.SwitchStatement) *ast.Node {
+	tx.beginScriptSwitchBlock()
+	defer tx.endSwitchBlock()
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *generatorTransformer) visitLabeledStatement(node *ast.LabeledStatement) *ast.Node {
+	tx.beginScriptLabeledBlock(node.Label.Text())
+	defer tx.endLabeledBlock()
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Visits a variable statement that does not directly belong to the state machine. `var` declarations are
+// hoisted so they remain visible from every state, while block-scoped declarations are only visible within the
+// statement that contains them.
+func (tx *generatorTransformer) visitVariableStatement(node *ast.VariableStatement) *ast.Node {
+	declarationList := node.DeclarationList.AsVariableDeclarationList()
+	if containsYield(node.AsNode()) {
+		tx.transformAndEmitVariableDeclarationList(declarationList)
+		return nil
+	}
+	if tx.EmitContext().EmitFlags(node.AsNode())&printer.EFCustomPrologue != 0 || declarationList.Flags&ast.NodeFlagsBlockScoped != 0 {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	return tx.hoistVariableStatement(node)
+}
+
+// Hoists the declarations of a variable statement to the enclosing function, returning a statement that
+// assigns their initializers, or nil if there are none.
+func (tx *generatorTransformer) hoistVariableStatement(node *ast.VariableStatement) *ast.Node {
+	declarationList := node.DeclarationList.AsVariableDeclarationList()
+	var expressions []*ast.Expression
+	for _, declaration := range declarationList.Declarations.Nodes {
+		tx.hoistVariable(declaration.Name())
+		if declaration.Initializer() != nil {
+			expressions = append(expressions, tx.transformInitializedVariable(declaration.AsVariableDeclaration()))
+		}
+	}
+	if len(expressions) == 0 {
+		return nil
+	}
+	statement := tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(expressions))
+	tx.EmitContext().SetOriginal(statement, node.AsNode())
+	tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
+	return statement
+}
+
+func (tx *generatorTransformer) transformInitializedVariable(node *ast.VariableDeclaration) *ast.Expression {
+	target := tx.transformBindingNameToAssignmentTarget(node.Name())
+	tx.EmitContext().SetSourceMapRange(target, node.Name().Loc)
+	assignment := tx.Factory().NewAssignmentExpression(target, tx.Visitor().VisitNode(node.Initializer))
+	tx.EmitContext().SetSourceMapRange(assignment, node.Loc)
+	return assignment
+}
+
+func (tx *generatorTransformer) hoistVariable(name *ast.Node) {
+	if ast.IsIdentifier(name) {
+		tx.EmitContext().AddVariableDeclaration(tx.getBindingName(name))
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if !ast.IsOmittedExpression(element) {
+			tx.hoistVariable(element.Name())
+		}
+	}
+}
+
+// Class declarations are block-scoped, so a class declared directly in the state machine is assigned to a
+// hoisted variable instead.
+func (tx *generatorTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if node.Name() == nil {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	f := tx.Factory()
+	updated := tx.Visitor().VisitEachChild(node.AsNode()).AsClassDeclaration()
+	tx.hoistVariable(node.Name())
+	classExpression := f.NewClassExpression(
+		transformers.ExtractModifiers(tx.EmitContext(), updated.Modifiers(), ^(ast.ModifierFlagsExport|ast.ModifierFlagsDefault)),
+		node.Name().Clone(f),
+		nil, /*typeParameters*/
+		updated.HeritageClauses,
+		updated.Members,
+	)
+	tx.EmitContext().SetOriginal(classExpression, node.AsNode())
+	classExpression.Loc = node.Loc
+	statement := f.NewExpressionStatement(f.NewAssignmentExpression(node.Name().Clone(f), classExpression))
+	tx.EmitContext().SetOriginal(statement, node.AsNode())
+	statement.Loc = node.Loc
+	return statement
+}
+
+func (tx *generatorTransformer) visitForStatement(node *ast.ForStatement) *ast.Node {
+	if tx.inStatementContainingYield {
+		tx.beginScriptLoopBlock()
+		defer tx.endLoopBlock()
+	}
+	if node.Initializer == nil || !ast.IsVariableDeclarationList(node.Initializer) || node.Initializer.Flags&ast.NodeFlagsBlockScoped != 0 {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	var expressions []*ast.Expression
+	for _, declaration := range node.Initializer.AsVariableDeclarationList().Declarations.Nodes {
+		tx.hoistVariable(declaration.Name())
+		if declaration.Initializer() != nil {
+			expressions = append(expressions, tx.transformInitializedVariable(declaration.AsVariableDeclaration()))
+		}
+	}
+	return tx.Factory().UpdateForStatement(
+		node,
+		tx.Factory().InlineExpressions(expressions),
+		tx.Visitor().VisitNode(node.Condition),
+		tx.Visitor().VisitNode(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.Visitor()),
+	)
+}
+
+func (tx *generatorTransformer) visitForInOrOfStatement(node *ast.ForInOrOfStatement) *ast.Node {
+	if tx.inStatementContainingYield {
+		tx.beginScriptLoopBlock()
+		defer tx.endLoopBlock()
+	}
+	if !ast.IsVariableDeclarationList(node.Initializer) || node.Initializer.Flags&ast.NodeFlagsBlockScoped != 0 {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	declarations := node.Initializer.AsVariableDeclarationList().Declarations.Nodes
+	for _, declaration := range declarations {
+		tx.hoistVariable(declaration.Name())
+	}
+	return tx.Factory().UpdateForInOrOfStatement(
+		node,
+		node.AwaitModifier,
+		tx.transformBindingNameToAssignmentTarget(declarations[0].Name()),
+		tx.Visitor().VisitNode(node.Expression),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.Visitor()),
+	)
+}
+
+func (tx *generatorTransformer) visitBreakStatement(node *ast.BreakStatement) *ast.Node {
+	if tx.inStatementContainingYield {
+		if label := tx.findBreakTarget(node.Label); label > 0 {
+			return tx.createInlineBreak(label, node.Loc)
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *generatorTransformer) visitContinueStatement(node *ast.ContinueStatement) *ast.Node {
+	if tx.inStatementContainingYield {
+		if label := tx.findContinueTarget(node.Label); label > 0 {
+			return tx.createInlineBreak(label, node.Loc)
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *generatorTransformer) visitReturnStatement(node *ast.ReturnStatement) *ast.Node {
+	return tx.createInlineReturn(tx.Visitor().VisitNode(node.Expression), node.Loc)
+}
+
+func (tx *generatorTransformer) visitBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	if ast.IsAssignmentOperator(node.OperatorToken.Kind) {
+		return tx.visitRightAssociativeBinaryExpression(node)
+	}
+	return tx.visitLeftAssociativeBinaryExpression(node)
+}
+
+// Visits an assignment whose right-hand side contains a `yield`. The parts of the left-hand side are evaluated
+// before the right-hand side, so they are cached:
+//
+//	a.b = yield;          =>   _b = a; ... _b.b = _a.sent();
+//	a[b] += yield;        =>   _b = a, _c = b; ... _b[_c] = _b[_c] + _a.sent();
+func (tx *generatorTransformer) visitRightAssociativeBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	if !containsYield(node.Right) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	f := tx.Factory()
+	var target *ast.Expression
+	switch node.Left.Kind {
+	case ast.KindPropertyAccessExpression:
+		left := node.Left.AsPropertyAccessExpression()
+		target = f.UpdatePropertyAccessExpression(left, tx.cacheExpression(tx.Visitor().VisitNode(left.Expression)), nil /*questionDotToken*/, left.Name())
+	case ast.KindElementAccessExpression:
+		left := node.Left.AsElementAccessExpression()
+		target = f.UpdateElementAccessExpression(
+			left,
+			tx.cacheExpression(tx.Visitor().VisitNode(left.Expression)),
+			nil, /*questionDotToken*/
+			tx.cacheExpression(tx.Visitor().VisitNode(left.ArgumentExpression)),
+		)
+	default:
+		target = tx.Visitor().VisitNode(node.Left)
+	}
+	if ast.IsCompoundAssignment(node.OperatorToken.Kind) {
+		value := f.NewBinaryExpression(
+			nil, /*modifiers*/
+			tx.cacheExpression(target),
+			nil, /*typeNode*/
+			f.NewToken(ast.GetNonAssignmentOperatorForCompoundAssignment(node.OperatorToken.Kind)),
+			tx.Visitor().VisitNode(node.Right),
+		)
+		value.Loc = node.Loc
+		assignment := f.NewAssignmentExpression(target, value)
+		assignment.Loc = node.Loc
+		return assignment
+	}
+	return f.UpdateBinaryExpression(node, nil /*modifiers*/, target, nil /*typeNode*/, node.OperatorToken, tx.Visitor().VisitNode(node.Right))
+}
+
+// Visits a binary expression whose right operand contains a `yield`. The left operand is evaluated first, so
+// it is cached:
+//
+//	a() + (yield) + c()   =>   _b = a(); ... _b + _a.sent() + c()
+func (tx *generatorTransformer) visitLeftAssociativeBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	if !containsYield(node.Right) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	switch node.OperatorToken.Kind {
+	case ast.KindAmpersandAmpersandToken, ast.KindBarBarToken:
+		return tx.visitLogicalBinaryExpression(node)
+	case ast.KindCommaToken:
+		return tx.visitCommaExpression(node)
+	}
+	return tx.Factory().UpdateBinaryExpression(
+		node,
+		nil, /*modifiers*/
+		tx.cacheExpression(tx.Visitor().VisitNode(node.Left)),
+		nil, /*typeNode*/
+		node.OperatorToken,
+		tx.Visitor().VisitNode(node.Right),
+	)
+}
+
+// Visits a comma expression that contains a `yield`, writing the operands that precede each `yield` as
+// separate statements.
+func (tx *generatorTransformer) visitCommaExpression(node *ast.BinaryExpression) *ast.Node {
+	var pendingExpressions []*ast.Expression
+	var visit func(node *ast.Expression)
+	visit = func(node *ast.Expression) {
+		if ast.IsBinaryExpression(node) && node.AsBinaryExpression().OperatorToken.Kind == ast.KindCommaToken {
+			visit(node.AsBinaryExpression().Left)
+			visit(node.AsBinaryExpression().Right)
+			return
+		}
+		if containsYield(node) && len(pendingExpressions) > 0 {
+			tx.emitStatement(tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(pendingExpressions)))
+			pendingExpressions = nil
+		}
+		pendingExpressions = append(pendingExpressions, tx.Visitor().VisitNode(node))
+	}
+	visit(node.Left)
+	visit(node.Right)
+	return tx.Factory().InlineExpressions(pendingExpressions)
+}
+
+// Visits a logical binary expression whose right operand contains a `yield`. The right operand is only evaluated
+// when the left operand does not short-circuit, so each operand is assigned to a temporary variable in turn:
+//
+//	x = a() && (yield);   =>   _b = a();
+//	                           if (!_b) return [3 /*break*/, 2];
+//	                           return [4 /*yield*/];
+//	                       case 1:
+//	                           _b = _a.sent();
+//	                           _a.label = 2;
+//	                       case 2:
+//	                           x = _b;
+func (tx *generatorTransformer) visitLogicalBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	resultLabel := tx.defineLabel()
+	resultLocal := tx.declareLocal("")
+	tx.emitAssignment(resultLocal, tx.Visitor().VisitNode(node.Left), node.Left.Loc)
+	if node.OperatorToken.Kind == ast.KindAmpersandAmpersandToken {
+		tx.emitBreakWhenFalse(resultLabel, resultLocal, node.Left.Loc)
+	} else {
+		tx.emitBreakWhenTrue(resultLabel, resultLocal, node.Left.Loc)
+	}
+	tx.emitAssignment(resultLocal, tx.Visitor().VisitNode(node.Right), node.Right.Loc)
+	tx.markLabel(resultLabel)
+	return resultLocal
+}
+
+// Visits a conditional expression whose branches contain a `yield`, writing each branch in its own state.
+func (tx *generatorTransformer) visitConditionalExpression(node *ast.ConditionalExpression) *ast.Node {
+	if !containsYield(node.WhenTrue) && !containsYield(node.WhenFalse) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	whenFalseLabel := tx.defineLabel()
+	resultLabel := tx.defineLabel()
+	resultLocal := tx.declareLocal("")
+	tx.emitBreakWhenFalse(whenFalseLabel, tx.Visitor().VisitNode(node.Condition), node.Condition.Loc)
+	tx.emitAssignment(resultLocal, tx.Visitor().VisitNode(node.WhenTrue), node.WhenTrue.Loc)
+	tx.emitBreak(resultLabel, core.UndefinedTextRange())
+	tx.markLabel(whenFalseLabel)
+	tx.emitAssignment(resultLocal, tx.Visitor().VisitNode(node.WhenFalse), node.WhenFalse.Loc)
+	tx.markLabel(resultLabel)
+	return resultLocal
+}
+
+// Visits a `yield` expression, which suspends the state machine and resumes in a new state with the value sent
+// to the generator:
+//
+//	x = yield a;    =>   return [4, a];
+//	                 case 1: x = _a.sent();
+func (tx *generatorTransformer) visitYieldExpression(node *ast.YieldExpression) *ast.Node {
+	resumeLabel := tx.defineLabel()
+	expression := tx.Visitor().VisitNode(node.Expression)
+	if node.AsteriskToken != nil {
+		// the delegate of an async generator is already an iterator
+		iterator := expression
+		if !tx.EmitContext().IsCallToHelper(node.Expression, "__asyncDelegator") {
+			iterator = tx.Factory().NewValuesHelper(expression)
+			iterator.Loc = node.Loc
+		}
+		tx.emitYieldStar(iterator, node.Loc)
+	} else {
+		tx.emitYield(expression, node.Loc)
+	}
+	tx.markLabel(resumeLabel)
+	return tx.createGeneratorResume(node.Loc)
+}
+
+func (tx *generatorTransformer) visitArrayLiteralExpression(node *ast.ArrayLiteralExpression) *ast.Node {
+	return tx.visitElements(node.Elements.Nodes, nil /*leadingElement*/, node.Loc, node.MultiLine)
+}
+
+// Visits a list of elements that contains a `yield`. The elements that precede each `yield` are collected into
+// a temporary array, so they are evaluated in order:
+//
+//	[a, yield, b]   =>   _b = [a];
+//	                     return [4];
+//	                 case 1: _b.concat([_a.sent(), b])
+func (tx *generatorTransformer) visitElements(elements []*ast.Expression, leadingElement *ast.Expression, location core.TextRange, multiLine bool) *ast.Expression {
+	f := tx.Factory()
+	numInitialElements := countInitialNodesWithoutYield(elements)
+
+	var temp *ast.IdentifierNode
+	if numInitialElements > 0 {
+		temp = tx.declareLocal("")
+		initialElements, _ := tx.Visitor().VisitSlice(elements[:numInitialElements])
+		if leadingElement != nil {
+			initialElements = append([]*ast.Expression{leadingElement}, initialElements...)
+			leadingElement = nil
+		}
+		tx.emitAssignment(temp, f.NewArrayLiteralExpression(f.NewNodeList(initialElements), false /*multiLine*/), core.UndefinedTextRange())
+	}
+
+	var expressions []*ast.Expression
+	for _, element := range elements[numInitialElements:] {
+		if containsYield(element) && len(expressions) > 0 {
+			var value *ast.Expression
+			if temp != nil {
+				value = f.NewMethodCall(temp, f.NewIdentifier("concat"), []*ast.Expression{f.NewArrayLiteralExpression(f.NewNodeList(expressions), multiLine)})
+			} else {
+				temp = tx.declareLocal("")
+				if leadingElement != nil {
+					expressions = append([]*ast.Expression{leadingElement}, expressions...)
+					leadingElement = nil
+				}
+				value = f.NewArrayLiteralExpression(f.NewNodeList(expressions), multiLine)
+			}
+			tx.emitAssignment(temp, value, core.UndefinedTextRange())
+			expressions = nil
+		}
+		expressions = append(expressions, tx.Visitor().VisitNode(element))
+	}
+
+	if temp != nil {
+		return f.NewMethodCall(temp, f.NewIdentifier("concat"), []*ast.Expression{f.NewArrayLiteralExpression(f.NewNodeList(expressions), multiLine)})
+	}
+	if leadingElement != nil {
+		expressions = append([]*ast.Expression{leadingElement}, expressions...)
+	}
+	result := f.NewArrayLiteralExpression(f.NewNodeList(expressions), multiLine)
+	result.Loc = location
+	return result
+}
+
+// Visits an object literal that contains a `yield`. The properties that precede the first `yield` are written
+// as an object literal, and the remaining properties are assigned to it in order:
+//
+//	x = { a: 1, b: yield, c: 2 }   =>   _b = { a: 1 };
+//	                                    return [4];
+//	                                case 1: x = (_b.b = _a.sent(), _b.c = 2, _b);
+func (tx *generatorTransformer) visitObjectLiteralExpression(node *ast.ObjectLiteralExpression) *ast.Node {
+	f := tx.Factory()
+	properties := node.Properties.Nodes
+	numInitialProperties := countInitialNodesWithoutYield(properties)
+
+	temp := tx.declareLocal("")
+	initialProperties, _ := tx.Visitor().VisitSlice(properties[:numInitialProperties])
+	tx.emitAssignment(temp, f.NewObjectLiteralExpression(f.NewNodeList(initialProperties), node.MultiLine), core.UndefinedTextRange())
+
+	var expressions []*ast.Expression
+	for _, property := range properties[numInitialProperties:] {
+		if containsYield(property) && len(expressions) > 0 {
+			tx.emitStatement(f.NewExpressionStatement(f.InlineExpressions(expressions)))
+			expressions = nil
+		}
+		expression := tx.createExpressionForObjectLiteralElement(node, property, temp)
+		if expression == nil {
+			continue
+		}
+		visited := tx.Visitor().VisitNode(expression)
+		if node.MultiLine {
+			tx.EmitContext().AddEmitFlags(visited, printer.EFStartOnNewLine)
+		}
+		expressions = append(expressions, visited)
+	}
+	result := temp
+	if node.MultiLine {
+		result = temp.Clone(f)
+		tx.EmitContext().AddEmitFlags(result, printer.EFStartOnNewLine)
+	}
+	expressions = append(expressions, result)
+	return f.InlineExpressions(expressions)
+}
+
+// Creates an expression that defines an element of an object literal on `receiver`.
+func (tx *generatorTransformer) createExpressionForObjectLiteralElement(node *ast.ObjectLiteralExpression, property *ast.Node, receiver *ast.Expression) *ast.Expression {
+	f := tx.Factory()
+	var expression *ast.Expression
+	switch property.Kind {
+	case ast.KindPropertyAssignment:
+		expression = f.NewAssignmentExpression(tx.createMemberAccessForPropertyName(receiver, property.Name()), property.Initializer())
+	case ast.KindShorthandPropertyAssignment:
+		expression = f.NewAssignmentExpression(tx.createMemberAccessForPropertyName(receiver, property.Name()), property.Name().Clone(f))
+	case ast.KindMethodDeclaration:
+		method := property.AsMethodDeclaration()
+		function := f.NewFunctionExpression(
+			method.Modifiers(),
+			method.AsteriskToken,
+			nil, /*name*/
+			nil, /*typeParameters*/
+			method.Parameters,
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			method.Body,
+		)
+		tx.EmitContext().SetOriginal(function, property)
+		function.Loc = property.Loc
+		expression = f.NewAssignmentExpression(tx.createMemberAccessForPropertyName(receiver, property.Name()), function)
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		expression = tx.createExpressionForAccessorDeclaration(node, property, receiver)
+		if expression == nil {
+			return nil
+		}
+	default:
+		panic("Unhandled object literal element kind: " + property.Kind.String())
+	}
+	tx.EmitContext().SetOriginal(expression, property)
+	expression.Loc = property.Loc
+	return expression
+}
+
+// Creates an `Object.defineProperty` call for a pair of accessors, or returns nil if `property` is not the first
+// accessor of its pair.
+func (tx *generatorTransformer) createExpressionForAccessorDeclaration(node *ast.ObjectLiteralExpression, property *ast.Node, receiver *ast.Expression) *ast.Expression {
+	f := tx.Factory()
+	var getAccessor, setAccessor *ast.Node
+	if text, ok := ast.TryGetTextOfPropertyName(property.Name()); ok {
+		for _, element := range node.Properties.Nodes {
+			if !ast.IsAccessor(element) {
+				continue
+			}
+			if elementText, ok := ast.TryGetTextOfPropertyName(element.Name()); !ok || elementText != text {
+				continue
+			}
+			if getAccessor == nil && setAccessor == nil && element != property {
+				// the pair was defined by an earlier accessor
+				return nil
+			}
+			if ast.IsGetAccessorDeclaration(element) {
+				getAccessor = core.OrElse(getAccessor, element)
+			} else {
+				setAccessor = core.OrElse(setAccessor, element)
+			}
+		}
+	} else if ast.IsGetAccessorDeclaration(property) {
+		getAccessor = property
+	} else {
+		setAccessor = property
+	}
+
+	var propertyName *ast.Expression
+	switch {
+	case ast.IsComputedPropertyName(property.Name()):
+		propertyName = property.Name().Expression()
+	case ast.IsIdentifier(property.Name()):
+		propertyName = f.NewStringLiteralFromNode(property.Name())
+	default:
+		propertyName = property.Name().Clone(f)
+	}
+	newAccessorFunction := func(accessor *ast.Node) *ast.Expression {
+		function := f.NewFunctionExpression(
+			nil, /*modifiers*/
+			nil, /*asteriskToken*/
+			nil, /*name*/
+			nil, /*typeParameters*/
+			accessor.ParameterList(),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			accessor.Body(),
+		)
+		tx.EmitContext().SetOriginal(function, accessor)
+		function.Loc = accessor.Loc
+		return function
+	}
+	var descriptorProperties []*ast.Node
+	if getAccessor != nil {
+		descriptorProperties = append(descriptorProperties, f.NewPropertyAssignment(nil, f.NewIdentifier("get"), nil, nil, newAccessorFunction(getAccessor)))
+	}
+	if setAccessor != nil {
+		descriptorProperties = append(descriptorProperties, f.NewPropertyAssignment(nil, f.NewIdentifier("set"), nil, nil, newAccessorFunction(setAccessor)))
+	}
+	descriptorProperties = append(descriptorProperties,
+		f.NewPropertyAssignment(nil, f.NewIdentifier("enumerable"), nil, nil, f.NewTrueExpression()),
+		f.NewPropertyAssignment(nil, f.NewIdentifier("configurable"), nil, nil, f.NewTrueExpression()),
+	)
+	descriptor := f.NewObjectLiteralExpression(f.NewNodeList(descriptorProperties), node.MultiLine)
+	return f.NewGlobalMethodCall("Object", "defineProperty", []*ast.Expression{receiver, propertyName, descriptor})
+}
+
+func (tx *generatorTransformer) createMemberAccessForPropertyName(receiver *ast.Expression, name *ast.PropertyName) *ast.Expression {
+	f := tx.Factory()
+	var expression *ast.Expression
+	switch {
+	case ast.IsComputedPropertyName(name):
+		expression = f.NewElementAccessExpression(receiver, nil /*questionDotToken*/, name.Expression(), ast.NodeFlagsNone)
+	case ast.IsMemberName(name):
+		expression = f.NewPropertyAccessExpression(receiver, nil /*questionDotToken*/, name.Clone(f), ast.NodeFlagsNone)
+	default:
+		expression = f.NewElementAccessExpression(receiver, nil /*questionDotToken*/, name.Clone(f), ast.NodeFlagsNone)
+	}
+	expression.Loc = name.Loc
+	return expression
+}
+
+func (tx *generatorTransformer) visitElementAccessExpression(node *ast.ElementAccessExpression) *ast.Node {
+	if !containsYield(node.ArgumentExpression) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	return tx.Factory().UpdateElementAccessExpression(
+		node,
+		tx.cacheExpression(tx.Visitor().VisitNode(node.Expression)),
+		node.QuestionDotToken,
+		tx.Visitor().VisitNode(node.ArgumentExpression),
+	)
+}
+
+// Visits a call whose arguments contain a `yield`. The callee and the arguments that precede the `yield` are
+// evaluated first, so the call is rewritten to use `Function.prototype.apply`:
+//
+//	a.b(c, yield)   =>   _c = (_b = a).b;
+//	                     _d = [c];
+//	                     return [4];
+//	                 case 1: _c.apply(_b, _d.concat([_a.sent()]))
+func (tx *generatorTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if ast.IsImportCall(node.AsNode()) || ast.IsSuperCall(node.AsNode()) || !core.Some(node.Arguments.Nodes, containsYield) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	target, thisArg := tx.Factory().NewCallBinding(node.Expression, tx.EmitContext().AddVariableDeclaration, true /*cacheIdentifiers*/)
+	updated := tx.Factory().NewFunctionApplyCall(
+		tx.cacheExpression(tx.Visitor().VisitNode(target)),
+		thisArg,
+		tx.visitElements(node.Arguments.Nodes, nil /*leadingElement*/, core.UndefinedTextRange(), false /*multiLine*/),
+	)
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+// Visits a `new` expression whose arguments contain a `yield`, binding the constructor to its arguments:
+//
+//	new a.B(c, yield)   =>   _c = (_b = a.B).bind;
+//	                         _d = [void 0, c];
+//	                         return [4];
+//	                     case 1: new (_c.apply(_b, _d.concat([_a.sent()])))()
+func (tx *generatorTransformer) visitNewExpression(node *ast.NewExpression) *ast.Node {
+	if node.Arguments == nil || !core.Some(node.Arguments.Nodes, containsYield) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	f := tx.Factory()
+	target, thisArg := f.NewCallBinding(
+		f.NewPropertyAccessExpression(node.Expression, nil /*questionDotToken*/, f.NewIdentifier("bind"), ast.NodeFlagsNone),
+		tx.EmitContext().AddVariableDeclaration,
+		false, /*cacheIdentifiers*/
+	)
+	updated := f.NewNewExpression(
+		f.NewFunctionApplyCall(
+			tx.cacheExpression(tx.Visitor().VisitNode(target)),
+			thisArg,
+			tx.visitElements(node.Arguments.Nodes, f.NewVoidZeroExpression(), core.UndefinedTextRange(), false /*multiLine*/),
+		),
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{}),
+	)
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+//
+// Writers for statements that belong to the state machine
+//
+
+func (tx *generatorTransformer) transformAndEmitStatements(statements []*ast.Statement) {
+	for _, statement := range statements {
+		tx.transformAndEmitStatement(statement)
+	}
+}
+
+func (tx *generatorTransformer) transformAndEmitEmbeddedStatement(node *ast.Statement) {
+	if ast.IsBlock(node) {
+		tx.transformAndEmitStatements(node.AsBlock().Statements.Nodes)
+	} else {
+		tx.transformAndEmitStatement(node)
+	}
+}
+
+func (tx *generatorTransformer) transformAndEmitStatement(node *ast.Statement) {
+	savedInStatementContainingYield := tx.inStatementContainingYield
+	defer func() { tx.inStatementContainingYield = savedInStatementContainingYield }()
+	if !tx.inStatementContainingYield {
+		tx.inStatementContainingYield = containsYield(node)
+	}
+	tx.transformAndEmitStatementWorker(node)
+}
+
+func (tx *generatorTransformer) transformAndEmitStatementWorker(node *ast.Statement) {
+	switch node.Kind {
+	case ast.KindBlock:
+		tx.transformAndEmitBlock(node.AsBlock())
+	case ast.KindVariableStatement:
+		tx.transformAndEmitVariableStatement(node.AsVariableStatement())
+	case ast.KindIfStatement:
+		tx.transformAndEmitIfStatement(node.AsIfStatement())
+	case ast.KindDoStatement:
+		tx.transformAndEmitDoStatement(node.AsDoStatement())
+	case ast.KindWhileStatement:
+		tx.transformAndEmitWhileStatement(node.AsWhileStatement())
+	case ast.KindForStatement:
+		tx.transformAndEmitForStatement(node.AsForStatement())
+	case ast.KindForInStatement:
+		tx.transformAndEmitForInStatement(node.AsForInOrOfStatement())
+	case ast.KindForOfStatement:
+		tx.transformAndEmitForOfStatement(node.AsForInOrOfStatement())
+	case ast.KindContinueStatement:
+		tx.transformAndEmitContinueStatement(node.AsContinueStatement())
+	case ast.KindBreakStatement:
+		tx.transformAndEmitBreakStatement(node.AsBreakStatement())
+	case ast.KindReturnStatement:
+		tx.transformAndEmitReturnStatement(node.AsReturnStatement())
+	case ast.KindWithStatement:
+		tx.transformAndEmitWithStatement(node.AsWithStatement())
+	case ast.KindSwitchStatement:
+		tx.transformAndEmitSwitchStatement(node.AsSwitchStatement())
+	case ast.KindLabeledStatement:
+		tx.transformAndEmitLabeledStatement(node.AsLabeledStatement())
+	case ast.KindThrowStatement:
+		tx.transformAndEmitThrowStatement(node.AsThrowStatement())
+	case ast.KindTryStatement:
+		tx.transformAndEmitTryStatement(node.AsTryStatement())
+	default:
+		tx.emitStatement(tx.Visitor().VisitNode(node))
+	}
+}
+
+func (tx *generatorTransformer) transformAndEmitBlock(node *ast.Block) {
+	if containsYield(node.AsNode()) {
+		tx.transformAndEmitStatements(node.Statements.Nodes)
+	} else {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+	}
+}
+
+// Writes a variable statement that belongs to the state machine. Every declaration is hoisted, including
+// block-scoped declarations, as the states that refer to them are written as separate `case` clauses.
+func (tx *generatorTransformer) transformAndEmitVariableStatement(node *ast.VariableStatement) {
+	if containsYield(node.AsNode()) {
+		tx.transformAndEmitVariableDeclarationList(node.DeclarationList.AsVariableDeclarationList())
+		tx.emitNop()
+		return
+	}
+	if tx.EmitContext().EmitFlags(node.AsNode())&printer.EFCustomPrologue != 0 {
+		tx.emitStatement(node.AsNode())
+		return
+	}
+	tx.emitStatement(tx.hoistVariableStatement(node))
+}
+
+// Writes the initializers of a variable declaration list that contains a `yield`, flushing the assignments that
+// precede each initializer containing a `yield` as a separate statement.
+func (tx *generatorTransformer) transformAndEmitVariableDeclarationList(node *ast.VariableDeclarationList) {
+	f := tx.Factory()
+	var pendingExpressions []*ast.Expression
+	for _, declaration := range node.Declarations.Nodes {
+		tx.hoistVariable(declaration.Name())
+		if declaration.Initializer() == nil {
+			continue
+		}
+		if containsYield(declaration.Initializer()) && len(pendingExpressions) > 0 {
+			tx.emitStatement(f.NewExpressionStatement(f.InlineExpressions(pendingExpressions)))
+			pendingExpressions = nil
+		}
+		pendingExpressions = append(pendingExpressions, tx.transformInitializedVariable(declaration.AsVariableDeclaration()))
+	}
+	if len(pendingExpressions) > 0 {
+		tx.emitStatement(f.NewExpressionStatement(f.InlineExpressions(pendingExpressions)))
+	}
+}
+
+func (tx *generatorTransformer) transformAndEmitIfStatement(node *ast.IfStatement) {
+	if !containsYield(node.ThenStatement) && !containsYield(node.ElseStatement) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      if (x)
+	//          /*thenStatement*/
+	//      else
+	//          /*elseStatement*/
+	//
+	// [intermediate]
+	//  .brfalse elseLabel, (x)
+	//      /*thenStatement*/
+	//  .br endLabel
+	//  .mark elseLabel
+	//      /*elseStatement*/
+	//  .mark endLabel
+	endLabel := tx.defineLabel()
+	elseLabel := endLabel
+	if node.ElseStatement != nil {
+		elseLabel = tx.defineLabel()
+	}
+	tx.emitBreakWhenFalse(elseLabel, tx.Visitor().VisitNode(node.Expression), node.Expression.Loc)
+	tx.transformAndEmitEmbeddedStatement(node.ThenStatement)
+	if node.ElseStatement != nil {
+		tx.emitBreak(endLabel, core.UndefinedTextRange())
+		tx.markLabel(elseLabel)
+		tx.transformAndEmitEmbeddedStatement(node.ElseStatement)
+	}
+	tx.markLabel(endLabel)
+}
+
+func (tx *generatorTransformer) transformAndEmitDoStatement(node *ast.DoStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      do {
+	//          /*body*/
+	//      }
+	//      while (i < 10);
+	//
+	// [intermediate]
+	//  .loop conditionLabel, endLabel
+	//  .mark loopLabel
+	//      /*body*/
+	//  .mark conditionLabel
+	//      .brtrue loopLabel, (i < 10)
+	//  .endloop
+	//  .mark endLabel
+	conditionLabel := tx.defineLabel()
+	loopLabel := tx.defineLabel()
+	tx.beginLoopBlock(conditionLabel)
+	tx.markLabel(loopLabel)
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.markLabel(conditionLabel)
+	tx.emitBreakWhenTrue(loopLabel, tx.Visitor().VisitNode(node.Expression), core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+func (tx *generatorTransformer) transformAndEmitWhileStatement(node *ast.WhileStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      while (i < 10) {
+	//          /*body*/
+	//      }
+	//
+	// [intermediate]
+	//  .loop loopLabel, endLabel
+	//  .mark loopLabel
+	//      .brfalse endLabel, (i < 10)
+	//      /*body*/
+	//      .br loopLabel
+	//  .endloop
+	//  .mark endLabel
+	loopLabel := tx.defineLabel()
+	endLabel := tx.beginLoopBlock(loopLabel)
+	tx.markLabel(loopLabel)
+	tx.emitBreakWhenFalse(endLabel, tx.Visitor().VisitNode(node.Expression), core.UndefinedTextRange())
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.emitBreak(loopLabel, core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+func (tx *generatorTransformer) transformAndEmitForStatement(node *ast.ForStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      for (var i = 0; i < 10; i++) {
+	//          /*body*/
+	//      }
+	//
+	// [intermediate]
+	//  .local i
+	//      i = 0;
+	//  .loop incrementLabel, endLoopLabel
+	//  .mark conditionLabel
+	//      .brfalse endLoopLabel, (i < 10)
+	//      /*body*/
+	//  .mark incrementLabel
+	//      i++;
+	//      .br conditionLabel
+	//  .endloop
+	//  .mark endLoopLabel
+	f := tx.Factory()
+	conditionLabel := tx.defineLabel()
+	incrementLabel := tx.defineLabel()
+	endLabel := tx.beginLoopBlock(incrementLabel)
+	if initializer := node.Initializer; initializer != nil {
+		if ast.IsVariableDeclarationList(initializer) {
+			tx.transformAndEmitVariableDeclarationList(initializer.AsVariableDeclarationList())
+		} else {
+			statement := f.NewExpressionStatement(tx.Visitor().VisitNode(initializer))
+			statement.Loc = initializer.Loc
+			tx.emitStatement(statement)
+		}
+	}
+	tx.markLabel(conditionLabel)
+	if node.Condition != nil {
+		tx.emitBreakWhenFalse(endLabel, tx.Visitor().VisitNode(node.Condition), core.UndefinedTextRange())
+	}
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.markLabel(incrementLabel)
+	if node.Incrementor != nil {
+		statement := f.NewExpressionStatement(tx.Visitor().VisitNode(node.Incrementor))
+		statement.Loc = node.Incrementor.Loc
+		tx.emitStatement(statement)
+	}
+	tx.emitBreak(conditionLabel, core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+func (tx *generatorTransformer) transformAndEmitForInStatement(node *ast.ForInOrOfStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      for (var p in o) {
+	//          /*body*/
+	//      }
+	//
+	// [intermediate]
+	//  .local _b, _c, _d, _i
+	//      _b = o;
+	//      _c = [];
+	//      for (_d in _b) _c.push(_d);
+	//      _i = 0;
+	//  .loop incrementLabel, endLoopLabel
+	//  .mark conditionLabel
+	//      .brfalse endLoopLabel, (_i < _c.length)
+	//      _d = _c[_i];
+	//      .brfalse incrementLabel, (_d in _b)
+	//      p = _d;
+	//      /*body*/
+	//  .mark incrementLabel
+	//      _i++;
+	//      .br conditionLabel
+	//  .endloop
+	//  .mark endLoopLabel
+	f := tx.Factory()
+	obj := tx.declareLocal("")
+	keysArray := tx.declareLocal("")
+	key := tx.declareLocal("")
+	keysIndex := f.NewLoopVariable()
+	tx.EmitContext().AddVariableDeclaration(keysIndex)
+	tx.emitAssignment(obj, tx.Visitor().VisitNode(node.Expression), core.UndefinedTextRange())
+	tx.emitAssignment(keysArray, f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Expression{}), false /*multiLine*/), core.UndefinedTextRange())
+	tx.emitStatement(f.NewForInOrOfStatement(
+		ast.KindForInStatement,
+		nil, /*awaitModifier*/
+		key,
+		obj,
+		f.NewExpressionStatement(f.NewMethodCall(keysArray, f.NewIdentifier("push"), []*ast.Expression{key})),
+	))
+	tx.emitAssignment(keysIndex, f.NewNumericLiteral("0"), core.UndefinedTextRange())
+
+	conditionLabel := tx.defineLabel()
+	incrementLabel := tx.defineLabel()
+	endLoopLabel := tx.beginLoopBlock(incrementLabel)
+	tx.markLabel(conditionLabel)
+	tx.emitBreakWhenFalse(endLoopLabel, tx.newLessThanLength(keysIndex, keysArray), core.UndefinedTextRange())
+	tx.emitAssignment(key, f.NewElementAccessExpression(keysArray, nil /*questionDotToken*/, keysIndex, ast.NodeFlagsNone), core.UndefinedTextRange())
+	tx.emitBreakWhenFalse(incrementLabel, f.NewBinaryExpression(nil /*modifiers*/, key, nil /*typeNode*/, f.NewToken(ast.KindInKeyword), obj), core.UndefinedTextRange())
+	tx.emitAssignment(tx.transformForInOrOfInitializer(node.Initializer), key, core.UndefinedTextRange())
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.markLabel(incrementLabel)
+	tx.emitStatement(f.NewExpressionStatement(f.NewPostfixUnaryExpression(keysIndex, ast.KindPlusPlusToken)))
+	tx.emitBreak(conditionLabel, core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+// Writes a `for..of` statement that contains a `yield`. Iterables are not supported without
+// `--downlevelIteration`, in which case the iteration transform has already rewritten the statement, so the
+// expression is indexed as an array.
+func (tx *generatorTransformer) transformAndEmitForOfStatement(node *ast.ForInOrOfStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      for (var v of a) {
+	//          /*body*/
+	//      }
+	//
+	// [intermediate]
+	//  .local _b, _i
+	//      _b = a;
+	//      _i = 0;
+	//  .loop incrementLabel, endLoopLabel
+	//  .mark conditionLabel
+	//      .brfalse endLoopLabel, (_i < _b.length)
+	//      v = _b[_i];
+	//      /*body*/
+	//  .mark incrementLabel
+	//      _i++;
+	//      .br conditionLabel
+	//  .endloop
+	//  .mark endLoopLabel
+	f := tx.Factory()
+	array := tx.declareLocal("")
+	index := f.NewLoopVariable()
+	tx.EmitContext().AddVariableDeclaration(index)
+	tx.emitAssignment(array, tx.Visitor().VisitNode(node.Expression), node.Expression.Loc)
+	tx.emitAssignment(index, f.NewNumericLiteral("0"), core.UndefinedTextRange())
+
+	conditionLabel := tx.defineLabel()
+	incrementLabel := tx.defineLabel()
+	endLoopLabel := tx.beginLoopBlock(incrementLabel)
+	tx.markLabel(conditionLabel)
+	tx.emitBreakWhenFalse(endLoopLabel, tx.newLessThanLength(index, array), core.UndefinedTextRange())
+	tx.emitAssignment(tx.transformForInOrOfInitializer(node.Initializer), f.NewElementAccessExpression(array, nil /*questionDotToken*/, index, ast.NodeFlagsNone), node.Initializer.Loc)
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.markLabel(incrementLabel)
+	tx.emitStatement(f.NewExpressionStatement(f.NewPostfixUnaryExpression(index, ast.KindPlusPlusToken)))
+	tx.emitBreak(conditionLabel, core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+// Transforms the initializer of a `for..in` or `for..of` statement into the target of an assignment, hoisting
+// any variables it declares.
+func (tx *generatorTransformer) transformForInOrOfInitializer(initializer *ast.ForInitializer) *ast.Expression {
+	if !ast.IsVariableDeclarationList(initializer) {
+		return tx.Visitor().VisitNode(initializer)
+	}
+	declarations := initializer.AsVariableDeclarationList().Declarations.Nodes
+	for _, declaration := range declarations {
+		tx.hoistVariable(declaration.Name())
+	}
+	return tx.transformBindingNameToAssignmentTarget(declarations[0].Name())
+}
+
+// Creates `index < array.length`.
+func (tx *generatorTransformer) newLessThanLength(index *ast.IdentifierNode, array *ast.IdentifierNode) *ast.Expression {
+	f := tx.Factory()
+	return f.NewBinaryExpression(
+		nil, /*modifiers*/
+		index,
+		nil, /*typeNode*/
+		f.NewToken(ast.KindLessThanToken),
+		f.NewPropertyAccessExpression(array, nil /*questionDotToken*/, f.NewIdentifier("length"), ast.NodeFlagsNone),
+	)
+}
+
+func (tx *generatorTransformer) transformAndEmitContinueStatement(node *ast.ContinueStatement) {
+	if label := tx.findContinueTarget(node.Label); label > 0 {
+		tx.emitBreak(label, node.Loc)
+	} else {
+		// an invalid `continue` without a containing loop is left as-is
+		tx.emitStatement(node.AsNode())
+	}
+}
+
+func (tx *generatorTransformer) transformAndEmitBreakStatement(node *ast.BreakStatement) {
+	if label := tx.findBreakTarget(node.Label); label > 0 {
+		tx.emitBreak(label, node.Loc)
+	} else {
+		// an invalid `break` without a containing loop, switch, or labeled statement is left as-is
+		tx.emitStatement(node.AsNode())
+	}
+}
+
+func (tx *generatorTransformer) transformAndEmitReturnStatement(node *ast.ReturnStatement) {
+	tx.emitReturn(tx.Visitor().VisitNode(node.Expression), node.Loc)
+}
+
+func (tx *generatorTransformer) transformAndEmitWithStatement(node *ast.WithStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      with (x) {
+	//          /*body*/
+	//      }
+	//
+	// [intermediate]
+	//  .with (x)
+	//      /*body*/
+	//  .endwith
+	tx.beginWithBlock(tx.cacheExpression(tx.Visitor().VisitNode(node.Expression)))
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.endWithBlock()
+}
+
+func (tx *generatorTransformer) transformAndEmitSwitchStatement(node *ast.SwitchStatement) {
+	if !containsYield(node.CaseBlock) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      switch (x) {
+	//          case a:
+	//              /*caseStatements*/
+	//          case b:
+	//              /*caseStatements*/
+	//          default:
+	//              /*defaultStatements*/
+	//      }
+	//
+	// [intermediate]
+	//  .local _b
+	//  .switch endLabel
+	//      _b = x;
+	//      switch (_b) {
+	//          case a:
+	//              .br clauseLabels[0]
+	//      }
+	//      switch (_b) {
+	//          case b:
+	//              .br clauseLabels[1]
+	//      }
+	//      .br clauseLabels[2]
+	//  .mark clauseLabels[0]
+	//      /*caseStatements*/
+	//  .mark clauseLabels[1]
+	//      /*caseStatements*/
+	//  .mark clauseLabels[2]
+	//      /*defaultStatements*/
+	//  .endswitch
+	//  .mark endLabel
+	f := tx.Factory()
+	clauses := node.CaseBlock.AsCaseBlock().Clauses.Nodes
+	endLabel := tx.beginSwitchBlock()
+	expression := tx.cacheExpression(tx.Visitor().VisitNode(node.Expression))
+
+	// create a label for each clause
+	clauseLabels := make([]generatorLabel, len(clauses))
+	defaultClauseIndex := -1
+	for i, clause := range clauses {
+		clauseLabels[i] = tx.defineLabel()
+		if clause.Kind == ast.KindDefaultClause && defaultClauseIndex == -1 {
+			defaultClauseIndex = i
+		}
+	}
+
+	// emit switch statements for each run of case clauses, breaking at case clauses whose expression contains a
+	// `yield` so the cases are tested in order
+	var pendingClauses []*ast.Node
+	for i, clause := range clauses {
+		if clause.Kind != ast.KindCaseClause {
+			continue
+		}
+		if containsYield(clause.Expression()) && len(pendingClauses) > 0 {
+			tx.emitStatement(f.NewSwitchStatement(expression, f.NewCaseBlock(f.NewNodeList(pendingClauses))))
+			pendingClauses = nil
+		}
+		pendingClauses = append(pendingClauses, f.NewCaseOrDefaultClause(
+			ast.KindCaseClause,
+			tx.Visitor().VisitNode(clause.Expression()),
+			f.NewNodeList([]*ast.Statement{tx.createInlineBreak(clauseLabels[i], clause.Expression().Loc)}),
+		))
+	}
+	if len(pendingClauses) > 0 {
+		tx.emitStatement(f.NewSwitchStatement(expression, f.NewCaseBlock(f.NewNodeList(pendingClauses))))
+	}
+
+	if defaultClauseIndex >= 0 {
+		tx.emitBreak(clauseLabels[defaultClauseIndex], core.UndefinedTextRange())
+	} else {
+		tx.emitBreak(endLabel, core.UndefinedTextRange())
+	}
+
+	for i, clause := range clauses {
+		tx.markLabel(clauseLabels[i])
+		tx.transformAndEmitStatements(clause.AsCaseOrDefaultClause().Statements.Nodes)
+	}
+
+	tx.endSwitchBlock()
+}
+
+func (tx *generatorTransformer) transformAndEmitLabeledStatement(node *ast.LabeledStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	// [source]
+	//      x: {
+	//          /*body*/
+	//      }
+	//
+	// [intermediate]
+	//  .labeled "x", endLabel
+	//      /*body*/
+	//  .endlabeled
+	//  .mark endLabel
+	tx.beginLabeledBlock(node.Label.Text())
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.endLabeledBlock()
+}
+
+func (tx *generatorTransformer) transformAndEmitThrowStatement(node *ast.ThrowStatement) {
+	expression := node.Expression
+	if expression == nil {
+		expression = tx.Factory().NewVoidZeroExpression()
+	}
+	tx.emitThrow(tx.Visitor().VisitNode(expression), node.Loc)
+}
+
+func (tx *generatorTransformer) transformAndEmitTryStatement(node *ast.TryStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitEachChild(node.AsNode()))
+		return
+	}
+	// [source]
+	//      try {
+	//          /*tryBlock*/
+	//      }
+	//      catch (e) {
+	//          /*catchBlock*/
+	//      }
+	//      finally {
+	//          /*finallyBlock*/
+	//      }
+	//
+	// [intermediate]
+	//  .local _b, e
+	//  .try tryLabel, catchLabel, finallyLabel, endLabel
+	//  .mark tryLabel
+	//  .nop
+	//      /*tryBlock*/
+	//  .br endLabel
+	//  .catch
+	//  .mark catchLabel
+	//      e = %error%;
+	//      /*catchBlock*/
+	//  .br endLabel
+	//  .finally
+	//  .mark finallyLabel
+	//      /*finallyBlock*/
+	//  .endfinally
+	//  .endtry
+	//  .mark endLabel
+	tx.beginExceptionBlock()
+	tx.transformAndEmitEmbeddedStatement(node.TryBlock)
+	if node.CatchClause != nil {
+		catchClause := node.CatchClause.AsCatchClause()
+		tx.beginCatchBlock(catchClause.VariableDeclaration)
+		tx.transformAndEmitEmbeddedStatement(catchClause.Block)
+	}
+	if node.FinallyBlock != nil {
+		tx.beginFinallyBlock()
+		tx.transformAndEmitEmbeddedStatement(node.FinallyBlock)
+	}
+	tx.endExceptionBlock()
+}
+
+func countInitialNodesWithoutYield(nodes []*ast.Node) int {
+	for i, node := range nodes {
+		if containsYield(node) {
+			return i
+		}
+	}
+	return len(nodes)
+}
+
+// Caches the value of an expression in a temporary variable so that it is evaluated before a `yield`.
+func (tx *generatorTransformer) cacheExpression(node *ast.Expression) *ast.Expression {
+	if ast.IsIdentifier(node) && (tx.EmitContext().HasAutoGenerateInfo(node) || tx.EmitContext().EmitFlags(node)&printer.EFHelperName != 0) {
+		return node
+	}
+	temp := tx.Factory().NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	tx.emitAssignment(temp, node, node.Loc)
+	return temp
+}
+
+// Declares a local variable that is hoisted to the enclosing function.
+func (tx *generatorTransformer) declareLocal(name string) *ast.IdentifierNode {
+	var temp *ast.IdentifierNode
+	if name != "" {
+		temp = tx.Factory().NewUniqueName(name)
+	} else {
+		temp = tx.Factory().NewTempVariable()
+	}
+	tx.EmitContext().AddVariableDeclaration(temp)
+	return temp
+}
+
+//
+// Labels and code blocks
+//
+
+// Defines a label that is not yet marked.
+func (tx *generatorTransformer) defineLabel() generatorLabel {
+	label := tx.nextLabelID
+	tx.nextLabelID++
+	for len(tx.labelOffsets) <= int(label) {
+		tx.labelOffsets = append(tx.labelOffsets, -1)
+	}
+	return label
+}
+
+// Marks the current operation with a label.
+func (tx *generatorTransformer) markLabel(label generatorLabel) {
+	debug.Assert(tx.labelOffsets != nil, "No labels were defined.")
+	tx.labelOffsets[label] = len(tx.operations)
+}
+
+func (tx *generatorTransformer) beginBlock(block *codeBlock) {
+	tx.blockActions = append(tx.blockActions, blockActionOpen)
+	tx.blockOffsets = append(tx.blockOffsets, len(tx.operations))
+	tx.blocks = append(tx.blocks, block)
+	tx.blockStack = append(tx.blockStack, block)
+}
+
+func (tx *generatorTransformer) endBlock() *codeBlock {
+	block := tx.peekBlock()
+	if block == nil {
+		panic("beginBlock was never called.")
+	}
+	tx.blockActions = append(tx.blockActions, blockActionClose)
+	tx.blockOffsets = append(tx.blockOffsets, len(tx.operations))
+	tx.blocks = append(tx.blocks, block)
+	tx.blockStack = tx.blockStack[:len(tx.blockStack)-1]
+	return block
+}
+
+func (tx *generatorTransformer) peekBlock() *codeBlock {
+	if len(tx.blockStack) == 0 {
+		return nil
+	}
+	return tx.blockStack[len(tx.blockStack)-1]
+}
+
+func (tx *generatorTransformer) assertPeekBlockKind(kind codeBlockKind) {
+	block := tx.peekBlock()
+	debug.Assert(block != nil && block.kind == kind, "Unexpected code block.")
+}
+
+func (tx *generatorTransformer) beginWithBlock(expression *ast.IdentifierNode) {
+	startLabel := tx.defineLabel()
+	endLabel := tx.defineLabel()
+	tx.markLabel(startLabel)
+	tx.beginBlock(&codeBlock{kind: codeBlockKindWith, expression: expression, startLabel: startLabel, endLabel: endLabel})
+}
+
+func (tx *generatorTransformer) endWithBlock() {
+	tx.assertPeekBlockKind(codeBlockKindWith)
+	block := tx.endBlock()
+	tx.markLabel(block.endLabel)
+}
+
+func (tx *generatorTransformer) beginExceptionBlock() generatorLabel {
+	startLabel := tx.defineLabel()
+	endLabel := tx.defineLabel()
+	tx.markLabel(startLabel)
+	tx.beginBlock(&codeBlock{kind: codeBlockKindException, exceptionState: exceptionBlockStateTry, startLabel: startLabel, endLabel: endLabel})
+	tx.emitNop()
+	return endLabel
+}
+
+// Enters the `catch` clause of an exception block, assigning the error to the hoisted catch variable.
+func (tx *generatorTransformer) beginCatchBlock(variable *ast.VariableDeclarationNode) {
+	tx.assertPeekBlockKind(codeBlockKindException)
+	var name *ast.Expression
+	if variable != nil {
+		tx.hoistVariable(variable.Name())
+		name = tx.transformBindingNameToAssignmentTarget(variable.Name())
+	}
+
+	exception := tx.peekBlock()
+	debug.Assert(exception.exceptionState < exceptionBlockStateCatch)
+	tx.emitBreak(exception.endLabel, core.UndefinedTextRange())
+	catchLabel := tx.defineLabel()
+	tx.markLabel(catchLabel)
+	exception.exceptionState = exceptionBlockStateCatch
+	exception.catchLabel = catchLabel
+	if name != nil {
+		tx.emitAssignment(name, tx.createGeneratorResume(core.UndefinedTextRange()), core.UndefinedTextRange())
+	} else {
+		tx.emitStatement(tx.Factory().NewExpressionStatement(tx.createGeneratorResume(core.UndefinedTextRange())))
+	}
+	tx.emitNop()
+}
+
+func (tx *generatorTransformer) beginFinallyBlock() {
+	tx.assertPeekBlockKind(codeBlockKindException)
+	exception := tx.peekBlock()
+	debug.Assert(exception.exceptionState < exceptionBlockStateFinally)
+	tx.emitBreak(exception.endLabel, core.UndefinedTextRange())
+	finallyLabel := tx.defineLabel()
+	tx.markLabel(finallyLabel)
+	exception.exceptionState = exceptionBlockStateFinally
+	exception.finallyLabel = finallyLabel
+}
+
+func (tx *generatorTransformer) endExceptionBlock() {
+	tx.assertPeekBlockKind(codeBlockKindException)
+	exception := tx.endBlock()
+	if exception.exceptionState < exceptionBlockStateFinally {
+		tx.emitBreak(exception.endLabel, core.UndefinedTextRange())
+	} else {
+		tx.emitEndfinally()
+	}
+	tx.markLabel(exception.endLabel)
+	tx.emitNop()
+	exception.exceptionState = exceptionBlockStateDone
+}
+
+// Begins a loop that does not contain a `yield` and is written as-is.
+func (tx *generatorTransformer) beginScriptLoopBlock() {
+	tx.beginBlock(&codeBlock{kind: codeBlockKindLoop, isScript: true, breakLabel: -1, continueLabel: -1})
+}
+
+// Begins a loop that contains a `yield`, returning the label that follows the loop.
+func (tx *generatorTransformer) beginLoopBlock(continueLabel generatorLabel) generatorLabel {
+	breakLabel := tx.defineLabel()
+	tx.beginBlock(&codeBlock{kind: codeBlockKindLoop, breakLabel: breakLabel, continueLabel: continueLabel})
+	return breakLabel
+}
+
+func (tx *generatorTransformer) endLoopBlock() {
+	tx.assertPeekBlockKind(codeBlockKindLoop)
+	block := tx.endBlock()
+	if !block.isScript {
+		tx.markLabel(block.breakLabel)
+	}
+}
+
+func (tx *generatorTransformer) beginScriptSwitchBlock() {
+	tx.beginBlock(&codeBlock{kind: codeBlockKindSwitch, isScript: true, breakLabel: -1})
+}
+
+func (tx *generatorTransformer) beginSwitchBlock() generatorLabel {
+	breakLabel := tx.defineLabel()
+	tx.beginBlock(&codeBlock{kind: codeBlockKindSwitch, breakLabel: breakLabel})
+	return breakLabel
+}
+
+func (tx *generatorTransformer) endSwitchBlock() {
+	tx.assertPeekBlockKind(codeBlockKindSwitch)
+	block := tx.endBlock()
+	if !block.isScript {
+		tx.markLabel(block.breakLabel)
+	}
+}
+
+func (tx *generatorTransformer) beginScriptLabeledBlock(labelText string) {
+	tx.beginBlock(&codeBlock{kind: codeBlockKindLabeled, isScript: true, labelText: labelText, breakLabel: -1})
+}
+
+func (tx *generatorTransformer) beginLabeledBlock(labelText string) {
+	breakLabel := tx.defineLabel()
+	tx.beginBlock(&codeBlock{kind: codeBlockKindLabeled, labelText: labelText, breakLabel: breakLabel})
+}
+
+func (tx *generatorTransformer) endLabeledBlock() {
+	tx.assertPeekBlockKind(codeBlockKindLabeled)
+	block := tx.endBlock()
+	if !block.isScript {
+		tx.markLabel(block.breakLabel)
+	}
+}
+
+func (block *codeBlock) supportsUnlabeledBreak() bool {
+	return block.kind == codeBlockKindSwitch || block.kind == codeBlockKindLoop
+}
+
+func (block *codeBlock) supportsLabeledBreakOrContinue() bool {
+	return block.kind == codeBlockKindLabeled
+}
+
+func (block *codeBlock) supportsUnlabeledContinue() bool {
+	return block.kind == codeBlockKindLoop
+}
+
+// Determines whether the block at `start` in the block stack is immediately labeled with `labelText`.
+func (tx *generatorTransformer) hasImmediateContainingLabeledBlock(labelText string, start int) bool {
+	for j := start; j >= 0; j-- {
+		containingBlock := tx.blockStack[j]
+		if !containingBlock.supportsLabeledBreakOrContinue() {
+			break
+		}
+		if containingBlock.labelText == labelText {
+			return true
+		}
+	}
+	return false
+}
+
+// Finds the label that a `break` statement jumps to, or 0 if the target is not part of the state machine.
+func (tx *generatorTransformer) findBreakTarget(label *ast.IdentifierNode) generatorLabel {
+	for i := len(tx.blockStack) - 1; i >= 0; i-- {
+		block := tx.blockStack[i]
+		if label == nil {
+			if block.supportsUnlabeledBreak() {
+				return block.breakLabel
+			}
+		} else if block.supportsLabeledBreakOrContinue() && block.labelText == label.Text() {
+			return block.breakLabel
+		} else if block.supportsUnlabeledBreak() && tx.hasImmediateContainingLabeledBlock(label.Text(), i-1) {
+			return block.breakLabel
+		}
+	}
+	return 0
+}
+
+// Finds the label that a `continue` statement jumps to, or 0 if the target is not part of the state machine.
+func (tx *generatorTransformer) findContinueTarget(label *ast.IdentifierNode) generatorLabel {
+	for i := len(tx.blockStack) - 1; i >= 0; i-- {
+		block := tx.blockStack[i]
+		if block.supportsUnlabeledContinue() && (label == nil || tx.hasImmediateContainingLabeledBlock(label.Text(), i-1)) {
+			return block.continueLabel
+		}
+	}
+	return 0
+}
+
+// Creates an expression that refers to a label. The number of the `case` clause for the label is not known
+// until the state machine is built, so the literal is updated once the label is written.
+func (tx *generatorTransformer) createLabel(label generatorLabel) *ast.Expression {
+	if label <= 0 {
+		return tx.Factory().NewOmittedExpression()
+	}
+	if tx.labelExpressions == nil {
+		tx.labelExpressions = make(map[generatorLabel][]*ast.Node)
+	}
+	expression := tx.Factory().NewNumericLiteral("9007199254740991")
+	tx.labelExpressions[label] = append(tx.labelExpressions[label], expression)
+	return expression
+}
+
+func (tx *generatorTransformer) createInstruction(instruction generatorInstruction) *ast.Expression {
+	literal := tx.Factory().NewNumericLiteral(strconv.Itoa(int(instruction)))
+	tx.EmitContext().AddSyntheticTrailingComment(literal, ast.KindMultiLineCommentTrivia, instruction.name(), false /*hasTrailingNewLine*/)
+	return literal
+}
+
+// Creates `return [3 /*break*/, label]` for a `break` or `continue` in a statement that is written as-is.
+func (tx *generatorTransformer) createInlineBreak(label generatorLabel, location core.TextRange) *ast.Statement {
+	debug.AssertLessThan(0, int(label), "Invalid label")
+	f := tx.Factory()
+	statement := f.NewReturnStatement(f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Expression{
+		tx.createInstruction(instructionBreak),
+		tx.createLabel(label),
+	}), false /*multiLine*/))
+	statement.Loc = location
+	return statement
+}
+
+// Creates `return [2 /*return*/, expression]` for a `return` in a statement that is written as-is.
+func (tx *generatorTransformer) createInlineReturn(expression *ast.Expression, location core.TextRange) *ast.Statement {
+	f := tx.Factory()
+	statement := f.NewReturnStatement(f.NewArrayLiteralExpression(f.NewNodeList(tx.newCompletion(instructionReturn, expression)), false /*multiLine*/))
+	statement.Loc = location
+	return statement
+}
+
+func (tx *generatorTransformer) newCompletion(instruction generatorInstruction, expression *ast.Expression) []*ast.Expression {
+	if expression == nil {
+		return []*ast.Expression{tx.createInstruction(instruction)}
+	}
+	return []*ast.Expression{tx.createInstruction(instruction), expression}
+}
+
+// Creates `state.sent()`, which evaluates to the value sent to the generator when it resumes.
+func (tx *generatorTransformer) createGeneratorResume(location core.TextRange) *ast.Expression {
+	f := tx.Factory()
+	expression := f.NewMethodCall(tx.state, f.NewIdentifier("sent"), []*ast.Expression{})
+	expression.Loc = location
+	return expression
+}
+
+//
+// Operations
+//
+
+func (tx *generatorTransformer) emitNop() {
+	tx.emitWorker(&generatorOperation{code: opCodeNop})
+}
+
+// Emits a statement, or a no-op if the statement was elided.
+func (tx *generatorTransformer) emitStatement(node *ast.Statement) {
+	if node == nil {
+		tx.emitNop()
+		return
+	}
+	tx.emitWorker(&generatorOperation{code: opCodeStatement, statement: node})
+}
+
+func (tx *generatorTransformer) emitAssignment(left *ast.Expression, right *ast.Expression, location core.TextRange) {
+	tx.emitWorker(&generatorOperation{code: opCodeAssign, target: left, expression: right, location: location})
+}
+
+func (tx *generatorTransformer) emitBreak(label generatorLabel, location core.TextRange) {
+	tx.emitWorker(&generatorOperation{code: opCodeBreak, label: label, location: location})
+}
+
+func (tx *generatorTransformer) emitBreakWhenTrue(label generatorLabel, condition *ast.Expression, location core.TextRange) {
+	tx.emitWorker(&generatorOperation{code: opCodeBreakWhenTrue, label: label, expression: condition, location: location})
+}
+
+func (tx *generatorTransformer) emitBreakWhenFalse(label generatorLabel, condition *ast.Expression, location core.TextRange) {
+	tx.emitWorker(&generatorOperation{code: opCodeBreakWhenFalse, label: label, expression: condition, location: location})
+}
+
+func (tx *generatorTransformer) emitYieldStar(expression *ast.Expression, location core.TextRange) {
+	tx.emitWorker(&generatorOperation{code: opCodeYieldStar, expression: expression, location: location})
+}
+
+func (tx *generatorTransformer) emitYield(expression *ast.Expression, location core.TextRange) {
+	tx.emitWorker(&generatorOperation{code: opCodeYield, expression: expression, location: location})
+}
+
+func (tx *generatorTransformer) emitReturn(expression *ast.Expression, location core.TextRange) {
+	tx.emitWorker(&generatorOperation{code: opCodeReturn, expression: expression, location: location})
+}
+
+func (tx *generatorTransformer) emitThrow(expression *ast.Expression, location core.TextRange) {
+	tx.emitWorker(&generatorOperation{code: opCodeThrow, expression: expression, location: location})
+}
+
+func (tx *generatorTransformer) emitEndfinally() {
+	tx.emitWorker(&generatorOperation{code: opCodeEndfinally})
+}
+
+func (tx *generatorTransformer) emitWorker(operation *generatorOperation) {
+	if tx.labelOffsets == nil {
+		// mark the entry point of the state machine
+		tx.markLabel(tx.defineLabel())
+	}
+	tx.operations = append(tx.operations, operation)
+}
+
+//
+// Building the state machine
+//
+
+// Builds the generator function that is passed to the `__generator` helper. An arrow function is used when the
+// body refers to `super`, which a function expression would not capture.
+func (tx *generatorTransformer) build(capturesSuper bool) *ast.Expression {
+	f := tx.Factory()
+	tx.blockIndex = 0
+	tx.labelNumber = 0
+	tx.labelNumbers = nil
+	tx.lastOperationWasAbrupt = false
+	tx.lastOperationWasCompletion = false
+	tx.clauses = nil
+	tx.statements = nil
+	tx.exceptionBlockStack = nil
+	tx.currentExceptionBlock = nil
+	tx.withBlockStack = nil
+
+	statements := tx.buildStatements()
+	parameters := f.NewNodeList([]*ast.Node{f.NewParameterDeclaration(nil, nil, tx.state, nil, nil, nil)})
+	body := f.NewBlock(f.NewNodeList(statements), len(statements) > 0 /*multiLine*/)
+	var function *ast.Expression
+	if capturesSuper {
+		function = f.NewArrowFunction(nil /*modifiers*/, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, f.NewToken(ast.KindEqualsGreaterThanToken), body)
+	} else {
+		function = f.NewFunctionExpression(nil /*modifiers*/, nil /*asteriskToken*/, nil /*name*/, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	}
+	tx.EmitContext().SetEmitFlags(function, printer.EFReuseTempVariableScope)
+	return f.NewGeneratorHelper(function)
+}
+
+func (tx *generatorTransformer) buildStatements() []*ast.Statement {
+	for operationIndex := range tx.operations {
+		tx.writeOperation(operationIndex)
+	}
+	tx.flushFinalLabel(len(tx.operations))
+
+	if tx.clauses != nil {
+		f := tx.Factory()
+		labelExpression := f.NewPropertyAccessExpression(tx.state, nil /*questionDotToken*/, f.NewIdentifier("label"), ast.NodeFlagsNone)
+		switchStatement := f.NewSwitchStatement(labelExpression, f.NewCaseBlock(f.NewNodeList(tx.clauses)))
+		tx.EmitContext().AddEmitFlags(switchStatement, printer.EFStartOnNewLine)
+		return []*ast.Statement{switchStatement}
+	}
+	return tx.statements
+}
+
+// Flushes the statements of the current label into a `case` clause.
+func (tx *generatorTransformer) flushLabel() {
+	if tx.statements == nil {
+		return
+	}
+	tx.appendLabel(!tx.lastOperationWasAbrupt /*markLabelEnd*/)
+	tx.lastOperationWasAbrupt = false
+	tx.lastOperationWasCompletion = false
+	tx.labelNumber++
+}
+
+// Flushes the final label, completing the generator if the end of the body is reachable.
+func (tx *generatorTransformer) flushFinalLabel(operationIndex int) {
+	if tx.isFinalLabelReachable(operationIndex) {
+		tx.tryEnterLabel(operationIndex)
+		tx.withBlockStack = nil
+		tx.writeReturn(nil /*expression*/, core.UndefinedTextRange())
+	}
+	if tx.statements != nil && tx.clauses != nil {
+		tx.appendLabel(false /*markLabelEnd*/)
+	}
+	tx.updateLabelExpressions()
+}
+
+func (tx *generatorTransformer) isFinalLabelReachable(operationIndex int) bool {
+	// if the last operation was *not* a completion (return/throw) then
+	// the final label is reachable.
+	if !tx.lastOperationWasCompletion {
+		return true
+	}
+	// if there are no labels defined or referenced, then the final label is
+	// not reachable.
+	if tx.labelOffsets == nil || tx.labelExpressions == nil {
+		return false
+	}
+	// if the label for this offset is referenced, then the final label
+	// is reachable.
+	for label, offset := range tx.labelOffsets {
+		if offset == operationIndex && tx.labelExpressions[generatorLabel(label)] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Appends a `case` clause for the current label number with the statements written so far.
+func (tx *generatorTransformer) appendLabel(markLabelEnd bool) {
+	f := tx.Factory()
+	if tx.statements != nil {
+		for i := len(tx.withBlockStack) - 1; i >= 0; i-- {
+			withBlock := tx.withBlockStack[i]
+			tx.statements = []*ast.Statement{f.NewWithStatement(withBlock.expression, f.NewBlock(f.NewNodeList(tx.statements), false /*multiLine*/))}
+		}
+
+		if tx.currentExceptionBlock != nil {
+			// we're inside of an exception block, so we need to record the try region:
+			//  _a.trys.push([startLabel, catchLabel, finallyLabel, endLabel]);
+			block := tx.currentExceptionBlock
+			trys := f.NewPropertyAccessExpression(tx.state, nil /*questionDotToken*/, f.NewIdentifier("trys"), ast.NodeFlagsNone)
+			region := f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Expression{
+				tx.createLabel(block.startLabel),
+				tx.createLabel(block.catchLabel),
+				tx.createLabel(block.finallyLabel),
+				tx.createLabel(block.endLabel),
+			}), false /*multiLine*/)
+			tx.statements = append([]*ast.Statement{f.NewExpressionStatement(f.NewMethodCall(trys, f.NewIdentifier("push"), []*ast.Expression{region}))}, tx.statements...)
+			tx.currentExceptionBlock = nil
+		}
+
+		if markLabelEnd {
+			// the last operation falls through to the next label:
+			//  _a.label = labelNumber + 1;
+			label := f.NewPropertyAccessExpression(tx.state, nil /*questionDotToken*/, f.NewIdentifier("label"), ast.NodeFlagsNone)
+			tx.statements = append(tx.statements, f.NewExpressionStatement(f.NewAssignmentExpression(label, f.NewNumericLiteral(strconv.Itoa(tx.labelNumber+1)))))
+		}
+	}
+
+	tx.clauses = append(tx.clauses, f.NewCaseOrDefaultClause(
+		ast.KindCaseClause,
+		f.NewNumericLiteral(strconv.Itoa(tx.labelNumber)),
+		f.NewNodeList(tx.statements),
+	))
+	tx.statements = nil
+}
+
+// Starts a new `case` clause if any label is marked at the operation.
+func (tx *generatorTransformer) tryEnterLabel(operationIndex int) {
+	for label, offset := range tx.labelOffsets {
+		if offset != operationIndex {
+			continue
+		}
+		tx.flushLabel()
+		for len(tx.labelNumbers) <= tx.labelNumber {
+			tx.labelNumbers = append(tx.labelNumbers, nil)
+		}
+		tx.labelNumbers[tx.labelNumber] = append(tx.labelNumbers[tx.labelNumber], generatorLabel(label))
+	}
+}
+
+// Updates the literals that refer to each label with the number of the `case` clause where it was written.
+func (tx *generatorTransformer) updateLabelExpressions() {
+	if tx.labelExpressions == nil {
+		return
+	}
+	for labelNumber, labels := range tx.labelNumbers {
+		for _, label := range labels {
+			for _, expression := range tx.labelExpressions[label] {
+				expression.AsNumericLiteral().Text = strconv.Itoa(labelNumber)
+			}
+		}
+	}
+}
+
+// Enters or leaves the exception and `with` blocks that begin or end at the operation.
+func (tx *generatorTransformer) tryEnterOrLeaveBlock(operationIndex int) {
+	for ; tx.blockIndex < len(tx.blockActions) && tx.blockOffsets[tx.blockIndex] <= operationIndex; tx.blockIndex++ {
+		block := tx.blocks[tx.blockIndex]
+		blockAction := tx.blockActions[tx.blockIndex]
+		switch block.kind {
+		case codeBlockKindException:
+			if blockAction == blockActionOpen {
+				if tx.statements == nil {
+					tx.statements = []*ast.Statement{}
+				}
+				tx.exceptionBlockStack = append(tx.exceptionBlockStack, tx.currentExceptionBlock)
+				tx.currentExceptionBlock = block
+			} else {
+				tx.currentExceptionBlock = tx.exceptionBlockStack[len(tx.exceptionBlockStack)-1]
+				tx.exceptionBlockStack = tx.exceptionBlockStack[:len(tx.exceptionBlockStack)-1]
+			}
+		case codeBlockKindWith:
+			if blockAction == blockActionOpen {
+				tx.withBlockStack = append(tx.withBlockStack, block)
+			} else {
+				tx.withBlockStack = tx.withBlockStack[:len(tx.withBlockStack)-1]
+			}
+		}
+	}
+}
+
+func (tx *generatorTransformer) writeOperation(operationIndex int) {
+	tx.tryEnterLabel(operationIndex)
+	tx.tryEnterOrLeaveBlock(operationIndex)
+
+	// early termination, nothing else to process in this label
+	if tx.lastOperationWasAbrupt {
+		return
+	}
+
+	tx.lastOperationWasAbrupt = false
+	tx.lastOperationWasCompletion = false
+
+	operation := tx.operations[operationIndex]
+	switch operation.code {
+	case opCodeNop:
+	case opCodeStatement:
+		tx.writeStatement(operation.statement)
+	case opCodeAssign:
+		tx.writeAssign(operation.target, operation.expression, operation.location)
+	case opCodeBreak:
+		tx.writeBreak(operation.label, operation.location)
+	case opCodeBreakWhenTrue:
+		tx.writeBreakWhenTrue(operation.label, operation.expression, operation.location)
+	case opCodeBreakWhenFalse:
+		tx.writeBreakWhenFalse(operation.label, operation.expression, operation.location)
+	case opCodeYield:
+		tx.writeYield(operation.expression, operation.location)
+	case opCodeYieldStar:
+		tx.writeYieldStar(operation.expression, operation.location)
+	case opCodeReturn:
+		tx.writeReturn(operation.expression, operation.location)
+	case opCodeThrow:
+		tx.writeThrow(operation.expression, operation.location)
+	case opCodeEndfinally:
+		tx.writeEndfinally()
+	}
+}
+
+func (tx *generatorTransformer) writeStatement(statement *ast.Statement) {
+	tx.statements = append(tx.statements, statement)
+}
+
+func (tx *generatorTransformer) writeAssign(left *ast.Expression, right *ast.Expression, location core.TextRange) {
+	statement := tx.Factory().NewExpressionStatement(tx.Factory().NewAssignmentExpression(left, right))
+	statement.Loc = location
+	tx.writeStatement(statement)
+}
+
+func (tx *generatorTransformer) writeThrow(expression *ast.Expression, location core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.lastOperationWasCompletion = true
+	statement := tx.Factory().NewThrowStatement(expression)
+	statement.Loc = location
+	tx.writeStatement(statement)
+}
+
+func (tx *generatorTransformer) writeReturn(expression *ast.Expression, location core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.lastOperationWasCompletion = true
+	tx.writeInstruction(tx.newCompletion(instructionReturn, expression), location)
+}
+
+func (tx *generatorTransformer) writeBreak(label generatorLabel, location core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.writeInstruction([]*ast.Expression{tx.createInstruction(instructionBreak), tx.createLabel(label)}, location)
+}
+
+func (tx *generatorTransformer) writeBreakWhenTrue(label generatorLabel, condition *ast.Expression, location core.TextRange) {
+	f := tx.Factory()
+	statement := f.NewIfStatement(condition, tx.newInstructionStatement([]*ast.Expression{tx.createInstruction(instructionBreak), tx.createLabel(label)}, location), nil /*elseStatement*/)
+	tx.EmitContext().SetEmitFlags(statement, printer.EFSingleLine)
+	tx.writeStatement(statement)
+}
+
+func (tx *generatorTransformer) writeBreakWhenFalse(label generatorLabel, condition *ast.Expression, location core.TextRange) {
+	f := tx.Factory()
+	statement := f.NewIfStatement(f.NewPrefixUnaryExpression(ast.KindExclamationToken, condition), tx.newInstructionStatement([]*ast.Expression{tx.createInstruction(instructionBreak), tx.createLabel(label)}, location), nil /*elseStatement*/)
+	tx.EmitContext().SetEmitFlags(statement, printer.EFSingleLine)
+	tx.writeStatement(statement)
+}
+
+func (tx *generatorTransformer) writeYield(expression *ast.Expression, location core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.writeInstruction(tx.newCompletion(instructionYield, expression), location)
+}
+
+func (tx *generatorTransformer) writeYieldStar(expression *ast.Expression, location core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.writeInstruction([]*ast.Expression{tx.createInstruction(instructionYieldStar), expression}, location)
+}
+
+func (tx *generatorTransformer) writeEndfinally() {
+	tx.lastOperationWasAbrupt = true
+	tx.writeInstruction([]*ast.Expression{tx.createInstruction(instructionEndfinally)}, core.UndefinedTextRange())
+}
+
+func (tx *generatorTransformer) writeInstruction(elements []*ast.Expression, location core.TextRange) {
+	tx.writeStatement(tx.newInstructionStatement(elements, location))
+}
+
+// Creates `return [instruction, ...operands]`.
+func (tx *generatorTransformer) newInstructionStatement(elements []*ast.Expression, location core.TextRange) *ast.Statement {
+	f := tx.Factory()
+	statement := f.NewReturnStatement(f.NewArrayLiteralExpression(f.NewNodeList(elements), false /*multiLine*/))
+	statement.Loc = location
+	tx.EmitContext().SetEmitFlags(statement, printer.EFNoTokenSourceMaps)
+	return statement
+}
@@ -0,0 +1,559 @@
+package estransforms
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/transformers"
+)
+
+type iterationFunctionScope struct {
+	inIterationStatement bool
+	loweredParameters    map[*ast.Node]struct{}
+}
+
+// Transforms `for..of` statements, spread elements, and array destructuring to use the iterator protocol through
+// the `__values`, `__read`, and `__spreadArray` helpers when `--downlevelIteration` is set. Without that option,
+// this syntax is left as-is.
+type iterationTransformer struct {
+	transformers.Transformer
+	compilerOptions *core.CompilerOptions
+	flattener       *destructuringFlattener
+
+	inIterationStatement        bool                   // whether the node being visited is within an iteration statement of the enclosing function
+	inExportedVariableStatement bool                   // whether the node being visited is within an exported variable statement
+	loweredParameters           map[*ast.Node]struct{} // parameters of the enclosing function that are evaluated in its body
+}
+
+func newIterationTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	tx := &iterationTransformer{compilerOptions: opts.CompilerOptions}
+	result := tx.NewTransformer(tx.visit, opts.Context)
+	tx.flattener = newDestructuringFlattener(tx.EmitContext(), tx.Visitor(), true /*downlevelIteration*/)
+	return result
+}
+
+func (tx *iterationTransformer) visit(node *ast.Node) *ast.Node {
+	if !tx.compilerOptions.DownlevelIteration.IsTrue() {
+		return node
+	}
+	if node.SubtreeFacts()&ast.SubtreeContainsIteration == 0 {
+		// parameters that follow a lowered parameter are moved into the body even if they do not use iteration
+		if _, ok := tx.loweredParameters[node]; !ok {
+			return node
+		}
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindLabeledStatement:
+		return tx.visitLabeledStatement(node.AsLabeledStatement())
+	case ast.KindForOfStatement:
+		return tx.visitForOfStatement(node.AsForInOrOfStatement(), nil /*outermostLabeledStatement*/)
+	case ast.KindForStatement, ast.KindForInStatement, ast.KindWhileStatement, ast.KindDoStatement:
+		return tx.visitIterationStatement(node)
+	case ast.KindExpressionStatement:
+		return tx.visitExpressionStatement(node.AsExpressionStatement())
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression(), false /*expressionResultIsUnused*/)
+	case ast.KindVariableStatement:
+		return tx.visitVariableStatement(node.AsVariableStatement())
+	case ast.KindVariableDeclaration:
+		return tx.visitVariableDeclaration(node.AsVariableDeclaration())
+	case ast.KindCatchClause:
+		return tx.visitCatchClause(node.AsCatchClause())
+	case ast.KindParameter:
+		return tx.visitParameter(node.AsParameterDeclaration())
+	case ast.KindArrayLiteralExpression:
+		return tx.visitArrayLiteralExpression(node.AsArrayLiteralExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindNewExpression:
+		return tx.visitNewExpression(node.AsNewExpression())
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindArrowFunction, ast.KindMethodDeclaration,
+		ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor:
+		return tx.visitFunctionLike(node)
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		// a loop in the enclosing function does not repeat the members of a class
+		inIterationStatement := tx.inIterationStatement
+		tx.inIterationStatement = false
+		defer func() { tx.inIterationStatement = inIterationStatement }()
+		return tx.Visitor().VisitEachChild(node)
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *iterationTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited.AsNode(), tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+func (tx *iterationTransformer) visitFunctionLike(node *ast.Node) *ast.Node {
+	scope := iterationFunctionScope{inIterationStatement: tx.inIterationStatement, loweredParameters: tx.loweredParameters}
+	defer func() {
+		tx.inIterationStatement = scope.inIterationStatement
+		tx.loweredParameters = scope.loweredParameters
+	}()
+	tx.inIterationStatement = false
+	tx.loweredParameters = collectLoweredParameters(node)
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// Collects the parameters of a function that must be evaluated in its body, starting with the first parameter whose
+// binding pattern reads from an iterator. Later parameters are moved as well so that they are evaluated in order.
+func collectLoweredParameters(node *ast.Node) map[*ast.Node]struct{} {
+	var result map[*ast.Node]struct{}
+	for _, parameter := range node.Parameters() {
+		if result == nil {
+			if !ast.IsBindingPattern(parameter.Name()) || parameter.Name().SubtreeFacts()&ast.SubtreeContainsIteration == 0 {
+				continue
+			}
+			result = make(map[*ast.Node]struct{})
+		}
+		result[parameter] = struct{}{}
+	}
+	return result
+}
+
+func (tx *iterationTransformer) visitParameter(node *ast.ParameterDeclaration) *ast.Node {
+	if _, ok := tx.loweredParameters[node.AsNode()]; !ok {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	f := tx.Factory()
+	if ast.IsBindingPattern(node.Name()) {
+		// `function f([a, b]) {}` becomes `function f(_a) { var _b = __read(_a, 2), a = _b[0], b = _b[1]; }`
+		name := f.NewGeneratedNameForNode(node.AsNode())
+		if len(node.Name().AsBindingPattern().Elements.Nodes) > 0 {
+			declarations := tx.flattener.flattenDestructuringBinding(flattenLevelAll, node.AsNode(), name, false /*hoist*/, false /*skipInitializer*/)
+			if declarations != nil {
+				statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(syntaxListToNodes(declarations))))
+				tx.EmitContext().AddInitializationStatement(statement)
+			}
+		} else if node.Initializer != nil {
+			// an empty binding pattern reads nothing, but its initializer must still be evaluated for side effects
+			tx.EmitContext().AddInitializationStatement(f.NewExpressionStatement(f.NewAssignmentExpression(name, tx.Visitor().VisitNode(node.Initializer))))
+		}
+		return f.UpdateParameterDeclaration(node, nil /*modifiers*/, node.DotDotDotToken, name, nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)
+	}
+
+	if node.Initializer != nil {
+		// `function f(x = 1) {}` becomes `function f(x) { if (x === void 0) { x = 1; } }`
+		name := node.Name().Clone(f)
+		name.Loc = node.Name().Loc
+		tx.EmitContext().AddEmitFlags(name, printer.EFNoSourceMap)
+
+		initializer := tx.Visitor().VisitNode(node.Initializer)
+		tx.EmitContext().AddEmitFlags(initializer, printer.EFNoSourceMap|printer.EFNoComments)
+
+		assignment := f.NewAssignmentExpression(name, initializer)
+		assignment.Loc = node.Loc
+		tx.EmitContext().AddEmitFlags(assignment, printer.EFNoComments)
+
+		block := f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewExpressionStatement(assignment)}), false /*multiLine*/)
+		block.Loc = node.Loc
+		tx.EmitContext().AddEmitFlags(block, printer.EFSingleLine|printer.EFNoTrailingSourceMap|printer.EFNoTokenSourceMaps|printer.EFNoComments)
+
+		statement := f.NewIfStatement(f.NewStrictEqualityExpression(node.Name().Clone(f), f.NewVoidZeroExpression()), block, nil /*elseStatement*/)
+		statement.Loc = node.Loc
+		tx.EmitContext().AddEmitFlags(statement, printer.EFNoTokenSourceMaps|printer.EFNoTrailingSourceMap|printer.EFNoComments|printer.EFStartOnNewLine)
+		tx.EmitContext().AddInitializationStatement(statement)
+	}
+	return f.UpdateParameterDeclaration(node, nil /*modifiers*/, node.DotDotDotToken, node.Name(), nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)
+}
+
+func (tx *iterationTransformer) visitLabeledStatement(node *ast.LabeledStatement) *ast.Node {
+	statement := node.Statement
+	for ast.IsLabeledStatement(statement) {
+		statement = statement.AsLabeledStatement().Statement
+	}
+	if ast.IsForOfStatement(statement) && statement.AsForInOrOfStatement().AwaitModifier == nil {
+		// the labels must remain on the loop that replaces the `for..of` statement so that `continue` still works
+		return tx.visitForOfStatement(statement.AsForInOrOfStatement(), node)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *iterationTransformer) visitIterationStatement(node *ast.Node) *ast.Node {
+	inIterationStatement := tx.inIterationStatement
+	tx.inIterationStatement = true
+	defer func() { tx.inIterationStatement = inIterationStatement }()
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// Transforms a `for..of` statement into a `for` statement that steps an iterator, closing the iterator if the loop
+// exits early:
+//
+//	try {
+//	    for (var y_1 = __values(y), y_1_1 = y_1.next(); !y_1_1.done; y_1_1 = y_1.next()) {
+//	        const x = y_1_1.value;
+//	    }
+//	}
+//	catch (e_1_1) { e_1 = { error: e_1_1 }; }
+//	finally {
+//	    try {
+//	        if (y_1_1 && !y_1_1.done && (_a = y_1.return)) _a.call(y_1);
+//	    }
+//	    finally { if (e_1) throw e_1.error; }
+//	}
+func (tx *iterationTransformer) visitForOfStatement(node *ast.ForInOrOfStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	if node.AwaitModifier != nil {
+		return tx.visitIterationStatement(node.AsNode())
+	}
+
+	inIterationStatement := tx.inIterationStatement
+	tx.inIterationStatement = true
+	defer func() { tx.inIterationStatement = inIterationStatement }()
+
+	f := tx.Factory()
+	expression := tx.Visitor().VisitNode(node.Expression)
+	var iterator, result *ast.IdentifierNode
+	if ast.IsIdentifier(expression) {
+		iterator = f.NewGeneratedNameForNode(expression)
+		result = f.NewGeneratedNameForNode(iterator)
+	} else {
+		iterator = f.NewTempVariable()
+		result = f.NewTempVariable()
+	}
+	errorRecord := f.NewUniqueName("e")
+	catchVariable := f.NewGeneratedNameForNode(errorRecord)
+	returnMethod := f.NewTempVariable()
+	callValues := f.NewValuesHelper(expression)
+	callValues.Loc = node.Expression.Loc
+	newCallNext := func() *ast.Expression {
+		return f.NewCallExpression(
+			f.NewPropertyAccessExpression(iterator, nil /*questionDotToken*/, f.NewIdentifier("next"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			f.NewNodeList([]*ast.Expression{}),
+			ast.NodeFlagsNone,
+		)
+	}
+	newGetDone := func() *ast.Expression {
+		return f.NewPropertyAccessExpression(result, nil /*questionDotToken*/, f.NewIdentifier("done"), ast.NodeFlagsNone)
+	}
+	getValue := f.NewPropertyAccessExpression(result, nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)
+
+	tx.EmitContext().AddVariableDeclaration(errorRecord)
+	tx.EmitContext().AddVariableDeclaration(returnMethod)
+
+	// if we are enclosed in an outer loop, ensure we reset the error record for each iteration
+	initializer := callValues
+	if inIterationStatement {
+		initializer = f.InlineExpressions([]*ast.Expression{
+			f.NewAssignmentExpression(errorRecord, f.NewVoidZeroExpression()),
+			callValues,
+		})
+	}
+
+	iteratorDeclaration := f.NewVariableDeclaration(iterator, nil /*exclamationToken*/, nil /*typeNode*/, initializer)
+	iteratorDeclaration.Loc = node.Expression.Loc
+	declarationList := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		iteratorDeclaration,
+		f.NewVariableDeclaration(result, nil /*exclamationToken*/, nil /*typeNode*/, newCallNext()),
+	}))
+	declarationList.Loc = node.Expression.Loc
+	tx.EmitContext().AddEmitFlags(declarationList, printer.EFNoHoisting)
+
+	forStatement := f.NewForStatement(
+		declarationList,
+		f.NewPrefixUnaryExpression(ast.KindExclamationToken, newGetDone()),
+		f.NewAssignmentExpression(result, newCallNext()),
+		tx.convertForOfStatementHead(node, getValue),
+	)
+	forStatement.Loc = node.Loc
+	tx.EmitContext().AddEmitFlags(forStatement, printer.EFNoTokenTrailingSourceMaps)
+	tx.EmitContext().SetOriginal(forStatement, node.AsNode())
+
+	catchBlock := f.NewBlock(f.NewNodeList([]*ast.Statement{
+		f.NewExpressionStatement(f.NewAssignmentExpression(
+			errorRecord,
+			f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+				f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("error"), nil /*postfixToken*/, nil /*typeNode*/, catchVariable),
+			}), false /*multiLine*/),
+		)),
+	}), false /*multiLine*/)
+	tx.EmitContext().AddEmitFlags(catchBlock, printer.EFSingleLine)
+
+	closeIterator := f.NewIfStatement(
+		f.NewLogicalANDExpression(
+			f.NewLogicalANDExpression(
+				result,
+				f.NewPrefixUnaryExpression(ast.KindExclamationToken, newGetDone()),
+			),
+			f.NewAssignmentExpression(
+				returnMethod,
+				f.NewPropertyAccessExpression(iterator, nil /*questionDotToken*/, f.NewIdentifier("return"), ast.NodeFlagsNone),
+			),
+		),
+		f.NewExpressionStatement(f.NewFunctionCallCall(returnMethod, iterator, nil /*argumentsList*/)),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().AddEmitFlags(closeIterator, printer.EFSingleLine)
+
+	rethrowError := f.NewIfStatement(
+		errorRecord,
+		f.NewThrowStatement(f.NewPropertyAccessExpression(errorRecord, nil /*questionDotToken*/, f.NewIdentifier("error"), ast.NodeFlagsNone)),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().AddEmitFlags(rethrowError, printer.EFSingleLine)
+	finallyBlock := f.NewBlock(f.NewNodeList([]*ast.Statement{rethrowError}), false /*multiLine*/)
+	tx.EmitContext().AddEmitFlags(finallyBlock, printer.EFSingleLine)
+
+	return f.NewTryStatement(
+		f.NewBlock(f.NewNodeList([]*ast.Statement{restoreEnclosingLabel(f, forStatement, outermostLabeledStatement)}), true /*multiLine*/),
+		f.NewCatchClause(f.NewVariableDeclaration(catchVariable, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/), catchBlock),
+		f.NewBlock(f.NewNodeList([]*ast.Statement{
+			f.NewTryStatement(
+				f.NewBlock(f.NewNodeList([]*ast.Statement{closeIterator}), true /*multiLine*/),
+				nil, /*catchClause*/
+				finallyBlock,
+			),
+		}), true /*multiLine*/),
+	)
+}
+
+// Creates the body of the loop that replaces a `for..of` statement, which binds the value of the current iteration
+// to the initializer before running the original body.
+func (tx *iterationTransformer) convertForOfStatementHead(node *ast.ForInOrOfStatement, boundValue *ast.Expression) *ast.BlockNode {
+	f := tx.Factory()
+	var statements []*ast.Statement
+	if binding := tx.Visitor().VisitNode(createForOfBindingStatement(f, node.Initializer, boundValue)); binding != nil {
+		statements = append(statements, binding)
+	}
+
+	bodyLocation := core.UndefinedTextRange()
+	statementsLocation := core.UndefinedTextRange()
+	statement := tx.EmitContext().VisitEmbeddedStatement(node.Statement, tx.Visitor())
+	if ast.IsBlock(statement) {
+		statements = append(statements, statement.AsBlock().Statements.Nodes...)
+		bodyLocation = statement.Loc
+		statementsLocation = statement.AsBlock().Statements.Loc
+	} else if statement != nil {
+		statements = append(statements, statement)
+	}
+
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = statementsLocation
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	block.Loc = bodyLocation
+	return block
+}
+
+func (tx *iterationTransformer) visitExpressionStatement(node *ast.ExpressionStatement) *ast.Node {
+	if ast.IsBinaryExpression(node.Expression) {
+		// the value of a destructuring assignment in statement position is never observed
+		return tx.Factory().UpdateExpressionStatement(node, tx.visitBinaryExpression(node.Expression.AsBinaryExpression(), true /*expressionResultIsUnused*/))
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *iterationTransformer) visitBinaryExpression(node *ast.BinaryExpression, expressionResultIsUnused bool) *ast.Node {
+	if ast.IsDestructuringAssignment(node.AsNode()) && assignmentPatternContainsArrayPattern(node.Left) {
+		return tx.flattener.flattenDestructuringAssignment(flattenLevelAll, node, !expressionResultIsUnused /*needsValue*/)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Determines whether an assignment pattern reads from an iterator, either directly or through a nested pattern.
+func assignmentPatternContainsArrayPattern(pattern *ast.Node) bool {
+	if ast.IsArrayLiteralExpression(pattern) {
+		return true
+	}
+	for _, element := range ast.GetElementsOfBindingOrAssignmentPattern(pattern) {
+		target := ast.GetTargetOfBindingOrAssignmentElement(element)
+		if target != nil && ast.IsAssignmentPattern(target) && assignmentPatternContainsArrayPattern(target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tx *iterationTransformer) visitVariableStatement(node *ast.VariableStatement) *ast.Node {
+	inExportedVariableStatement := tx.inExportedVariableStatement
+	tx.inExportedVariableStatement = ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport)
+	defer func() { tx.inExportedVariableStatement = inExportedVariableStatement }()
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *iterationTransformer) visitVariableDeclaration(node *ast.VariableDeclaration) *ast.Node {
+	exported := tx.inExportedVariableStatement
+	tx.inExportedVariableStatement = false
+	defer func() { tx.inExportedVariableStatement = exported }()
+	if ast.IsBindingPattern(node.Name()) && node.Name().SubtreeFacts()&ast.SubtreeContainsIteration != 0 {
+		// temporaries of an exported declaration are hoisted so that they are not exported themselves
+		return tx.flattener.flattenDestructuringBinding(flattenLevelAll, node.AsNode(), nil /*rvalue*/, exported /*hoist*/, false /*skipInitializer*/)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *iterationTransformer) visitCatchClause(node *ast.CatchClause) *ast.Node {
+	if node.VariableDeclaration == nil || !ast.IsBindingPattern(node.VariableDeclaration.Name()) || node.VariableDeclaration.Name().SubtreeFacts()&ast.SubtreeContainsIteration == 0 {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// `catch ([a, b]) {}` becomes `catch (_a) { var _b = __read(_a, 2), a = _b[0], b = _b[1]; }`
+	f := tx.Factory()
+	name := f.NewGeneratedNameForNode(node.VariableDeclaration.Name())
+	updatedDecl := f.UpdateVariableDeclaration(node.VariableDeclaration.AsVariableDeclaration(), node.VariableDeclaration.Name(), nil /*exclamationToken*/, nil /*typeNode*/, name)
+	bindings := tx.flattener.flattenDestructuringBinding(flattenLevelAll, updatedDecl, nil /*rvalue*/, false /*hoist*/, false /*skipInitializer*/)
+	block := tx.Visitor().VisitNode(node.Block)
+	if bindings != nil {
+		statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(syntaxListToNodes(bindings))))
+		statementList := f.NewNodeList(append([]*ast.Statement{statement}, block.AsBlock().Statements.Nodes...))
+		statementList.Loc = block.AsBlock().Statements.Loc
+		block = f.UpdateBlock(block.AsBlock(), statementList)
+	}
+	return f.UpdateCatchClause(
+		node,
+		f.UpdateVariableDeclaration(node.VariableDeclaration.AsVariableDeclaration(), name, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/),
+		block,
+	)
+}
+
+func (tx *iterationTransformer) visitArrayLiteralExpression(node *ast.ArrayLiteralExpression) *ast.Node {
+	if core.Some(node.Elements.Nodes, ast.IsSpreadElement) {
+		return tx.transformAndSpreadElements(node.Elements, false /*isArgumentList*/, node.MultiLine)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Visits a call with spread arguments, which becomes a call to `Function.prototype.apply`:
+//
+//	a.b(...c)  =>  a.b.apply(a, __spreadArray([], __read(c), false))
+func (tx *iterationTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if !core.Some(node.Arguments.Nodes, ast.IsSpreadElement) || ast.IsSuperCall(node.AsNode()) || ast.IsImportCall(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	f := tx.Factory()
+	target, thisArg := f.NewCallBinding(node.Expression, tx.EmitContext().AddVariableDeclaration, false /*cacheIdentifiers*/)
+	updated := f.NewFunctionApplyCall(
+		tx.Visitor().VisitNode(target),
+		tx.Visitor().VisitNode(thisArg),
+		tx.transformAndSpreadElements(node.Arguments, true /*isArgumentList*/, false /*multiLine*/),
+	)
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+// Visits a `new` expression with spread arguments, which binds the constructor to its arguments:
+//
+//	new C(...a)  =>  new (C.bind.apply(C, __spreadArray([void 0], __read(a), false)))()
+func (tx *iterationTransformer) visitNewExpression(node *ast.NewExpression) *ast.Node {
+	if node.Arguments == nil || !core.Some(node.Arguments.Nodes, ast.IsSpreadElement) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	f := tx.Factory()
+	target, thisArg := f.NewCallBinding(
+		f.NewPropertyAccessExpression(node.Expression, nil /*questionDotToken*/, f.NewIdentifier("bind"), ast.NodeFlagsNone),
+		tx.EmitContext().AddVariableDeclaration,
+		false, /*cacheIdentifiers*/
+	)
+	arguments := f.NewNodeList(append([]*ast.Expression{f.NewVoidZeroExpression()}, node.Arguments.Nodes...))
+	updated := f.NewNewExpression(
+		f.NewFunctionApplyCall(
+			tx.Visitor().VisitNode(target),
+			thisArg,
+			tx.transformAndSpreadElements(arguments, true /*isArgumentList*/, false /*multiLine*/),
+		),
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{}),
+	)
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+type spreadSegmentKind int
+
+const (
+	spreadSegmentKindNone           spreadSegmentKind = iota // not a spread segment
+	spreadSegmentKindUnpackedSpread                          // a spread segment that may contain holes, which must be replaced with `undefined`
+	spreadSegmentKindPackedSpread                            // a spread segment that is known not to contain holes, such as `[...[1, 2]]` or `[...__read(a)]`
+)
+
+type spreadSegment struct {
+	kind       spreadSegmentKind
+	expression *ast.Expression
+}
+
+// Transforms a list of elements containing spread elements into a series of calls to `__spreadArray`:
+//
+//	[a, ...b, c]  =>  __spreadArray(__spreadArray([a], __read(b), false), [c], false)
+//	[...a, b]     =>  __spreadArray(__spreadArray([], __read(a), false), [b], false)
+func (tx *iterationTransformer) transformAndSpreadElements(elements *ast.NodeList, isArgumentList bool, multiLine bool) *ast.Expression {
+	f := tx.Factory()
+	var segments []spreadSegment
+	var chunk []*ast.Expression
+	flushChunk := func() {
+		if len(chunk) == 0 {
+			return
+		}
+		// non-spread segments are not packed so that `[1, , ...[2, , 3], , 4]` becomes `[1, , 2, undefined, 3, , 4]`
+		segments = append(segments, spreadSegment{spreadSegmentKindNone, f.NewArrayLiteralExpression(tx.Visitor().VisitNodes(f.NewNodeList(chunk)), multiLine)})
+		chunk = nil
+	}
+	for _, element := range elements.Nodes {
+		if ast.IsSpreadElement(element) {
+			flushChunk()
+			segments = append(segments, tx.visitExpressionOfSpread(element.AsSpreadElement()))
+		} else {
+			chunk = append(chunk, element)
+		}
+	}
+	flushChunk()
+
+	if len(segments) == 1 {
+		// arguments spread into `apply` must still be coerced into an array, so only reuse an existing array
+		first := segments[0]
+		if isPackedArrayLiteral(first.expression) || tx.EmitContext().IsCallToHelper(first.expression, "__spreadArray") {
+			return first.expression
+		}
+	}
+
+	startsWithSpread := segments[0].kind != spreadSegmentKindNone
+	var expression *ast.Expression
+	if startsWithSpread {
+		expression = f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Expression{}), false /*multiLine*/)
+	} else {
+		expression = segments[0].expression
+		segments = segments[1:]
+	}
+	for _, segment := range segments {
+		// holes do not matter in an argument list
+		expression = f.NewSpreadArrayHelper(expression, segment.expression, segment.kind == spreadSegmentKindUnpackedSpread && !isArgumentList)
+	}
+	return expression
+}
+
+func (tx *iterationTransformer) visitExpressionOfSpread(node *ast.SpreadElement) spreadSegment {
+	expression := tx.Visitor().VisitNode(node.Expression)
+
+	// existing array literals and calls to `__read` do not need to be packed
+	isCallToReadHelper := tx.EmitContext().IsCallToHelper(expression, "__read")
+	kind := spreadSegmentKindUnpackedSpread
+	if isCallToReadHelper || isPackedArrayLiteral(expression) {
+		kind = spreadSegmentKindPackedSpread
+	}
+
+	// array literals are packed by `__spreadArray`, so they do not need to be read through the iterator protocol
+	if kind == spreadSegmentKindUnpackedSpread && !ast.IsArrayLiteralExpression(expression) {
+		expression = tx.Factory().NewReadHelper(expression, -1 /*count*/)
+		kind = spreadSegmentKindPackedSpread
+	}
+	return spreadSegment{kind, expression}
+}
+
+func isPackedArrayLiteral(node *ast.Expression) bool {
+	return ast.IsArrayLiteralExpression(node) && !core.Some(node.AsArrayLiteralExpression().Elements.Nodes, ast.IsOmittedExpression)
+}
+
+func syntaxListToNodes(node *ast.Node) []*ast.Node {
+	if node.Kind == ast.KindSyntaxList {
+		return node.AsSyntaxList().Children
+	}
+	return []*ast.Node{node}
+}
@@ -1,6 +1,8 @@
 package estransforms
 
 import (
+	"slices"
+
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
@@ -47,3 +49,72 @@ func createNotNullCondition(emitContext *printer.EmitContext, left *ast.Node, ri
 		),
 	)
 }
+
+// Inserts statements following the `super` call at `superPath` (if any) and any statements matching `skip` that
+// immediately follow it.
+func insertStatementsAfterSuperCall(f *printer.NodeFactory, statements []*ast.Statement, superPath []int, inserted []*ast.Statement, skip func(*ast.Statement) bool) []*ast.Statement {
+	index := 0
+	if len(superPath) > 0 {
+		superStatementIndex := superPath[0]
+		superStatement := statements[superStatementIndex]
+		if ast.IsTryStatement(superStatement) {
+			tryStatement := superStatement.AsTryStatement()
+			tryBlock := tryStatement.TryBlock.AsBlock()
+			tryBlockStatementList := f.NewNodeList(insertStatementsAfterSuperCall(f, tryBlock.Statements.Nodes, superPath[1:], inserted, skip))
+			tryBlockStatementList.Loc = tryBlock.Statements.Loc
+			result := slices.Clone(statements)
+			result[superStatementIndex] = f.UpdateTryStatement(
+				tryStatement,
+				f.UpdateBlock(tryBlock, tryBlockStatementList),
+				tryStatement.CatchClause,
+				tryStatement.FinallyBlock,
+			)
+			return result
+		}
+		index = superStatementIndex + 1
+	}
+	for skip != nil && index < len(statements) && skip(statements[index]) {
+		index++
+	}
+	return slices.Concat(statements[:index], inserted, statements[index:])
+}
+
+// Creates the statement that binds the value of the current iteration to the initializer of a `for..of` statement.
+func createForOfBindingStatement(f *printer.NodeFactory, node *ast.Node, boundValue *ast.Node) *ast.Node {
+	if ast.IsVariableDeclarationList(node) {
+		firstDeclaration := node.AsVariableDeclarationList().Declarations.Nodes[0]
+		updatedDeclaration := f.UpdateVariableDeclaration(
+			firstDeclaration.AsVariableDeclaration(),
+			firstDeclaration.Name(),
+			nil,
+			nil,
+			boundValue,
+		)
+		statement := f.NewVariableStatement(
+			nil,
+			f.UpdateVariableDeclarationList(
+				node.AsVariableDeclarationList(),
+				f.NewNodeList([]*ast.Node{updatedDeclaration}),
+			),
+		)
+		statement.Loc = node.Loc
+		return statement
+	} else {
+		updatedExpression := f.NewAssignmentExpression(node, boundValue)
+		updatedExpression.Loc = node.Loc
+		statement := f.NewExpressionStatement(updatedExpression)
+		statement.Loc = node.Loc
+		return statement
+	}
+}
+
+// Wraps a statement that replaces the innermost statement of `outermostLabeledStatement` in the same labels.
+func restoreEnclosingLabel(f *printer.NodeFactory, node *ast.Statement, outermostLabeledStatement *ast.LabeledStatement) *ast.Statement {
+	if outermostLabeledStatement == nil {
+		return node
+	}
+	if ast.IsLabeledStatement(outermostLabeledStatement.Statement) {
+		node = restoreEnclosingLabel(f, node, outermostLabeledStatement.Statement.AsLabeledStatement())
+	}
+	return f.UpdateLabeledStatement(outermostLabeledStatement, outermostLabeledStatement.Label, node)
+}
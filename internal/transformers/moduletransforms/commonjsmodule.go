@@ -330,7 +330,7 @@ func (tx *CommonJSModuleTransformer) transformCommonJSModule(node *ast.SourceFil
 					)
 				} else {
 					name := nextId.Clone(tx.Factory())
-					tx.EmitContext().SetEmitFlags(name, printer.EFNoSourceMap) // TODO: Strada emits comments here, but shouldn't
+					tx.EmitContext().SetEmitFlags(name, printer.EFNoSourceMap|printer.EFNoComments)
 					left = tx.Factory().NewPropertyAccessExpression(
 						tx.Factory().NewIdentifier("exports"),
 						nil, /*questionDotToken*/
@@ -659,10 +659,12 @@ func (tx *CommonJSModuleTransformer) createExportExpression(name *ast.ModuleExpo
 		} else {
 			// emits:
 			//  exports.<name> = <value>;
+			exportName := name.Clone(tx.Factory())
+			tx.EmitContext().AddEmitFlags(exportName, printer.EFNoComments)
 			left = tx.Factory().NewPropertyAccessExpression(
 				tx.Factory().NewIdentifier("exports"),
 				nil, /*questionDotToken*/
-				name.Clone(tx.Factory()),
+				exportName,
 				ast.NodeFlagsNone,
 			)
 		}
@@ -1916,10 +1918,12 @@ func (tx *CommonJSModuleTransformer) visitExpressionIdentifier(node *ast.Identif
 		!isDeclarationNameOfEnumOrNamespace(tx.EmitContext(), node) {
 		exportContainer := tx.resolver.GetReferencedExportContainer(tx.EmitContext().MostOriginal(node), transformers.IsExportName(tx.EmitContext(), node))
 		if exportContainer != nil && ast.IsSourceFile(exportContainer) {
+			referenceName := node.Clone(tx.Factory())
+			tx.EmitContext().AddEmitFlags(referenceName, printer.EFNoComments)
 			reference := tx.Factory().NewPropertyAccessExpression(
 				tx.Factory().NewIdentifier("exports"),
 				nil, /*questionDotToken*/
-				node.Clone(tx.Factory()),
+				referenceName,
 				ast.NodeFlagsNone,
 			)
 			tx.EmitContext().AssignCommentAndSourceMapRanges(reference, node)
@@ -12,6 +12,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/testutil/emittestutil"
 	"github.com/microsoft/typescript-go/internal/testutil/parsetestutil"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/transformers"
 	"github.com/microsoft/typescript-go/internal/transformers/tstransforms"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
@@ -93,6 +94,10 @@ func (p *fakeProgram) GetProjectReferenceFromOutputDts(path tspath.Path) *tsopti
 	return nil
 }
 
+func (p *fakeProgram) Tracing() *tracing.Tracing {
+	return nil
+}
+
 func (p *fakeProgram) UseCaseSensitiveFileNames() bool {
 	return true
 }
@@ -0,0 +1,678 @@
+package tstransforms
+
+import (
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/binder"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/transformers"
+)
+
+// Transforms legacy decorators (those enabled by `--experimentalDecorators`) into calls to the `__decorate` and `__param`
+// helpers. When `--emitDecoratorMetadata` is set, calls to the `__metadata` helper describing the design-time types of
+// each decorated declaration are added as well:
+//
+//	let C = class C {
+//	    m(x) {}
+//	};
+//	__decorate([
+//	    dec,
+//	    __param(0, inject),
+//	    __metadata("design:type", Function),
+//	    __metadata("design:paramtypes", [Number]),
+//	    __metadata("design:returntype", void 0)
+//	], C.prototype, "m", null);
+//	C = __decorate([
+//	    component
+//	], C);
+type LegacyDecoratorsTransformer struct {
+	transformers.Transformer
+	languageVersion      core.ScriptTarget
+	resolver             binder.ReferenceResolver
+	serializer           *typeSerializer                   // serializes design-time types, or nil if `--emitDecoratorMetadata` is not set
+	classAliasReferences map[*ast.Node]*ast.IdentifierNode // maps references to a decorated class from within its body to the alias for the class
+}
+
+func NewLegacyDecoratorsTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
+	compilerOptions := opt.CompilerOptions
+	emitContext := opt.Context
+	tx := &LegacyDecoratorsTransformer{languageVersion: compilerOptions.GetEmitScriptTarget(), resolver: opt.Resolver}
+	if compilerOptions.EmitDecoratorMetadata.IsTrue() && opt.EmitResolver != nil {
+		tx.serializer = newTypeSerializer(emitContext, opt.EmitResolver, compilerOptions)
+	}
+	return tx.NewTransformer(tx.visit, emitContext)
+}
+
+func (tx *LegacyDecoratorsTransformer) visit(node *ast.Node) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsDecorators == 0 &&
+		(len(tx.classAliasReferences) == 0 || node.SubtreeFacts()&ast.SubtreeContainsIdentifier == 0) {
+		return node
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindDecorator:
+		// decorators are evaluated separately and removed from their declarations
+		return nil
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindConstructor:
+		return tx.visitConstructorDeclaration(node.AsConstructorDeclaration())
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(node.AsMethodDeclaration())
+	case ast.KindGetAccessor:
+		return tx.visitGetAccessorDeclaration(node.AsGetAccessorDeclaration())
+	case ast.KindSetAccessor:
+		return tx.visitSetAccessorDeclaration(node.AsSetAccessorDeclaration())
+	case ast.KindPropertyDeclaration:
+		return tx.visitPropertyDeclaration(node.AsPropertyDeclaration())
+	case ast.KindParameter:
+		return tx.visitParameterDeclaration(node.AsParameterDeclaration())
+	case ast.KindIdentifier:
+		return tx.visitIdentifier(node)
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *LegacyDecoratorsTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited.AsNode(), tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+func (tx *LegacyDecoratorsTransformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	// references to a decorated class from within its body must refer to the undecorated class, as the class
+	// decorators may replace the binding before those references are evaluated
+	if classAlias, ok := tx.classAliasReferences[tx.EmitContext().MostOriginal(node)]; ok {
+		alias := classAlias.Clone(tx.Factory())
+		tx.EmitContext().AssignCommentAndSourceMapRanges(alias, node)
+		return alias
+	}
+	return node
+}
+
+//
+// Classes
+//
+
+func (tx *LegacyDecoratorsTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if !classOrConstructorParameterIsDecorated(node.AsNode()) && !childIsDecorated(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	var statements []*ast.Statement
+	if classOrConstructorParameterIsDecorated(node.AsNode()) {
+		statements = tx.transformClassDeclarationWithClassDecorators(node)
+	} else {
+		statements = tx.transformClassDeclarationWithoutClassDecorators(node)
+	}
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
+func (tx *LegacyDecoratorsTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	// Legacy decorators are not supported on class expressions, so they are only removed.
+	return tx.Factory().UpdateClassExpression(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.Visitor().VisitNodes(node.HeritageClauses),
+		tx.Visitor().VisitNodes(node.Members),
+	)
+}
+
+// Gets the name of a class whose members are decorated, as the decorations must be able to refer to the class.
+func (tx *LegacyDecoratorsTransformer) getClassName(node *ast.ClassDeclaration) *ast.IdentifierNode {
+	if name := node.Name(); name != nil {
+		return name
+	}
+	return tx.Factory().NewGeneratedNameForNode(node.AsNode())
+}
+
+// Transforms a class declaration without class decorators, but with decorated members or parameters:
+//
+//	class C {
+//	    @dec m() {}
+//	}
+//
+// Is transformed into:
+//
+//	class C {
+//	    m() {}
+//	}
+//	__decorate([
+//	    dec
+//	], C.prototype, "m", null);
+func (tx *LegacyDecoratorsTransformer) transformClassDeclarationWithoutClassDecorators(node *ast.ClassDeclaration) []*ast.Statement {
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+	members, decorationStatements := tx.transformDecoratorsOfClassElements(node, tx.Visitor().VisitNodes(node.Members))
+	updated := tx.Factory().UpdateClassDeclaration(node, modifiers, tx.getClassName(node), nil /*typeParameters*/, heritageClauses, members)
+	return append([]*ast.Statement{updated}, decorationStatements...)
+}
+
+// Transforms a class declaration with class decorators or decorated constructor parameters:
+//
+//	@dec
+//	export class C {
+//	    static x = C;
+//	}
+//
+// Is transformed into:
+//
+//	var C_1;
+//	let C = C_1 = class C {
+//	    static x = C_1;
+//	};
+//	C = C_1 = __decorate([
+//	    dec
+//	], C);
+//	export { C };
+func (tx *LegacyDecoratorsTransformer) transformClassDeclarationWithClassDecorators(node *ast.ClassDeclaration) []*ast.Statement {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	classNode := node.AsNode()
+	isExport := ast.HasSyntacticModifier(classNode, ast.ModifierFlagsExport)
+	isDefault := ast.HasSyntacticModifier(classNode, ast.ModifierFlagsDefault)
+	modifiers := transformers.ExtractModifiers(ctx, node.Modifiers(), ^(ast.ModifierFlagsExportDefault | ast.ModifierFlagsDecorator))
+	modifiers = tx.Visitor().VisitModifiers(modifiers)
+	location := moveRangePastModifiers(classNode)
+	classAlias := tx.getClassAliasIfNeeded(node)
+	declarationName := f.GetLocalNameEx(classNode, printer.AssignedNameOptions{AllowSourceMaps: true})
+
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+	members, decorationStatements := tx.transformDecoratorsOfClassElements(node, tx.Visitor().VisitNodes(node.Members))
+
+	// If the class is referenced from a static initializer, the alias must be assigned before the static initializer
+	// is evaluated:
+	//
+	//  let C = class C {
+	//      static { C_1 = this; }
+	//      static x = C_1;
+	//  };
+	assignClassAliasInStaticBlock := tx.languageVersion >= core.ScriptTargetES2022 &&
+		classAlias != nil &&
+		core.Some(members.Nodes, func(member *ast.Node) bool {
+			return ast.IsPropertyDeclaration(member) && ast.HasStaticModifier(member) || ast.IsClassStaticBlockDeclaration(member)
+		})
+	if assignClassAliasInStaticBlock {
+		aliasAssignment := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList([]*ast.Statement{
+			f.NewExpressionStatement(f.NewAssignmentExpression(classAlias.Clone(f), f.NewThisExpression())),
+		}), false /*multiLine*/))
+		newMembers := f.NewNodeList(append([]*ast.Node{aliasAssignment}, members.Nodes...))
+		newMembers.Loc = members.Loc
+		members = newMembers
+	}
+
+	var className *ast.IdentifierNode
+	if name := node.Name(); name != nil && !transformers.IsGeneratedIdentifier(ctx, name) {
+		className = name
+	}
+	classExpression := f.NewClassExpression(modifiers, className, nil /*typeParameters*/, heritageClauses, members)
+	ctx.SetOriginal(classExpression, classNode)
+	classExpression.Loc = location
+
+	//  let C = class C {
+	//  };
+	//  let C = C_1 = class C {
+	//  };
+	initializer := classExpression
+	if classAlias != nil && !assignClassAliasInStaticBlock {
+		initializer = f.NewAssignmentExpression(classAlias.Clone(f), classExpression)
+	}
+	declaration := f.NewVariableDeclaration(declarationName, nil /*exclamationToken*/, nil /*typeNode*/, initializer)
+	ctx.SetOriginal(declaration, classNode)
+	statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsLet, f.NewNodeList([]*ast.Node{declaration})))
+	ctx.SetOriginal(statement, classNode)
+	statement.Loc = location
+	ctx.AssignCommentRange(statement, classNode)
+
+	statements := append([]*ast.Statement{statement}, decorationStatements...)
+	if expression := tx.generateConstructorDecorationExpression(node, classAlias); expression != nil {
+		expressionStatement := f.NewExpressionStatement(expression)
+		ctx.SetOriginal(expressionStatement, classNode)
+		statements = append(statements, expressionStatement)
+	}
+
+	if isExport {
+		var exportStatement *ast.Statement
+		if isDefault {
+			// export default C;
+			exportStatement = f.NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, declarationName.Clone(f))
+		} else {
+			// export { C };
+			exportStatement = f.NewExportDeclaration(
+				nil,   /*modifiers*/
+				false, /*isTypeOnly*/
+				f.NewNamedExports(f.NewNodeList([]*ast.Node{
+					f.NewExportSpecifier(false /*isTypeOnly*/, nil /*propertyName*/, f.GetDeclarationName(classNode)),
+				})),
+				nil, /*moduleSpecifier*/
+				nil, /*attributes*/
+			)
+		}
+		ctx.SetOriginal(exportStatement, classNode)
+		statements = append(statements, exportStatement)
+	}
+	return statements
+}
+
+// Gets a unique alias for a decorated class if the class is referenced from within its own body. Such references must
+// observe the undecorated class, as the class decorators may replace the binding of the class.
+func (tx *LegacyDecoratorsTransformer) getClassAliasIfNeeded(node *ast.ClassDeclaration) *ast.IdentifierNode {
+	original := tx.EmitContext().MostOriginal(node.AsNode())
+	if !ast.HasDecorators(original) || original.Name() == nil || !ast.IsClassDeclaration(original) {
+		return nil
+	}
+
+	var references []*ast.IdentifierNode
+	name := original.Name().Text()
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsIdentifier(node) {
+			if node.Text() == name && node.Parent != nil && transformers.IsIdentifierReference(node, node.Parent) &&
+				tx.resolver.GetReferencedValueDeclaration(node) == original {
+				references = append(references, node)
+			}
+			return false
+		}
+		return node.ForEachChild(visit)
+	}
+	// references from the class decorators are evaluated outside of the class body, and are not aliased
+	for _, member := range original.Members() {
+		visit(member)
+	}
+	if len(references) == 0 {
+		return nil
+	}
+
+	classAlias := tx.Factory().NewUniqueName(name)
+	tx.EmitContext().AddVariableDeclaration(classAlias)
+	if tx.classAliasReferences == nil {
+		tx.classAliasReferences = make(map[*ast.Node]*ast.IdentifierNode)
+	}
+	for _, reference := range references {
+		tx.classAliasReferences[reference] = classAlias
+	}
+	return classAlias
+}
+
+// Adds the decoration statements for the decorated members of a class, or moves them into a trailing static block if
+// a decorator must be evaluated within the class body.
+func (tx *LegacyDecoratorsTransformer) transformDecoratorsOfClassElements(node *ast.ClassDeclaration, members *ast.NodeList) (*ast.NodeList, []*ast.Statement) {
+	f := tx.Factory()
+	var decorationStatements []*ast.Statement
+	decorationStatements = tx.addClassElementDecorationStatements(decorationStatements, node, false /*isStatic*/)
+	decorationStatements = tx.addClassElementDecorationStatements(decorationStatements, node, true /*isStatic*/)
+	if hasClassElementWithDecoratorContainingPrivateIdentifierInExpression(node) {
+		// A decorator such as `@dec(o => #x in o)` can only be evaluated within the class body.
+		staticBlock := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList(decorationStatements), true /*multiLine*/))
+		newMembers := f.NewNodeList(append(slices.Clone(members.Nodes), staticBlock))
+		newMembers.Loc = members.Loc
+		return newMembers, nil
+	}
+	return members, decorationStatements
+}
+
+// Generates the expression used to decorate a class:
+//
+//	C = C_1 = __decorate([dec], C);
+func (tx *LegacyDecoratorsTransformer) generateConstructorDecorationExpression(node *ast.ClassDeclaration, classAlias *ast.IdentifierNode) *ast.Expression {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+	decorators := tx.transformDecorators(node.AsNode())
+	if constructor := ast.FindConstructorDeclaration(node.AsNode()); constructor != nil {
+		decorators = append(decorators, tx.transformDecoratorsOfParameters(constructor)...)
+	}
+	if tx.serializer != nil {
+		decorators = append(decorators, tx.transformTypeMetadata(node.AsNode(), node.AsNode())...)
+	}
+	if len(decorators) == 0 {
+		return nil
+	}
+
+	localName := f.GetDeclarationNameEx(node.AsNode(), printer.NameOptions{AllowSourceMaps: true})
+	decorate := f.NewDecorateHelper(decorators, localName, nil /*memberName*/, nil /*descriptor*/)
+	if classAlias != nil {
+		decorate = f.NewAssignmentExpression(classAlias.Clone(f), decorate)
+	}
+	expression := f.NewAssignmentExpression(localName.Clone(f), decorate)
+	ctx.SetEmitFlags(expression, printer.EFNoComments)
+	ctx.SetSourceMapRange(expression, moveRangePastModifiers(node.AsNode()))
+	return expression
+}
+
+//
+// Class elements
+//
+
+func (tx *LegacyDecoratorsTransformer) addClassElementDecorationStatements(statements []*ast.Statement, node *ast.ClassDeclaration, isStatic bool) []*ast.Statement {
+	for _, member := range node.Members.Nodes {
+		if ast.IsStatic(member) != isStatic || !classElementOrClassElementParameterIsDecorated(member) {
+			continue
+		}
+		if expression := tx.generateClassElementDecorationExpression(node, member); expression != nil {
+			statements = append(statements, tx.Factory().NewExpressionStatement(expression))
+		}
+	}
+	return statements
+}
+
+// Generates an expression used to evaluate the decorators of a class element:
+//
+//	__decorate([dec], C.prototype, "m", null);
+func (tx *LegacyDecoratorsTransformer) generateClassElementDecorationExpression(node *ast.ClassDeclaration, member *ast.ClassElement) *ast.Expression {
+	ctx := tx.EmitContext()
+	f := tx.Factory()
+
+	var decorators []*ast.Expression
+	switch member.Kind {
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		// The decorators of a pair of accessors are applied once, and only those of the first decorated accessor are
+		// used. The parameter decorators are always taken from the set accessor.
+		accessors := getAllAccessorDeclarations(node.AsNode(), member)
+		firstAccessorWithDecorators := accessors.firstAccessor
+		if !ast.HasDecorators(firstAccessorWithDecorators) {
+			firstAccessorWithDecorators = accessors.secondAccessor
+		}
+		if firstAccessorWithDecorators != member {
+			return nil
+		}
+		decorators = tx.transformDecorators(member)
+		if accessors.setAccessor != nil {
+			decorators = append(decorators, tx.transformDecoratorsOfParameters(accessors.setAccessor)...)
+		}
+	case ast.KindMethodDeclaration:
+		decorators = append(tx.transformDecorators(member), tx.transformDecoratorsOfParameters(member)...)
+	case ast.KindPropertyDeclaration:
+		decorators = tx.transformDecorators(member)
+	}
+	if len(decorators) == 0 {
+		return nil
+	}
+	if tx.serializer != nil {
+		decorators = append(decorators, tx.transformTypeMetadata(member, node.AsNode())...)
+	}
+
+	var prefix *ast.Expression
+	if ast.IsStatic(member) {
+		prefix = f.GetDeclarationName(node.AsNode())
+	} else {
+		prefix = f.NewPropertyAccessExpression(f.GetDeclarationName(node.AsNode()), nil /*questionDotToken*/, f.NewIdentifier("prototype"), ast.NodeFlagsNone)
+	}
+	memberName := tx.getExpressionForPropertyName(member)
+
+	// A property is defined on the instance, not the prototype, so no descriptor is read for it.
+	var descriptor *ast.Expression
+	if ast.IsPropertyDeclaration(member) && !ast.HasAccessorModifier(member) {
+		descriptor = f.NewVoidZeroExpression()
+	} else {
+		descriptor = f.NewKeywordExpression(ast.KindNullKeyword)
+	}
+
+	helper := f.NewDecorateHelper(decorators, prefix, memberName, descriptor)
+	ctx.SetEmitFlags(helper, printer.EFNoComments)
+	ctx.SetSourceMapRange(helper, moveRangePastModifiers(member))
+	return helper
+}
+
+// Gets an expression that evaluates to the name of a class element, for use as the `key` argument of `__decorate`.
+func (tx *LegacyDecoratorsTransformer) getExpressionForPropertyName(member *ast.ClassElement) *ast.Expression {
+	f := tx.Factory()
+	name := member.Name()
+	switch {
+	case ast.IsPrivateIdentifier(name):
+		return f.NewStringLiteral("")
+	case ast.IsComputedPropertyName(name):
+		expression := name.Expression()
+		if !transformers.IsSimpleInlineableExpression(ast.SkipPartiallyEmittedExpressions(expression)) {
+			// the name was hoisted to a variable by `visitPropertyNameOfClassElement`
+			return f.NewGeneratedNameForNode(name)
+		}
+		return expression.Clone(f)
+	case ast.IsIdentifier(name):
+		return f.NewStringLiteral(name.Text())
+	default:
+		return name.Clone(f)
+	}
+}
+
+// Visits the name of a class element. The computed name of a decorated element is evaluated more than once, so it is
+// hoisted to a variable when it is not side-effect free:
+//
+//	[_a = expr]() {}
+func (tx *LegacyDecoratorsTransformer) visitPropertyNameOfClassElement(member *ast.ClassElement) *ast.PropertyName {
+	name := member.Name()
+	if ast.IsComputedPropertyName(name) && ast.HasDecorators(member) {
+		expression := tx.Visitor().VisitNode(name.Expression())
+		if !transformers.IsSimpleInlineableExpression(ast.SkipPartiallyEmittedExpressions(expression)) {
+			generatedName := tx.Factory().NewGeneratedNameForNode(name)
+			tx.EmitContext().AddVariableDeclaration(generatedName)
+			return tx.Factory().UpdateComputedPropertyName(name.AsComputedPropertyName(), tx.Factory().NewAssignmentExpression(generatedName, expression))
+		}
+	}
+	return tx.Visitor().VisitNode(name)
+}
+
+// Sets the comment and source map ranges of an updated class element so they exclude its removed decorators.
+func (tx *LegacyDecoratorsTransformer) finishClassElement(updated *ast.Node, original *ast.Node) *ast.Node {
+	if updated != original {
+		tx.EmitContext().AssignCommentRange(updated, original)
+		tx.EmitContext().SetSourceMapRange(updated, moveRangePastModifiers(original))
+	}
+	return updated
+}
+
+func (tx *LegacyDecoratorsTransformer) visitConstructorDeclaration(node *ast.ConstructorDeclaration) *ast.Node {
+	return tx.Factory().UpdateConstructorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		nil, /*typeParameters*/
+		tx.Visitor().VisitNodes(node.Parameters),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Visitor().VisitNode(node.Body),
+	)
+}
+
+func (tx *LegacyDecoratorsTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	return tx.finishClassElement(tx.Factory().UpdateMethodDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.AsteriskToken,
+		tx.visitPropertyNameOfClassElement(node.AsNode()),
+		nil, /*postfixToken*/
+		nil, /*typeParameters*/
+		tx.Visitor().VisitNodes(node.Parameters),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Visitor().VisitNode(node.Body),
+	), node.AsNode())
+}
+
+func (tx *LegacyDecoratorsTransformer) visitGetAccessorDeclaration(node *ast.GetAccessorDeclaration) *ast.Node {
+	return tx.finishClassElement(tx.Factory().UpdateGetAccessorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		tx.visitPropertyNameOfClassElement(node.AsNode()),
+		nil, /*typeParameters*/
+		tx.Visitor().VisitNodes(node.Parameters),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Visitor().VisitNode(node.Body),
+	), node.AsNode())
+}
+
+func (tx *LegacyDecoratorsTransformer) visitSetAccessorDeclaration(node *ast.SetAccessorDeclaration) *ast.Node {
+	return tx.finishClassElement(tx.Factory().UpdateSetAccessorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		tx.visitPropertyNameOfClassElement(node.AsNode()),
+		nil, /*typeParameters*/
+		tx.Visitor().VisitNodes(node.Parameters),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Visitor().VisitNode(node.Body),
+	), node.AsNode())
+}
+
+func (tx *LegacyDecoratorsTransformer) visitPropertyDeclaration(node *ast.PropertyDeclaration) *ast.Node {
+	return tx.finishClassElement(tx.Factory().UpdatePropertyDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		tx.visitPropertyNameOfClassElement(node.AsNode()),
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		tx.Visitor().VisitNode(node.Initializer),
+	), node.AsNode())
+}
+
+func (tx *LegacyDecoratorsTransformer) visitParameterDeclaration(node *ast.ParameterDeclaration) *ast.Node {
+	updated := tx.Factory().UpdateParameterDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.DotDotDotToken,
+		tx.Visitor().VisitNode(node.Name()),
+		nil, /*questionToken*/
+		nil, /*typeNode*/
+		tx.Visitor().VisitNode(node.Initializer),
+	)
+	if updated != node.AsNode() {
+		tx.EmitContext().AssignCommentRange(updated, node.AsNode())
+		updated.Loc = moveRangePastModifiers(node.AsNode())
+		tx.EmitContext().SetSourceMapRange(updated, moveRangePastModifiers(node.AsNode()))
+		tx.EmitContext().AddEmitFlags(updated.Name(), printer.EFNoTrailingSourceMap)
+	}
+	return updated
+}
+
+//
+// Decorators
+//
+
+// Visits the decorators of a declaration, returning the decorator expressions.
+func (tx *LegacyDecoratorsTransformer) transformDecorators(node *ast.Node) []*ast.Expression {
+	var decorators []*ast.Expression
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) {
+				decorators = append(decorators, tx.Visitor().VisitNode(modifier.Expression()))
+			}
+		}
+	}
+	return decorators
+}
+
+// Visits the decorators of the parameters of a function-like declaration, wrapping each in a call to `__param`.
+func (tx *LegacyDecoratorsTransformer) transformDecoratorsOfParameters(node *ast.Node) []*ast.Expression {
+	var decorators []*ast.Expression
+	for i, parameter := range node.Parameters() {
+		if modifiers := parameter.Modifiers(); modifiers != nil {
+			for _, modifier := range modifiers.Nodes {
+				if ast.IsDecorator(modifier) {
+					helper := tx.Factory().NewParamHelper(tx.Visitor().VisitNode(modifier.Expression()), i, modifier.Expression().Loc)
+					tx.EmitContext().SetEmitFlags(helper, printer.EFNoComments)
+					decorators = append(decorators, helper)
+				}
+			}
+		}
+	}
+	return decorators
+}
+
+// Creates the `__metadata` calls describing the design-time types of a decorated declaration. Types are read from the
+// original parse tree, as type annotations have already been erased.
+func (tx *LegacyDecoratorsTransformer) transformTypeMetadata(node *ast.Node, container *ast.Node) []*ast.Expression {
+	f := tx.Factory()
+	node = tx.EmitContext().MostOriginal(node)
+	container = tx.EmitContext().MostOriginal(container)
+	if !ast.IsParseTreeNode(node) || !ast.IsParseTreeNode(container) {
+		return nil
+	}
+
+	var metadata []*ast.Expression
+	switch node.Kind {
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindPropertyDeclaration:
+		metadata = append(metadata, f.NewMetadataHelper("design:type", tx.serializer.serializeTypeOfNode(node, container)))
+	}
+	switch node.Kind {
+	case ast.KindClassDeclaration:
+		if ast.FindConstructorDeclaration(node) != nil {
+			metadata = append(metadata, f.NewMetadataHelper("design:paramtypes", tx.serializer.serializeParameterTypesOfNode(node, container)))
+		}
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		metadata = append(metadata, f.NewMetadataHelper("design:paramtypes", tx.serializer.serializeParameterTypesOfNode(node, container)))
+	}
+	if node.Kind == ast.KindMethodDeclaration {
+		metadata = append(metadata, f.NewMetadataHelper("design:returntype", tx.serializer.serializeReturnTypeOfNode(node, container)))
+	}
+	return metadata
+}
+
+// Gets whether a class has class decorators or constructor parameter decorators.
+func classOrConstructorParameterIsDecorated(node *ast.Node) bool {
+	if ast.HasDecorators(node) {
+		return true
+	}
+	constructor := ast.FindConstructorDeclaration(node)
+	return constructor != nil && core.Some(constructor.Parameters(), ast.HasDecorators)
+}
+
+// Gets whether any element of a class, or any parameter of an element of a class, is decorated.
+func childIsDecorated(node *ast.Node) bool {
+	return core.Some(node.Members(), classElementOrClassElementParameterIsDecorated)
+}
+
+// Gets whether a class element, or any of its parameters, is decorated. Constructors are decorated as part of their class.
+func classElementOrClassElementParameterIsDecorated(member *ast.Node) bool {
+	switch member.Kind {
+	case ast.KindPropertyDeclaration:
+		return ast.HasDecorators(member) && !ast.IsPrivateIdentifier(member.Name())
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		if member.Body() == nil || ast.IsPrivateIdentifier(member.Name()) {
+			return false
+		}
+		return ast.HasDecorators(member) || core.Some(member.Parameters(), ast.HasDecorators)
+	}
+	return false
+}
+
+func hasClassElementWithDecoratorContainingPrivateIdentifierInExpression(node *ast.ClassDeclaration) bool {
+	for _, member := range node.Members.Nodes {
+		if !classElementOrClassElementParameterIsDecorated(member) {
+			continue
+		}
+		if decoratorsContainPrivateIdentifierInExpression(member) {
+			return true
+		}
+		if ast.IsFunctionLike(member) && core.Some(member.Parameters(), decoratorsContainPrivateIdentifierInExpression) {
+			return true
+		}
+	}
+	return false
+}
+
+// Gets whether any decorator of a declaration contains an expression such as `#x in o`.
+func decoratorsContainPrivateIdentifierInExpression(node *ast.Node) bool {
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsBinaryExpression(node) && node.AsBinaryExpression().OperatorToken.Kind == ast.KindInKeyword && ast.IsPrivateIdentifier(node.AsBinaryExpression().Left) {
+			return true
+		}
+		return node.ForEachChild(visit)
+	}
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) && visit(modifier) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -897,7 +897,7 @@ func (tx *RuntimeSyntaxTransformer) visitConstructorBody(body *ast.Block, constr
 
 	var superPath []int
 	if ast.IsClassLike(grandparentOfBody) && ast.GetExtendsHeritageClauseElement(grandparentOfBody) != nil {
-		superPath = findSuperStatementIndexPath(rest, 0)
+		superPath = transformers.FindSuperStatementIndexPath(rest, 0)
 	}
 
 	if len(superPath) > 0 {
@@ -919,33 +919,6 @@ func (tx *RuntimeSyntaxTransformer) visitConstructorBody(body *ast.Block, constr
 	return updated
 }
 
-// finds a path to a statement containing a `super` call, descending through `try` blocks
-func findSuperStatementIndexPath(statements []*ast.Statement, start int) []int {
-	for i := start; i < len(statements); i++ {
-		statement := statements[i]
-		if getSuperCallFromStatement(statement) != nil {
-			indices := make([]int, 1, 2)
-			indices[0] = i
-			return indices
-		} else if ast.IsTryStatement(statement) {
-			return slices.Insert(findSuperStatementIndexPath(statement.AsTryStatement().TryBlock.AsBlock().Statements.Nodes, 0), 0, i)
-		}
-	}
-	return nil
-}
-
-func getSuperCallFromStatement(statement *ast.Statement) *ast.Node {
-	if !ast.IsExpressionStatement(statement) {
-		return nil
-	}
-
-	expression := ast.SkipParentheses(statement.Expression())
-	if ast.IsSuperCall(expression) {
-		return expression
-	}
-	return nil
-}
-
 func (tx *RuntimeSyntaxTransformer) transformConstructorBodyWorker(statementsIn []*ast.Statement, superPath []int, initializerStatements []*ast.Statement) []*ast.Statement {
 	var statementsOut []*ast.Statement
 	superStatementIndex := superPath[0]
@@ -207,6 +207,10 @@ func (tx *TypeEraserTransformer) visit(node *ast.Node) *ast.Node {
 		if ast.IsParameterPropertyDeclaration(node, tx.parentNode) {
 			modifiers = transformers.ExtractModifiers(tx.EmitContext(), n.Modifiers(), ast.ModifierFlagsParameterPropertyModifier)
 		}
+		// preserve parameter decorators to be handled by the legacy decorators transformer
+		if tx.compilerOptions.ExperimentalDecorators.IsTrue() && ast.HasDecorators(node) {
+			modifiers = tx.visitParameterDecorators(n, modifiers)
+		}
 		return tx.Factory().UpdateParameterDeclaration(n, modifiers, n.DotDotDotToken, tx.Visitor().VisitNode(n.Name()), nil, nil, tx.Visitor().VisitNode(n.Initializer))
 
 	case ast.KindCallExpression:
@@ -230,7 +234,8 @@ func (tx *TypeEraserTransformer) visit(node *ast.Node) *ast.Node {
 	case ast.KindParenthesizedExpression:
 		n := node.AsParenthesizedExpression()
 		expression := ast.SkipOuterExpressions(n.Expression, ^(ast.OEKAssertions | ast.OEKExpressionsWithTypeArguments))
-		if ast.IsAssertionExpression(expression) || ast.IsSatisfiesExpression(expression) {
+		// The parentheses of a JSDoc type assertion in a JS file are part of the assertion and are kept.
+		if (ast.IsAssertionExpression(expression) || ast.IsSatisfiesExpression(expression)) && expression.Flags&ast.NodeFlagsReparsed == 0 {
 			partial := tx.Factory().NewPartiallyEmittedExpression(tx.Visitor().VisitNode(n.Expression))
 			tx.EmitContext().SetOriginal(partial, node)
 			partial.Loc = node.Loc
@@ -350,3 +355,19 @@ func (tx *TypeEraserTransformer) visit(node *ast.Node) *ast.Node {
 		return tx.Visitor().VisitEachChild(node)
 	}
 }
+
+// Prepends the visited decorators of a parameter to the provided modifiers.
+func (tx *TypeEraserTransformer) visitParameterDecorators(node *ast.ParameterDeclaration, modifiers *ast.ModifierList) *ast.ModifierList {
+	var nodes []*ast.Node
+	for _, modifier := range node.Modifiers().Nodes {
+		if ast.IsDecorator(modifier) {
+			nodes = append(nodes, tx.Visitor().VisitNode(modifier))
+		}
+	}
+	if modifiers != nil {
+		nodes = append(nodes, modifiers.Nodes...)
+	}
+	result := tx.Factory().NewModifierList(nodes)
+	result.Loc = node.Modifiers().Loc
+	return result
+}
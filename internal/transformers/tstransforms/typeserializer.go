@@ -0,0 +1,420 @@
+package tstransforms
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+)
+
+// Serializes type annotations into runtime expressions for the `design:type`, `design:paramtypes`, and
+// `design:returntype` metadata emitted when `emitDecoratorMetadata` is enabled.
+//
+// All nodes provided to the serializer are expected to be parse tree nodes, as the type annotations they
+// reference have already been erased from the transformed tree.
+type typeSerializer struct {
+	emitContext      *printer.EmitContext
+	resolver         printer.EmitResolver
+	strictNullChecks bool
+	languageVersion  core.ScriptTarget
+}
+
+func newTypeSerializer(emitContext *printer.EmitContext, resolver printer.EmitResolver, compilerOptions *core.CompilerOptions) *typeSerializer {
+	return &typeSerializer{
+		emitContext:      emitContext,
+		resolver:         resolver,
+		strictNullChecks: compilerOptions.GetStrictOptionValue(compilerOptions.StrictNullChecks),
+		languageVersion:  compilerOptions.GetEmitScriptTarget(),
+	}
+}
+
+// Serializes the type of a node for use with decorator type metadata.
+func (s *typeSerializer) serializeTypeOfNode(node *ast.Node, container *ast.ClassLikeDeclaration) *ast.Expression {
+	f := s.emitContext.Factory
+	switch node.Kind {
+	case ast.KindPropertyDeclaration, ast.KindParameter:
+		return s.serializeTypeNode(node.Type(), container)
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		return s.serializeTypeNode(getAccessorTypeNode(node, container), container)
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindMethodDeclaration:
+		return f.NewIdentifier("Function")
+	default:
+		return f.NewVoidZeroExpression()
+	}
+}
+
+// Serializes the types of the parameters of a node for use with decorator type metadata.
+func (s *typeSerializer) serializeParameterTypesOfNode(node *ast.Node, container *ast.ClassLikeDeclaration) *ast.Expression {
+	f := s.emitContext.Factory
+	var valueDeclaration *ast.Node
+	if ast.IsClassLike(node) {
+		valueDeclaration = ast.FindConstructorDeclaration(node)
+	} else if ast.IsFunctionLike(node) && node.Body() != nil {
+		valueDeclaration = node
+	}
+
+	var expressions []*ast.Expression
+	if valueDeclaration != nil {
+		parameters := getParametersOfDecoratedDeclaration(valueDeclaration, container)
+		for i, parameter := range parameters {
+			if i == 0 && ast.IsThisParameter(parameter) {
+				continue
+			}
+			if parameter.AsParameterDeclaration().DotDotDotToken != nil {
+				expressions = append(expressions, s.serializeTypeNode(getRestParameterElementType(parameter.Type()), container))
+			} else {
+				expressions = append(expressions, s.serializeTypeOfNode(parameter, container))
+			}
+		}
+	}
+	return f.NewArrayLiteralExpression(f.NewNodeList(expressions), false /*multiLine*/)
+}
+
+// Serializes the return type of a node for use with decorator type metadata.
+func (s *typeSerializer) serializeReturnTypeOfNode(node *ast.Node, container *ast.ClassLikeDeclaration) *ast.Expression {
+	f := s.emitContext.Factory
+	if ast.IsFunctionLike(node) && node.Type() != nil {
+		return s.serializeTypeNode(node.Type(), container)
+	}
+	if ast.IsFunctionLike(node) && ast.HasSyntacticModifier(node, ast.ModifierFlagsAsync) {
+		return f.NewIdentifier("Promise")
+	}
+	return f.NewVoidZeroExpression()
+}
+
+// Serializes a type node for use with decorator type metadata.
+//
+// Types are serialized in the following fashion:
+//   - Void types point to "undefined" (e.g. "void 0")
+//   - Function and Constructor types point to the global "Function" constructor.
+//   - Interface types with a call or construct signature types point to the global
+//     "Function" constructor.
+//   - Array and Tuple types point to the global "Array" constructor.
+//   - Type predicates and booleans point to the global "Boolean" constructor.
+//   - String literal types and strings point to the global "String" constructor.
+//   - Enum and number types point to the global "Number" constructor.
+//   - Symbol types point to the global "Symbol" constructor.
+//   - Type references to classes (or class-like variables) point to the constructor for the class.
+//   - Anything else points to the global "Object" constructor.
+func (s *typeSerializer) serializeTypeNode(node *ast.TypeNode, container *ast.ClassLikeDeclaration) *ast.Expression {
+	f := s.emitContext.Factory
+	if node == nil {
+		return f.NewIdentifier("Object")
+	}
+
+	node = ast.SkipTypeParentheses(node)
+	switch node.Kind {
+	case ast.KindVoidKeyword, ast.KindUndefinedKeyword, ast.KindNeverKeyword:
+		return f.NewVoidZeroExpression()
+	case ast.KindFunctionType, ast.KindConstructorType:
+		return f.NewIdentifier("Function")
+	case ast.KindArrayType, ast.KindTupleType:
+		return f.NewIdentifier("Array")
+	case ast.KindTypePredicate:
+		if node.AsTypePredicateNode().AssertsModifier != nil {
+			return f.NewVoidZeroExpression()
+		}
+		return f.NewIdentifier("Boolean")
+	case ast.KindBooleanKeyword:
+		return f.NewIdentifier("Boolean")
+	case ast.KindTemplateLiteralType, ast.KindStringKeyword:
+		return f.NewIdentifier("String")
+	case ast.KindObjectKeyword:
+		return f.NewIdentifier("Object")
+	case ast.KindLiteralType:
+		return s.serializeLiteralOfLiteralTypeNode(node.AsLiteralTypeNode().Literal)
+	case ast.KindNumberKeyword:
+		return f.NewIdentifier("Number")
+	case ast.KindBigIntKeyword:
+		return s.getGlobalBigIntNameWithFallback()
+	case ast.KindSymbolKeyword:
+		return f.NewIdentifier("Symbol")
+	case ast.KindTypeReference:
+		return s.serializeTypeReferenceNode(node.AsTypeReferenceNode(), container)
+	case ast.KindIntersectionType:
+		return s.serializeUnionOrIntersectionConstituents(node.AsIntersectionTypeNode().Types.Nodes, true /*isIntersection*/, container)
+	case ast.KindUnionType:
+		return s.serializeUnionOrIntersectionConstituents(node.AsUnionTypeNode().Types.Nodes, false /*isIntersection*/, container)
+	case ast.KindConditionalType:
+		n := node.AsConditionalTypeNode()
+		return s.serializeUnionOrIntersectionConstituents([]*ast.TypeNode{n.TrueType, n.FalseType}, false /*isIntersection*/, container)
+	case ast.KindTypeOperator:
+		if node.AsTypeOperatorNode().Operator == ast.KindReadonlyKeyword {
+			return s.serializeTypeNode(node.AsTypeOperatorNode().Type, container)
+		}
+	}
+	return f.NewIdentifier("Object")
+}
+
+func (s *typeSerializer) serializeLiteralOfLiteralTypeNode(node *ast.Node) *ast.Expression {
+	f := s.emitContext.Factory
+	switch node.Kind {
+	case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral:
+		return f.NewIdentifier("String")
+	case ast.KindPrefixUnaryExpression:
+		return s.serializeLiteralOfLiteralTypeNode(node.AsPrefixUnaryExpression().Operand)
+	case ast.KindNumericLiteral:
+		return f.NewIdentifier("Number")
+	case ast.KindBigIntLiteral:
+		return s.getGlobalBigIntNameWithFallback()
+	case ast.KindTrueKeyword, ast.KindFalseKeyword:
+		return f.NewIdentifier("Boolean")
+	case ast.KindNullKeyword:
+		return f.NewVoidZeroExpression()
+	}
+	return f.NewIdentifier("Object")
+}
+
+func (s *typeSerializer) serializeUnionOrIntersectionConstituents(types []*ast.TypeNode, isIntersection bool, container *ast.ClassLikeDeclaration) *ast.Expression {
+	f := s.emitContext.Factory
+	// Note when updating logic here also update `getEntityNameForDecoratorMetadata` in checker.go so that aliases can be marked as referenced
+	var serializedType *ast.Expression
+	for _, typeNode := range types {
+		typeNode = ast.SkipTypeParentheses(typeNode)
+		switch {
+		case typeNode.Kind == ast.KindNeverKeyword:
+			if isIntersection {
+				return f.NewVoidZeroExpression() // Reduce to `never` in an intersection
+			}
+			continue // Elide `never` in a union
+		case typeNode.Kind == ast.KindUnknownKeyword:
+			if !isIntersection {
+				return f.NewIdentifier("Object") // Reduce to `unknown` in a union
+			}
+			continue // Elide `unknown` in an intersection
+		case typeNode.Kind == ast.KindAnyKeyword:
+			return f.NewIdentifier("Object") // Reduce to `any` in a union or intersection
+		case !s.strictNullChecks && (ast.IsLiteralTypeNode(typeNode) && typeNode.AsLiteralTypeNode().Literal.Kind == ast.KindNullKeyword || typeNode.Kind == ast.KindUndefinedKeyword):
+			continue // Elide null and undefined from unions for metadata, just like what we did prior to the implementation of strict null checks
+		}
+
+		serializedConstituent := s.serializeTypeNode(typeNode, container)
+		if ast.IsIdentifier(serializedConstituent) && serializedConstituent.Text() == "Object" {
+			// One of the individual is global object, return immediately
+			return serializedConstituent
+		}
+
+		// If there exists union that is not `void 0` expression, check if the the common type is identifier.
+		// anything more complex and we will just default to Object
+		if serializedType != nil {
+			// Different types
+			if !s.equateSerializedTypeNodes(serializedType, serializedConstituent) {
+				return f.NewIdentifier("Object")
+			}
+		} else {
+			// Initialize the union type
+			serializedType = serializedConstituent
+		}
+	}
+
+	// If we were able to find common type, use it
+	if serializedType != nil {
+		return serializedType
+	}
+	// Fallback is only hit if all union constituents are null/undefined/never
+	return f.NewVoidZeroExpression()
+}
+
+func (s *typeSerializer) equateSerializedTypeNodes(left *ast.Expression, right *ast.Expression) bool {
+	switch {
+	case s.emitContext.HasAutoGenerateInfo(left):
+		// temp vars used in fallback
+		return s.emitContext.HasAutoGenerateInfo(right)
+	case ast.IsIdentifier(left):
+		// entity names
+		return ast.IsIdentifier(right) && !s.emitContext.HasAutoGenerateInfo(right) && left.Text() == right.Text()
+	case ast.IsPropertyAccessExpression(left):
+		return ast.IsPropertyAccessExpression(right) &&
+			s.equateSerializedTypeNodes(left.Expression(), right.Expression()) &&
+			s.equateSerializedTypeNodes(left.Name(), right.Name())
+	case ast.IsVoidExpression(left):
+		// `void 0`
+		return ast.IsVoidExpression(right) &&
+			ast.IsNumericLiteral(left.Expression()) && left.Expression().Text() == "0" &&
+			ast.IsNumericLiteral(right.Expression()) && right.Expression().Text() == "0"
+	case ast.IsStringLiteral(left):
+		// `"undefined"` or `"function"` in `typeof` checks
+		return ast.IsStringLiteral(right) && left.Text() == right.Text()
+	case ast.IsTypeOfExpression(left), ast.IsParenthesizedExpression(left):
+		// used in `typeof` checks for fallback, or parens in `typeof` checks with temps
+		return right.Kind == left.Kind && s.equateSerializedTypeNodes(left.Expression(), right.Expression())
+	case ast.IsConditionalExpression(left):
+		// conditionals used in fallback
+		l, r := left.AsConditionalExpression(), right.AsConditionalExpression()
+		return ast.IsConditionalExpression(right) &&
+			s.equateSerializedTypeNodes(l.Condition, r.Condition) &&
+			s.equateSerializedTypeNodes(l.WhenTrue, r.WhenTrue) &&
+			s.equateSerializedTypeNodes(l.WhenFalse, r.WhenFalse)
+	case ast.IsBinaryExpression(left):
+		// logical binary and assignments used in fallback
+		if !ast.IsBinaryExpression(right) {
+			return false
+		}
+		l, r := left.AsBinaryExpression(), right.AsBinaryExpression()
+		return l.OperatorToken.Kind == r.OperatorToken.Kind &&
+			s.equateSerializedTypeNodes(l.Left, r.Left) &&
+			s.equateSerializedTypeNodes(l.Right, r.Right)
+	}
+	return false
+}
+
+// Serializes a TypeReferenceNode to an appropriate JS constructor value for use with decorator type metadata.
+func (s *typeSerializer) serializeTypeReferenceNode(node *ast.TypeReferenceNode, container *ast.ClassLikeDeclaration) *ast.Expression {
+	f := s.emitContext.Factory
+	kind := s.resolver.GetTypeReferenceSerializationKind(node.TypeName, container)
+	switch kind {
+	case printer.TypeReferenceSerializationKindUnknown:
+		// From conditional type type reference that cannot be resolved is Similar to any or unknown
+		if ast.FindAncestor(node.AsNode(), func(n *ast.Node) bool {
+			return n.Parent != nil && ast.IsConditionalTypeNode(n.Parent) && (n.Parent.AsConditionalTypeNode().TrueType == n || n.Parent.AsConditionalTypeNode().FalseType == n)
+		}) != nil {
+			return f.NewIdentifier("Object")
+		}
+
+		serialized := s.serializeEntityNameAsExpressionFallback(node.TypeName)
+		temp := f.NewTempVariable()
+		s.emitContext.AddVariableDeclaration(temp)
+		return f.NewConditionalExpression(
+			f.NewTypeCheck(f.NewAssignmentExpression(temp, serialized), "function"),
+			f.NewToken(ast.KindQuestionToken),
+			temp.Clone(f),
+			f.NewToken(ast.KindColonToken),
+			f.NewIdentifier("Object"),
+		)
+	case printer.TypeReferenceSerializationKindTypeWithConstructSignatureAndValue:
+		return s.serializeEntityNameAsExpression(node.TypeName)
+	case printer.TypeReferenceSerializationKindVoidNullableOrNeverType:
+		return f.NewVoidZeroExpression()
+	case printer.TypeReferenceSerializationKindBigIntLikeType:
+		return s.getGlobalBigIntNameWithFallback()
+	case printer.TypeReferenceSerializationKindBooleanType:
+		return f.NewIdentifier("Boolean")
+	case printer.TypeReferenceSerializationKindNumberLikeType:
+		return f.NewIdentifier("Number")
+	case printer.TypeReferenceSerializationKindStringLikeType:
+		return f.NewIdentifier("String")
+	case printer.TypeReferenceSerializationKindArrayLikeType:
+		return f.NewIdentifier("Array")
+	case printer.TypeReferenceSerializationKindESSymbolType:
+		return f.NewIdentifier("Symbol")
+	case printer.TypeReferenceSerializationKindTypeWithCallSignature:
+		return f.NewIdentifier("Function")
+	case printer.TypeReferenceSerializationKindPromise:
+		return f.NewIdentifier("Promise")
+	case printer.TypeReferenceSerializationKindObjectType:
+		return f.NewIdentifier("Object")
+	default:
+		panic("Unhandled TypeReferenceSerializationKind")
+	}
+}
+
+// Produces an expression that results in `right` if `left` is not undefined at runtime:
+//
+//	typeof left !== "undefined" && right
+func (s *typeSerializer) createCheckedValue(left *ast.Expression, right *ast.Expression) *ast.Expression {
+	f := s.emitContext.Factory
+	return f.NewLogicalANDExpression(
+		f.NewStrictInequalityExpression(f.NewTypeOfExpression(left), f.NewStringLiteral("undefined")),
+		right,
+	)
+}
+
+// Serializes an entity name which may not exist at runtime, but whose access shouldn't throw
+func (s *typeSerializer) serializeEntityNameAsExpressionFallback(node *ast.EntityName) *ast.Expression {
+	f := s.emitContext.Factory
+	if node.Kind == ast.KindIdentifier {
+		// A -> typeof A !== "undefined" && A
+		return s.createCheckedValue(s.serializeEntityNameAsExpression(node), s.serializeEntityNameAsExpression(node))
+	}
+
+	qualifiedName := node.AsQualifiedName()
+	if qualifiedName.Left.Kind == ast.KindIdentifier {
+		// A.B -> typeof A !== "undefined" && A.B
+		return s.createCheckedValue(s.serializeEntityNameAsExpression(qualifiedName.Left), s.serializeEntityNameAsExpression(node))
+	}
+
+	// A.B.C -> typeof A !== "undefined" && (_a = A.B) !== void 0 && _a.C
+	left := s.serializeEntityNameAsExpressionFallback(qualifiedName.Left).AsBinaryExpression()
+	temp := f.NewTempVariable()
+	s.emitContext.AddVariableDeclaration(temp)
+	return f.NewLogicalANDExpression(
+		f.NewLogicalANDExpression(
+			left.Left,
+			f.NewStrictInequalityExpression(f.NewAssignmentExpression(temp, left.Right), f.NewVoidZeroExpression()),
+		),
+		f.NewPropertyAccessExpression(temp.Clone(f), nil /*questionDotToken*/, qualifiedName.Right.Clone(f), ast.NodeFlagsNone),
+	)
+}
+
+// Serializes an entity name as an expression for decorator type metadata.
+func (s *typeSerializer) serializeEntityNameAsExpression(node *ast.EntityName) *ast.Expression {
+	f := s.emitContext.Factory
+	switch node.Kind {
+	case ast.KindIdentifier:
+		// Create a clone of the name whose original is the type reference, so that later transforms resolve it
+		// as if it were a reference in an expression position of the source tree.
+		name := f.NewIdentifier(node.Text())
+		name.Loc = node.Loc
+		s.emitContext.SetOriginal(name, node)
+		return name
+	case ast.KindQualifiedName:
+		qualifiedName := node.AsQualifiedName()
+		return f.NewPropertyAccessExpression(s.serializeEntityNameAsExpression(qualifiedName.Left), nil /*questionDotToken*/, qualifiedName.Right.Clone(f), ast.NodeFlagsNone)
+	}
+	panic("Unhandled EntityName kind")
+}
+
+// Gets an expression that points to the global "BigInt" constructor at runtime if it is available.
+func (s *typeSerializer) getGlobalBigIntNameWithFallback() *ast.Expression {
+	f := s.emitContext.Factory
+	if s.languageVersion < core.ScriptTargetES2020 {
+		return f.NewConditionalExpression(
+			f.NewTypeCheck(f.NewIdentifier("BigInt"), "function"),
+			f.NewToken(ast.KindQuestionToken),
+			f.NewIdentifier("BigInt"),
+			f.NewToken(ast.KindColonToken),
+			f.NewIdentifier("Object"),
+		)
+	}
+	return f.NewIdentifier("BigInt")
+}
+
+// Gets the type annotation shared by a pair of accessors, preferring the type of the set accessor's parameter.
+func getAccessorTypeNode(node *ast.Node, container *ast.ClassLikeDeclaration) *ast.TypeNode {
+	accessors := getAllAccessorDeclarations(container, node)
+	if accessors.setAccessor != nil {
+		if parameters := accessors.setAccessor.Parameters(); len(parameters) > 0 {
+			if typeNode := core.LastOrNil(parameters).Type(); typeNode != nil {
+				return typeNode
+			}
+		}
+	}
+	if accessors.getAccessor != nil {
+		return accessors.getAccessor.Type()
+	}
+	return nil
+}
+
+// Gets the parameters of a decorated declaration. The parameters of a get accessor are those of its paired set accessor.
+func getParametersOfDecoratedDeclaration(node *ast.Node, container *ast.ClassLikeDeclaration) []*ast.ParameterDeclarationNode {
+	if container != nil && node.Kind == ast.KindGetAccessor {
+		if setAccessor := getAllAccessorDeclarations(container, node).setAccessor; setAccessor != nil {
+			return setAccessor.Parameters()
+		}
+	}
+	return node.Parameters()
+}
+
+// Gets the element type of the type annotation of a rest parameter, if it can be determined syntactically.
+func getRestParameterElementType(node *ast.TypeNode) *ast.TypeNode {
+	if node != nil {
+		switch {
+		case node.Kind == ast.KindArrayType:
+			return node.AsArrayTypeNode().ElementType
+		case node.Kind == ast.KindTypeReference:
+			if typeArguments := node.AsTypeReferenceNode().TypeArguments; typeArguments != nil && len(typeArguments.Nodes) == 1 {
+				return typeArguments.Nodes[0]
+			}
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package tstransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/jsnum"
 	"github.com/microsoft/typescript-go/internal/printer"
 )
@@ -39,3 +40,57 @@ func isInstantiatedModule(node *ast.ModuleDeclarationNode, preserveConstEnums bo
 	return moduleState == ast.ModuleInstanceStateInstantiated ||
 		(preserveConstEnums && moduleState == ast.ModuleInstanceStateConstEnumOnly)
 }
+
+// Gets the range of a declaration that follows its decorators and modifiers, which are typically removed by the
+// decorator transforms.
+func moveRangePastModifiers(node *ast.Node) core.TextRange {
+	if ast.IsPropertyDeclaration(node) || ast.IsMethodDeclaration(node) {
+		return node.Loc.WithPos(node.Name().Pos())
+	}
+	if modifiers := node.Modifiers(); modifiers != nil && len(modifiers.Nodes) > 0 {
+		if lastModifier := core.LastOrNil(modifiers.Nodes); !ast.PositionIsSynthesized(lastModifier.End()) {
+			return node.Loc.WithPos(lastModifier.End())
+		}
+	}
+	return node.Loc
+}
+
+type allAccessorDeclarations struct {
+	firstAccessor  *ast.AccessorDeclaration
+	secondAccessor *ast.AccessorDeclaration
+	getAccessor    *ast.AccessorDeclaration
+	setAccessor    *ast.AccessorDeclaration
+}
+
+// Gets the accessors of a class that share the name and staticness of the provided accessor. An accessor with a
+// dynamic name is never paired with another accessor.
+func getAllAccessorDeclarations(container *ast.ClassLikeDeclaration, accessor *ast.AccessorDeclaration) allAccessorDeclarations {
+	var result allAccessorDeclarations
+	add := func(member *ast.Node) {
+		if result.firstAccessor == nil {
+			result.firstAccessor = member
+		} else if result.secondAccessor == nil {
+			result.secondAccessor = member
+		}
+		if member.Kind == ast.KindGetAccessor && result.getAccessor == nil {
+			result.getAccessor = member
+		}
+		if member.Kind == ast.KindSetAccessor && result.setAccessor == nil {
+			result.setAccessor = member
+		}
+	}
+
+	if ast.HasDynamicName(accessor) {
+		add(accessor)
+		return result
+	}
+
+	accessorName := ast.GetPropertyNameForPropertyNameNode(accessor.Name())
+	for _, member := range container.Members() {
+		if ast.IsAccessor(member) && ast.IsStatic(member) == ast.IsStatic(accessor) && !ast.HasDynamicName(member) &&
+			ast.GetPropertyNameForPropertyNameNode(member.Name()) == accessorName {
+			add(member)
+		}
+	}
+	return result
+}
@@ -272,3 +272,31 @@ func IsOriginalNodeSingleLine(emitContext *printer.EmitContext, node *ast.Node)
 func IsSimpleInlineableExpression(expression *ast.Expression) bool {
 	return !ast.IsIdentifier(expression) && IsSimpleCopiableExpression(expression)
 }
+
+// Finds a path to a statement containing a `super` call, descending through `try` blocks
+func FindSuperStatementIndexPath(statements []*ast.Statement, start int) []int {
+	for i := start; i < len(statements); i++ {
+		statement := statements[i]
+		if GetSuperCallFromStatement(statement) != nil {
+			indices := make([]int, 1, 2)
+			indices[0] = i
+			return indices
+		} else if ast.IsTryStatement(statement) {
+			return slices.Insert(FindSuperStatementIndexPath(statement.AsTryStatement().TryBlock.AsBlock().Statements.Nodes, 0), 0, i)
+		}
+	}
+	return nil
+}
+
+// Gets the `super(...)` call of an expression statement, if it is one.
+func GetSuperCallFromStatement(statement *ast.Statement) *ast.Node {
+	if !ast.IsExpressionStatement(statement) {
+		return nil
+	}
+
+	expression := ast.SkipParentheses(statement.Expression())
+	if ast.IsSuperCall(expression) {
+		return expression
+	}
+	return nil
+}
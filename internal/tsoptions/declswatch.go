@@ -86,3 +86,9 @@ var OptionsForWatch = []*CommandLineOption{
 		Description:                        diagnostics.Remove_a_list_of_files_from_the_watch_mode_s_processing,
 	},
 }
+
+var watchOptionsDeclaration = &CommandLineOption{
+	Name:           "watchOptions",
+	Kind:           CommandLineOptionTypeObject,
+	ElementOptions: commandLineOptionsToMap(OptionsForWatch),
+}
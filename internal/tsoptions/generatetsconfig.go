@@ -0,0 +1,208 @@
+package tsoptions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/jsonutil"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// defaultInitCompilerOptions are the options that `tsc --init` enables in a new tsconfig.json
+// unless they are overridden on the command line.
+var defaultInitCompilerOptions = &core.CompilerOptions{
+	Module:                           core.ModuleKindCommonJS,
+	Target:                           core.ScriptTargetES2016,
+	Strict:                           core.TSTrue,
+	ESModuleInterop:                  core.TSTrue,
+	ForceConsistentCasingInFileNames: core.TSTrue,
+	SkipLibCheck:                     core.TSTrue,
+}
+
+// Categories that are always written to a generated tsconfig.json, in the order they appear.
+// Options of any other category are appended afterwards in declaration order.
+var generatedTSConfigCategoryOrder = []*diagnostics.Message{
+	diagnostics.Projects,
+	diagnostics.Language_and_Environment,
+	diagnostics.Modules,
+	diagnostics.JavaScript_Support,
+	diagnostics.Emit,
+	diagnostics.Interop_Constraints,
+	diagnostics.Type_Checking,
+	diagnostics.Completeness,
+}
+
+// Categories that are too niche to list in a generated tsconfig.json unless one of their options is set.
+var generatedTSConfigSkippedCategories = []*diagnostics.Message{
+	diagnostics.Command_line_Options,
+	diagnostics.Editor_Support,
+	diagnostics.Compiler_Diagnostics,
+	diagnostics.Backwards_Compatibility,
+	diagnostics.Watch_and_Build_Modes,
+	diagnostics.Output_Formatting,
+}
+
+// GenerateTSConfig returns the contents of the tsconfig.json written by `tsc --init`. Every
+// option that can appear in a config file is listed with its description, grouped by category;
+// options passed on the command line (and the default init options) are active, the rest are
+// commented out.
+func GenerateTSConfig(options *core.CompilerOptions, fileNames []string, comparePathsOptions tspath.ComparePathsOptions, newLine string) string {
+	compilerOptionsMap := getSerializedInitCompilerOptions(options, comparePathsOptions)
+
+	isAllowedOptionForOutput := func(option *CommandLineOption) bool {
+		return !option.IsCommandLineOnly && option.Category != nil &&
+			(!slices.Contains(generatedTSConfigSkippedCategories, option.Category) || compilerOptionsMap.Has(option.Name))
+	}
+
+	// Filter applicable options to place in the file
+	categories := slices.Clone(generatedTSConfigCategoryOrder)
+	categorizedOptions := make(map[*diagnostics.Message][]*CommandLineOption, len(categories))
+	knownKeys := 0
+	for _, option := range OptionsDeclarations {
+		if !isAllowedOptionForOutput(option) {
+			continue
+		}
+		if _, ok := categorizedOptions[option.Category]; !ok && !slices.Contains(categories, option.Category) {
+			categories = append(categories, option.Category)
+		}
+		categorizedOptions[option.Category] = append(categorizedOptions[option.Category], option)
+		if compilerOptionsMap.Has(option.Name) {
+			knownKeys++
+		}
+	}
+
+	type entry struct {
+		value       string
+		description string
+	}
+
+	// Serialize all options and their descriptions
+	marginLength := 0
+	seenKnownKeys := 0
+	var entries []entry
+	for _, category := range categories {
+		if len(entries) != 0 {
+			entries = append(entries, entry{})
+		}
+		entries = append(entries, entry{value: "/* " + category.Format() + " */"})
+		for _, option := range categorizedOptions[category] {
+			var optionName string
+			if value, ok := compilerOptionsMap.Get(option.Name); ok {
+				seenKnownKeys++
+				optionName = fmt.Sprintf("%q: %s%s", option.Name, stringifyOptionValue(value), core.IfElse(seenKnownKeys == knownKeys, "", ","))
+			} else {
+				optionName = fmt.Sprintf("// %q: %s,", option.Name, stringifyOptionValue(getDefaultValueForOption(option)))
+			}
+			description := option.Name
+			if option.Description != nil {
+				description = option.Description.Format()
+			}
+			entries = append(entries, entry{value: optionName, description: "/* " + description + " */"})
+			marginLength = max(len(optionName), marginLength)
+		}
+	}
+
+	// Write the output
+	const tab = "  "
+	result := []string{
+		"{",
+		tab + `"compilerOptions": {`,
+		tab + tab + "/* " + diagnostics.Visit_https_Colon_Slash_Slashaka_ms_Slashtsconfig_to_read_more_about_this_file.Format() + " */",
+		"",
+	}
+	// Print out each row, aligning all the descriptions on the same column.
+	for _, entry := range entries {
+		if entry.value == "" {
+			result = append(result, "")
+			continue
+		}
+		line := tab + tab + entry.value
+		if entry.description != "" {
+			line += strings.Repeat(" ", marginLength-len(entry.value)+2) + entry.description
+		}
+		result = append(result, line)
+	}
+	if len(fileNames) != 0 {
+		result = append(result, tab+"},", tab+`"files": [`)
+		for i, fileName := range fileNames {
+			result = append(result, tab+tab+stringifyOptionValue(fileName)+core.IfElse(i == len(fileNames)-1, "", ","))
+		}
+		result = append(result, tab+"]")
+	} else {
+		result = append(result, tab+"}")
+	}
+	result = append(result, "}")
+
+	return strings.Join(result, newLine) + newLine
+}
+
+// GetCompilerOptionsDiffValue lists, one per line, the options that are active in a generated
+// tsconfig.json, in declaration order.
+func GetCompilerOptionsDiffValue(options *core.CompilerOptions, comparePathsOptions tspath.ComparePathsOptions, newLine string) string {
+	compilerOptionsMap := getSerializedInitCompilerOptions(options, comparePathsOptions)
+	var result []string
+	for _, option := range OptionsDeclarations {
+		if value, ok := compilerOptionsMap.Get(option.Name); ok {
+			result = append(result, "  "+option.Name+": "+formatOptionValue(value))
+		}
+	}
+	return strings.Join(result, newLine) + newLine
+}
+
+func getSerializedInitCompilerOptions(options *core.CompilerOptions, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	toRelativePath := func(fileName string) string {
+		return tspath.ConvertToRelativePath(fileName, comparePathsOptions)
+	}
+	return serializeCompilerOptions(mergeCompilerOptions(defaultInitCompilerOptions.Clone(), options, nil), toRelativePath)
+}
+
+func getDefaultValueForOption(option *CommandLineOption) any {
+	switch option.Kind {
+	case CommandLineOptionTypeNumber:
+		return 1
+	case CommandLineOptionTypeBoolean:
+		return true
+	case CommandLineOptionTypeString:
+		if !option.IsFilePath {
+			return ""
+		}
+		defaultValue, _ := option.DefaultValueDescription.(string)
+		return "./" + defaultValue
+	case CommandLineOptionTypeList:
+		return []string{}
+	case CommandLineOptionTypeListOrElement:
+		return getDefaultValueForOption(option.Elements())
+	case CommandLineOptionTypeObject:
+		return &collections.OrderedMap[string, any]{}
+	case CommandLineOptionTypeEnum:
+		for name := range option.EnumMap().Keys() {
+			return name
+		}
+	}
+	panic("Expected option '" + option.Name + "' to have a default value.")
+}
+
+func stringifyOptionValue(value any) string {
+	text, err := jsonutil.MarshalIndent(value, "", "")
+	if err != nil {
+		panic(err)
+	}
+	return string(text)
+}
+
+// formatOptionValue formats a serialized option value for display in the terminal.
+func formatOptionValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	case bool, int:
+		return fmt.Sprint(v)
+	}
+	return stringifyOptionValue(value)
+}
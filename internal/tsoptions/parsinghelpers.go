@@ -583,6 +583,30 @@ func mergeCompilerOptions(targetOptions, sourceOptions *core.CompilerOptions, ra
 	return targetOptions
 }
 
+// mergeWatchOptions copies the set fields of the source watch options over the target watch options.
+func mergeWatchOptions(targetOptions, sourceOptions *core.WatchOptions) *core.WatchOptions {
+	if sourceOptions == nil {
+		return targetOptions
+	}
+	targetValue := reflect.ValueOf(targetOptions).Elem()
+	sourceValue := reflect.ValueOf(sourceOptions).Elem()
+	for i := range targetValue.NumField() {
+		if sourceField := sourceValue.Field(i); !sourceField.IsZero() {
+			targetValue.Field(i).Set(sourceField)
+		}
+	}
+	return targetOptions
+}
+
+// MergeWatchOptions returns the watch options of a config file overridden by the watch options
+// given on the command line.
+func MergeWatchOptions(configWatchOptions, commandLineWatchOptions *core.WatchOptions) *core.WatchOptions {
+	if configWatchOptions == nil {
+		return commandLineWatchOptions
+	}
+	return mergeWatchOptions(mergeWatchOptions(&core.WatchOptions{}, configWatchOptions), commandLineWatchOptions)
+}
+
 func convertToOptionsWithAbsolutePaths(optionsBase *collections.OrderedMap[string, any], optionMap CommandLineOptionNameMap, cwd string) *collections.OrderedMap[string, any] {
 	// !!! convert to options with absolute paths was previously done with `CompilerOptions` object, but for ease of implementation, we do it pre-conversion.
 	// !!! Revisit this choice if/when refactoring when conversion is done in tsconfig parsing
@@ -0,0 +1,105 @@
+package tsoptions
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// serializeCompilerOptions converts the set compiler options back to the values they would have
+// in a tsconfig.json, keyed by option name in declaration order. Command line only flags are
+// omitted, enum values are mapped back to their names, and file paths are rewritten with
+// toRelativePath.
+func serializeCompilerOptions(options *core.CompilerOptions, toRelativePath func(string) string) *collections.OrderedMap[string, any] {
+	values := make(map[string]any)
+	ForEachCompilerOptionValue(options, func(option *CommandLineOption) bool {
+		return option.Category != diagnostics.Command_line_Options && option.Category != diagnostics.Output_Formatting
+	}, func(option *CommandLineOption, value reflect.Value, i int) bool {
+		if !value.IsZero() {
+			values[option.Name] = serializeOptionValue(option, value.Interface(), toRelativePath)
+		}
+		return false
+	})
+	return orderSerializedOptions(values, OptionsDeclarations)
+}
+
+// SerializeWatchOptions is the watch options counterpart of serializeCompilerOptions.
+func SerializeWatchOptions(options *core.WatchOptions, toRelativePath func(string) string) *collections.OrderedMap[string, any] {
+	values := make(map[string]any)
+	optionsValue := reflect.ValueOf(options).Elem()
+	optionsType := optionsValue.Type()
+	for i := range optionsValue.NumField() {
+		value := optionsValue.Field(i)
+		if value.IsZero() {
+			continue
+		}
+		name, _, _ := strings.Cut(optionsType.Field(i).Tag.Get("json"), ",")
+		if option := WatchNameMap.Get(name); option != nil {
+			values[option.Name] = serializeOptionValue(option, value.Interface(), toRelativePath)
+		}
+	}
+	return orderSerializedOptions(values, OptionsForWatch)
+}
+
+func orderSerializedOptions(values map[string]any, declarations []*CommandLineOption) *collections.OrderedMap[string, any] {
+	result := collections.NewOrderedMapWithSizeHint[string, any](len(values))
+	for _, option := range declarations {
+		if value, ok := values[option.Name]; ok && !result.Has(option.Name) {
+			result.Set(option.Name, value)
+		}
+	}
+	return result
+}
+
+func serializeOptionValue(option *CommandLineOption, value any, toRelativePath func(string) string) any {
+	switch v := value.(type) {
+	case core.Tristate:
+		return v.IsTrue()
+	case *int:
+		return *v
+	case string:
+		if option.Kind == CommandLineOptionTypeEnum {
+			return getNameOfCompilerOptionValue(option, v)
+		}
+		if option.IsFilePath {
+			return toRelativePath(v)
+		}
+		return v
+	case []string:
+		if element := option.Elements(); element != nil {
+			switch {
+			case element.Kind == CommandLineOptionTypeEnum:
+				return core.Map(v, func(item string) string { return getNameOfCompilerOptionValue(element, item) })
+			case element.IsFilePath:
+				return core.Map(v, toRelativePath)
+			}
+		}
+		return v
+	case *collections.OrderedMap[string, []string]:
+		// Substitutions such as ${configDir} leave rooted path mappings behind.
+		paths := collections.NewOrderedMapWithSizeHint[string, []string](v.Size())
+		for key, mappings := range v.Entries() {
+			paths.Set(key, core.Map(mappings, func(mapping string) string {
+				return core.IfElse(tspath.IsRootedDiskPath(mapping), toRelativePath(mapping), mapping)
+			}))
+		}
+		return paths
+	}
+	if option.Kind == CommandLineOptionTypeEnum {
+		return getNameOfCompilerOptionValue(option, value)
+	}
+	return value
+}
+
+func getNameOfCompilerOptionValue[T comparable](option *CommandLineOption, value T) string {
+	for name, enumValue := range option.EnumMap().Entries() {
+		if v, ok := enumValue.(T); ok && v == value {
+			return name
+		}
+	}
+	return ""
+}
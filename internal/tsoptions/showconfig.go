@@ -0,0 +1,63 @@
+package tsoptions
+
+import (
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// ConvertToTSConfig converts a parsed command line back into the tsconfig.json that would
+// produce it, as printed by `tsc --showConfig`. Options inherited through `extends` are
+// flattened into compilerOptions, enum values use their tsconfig names, and every path is
+// relative to configFileName.
+func ConvertToTSConfig(configParseResult *ParsedCommandLine, configFileName string, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	configFilePath := tspath.GetNormalizedAbsolutePath(configFileName, comparePathsOptions.CurrentDirectory)
+	configDirectory := tspath.GetDirectoryPath(configFilePath)
+	toRelativePath := func(fileName string) string {
+		return tspath.GetRelativePathFromFile(configFilePath, tspath.GetNormalizedAbsolutePath(fileName, configDirectory), comparePathsOptions)
+	}
+	toRelativeSpec := func(spec string) string {
+		if !tspath.IsRootedDiskPath(spec) {
+			return spec
+		}
+		return toRelativePath(spec)
+	}
+
+	compilerOptions := serializeCompilerOptions(configParseResult.CompilerOptions(), toRelativePath)
+
+	config := &collections.OrderedMap[string, any]{}
+	config.Set("compilerOptions", compilerOptions)
+	if watchOptions := configParseResult.ParsedConfig.WatchOptions; watchOptions != nil {
+		if serialized := SerializeWatchOptions(watchOptions, toRelativePath); serialized.Size() != 0 {
+			config.Set("watchOptions", serialized)
+		}
+	}
+	if references := configParseResult.ProjectReferences(); len(references) != 0 {
+		config.Set("references", core.Map(references, func(reference *core.ProjectReference) *collections.OrderedMap[string, any] {
+			serialized := &collections.OrderedMap[string, any]{}
+			serialized.Set("path", reference.OriginalPath)
+			if reference.Circular {
+				serialized.Set("circular", true)
+			}
+			return serialized
+		}))
+	}
+	if files := configParseResult.FileNames(); len(files) != 0 {
+		config.Set("files", core.Map(files, toRelativePath))
+	}
+	if configParseResult.ConfigFile != nil && configParseResult.ConfigFile.configFileSpecs != nil {
+		specs := configParseResult.ConfigFile.configFileSpecs
+		if len(specs.validatedIncludeSpecs) != 0 && !specs.isDefaultIncludeSpec && !slices.Equal(specs.validatedIncludeSpecs, []string{defaultIncludeSpec}) {
+			config.Set("include", core.Map(specs.validatedIncludeSpecs, toRelativeSpec))
+		}
+		if len(specs.validatedExcludeSpecs) != 0 {
+			config.Set("exclude", core.Map(specs.validatedExcludeSpecs, toRelativeSpec))
+		}
+	}
+	if configParseResult.CompileOnSave != nil && *configParseResult.CompileOnSave {
+		config.Set("compileOnSave", true)
+	}
+	return config
+}
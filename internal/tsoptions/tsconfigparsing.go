@@ -21,9 +21,8 @@ import (
 )
 
 type extendsResult struct {
-	options *core.CompilerOptions
-	// watchOptions        compiler.WatchOptions
-	watchOptionsCopied  bool
+	options             *core.CompilerOptions
+	watchOptions        *core.WatchOptions
 	include             []any
 	exclude             []any
 	files               []any
@@ -57,7 +56,7 @@ var tsconfigRootOptionsMap = &CommandLineOption{
 	Kind: CommandLineOptionTypeObject,
 	ElementOptions: commandLineOptionsToMap([]*CommandLineOption{
 		compilerOptionsDeclaration,
-		// watchOptionsDeclaration,
+		watchOptionsDeclaration,
 		typeAcquisitionDeclaration,
 		extendsOptionDeclaration,
 		{
@@ -163,9 +162,9 @@ type ExtendedConfigCacheEntry struct {
 }
 
 type parsedTsconfig struct {
-	raw     any
-	options *core.CompilerOptions
-	// watchOptions *core.WatchOptions
+	raw             any
+	options         *core.CompilerOptions
+	watchOptions    *core.WatchOptions
 	typeAcquisition *core.TypeAcquisition
 	// Note that the case of the config path has not yet been normalized, as no files have been imported into the project yet
 	extendedConfigPath any
@@ -179,7 +178,7 @@ func parseOwnConfigOfJsonSourceFile(
 ) (*parsedTsconfig, []*ast.Diagnostic) {
 	compilerOptions := getDefaultCompilerOptions(configFileName)
 	typeAcquisition := getDefaultTypeAcquisition(configFileName)
-	// var watchOptions *compiler.WatchOptions
+	var watchOptions *core.WatchOptions
 	var extendedConfigPath any
 	var rootCompilerOptions []*ast.PropertyName
 	var errors []*ast.Diagnostic
@@ -201,6 +200,11 @@ func parseOwnConfigOfJsonSourceFile(
 				switch parentOption.Name {
 				case "compilerOptions":
 					parseDiagnostics = ParseCompilerOptions(option.Name, value, compilerOptions)
+				case "watchOptions":
+					if watchOptions == nil {
+						watchOptions = &core.WatchOptions{}
+					}
+					parseDiagnostics = ParseWatchOptions(option.Name, value, watchOptions)
 				case "typeAcquisition":
 					parseDiagnostics = ParseTypeAcquisition(option.Name, value, typeAcquisition)
 				}
@@ -250,9 +254,9 @@ func parseOwnConfigOfJsonSourceFile(
 	//    errors = append(errors, ast.NewDiagnostic(sourceFile, rootCompilerOptions[0], diagnostics.X_0_should_be_set_inside_the_compilerOptions_object_of_the_config_json_file))
 	// }
 	return &parsedTsconfig{
-		raw:     json,
-		options: compilerOptions,
-		// watchOptions:    watchOptions,
+		raw:                json,
+		options:            compilerOptions,
+		watchOptions:       watchOptions,
 		typeAcquisition:    typeAcquisition,
 		extendedConfigPath: extendedConfigPath,
 	}, errors
@@ -880,6 +884,15 @@ func convertTypeAcquisitionFromJsonWorker(jsonOptions any, basePath string, conf
 	return options, errors
 }
 
+func convertWatchOptionsFromJsonWorker(jsonOptions any, basePath string) (*core.WatchOptions, []*ast.Diagnostic) {
+	if jsonOptions == nil {
+		return nil, nil
+	}
+	options := &core.WatchOptions{}
+	_, errors := convertOptionsFromJson(watchOptionsDeclaration.ElementOptions, jsonOptions, basePath, &watchOptionsParser{options})
+	return options, errors
+}
+
 func parseOwnConfigOfJson(
 	json *collections.OrderedMap[string, any],
 	host ParseConfigHost,
@@ -892,9 +905,13 @@ func parseOwnConfigOfJson(
 	}
 	options, err := convertCompilerOptionsFromJsonWorker(json.GetOrZero("compilerOptions"), basePath, configFileName)
 	typeAcquisition, err2 := convertTypeAcquisitionFromJsonWorker(json.GetOrZero("typeAcquisition"), basePath, configFileName)
-	errors = append(append(errors, err...), err2...)
-	// watchOptions := convertWatchOptionsFromJsonWorker(json.watchOptions, basePath, errors)
-	// json.compileOnSave = convertCompileOnSaveOptionFromJson(json, basePath, errors)
+	watchOptions, err3 := convertWatchOptionsFromJsonWorker(json.GetOrZero("watchOptions"), basePath)
+	errors = append(append(append(errors, err...), err2...), err3...)
+	if json.Has(compileOnSaveCommandLineOption.Name) {
+		compileOnSave, err := convertJsonOption(compileOnSaveCommandLineOption, json.GetOrZero(compileOnSaveCommandLineOption.Name), basePath, nil, nil, nil)
+		errors = append(errors, err...)
+		json.Set(compileOnSaveCommandLineOption.Name, compileOnSave == true)
+	}
 	var extendedConfigPath []string
 	if extends := json.GetOrZero("extends"); extends != nil && extends != "" {
 		extendedConfigPath, err = getExtendsConfigPathOrArray(extends, host, basePath, configFileName, nil, nil, nil)
@@ -903,6 +920,7 @@ func parseOwnConfigOfJson(
 	parsedConfig := &parsedTsconfig{
 		raw:                json,
 		options:            options,
+		watchOptions:       watchOptions,
 		typeAcquisition:    typeAcquisition,
 		extendedConfigPath: extendedConfigPath,
 	}
@@ -1067,6 +1085,12 @@ func parseConfig(
 				}
 			}
 			mergeCompilerOptions(result.options, extendedConfig.options, extendsRaw)
+			if extendedConfig.watchOptions != nil {
+				if result.watchOptions == nil {
+					result.watchOptions = &core.WatchOptions{}
+				}
+				mergeWatchOptions(result.watchOptions, extendedConfig.watchOptions)
+			}
 		}
 	}
 
@@ -1101,9 +1125,11 @@ func parseConfig(
 			}
 		}
 		ownConfig.options = mergeCompilerOptions(result.options, ownConfig.options, ownConfig.raw)
-		// ownConfig.watchOptions = ownConfig.watchOptions && result.watchOptions ?
-		//     assignWatchOptions(result, ownConfig.watchOptions) :
-		//     ownConfig.watchOptions || result.watchOptions;
+		if ownConfig.watchOptions != nil && result.watchOptions != nil {
+			ownConfig.watchOptions = mergeWatchOptions(result.watchOptions, ownConfig.watchOptions)
+		} else if ownConfig.watchOptions == nil {
+			ownConfig.watchOptions = result.watchOptions
+		}
 	}
 	return ownConfig, errors
 }
@@ -1146,6 +1172,7 @@ func parseJsonConfigFileContentWorker(
 	parsedConfig, errors := parseConfig(json, sourceFile, host, basePath, configFileName, resolutionStackString, extendedConfigCache)
 	mergeCompilerOptions(parsedConfig.options, existingOptions, nil)
 	handleOptionConfigDirTemplateSubstitution(parsedConfig.options, basePathForFileNames)
+	handleWatchOptionsConfigDirTemplateSubstitution(parsedConfig.watchOptions, basePathForFileNames)
 	rawConfig := parseJsonToStringKey(parsedConfig.raw)
 	if configFileName != "" && parsedConfig.options != nil {
 		parsedConfig.options.ConfigFilePath = tspath.NormalizeSlashes(configFileName)
@@ -1305,18 +1332,26 @@ func parseJsonConfigFileContentWorker(
 		return projectReferences
 	}
 
+	var compileOnSave *bool
+	if rawMap, ok := parsedConfig.raw.(*collections.OrderedMap[string, any]); ok {
+		if value, ok := rawMap.GetOrZero(compileOnSaveCommandLineOption.Name).(bool); ok {
+			compileOnSave = &value
+		}
+	}
+
 	fileNames, literalFileNamesLen := getFileNames(basePathForFileNames)
 	return &ParsedCommandLine{
 		ParsedConfig: &core.ParsedOptions{
-			CompilerOptions: parsedConfig.options,
-			TypeAcquisition: parsedConfig.typeAcquisition,
-			// WatchOptions:      nil,
+			CompilerOptions:   parsedConfig.options,
+			TypeAcquisition:   parsedConfig.typeAcquisition,
+			WatchOptions:      parsedConfig.watchOptions,
 			FileNames:         fileNames,
 			ProjectReferences: getProjectReferences(basePathForFileNames),
 		},
-		ConfigFile: sourceFile,
-		Raw:        parsedConfig.raw,
-		Errors:     errors,
+		ConfigFile:    sourceFile,
+		Raw:           parsedConfig.raw,
+		Errors:        errors,
+		CompileOnSave: compileOnSave,
 
 		extraFileExtensions: extraFileExtensions,
 		comparePathsOptions: tspath.ComparePathsOptions{
@@ -1538,6 +1573,18 @@ func handleOptionConfigDirTemplateSubstitution(compilerOptions *core.CompilerOpt
 	}
 }
 
+func handleWatchOptionsConfigDirTemplateSubstitution(watchOptions *core.WatchOptions, basePath string) {
+	if watchOptions == nil {
+		return
+	}
+	if excludeDirectories := getSubstitutedStringArrayWithConfigDirTemplate(watchOptions.ExcludeDir, basePath); excludeDirectories != nil {
+		watchOptions.ExcludeDir = excludeDirectories
+	}
+	if excludeFiles := getSubstitutedStringArrayWithConfigDirTemplate(watchOptions.ExcludeFiles, basePath); excludeFiles != nil {
+		watchOptions.ExcludeFiles = excludeFiles
+	}
+}
+
 // hasFileWithHigherPriorityExtension determines whether a literal or wildcard file has already been included that has a higher extension priority.
 // file is the path to the file.
 func hasFileWithHigherPriorityExtension(file string, extensions [][]string, hasFile func(fileName string) bool) bool {
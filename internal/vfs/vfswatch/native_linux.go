@@ -0,0 +1,145 @@
+package vfswatch
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// Events that change a watched file.
+	fileEvents = unix.IN_MODIFY | unix.IN_ATTRIB | unix.IN_DELETE_SELF | unix.IN_MOVE_SELF
+	// Events that add or remove an entry of a watched directory.
+	directoryEvents = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO
+)
+
+// nativeWatcher watches paths with inotify. Each path has its own watch, since inotify does not
+// watch directories recursively.
+type nativeWatcher struct {
+	fd      int
+	file    *os.File // Wraps fd for reading; its Fd method must not be used, since it makes reads block.
+	changes *changes
+
+	mu            sync.Mutex
+	watches       map[string]*nativeWatch
+	watchesByDesc map[int32]*nativeWatch
+	descs         map[string]int32
+}
+
+func newNativeWatcher(changes *changes) (*nativeWatcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	n := &nativeWatcher{
+		fd: fd,
+		// A non-blocking file is read through the runtime poller, so close interrupts a pending read.
+		file:          os.NewFile(uintptr(fd), "inotify"),
+		changes:       changes,
+		watches:       make(map[string]*nativeWatch),
+		watchesByDesc: make(map[int32]*nativeWatch),
+		descs:         make(map[string]int32),
+	}
+	go n.run()
+	return n, nil
+}
+
+// update replaces the watched paths, and returns the watches that could not be added, such as
+// when the limit on the number of watches has been reached.
+func (n *nativeWatcher) update(watches map[string]*nativeWatch) []*nativeWatch {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for path, desc := range n.descs {
+		if _, ok := watches[path]; !ok {
+			_, _ = unix.InotifyRmWatch(n.fd, uint32(desc))
+			n.remove(path)
+		}
+	}
+
+	var failed []*nativeWatch
+	for path, watch := range watches {
+		if _, ok := n.descs[path]; !ok {
+			desc, err := unix.InotifyAddWatch(n.fd, path, fileEvents|directoryEvents)
+			if err != nil {
+				failed = append(failed, watch)
+				continue
+			}
+			n.descs[path] = int32(desc)
+		}
+		n.watches[path] = watch
+		n.watchesByDesc[n.descs[path]] = watch
+	}
+	return failed
+}
+
+func (n *nativeWatcher) remove(path string) {
+	if n.watchesByDesc[n.descs[path]] == n.watches[path] {
+		delete(n.watchesByDesc, n.descs[path])
+	}
+	delete(n.descs, path)
+	delete(n.watches, path)
+}
+
+func (n *nativeWatcher) run() {
+	var buf [4096 * (unix.SizeofInotifyEvent + unix.NAME_MAX + 1)]byte
+	for {
+		count, err := n.file.Read(buf[:])
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				// Reading failed; report that anything may have changed so that the caller checks.
+				n.changes.addAll()
+			}
+			return
+		}
+		n.mu.Lock()
+		for offset := 0; offset+unix.SizeofInotifyEvent <= count; {
+			event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + unix.SizeofInotifyEvent
+			name := strings.TrimRight(string(buf[nameStart:nameStart+int(event.Len)]), "\x00")
+			n.handleEvent(event.Wd, event.Mask, name)
+			offset = nameStart + int(event.Len)
+		}
+		n.mu.Unlock()
+	}
+}
+
+// handleEvent records the path that an event changed, if it is watched.
+func (n *nativeWatcher) handleEvent(desc int32, mask uint32, name string) {
+	if mask&unix.IN_Q_OVERFLOW != 0 {
+		// Events were lost.
+		n.changes.addAll()
+		return
+	}
+	watch := n.watchesByDesc[desc]
+	if watch == nil {
+		return
+	}
+	if mask&unix.IN_IGNORED != 0 {
+		// The watched path was removed; it is watched again by the next update if it returns.
+		n.remove(watch.path)
+		n.changes.add(watch.path)
+		return
+	}
+	if watch.file || name == "" {
+		if watch.file || mask&(unix.IN_DELETE_SELF|unix.IN_MOVE_SELF) != 0 {
+			n.changes.add(watch.path)
+		}
+		return
+	}
+	if _, ok := watch.children[name]; ok {
+		n.changes.add(tspath.CombinePaths(watch.path, name))
+	}
+	if watch.directory && mask&directoryEvents != 0 {
+		n.changes.add(watch.path)
+	}
+}
+
+func (n *nativeWatcher) close() error {
+	return n.file.Close()
+}
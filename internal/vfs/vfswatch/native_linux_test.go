@@ -0,0 +1,38 @@
+package vfswatch_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
+	"gotest.tools/v3/assert"
+)
+
+func TestNative(t *testing.T) {
+	t.Parallel()
+	dir := tspath.NormalizePath(t.TempDir())
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "src", "nested"), 0o777))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "src", "a.ts"), []byte("export const a = 1;"), 0o666))
+
+	for _, fileKind := range []core.WatchFileKind{core.WatchFileKindUseFsEvents, core.WatchFileKindUseFsEventsOnParentDirectory} {
+		// Polling would take longer than the test timeout, so changes must be reported natively.
+		interval := 1000 * 60 * 60
+		watcher := vfswatch.New(osvfs.FS(), &core.WatchOptions{Interval: &interval, FileKind: fileKind}, dir)
+		watcher.Update([]string{dir + "/src/a.ts"}, map[string]bool{dir + "/src": true})
+
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, "src", "a.ts"), []byte("export const a = 2;"), 0o666))
+		assert.DeepEqual(t, waitForChange(t, watcher), []string{dir + "/src/a.ts"})
+
+		// Files added to a subdirectory of a recursively watched directory are reported.
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, "src", "nested", "b.ts"), nil, 0o666))
+		assert.DeepEqual(t, waitForChange(t, watcher), []string{dir + "/src/nested"})
+		assert.NilError(t, os.Remove(filepath.Join(dir, "src", "nested", "b.ts")))
+		assert.DeepEqual(t, waitForChange(t, watcher), []string{dir + "/src/nested"})
+
+		assert.NilError(t, watcher.Close())
+	}
+}
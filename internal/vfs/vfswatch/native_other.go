@@ -0,0 +1,20 @@
+//go:build !linux
+
+package vfswatch
+
+import "errors"
+
+// nativeWatcher is not implemented on this platform; everything is polled.
+type nativeWatcher struct{}
+
+func newNativeWatcher(changes *changes) (*nativeWatcher, error) {
+	return nil, errors.New("native file watching is not supported on this platform")
+}
+
+func (n *nativeWatcher) update(watches map[string]*nativeWatch) []*nativeWatch {
+	panic("unreachable")
+}
+
+func (n *nativeWatcher) close() error {
+	return nil
+}
@@ -0,0 +1,210 @@
+package vfswatch
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/vfs"
+)
+
+// Polling priorities, as the number of intervals between checks of a path. They follow the
+// ratios of TypeScript's low (250ms), medium (500ms) and high (2000ms) polling intervals.
+const (
+	priorityHigh   = 1
+	priorityMedium = 2
+	priorityLow    = 8
+)
+
+// unchangedPollThreshold is the number of checks in a row that must find a path unchanged before
+// dynamic priority polling lowers its priority.
+const unchangedPollThreshold = 32
+
+// poller watches files and directories by checking them periodically: files for a new modified
+// time, and directories for a new set of entries.
+type poller struct {
+	fs       vfs.FS
+	kind     core.PollingKind
+	interval time.Duration
+	changes  *changes
+
+	mu          sync.Mutex
+	files       map[string]*polledPath
+	directories map[string]*polledPath
+	paths       []*polledPath
+	next        int
+	stop        chan struct{}
+}
+
+type polledPath struct {
+	path      string
+	directory bool
+	modTime   time.Time // The last seen modified time of a file.
+	entries   string    // The last seen entries of a directory.
+	priority  int       // The number of intervals between checks.
+	wait      int       // The number of intervals until the next check.
+	unchanged int       // The number of checks in a row that found the path unchanged.
+}
+
+func newPoller(fs vfs.FS, kind core.PollingKind, interval time.Duration, changes *changes) *poller {
+	return &poller{
+		fs:       fs,
+		kind:     kind,
+		interval: interval,
+		changes:  changes,
+	}
+}
+
+// update replaces the polled paths. The last seen state of paths that were already polled is
+// kept, so that changes made since they were last checked are still reported.
+func (p *poller) update(files []string, directories []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.files = p.updatePaths(p.files, files, false)
+	p.directories = p.updatePaths(p.directories, directories, true)
+	p.paths = slices.Concat(
+		slices.SortedFunc(maps.Values(p.files), comparePolledPaths),
+		slices.SortedFunc(maps.Values(p.directories), comparePolledPaths),
+	)
+	p.next = 0
+
+	if len(p.paths) != 0 && p.stop == nil {
+		p.stop = make(chan struct{})
+		go p.run(p.stop)
+	}
+}
+
+func (p *poller) updatePaths(old map[string]*polledPath, paths []string, directory bool) map[string]*polledPath {
+	result := make(map[string]*polledPath, len(paths))
+	for _, path := range paths {
+		if polled, ok := old[path]; ok {
+			result[path] = polled
+			continue
+		}
+		polled := &polledPath{path: path, directory: directory, priority: p.getInitialPriority(path)}
+		polled.wait = polled.priority
+		if directory {
+			polled.entries = p.getEntries(path)
+		} else {
+			polled.modTime = p.getModTime(path)
+		}
+		result[path] = polled
+	}
+	return result
+}
+
+func comparePolledPaths(a, b *polledPath) int {
+	return strings.Compare(a.path, b.path)
+}
+
+func (p *poller) getInitialPriority(path string) int {
+	// Packages rarely change, so as in TypeScript they are polled less often than the project.
+	if p.kind == core.PollingKindPriorityInterval && strings.Contains(path, "/node_modules/") {
+		return priorityLow
+	}
+	return priorityHigh
+}
+
+func (p *poller) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+// poll checks the paths that are due and records those that changed. Fixed chunk size polling
+// checks the next chunk of paths in turn; the other kinds check each path as often as its
+// priority says.
+func (p *poller) poll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.kind == core.PollingKindFixedChunkSize {
+		for range min(len(p.paths), fixedChunkSize) {
+			polled := p.paths[p.next]
+			p.next = (p.next + 1) % len(p.paths)
+			if p.check(polled) {
+				p.changes.add(polled.path)
+			}
+		}
+		return
+	}
+
+	for _, polled := range p.paths {
+		if polled.wait--; polled.wait > 0 {
+			continue
+		}
+		if p.check(polled) {
+			p.changes.add(polled.path)
+			polled.unchanged = 0
+			if p.kind == core.PollingKindDynamicPriority {
+				// A path that changed is likely to change again soon.
+				polled.priority = priorityHigh
+			}
+		} else if p.kind == core.PollingKindDynamicPriority {
+			if polled.unchanged++; polled.unchanged >= unchangedPollThreshold {
+				polled.priority = lowerPriority(polled.priority)
+				polled.unchanged = 0
+			}
+		}
+		polled.wait = polled.priority
+	}
+}
+
+func lowerPriority(priority int) int {
+	if priority == priorityHigh {
+		return priorityMedium
+	}
+	return priorityLow
+}
+
+// check reports whether a path changed since it was last checked.
+func (p *poller) check(polled *polledPath) bool {
+	if polled.directory {
+		entries := p.getEntries(polled.path)
+		if entries == polled.entries {
+			return false
+		}
+		polled.entries = entries
+		return true
+	}
+	modTime := p.getModTime(polled.path)
+	if modTime.Equal(polled.modTime) {
+		return false
+	}
+	polled.modTime = modTime
+	return true
+}
+
+// getModTime returns the modified time of a file, or the zero time if it does not exist.
+func (p *poller) getModTime(file string) time.Time {
+	if info := p.fs.Stat(file); info != nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+// getEntries returns a string that identifies the entries of a directory.
+func (p *poller) getEntries(directory string) string {
+	entries := p.fs.GetAccessibleEntries(directory)
+	return strings.Join(entries.Files, "\x00") + "\x01" + strings.Join(entries.Directories, "\x00")
+}
+
+func (p *poller) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop != nil {
+		close(p.stop)
+		p.stop = nil
+	}
+}
@@ -0,0 +1,64 @@
+package vfswatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/vfs"
+	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+	"gotest.tools/v3/assert"
+)
+
+// newTestPoller returns a poller of the given files that only checks them when poll is called.
+func newTestPoller(t *testing.T, kind core.PollingKind, files ...string) (*poller, *changes, vfs.FS) {
+	t.Helper()
+	contents := make(map[string]string, len(files))
+	for _, file := range files {
+		contents[file] = ""
+	}
+	fs := vfstest.FromMap(contents, true)
+	changes := newChanges()
+	p := newPoller(fs, kind, time.Hour, changes)
+	t.Cleanup(p.close)
+	p.update(files, nil)
+	return p, changes, fs
+}
+
+func TestPriorityPolling(t *testing.T) {
+	t.Parallel()
+	p, changes, fs := newTestPoller(t, core.PollingKindPriorityInterval, "/project/a.ts", "/project/node_modules/b/index.d.ts")
+
+	assert.NilError(t, fs.Chtimes("/project/a.ts", time.Time{}, time.Unix(1, 0)))
+	assert.NilError(t, fs.Chtimes("/project/node_modules/b/index.d.ts", time.Time{}, time.Unix(1, 0)))
+	p.poll()
+	assert.DeepEqual(t, changes.take(), []string{"/project/a.ts"})
+
+	// Packages are checked less often.
+	for range priorityLow - 2 {
+		p.poll()
+	}
+	assert.DeepEqual(t, changes.take(), []string{})
+	p.poll()
+	assert.DeepEqual(t, changes.take(), []string{"/project/node_modules/b/index.d.ts"})
+}
+
+func TestDynamicPriorityPolling(t *testing.T) {
+	t.Parallel()
+	p, changes, fs := newTestPoller(t, core.PollingKindDynamicPriority, "/project/a.ts")
+
+	// A file that does not change is checked less often...
+	for range unchangedPollThreshold {
+		p.poll()
+	}
+	assert.NilError(t, fs.Chtimes("/project/a.ts", time.Time{}, time.Unix(1, 0)))
+	p.poll()
+	assert.DeepEqual(t, changes.take(), []string{})
+	p.poll()
+	assert.DeepEqual(t, changes.take(), []string{"/project/a.ts"})
+
+	// ...until it changes.
+	assert.NilError(t, fs.Chtimes("/project/a.ts", time.Time{}, time.Unix(2, 0)))
+	p.poll()
+	assert.DeepEqual(t, changes.take(), []string{"/project/a.ts"})
+}
@@ -0,0 +1,335 @@
+// Package vfswatch watches files and directories for changes. It uses native file system
+// events where they are available, and falls back to polling where they are not (or where the
+// watch options ask for polling).
+package vfswatch
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
+)
+
+// Watcher reports changes to a set of files and directories.
+type Watcher interface {
+	// Update replaces the files and directories that are watched. Directories map to whether
+	// they are watched recursively. A watched directory reports entries being added or removed,
+	// but not changes to the files it contains.
+	Update(files []string, directories map[string]bool)
+	// Wait blocks until something that is watched changes, and then until changes settle. It
+	// returns the paths that changed: files, and directories whose entries changed. It returns
+	// nil if changes may have been missed, in which case every watched path should be checked.
+	Wait() []string
+	// Close stops watching.
+	Close() error
+}
+
+// debounce is how long Wait waits for changes to settle, so that a burst of changes (such as
+// switching branches) is reported once.
+const debounce = 250 * time.Millisecond
+
+// fixedChunkSize is the number of files and directories polled per interval by fixed chunk
+// size polling.
+const fixedChunkSize = 32
+
+// Paths that are never watched, as in TypeScript: hidden directories in node_modules, git
+// metadata, and editor lock files.
+var ignoredPathFragments = []string{"/node_modules/.", "/.git", "/.#"}
+
+type watcher struct {
+	fs                 vfs.FS
+	options            *core.WatchOptions
+	excludeDirectories *regexp.Regexp
+	excludeFiles       *regexp.Regexp
+	changes            *changes
+
+	native    *nativeWatcher
+	nativeErr error
+	pollers   map[core.PollingKind]*poller
+}
+
+var _ Watcher = (*watcher)(nil)
+
+// New returns a watcher for the given watch options. Relative paths in excludeDirectories and
+// excludeFiles are resolved against basePath, usually the directory of the config file.
+func New(fs vfs.FS, options *core.WatchOptions, basePath string) Watcher {
+	w := &watcher{
+		fs:      fs,
+		options: options,
+		changes: newChanges(),
+		pollers: make(map[core.PollingKind]*poller),
+	}
+	if options != nil {
+		w.excludeDirectories = getExcludeRegex(options.ExcludeDir, basePath, fs.UseCaseSensitiveFileNames())
+		w.excludeFiles = getExcludeRegex(options.ExcludeFiles, basePath, fs.UseCaseSensitiveFileNames())
+	}
+	return w
+}
+
+func getExcludeRegex(specs []string, basePath string, useCaseSensitiveFileNames bool) *regexp.Regexp {
+	pattern := vfs.GetRegularExpressionForWildcard(specs, basePath, "exclude")
+	if pattern == "" {
+		return nil
+	}
+	if !useCaseSensitiveFileNames {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.MustCompile(pattern)
+}
+
+func (w *watcher) Update(files []string, directories map[string]bool) {
+	native := make(map[string]*nativeWatch)
+	getNativeWatch := func(path string) *nativeWatch {
+		watch := native[path]
+		if watch == nil {
+			watch = &nativeWatch{path: path}
+			native[path] = watch
+		}
+		return watch
+	}
+	polledFiles := make(map[core.PollingKind][]string)
+	polledDirectories := make(map[core.PollingKind][]string)
+
+	fileKind := core.WatchFileKindNone
+	directoryKind := core.WatchDirectoryKindNone
+	if w.options != nil {
+		fileKind = w.options.FileKind
+		directoryKind = w.options.DirectoryKind
+	}
+
+	for _, file := range files {
+		if w.excludeFiles != nil && w.excludeFiles.MatchString(file) {
+			continue
+		}
+		switch fileKind {
+		case core.WatchFileKindNone, core.WatchFileKindUseFsEvents:
+			getNativeWatch(file).file = true
+		case core.WatchFileKindUseFsEventsOnParentDirectory:
+			watch := getNativeWatch(tspath.GetDirectoryPath(file))
+			if watch.children == nil {
+				watch.children = make(map[string]struct{})
+			}
+			watch.children[tspath.GetBaseFileName(file)] = struct{}{}
+		default:
+			kind := getPollingKindOfFileKind(fileKind)
+			polledFiles[kind] = append(polledFiles[kind], file)
+		}
+	}
+	for _, directory := range w.expandDirectories(directories) {
+		switch directoryKind {
+		case core.WatchDirectoryKindNone, core.WatchDirectoryKindUseFsEvents:
+			getNativeWatch(directory).directory = true
+		default:
+			kind := getPollingKindOfDirectoryKind(directoryKind)
+			polledDirectories[kind] = append(polledDirectories[kind], directory)
+		}
+	}
+
+	// Anything that cannot be watched natively is polled instead.
+	var failed []*nativeWatch
+	if len(native) != 0 && w.native == nil && w.nativeErr == nil {
+		w.native, w.nativeErr = newNativeWatcher(w.changes)
+	}
+	if w.native != nil {
+		failed = w.native.update(native)
+	} else {
+		failed = slices.Collect(maps.Values(native))
+	}
+	fallback := w.getFallbackPollingKind()
+	for _, watch := range failed {
+		if watch.file {
+			polledFiles[fallback] = append(polledFiles[fallback], watch.path)
+		}
+		if watch.directory {
+			polledDirectories[fallback] = append(polledDirectories[fallback], watch.path)
+		}
+		for name := range watch.children {
+			polledFiles[fallback] = append(polledFiles[fallback], tspath.CombinePaths(watch.path, name))
+		}
+	}
+
+	for kind := range polledFiles {
+		w.getPoller(kind)
+	}
+	for kind := range polledDirectories {
+		w.getPoller(kind)
+	}
+	for kind, p := range w.pollers {
+		p.update(polledFiles[kind], polledDirectories[kind])
+	}
+}
+
+// expandDirectories returns the watched directories along with the subdirectories of those
+// watched recursively, since directories can only be watched one at a time.
+func (w *watcher) expandDirectories(directories map[string]bool) []string {
+	var seen collections.Set[string]
+	var result []string
+	for _, directory := range slices.Sorted(maps.Keys(directories)) {
+		if w.isExcludedDirectory(directory) || !seen.AddIfAbsent(directory) {
+			continue
+		}
+		result = append(result, directory)
+		if !directories[directory] {
+			continue
+		}
+		_ = w.fs.WalkDir(directory, func(path string, d vfs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() || path == directory {
+				return nil
+			}
+			if w.isExcludedDirectory(path) {
+				return vfs.SkipDir
+			}
+			if seen.AddIfAbsent(path) {
+				result = append(result, path)
+			}
+			return nil
+		})
+	}
+	return result
+}
+
+func (w *watcher) isExcludedDirectory(path string) bool {
+	for _, fragment := range ignoredPathFragments {
+		if strings.Contains(path, fragment) {
+			return true
+		}
+	}
+	// Exclude patterns match the paths under a directory, so the directory itself is matched with
+	// a trailing separator, as in TypeScript.
+	return w.excludeDirectories != nil && w.excludeDirectories.MatchString(tspath.EnsureTrailingDirectorySeparator(path))
+}
+
+func (w *watcher) getFallbackPollingKind() core.PollingKind {
+	if w.options == nil || w.options.FallbackPolling == core.PollingKindNone {
+		return core.PollingKindPriorityInterval
+	}
+	return w.options.FallbackPolling
+}
+
+func (w *watcher) getPoller(kind core.PollingKind) *poller {
+	p := w.pollers[kind]
+	if p == nil {
+		p = newPoller(w.fs, kind, w.options.WatchInterval(), w.changes)
+		w.pollers[kind] = p
+	}
+	return p
+}
+
+func getPollingKindOfFileKind(kind core.WatchFileKind) core.PollingKind {
+	switch kind {
+	case core.WatchFileKindFixedPollingInterval:
+		return core.PollingKindFixedInterval
+	case core.WatchFileKindDynamicPriorityPolling:
+		return core.PollingKindDynamicPriority
+	case core.WatchFileKindFixedChunkSizePolling:
+		return core.PollingKindFixedChunkSize
+	default:
+		return core.PollingKindPriorityInterval
+	}
+}
+
+func getPollingKindOfDirectoryKind(kind core.WatchDirectoryKind) core.PollingKind {
+	switch kind {
+	case core.WatchDirectoryKindDynamicPriorityPolling:
+		return core.PollingKindDynamicPriority
+	case core.WatchDirectoryKindFixedChunkSizePolling:
+		return core.PollingKindFixedChunkSize
+	default:
+		return core.PollingKindFixedInterval
+	}
+}
+
+func (w *watcher) Wait() []string {
+	<-w.changes.wake
+	timer := time.NewTimer(debounce)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.changes.wake:
+			timer.Reset(debounce)
+		case <-timer.C:
+			return w.changes.take()
+		}
+	}
+}
+
+func (w *watcher) Close() error {
+	for _, p := range w.pollers {
+		p.close()
+	}
+	if w.native != nil {
+		return w.native.close()
+	}
+	return nil
+}
+
+// changes collects the paths that changed until Wait takes them.
+type changes struct {
+	mu    sync.Mutex
+	paths collections.Set[string]
+	all   bool
+	wake  chan struct{}
+}
+
+func newChanges() *changes {
+	return &changes{wake: make(chan struct{}, 1)}
+}
+
+// add records that path changed.
+func (c *changes) add(path string) {
+	c.mu.Lock()
+	c.paths.Add(path)
+	c.mu.Unlock()
+	c.notify()
+}
+
+// addAll records that changes may have been missed, so that every watched path must be checked.
+func (c *changes) addAll() {
+	c.mu.Lock()
+	c.all = true
+	c.mu.Unlock()
+	c.notify()
+}
+
+// notify wakes Wait, without blocking if it has already been woken.
+func (c *changes) notify() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// take returns and clears the recorded changes.
+func (c *changes) take() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var paths []string
+	if !c.all {
+		paths = make([]string, 0, c.paths.Len())
+		for path := range c.paths.Keys() {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+	}
+	c.paths.Clear()
+	c.all = false
+	return paths
+}
+
+// nativeWatch describes what to watch at a path with native events.
+type nativeWatch struct {
+	path string
+	// file is set if path is a file whose changes are reported.
+	file bool
+	// directory is set if path is a directory whose entries being added or removed are reported.
+	directory bool
+	// children are the names of entries of the directory at path whose changes are reported.
+	children map[string]struct{}
+}
@@ -0,0 +1,102 @@
+package vfswatch_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/vfs"
+	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+	"github.com/microsoft/typescript-go/internal/vfs/vfswatch"
+	"gotest.tools/v3/assert"
+)
+
+func waitForChange(t *testing.T, watcher vfswatch.Watcher) []string {
+	t.Helper()
+	done := make(chan []string)
+	go func() {
+		done <- watcher.Wait()
+	}()
+	select {
+	case changed := <-done:
+		return changed
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a change")
+		return nil
+	}
+}
+
+func newPollingWatcher(t *testing.T, fs vfs.FS, options *core.WatchOptions) vfswatch.Watcher {
+	t.Helper()
+	interval := 10
+	options.Interval = &interval
+	watcher := vfswatch.New(fs, options, "/project")
+	t.Cleanup(func() { assert.NilError(t, watcher.Close()) })
+	return watcher
+}
+
+func TestPollingFile(t *testing.T) {
+	t.Parallel()
+	fs := vfstest.FromMap(map[string]string{
+		"/project/a.ts": "export const a = 1;",
+		"/project/b.ts": "export const b = 1;",
+	}, true)
+	watcher := newPollingWatcher(t, fs, &core.WatchOptions{FileKind: core.WatchFileKindFixedPollingInterval})
+	watcher.Update([]string{"/project/a.ts", "/project/b.ts"}, nil)
+
+	assert.NilError(t, fs.Chtimes("/project/b.ts", time.Time{}, time.Unix(1, 0)))
+	assert.DeepEqual(t, waitForChange(t, watcher), []string{"/project/b.ts"})
+
+	assert.NilError(t, fs.Remove("/project/a.ts"))
+	assert.DeepEqual(t, waitForChange(t, watcher), []string{"/project/a.ts"})
+}
+
+func TestPollingChunks(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{}
+	var fileNames []string
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		fileName := "/project/" + name + ".ts"
+		files[fileName] = ""
+		fileNames = append(fileNames, fileName)
+	}
+	fs := vfstest.FromMap(files, true)
+	watcher := newPollingWatcher(t, fs, &core.WatchOptions{FileKind: core.WatchFileKindFixedChunkSizePolling})
+	watcher.Update(fileNames, nil)
+
+	assert.NilError(t, fs.Chtimes("/project/h.ts", time.Time{}, time.Unix(1, 0)))
+	assert.DeepEqual(t, waitForChange(t, watcher), []string{"/project/h.ts"})
+}
+
+func TestPollingDirectory(t *testing.T) {
+	t.Parallel()
+	fs := vfstest.FromMap(map[string]string{
+		"/project/src/a.ts":           "export const a = 1;",
+		"/project/src/nested/b.ts":    "export const b = 1;",
+		"/project/src/generated/c.ts": "export const c = 1;",
+	}, true)
+	watcher := newPollingWatcher(t, fs, &core.WatchOptions{
+		DirectoryKind: core.WatchDirectoryKindFixedPollingInterval,
+		ExcludeDir:    []string{"src/generated"},
+	})
+	watcher.Update(nil, map[string]bool{"/project/src": true})
+
+	// Files added to an excluded directory are not reported...
+	assert.NilError(t, fs.WriteFile("/project/src/generated/d.ts", "", false))
+	// ...but files added to subdirectories of a recursively watched directory are.
+	assert.NilError(t, fs.WriteFile("/project/src/nested/e.ts", "", false))
+	assert.DeepEqual(t, waitForChange(t, watcher), []string{"/project/src/nested"})
+}
+
+func TestFallbackPolling(t *testing.T) {
+	t.Parallel()
+	// Files in a virtual file system do not exist on disk, so cannot be watched natively.
+	fs := vfstest.FromMap(map[string]string{
+		"/vfswatch/project/a.ts": "export const a = 1;",
+	}, true)
+	watcher := newPollingWatcher(t, fs, &core.WatchOptions{FallbackPolling: core.PollingKindFixedInterval})
+	watcher.Update([]string{"/vfswatch/project/a.ts"}, map[string]bool{"/vfswatch/project": false})
+
+	assert.NilError(t, fs.WriteFile("/vfswatch/project/b.ts", "", false))
+	assert.DeepEqual(t, waitForChange(t, watcher), []string{"/vfswatch/project"})
+}
@@ -0,0 +1,165 @@
+//// [tests/cases/compiler/asyncFunctionDownlevel.ts] ////
+
+//// [asyncFunctionDownlevel.ts]
+declare const p: Promise<number>;
+
+async function simple(x: number) {
+    "use strict";
+    const y = await p;
+    return x + y;
+}
+
+async function withDefaults(a: number, b = 1, ...rest: number[]) {
+    return a + b + rest.length + await p;
+}
+
+async function withPattern({ a }: { a: number }, [b]: number[]) {
+    return a + b;
+}
+
+const arrow = async (x: number) => await p + x;
+
+const arrowWithDefaults = async (a: number, b = a) => {
+    return a + b;
+};
+
+function outer(this: unknown) {
+    const inner = async () => {
+        return [this, arguments.length, await p];
+    };
+    return inner;
+}
+
+async function usesArguments() {
+    const f = () => arguments[0];
+    return { arguments, f, length: arguments.length };
+}
+
+async function shadowedVars(x: number, { y }: { y: number }) {
+    var x = 1;
+    var z = 2, { y } = { y: x };
+    for (var x of [1, 2]) {
+    }
+    for (var i = 0, y = 0; i < x; i++) {
+    }
+    try {
+    }
+    catch (x) {
+        var x: number;
+    }
+    return x + y + z;
+}
+
+async function nested() {
+    async function inner() {
+        await p;
+    }
+    function notAsync() {
+        return arguments;
+    }
+    await inner();
+    return notAsync;
+}
+
+class C {
+    async method() {
+        return this;
+    }
+    static async staticMethod() {
+        await p;
+    }
+}
+
+
+//// [asyncFunctionDownlevel.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+function simple(x) {
+    "use strict";
+    return __awaiter(this, void 0, void 0, function* () {
+        const y = yield p;
+        return x + y;
+    });
+}
+function withDefaults(a_1) {
+    return __awaiter(this, arguments, void 0, function* (a, b = 1, ...rest) {
+        return a + b + rest.length + (yield p);
+    });
+}
+function withPattern(_a, _b) {
+    return __awaiter(this, arguments, void 0, function* ({ a }, [b]) {
+        return a + b;
+    });
+}
+const arrow = (x) => __awaiter(this, void 0, void 0, function* () {
+    return (yield p) + x;
+});
+const arrowWithDefaults = (a_1, ...args_1) => __awaiter(this, [a_1, ...args_1], void 0, function* (a, b = a) {
+    return a + b;
+});
+function outer() {
+    const inner = () => {
+        var arguments_1 = arguments;
+        return __awaiter(this, void 0, void 0, function* () {
+            return [this, arguments_1.length, yield p];
+        });
+    };
+    return inner;
+}
+function usesArguments() {
+    var arguments_2 = arguments;
+    return __awaiter(this, void 0, void 0, function* () {
+        const f = () => arguments_2[0];
+        return { arguments: arguments_2, f, length: arguments_2.length };
+    });
+}
+function shadowedVars(x_1, _a) {
+    return __awaiter(this, arguments, void 0, function* (x, { y }) {
+        var x, z, y, x, i, y;
+        x = 1;
+        z = 2, { y } = { y: x };
+        for (x of [1, 2]) {
+        }
+        for (i = 0, y = 0; i < x; i++) {
+        }
+        try {
+        }
+        catch (x) {
+            var x;
+        }
+        return x + y + z;
+    });
+}
+function nested() {
+    return __awaiter(this, void 0, void 0, function* () {
+        function inner() {
+            return __awaiter(this, void 0, void 0, function* () {
+                yield p;
+            });
+        }
+        function notAsync() {
+            return arguments;
+        }
+        yield inner();
+        return notAsync;
+    });
+}
+class C {
+    method() {
+        return __awaiter(this, void 0, void 0, function* () {
+            return this;
+        });
+    }
+    static staticMethod() {
+        return __awaiter(this, void 0, void 0, function* () {
+            yield p;
+        });
+    }
+}
@@ -0,0 +1,41 @@
+//// [tests/cases/compiler/asyncImportHelpers.ts] ////
+
+//// [main.ts]
+export async function f() {
+    await Promise.resolve();
+}
+
+//// [script.ts]
+async function g() {
+    await Promise.resolve();
+}
+
+//// [tslib.d.ts]
+export declare function __awaiter(thisArg: any, _arguments: any, P: Function, generator: Function): any;
+
+
+//// [main.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+const tslib_1 = require("tslib");
+exports.f = f;
+function f() {
+    return tslib_1.__awaiter(this, void 0, void 0, function* () {
+        yield Promise.resolve();
+    });
+}
+//// [script.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+function g() {
+    return __awaiter(this, void 0, void 0, function* () {
+        yield Promise.resolve();
+    });
+}
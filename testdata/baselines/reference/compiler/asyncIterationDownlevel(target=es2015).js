@@ -0,0 +1,320 @@
+//// [tests/cases/compiler/asyncIterationDownlevel.ts] ////
+
+//// [asyncIterationDownlevel.ts]
+declare const items: AsyncIterable<number>;
+declare function getPairs(): AsyncIterable<{ key: string; value: number; extra: boolean }>;
+declare function log(...args: any[]): void;
+
+async function sum() {
+    let total = 0;
+    for await (const item of items) {
+        total += item;
+    }
+    return total;
+}
+
+async function assignToExisting() {
+    let item: number;
+    for await (item of items) {
+        log(item);
+    }
+}
+
+async function labeledAndNested() {
+    outer: for await (const x of items) {
+        for (let i = 0; i < x; i++) {
+            for await (const y of items) {
+                if (y > i) continue outer;
+                if (y < 0) break outer;
+            }
+        }
+    }
+}
+
+async function withObjectRest() {
+    for await (const { key, ...rest } of getPairs()) {
+        log(key, rest);
+    }
+}
+
+async function* numbers(start = 0, ...more: number[]): AsyncGenerator<number, number, boolean | undefined> {
+    const first = await Promise.resolve(start);
+    const stop = yield first;
+    if (stop) return -1;
+    yield* more;
+    yield* items;
+    for await (const x of items) yield x;
+    try {
+        yield await Promise.resolve(first + 1);
+    }
+    finally {
+        log("cleanup");
+    }
+    return first;
+}
+
+async function* noReturnValue() {
+    yield;
+    return;
+}
+
+class Base {
+    get name() { return "base"; }
+    async *values(): AsyncGenerator<string> {}
+}
+
+class Derived extends Base {
+    async *values() {
+        const key = "values";
+        yield* super[key]();
+        yield* super.values();
+        yield super.name;
+        const inner = async () => super.name;
+        yield await inner();
+    }
+}
+
+const obj = {
+    async *[Symbol.asyncIterator]() {
+        yield 1;
+    },
+    async *gen() {
+        yield this;
+    },
+};
+
+const expr = async function* named() {
+    const sync = function* () { yield 1; };
+    yield* sync();
+};
+
+
+//// [asyncIterationDownlevel.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+var __rest = (this && this.__rest) || function (s, e) {
+    var t = {};
+    for (var p in s) if (Object.prototype.hasOwnProperty.call(s, p) && e.indexOf(p) < 0)
+        t[p] = s[p];
+    if (s != null && typeof Object.getOwnPropertySymbols === "function")
+        for (var i = 0, p = Object.getOwnPropertySymbols(s); i < p.length; i++) {
+            if (e.indexOf(p[i]) < 0 && Object.prototype.propertyIsEnumerable.call(s, p[i]))
+                t[p[i]] = s[p[i]];
+        }
+    return t;
+};
+var __asyncValues = (this && this.__asyncValues) || function (o) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var m = o[Symbol.asyncIterator], i;
+    return m ? m.call(o) : (o = typeof __values === "function" ? __values(o) : o[Symbol.iterator](), i = {}, verb("next"), verb("throw"), verb("return"), i[Symbol.asyncIterator] = function () { return this; }, i);
+    function verb(n) { i[n] = o[n] && function (v) { return new Promise(function (resolve, reject) { v = o[n](v), settle(resolve, reject, v.done, v.value); }); }; }
+    function settle(resolve, reject, d, v) { Promise.resolve(v).then(function(v) { resolve({ value: v, done: d }); }, reject); }
+};
+var __await = (this && this.__await) || function (v) { return this instanceof __await ? (this.v = v, this) : new __await(v); }
+var __asyncDelegator = (this && this.__asyncDelegator) || function (o) {
+    var i, p;
+    return i = {}, verb("next"), verb("throw", function (e) { throw e; }), verb("return"), i[Symbol.iterator] = function () { return this; }, i;
+    function verb(n, f) { i[n] = o[n] ? function (v) { return (p = !p) ? { value: __await(o[n](v)), done: false } : f ? f(v) : v; } : f; }
+};
+var __asyncGenerator = (this && this.__asyncGenerator) || function (thisArg, _arguments, generator) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var g = generator.apply(thisArg, _arguments || []), i, q = [];
+    return i = Object.create((typeof AsyncIterator === "function" ? AsyncIterator : Object).prototype), verb("next"), verb("throw"), verb("return", awaitReturn), i[Symbol.asyncIterator] = function () { return this; }, i;
+    function awaitReturn(f) { return function (v) { return Promise.resolve(v).then(f, reject); }; }
+    function verb(n, f) { if (g[n]) { i[n] = function (v) { return new Promise(function (a, b) { q.push([n, v, a, b]) > 1 || resume(n, v); }); }; if (f) i[n] = f(i[n]); } }
+    function resume(n, v) { try { step(g[n](v)); } catch (e) { settle(q[0][3], e); } }
+    function step(r) { r.value instanceof __await ? Promise.resolve(r.value.v).then(fulfill, reject) : settle(q[0][2], r); }
+    function fulfill(value) { resume("next", value); }
+    function reject(value) { resume("throw", value); }
+    function settle(f, v) { if (f(v), q.shift(), q.length) resume(q[0][0], q[0][1]); }
+};
+function sum() {
+    return __awaiter(this, void 0, void 0, function* () {
+        var _a, e_1, _b, _c;
+        let total = 0;
+        try {
+            for (var _d = true, items_1 = __asyncValues(items), items_1_1; items_1_1 = (yield items_1.next()), _a = items_1_1.done, !_a; _d = true) {
+                _c = items_1_1.value;
+                _d = false;
+                const item = _c;
+                total += item;
+            }
+        }
+        catch (e_1_1) { e_1 = { error: e_1_1 }; }
+        finally {
+            try {
+                if (!_d && !_a && (_b = items_1.return)) yield _b.call(items_1);
+            }
+            finally { if (e_1) throw e_1.error; }
+        }
+        return total;
+    });
+}
+function assignToExisting() {
+    return __awaiter(this, void 0, void 0, function* () {
+        var _a, e_2, _b, _c;
+        let item;
+        try {
+            for (var _d = true, items_2 = __asyncValues(items), items_2_1; items_2_1 = (yield items_2.next()), _a = items_2_1.done, !_a; _d = true) {
+                _c = items_2_1.value;
+                _d = false;
+                item = _c;
+                log(item);
+            }
+        }
+        catch (e_2_1) { e_2 = { error: e_2_1 }; }
+        finally {
+            try {
+                if (!_d && !_a && (_b = items_2.return)) yield _b.call(items_2);
+            }
+            finally { if (e_2) throw e_2.error; }
+        }
+    });
+}
+function labeledAndNested() {
+    return __awaiter(this, void 0, void 0, function* () {
+        var _a, e_3, _b, _c, _d, e_4, _e, _f;
+        try {
+            outer: for (var _g = true, items_3 = __asyncValues(items), items_3_1; items_3_1 = (yield items_3.next()), _a = items_3_1.done, !_a; _g = true) {
+                _c = items_3_1.value;
+                _g = false;
+                const x = _c;
+                for (let i = 0; i < x; i++) {
+                    try {
+                        for (var _h = true, items_4 = (e_4 = void 0, __asyncValues(items)), items_4_1; items_4_1 = (yield items_4.next()), _d = items_4_1.done, !_d; _h = true) {
+                            _f = items_4_1.value;
+                            _h = false;
+                            const y = _f;
+                            if (y > i)
+                                continue outer;
+                            if (y < 0)
+                                break outer;
+                        }
+                    }
+                    catch (e_4_1) { e_4 = { error: e_4_1 }; }
+                    finally {
+                        try {
+                            if (!_h && !_d && (_e = items_4.return)) yield _e.call(items_4);
+                        }
+                        finally { if (e_4) throw e_4.error; }
+                    }
+                }
+            }
+        }
+        catch (e_3_1) { e_3 = { error: e_3_1 }; }
+        finally {
+            try {
+                if (!_g && !_a && (_b = items_3.return)) yield _b.call(items_3);
+            }
+            finally { if (e_3) throw e_3.error; }
+        }
+    });
+}
+function withObjectRest() {
+    return __awaiter(this, void 0, void 0, function* () {
+        var _a, e_5, _b, _c;
+        try {
+            for (var _d = true, _e = __asyncValues(getPairs()), _f; _f = (yield _e.next()), _a = _f.done, !_a; _d = true) {
+                _c = _f.value;
+                _d = false;
+                let _g = _c;
+                const { key } = _g, rest = __rest(_g, ["key"]);
+                log(key, rest);
+            }
+        }
+        catch (e_5_1) { e_5 = { error: e_5_1 }; }
+        finally {
+            try {
+                if (!_d && !_a && (_b = _e.return)) yield _b.call(_e);
+            }
+            finally { if (e_5) throw e_5.error; }
+        }
+    });
+}
+function numbers() {
+    return __asyncGenerator(this, arguments, function* numbers_1(start = 0, ...more) {
+        var _a, e_6, _b, _c;
+        const first = yield __await(Promise.resolve(start));
+        const stop = yield yield __await(first);
+        if (stop)
+            return yield __await(-1);
+        yield __await(yield* __asyncDelegator(__asyncValues(more)));
+        yield __await(yield* __asyncDelegator(__asyncValues(items)));
+        try {
+            for (var _d = true, items_5 = __asyncValues(items), items_5_1; items_5_1 = (yield __await(items_5.next())), _a = items_5_1.done, !_a; _d = true) {
+                _c = items_5_1.value;
+                _d = false;
+                const x = _c;
+                yield yield __await(x);
+            }
+        }
+        catch (e_6_1) { e_6 = { error: e_6_1 }; }
+        finally {
+            try {
+                if (!_d && !_a && (_b = items_5.return)) yield __await(_b.call(items_5));
+            }
+            finally { if (e_6) throw e_6.error; }
+        }
+        try {
+            yield yield __await(yield __await(Promise.resolve(first + 1)));
+        }
+        finally {
+            log("cleanup");
+        }
+        return yield __await(first);
+    });
+}
+function noReturnValue() {
+    return __asyncGenerator(this, arguments, function* noReturnValue_1() {
+        yield yield __await(void 0);
+        return yield __await(void 0);
+    });
+}
+class Base {
+    get name() { return "base"; }
+    values() { return __asyncGenerator(this, arguments, function* values_1() { }); }
+}
+class Derived extends Base {
+    values() {
+        const _superIndex = name => super[name];
+        const _super = Object.create(null, {
+            values: { get: () => super.values },
+            name: { get: () => super.name }
+        });
+        return __asyncGenerator(this, arguments, function* values_2() {
+            const key = "values";
+            yield __await(yield* __asyncDelegator(__asyncValues(_superIndex(key).call(this))));
+            yield __await(yield* __asyncDelegator(__asyncValues(_super.values.call(this))));
+            yield yield __await(_super.name);
+            const inner = () => __awaiter(this, void 0, void 0, function* () {
+                return _super.name;
+            });
+            yield yield __await(yield __await(inner()));
+        });
+    }
+}
+const obj = {
+    [Symbol.asyncIterator]() {
+        return __asyncGenerator(this, arguments, function* _a() {
+            yield yield __await(1);
+        });
+    },
+    gen() {
+        return __asyncGenerator(this, arguments, function* gen_1() {
+            yield yield __await(this);
+        });
+    },
+};
+const expr = function named() {
+    return __asyncGenerator(this, arguments, function* named_1() {
+        const sync = function* () { yield 1; };
+        yield __await(yield* __asyncDelegator(__asyncValues(sync())));
+    });
+};
@@ -0,0 +1,121 @@
+//// [tests/cases/compiler/asyncMethodWithSuperDownlevel.ts] ////
+
+//// [asyncMethodWithSuperDownlevel.ts]
+class A {
+    x() {
+        return 1;
+    }
+    get y() {
+        return 2;
+    }
+    set y(value: number) {
+    }
+}
+
+class B extends A {
+    async simple() {
+        const a = super.x();
+        const b = super["x"]();
+        const c = super.y;
+        return a + b + c;
+    }
+
+    async assignment() {
+        super.y = 1;
+        super["y"] = 2;
+        ({ f: super.y } = { f: 3 });
+    }
+
+    arrow() {
+        const before = super.y;
+        const f = async () => super.x() + before;
+        return f;
+    }
+
+    notAsync() {
+        return super.x();
+    }
+}
+
+const o = {
+    __proto__: new A(),
+    async method() {
+        return super.x();
+    },
+};
+
+
+//// [asyncMethodWithSuperDownlevel.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+class A {
+    x() {
+        return 1;
+    }
+    get y() {
+        return 2;
+    }
+    set y(value) {
+    }
+}
+class B extends A {
+    simple() {
+        const _superIndex = name => super[name];
+        const _super = Object.create(null, {
+            x: { get: () => super.x },
+            y: { get: () => super.y }
+        });
+        return __awaiter(this, void 0, void 0, function* () {
+            const a = _super.x.call(this);
+            const b = _superIndex("x").call(this);
+            const c = _super.y;
+            return a + b + c;
+        });
+    }
+    assignment() {
+        const _superIndex = (function (geti, seti) {
+            const cache = Object.create(null);
+            return name => cache[name] || (cache[name] = { get value() { return geti(name); }, set value(v) { seti(name, v); } });
+        })(name => super[name], (name, value) => super[name] = value);
+        const _super = Object.create(null, {
+            y: { get: () => super.y, set: v => super.y = v }
+        });
+        return __awaiter(this, void 0, void 0, function* () {
+            _super.y = 1;
+            _superIndex("y").value = 2;
+            ({ f: _super.y } = { f: 3 });
+        });
+    }
+    arrow() {
+        const _super = Object.create(null, {
+            y: { get: () => super.y },
+            x: { get: () => super.x }
+        });
+        const before = _super.y;
+        const f = () => __awaiter(this, void 0, void 0, function* () {
+            return _super.x.call(this) + before;
+        });
+        return f;
+    }
+    notAsync() {
+        return super.x();
+    }
+}
+const o = {
+    __proto__: new A(),
+    method() {
+        const _super = Object.create(null, {
+            x: { get: () => super.x }
+        });
+        return __awaiter(this, void 0, void 0, function* () {
+            return _super.x.call(this);
+        });
+    },
+};
@@ -0,0 +1,73 @@
+//// [tests/cases/compiler/autoAccessorDownlevel.ts] ////
+
+//// [autoAccessorDownlevel.ts]
+declare function key(): "k";
+
+class C {
+    accessor a = 1;
+    accessor #b = 2;
+    static accessor c: string;
+    static accessor #d = 3;
+    accessor ["e"] = 4;
+    accessor [key()] = 5;
+
+    test() {
+        this.a = this.#b;
+        C.c = String(C.#d);
+    }
+}
+
+const Expression = class {
+    static accessor x = 1;
+};
+
+
+//// [autoAccessorDownlevel.js]
+var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};
+var __classPrivateFieldSet = (this && this.__classPrivateFieldSet) || function (receiver, state, value, kind, f) {
+    if (kind === "m") throw new TypeError("Private method is not writable");
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a setter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot write private member to an object whose class did not declare it");
+    return (kind === "a" ? f.call(receiver, value) : f ? f.value = value : state.set(receiver, value)), value;
+};
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var _a, _b, _C_a_accessor_storage, _C_b_accessor_storage, _C_instances, _C_b_get, _C_b_set, _C_c_accessor_storage, _C_d_accessor_storage, _C_d_get, _C_d_set, _C__a_accessor_storage, _C__b_accessor_storage, _c, _x_accessor_storage;
+class C {
+    constructor() {
+        _C_instances.add(this);
+        _C_a_accessor_storage.set(this, 1);
+        _C_b_accessor_storage.set(this, 2);
+        _C__a_accessor_storage.set(this, 4);
+        _C__b_accessor_storage.set(this, 5);
+    }
+    get a() { return __classPrivateFieldGet(this, _C_a_accessor_storage, "f"); }
+    set a(value) { __classPrivateFieldSet(this, _C_a_accessor_storage, value, "f"); }
+    static get c() { return __classPrivateFieldGet(_a, _a, "f", _C_c_accessor_storage); }
+    static set c(value) { __classPrivateFieldSet(_a, _a, value, "f", _C_c_accessor_storage); }
+    get ["e"]() { return __classPrivateFieldGet(this, _C__a_accessor_storage, "f"); }
+    set ["e"](value) { __classPrivateFieldSet(this, _C__a_accessor_storage, value, "f"); }
+    get [_b = key()]() { return __classPrivateFieldGet(this, _C__b_accessor_storage, "f"); }
+    set [_b](value) { __classPrivateFieldSet(this, _C__b_accessor_storage, value, "f"); }
+    test() {
+        this.a = __classPrivateFieldGet(this, _C_instances, "a", _C_b_get);
+        C.c = String(__classPrivateFieldGet(C, _a, "a", _C_d_get));
+    }
+}
+_a = C;
+_C_a_accessor_storage = new WeakMap(), _C_b_accessor_storage = new WeakMap(), _C_instances = new WeakSet(), _C__a_accessor_storage = new WeakMap(), _C__b_accessor_storage = new WeakMap(), _C_b_get = function _C_b_get() { return __classPrivateFieldGet(this, _C_b_accessor_storage, "f"); }, _C_b_set = function _C_b_set(value) { __classPrivateFieldSet(this, _C_b_accessor_storage, value, "f"); }, _C_d_get = function _C_d_get() { return __classPrivateFieldGet(_a, _a, "f", _C_d_accessor_storage); }, _C_d_set = function _C_d_set(value) { __classPrivateFieldSet(_a, _a, value, "f", _C_d_accessor_storage); };
+_C_c_accessor_storage = { value: void 0 };
+_C_d_accessor_storage = { value: 3 };
+const Expression = (_c = class {
+    static get x() { return __classPrivateFieldGet(_c, _c, "f", _x_accessor_storage); }
+    static set x(value) { __classPrivateFieldSet(_c, _c, value, "f", _x_accessor_storage); }
+},
+    __setFunctionName(_c, "Expression"),
+    _x_accessor_storage = { value: 1 },
+    _c);
@@ -0,0 +1,55 @@
+//// [tests/cases/compiler/autoAccessorDownlevel.ts] ////
+
+//// [autoAccessorDownlevel.ts]
+declare function key(): "k";
+
+class C {
+    accessor a = 1;
+    accessor #b = 2;
+    static accessor c: string;
+    static accessor #d = 3;
+    accessor ["e"] = 4;
+    accessor [key()] = 5;
+
+    test() {
+        this.a = this.#b;
+        C.c = String(C.#d);
+    }
+}
+
+const Expression = class {
+    static accessor x = 1;
+};
+
+
+//// [autoAccessorDownlevel.js]
+var _a;
+class C {
+    #a_accessor_storage = 1;
+    get a() { return this.#a_accessor_storage; }
+    set a(value) { this.#a_accessor_storage = value; }
+    #b_accessor_storage = 2;
+    get #b() { return this.#b_accessor_storage; }
+    set #b(value) { this.#b_accessor_storage = value; }
+    static #c_accessor_storage;
+    static get c() { return C.#c_accessor_storage; }
+    static set c(value) { C.#c_accessor_storage = value; }
+    static #d_accessor_storage = 3;
+    static get #d() { return C.#d_accessor_storage; }
+    static set #d(value) { C.#d_accessor_storage = value; }
+    #_a_accessor_storage = 4;
+    get ["e"]() { return this.#_a_accessor_storage; }
+    set ["e"](value) { this.#_a_accessor_storage = value; }
+    #_b_accessor_storage = 5;
+    get [_a = key()]() { return this.#_b_accessor_storage; }
+    set [_a](value) { this.#_b_accessor_storage = value; }
+    test() {
+        this.a = this.#b;
+        C.c = String(C.#d);
+    }
+}
+const Expression = class {
+    static #x_accessor_storage = 1;
+    static get x() { return this.#x_accessor_storage; }
+    static set x(value) { this.#x_accessor_storage = value; }
+};
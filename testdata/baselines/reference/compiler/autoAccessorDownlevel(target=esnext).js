@@ -0,0 +1,40 @@
+//// [tests/cases/compiler/autoAccessorDownlevel.ts] ////
+
+//// [autoAccessorDownlevel.ts]
+declare function key(): "k";
+
+class C {
+    accessor a = 1;
+    accessor #b = 2;
+    static accessor c: string;
+    static accessor #d = 3;
+    accessor ["e"] = 4;
+    accessor [key()] = 5;
+
+    test() {
+        this.a = this.#b;
+        C.c = String(C.#d);
+    }
+}
+
+const Expression = class {
+    static accessor x = 1;
+};
+
+
+//// [autoAccessorDownlevel.js]
+class C {
+    accessor a = 1;
+    accessor #b = 2;
+    static accessor c;
+    static accessor #d = 3;
+    accessor ["e"] = 4;
+    accessor [key()] = 5;
+    test() {
+        this.a = this.#b;
+        C.c = String(C.#d);
+    }
+}
+const Expression = class {
+    static accessor x = 1;
+};
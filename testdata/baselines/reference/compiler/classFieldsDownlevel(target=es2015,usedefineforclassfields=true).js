@@ -0,0 +1,194 @@
+//// [tests/cases/compiler/classFieldsDownlevel.ts] ////
+
+//// [classFieldsDownlevel.ts]
+declare function key1(): "k1";
+declare function key2(): "k2";
+declare function key3(): "k3";
+declare const sym: unique symbol;
+
+class Base {
+    a = 1;
+    b: number;
+    ["c"] = 2;
+    [key1()] = 3;
+    [sym]: string;
+    static d = 4;
+    static e: number;
+    static [key2()] = this.d;
+
+    constructor(public p: number, readonly q = 2) {
+        console.log(this.a);
+    }
+}
+
+class Derived extends Base {
+    f = this.a + 1;
+    static g = super.d;
+}
+
+class DerivedWithConstructor extends Base {
+    /** comment */
+    h = "h";
+
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        console.log("after");
+    }
+}
+
+class Keys {
+    [key1()] = 1;
+    [key2()]: number;
+    [key3()]() {}
+}
+
+const Expression = class {
+    static x = 1;
+    y = 2;
+};
+
+
+//// [classFieldsDownlevel.js]
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var _a, _b, _c, _d;
+var _e, _f, _g, _h, _j;
+class Base {
+    constructor(p, q = 2) {
+        Object.defineProperty(this, "p", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: p
+        });
+        Object.defineProperty(this, "q", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: q
+        });
+        Object.defineProperty(this, "a", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: 1
+        });
+        Object.defineProperty(this, "b", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: void 0
+        });
+        Object.defineProperty(this, "c", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: 2
+        });
+        Object.defineProperty(this, _e, {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: 3
+        });
+        Object.defineProperty(this, _f, {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: void 0
+        });
+        console.log(this.a);
+    }
+}
+_a = Base;
+_e = key1(), _f = sym, _g = key2();
+Object.defineProperty(Base, "d", {
+    enumerable: true,
+    configurable: true,
+    writable: true,
+    value: 4
+});
+Object.defineProperty(Base, "e", {
+    enumerable: true,
+    configurable: true,
+    writable: true,
+    value: void 0
+});
+Object.defineProperty(Base, _g, {
+    enumerable: true,
+    configurable: true,
+    writable: true,
+    value: _a.d
+});
+class Derived extends (_b = Base) {
+    constructor() {
+        super(...arguments);
+        Object.defineProperty(this, "f", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: this.a + 1
+        });
+    }
+}
+_c = Derived;
+Object.defineProperty(Derived, "g", {
+    enumerable: true,
+    configurable: true,
+    writable: true,
+    value: Reflect.get(_b, "d", _c)
+});
+class DerivedWithConstructor extends Base {
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        /** comment */
+        Object.defineProperty(this, "h", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: "h"
+        });
+        console.log("after");
+    }
+}
+class Keys {
+    constructor() {
+        Object.defineProperty(this, _h, {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: 1
+        });
+        Object.defineProperty(this, _j, {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: void 0
+        });
+    }
+    [(_h = key1(), _j = key2(), key3())]() { }
+}
+const Expression = (_d = class {
+    constructor() {
+        Object.defineProperty(this, "y", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: 2
+        });
+    }
+},
+    __setFunctionName(_d, "Expression"),
+    Object.defineProperty(_d, "x", {
+        enumerable: true,
+        configurable: true,
+        writable: true,
+        value: 1
+    }),
+    _d);
@@ -0,0 +1,105 @@
+//// [tests/cases/compiler/classFieldsDownlevel.ts] ////
+
+//// [classFieldsDownlevel.ts]
+declare function key1(): "k1";
+declare function key2(): "k2";
+declare function key3(): "k3";
+declare const sym: unique symbol;
+
+class Base {
+    a = 1;
+    b: number;
+    ["c"] = 2;
+    [key1()] = 3;
+    [sym]: string;
+    static d = 4;
+    static e: number;
+    static [key2()] = this.d;
+
+    constructor(public p: number, readonly q = 2) {
+        console.log(this.a);
+    }
+}
+
+class Derived extends Base {
+    f = this.a + 1;
+    static g = super.d;
+}
+
+class DerivedWithConstructor extends Base {
+    /** comment */
+    h = "h";
+
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        console.log("after");
+    }
+}
+
+class Keys {
+    [key1()] = 1;
+    [key2()]: number;
+    [key3()]() {}
+}
+
+const Expression = class {
+    static x = 1;
+    y = 2;
+};
+
+
+//// [classFieldsDownlevel.js]
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var _a, _b, _c, _d;
+var _e, _f, _g;
+class Base {
+    constructor(p, q = 2) {
+        this.p = p;
+        this.q = q;
+        this.a = 1;
+        this["c"] = 2;
+        this[_e] = 3;
+        console.log(this.a);
+    }
+}
+_a = Base;
+_e = key1(), _f = key2();
+Base.d = 4;
+Base[_f] = _a.d;
+class Derived extends (_b = Base) {
+    constructor() {
+        super(...arguments);
+        this.f = this.a + 1;
+    }
+}
+_c = Derived;
+Derived.g = Reflect.get(_b, "d", _c);
+class DerivedWithConstructor extends Base {
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        /** comment */
+        this.h = "h";
+        console.log("after");
+    }
+}
+class Keys {
+    constructor() {
+        this[_g] = 1;
+    }
+    [(_g = key1(), key2(), key3())]() { }
+}
+const Expression = (_d = class {
+    constructor() {
+        this.y = 2;
+    }
+},
+    __setFunctionName(_d, "Expression"),
+    _d.x = 1,
+    _d);
@@ -0,0 +1,93 @@
+//// [tests/cases/compiler/classFieldsDownlevel.ts] ////
+
+//// [classFieldsDownlevel.ts]
+declare function key1(): "k1";
+declare function key2(): "k2";
+declare function key3(): "k3";
+declare const sym: unique symbol;
+
+class Base {
+    a = 1;
+    b: number;
+    ["c"] = 2;
+    [key1()] = 3;
+    [sym]: string;
+    static d = 4;
+    static e: number;
+    static [key2()] = this.d;
+
+    constructor(public p: number, readonly q = 2) {
+        console.log(this.a);
+    }
+}
+
+class Derived extends Base {
+    f = this.a + 1;
+    static g = super.d;
+}
+
+class DerivedWithConstructor extends Base {
+    /** comment */
+    h = "h";
+
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        console.log("after");
+    }
+}
+
+class Keys {
+    [key1()] = 1;
+    [key2()]: number;
+    [key3()]() {}
+}
+
+const Expression = class {
+    static x = 1;
+    y = 2;
+};
+
+
+//// [classFieldsDownlevel.js]
+class Base {
+    p;
+    q;
+    a = 1;
+    b;
+    ["c"] = 2;
+    [key1()] = 3;
+    [sym];
+    static d = 4;
+    static e;
+    static [key2()] = this.d;
+    constructor(p, q = 2) {
+        this.p = p;
+        this.q = q;
+        console.log(this.a);
+    }
+}
+class Derived extends Base {
+    f = this.a + 1;
+    static g = super.d;
+}
+class DerivedWithConstructor extends Base {
+    /** comment */
+    h = "h";
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        console.log("after");
+    }
+}
+class Keys {
+    [key1()] = 1;
+    [key2()];
+    [key3()]() { }
+}
+const Expression = class {
+    static x = 1;
+    y = 2;
+};
@@ -0,0 +1,96 @@
+//// [tests/cases/compiler/classFieldsDownlevel.ts] ////
+
+//// [classFieldsDownlevel.ts]
+declare function key1(): "k1";
+declare function key2(): "k2";
+declare function key3(): "k3";
+declare const sym: unique symbol;
+
+class Base {
+    a = 1;
+    b: number;
+    ["c"] = 2;
+    [key1()] = 3;
+    [sym]: string;
+    static d = 4;
+    static e: number;
+    static [key2()] = this.d;
+
+    constructor(public p: number, readonly q = 2) {
+        console.log(this.a);
+    }
+}
+
+class Derived extends Base {
+    f = this.a + 1;
+    static g = super.d;
+}
+
+class DerivedWithConstructor extends Base {
+    /** comment */
+    h = "h";
+
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        console.log("after");
+    }
+}
+
+class Keys {
+    [key1()] = 1;
+    [key2()]: number;
+    [key3()]() {}
+}
+
+const Expression = class {
+    static x = 1;
+    y = 2;
+};
+
+
+//// [classFieldsDownlevel.js]
+var _a, _b, _c;
+class Base {
+    static { _a = key1(), _b = key2(); }
+    static { this.d = 4; }
+    static { this[_b] = this.d; }
+    constructor(p, q = 2) {
+        this.p = p;
+        this.q = q;
+        this.a = 1;
+        this["c"] = 2;
+        this[_a] = 3;
+        console.log(this.a);
+    }
+}
+class Derived extends Base {
+    constructor() {
+        super(...arguments);
+        this.f = this.a + 1;
+    }
+    static { this.g = super.d; }
+}
+class DerivedWithConstructor extends Base {
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        /** comment */
+        this.h = "h";
+        console.log("after");
+    }
+}
+class Keys {
+    constructor() {
+        this[_c] = 1;
+    }
+    [(_c = key1(), key2(), key3())]() { }
+}
+const Expression = class {
+    constructor() {
+        this.y = 2;
+    }
+    static { this.x = 1; }
+};
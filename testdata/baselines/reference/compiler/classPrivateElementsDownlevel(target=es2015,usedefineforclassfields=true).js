@@ -0,0 +1,191 @@
+//// [tests/cases/compiler/classPrivateElementsDownlevel.ts] ////
+
+//// [classPrivateElementsDownlevel.ts]
+class C {
+    #value = 1;
+    #unset: number;
+    static #count = 0;
+
+    #method(n: number) {
+        return this.#value + n;
+    }
+    get #doubled() {
+        return this.#value * 2;
+    }
+    set #doubled(v: number) {
+        this.#value = v / 2;
+    }
+    static #create() {
+        C.#count++;
+        return new C();
+    }
+    #tag(strings: TemplateStringsArray) {
+        return strings.length;
+    }
+    async #load() {
+        return this.#value;
+    }
+
+    test(other: C, arr: number[]) {
+        this.#value = 2;
+        this.#value += 3;
+        other.#value **= 2;
+        this.#value++;
+        const post = other.#value--;
+        const pre = ++this.#value;
+        this.#method(1);
+        other.#tag`tagged`;
+        this.#doubled = this.#doubled + 1;
+        [this.#value, other.#unset] = arr;
+        ({ x: this.#value, ...other.#unset as any } = { x: 1 } as any);
+        for (this.#value = 0; this.#value < 10; this.#value++) {}
+        return #value in other && C.#create() && post && pre && this.#load();
+    }
+
+    static {
+        C.#count = 10;
+    }
+}
+
+class Outer {
+    #x = 1;
+    #y = 2;
+    m() {
+        return class Inner {
+            #x = 3;
+            get(o: Outer, i: Inner) {
+                return o.#y + i.#x;
+            }
+        };
+    }
+}
+
+const anonymous = class {
+    #x = 1;
+    static y = 2;
+};
+
+
+//// [classPrivateElementsDownlevel.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};
+var __classPrivateFieldSet = (this && this.__classPrivateFieldSet) || function (receiver, state, value, kind, f) {
+    if (kind === "m") throw new TypeError("Private method is not writable");
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a setter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot write private member to an object whose class did not declare it");
+    return (kind === "a" ? f.call(receiver, value) : f ? f.value = value : state.set(receiver, value)), value;
+};
+var __classPrivateFieldIn = (this && this.__classPrivateFieldIn) || function(state, receiver) {
+    if (receiver === null || (typeof receiver !== "object" && typeof receiver !== "function")) throw new TypeError("Cannot use 'in' operator on non-object");
+    return typeof state === "function" ? receiver === state : state.has(receiver);
+};
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var __rest = (this && this.__rest) || function (s, e) {
+    var t = {};
+    for (var p in s) if (Object.prototype.hasOwnProperty.call(s, p) && e.indexOf(p) < 0)
+        t[p] = s[p];
+    if (s != null && typeof Object.getOwnPropertySymbols === "function")
+        for (var i = 0, p = Object.getOwnPropertySymbols(s); i < p.length; i++) {
+            if (e.indexOf(p[i]) < 0 && Object.prototype.propertyIsEnumerable.call(s, p[i]))
+                t[p[i]] = s[p[i]];
+        }
+    return t;
+};
+var _a;
+var _C_value, _C_unset, _b, _C_count, _C_instances, _C_method, _C_doubled_get, _C_doubled_set, _C_create, _C_tag, _C_load, _Outer_x, _Outer_y, _x;
+class C {
+    constructor() {
+        _C_instances.add(this);
+        _C_value.set(this, 1);
+        _C_unset.set(this, void 0);
+    }
+    test(other, arr) {
+        var _c, _d, _e, _f, _g, _h, _j, _k, _l;
+        var _m;
+        __classPrivateFieldSet(this, _C_value, 2, "f");
+        __classPrivateFieldSet(this, _C_value, __classPrivateFieldGet(this, _C_value, "f") + 3, "f");
+        __classPrivateFieldSet(_c = other, _C_value, Math.pow(__classPrivateFieldGet(_c, _C_value, "f"), 2), "f");
+        __classPrivateFieldSet(this, _C_value, (_d = __classPrivateFieldGet(this, _C_value, "f"), _d++, _d), "f");
+        const post = (__classPrivateFieldSet(_e = other, _C_value, (_g = __classPrivateFieldGet(_e, _C_value, "f"), _f = _g--, _g), "f"), _f);
+        const pre = __classPrivateFieldSet(this, _C_value, (_h = __classPrivateFieldGet(this, _C_value, "f"), ++_h), "f");
+        __classPrivateFieldGet(this, _C_instances, "m", _C_method).call(this, 1);
+        __classPrivateFieldGet(other, _C_instances, "m", _C_tag).bind(other) `tagged`;
+        __classPrivateFieldSet(this, _C_instances, __classPrivateFieldGet(this, _C_instances, "a", _C_doubled_get) + 1, "a", _C_doubled_set);
+        _j = this, [({ set value(_c) { __classPrivateFieldSet(_j, _C_value, _c, "f"); } }).value, ({ set value(_c) { __classPrivateFieldSet(other, _C_unset, _c, "f"); } }).value] = arr;
+        (_k = this, _m = { x: 1 }, { x: ({ set value(_c) { __classPrivateFieldSet(_k, _C_value, _c, "f"); } }).value } = _m, ({ set value(_c) { __classPrivateFieldSet(other, _C_unset, _c, "f"); } }).value = __rest(_m, ["x"]));
+        for (__classPrivateFieldSet(this, _C_value, 0, "f"); __classPrivateFieldGet(this, _C_value, "f") < 10; __classPrivateFieldSet(this, _C_value, (_l = __classPrivateFieldGet(this, _C_value, "f"), _l++, _l), "f")) { }
+        return __classPrivateFieldIn(_C_value, other) && __classPrivateFieldGet(C, _b, "m", _C_create).call(C) && post && pre && __classPrivateFieldGet(this, _C_instances, "m", _C_load).call(this);
+    }
+}
+_b = C;
+_C_value = new WeakMap(), _C_unset = new WeakMap(), _C_instances = new WeakSet(), _C_method = function _C_method(n) {
+    return __classPrivateFieldGet(this, _C_value, "f") + n;
+}, _C_doubled_get = function _C_doubled_get() {
+    return __classPrivateFieldGet(this, _C_value, "f") * 2;
+}, _C_doubled_set = function _C_doubled_set(v) {
+    __classPrivateFieldSet(this, _C_value, v / 2, "f");
+}, _C_create = function _C_create() {
+    var _c, _d;
+    __classPrivateFieldSet(_c = C, _b, (_d = __classPrivateFieldGet(_c, _b, "f", _C_count), _d++, _d), "f", _C_count);
+    return new C();
+}, _C_tag = function _C_tag(strings) {
+    return strings.length;
+}, _C_load = function _C_load() {
+    return __awaiter(this, void 0, void 0, function* () {
+        return __classPrivateFieldGet(this, _C_value, "f");
+    });
+};
+_C_count = { value: 0 };
+(() => {
+    __classPrivateFieldSet(C, _b, 10, "f", _C_count);
+})();
+class Outer {
+    constructor() {
+        _Outer_x.set(this, 1);
+        _Outer_y.set(this, 2);
+    }
+    m() {
+        var _c;
+        var _Inner_x;
+        return _c = class Inner {
+            constructor() {
+                _Inner_x.set(this, 3);
+            }
+            get(o, i) {
+                return __classPrivateFieldGet(o, _Outer_y, "f") + __classPrivateFieldGet(i, _Inner_x, "f");
+            }
+        },
+            _Inner_x = new WeakMap(),
+            _c;
+    }
+}
+_Outer_x = new WeakMap(), _Outer_y = new WeakMap();
+const anonymous = (_a = class {
+    constructor() {
+        _x.set(this, 1);
+    }
+},
+    __setFunctionName(_a, "anonymous"),
+    _x = new WeakMap(),
+    Object.defineProperty(_a, "y", {
+        enumerable: true,
+        configurable: true,
+        writable: true,
+        value: 2
+    }),
+    _a);
@@ -0,0 +1,127 @@
+//// [tests/cases/compiler/classPrivateElementsDownlevel.ts] ////
+
+//// [classPrivateElementsDownlevel.ts]
+class C {
+    #value = 1;
+    #unset: number;
+    static #count = 0;
+
+    #method(n: number) {
+        return this.#value + n;
+    }
+    get #doubled() {
+        return this.#value * 2;
+    }
+    set #doubled(v: number) {
+        this.#value = v / 2;
+    }
+    static #create() {
+        C.#count++;
+        return new C();
+    }
+    #tag(strings: TemplateStringsArray) {
+        return strings.length;
+    }
+    async #load() {
+        return this.#value;
+    }
+
+    test(other: C, arr: number[]) {
+        this.#value = 2;
+        this.#value += 3;
+        other.#value **= 2;
+        this.#value++;
+        const post = other.#value--;
+        const pre = ++this.#value;
+        this.#method(1);
+        other.#tag`tagged`;
+        this.#doubled = this.#doubled + 1;
+        [this.#value, other.#unset] = arr;
+        ({ x: this.#value, ...other.#unset as any } = { x: 1 } as any);
+        for (this.#value = 0; this.#value < 10; this.#value++) {}
+        return #value in other && C.#create() && post && pre && this.#load();
+    }
+
+    static {
+        C.#count = 10;
+    }
+}
+
+class Outer {
+    #x = 1;
+    #y = 2;
+    m() {
+        return class Inner {
+            #x = 3;
+            get(o: Outer, i: Inner) {
+                return o.#y + i.#x;
+            }
+        };
+    }
+}
+
+const anonymous = class {
+    #x = 1;
+    static y = 2;
+};
+
+
+//// [classPrivateElementsDownlevel.js]
+class C {
+    #value = 1;
+    #unset;
+    static #count = 0;
+    #method(n) {
+        return this.#value + n;
+    }
+    get #doubled() {
+        return this.#value * 2;
+    }
+    set #doubled(v) {
+        this.#value = v / 2;
+    }
+    static #create() {
+        C.#count++;
+        return new C();
+    }
+    #tag(strings) {
+        return strings.length;
+    }
+    async #load() {
+        return this.#value;
+    }
+    test(other, arr) {
+        this.#value = 2;
+        this.#value += 3;
+        other.#value **= 2;
+        this.#value++;
+        const post = other.#value--;
+        const pre = ++this.#value;
+        this.#method(1);
+        other.#tag `tagged`;
+        this.#doubled = this.#doubled + 1;
+        [this.#value, other.#unset] = arr;
+        ({ x: this.#value, ...other.#unset } = { x: 1 });
+        for (this.#value = 0; this.#value < 10; this.#value++) { }
+        return #value in other && C.#create() && post && pre && this.#load();
+    }
+    static {
+        C.#count = 10;
+    }
+}
+class Outer {
+    #x = 1;
+    #y = 2;
+    m() {
+        return class Inner {
+            #x = 3;
+            get(o, i) {
+                return o.#y + i.#x;
+            }
+        };
+    }
+}
+const anonymous = class {
+    #x = 1;
+    static { this.y = 2; }
+};
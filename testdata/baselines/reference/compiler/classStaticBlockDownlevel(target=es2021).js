@@ -0,0 +1,92 @@
+//// [tests/cases/compiler/classStaticBlockDownlevel.ts] ////
+
+//// [classStaticBlockDownlevel.ts]
+class A {
+    static a = 1;
+    static m() { return 2; }
+}
+
+class B extends A {
+    static b = 3;
+    static c = super.a;
+    static {
+        this.b = this.c;
+        super.a;
+        super["a"] = 4;
+        super.a += 5;
+        const x = super.m();
+        const f = () => this.b;
+        function g(this: any) { return this; }
+    }
+}
+
+function outer() {
+    return class {
+        static foo = 1;
+        static {
+            const c = class {
+                static bar = 2;
+                static {
+                    // comment
+                    var temp = this.bar;
+                }
+            };
+        }
+    };
+}
+
+export default class {
+    static {
+        console.log("default");
+    }
+}
+
+
+//// [classStaticBlockDownlevel.js]
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var _a, _b;
+class A {
+    static m() { return 2; }
+}
+A.a = 1;
+class B extends (_a = A) {
+}
+_b = B;
+B.b = 3;
+B.c = Reflect.get(_a, "a", _b);
+(() => {
+    _b.b = _b.c;
+    Reflect.get(_a, "a", _b);
+    Reflect.set(_a, "a", 4, _b);
+    Reflect.set(_a, "a", Reflect.get(_a, "a", _b) + 5, _b);
+    const x = Reflect.get(_a, "m", _b).call(_b);
+    const f = () => _b.b;
+    function g() { return this; }
+})();
+function outer() {
+    var _c;
+    return _c = class {
+    },
+        _c.foo = 1,
+        (() => {
+            var _d;
+            const c = (_d = class {
+            },
+                __setFunctionName(_d, "c"),
+                _d.bar = 2,
+                (() => {
+                    // comment
+                    var temp = _d.bar;
+                })(),
+                _d);
+        })(),
+        _c;
+}
+export default class default_1 {
+}
+(() => {
+    console.log("default");
+})();
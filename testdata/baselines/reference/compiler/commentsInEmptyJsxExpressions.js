@@ -0,0 +1,26 @@
+//// [tests/cases/compiler/commentsInEmptyJsxExpressions.tsx] ////
+
+//// [commentsInEmptyJsxExpressions.tsx]
+declare namespace JSX { interface IntrinsicElements { div: any } }
+
+export const el = <div>
+    {/* block */}
+    {
+        // line 1
+    }
+    {// line 2
+    }
+    {}
+</div>;
+
+
+//// [commentsInEmptyJsxExpressions.jsx]
+export const el = <div>
+    {/* block */}
+    {
+    // line 1
+    }
+    {// line 2
+    }
+    
+</div>;
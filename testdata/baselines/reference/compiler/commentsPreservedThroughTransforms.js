@@ -0,0 +1,77 @@
+//// [tests/cases/compiler/commentsPreservedThroughTransforms.ts] ////
+
+//// [commentsPreservedThroughTransforms.ts]
+/** doc for x */
+export const x = 1,
+    /** doc for y */
+    y = 2;
+
+export namespace N {
+    /** inner var */
+    export let v = 1,
+        /** doc for w */
+        w = 2;
+}
+
+declare const a: unknown;
+
+export function f() {
+    return (// comment
+        a as number
+    );
+}
+
+export function g() {
+    return (
+        /* block comment */ a as number
+    );
+}
+
+export const obj = {
+    key: /** trailing colon */ 1,
+};
+
+/** enum doc */
+export enum E {
+    /** member doc */
+    A,
+    B = 2,
+}
+
+
+//// [commentsPreservedThroughTransforms.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.E = exports.obj = exports.N = exports.y = exports.x = void 0;
+exports.f = f;
+exports.g = g;
+/** doc for x */
+exports.x = 1, 
+/** doc for y */
+exports.y = 2;
+var N;
+(function (N) {
+    /** inner var */
+    N.v = 1, 
+    /** doc for w */
+    N.w = 2;
+})(N || (exports.N = N = {}));
+function f() {
+    return ( // comment
+    a);
+}
+function g() {
+    return (
+    /* block comment */ a);
+}
+exports.obj = {
+    key: /** trailing colon */ 1,
+};
+/** enum doc */
+var E;
+(function (E) {
+    /** member doc */
+    E[E["A"] = 0] = "A";
+    E[E["B"] = 2] = "B";
+})(E || (exports.E = E = {}));
+//# sourceMappingURL=commentsPreservedThroughTransforms.js.map
\ No newline at end of file
@@ -30,7 +30,9 @@ Object.defineProperty(exports, "__esModule", { value: true });
 exports.Foo = void 0;
 const other_1 = require("./other");
 class Foo {
-    bar = (0, other_1.foo)();
+    constructor() {
+        this.bar = (0, other_1.foo)();
+    }
 }
 exports.Foo = Foo;
 
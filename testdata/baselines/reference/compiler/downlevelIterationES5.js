@@ -0,0 +1,214 @@
+//// [tests/cases/compiler/downlevelIterationES5.ts] ////
+
+//// [downlevelIterationES5.ts]
+declare const iterable: Iterable<number>;
+declare function use(...args: any[]): void;
+declare class Box {
+    constructor(...values: number[]);
+}
+
+for (const x of iterable) {
+    use(x);
+}
+
+label: for (const [a, b] of new Map<string, number>()) {
+    for (const c of iterable) {
+        if (c) continue label;
+        use(a, b, c);
+    }
+}
+
+const spread = [...iterable, 1, ...[2, 3]];
+use(...iterable);
+use(1, ...iterable, 2);
+const o = { method(...args: number[]) { return args; } };
+o.method(...iterable);
+new Box(...iterable, 1);
+
+const [first, second = 2, ...rest] = iterable;
+let p: number, q: number;
+[p, q] = [q, p];
+
+function params([a, b]: number[], c = a) {
+    return a + b + c;
+}
+
+try {
+    use();
+}
+catch ([e1, e2]) {
+    use(e1, e2);
+}
+
+function* gen() {
+    yield* iterable;
+    for (const x of iterable) {
+        yield x;
+    }
+}
+
+
+//// [downlevelIterationES5.js]
+var __generator = (this && this.__generator) || function (thisArg, body) {
+    var _ = { label: 0, sent: function() { if (t[0] & 1) throw t[1]; return t[1]; }, trys: [], ops: [] }, f, y, t, g = Object.create((typeof Iterator === "function" ? Iterator : Object).prototype);
+    return g.next = verb(0), g["throw"] = verb(1), g["return"] = verb(2), typeof Symbol === "function" && (g[Symbol.iterator] = function() { return this; }), g;
+    function verb(n) { return function (v) { return step([n, v]); }; }
+    function step(op) {
+        if (f) throw new TypeError("Generator is already executing.");
+        while (g && (g = 0, op[0] && (_ = 0)), _) try {
+            if (f = 1, y && (t = op[0] & 2 ? y["return"] : op[0] ? y["throw"] || ((t = y["return"]) && t.call(y), 0) : y.next) && !(t = t.call(y, op[1])).done) return t;
+            if (y = 0, t) op = [op[0] & 2, t.value];
+            switch (op[0]) {
+                case 0: case 1: t = op; break;
+                case 4: _.label++; return { value: op[1], done: false };
+                case 5: _.label++; y = op[1]; op = [0]; continue;
+                case 7: op = _.ops.pop(); _.trys.pop(); continue;
+                default:
+                    if (!(t = _.trys, t = t.length > 0 && t[t.length - 1]) && (op[0] === 6 || op[0] === 2)) { _ = 0; continue; }
+                    if (op[0] === 3 && (!t || (op[1] > t[0] && op[1] < t[3]))) { _.label = op[1]; break; }
+                    if (op[0] === 6 && _.label < t[1]) { _.label = t[1]; t = op; break; }
+                    if (t && _.label < t[2]) { _.label = t[2]; _.ops.push(op); break; }
+                    if (t[2]) _.ops.pop();
+                    _.trys.pop(); continue;
+            }
+            op = body.call(thisArg, _);
+        } catch (e) { op = [6, e]; y = 0; } finally { f = t = 0; }
+        if (op[0] & 5) throw op[1]; return { value: op[0] ? op[1] : void 0, done: true };
+    }
+};
+var __values = (this && this.__values) || function(o) {
+    var s = typeof Symbol === "function" && Symbol.iterator, m = s && o[s], i = 0;
+    if (m) return m.call(o);
+    if (o && typeof o.length === "number") return {
+        next: function () {
+            if (o && i >= o.length) o = void 0;
+            return { value: o && o[i++], done: !o };
+        }
+    };
+    throw new TypeError(s ? "Object is not iterable." : "Symbol.iterator is not defined.");
+};
+var __read = (this && this.__read) || function (o, n) {
+    var m = typeof Symbol === "function" && o[Symbol.iterator];
+    if (!m) return o;
+    var i = m.call(o), r, ar = [], e;
+    try {
+        while ((n === void 0 || n-- > 0) && !(r = i.next()).done) ar.push(r.value);
+    }
+    catch (error) { e = { error: error }; }
+    finally {
+        try {
+            if (r && !r.done && (m = i["return"])) m.call(i);
+        }
+        finally { if (e) throw e.error; }
+    }
+    return ar;
+};
+var __spreadArray = (this && this.__spreadArray) || function (to, from, pack) {
+    if (pack || arguments.length === 2) for (var i = 0, l = from.length, ar; i < l; i++) {
+        if (ar || !(i in from)) {
+            if (!ar) ar = Array.prototype.slice.call(from, 0, i);
+            ar[i] = from[i];
+        }
+    }
+    return to.concat(ar || Array.prototype.slice.call(from));
+};
+var e_1, _a, e_2, _b, e_3, _c, _d;
+try {
+    for (var iterable_1 = __values(iterable), iterable_1_1 = iterable_1.next(); !iterable_1_1.done; iterable_1_1 = iterable_1.next()) {
+        const x = iterable_1_1.value;
+        use(x);
+    }
+}
+catch (e_1_1) { e_1 = { error: e_1_1 }; }
+finally {
+    try {
+        if (iterable_1_1 && !iterable_1_1.done && (_a = iterable_1.return)) _a.call(iterable_1);
+    }
+    finally { if (e_1) throw e_1.error; }
+}
+try {
+    label: for (var _e = __values(new Map()), _f = _e.next(); !_f.done; _f = _e.next()) {
+        const _g = __read(_f.value, 2), a = _g[0], b = _g[1];
+        try {
+            for (var iterable_2 = (e_3 = void 0, __values(iterable)), iterable_2_1 = iterable_2.next(); !iterable_2_1.done; iterable_2_1 = iterable_2.next()) {
+                const c = iterable_2_1.value;
+                if (c)
+                    continue label;
+                use(a, b, c);
+            }
+        }
+        catch (e_3_1) { e_3 = { error: e_3_1 }; }
+        finally {
+            try {
+                if (iterable_2_1 && !iterable_2_1.done && (_c = iterable_2.return)) _c.call(iterable_2);
+            }
+            finally { if (e_3) throw e_3.error; }
+        }
+    }
+}
+catch (e_2_1) { e_2 = { error: e_2_1 }; }
+finally {
+    try {
+        if (_f && !_f.done && (_b = _e.return)) _b.call(_e);
+    }
+    finally { if (e_2) throw e_2.error; }
+}
+const spread = __spreadArray(__spreadArray(__spreadArray([], __read(iterable), false), [1], false), [2, 3], false);
+use.apply(void 0, __spreadArray([], __read(iterable), false));
+use.apply(void 0, __spreadArray(__spreadArray([1], __read(iterable), false), [2], false));
+const o = { method(...args) { return args; } };
+o.method.apply(o, __spreadArray([], __read(iterable), false));
+new (Box.bind.apply(Box, __spreadArray(__spreadArray([void 0], __read(iterable), false), [1], false)))();
+const _h = __read(iterable), first = _h[0], _j = _h[1], second = _j === void 0 ? 2 : _j, rest = _h.slice(2);
+let p, q;
+_d = __read([q, p], 2), p = _d[0], q = _d[1];
+function params(_a, c) {
+    var _b = __read(_a, 2), a = _b[0], b = _b[1];
+    if (c === void 0) { c = a; }
+    return a + b + c;
+}
+try {
+    use();
+}
+catch (_k) {
+    var _l = __read(_k, 2), e1 = _l[0], e2 = _l[1];
+    use(e1, e2);
+}
+function gen() {
+    var iterable_3, iterable_3_1, x, e_4_1;
+    var e_4, _a;
+    return __generator(this, function (_b) {
+        switch (_b.label) {
+            case 0: return [5 /*yield**/, __values(iterable)];
+            case 1:
+                _b.sent();
+                _b.label = 2;
+            case 2:
+                _b.trys.push([2, 7, 8, 9]);
+                iterable_3 = __values(iterable), iterable_3_1 = iterable_3.next();
+                _b.label = 3;
+            case 3:
+                if (!!iterable_3_1.done) return [3 /*break*/, 6];
+                x = iterable_3_1.value;
+                return [4 /*yield*/, x];
+            case 4:
+                _b.sent();
+                _b.label = 5;
+            case 5:
+                iterable_3_1 = iterable_3.next();
+                return [3 /*break*/, 3];
+            case 6: return [3 /*break*/, 9];
+            case 7:
+                e_4_1 = _b.sent();
+                e_4 = { error: e_4_1 };
+                return [3 /*break*/, 9];
+            case 8:
+                try {
+                    if (iterable_3_1 && !iterable_3_1.done && (_a = iterable_3.return)) _a.call(iterable_3);
+                }
+                finally { if (e_4) throw e_4.error; }
+                return [7 /*endfinally*/];
+            case 9: return [2 /*return*/];
+        }
+    });
+}
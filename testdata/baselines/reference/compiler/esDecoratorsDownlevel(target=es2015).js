@@ -0,0 +1,270 @@
+//// [tests/cases/compiler/esDecoratorsDownlevel.ts] ////
+
+//// [esDecoratorsDownlevel.ts]
+declare function dec(value: any, context: DecoratorContext): any;
+declare const decorators: { bound: typeof dec };
+declare function key(): "k";
+
+@dec
+class C {
+    @dec method() {}
+    @dec get x() { return 1; }
+    @dec set x(value: number) {}
+    @dec accessor y = 2;
+    @dec field = 3;
+    @dec static staticField = 4;
+    @dec static staticMethod() {}
+    @dec #privateMethod() {}
+    @dec get #privateGetter() { return 5; }
+    @dec set #privateSetter(value: number) {}
+    @dec accessor #privateAccessor = 6;
+    @dec static accessor #staticPrivateAccessor = 7;
+    @decorators.bound ["computed"] = 8;
+    undecorated = 9;
+    @dec [key()]() {}
+}
+
+class D extends C {
+    @dec field = 10;
+
+    constructor() {
+        super();
+        console.log(this.field);
+    }
+}
+
+function addInitializer(value: any, context: DecoratorContext) {
+    context.addInitializer(function () {
+        console.log("initialized");
+    });
+    (context.metadata as any)[String(context.name)] = true;
+}
+
+export const Expression = @addInitializer class {
+    @addInitializer static x = 1;
+};
+
+export default @dec class {
+    static y = this;
+}
+
+
+//// [esDecoratorsDownlevel.js]
+var __runInitializers = (this && this.__runInitializers) || function (thisArg, initializers, value) {
+    var useValue = arguments.length > 2;
+    for (var i = 0; i < initializers.length; i++) {
+        value = useValue ? initializers[i].call(thisArg, value) : initializers[i].call(thisArg);
+    }
+    return useValue ? value : void 0;
+};
+var __esDecorate = (this && this.__esDecorate) || function (ctor, descriptorIn, decorators, contextIn, initializers, extraInitializers) {
+    function accept(f) { if (f !== void 0 && typeof f !== "function") throw new TypeError("Function expected"); return f; }
+    var kind = contextIn.kind, key = kind === "getter" ? "get" : kind === "setter" ? "set" : "value";
+    var target = !descriptorIn && ctor ? contextIn["static"] ? ctor : ctor.prototype : null;
+    var descriptor = descriptorIn || (target ? Object.getOwnPropertyDescriptor(target, contextIn.name) : {});
+    var _, done = false;
+    for (var i = decorators.length - 1; i >= 0; i--) {
+        var context = {};
+        for (var p in contextIn) context[p] = p === "access" ? {} : contextIn[p];
+        for (var p in contextIn.access) context.access[p] = contextIn.access[p];
+        context.addInitializer = function (f) { if (done) throw new TypeError("Cannot add initializers after decoration has completed"); extraInitializers.push(accept(f || null)); };
+        var result = (0, decorators[i])(kind === "accessor" ? { get: descriptor.get, set: descriptor.set } : descriptor[key], context);
+        if (kind === "accessor") {
+            if (result === void 0) continue;
+            if (result === null || typeof result !== "object") throw new TypeError("Object expected");
+            if (_ = accept(result.get)) descriptor.get = _;
+            if (_ = accept(result.set)) descriptor.set = _;
+            if (_ = accept(result.init)) initializers.unshift(_);
+        }
+        else if (_ = accept(result)) {
+            if (kind === "field") initializers.unshift(_);
+            else descriptor[key] = _;
+        }
+    }
+    if (target) Object.defineProperty(target, contextIn.name, descriptor);
+    done = true;
+};
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var __propKey = (this && this.__propKey) || function (x) {
+    return typeof x === "symbol" ? x : "".concat(x);
+};
+var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};
+var __classPrivateFieldSet = (this && this.__classPrivateFieldSet) || function (receiver, state, value, kind, f) {
+    if (kind === "m") throw new TypeError("Private method is not writable");
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a setter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot write private member to an object whose class did not declare it");
+    return (kind === "a" ? f.call(receiver, value) : f ? f.value = value : state.set(receiver, value)), value;
+};
+var __classPrivateFieldIn = (this && this.__classPrivateFieldIn) || function(state, receiver) {
+    if (receiver === null || (typeof receiver !== "object" && typeof receiver !== "function")) throw new TypeError("Cannot use 'in' operator on non-object");
+    return typeof state === "function" ? receiver === state : state.has(receiver);
+};
+let C = (() => {
+    var _y_accessor_storage, _instances, _privateMethod_get, _privateGetter_get, _privateSetter_set, _privateAccessor_accessor_storage, _privateAccessor_get, _privateAccessor_set, _staticPrivateAccessor_accessor_storage, _staticPrivateAccessor_get, _staticPrivateAccessor_set;
+    var _a, _b;
+    let _classDecorators = [dec];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    let _staticExtraInitializers = [];
+    let _instanceExtraInitializers = [];
+    let _static_staticField_decorators;
+    let _static_staticField_initializers = [];
+    let _static_staticField_extraInitializers = [];
+    let _static_staticMethod_decorators;
+    let _static_private_staticPrivateAccessor_decorators;
+    let _static_private_staticPrivateAccessor_initializers = [];
+    let _static_private_staticPrivateAccessor_extraInitializers = [];
+    let _static_private_staticPrivateAccessor_descriptor;
+    let _method_decorators;
+    let _get_x_decorators;
+    let _set_x_decorators;
+    let _y_decorators;
+    let _y_initializers = [];
+    let _y_extraInitializers = [];
+    let _field_decorators;
+    let _field_initializers = [];
+    let _field_extraInitializers = [];
+    let _private_privateMethod_decorators;
+    let _private_privateMethod_descriptor;
+    let _private_get_privateGetter_decorators;
+    let _private_get_privateGetter_descriptor;
+    let _private_set_privateSetter_decorators;
+    let _private_set_privateSetter_descriptor;
+    let _private_privateAccessor_decorators;
+    let _private_privateAccessor_initializers = [];
+    let _private_privateAccessor_extraInitializers = [];
+    let _private_privateAccessor_descriptor;
+    let _member_decorators;
+    let _member_initializers = [];
+    let _member_extraInitializers = [];
+    let _member_decorators_1;
+    var C = (_classThis = class {
+        constructor() {
+            _instances.add(this);
+            _y_accessor_storage.set(this, (__runInitializers(this, _instanceExtraInitializers), __runInitializers(this, _y_initializers, 2)));
+            this.field = (__runInitializers(this, _y_extraInitializers), __runInitializers(this, _field_initializers, 3));
+            _privateAccessor_accessor_storage.set(this, (__runInitializers(this, _field_extraInitializers), __runInitializers(this, _private_privateAccessor_initializers, 6)));
+            this["computed"] = (__runInitializers(this, _private_privateAccessor_extraInitializers), __runInitializers(this, _member_initializers, 8));
+            this.undecorated = (__runInitializers(this, _member_extraInitializers), 9);
+        }
+        method() { }
+        get x() { return 1; }
+        set x(value) { }
+        get y() { return __classPrivateFieldGet(this, _y_accessor_storage, "f"); }
+        set y(value) { __classPrivateFieldSet(this, _y_accessor_storage, value, "f"); }
+        static staticMethod() { }
+        [(_method_decorators = [dec], _get_x_decorators = [dec], _set_x_decorators = [dec], _y_decorators = [dec], _field_decorators = [dec], _static_staticField_decorators = [dec], _static_staticMethod_decorators = [dec], _private_privateMethod_decorators = [dec], _private_get_privateGetter_decorators = [dec], _private_set_privateSetter_decorators = [dec], _private_privateAccessor_decorators = [dec], _static_private_staticPrivateAccessor_decorators = [dec], _member_decorators = [(_a = decorators).bound.bind(_a)], _member_decorators_1 = [dec], _b = __propKey(key()))]() { }
+    },
+        __setFunctionName(_classThis, "C"), _y_accessor_storage = new WeakMap(), _instances = new WeakSet(), _privateAccessor_accessor_storage = new WeakMap(), _privateMethod_get = function _privateMethod_get() { return _private_privateMethod_descriptor.value; }, _privateGetter_get = function _privateGetter_get() { return _private_get_privateGetter_descriptor.get.call(this); }, _privateSetter_set = function _privateSetter_set(value) { return _private_set_privateSetter_descriptor.set.call(this, value); }, _privateAccessor_get = function _privateAccessor_get() { return _private_privateAccessor_descriptor.get.call(this); }, _privateAccessor_set = function _privateAccessor_set(value) { return _private_privateAccessor_descriptor.set.call(this, value); }, _staticPrivateAccessor_get = function _staticPrivateAccessor_get() { return _static_private_staticPrivateAccessor_descriptor.get.call(this); }, _staticPrivateAccessor_set = function _staticPrivateAccessor_set(value) { return _static_private_staticPrivateAccessor_descriptor.set.call(this, value); },
+        (() => {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            __esDecorate(_classThis, null, _static_staticMethod_decorators, { kind: "method", name: "staticMethod", static: true, private: false, access: { has: obj => "staticMethod" in obj, get: obj => obj.staticMethod }, metadata: _metadata }, null, _staticExtraInitializers);
+            __esDecorate(_classThis, _static_private_staticPrivateAccessor_descriptor = { get: __setFunctionName(function () { return __classPrivateFieldGet(this, _classThis, "f", _staticPrivateAccessor_accessor_storage); }, "#staticPrivateAccessor", "get"), set: __setFunctionName(function (value) { __classPrivateFieldSet(this, _classThis, value, "f", _staticPrivateAccessor_accessor_storage); }, "#staticPrivateAccessor", "set") }, _static_private_staticPrivateAccessor_decorators, { kind: "accessor", name: "#staticPrivateAccessor", static: true, private: true, access: { has: obj => __classPrivateFieldIn(_classThis, obj), get: obj => __classPrivateFieldGet(obj, _classThis, "a", _staticPrivateAccessor_get), set: (obj, value) => { __classPrivateFieldSet(obj, _classThis, value, "a", _staticPrivateAccessor_set); } }, metadata: _metadata }, _static_private_staticPrivateAccessor_initializers, _static_private_staticPrivateAccessor_extraInitializers);
+            __esDecorate(_classThis, null, _method_decorators, { kind: "method", name: "method", static: false, private: false, access: { has: obj => "method" in obj, get: obj => obj.method }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(_classThis, null, _get_x_decorators, { kind: "getter", name: "x", static: false, private: false, access: { has: obj => "x" in obj, get: obj => obj.x }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(_classThis, null, _set_x_decorators, { kind: "setter", name: "x", static: false, private: false, access: { has: obj => "x" in obj, set: (obj, value) => { obj.x = value; } }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(_classThis, null, _y_decorators, { kind: "accessor", name: "y", static: false, private: false, access: { has: obj => "y" in obj, get: obj => obj.y, set: (obj, value) => { obj.y = value; } }, metadata: _metadata }, _y_initializers, _y_extraInitializers);
+            __esDecorate(_classThis, _private_privateMethod_descriptor = { value: __setFunctionName(function () { }, "#privateMethod") }, _private_privateMethod_decorators, { kind: "method", name: "#privateMethod", static: false, private: true, access: { has: obj => __classPrivateFieldIn(_instances, obj), get: obj => __classPrivateFieldGet(obj, _instances, "a", _privateMethod_get) }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(_classThis, _private_get_privateGetter_descriptor = { get: __setFunctionName(function () { return 5; }, "#privateGetter", "get") }, _private_get_privateGetter_decorators, { kind: "getter", name: "#privateGetter", static: false, private: true, access: { has: obj => __classPrivateFieldIn(_instances, obj), get: obj => __classPrivateFieldGet(obj, _instances, "a", _privateGetter_get) }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(_classThis, _private_set_privateSetter_descriptor = { set: __setFunctionName(function (value) { }, "#privateSetter", "set") }, _private_set_privateSetter_decorators, { kind: "setter", name: "#privateSetter", static: false, private: true, access: { has: obj => __classPrivateFieldIn(_instances, obj), set: (obj, value) => { __classPrivateFieldSet(obj, _instances, value, "a", _privateSetter_set); } }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(_classThis, _private_privateAccessor_descriptor = { get: __setFunctionName(function () { return __classPrivateFieldGet(this, _privateAccessor_accessor_storage, "f"); }, "#privateAccessor", "get"), set: __setFunctionName(function (value) { __classPrivateFieldSet(this, _privateAccessor_accessor_storage, value, "f"); }, "#privateAccessor", "set") }, _private_privateAccessor_decorators, { kind: "accessor", name: "#privateAccessor", static: false, private: true, access: { has: obj => __classPrivateFieldIn(_instances, obj), get: obj => __classPrivateFieldGet(obj, _instances, "a", _privateAccessor_get), set: (obj, value) => { __classPrivateFieldSet(obj, _instances, value, "a", _privateAccessor_set); } }, metadata: _metadata }, _private_privateAccessor_initializers, _private_privateAccessor_extraInitializers);
+            __esDecorate(_classThis, null, _member_decorators_1, { kind: "method", name: _b, static: false, private: false, access: { has: obj => _b in obj, get: obj => obj[_b] }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(null, null, _static_staticField_decorators, { kind: "field", name: "staticField", static: true, private: false, access: { has: obj => "staticField" in obj, get: obj => obj.staticField, set: (obj, value) => { obj.staticField = value; } }, metadata: _metadata }, _static_staticField_initializers, _static_staticField_extraInitializers);
+            __esDecorate(null, null, _field_decorators, { kind: "field", name: "field", static: false, private: false, access: { has: obj => "field" in obj, get: obj => obj.field, set: (obj, value) => { obj.field = value; } }, metadata: _metadata }, _field_initializers, _field_extraInitializers);
+            __esDecorate(null, null, _member_decorators, { kind: "field", name: "computed", static: false, private: false, access: { has: obj => "computed" in obj, get: obj => obj["computed"], set: (obj, value) => { obj["computed"] = value; } }, metadata: _metadata }, _member_initializers, _member_extraInitializers);
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            C = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        })(),
+        _classThis.staticField = (__runInitializers(_classThis, _staticExtraInitializers), __runInitializers(_classThis, _static_staticField_initializers, 4)),
+        _staticPrivateAccessor_accessor_storage = { value: (__runInitializers(_classThis, _static_staticField_extraInitializers), __runInitializers(_classThis, _static_private_staticPrivateAccessor_initializers, 7)) },
+        (() => {
+            __runInitializers(_classThis, _static_private_staticPrivateAccessor_extraInitializers);
+            __runInitializers(_classThis, _classExtraInitializers);
+        })(),
+        _classThis);
+    return C = _classThis;
+})();
+let D = (() => {
+    var _a;
+    let _classSuper = C;
+    let _field_decorators;
+    let _field_initializers = [];
+    let _field_extraInitializers = [];
+    return _a = class D extends _classSuper {
+        constructor() {
+            super();
+            this.field = __runInitializers(this, _field_initializers, 10);
+            __runInitializers(this, _field_extraInitializers);
+            console.log(this.field);
+        }
+    },
+        (() => {
+            var _b;
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create((_b = _classSuper[Symbol.metadata]) !== null && _b !== void 0 ? _b : null) : void 0;
+            _field_decorators = [dec];
+            __esDecorate(null, null, _field_decorators, { kind: "field", name: "field", static: false, private: false, access: { has: obj => "field" in obj, get: obj => obj.field, set: (obj, value) => { obj.field = value; } }, metadata: _metadata }, _field_initializers, _field_extraInitializers);
+            if (_metadata) Object.defineProperty(_a, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        })(),
+        _a;
+})();
+function addInitializer(value, context) {
+    context.addInitializer(function () {
+        console.log("initialized");
+    });
+    context.metadata[String(context.name)] = true;
+}
+export const Expression = (() => {
+    let _classDecorators = [addInitializer];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    let _static_x_decorators;
+    let _static_x_initializers = [];
+    let _static_x_extraInitializers = [];
+    var class_1 = (_classThis = class {
+    },
+        __setFunctionName(_classThis, "Expression"),
+        (() => {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            _static_x_decorators = [addInitializer];
+            __esDecorate(null, null, _static_x_decorators, { kind: "field", name: "x", static: true, private: false, access: { has: obj => "x" in obj, get: obj => obj.x, set: (obj, value) => { obj.x = value; } }, metadata: _metadata }, _static_x_initializers, _static_x_extraInitializers);
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            class_1 = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        })(),
+        _classThis.x = __runInitializers(_classThis, _static_x_initializers, 1),
+        (() => {
+            __runInitializers(_classThis, _static_x_extraInitializers);
+            __runInitializers(_classThis, _classExtraInitializers);
+        })(),
+        _classThis);
+    return class_1 = _classThis;
+})();
+export default (() => {
+    let _classDecorators = [dec];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    var default_1 = (_classThis = class {
+    },
+        __setFunctionName(_classThis, "default"),
+        (() => {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            default_1 = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        })(),
+        _classThis.y = _classThis,
+        __runInitializers(_classThis, _classExtraInitializers),
+        _classThis);
+    return default_1 = _classThis;
+})();
@@ -0,0 +1,448 @@
+//// [tests/cases/compiler/generatorsDownlevel.ts] ////
+
+//// [generatorsDownlevel.ts]
+function* simple() {
+    yield 1;
+    const x = yield 2;
+    return x;
+}
+
+function* empty() {
+}
+
+function* loops(n: number) {
+    for (let i = 0; i < n; i++) {
+        if (i % 2) continue;
+        yield i;
+    }
+    let j = 0;
+    while (j < n) {
+        yield j++;
+    }
+    do {
+        yield j--;
+    } while (j > 0);
+    for (var key in { a: 1, b: 2 }) {
+        yield key;
+    }
+    for (const item of [1, 2, 3]) {
+        yield item;
+    }
+}
+
+function* tryCatchFinally() {
+    try {
+        yield 1;
+    }
+    catch (e) {
+        yield e;
+    }
+    finally {
+        yield 3;
+    }
+}
+
+function* expressions(a: any, b: any) {
+    const arr = [a, yield, b];
+    const obj = { a, b: yield, c: 2 };
+    const call = a.method(b, yield);
+    const cond = a ? yield 1 : yield 2;
+    const logical = a && (yield 3);
+    a.prop += yield 4;
+    const created = new a.Ctor(b, yield);
+    return [arr, obj, call, cond, logical, created];
+}
+
+function* delegate() {
+    yield* simple();
+    return yield* [1, 2];
+}
+
+function* labeledAndSwitch(x: number) {
+    outer: for (let i = 0; i < x; i++) {
+        for (let j = 0; j < x; j++) {
+            if (j > i) continue outer;
+            if (j === 5) break outer;
+            yield [i, j];
+        }
+    }
+    switch (x) {
+        case 1:
+            yield "one";
+            break;
+        case yield "test":
+            return;
+        default:
+            yield "other";
+    }
+}
+
+function* hoisted() {
+    yield inner();
+    function inner() {
+        return 1;
+    }
+    class C {
+        method() {
+            return 2;
+        }
+    }
+    yield new C();
+}
+
+const obj = {
+    *method() {
+        yield this;
+    }
+};
+
+class Derived extends Object {
+    *method() {
+        yield super.toString();
+    }
+}
+
+async function asyncFunction() {
+    await Promise.resolve(1);
+    const x = await Promise.resolve(2);
+    return x;
+}
+
+
+//// [generatorsDownlevel.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+var __generator = (this && this.__generator) || function (thisArg, body) {
+    var _ = { label: 0, sent: function() { if (t[0] & 1) throw t[1]; return t[1]; }, trys: [], ops: [] }, f, y, t, g = Object.create((typeof Iterator === "function" ? Iterator : Object).prototype);
+    return g.next = verb(0), g["throw"] = verb(1), g["return"] = verb(2), typeof Symbol === "function" && (g[Symbol.iterator] = function() { return this; }), g;
+    function verb(n) { return function (v) { return step([n, v]); }; }
+    function step(op) {
+        if (f) throw new TypeError("Generator is already executing.");
+        while (g && (g = 0, op[0] && (_ = 0)), _) try {
+            if (f = 1, y && (t = op[0] & 2 ? y["return"] : op[0] ? y["throw"] || ((t = y["return"]) && t.call(y), 0) : y.next) && !(t = t.call(y, op[1])).done) return t;
+            if (y = 0, t) op = [op[0] & 2, t.value];
+            switch (op[0]) {
+                case 0: case 1: t = op; break;
+                case 4: _.label++; return { value: op[1], done: false };
+                case 5: _.label++; y = op[1]; op = [0]; continue;
+                case 7: op = _.ops.pop(); _.trys.pop(); continue;
+                default:
+                    if (!(t = _.trys, t = t.length > 0 && t[t.length - 1]) && (op[0] === 6 || op[0] === 2)) { _ = 0; continue; }
+                    if (op[0] === 3 && (!t || (op[1] > t[0] && op[1] < t[3]))) { _.label = op[1]; break; }
+                    if (op[0] === 6 && _.label < t[1]) { _.label = t[1]; t = op; break; }
+                    if (t && _.label < t[2]) { _.label = t[2]; _.ops.push(op); break; }
+                    if (t[2]) _.ops.pop();
+                    _.trys.pop(); continue;
+            }
+            op = body.call(thisArg, _);
+        } catch (e) { op = [6, e]; y = 0; } finally { f = t = 0; }
+        if (op[0] & 5) throw op[1]; return { value: op[0] ? op[1] : void 0, done: true };
+    }
+};
+var __values = (this && this.__values) || function(o) {
+    var s = typeof Symbol === "function" && Symbol.iterator, m = s && o[s], i = 0;
+    if (m) return m.call(o);
+    if (o && typeof o.length === "number") return {
+        next: function () {
+            if (o && i >= o.length) o = void 0;
+            return { value: o && o[i++], done: !o };
+        }
+    };
+    throw new TypeError(s ? "Object is not iterable." : "Symbol.iterator is not defined.");
+};
+function simple() {
+    var x;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0: return [4 /*yield*/, 1];
+            case 1:
+                _a.sent();
+                return [4 /*yield*/, 2];
+            case 2:
+                x = _a.sent();
+                return [2 /*return*/, x];
+        }
+    });
+}
+function empty() {
+    return __generator(this, function (_a) {
+        return [2 /*return*/];
+    });
+}
+function loops(n) {
+    var i, j, _a, _b, _c, _i, key, _d, _e, item;
+    return __generator(this, function (_f) {
+        switch (_f.label) {
+            case 0:
+                i = 0;
+                _f.label = 1;
+            case 1:
+                if (!(i < n)) return [3 /*break*/, 4];
+                if (i % 2)
+                    return [3 /*break*/, 3];
+                return [4 /*yield*/, i];
+            case 2:
+                _f.sent();
+                _f.label = 3;
+            case 3:
+                i++;
+                return [3 /*break*/, 1];
+            case 4:
+                j = 0;
+                _f.label = 5;
+            case 5:
+                if (!(j < n)) return [3 /*break*/, 7];
+                return [4 /*yield*/, j++];
+            case 6:
+                _f.sent();
+                return [3 /*break*/, 5];
+            case 7: return [4 /*yield*/, j--];
+            case 8:
+                _f.sent();
+                _f.label = 9;
+            case 9:
+                if (j > 0) return [3 /*break*/, 7];
+                _f.label = 10;
+            case 10:
+                _a = { a: 1, b: 2 };
+                _b = [];
+                for (_c in _a)
+                    _b.push(_c);
+                _i = 0;
+                _f.label = 11;
+            case 11:
+                if (!(_i < _b.length)) return [3 /*break*/, 14];
+                _c = _b[_i];
+                if (!(_c in _a)) return [3 /*break*/, 13];
+                key = _c;
+                return [4 /*yield*/, key];
+            case 12:
+                _f.sent();
+                _f.label = 13;
+            case 13:
+                _i++;
+                return [3 /*break*/, 11];
+            case 14:
+                _d = [1, 2, 3];
+                _e = 0;
+                _f.label = 15;
+            case 15:
+                if (!(_e < _d.length)) return [3 /*break*/, 18];
+                item = _d[_e];
+                return [4 /*yield*/, item];
+            case 16:
+                _f.sent();
+                _f.label = 17;
+            case 17:
+                _e++;
+                return [3 /*break*/, 15];
+            case 18: return [2 /*return*/];
+        }
+    });
+}
+function tryCatchFinally() {
+    var e;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0:
+                _a.trys.push([0, 2, 4, 6]);
+                return [4 /*yield*/, 1];
+            case 1:
+                _a.sent();
+                return [3 /*break*/, 6];
+            case 2:
+                e = _a.sent();
+                return [4 /*yield*/, e];
+            case 3:
+                _a.sent();
+                return [3 /*break*/, 6];
+            case 4: return [4 /*yield*/, 3];
+            case 5:
+                _a.sent();
+                return [7 /*endfinally*/];
+            case 6: return [2 /*return*/];
+        }
+    });
+}
+function expressions(a, b) {
+    var arr, _a, obj, _b, call, _c, _d, _e, cond, _f, logical, _g, _h, _j, created, _k, _l, _m;
+    return __generator(this, function (_o) {
+        switch (_o.label) {
+            case 0:
+                _a = [a];
+                return [4 /*yield*/];
+            case 1:
+                arr = _a.concat([_o.sent(), b]);
+                _b = { a };
+                return [4 /*yield*/];
+            case 2:
+                obj = (_b.b = _o.sent(), _b.c = 2, _b);
+                _d = (_c = a).method;
+                _e = [b];
+                return [4 /*yield*/];
+            case 3:
+                call = _d.apply(_c, _e.concat([_o.sent()]));
+                if (!a) return [3 /*break*/, 5];
+                return [4 /*yield*/, 1];
+            case 4:
+                _f = _o.sent();
+                return [3 /*break*/, 7];
+            case 5: return [4 /*yield*/, 2];
+            case 6:
+                _f = _o.sent();
+                _o.label = 7;
+            case 7:
+                cond = _f;
+                _g = a;
+                if (!_g) return [3 /*break*/, 9];
+                return [4 /*yield*/, 3];
+            case 8:
+                _g = (_o.sent());
+                _o.label = 9;
+            case 9:
+                logical = _g;
+                _h = a;
+                _j = _h.prop;
+                return [4 /*yield*/, 4];
+            case 10:
+                _h.prop = _j + _o.sent();
+                _l = (_k = a.Ctor).bind;
+                _m = [void 0, b];
+                return [4 /*yield*/];
+            case 11:
+                created = new (_l.apply(_k, _m.concat([_o.sent()])))();
+                return [2 /*return*/, [arr, obj, call, cond, logical, created]];
+        }
+    });
+}
+function delegate() {
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0: return [5 /*yield**/, __values(simple())];
+            case 1:
+                _a.sent();
+                return [5 /*yield**/, __values([1, 2])];
+            case 2: return [2 /*return*/, _a.sent()];
+        }
+    });
+}
+function labeledAndSwitch(x) {
+    var i, j, _a;
+    return __generator(this, function (_b) {
+        switch (_b.label) {
+            case 0:
+                i = 0;
+                _b.label = 1;
+            case 1:
+                if (!(i < x)) return [3 /*break*/, 6];
+                j = 0;
+                _b.label = 2;
+            case 2:
+                if (!(j < x)) return [3 /*break*/, 5];
+                if (j > i)
+                    return [3 /*break*/, 5];
+                if (j === 5)
+                    return [3 /*break*/, 6];
+                return [4 /*yield*/, [i, j]];
+            case 3:
+                _b.sent();
+                _b.label = 4;
+            case 4:
+                j++;
+                return [3 /*break*/, 2];
+            case 5:
+                i++;
+                return [3 /*break*/, 1];
+            case 6:
+                _a = x;
+                switch (_a) {
+                    case 1: return [3 /*break*/, 8];
+                }
+                return [4 /*yield*/, "test"];
+            case 7:
+                switch (_a) {
+                    case _b.sent(): return [3 /*break*/, 10];
+                }
+                return [3 /*break*/, 11];
+            case 8: return [4 /*yield*/, "one"];
+            case 9:
+                _b.sent();
+                return [3 /*break*/, 13];
+            case 10: return [2 /*return*/];
+            case 11: return [4 /*yield*/, "other"];
+            case 12:
+                _b.sent();
+                _b.label = 13;
+            case 13: return [2 /*return*/];
+        }
+    });
+}
+function hoisted() {
+    function inner() {
+        return 1;
+    }
+    var C;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0: return [4 /*yield*/, inner()];
+            case 1:
+                _a.sent();
+                C = class C {
+                    method() {
+                        return 2;
+                    }
+                };
+                return [4 /*yield*/, new C()];
+            case 2:
+                _a.sent();
+                return [2 /*return*/];
+        }
+    });
+}
+const obj = {
+    method() {
+        return __generator(this, function (_a) {
+            switch (_a.label) {
+                case 0: return [4 /*yield*/, this];
+                case 1:
+                    _a.sent();
+                    return [2 /*return*/];
+            }
+        });
+    }
+};
+class Derived extends Object {
+    method() {
+        return __generator(this, _a => {
+            switch (_a.label) {
+                case 0: return [4 /*yield*/, super.toString()];
+                case 1:
+                    _a.sent();
+                    return [2 /*return*/];
+            }
+        });
+    }
+}
+function asyncFunction() {
+    return __awaiter(this, void 0, void 0, function () {
+        var x;
+        return __generator(this, function (_a) {
+            switch (_a.label) {
+                case 0: return [4 /*yield*/, Promise.resolve(1)];
+                case 1:
+                    _a.sent();
+                    return [4 /*yield*/, Promise.resolve(2)];
+                case 2:
+                    x = _a.sent();
+                    return [2 /*return*/, x];
+            }
+        });
+    });
+}
@@ -0,0 +1,176 @@
+//// [tests/cases/compiler/generatorsDownlevelShadowedBindings.ts] ////
+
+//// [generatorsDownlevelShadowedBindings.ts]
+function* shadowedConst() {
+    const x = 1;
+    {
+        const x = yield 0;
+        console.log(x);
+    }
+    return x;
+}
+
+function* shadowedCatch() {
+    let e = "outer";
+    try {
+        yield 0;
+        throw new Error();
+    }
+    catch (e) {
+        yield e;
+    }
+    return e;
+}
+
+function* shadowedInSiblingBlocks() {
+    {
+        let x = yield 1;
+        const f = () => x;
+        yield f();
+    }
+    {
+        let { x } = { x: yield 2 };
+        yield { x };
+    }
+}
+
+const x = "global";
+function* shadowedOuterReference() {
+    if (x) {
+        const x = yield 0;
+        yield x;
+    }
+    return x;
+}
+
+const g = shadowedConst();
+g.next();
+console.log(g.next(42).value); // 1
+
+const h = shadowedCatch();
+h.next();
+h.next();
+console.log(h.next().value); // "outer"
+
+const o = shadowedOuterReference();
+o.next();
+o.next("inner");
+console.log(o.next().value); // "global"
+
+
+//// [generatorsDownlevelShadowedBindings.js]
+var __generator = (this && this.__generator) || function (thisArg, body) {
+    var _ = { label: 0, sent: function() { if (t[0] & 1) throw t[1]; return t[1]; }, trys: [], ops: [] }, f, y, t, g = Object.create((typeof Iterator === "function" ? Iterator : Object).prototype);
+    return g.next = verb(0), g["throw"] = verb(1), g["return"] = verb(2), typeof Symbol === "function" && (g[Symbol.iterator] = function() { return this; }), g;
+    function verb(n) { return function (v) { return step([n, v]); }; }
+    function step(op) {
+        if (f) throw new TypeError("Generator is already executing.");
+        while (g && (g = 0, op[0] && (_ = 0)), _) try {
+            if (f = 1, y && (t = op[0] & 2 ? y["return"] : op[0] ? y["throw"] || ((t = y["return"]) && t.call(y), 0) : y.next) && !(t = t.call(y, op[1])).done) return t;
+            if (y = 0, t) op = [op[0] & 2, t.value];
+            switch (op[0]) {
+                case 0: case 1: t = op; break;
+                case 4: _.label++; return { value: op[1], done: false };
+                case 5: _.label++; y = op[1]; op = [0]; continue;
+                case 7: op = _.ops.pop(); _.trys.pop(); continue;
+                default:
+                    if (!(t = _.trys, t = t.length > 0 && t[t.length - 1]) && (op[0] === 6 || op[0] === 2)) { _ = 0; continue; }
+                    if (op[0] === 3 && (!t || (op[1] > t[0] && op[1] < t[3]))) { _.label = op[1]; break; }
+                    if (op[0] === 6 && _.label < t[1]) { _.label = t[1]; t = op; break; }
+                    if (t && _.label < t[2]) { _.label = t[2]; _.ops.push(op); break; }
+                    if (t[2]) _.ops.pop();
+                    _.trys.pop(); continue;
+            }
+            op = body.call(thisArg, _);
+        } catch (e) { op = [6, e]; y = 0; } finally { f = t = 0; }
+        if (op[0] & 5) throw op[1]; return { value: op[0] ? op[1] : void 0, done: true };
+    }
+};
+function shadowedConst() {
+    var x, x_1;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0:
+                x = 1;
+                return [4 /*yield*/, 0];
+            case 1:
+                x_1 = _a.sent();
+                console.log(x_1);
+                return [2 /*return*/, x];
+        }
+    });
+}
+function shadowedCatch() {
+    var e, e_1;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0:
+                e = "outer";
+                _a.label = 1;
+            case 1:
+                _a.trys.push([1, 3, , 5]);
+                return [4 /*yield*/, 0];
+            case 2:
+                _a.sent();
+                throw new Error();
+            case 3:
+                e_1 = _a.sent();
+                return [4 /*yield*/, e_1];
+            case 4:
+                _a.sent();
+                return [3 /*break*/, 5];
+            case 5: return [2 /*return*/, e];
+        }
+    });
+}
+function shadowedInSiblingBlocks() {
+    var x_2, f, x_3, _a;
+    return __generator(this, function (_b) {
+        switch (_b.label) {
+            case 0: return [4 /*yield*/, 1];
+            case 1:
+                x_2 = _b.sent();
+                f = () => x_2;
+                return [4 /*yield*/, f()];
+            case 2:
+                _b.sent();
+                _a = {};
+                return [4 /*yield*/, 2];
+            case 3:
+                ({ x: x_3 } = (_a.x = _b.sent(), _a));
+                return [4 /*yield*/, { x: x_3 }];
+            case 4:
+                _b.sent();
+                return [2 /*return*/];
+        }
+    });
+}
+const x = "global";
+function shadowedOuterReference() {
+    var x_4;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0:
+                if (!x) return [3 /*break*/, 3];
+                return [4 /*yield*/, 0];
+            case 1:
+                x_4 = _a.sent();
+                return [4 /*yield*/, x_4];
+            case 2:
+                _a.sent();
+                _a.label = 3;
+            case 3: return [2 /*return*/, x];
+        }
+    });
+}
+const g = shadowedConst();
+g.next();
+console.log(g.next(42).value); // 1
+const h = shadowedCatch();
+h.next();
+h.next();
+console.log(h.next().value); // "outer"
+const o = shadowedOuterReference();
+o.next();
+o.next("inner");
+console.log(o.next().value); // "global"
@@ -0,0 +1,193 @@
+//// [tests/cases/compiler/isolatedDeclarationErrorsInference.ts] ////
+
+//// [isolatedDeclarationErrorsInference.ts]
+const value = 1;
+const key = "k" + value;
+
+export function noReturn() {}
+export function singleReturn() { return 0; }
+export const arrow = () => "S";
+export function multipleReturns(x: boolean) { if (x) return 1; return 2; }
+export async function asyncReturn() { return 1; }
+export function parameterDefaults(cb = function () {}, n = 1, v = value) {}
+
+export const fromCall = Math.random();
+export const nothing = undefined;
+export const empty = null;
+export let template = `a${value}`;
+export const constTemplate = `a${value}`;
+export let mutableArray = [1, 2, 3];
+export const constArray = [1, "a"] as const;
+export const spreadArray = [1, ...[2]] as const;
+export const asserted = value as number;
+
+export const obj = {
+    value,
+    ...{ a: 1 },
+    [key]: 1,
+    method() { return 1; },
+    badMethod() { return value; },
+    get x() { return 1; },
+    prop: value,
+};
+
+export class C {
+    field = 1;
+    readonly readonlyTemplate = `x${value}`;
+    other = value;
+    private hidden = value;
+    [key] = 1;
+    get accessor() { return this.field; }
+    set accessor(v) {}
+    set setterOnly(v) {}
+    method() {}
+}
+
+export const Cls = class {};
+export const { a, b } = { a: 1, b: 2 };
+
+export enum E {
+    A = value,
+    B = 2,
+}
+
+export function expando(): void {}
+expando.prop = 1;
+expando.prop = 2;
+expando.other = "";
+
+export default value + 1;
+
+function mixin<T extends new (...args: any[]) => object>(base: T) { return base; }
+export class Base {}
+export class Derived extends mixin(Base) {}
+export const classes = [class {}] as const;
+
+
+//// [isolatedDeclarationErrorsInference.js]
+const value = 1;
+const key = "k" + value;
+export function noReturn() { }
+export function singleReturn() { return 0; }
+export const arrow = () => "S";
+export function multipleReturns(x) { if (x)
+    return 1; return 2; }
+export async function asyncReturn() { return 1; }
+export function parameterDefaults(cb = function () { }, n = 1, v = value) { }
+export const fromCall = Math.random();
+export const nothing = undefined;
+export const empty = null;
+export let template = `a${value}`;
+export const constTemplate = `a${value}`;
+export let mutableArray = [1, 2, 3];
+export const constArray = [1, "a"];
+export const spreadArray = [1, ...[2]];
+export const asserted = value;
+export const obj = {
+    value,
+    ...{ a: 1 },
+    [key]: 1,
+    method() { return 1; },
+    badMethod() { return value; },
+    get x() { return 1; },
+    prop: value,
+};
+export class C {
+    field = 1;
+    readonlyTemplate = `x${value}`;
+    other = value;
+    hidden = value;
+    [key] = 1;
+    get accessor() { return this.field; }
+    set accessor(v) { }
+    set setterOnly(v) { }
+    method() { }
+}
+export const Cls = class {
+};
+export const { a, b } = { a: 1, b: 2 };
+export { E };
+var E;
+(function (E) {
+    E["A"] = value;
+    if (typeof E.A !== "string") E[E.A] = "A";
+    E[E["B"] = 2] = "B";
+})(E || (E = {}));
+export function expando() { }
+expando.prop = 1;
+expando.prop = 2;
+expando.other = "";
+export default value + 1;
+function mixin(base) { return base; }
+export class Base {
+}
+export class Derived extends mixin(Base) {
+}
+export const classes = [class {
+    }];
+
+
+//// [isolatedDeclarationErrorsInference.d.ts]
+declare const key: string;
+export declare function noReturn(): void;
+export declare function singleReturn(): number;
+export declare const arrow: () => string;
+export declare function multipleReturns(x: boolean): 1 | 2;
+export declare function asyncReturn(): Promise<number>;
+export declare function parameterDefaults(cb?: () => void, n?: number, v?: number): void;
+export declare const fromCall: number;
+export declare const nothing: undefined;
+export declare const empty: null;
+export declare let template: string;
+export declare const constTemplate = "a1";
+export declare let mutableArray: number[];
+export declare const constArray: readonly [1, "a"];
+export declare const spreadArray: readonly [1, 2];
+export declare const asserted: number;
+export declare const obj: {
+    value: number;
+    a: number;
+    method(): number;
+    badMethod(): number;
+    x: number;
+    prop: number;
+};
+export declare class C {
+    [key]: number;
+    field: number;
+    readonly readonlyTemplate: string;
+    other: number;
+    private hidden;
+    get accessor(): number;
+    set accessor(v: number);
+    set setterOnly(v: any);
+    method(): void;
+}
+export declare const Cls: {
+    new (): {};
+};
+export declare const a: number, b: number;
+export declare enum E {
+    A = 1,
+    B = 2
+}
+export declare function expando(): void;
+export declare namespace expando {
+    var prop: number;
+}
+export declare namespace expando {
+    var prop: number;
+}
+export declare namespace expando {
+    var other: string;
+}
+declare const _default: number;
+export default _default;
+export declare class Base {
+}
+declare const Derived_base: typeof Base;
+export declare class Derived extends Derived_base {
+}
+export declare const classes: readonly [{
+    new (): {};
+}];
@@ -0,0 +1,20 @@
+//// [tests/cases/compiler/jsdocTypeAssertionParenthesesPreserved.ts] ////
+
+//// [jsdocTypeAssertionParenthesesPreserved.js]
+/** @typedef {string & {__nominal: true}} MyNominal */
+const variable = {
+    key: /** @type {MyNominal} */('value'),
+};
+const key = /** @type {any} */ (variable).key;
+const satisfied = /** @satisfies {{ key: string }} */ ({ key: 'value' });
+
+
+//// [jsdocTypeAssertionParenthesesPreserved.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+/** @typedef {string & {__nominal: true}} MyNominal */
+const variable = {
+    key: /** @type {MyNominal} */ ('value'),
+};
+const key = /** @type {any} */ (variable).key;
+const satisfied = /** @satisfies {{ key: string }} */ ({ key: 'value' });
@@ -0,0 +1,205 @@
+//// [tests/cases/compiler/legacyDecoratorsMetadata.ts] ////
+
+//// [services.ts]
+export class Logger {
+    log(message: string) {}
+}
+
+export class Clock {
+    now() { return Date.now(); }
+}
+
+export interface Options {
+    verbose: boolean;
+}
+
+//// [main.ts]
+import { Clock, Logger, Options } from "./services";
+
+declare function Injectable(): ClassDecorator;
+declare function Inject(token: string): ParameterDecorator;
+declare const Input: PropertyDecorator;
+declare const Method: MethodDecorator;
+declare const Accessor: MethodDecorator;
+
+declare const key: string;
+
+@Injectable()
+export class Service {
+    static instance?: Service;
+
+    @Input
+    name: string | undefined;
+
+    @Input
+    count: number | null = 0;
+
+    @Input
+    options: Options | undefined;
+
+    @Input
+    started: Date = new Date();
+
+    @Input
+    [key]: bigint;
+
+    constructor(private logger: Logger, clock: Clock, @Inject("config") config: Options, ...rest: string[]) {}
+
+    @Method
+    run(@Inject("arg") value: number, flag?: boolean): Promise<void> {
+        return Promise.resolve();
+    }
+
+    @Method
+    async runAsync() {}
+
+    @Accessor
+    get value(): string { return ""; }
+    set value(v: string) {}
+
+    @Method
+    static create(): Service {
+        return Service.instance ??= new Service(new Logger(), undefined!, { verbose: false });
+    }
+}
+
+export class Plain {
+    @Input
+    map: Map<string, Service> | undefined;
+
+    @Method
+    method(@Inject("value") value: "a" | "b", callback: () => void) {}
+}
+
+@Injectable()
+export default class {
+    constructor(service: Service) {}
+}
+
+
+//// [services.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.Clock = exports.Logger = void 0;
+class Logger {
+    log(message) { }
+}
+exports.Logger = Logger;
+class Clock {
+    now() { return Date.now(); }
+}
+exports.Clock = Clock;
+//// [main.js]
+"use strict";
+var __decorate = (this && this.__decorate) || function (decorators, target, key, desc) {
+    var c = arguments.length, r = c < 3 ? target : desc === null ? desc = Object.getOwnPropertyDescriptor(target, key) : desc, d;
+    if (typeof Reflect === "object" && typeof Reflect.decorate === "function") r = Reflect.decorate(decorators, target, key, desc);
+    else for (var i = decorators.length - 1; i >= 0; i--) if (d = decorators[i]) r = (c < 3 ? d(r) : c > 3 ? d(target, key, r) : d(target, key)) || r;
+    return c > 3 && r && Object.defineProperty(target, key, r), r;
+};
+var __metadata = (this && this.__metadata) || function (k, v) {
+    if (typeof Reflect === "object" && typeof Reflect.metadata === "function") return Reflect.metadata(k, v);
+};
+var __param = (this && this.__param) || function (paramIndex, decorator) {
+    return function (target, key) { decorator(target, key, paramIndex); }
+};
+var Service_1, _a;
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.Plain = exports.Service = void 0;
+const services_1 = require("./services");
+let Service = class Service {
+    static { Service_1 = this; }
+    logger;
+    static instance;
+    name;
+    count = 0;
+    options;
+    started = new Date();
+    [_a = key];
+    constructor(logger, clock, config, ...rest) {
+        this.logger = logger;
+    }
+    run(value, flag) {
+        return Promise.resolve();
+    }
+    async runAsync() { }
+    get value() { return ""; }
+    set value(v) { }
+    static create() {
+        return Service_1.instance ??= new Service_1(new services_1.Logger(), undefined, { verbose: false });
+    }
+};
+exports.Service = Service;
+__decorate([
+    Input,
+    __metadata("design:type", Object)
+], Service.prototype, "name", void 0);
+__decorate([
+    Input,
+    __metadata("design:type", Object)
+], Service.prototype, "count", void 0);
+__decorate([
+    Input,
+    __metadata("design:type", Object)
+], Service.prototype, "options", void 0);
+__decorate([
+    Input,
+    __metadata("design:type", Date)
+], Service.prototype, "started", void 0);
+__decorate([
+    Input,
+    __metadata("design:type", BigInt)
+], Service.prototype, _a, void 0);
+__decorate([
+    Method,
+    __param(0, Inject("arg")),
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", [Number, Boolean]),
+    __metadata("design:returntype", Promise)
+], Service.prototype, "run", null);
+__decorate([
+    Method,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", []),
+    __metadata("design:returntype", Promise)
+], Service.prototype, "runAsync", null);
+__decorate([
+    Accessor,
+    __metadata("design:type", String),
+    __metadata("design:paramtypes", [String])
+], Service.prototype, "value", null);
+__decorate([
+    Method,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", []),
+    __metadata("design:returntype", Service)
+], Service, "create", null);
+exports.Service = Service = Service_1 = __decorate([
+    Injectable(),
+    __param(2, Inject("config")),
+    __metadata("design:paramtypes", [services_1.Logger, services_1.Clock, Object, String])
+], Service);
+class Plain {
+    map;
+    method(value, callback) { }
+}
+exports.Plain = Plain;
+__decorate([
+    Input,
+    __metadata("design:type", Object)
+], Plain.prototype, "map", void 0);
+__decorate([
+    Method,
+    __param(0, Inject("value")),
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", [String, Function]),
+    __metadata("design:returntype", void 0)
+], Plain.prototype, "method", null);
+let default_1 = class {
+    constructor(service) { }
+};
+default_1 = __decorate([
+    Injectable(),
+    __metadata("design:paramtypes", [Service])
+], default_1);
+exports.default = default_1;
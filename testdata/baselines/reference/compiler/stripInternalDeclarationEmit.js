@@ -0,0 +1,161 @@
+//// [tests/cases/compiler/stripInternalDeclarationEmit.ts] ////
+
+//// [a.ts]
+/** Public class */
+export class C {
+    /** Public method */
+    method(): void {}
+    /** @internal */
+    internalMethod(): void {}
+    /** @internal */
+    internalProp = 1;
+    constructor(
+        /** Public parameter property */
+        public x: number,
+        /** @internal */
+        public y: number,
+    ) {}
+}
+
+/** @internal */
+export class InternalClass {}
+
+/** Public interface */
+export interface I {
+    /** Public member */
+    a: number;
+    /** @internal */
+    b: string;
+}
+
+/** Public enum */
+export enum E {
+    /** Public member */
+    A,
+    /** @internal */
+    B,
+    C,
+}
+
+/** Public namespace */
+export namespace N {
+    /** Public value */
+    export const visible = 1;
+    /** @internal */
+    export const hidden = 2;
+    /** @internal */
+    export namespace Inner {
+        export const z = 3;
+    }
+}
+
+/** Public function */
+export function f(a: number): number;
+/** @internal */
+export function f(a: string): string;
+export function f(a: any) { return a; }
+
+/** @internal */
+export { C as InternalAlias };
+export { C as PublicAlias };
+
+//// [b.ts]
+/** The default export */
+export default 1 + 1;
+
+
+//// [a.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.PublicAlias = exports.InternalAlias = exports.N = exports.E = exports.InternalClass = exports.C = void 0;
+exports.f = f;
+/** Public class */
+class C {
+    /** Public method */
+    method() { }
+    /** @internal */
+    internalMethod() { }
+    constructor(
+    /** Public parameter property */
+    x, 
+    /** @internal */
+    y) {
+        this.x = x;
+        this.y = y;
+        /** @internal */
+        this.internalProp = 1;
+    }
+}
+exports.C = C;
+exports.InternalAlias = C;
+exports.PublicAlias = C;
+/** @internal */
+class InternalClass {
+}
+exports.InternalClass = InternalClass;
+/** Public enum */
+var E;
+(function (E) {
+    /** Public member */
+    E[E["A"] = 0] = "A";
+    /** @internal */
+    E[E["B"] = 1] = "B";
+    E[E["C"] = 2] = "C";
+})(E || (exports.E = E = {}));
+/** Public namespace */
+var N;
+(function (N) {
+    /** Public value */
+    N.visible = 1;
+    /** @internal */
+    N.hidden = 2;
+    /** @internal */
+    let Inner;
+    (function (Inner) {
+        Inner.z = 3;
+    })(Inner = N.Inner || (N.Inner = {}));
+})(N || (exports.N = N = {}));
+function f(a) { return a; }
+//// [b.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+/** The default export */
+exports.default = 1 + 1;
+
+
+//// [a.d.ts]
+/** Public class */
+export declare class C {
+    /** Public parameter property */
+    x: number;
+    /** Public method */
+    method(): void;
+    constructor(
+    /** Public parameter property */
+    x: number, 
+    /** @internal */
+    y: number);
+}
+/** Public interface */
+export interface I {
+    /** Public member */
+    a: number;
+}
+/** Public enum */
+export declare enum E {
+    /** Public member */
+    A = 0,
+    C = 2
+}
+/** Public namespace */
+export declare namespace N {
+    /** Public value */
+    const visible = 1;
+}
+/** Public function */
+export declare function f(a: number): number;
+export { C as PublicAlias };
+//// [b.d.ts]
+/** The default export */
+declare const _default: number;
+export default _default;
@@ -22,11 +22,11 @@ c.position;
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.C = void 0;
 class C {
-    name = "CompileDiagnostic";
     /**
      * @param {[number, number] | undefined} position
      */
     constructor(position) {
+        this.name = "CompileDiagnostic";
         if (position) {
             this.position = position;
         }
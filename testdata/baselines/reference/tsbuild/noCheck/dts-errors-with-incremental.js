@@ -70,7 +70,9 @@ export declare const a: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.a = a;
 
@@ -482,7 +484,9 @@ export declare const a: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.a = a;
 
@@ -1079,7 +1083,9 @@ export declare const a: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.a = a;
 
@@ -70,7 +70,9 @@ export declare const a: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.a = a;
 
@@ -333,7 +335,9 @@ export declare const a: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.a = a;
 
@@ -682,7 +686,9 @@ export declare const a: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.a = a;
 
@@ -70,7 +70,9 @@ export declare class classC {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop = 1;
+    constructor() {
+        this.prop = 1;
+    }
 }
 exports.classC = classC;
 
@@ -95,7 +97,9 @@ Object.defineProperty(exports, "__esModule", { value: true });
 exports.indirectClass = void 0;
 const class_1 = require("./class");
 class indirectClass {
-    classC = new class_1.classC();
+    constructor() {
+        this.classC = new class_1.classC();
+    }
 }
 exports.indirectClass = indirectClass;
 
@@ -859,7 +863,9 @@ export declare class classC {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop1 = 1;
+    constructor() {
+        this.prop1 = 1;
+    }
 }
 exports.classC = classC;
 
@@ -1472,7 +1478,9 @@ export declare class classC {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop = 1;
+    constructor() {
+        this.prop = 1;
+    }
 }
 exports.classC = classC;
 
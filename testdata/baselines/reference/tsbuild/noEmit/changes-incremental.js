@@ -65,7 +65,9 @@ declare const console: { log(msg: any): void; };
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop = 1;
+    constructor() {
+        this.prop = 1;
+    }
 }
 exports.classC = classC;
 
@@ -81,7 +83,9 @@ Object.defineProperty(exports, "__esModule", { value: true });
 exports.indirectClass = void 0;
 const class_1 = require("./class");
 class indirectClass {
-    classC = new class_1.classC();
+    constructor() {
+        this.classC = new class_1.classC();
+    }
 }
 exports.indirectClass = indirectClass;
 
@@ -747,7 +751,9 @@ Errors  Files
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop1 = 1;
+    constructor() {
+        this.prop1 = 1;
+    }
 }
 exports.classC = classC;
 
@@ -1277,7 +1283,9 @@ Output::
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop = 1;
+    constructor() {
+        this.prop = 1;
+    }
 }
 exports.classC = classC;
 
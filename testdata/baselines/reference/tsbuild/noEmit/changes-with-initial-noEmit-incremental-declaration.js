@@ -255,7 +255,9 @@ export declare class classC {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop = 1;
+    constructor() {
+        this.prop = 1;
+    }
 }
 exports.classC = classC;
 
@@ -280,7 +282,9 @@ Object.defineProperty(exports, "__esModule", { value: true });
 exports.indirectClass = void 0;
 const class_1 = require("./class");
 class indirectClass {
-    classC = new class_1.classC();
+    constructor() {
+        this.classC = new class_1.classC();
+    }
 }
 exports.indirectClass = indirectClass;
 
@@ -509,7 +513,9 @@ export declare class classC {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop1 = 1;
+    constructor() {
+        this.prop1 = 1;
+    }
 }
 exports.classC = classC;
 
@@ -925,7 +931,9 @@ export declare class classC {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.classC = void 0;
 class classC {
-    prop = 1;
+    constructor() {
+        this.prop = 1;
+    }
 }
 exports.classC = classC;
 
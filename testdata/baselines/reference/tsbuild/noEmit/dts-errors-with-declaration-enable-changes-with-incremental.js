@@ -385,7 +385,9 @@ declare const a: {
 
 //// [/home/src/projects/project/a.js] *new* 
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 
 //// [/home/src/projects/project/tsconfig.tsbuildinfo] *modified* 
@@ -676,7 +676,9 @@ export declare const a: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.a = a;
 
@@ -701,7 +703,9 @@ export declare const c: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.c = void 0;
 const c = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.c = c;
 
@@ -717,7 +721,9 @@ export declare const d: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.d = void 0;
 const d = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.d = d;
 
@@ -506,7 +506,9 @@ declare const a: {
 
 //// [/home/src/projects/project/a.js] *modified* 
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 
 //// [/home/src/projects/project/tsconfig.tsbuildinfo] *modified* 
@@ -211,7 +211,9 @@ Output::
 
 //// [/home/src/projects/project/a.js] *modified* 
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 
 //// [/home/src/projects/project/tsconfig.tsbuildinfo] *rewrite with same content*
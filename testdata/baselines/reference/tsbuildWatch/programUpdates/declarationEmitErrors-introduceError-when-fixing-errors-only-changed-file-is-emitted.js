@@ -183,8 +183,10 @@ export declare var myClassWithError: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.myClassWithError = void 0;
 var myClassWithError = class {
+    constructor() {
+        this.p = 12;
+    }
     tags() { }
-    p = 12;
 };
 exports.myClassWithError = myClassWithError;
 
@@ -0,0 +1,42 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/index.ts] *new* 
+const a: number = "hello";
+missing();
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{}
+
+tsgo --locale pt-BR --pretty false
+ExitStatus:: DiagnosticsPresent_OutputsGenerated
+Output::
+index.ts(1,7): error TS2322: O tipo 'string' não pode ser atribuído ao tipo 'number'.
+index.ts(2,1): error TS2304: Não é possível localizar o nome 'missing'.
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+//// [/home/src/workspaces/project/index.js] *new* 
+const a = "hello";
+missing();
+
+
@@ -0,0 +1,11 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{ "compilerOptions": { "target": "es2020" }, "include": ["src"] }
+
+tsgo --showConfig
+ExitStatus:: DiagnosticsPresent_OutputsSkipped
+Output::
+[91merror[0m[90m TS18003: [0mNo inputs were found in config file '/home/src/workspaces/project/tsconfig.json'. Specified 'include' paths were '["src"]' and 'exclude' paths were '[]'.
+
@@ -0,0 +1,25 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/src/index.ts] *new* 
+export const a = 1;
+//// [/home/src/workspaces/project/tsconfig.base.json] *new* 
+{ "compileOnSave": true, "compilerOptions": { "strict": true } }
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{ "extends": "./tsconfig.base.json", "include": ["src"] }
+
+tsgo --showConfig
+ExitStatus:: Success
+Output::
+{
+    "compilerOptions": {
+        "strict": true
+    },
+    "files": [
+        "./src/index.ts"
+    ],
+    "include": [
+        "src"
+    ],
+    "compileOnSave": true
+}
@@ -0,0 +1,20 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/src/index.ts] *new* 
+export const a = 1;
+
+tsgo --showConfig --target esnext --moduleResolution bundler --module preserve --outDir out src/index.ts
+ExitStatus:: Success
+Output::
+{
+    "compilerOptions": {
+        "target": "esnext",
+        "module": "preserve",
+        "outDir": "./out",
+        "moduleResolution": "bundler"
+    },
+    "files": [
+        "./src/index.ts"
+    ]
+}
@@ -0,0 +1,87 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/shared/index.ts] *new* 
+export const d = 4;
+//// [/home/src/workspaces/project/shared/tsconfig.json] *new* 
+{ "compilerOptions": { "composite": true } }
+//// [/home/src/workspaces/project/src/app.test.ts] *new* 
+export const c = 3;
+//// [/home/src/workspaces/project/src/app.tsx] *new* 
+export const b = 2;
+//// [/home/src/workspaces/project/src/index.ts] *new* 
+export const a = 1;
+//// [/home/src/workspaces/project/tsconfig.base.json] *new* 
+{
+    "compilerOptions": {
+        "target": "es2020",
+        "module": "nodenext",
+        "lib": ["es2020", "dom"],
+        "strict": true,
+        "typeRoots": ["./typings"]
+    },
+    "watchOptions": {
+        "watchFile": "fixedPollingInterval",
+        "excludeDirectories": ["./node_modules"]
+    }
+}
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{
+    "extends": "./tsconfig.base.json",
+    "compileOnSave": true,
+    "compilerOptions": {
+        "jsx": "react-jsx",
+        "outDir": "./dist",
+        "rootDir": "./src",
+        "noUnusedLocals": false
+    },
+    "include": ["src"],
+    "exclude": ["src/**/*.test.ts"],
+    "references": [{ "path": "./shared" }]
+}
+
+tsgo --showConfig --listFiles --declaration
+ExitStatus:: Success
+Output::
+{
+    "compilerOptions": {
+        "listFiles": true,
+        "declaration": true,
+        "target": "es2020",
+        "module": "nodenext",
+        "lib": [
+            "es2020",
+            "dom"
+        ],
+        "jsx": "react-jsx",
+        "outDir": "./dist",
+        "rootDir": "./src",
+        "strict": true,
+        "noUnusedLocals": false,
+        "typeRoots": [
+            "./typings"
+        ]
+    },
+    "watchOptions": {
+        "watchFile": "fixedpollinginterval",
+        "excludeDirectories": [
+            "./node_modules"
+        ]
+    },
+    "references": [
+        {
+            "path": "./shared"
+        }
+    ],
+    "files": [
+        "./src/app.tsx",
+        "./src/index.ts"
+    ],
+    "include": [
+        "src"
+    ],
+    "exclude": [
+        "src/**/*.test.ts"
+    ],
+    "compileOnSave": true
+}
@@ -77,7 +77,6 @@ import { PartialType } from './utils';
 class Common {
 }
 export class Sub extends PartialType(Common) {
-    id;
 }
 
 //// [D:/Work/pkg1/dist/utils/index.d.ts] *new* 
@@ -43,22 +43,36 @@ tsgo --showConfig
 ExitStatus:: Success
 Output::
 {
-    "declaration": true,
-    "declarationDir": "/home/src/projects/myproject/decls",
-    "outDir": "/home/src/projects/myproject/outDir",
-    "paths": {
-        "@myscope/*": [
-            "/home/src/projects/myproject/types/*"
+    "compilerOptions": {
+        "traceResolution": true,
+        "declaration": true,
+        "outDir": "./outDir",
+        "paths": {
+            "@myscope/*": [
+                "./types/*"
+            ]
+        },
+        "typeRoots": [
+            "../configs/first/root1",
+            "./root2",
+            "../configs/first/root3"
+        ],
+        "types": [],
+        "declarationDir": "./decls"
+    },
+    "watchOptions": {
+        "excludeFiles": [
+            "./main.ts"
         ]
     },
-    "traceResolution": true,
-    "typeRoots": [
-        "/home/src/projects/configs/first/root1",
-        "/home/src/projects/myproject/root2",
-        "/home/src/projects/configs/first/root3"
+    "files": [
+        "./main.ts"
+    ],
+    "include": [
+        "./src"
     ],
-    "types": [],
-    "configFilePath": "/home/src/projects/myproject/tsconfig.json",
-    "pathsBasePath": "/home/src/projects/configs/second",
-    "showConfig": true
+    "exclude": [
+        "./outDir",
+        "./decls"
+    ]
 }
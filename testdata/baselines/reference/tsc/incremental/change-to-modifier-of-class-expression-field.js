@@ -53,7 +53,9 @@ Output::
 //// [/home/src/workspaces/project/MessageablePerson.js] *new* 
 const Messageable = () => {
     return class MessageableClass {
-        message = 'hello';
+        constructor() {
+            this.message = 'hello';
+        }
     };
 };
 const wrapper = () => Messageable();
@@ -102,7 +102,9 @@ ExitStatus:: Success
 Output::
 //// [/home/src/projects/project/a.js] *modified* 
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 
 
@@ -40,6 +40,14 @@ interface Symbol {
 }
 declare const console: { log(msg: any): void; };
 
+Watches::
+WatchOptions:: {"watchInterval":1000}
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/first.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
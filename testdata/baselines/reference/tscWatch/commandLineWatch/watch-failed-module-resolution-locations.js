@@ -0,0 +1,85 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/index.ts] *new* 
+import { pkg } from "pkg";
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{ "compilerOptions": { "noEmit": true }, "files": ["index.ts"] }
+
+tsgo --watch
+ExitStatus:: Success
+Output::
+build starting at HH:MM:SS AM
+[96mindex.ts[0m:[93m1[0m:[93m21[0m - [91merror[0m[90m TS2307: [0mCannot find module 'pkg' or its corresponding type declarations.
+
+[7m1[0m import { pkg } from "pkg";
+[7m [0m [91m                    ~~~~~[0m
+
+
+Found 1 error in index.ts[90m:1[0m
+
+build finished in d.ddds
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/index.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project
+tsconfig.json::
+SemanticDiagnostics::
+*refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
+*refresh*    /home/src/workspaces/project/index.ts
+Signatures::
+
+
+Edit [0]:: add package
+//// [/home/src/workspaces/project/node_modules/pkg/index.d.ts] *new* 
+export const pkg: string;
+
+
+Output::
+build starting at HH:MM:SS AM
+build finished in d.ddds
+
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/index.ts
+/home/src/workspaces/project/node_modules/pkg/index.d.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project
+/home/src/workspaces/project/node_modules
+/home/src/workspaces/project/node_modules/pkg
+tsconfig.json::
+SemanticDiagnostics::
+*refresh*    /home/src/workspaces/project/node_modules/pkg/index.d.ts
+*refresh*    /home/src/workspaces/project/index.ts
+Signatures::
+(used version)   /home/src/workspaces/project/node_modules/pkg/index.d.ts
+(computed .d.ts) /home/src/workspaces/project/index.ts
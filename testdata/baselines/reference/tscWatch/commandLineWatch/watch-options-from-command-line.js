@@ -0,0 +1,82 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/index.ts] *new* 
+
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{
+    "watchOptions": { "watchDirectory": "fixedPollingInterval" }
+}
+
+tsgo --watch --watchFile fixedPollingInterval
+ExitStatus:: Success
+Output::
+build starting at HH:MM:SS AM
+build finished in d.ddds
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+//// [/home/src/workspaces/project/index.js] *new* 
+
+
+Watches::
+WatchOptions:: {"watchFile":"fixedpollinginterval","watchDirectory":"fixedpollinginterval"}
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/index.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
+tsconfig.json::
+SemanticDiagnostics::
+*refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
+*refresh*    /home/src/workspaces/project/index.ts
+Signatures::
+
+
+Edit [0]:: change config
+//// [/home/src/workspaces/project/tsconfig.json] *modified* 
+{
+    "compilerOptions": { "strict": true },
+    "watchOptions": { "watchDirectory": "fixedPollingInterval" }
+}
+
+
+Output::
+build starting at HH:MM:SS AM
+build finished in d.ddds
+//// [/home/src/workspaces/project/index.js] *rewrite with same content*
+
+Watches::
+WatchOptions:: {"watchFile":"fixedpollinginterval","watchDirectory":"fixedpollinginterval"}
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/index.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
+tsconfig.json::
+SemanticDiagnostics::
+*refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
+*refresh*    /home/src/workspaces/project/index.ts
+Signatures::
@@ -0,0 +1,831 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/index.ts] *new* 
+export const a: number = 1;
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{}
+
+tsgo --watch --generateTrace trace --singleThreaded
+ExitStatus:: Success
+Output::
+build starting at HH:MM:SS AM
+build finished in d.ddds
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+//// [/home/src/workspaces/project/index.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.a = void 0;
+exports.a = 1;
+
+//// [/home/src/workspaces/project/trace/legend.json] *new* 
+[
+{"configFilePath":"/home/src/workspaces/project/tsconfig.json","tracePath":"/home/src/workspaces/project/trace/trace.1.json","typesPath":"/home/src/workspaces/project/trace/types.1.json"}
+]
+
+//// [/home/src/workspaces/project/trace/trace.1.json] *new* 
+[
+{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"process_name","args":{"name":"tsc"}},
+{"pid":1,"tid":1,"ph":"M","cat":"disabled-by-default-devtools.timeline","ts":0,"name":"TracingStartedInBrowser","args":{"data":{"sessionId":"-1"}}},
+{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Main"}},
+{"pid":1,"tid":1,"ph":"B","cat":"program","ts":1000000,"name":"createProgram","args":{"configFilePath":"/home/src/workspaces/project/tsconfig.json","rootDir":"/home/src/workspaces/project"}},
+{"pid":1,"tid":2,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Worker"}},
+{"pid":1,"tid":2,"ph":"B","cat":"parse","ts":2000000,"name":"createSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"parse","ts":3000000,"name":"createSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"B","cat":"parse","ts":4000000,"name":"createSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"parse","ts":5000000,"name":"createSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":1,"ph":"E","cat":"program","ts":6000000,"name":"createProgram","args":{"configFilePath":"/home/src/workspaces/project/tsconfig.json","rootDir":"/home/src/workspaces/project"}},
+{"pid":1,"tid":2,"ph":"B","cat":"bind","ts":7000000,"name":"bindSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"bind","ts":8000000,"name":"bindSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"B","cat":"bind","ts":9000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"bind","ts":10000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":3,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker"}},
+{"pid":1,"tid":4,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker"}},
+{"pid":1,"tid":5,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker"}},
+{"pid":1,"tid":2,"ph":"B","cat":"bind","ts":11000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"bind","ts":12000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":6,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker"}},
+{"pid":1,"tid":3,"ph":"B","cat":"check","ts":18000000,"name":"checkSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":3,"ph":"X","cat":"check","ts":19000000,"name":"checkVariableDeclaration","dur":1000000,"args":{"end":537,"kind":"VariableDeclaration","path":"/home/src/tslibs/TS/Lib/lib.d.ts","pos":511}},
+{"pid":1,"tid":3,"ph":"X","cat":"check","ts":22000000,"name":"checkExpression","dur":1000000,"args":{"end":578,"kind":"Identifier","path":"/home/src/tslibs/TS/Lib/lib.d.ts","pos":572}},
+{"pid":1,"tid":3,"ph":"X","cat":"check","ts":21000000,"name":"checkExpression","dur":3000000,"args":{"end":590,"kind":"PropertyAccessExpression","path":"/home/src/tslibs/TS/Lib/lib.d.ts","pos":572}},
+{"pid":1,"tid":3,"ph":"X","cat":"check","ts":25000000,"name":"checkVariableDeclaration","dur":1000000,"args":{"end":650,"kind":"VariableDeclaration","path":"/home/src/tslibs/TS/Lib/lib.d.ts","pos":616}},
+{"pid":1,"tid":3,"ph":"X","cat":"check","ts":27000000,"name":"checkDeferredNode","dur":1000000,"args":{"end":297,"kind":"TypeParameter","path":"/home/src/tslibs/TS/Lib/lib.d.ts","pos":296}},
+{"pid":1,"tid":3,"ph":"X","cat":"check","ts":29000000,"name":"checkDeferredNode","dur":1000000,"args":{"end":360,"kind":"TypeParameter","path":"/home/src/tslibs/TS/Lib/lib.d.ts","pos":359}},
+{"pid":1,"tid":3,"ph":"E","cat":"check","ts":31000000,"name":"checkSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":4,"ph":"B","cat":"check","ts":32000000,"name":"checkSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":4,"ph":"X","cat":"check","ts":34000000,"name":"checkExpression","dur":1000000,"args":{"end":26,"kind":"NumericLiteral","path":"/home/src/workspaces/project/index.ts","pos":24}},
+{"pid":1,"tid":4,"ph":"X","cat":"check","ts":33000000,"name":"checkVariableDeclaration","dur":3000000,"args":{"end":26,"kind":"VariableDeclaration","path":"/home/src/workspaces/project/index.ts","pos":12}},
+{"pid":1,"tid":4,"ph":"E","cat":"check","ts":37000000,"name":"checkSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"B","cat":"emit","ts":40000000,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/index.js"}},
+{"pid":1,"tid":2,"ph":"E","cat":"emit","ts":42000000,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/index.js"}},
+{"pid":1,"tid":2,"ph":"B","cat":"emit","ts":43000000,"name":"emitDeclarationFileOrBundle","args":{"declarationFilePath":""}},
+{"pid":1,"tid":2,"ph":"E","cat":"emit","ts":44000000,"name":"emitDeclarationFileOrBundle","args":{"declarationFilePath":""}}
+]
+
+//// [/home/src/workspaces/project/trace/types.1.json] *new* 
+[
+{"id":1,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":6,"tid":3,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"tid":3,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"tid":3,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"tid":3,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"tid":3,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"tid":3,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"tid":3,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"tid":3,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"tid":3,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"tid":3,"intrinsicName":"string","flags":["String"]},
+{"id":17,"tid":3,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"tid":3,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"tid":3,"flags":["BooleanLiteral"],"display":"false"},
+{"id":20,"tid":3,"flags":["BooleanLiteral"],"display":"false"},
+{"id":21,"tid":3,"flags":["BooleanLiteral"],"display":"true"},
+{"id":22,"tid":3,"flags":["BooleanLiteral"],"display":"true"},
+{"id":23,"tid":3,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"tid":3,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"tid":3,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"tid":3,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"tid":3,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"tid":3,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"tid":3,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"tid":3,"flags":["TemplateLiteral"]},
+{"id":35,"tid":3,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":38,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":39,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":40,"tid":3,"symbolName":"__type","flags":["Object"],"display":"{}"},
+{"id":41,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":42,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":43,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":44,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":45,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":46,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":47,"tid":3,"flags":["TypeParameter"]},
+{"id":48,"tid":3,"flags":["TypeParameter"]},
+{"id":49,"tid":3,"flags":["TypeParameter"]},
+{"id":50,"tid":3,"flags":["TypeParameter"]},
+{"id":51,"tid":3,"flags":["TypeParameter"]},
+{"id":52,"tid":3,"flags":["StringLiteral"],"display":"\"\""},
+{"id":53,"tid":3,"flags":["NumberLiteral"],"display":"0"},
+{"id":54,"tid":3,"flags":["BigIntLiteral"],"display":"0n"},
+{"id":55,"tid":3,"flags":["StringLiteral"],"display":"\"bigint\""},
+{"id":56,"tid":3,"flags":["StringLiteral"],"display":"\"boolean\""},
+{"id":57,"tid":3,"flags":["StringLiteral"],"display":"\"function\""},
+{"id":58,"tid":3,"flags":["StringLiteral"],"display":"\"number\""},
+{"id":59,"tid":3,"flags":["StringLiteral"],"display":"\"object\""},
+{"id":60,"tid":3,"flags":["StringLiteral"],"display":"\"string\""},
+{"id":61,"tid":3,"flags":["StringLiteral"],"display":"\"symbol\""},
+{"id":62,"tid":3,"flags":["StringLiteral"],"display":"\"undefined\""},
+{"id":63,"tid":3,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},
+{"id":64,"tid":3,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},
+{"id":65,"tid":3,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},
+{"id":66,"tid":3,"symbolName":"Array","instantiatedType":66,"typeArguments":[67],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":67,"tid":3,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},
+{"id":68,"tid":3,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},
+{"id":69,"tid":3,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},
+{"id":70,"tid":3,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},
+{"id":71,"tid":3,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},
+{"id":72,"tid":3,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},
+{"id":73,"tid":3,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":74,"tid":3,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},
+{"id":75,"tid":3,"symbolName":"Array","instantiatedType":66,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":76,"tid":3,"symbolName":"Array","instantiatedType":66,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":77,"tid":3,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":78,"tid":3,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},
+{"id":79,"tid":3,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},
+{"id":80,"tid":3,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":81,"tid":3,"symbolName":"CallableFunction","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":3,"character":22},"end":{"line":4,"character":30}},"flags":["Object"]},
+{"id":82,"tid":3,"symbolName":"NewableFunction","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":4,"character":30},"end":{"line":5,"character":29}},"flags":["Object"]},
+{"id":83,"tid":3,"symbolName":"Array","instantiatedType":66,"typeArguments":[67,68],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":84,"tid":3,"flags":["StringLiteral"],"display":"\"length\""},
+{"id":85,"tid":3,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78,79],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":86,"tid":3,"symbolName":"SymbolConstructor","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":30},"end":{"line":17,"character":2}},"flags":["Object"]},
+{"id":87,"tid":3,"symbolName":"toStringTag","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":15,"character":31},"end":{"line":16,"character":34}},"flags":["UniqueESSymbol"]},
+{"id":88,"tid":3,"symbolName":"Symbol","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":18,"character":12},"end":{"line":18,"character":38}},"flags":["Object"]},
+{"id":89,"tid":3,"symbolName":"__type","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":22,"character":23},"end":{"line":22,"character":48}},"flags":["Object"],"display":"{ log(msg: any): void; }"},
+{"id":1,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":6,"tid":4,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"tid":4,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"tid":4,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"tid":4,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"tid":4,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"tid":4,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"tid":4,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"tid":4,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"tid":4,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"tid":4,"intrinsicName":"string","flags":["String"]},
+{"id":17,"tid":4,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"tid":4,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"tid":4,"flags":["BooleanLiteral"],"display":"false"},
+{"id":20,"tid":4,"flags":["BooleanLiteral"],"display":"false"},
+{"id":21,"tid":4,"flags":["BooleanLiteral"],"display":"true"},
+{"id":22,"tid":4,"flags":["BooleanLiteral"],"display":"true"},
+{"id":23,"tid":4,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"tid":4,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"tid":4,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"tid":4,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"tid":4,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"tid":4,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"tid":4,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"tid":4,"flags":["TemplateLiteral"]},
+{"id":35,"tid":4,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":38,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":39,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":40,"tid":4,"symbolName":"__type","flags":["Object"],"display":"{}"},
+{"id":41,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":42,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":43,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":44,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":45,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":46,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":47,"tid":4,"flags":["TypeParameter"]},
+{"id":48,"tid":4,"flags":["TypeParameter"]},
+{"id":49,"tid":4,"flags":["TypeParameter"]},
+{"id":50,"tid":4,"flags":["TypeParameter"]},
+{"id":51,"tid":4,"flags":["TypeParameter"]},
+{"id":52,"tid":4,"flags":["StringLiteral"],"display":"\"\""},
+{"id":53,"tid":4,"flags":["NumberLiteral"],"display":"0"},
+{"id":54,"tid":4,"flags":["BigIntLiteral"],"display":"0n"},
+{"id":55,"tid":4,"flags":["StringLiteral"],"display":"\"bigint\""},
+{"id":56,"tid":4,"flags":["StringLiteral"],"display":"\"boolean\""},
+{"id":57,"tid":4,"flags":["StringLiteral"],"display":"\"function\""},
+{"id":58,"tid":4,"flags":["StringLiteral"],"display":"\"number\""},
+{"id":59,"tid":4,"flags":["StringLiteral"],"display":"\"object\""},
+{"id":60,"tid":4,"flags":["StringLiteral"],"display":"\"string\""},
+{"id":61,"tid":4,"flags":["StringLiteral"],"display":"\"symbol\""},
+{"id":62,"tid":4,"flags":["StringLiteral"],"display":"\"undefined\""},
+{"id":63,"tid":4,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},
+{"id":64,"tid":4,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},
+{"id":65,"tid":4,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},
+{"id":66,"tid":4,"symbolName":"Array","instantiatedType":66,"typeArguments":[67],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":67,"tid":4,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},
+{"id":68,"tid":4,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},
+{"id":69,"tid":4,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},
+{"id":70,"tid":4,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},
+{"id":71,"tid":4,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},
+{"id":72,"tid":4,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},
+{"id":73,"tid":4,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":74,"tid":4,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},
+{"id":75,"tid":4,"symbolName":"Array","instantiatedType":66,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":76,"tid":4,"symbolName":"Array","instantiatedType":66,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":77,"tid":4,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":78,"tid":4,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},
+{"id":79,"tid":4,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},
+{"id":80,"tid":4,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":81,"tid":4,"flags":["NumberLiteral"],"display":"1"},
+{"id":82,"tid":4,"flags":["NumberLiteral"],"display":"1"},
+{"id":1,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":6,"tid":5,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"tid":5,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"tid":5,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"tid":5,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"tid":5,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"tid":5,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"tid":5,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"tid":5,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"tid":5,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"tid":5,"intrinsicName":"string","flags":["String"]},
+{"id":17,"tid":5,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"tid":5,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"tid":5,"flags":["BooleanLiteral"],"display":"false"},
+{"id":20,"tid":5,"flags":["BooleanLiteral"],"display":"false"},
+{"id":21,"tid":5,"flags":["BooleanLiteral"],"display":"true"},
+{"id":22,"tid":5,"flags":["BooleanLiteral"],"display":"true"},
+{"id":23,"tid":5,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"tid":5,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"tid":5,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"tid":5,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"tid":5,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"tid":5,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"tid":5,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"tid":5,"flags":["TemplateLiteral"]},
+{"id":35,"tid":5,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":38,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":39,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":40,"tid":5,"symbolName":"__type","flags":["Object"],"display":"{}"},
+{"id":41,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":42,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":43,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":44,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":45,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":46,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":47,"tid":5,"flags":["TypeParameter"]},
+{"id":48,"tid":5,"flags":["TypeParameter"]},
+{"id":49,"tid":5,"flags":["TypeParameter"]},
+{"id":50,"tid":5,"flags":["TypeParameter"]},
+{"id":51,"tid":5,"flags":["TypeParameter"]},
+{"id":52,"tid":5,"flags":["StringLiteral"],"display":"\"\""},
+{"id":53,"tid":5,"flags":["NumberLiteral"],"display":"0"},
+{"id":54,"tid":5,"flags":["BigIntLiteral"],"display":"0n"},
+{"id":55,"tid":5,"flags":["StringLiteral"],"display":"\"bigint\""},
+{"id":56,"tid":5,"flags":["StringLiteral"],"display":"\"boolean\""},
+{"id":57,"tid":5,"flags":["StringLiteral"],"display":"\"function\""},
+{"id":58,"tid":5,"flags":["StringLiteral"],"display":"\"number\""},
+{"id":59,"tid":5,"flags":["StringLiteral"],"display":"\"object\""},
+{"id":60,"tid":5,"flags":["StringLiteral"],"display":"\"string\""},
+{"id":61,"tid":5,"flags":["StringLiteral"],"display":"\"symbol\""},
+{"id":62,"tid":5,"flags":["StringLiteral"],"display":"\"undefined\""},
+{"id":63,"tid":5,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},
+{"id":64,"tid":5,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},
+{"id":65,"tid":5,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},
+{"id":66,"tid":5,"symbolName":"Array","instantiatedType":66,"typeArguments":[67],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":67,"tid":5,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},
+{"id":68,"tid":5,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},
+{"id":69,"tid":5,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},
+{"id":70,"tid":5,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},
+{"id":71,"tid":5,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},
+{"id":72,"tid":5,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},
+{"id":73,"tid":5,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":74,"tid":5,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},
+{"id":75,"tid":5,"symbolName":"Array","instantiatedType":66,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":76,"tid":5,"symbolName":"Array","instantiatedType":66,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":77,"tid":5,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":78,"tid":5,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},
+{"id":79,"tid":5,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},
+{"id":80,"tid":5,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":1,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":6,"tid":6,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"tid":6,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"tid":6,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"tid":6,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"tid":6,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"tid":6,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"tid":6,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"tid":6,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"tid":6,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"tid":6,"intrinsicName":"string","flags":["String"]},
+{"id":17,"tid":6,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"tid":6,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"tid":6,"flags":["BooleanLiteral"],"display":"false"},
+{"id":20,"tid":6,"flags":["BooleanLiteral"],"display":"false"},
+{"id":21,"tid":6,"flags":["BooleanLiteral"],"display":"true"},
+{"id":22,"tid":6,"flags":["BooleanLiteral"],"display":"true"},
+{"id":23,"tid":6,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"tid":6,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"tid":6,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"tid":6,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"tid":6,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"tid":6,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"tid":6,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"tid":6,"flags":["TemplateLiteral"]},
+{"id":35,"tid":6,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":38,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":39,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":40,"tid":6,"symbolName":"__type","flags":["Object"],"display":"{}"},
+{"id":41,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":42,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":43,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":44,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":45,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":46,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":47,"tid":6,"flags":["TypeParameter"]},
+{"id":48,"tid":6,"flags":["TypeParameter"]},
+{"id":49,"tid":6,"flags":["TypeParameter"]},
+{"id":50,"tid":6,"flags":["TypeParameter"]},
+{"id":51,"tid":6,"flags":["TypeParameter"]},
+{"id":52,"tid":6,"flags":["StringLiteral"],"display":"\"\""},
+{"id":53,"tid":6,"flags":["NumberLiteral"],"display":"0"},
+{"id":54,"tid":6,"flags":["BigIntLiteral"],"display":"0n"},
+{"id":55,"tid":6,"flags":["StringLiteral"],"display":"\"bigint\""},
+{"id":56,"tid":6,"flags":["StringLiteral"],"display":"\"boolean\""},
+{"id":57,"tid":6,"flags":["StringLiteral"],"display":"\"function\""},
+{"id":58,"tid":6,"flags":["StringLiteral"],"display":"\"number\""},
+{"id":59,"tid":6,"flags":["StringLiteral"],"display":"\"object\""},
+{"id":60,"tid":6,"flags":["StringLiteral"],"display":"\"string\""},
+{"id":61,"tid":6,"flags":["StringLiteral"],"display":"\"symbol\""},
+{"id":62,"tid":6,"flags":["StringLiteral"],"display":"\"undefined\""},
+{"id":63,"tid":6,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},
+{"id":64,"tid":6,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},
+{"id":65,"tid":6,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},
+{"id":66,"tid":6,"symbolName":"Array","instantiatedType":66,"typeArguments":[67],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":67,"tid":6,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},
+{"id":68,"tid":6,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},
+{"id":69,"tid":6,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},
+{"id":70,"tid":6,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},
+{"id":71,"tid":6,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},
+{"id":72,"tid":6,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},
+{"id":73,"tid":6,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":74,"tid":6,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},
+{"id":75,"tid":6,"symbolName":"Array","instantiatedType":66,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":76,"tid":6,"symbolName":"Array","instantiatedType":66,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":77,"tid":6,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":78,"tid":6,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},
+{"id":79,"tid":6,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},
+{"id":80,"tid":6,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]}
+]
+
+
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/index.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
+tsconfig.json::
+SemanticDiagnostics::
+*refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
+*refresh*    /home/src/workspaces/project/index.ts
+Signatures::
+
+
+Edit [0]:: change file
+//// [/home/src/workspaces/project/index.ts] *modified* 
+export const a: string = "";
+
+
+Output::
+build starting at HH:MM:SS AM
+build finished in d.ddds
+//// [/home/src/workspaces/project/index.js] *modified* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.a = void 0;
+exports.a = "";
+
+//// [/home/src/workspaces/project/trace/legend.json] *modified* 
+[
+{"configFilePath":"/home/src/workspaces/project/tsconfig.json","tracePath":"/home/src/workspaces/project/trace/trace.1.json","typesPath":"/home/src/workspaces/project/trace/types.1.json"},
+{"configFilePath":"/home/src/workspaces/project/tsconfig.json","tracePath":"/home/src/workspaces/project/trace/trace.2.json","typesPath":"/home/src/workspaces/project/trace/types.2.json"}
+]
+
+//// [/home/src/workspaces/project/trace/trace.2.json] *new* 
+[
+{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"process_name","args":{"name":"tsc"}},
+{"pid":1,"tid":1,"ph":"M","cat":"disabled-by-default-devtools.timeline","ts":0,"name":"TracingStartedInBrowser","args":{"data":{"sessionId":"-1"}}},
+{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Main"}},
+{"pid":1,"tid":1,"ph":"B","cat":"program","ts":1000000,"name":"createProgram","args":{"configFilePath":"/home/src/workspaces/project/tsconfig.json","rootDir":"/home/src/workspaces/project"}},
+{"pid":1,"tid":2,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Worker"}},
+{"pid":1,"tid":2,"ph":"B","cat":"parse","ts":2000000,"name":"createSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"parse","ts":3000000,"name":"createSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"B","cat":"parse","ts":4000000,"name":"createSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"parse","ts":5000000,"name":"createSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":1,"ph":"E","cat":"program","ts":6000000,"name":"createProgram","args":{"configFilePath":"/home/src/workspaces/project/tsconfig.json","rootDir":"/home/src/workspaces/project"}},
+{"pid":1,"tid":2,"ph":"B","cat":"bind","ts":7000000,"name":"bindSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"bind","ts":8000000,"name":"bindSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"B","cat":"bind","ts":9000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"bind","ts":10000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":3,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker"}},
+{"pid":1,"tid":4,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker"}},
+{"pid":1,"tid":5,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker"}},
+{"pid":1,"tid":2,"ph":"B","cat":"bind","ts":11000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"bind","ts":12000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},
+{"pid":1,"tid":6,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker"}},
+{"pid":1,"tid":2,"ph":"B","cat":"emit","ts":18000000,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/index.js"}},
+{"pid":1,"tid":2,"ph":"E","cat":"emit","ts":19000000,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/index.js"}},
+{"pid":1,"tid":2,"ph":"B","cat":"emit","ts":20000000,"name":"emitDeclarationFileOrBundle","args":{"declarationFilePath":"/home/src/workspaces/project/index.d.ts"}},
+{"pid":1,"tid":2,"ph":"E","cat":"emit","ts":21000000,"name":"emitDeclarationFileOrBundle","args":{"declarationFilePath":"/home/src/workspaces/project/index.d.ts"}},
+{"pid":1,"tid":4,"ph":"B","cat":"check","ts":22000000,"name":"checkSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":4,"ph":"X","cat":"check","ts":24000000,"name":"checkExpression","dur":1000000,"args":{"end":27,"kind":"StringLiteral","path":"/home/src/workspaces/project/index.ts","pos":24}},
+{"pid":1,"tid":4,"ph":"X","cat":"check","ts":23000000,"name":"checkVariableDeclaration","dur":3000000,"args":{"end":27,"kind":"VariableDeclaration","path":"/home/src/workspaces/project/index.ts","pos":12}},
+{"pid":1,"tid":4,"ph":"E","cat":"check","ts":27000000,"name":"checkSourceFile","args":{"path":"/home/src/workspaces/project/index.ts"}},
+{"pid":1,"tid":2,"ph":"B","cat":"emit","ts":30000000,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/index.js"}},
+{"pid":1,"tid":2,"ph":"E","cat":"emit","ts":32000000,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/index.js"}},
+{"pid":1,"tid":2,"ph":"B","cat":"emit","ts":33000000,"name":"emitDeclarationFileOrBundle","args":{"declarationFilePath":""}},
+{"pid":1,"tid":2,"ph":"E","cat":"emit","ts":34000000,"name":"emitDeclarationFileOrBundle","args":{"declarationFilePath":""}}
+]
+
+//// [/home/src/workspaces/project/trace/types.2.json] *new* 
+[
+{"id":1,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":6,"tid":3,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"tid":3,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"tid":3,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"tid":3,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"tid":3,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"tid":3,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"tid":3,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"tid":3,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"tid":3,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"tid":3,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"tid":3,"intrinsicName":"string","flags":["String"]},
+{"id":17,"tid":3,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"tid":3,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"tid":3,"flags":["BooleanLiteral"],"display":"false"},
+{"id":20,"tid":3,"flags":["BooleanLiteral"],"display":"false"},
+{"id":21,"tid":3,"flags":["BooleanLiteral"],"display":"true"},
+{"id":22,"tid":3,"flags":["BooleanLiteral"],"display":"true"},
+{"id":23,"tid":3,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"tid":3,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"tid":3,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"tid":3,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"tid":3,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"tid":3,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"tid":3,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"tid":3,"flags":["TemplateLiteral"]},
+{"id":35,"tid":3,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"tid":3,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":38,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":39,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":40,"tid":3,"symbolName":"__type","flags":["Object"],"display":"{}"},
+{"id":41,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":42,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":43,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":44,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":45,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":46,"tid":3,"flags":["Object"],"display":"{}"},
+{"id":47,"tid":3,"flags":["TypeParameter"]},
+{"id":48,"tid":3,"flags":["TypeParameter"]},
+{"id":49,"tid":3,"flags":["TypeParameter"]},
+{"id":50,"tid":3,"flags":["TypeParameter"]},
+{"id":51,"tid":3,"flags":["TypeParameter"]},
+{"id":52,"tid":3,"flags":["StringLiteral"],"display":"\"\""},
+{"id":53,"tid":3,"flags":["NumberLiteral"],"display":"0"},
+{"id":54,"tid":3,"flags":["BigIntLiteral"],"display":"0n"},
+{"id":55,"tid":3,"flags":["StringLiteral"],"display":"\"bigint\""},
+{"id":56,"tid":3,"flags":["StringLiteral"],"display":"\"boolean\""},
+{"id":57,"tid":3,"flags":["StringLiteral"],"display":"\"function\""},
+{"id":58,"tid":3,"flags":["StringLiteral"],"display":"\"number\""},
+{"id":59,"tid":3,"flags":["StringLiteral"],"display":"\"object\""},
+{"id":60,"tid":3,"flags":["StringLiteral"],"display":"\"string\""},
+{"id":61,"tid":3,"flags":["StringLiteral"],"display":"\"symbol\""},
+{"id":62,"tid":3,"flags":["StringLiteral"],"display":"\"undefined\""},
+{"id":63,"tid":3,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},
+{"id":64,"tid":3,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},
+{"id":65,"tid":3,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},
+{"id":66,"tid":3,"symbolName":"Array","instantiatedType":66,"typeArguments":[67],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":67,"tid":3,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},
+{"id":68,"tid":3,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},
+{"id":69,"tid":3,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},
+{"id":70,"tid":3,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},
+{"id":71,"tid":3,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},
+{"id":72,"tid":3,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},
+{"id":73,"tid":3,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":74,"tid":3,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},
+{"id":75,"tid":3,"symbolName":"Array","instantiatedType":66,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":76,"tid":3,"symbolName":"Array","instantiatedType":66,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":77,"tid":3,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":78,"tid":3,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},
+{"id":79,"tid":3,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},
+{"id":80,"tid":3,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":1,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":6,"tid":4,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"tid":4,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"tid":4,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"tid":4,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"tid":4,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"tid":4,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"tid":4,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"tid":4,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"tid":4,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"tid":4,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"tid":4,"intrinsicName":"string","flags":["String"]},
+{"id":17,"tid":4,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"tid":4,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"tid":4,"flags":["BooleanLiteral"],"display":"false"},
+{"id":20,"tid":4,"flags":["BooleanLiteral"],"display":"false"},
+{"id":21,"tid":4,"flags":["BooleanLiteral"],"display":"true"},
+{"id":22,"tid":4,"flags":["BooleanLiteral"],"display":"true"},
+{"id":23,"tid":4,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"tid":4,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"tid":4,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"tid":4,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"tid":4,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"tid":4,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"tid":4,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"tid":4,"flags":["TemplateLiteral"]},
+{"id":35,"tid":4,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"tid":4,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":38,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":39,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":40,"tid":4,"symbolName":"__type","flags":["Object"],"display":"{}"},
+{"id":41,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":42,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":43,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":44,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":45,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":46,"tid":4,"flags":["Object"],"display":"{}"},
+{"id":47,"tid":4,"flags":["TypeParameter"]},
+{"id":48,"tid":4,"flags":["TypeParameter"]},
+{"id":49,"tid":4,"flags":["TypeParameter"]},
+{"id":50,"tid":4,"flags":["TypeParameter"]},
+{"id":51,"tid":4,"flags":["TypeParameter"]},
+{"id":52,"tid":4,"flags":["StringLiteral"],"display":"\"\""},
+{"id":53,"tid":4,"flags":["NumberLiteral"],"display":"0"},
+{"id":54,"tid":4,"flags":["BigIntLiteral"],"display":"0n"},
+{"id":55,"tid":4,"flags":["StringLiteral"],"display":"\"bigint\""},
+{"id":56,"tid":4,"flags":["StringLiteral"],"display":"\"boolean\""},
+{"id":57,"tid":4,"flags":["StringLiteral"],"display":"\"function\""},
+{"id":58,"tid":4,"flags":["StringLiteral"],"display":"\"number\""},
+{"id":59,"tid":4,"flags":["StringLiteral"],"display":"\"object\""},
+{"id":60,"tid":4,"flags":["StringLiteral"],"display":"\"string\""},
+{"id":61,"tid":4,"flags":["StringLiteral"],"display":"\"symbol\""},
+{"id":62,"tid":4,"flags":["StringLiteral"],"display":"\"undefined\""},
+{"id":63,"tid":4,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},
+{"id":64,"tid":4,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},
+{"id":65,"tid":4,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},
+{"id":66,"tid":4,"symbolName":"Array","instantiatedType":66,"typeArguments":[67],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":67,"tid":4,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},
+{"id":68,"tid":4,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},
+{"id":69,"tid":4,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},
+{"id":70,"tid":4,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},
+{"id":71,"tid":4,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},
+{"id":72,"tid":4,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},
+{"id":73,"tid":4,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":74,"tid":4,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},
+{"id":75,"tid":4,"symbolName":"Array","instantiatedType":66,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":76,"tid":4,"symbolName":"Array","instantiatedType":66,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":77,"tid":4,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":78,"tid":4,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},
+{"id":79,"tid":4,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},
+{"id":80,"tid":4,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":81,"tid":4,"flags":["StringLiteral"],"display":"\"\""},
+{"id":1,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":6,"tid":5,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"tid":5,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"tid":5,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"tid":5,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"tid":5,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"tid":5,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"tid":5,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"tid":5,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"tid":5,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"tid":5,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"tid":5,"intrinsicName":"string","flags":["String"]},
+{"id":17,"tid":5,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"tid":5,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"tid":5,"flags":["BooleanLiteral"],"display":"false"},
+{"id":20,"tid":5,"flags":["BooleanLiteral"],"display":"false"},
+{"id":21,"tid":5,"flags":["BooleanLiteral"],"display":"true"},
+{"id":22,"tid":5,"flags":["BooleanLiteral"],"display":"true"},
+{"id":23,"tid":5,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"tid":5,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"tid":5,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"tid":5,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"tid":5,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"tid":5,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"tid":5,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"tid":5,"flags":["TemplateLiteral"]},
+{"id":35,"tid":5,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"tid":5,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":38,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":39,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":40,"tid":5,"symbolName":"__type","flags":["Object"],"display":"{}"},
+{"id":41,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":42,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":43,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":44,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":45,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":46,"tid":5,"flags":["Object"],"display":"{}"},
+{"id":47,"tid":5,"flags":["TypeParameter"]},
+{"id":48,"tid":5,"flags":["TypeParameter"]},
+{"id":49,"tid":5,"flags":["TypeParameter"]},
+{"id":50,"tid":5,"flags":["TypeParameter"]},
+{"id":51,"tid":5,"flags":["TypeParameter"]},
+{"id":52,"tid":5,"flags":["StringLiteral"],"display":"\"\""},
+{"id":53,"tid":5,"flags":["NumberLiteral"],"display":"0"},
+{"id":54,"tid":5,"flags":["BigIntLiteral"],"display":"0n"},
+{"id":55,"tid":5,"flags":["StringLiteral"],"display":"\"bigint\""},
+{"id":56,"tid":5,"flags":["StringLiteral"],"display":"\"boolean\""},
+{"id":57,"tid":5,"flags":["StringLiteral"],"display":"\"function\""},
+{"id":58,"tid":5,"flags":["StringLiteral"],"display":"\"number\""},
+{"id":59,"tid":5,"flags":["StringLiteral"],"display":"\"object\""},
+{"id":60,"tid":5,"flags":["StringLiteral"],"display":"\"string\""},
+{"id":61,"tid":5,"flags":["StringLiteral"],"display":"\"symbol\""},
+{"id":62,"tid":5,"flags":["StringLiteral"],"display":"\"undefined\""},
+{"id":63,"tid":5,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},
+{"id":64,"tid":5,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},
+{"id":65,"tid":5,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},
+{"id":66,"tid":5,"symbolName":"Array","instantiatedType":66,"typeArguments":[67],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":67,"tid":5,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},
+{"id":68,"tid":5,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},
+{"id":69,"tid":5,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},
+{"id":70,"tid":5,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},
+{"id":71,"tid":5,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},
+{"id":72,"tid":5,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},
+{"id":73,"tid":5,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":74,"tid":5,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},
+{"id":75,"tid":5,"symbolName":"Array","instantiatedType":66,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":76,"tid":5,"symbolName":"Array","instantiatedType":66,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":77,"tid":5,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":78,"tid":5,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},
+{"id":79,"tid":5,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},
+{"id":80,"tid":5,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":1,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":2,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":3,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":4,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":5,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":6,"tid":6,"intrinsicName":"unresolved","flags":["Any"]},
+{"id":7,"tid":6,"intrinsicName":"any","flags":["Any"]},
+{"id":8,"tid":6,"intrinsicName":"intrinsic","flags":["Any"]},
+{"id":9,"tid":6,"intrinsicName":"unknown","flags":["Unknown"]},
+{"id":10,"tid":6,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":11,"tid":6,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":12,"tid":6,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":13,"tid":6,"intrinsicName":"undefined","flags":["Undefined"]},
+{"id":14,"tid":6,"intrinsicName":"null","flags":["Null"]},
+{"id":15,"tid":6,"intrinsicName":"null","flags":["Null"]},
+{"id":16,"tid":6,"intrinsicName":"string","flags":["String"]},
+{"id":17,"tid":6,"intrinsicName":"number","flags":["Number"]},
+{"id":18,"tid":6,"intrinsicName":"bigint","flags":["BigInt"]},
+{"id":19,"tid":6,"flags":["BooleanLiteral"],"display":"false"},
+{"id":20,"tid":6,"flags":["BooleanLiteral"],"display":"false"},
+{"id":21,"tid":6,"flags":["BooleanLiteral"],"display":"true"},
+{"id":22,"tid":6,"flags":["BooleanLiteral"],"display":"true"},
+{"id":23,"tid":6,"unionTypes":[19,21],"flags":["Boolean","Union"]},
+{"id":24,"tid":6,"intrinsicName":"symbol","flags":["ESSymbol"]},
+{"id":25,"tid":6,"intrinsicName":"void","flags":["Void"]},
+{"id":26,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":27,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":28,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":29,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":30,"tid":6,"intrinsicName":"object","flags":["NonPrimitive"]},
+{"id":31,"tid":6,"unionTypes":[16,17],"flags":["Union"]},
+{"id":32,"tid":6,"unionTypes":[16,17,24],"flags":["Union"]},
+{"id":33,"tid":6,"unionTypes":[17,18],"flags":["Union"]},
+{"id":34,"tid":6,"flags":["TemplateLiteral"]},
+{"id":35,"tid":6,"unionTypes":[16,17,18,19,21],"flags":["Union"]},
+{"id":36,"tid":6,"intrinsicName":"never","flags":["Never"]},
+{"id":37,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":38,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":39,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":40,"tid":6,"symbolName":"__type","flags":["Object"],"display":"{}"},
+{"id":41,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":42,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":43,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":44,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":45,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":46,"tid":6,"flags":["Object"],"display":"{}"},
+{"id":47,"tid":6,"flags":["TypeParameter"]},
+{"id":48,"tid":6,"flags":["TypeParameter"]},
+{"id":49,"tid":6,"flags":["TypeParameter"]},
+{"id":50,"tid":6,"flags":["TypeParameter"]},
+{"id":51,"tid":6,"flags":["TypeParameter"]},
+{"id":52,"tid":6,"flags":["StringLiteral"],"display":"\"\""},
+{"id":53,"tid":6,"flags":["NumberLiteral"],"display":"0"},
+{"id":54,"tid":6,"flags":["BigIntLiteral"],"display":"0n"},
+{"id":55,"tid":6,"flags":["StringLiteral"],"display":"\"bigint\""},
+{"id":56,"tid":6,"flags":["StringLiteral"],"display":"\"boolean\""},
+{"id":57,"tid":6,"flags":["StringLiteral"],"display":"\"function\""},
+{"id":58,"tid":6,"flags":["StringLiteral"],"display":"\"number\""},
+{"id":59,"tid":6,"flags":["StringLiteral"],"display":"\"object\""},
+{"id":60,"tid":6,"flags":["StringLiteral"],"display":"\"string\""},
+{"id":61,"tid":6,"flags":["StringLiteral"],"display":"\"symbol\""},
+{"id":62,"tid":6,"flags":["StringLiteral"],"display":"\"undefined\""},
+{"id":63,"tid":6,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},
+{"id":64,"tid":6,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},
+{"id":65,"tid":6,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},
+{"id":66,"tid":6,"symbolName":"Array","instantiatedType":66,"typeArguments":[67],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":67,"tid":6,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},
+{"id":68,"tid":6,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},
+{"id":69,"tid":6,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},
+{"id":70,"tid":6,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},
+{"id":71,"tid":6,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},
+{"id":72,"tid":6,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},
+{"id":73,"tid":6,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},
+{"id":74,"tid":6,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},
+{"id":75,"tid":6,"symbolName":"Array","instantiatedType":66,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":76,"tid":6,"symbolName":"Array","instantiatedType":66,"typeArguments":[2],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},
+{"id":77,"tid":6,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[78],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},
+{"id":78,"tid":6,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},
+{"id":79,"tid":6,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},
+{"id":80,"tid":6,"symbolName":"ReadonlyArray","instantiatedType":77,"typeArguments":[1],"firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]}
+]
+
+
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/index.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
+tsconfig.json::
+SemanticDiagnostics::
+*refresh*    /home/src/workspaces/project/index.ts
+Signatures::
+(computed .d.ts) /home/src/workspaces/project/index.ts
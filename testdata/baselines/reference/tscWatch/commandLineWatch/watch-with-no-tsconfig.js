@@ -35,6 +35,11 @@ declare const console: { log(msg: any): void; };
 //// [/home/src/workspaces/project/index.js] *new* 
 
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/index.ts
+Directories::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
 *refresh*    /home/src/workspaces/project/index.ts
@@ -37,6 +37,13 @@ declare const console: { log(msg: any): void; };
 //// [/home/src/workspaces/project/index.js] *new* 
 
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/index.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -39,6 +39,13 @@ interface Symbol {
 }
 declare const console: { log(msg: any): void; };
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -55,6 +62,13 @@ Output::
 build starting at HH:MM:SS AM
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -79,6 +93,13 @@ build finished in d.ddds
 const a = "hello";
 
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -98,6 +119,13 @@ Output::
 build starting at HH:MM:SS AM
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -112,6 +140,13 @@ Output::
 build starting at HH:MM:SS AM
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -134,10 +169,19 @@ build starting at HH:MM:SS AM
 build finished in d.ddds
 //// [/home/src/workspaces/project/a.js] *modified* 
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -157,6 +201,13 @@ Output::
 build starting at HH:MM:SS AM
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -52,6 +52,13 @@ interface Symbol {
 }
 declare const console: { log(msg: any): void; };
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -68,6 +75,13 @@ Output::
 build starting at HH:MM:SS AM
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -95,6 +109,13 @@ declare const a = "hello";
 const a = "hello";
 
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -114,6 +135,13 @@ Output::
 build starting at HH:MM:SS AM
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -140,6 +168,13 @@ Found 1 error in a.ts[90m:1[0m
 
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -181,10 +216,19 @@ declare const a: {
 
 //// [/home/src/workspaces/project/a.js] *modified* 
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -216,6 +260,13 @@ Found 1 error in a.ts[90m:1[0m
 
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
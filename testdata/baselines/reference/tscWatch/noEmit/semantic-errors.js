@@ -47,6 +47,13 @@ interface Symbol {
 }
 declare const console: { log(msg: any): void; };
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -63,6 +70,13 @@ Output::
 build starting at HH:MM:SS AM
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -87,6 +101,13 @@ build finished in d.ddds
 const a = "hello";
 
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -106,6 +127,13 @@ Output::
 build starting at HH:MM:SS AM
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -128,6 +156,13 @@ Found 1 error in a.ts[90m:1[0m
 
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 *refresh*    /home/src/tslibs/TS/Lib/lib.d.ts
@@ -158,6 +193,13 @@ Found 1 error in a.ts[90m:1[0m
 build finished in d.ddds
 //// [/home/src/workspaces/project/a.js] *rewrite with same content*
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
@@ -185,6 +227,13 @@ Found 1 error in a.ts[90m:1[0m
 
 build finished in d.ddds
 
+Watches::
+Files::
+/home/src/tslibs/TS/Lib/lib.d.ts
+/home/src/workspaces/project/a.ts
+/home/src/workspaces/project/tsconfig.json
+Directories::
+/home/src/workspaces/project *recursive*
 tsconfig.json::
 SemanticDiagnostics::
 Signatures::
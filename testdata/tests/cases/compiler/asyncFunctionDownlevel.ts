@@ -0,0 +1,70 @@
+// @target: es2016
+
+declare const p: Promise<number>;
+
+async function simple(x: number) {
+    "use strict";
+    const y = await p;
+    return x + y;
+}
+
+async function withDefaults(a: number, b = 1, ...rest: number[]) {
+    return a + b + rest.length + await p;
+}
+
+async function withPattern({ a }: { a: number }, [b]: number[]) {
+    return a + b;
+}
+
+const arrow = async (x: number) => await p + x;
+
+const arrowWithDefaults = async (a: number, b = a) => {
+    return a + b;
+};
+
+function outer(this: unknown) {
+    const inner = async () => {
+        return [this, arguments.length, await p];
+    };
+    return inner;
+}
+
+async function usesArguments() {
+    const f = () => arguments[0];
+    return { arguments, f, length: arguments.length };
+}
+
+async function shadowedVars(x: number, { y }: { y: number }) {
+    var x = 1;
+    var z = 2, { y } = { y: x };
+    for (var x of [1, 2]) {
+    }
+    for (var i = 0, y = 0; i < x; i++) {
+    }
+    try {
+    }
+    catch (x) {
+        var x: number;
+    }
+    return x + y + z;
+}
+
+async function nested() {
+    async function inner() {
+        await p;
+    }
+    function notAsync() {
+        return arguments;
+    }
+    await inner();
+    return notAsync;
+}
+
+class C {
+    async method() {
+        return this;
+    }
+    static async staticMethod() {
+        await p;
+    }
+}
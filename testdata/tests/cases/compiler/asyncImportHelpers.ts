@@ -0,0 +1,16 @@
+// @target: es2016
+// @module: commonjs
+// @importHelpers: true
+
+// @filename: main.ts
+export async function f() {
+    await Promise.resolve();
+}
+
+// @filename: script.ts
+async function g() {
+    await Promise.resolve();
+}
+
+// @filename: tslib.d.ts
+export declare function __awaiter(thisArg: any, _arguments: any, P: Function, generator: Function): any;
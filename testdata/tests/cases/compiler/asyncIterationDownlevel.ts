@@ -0,0 +1,89 @@
+// @target: es2017, es2015
+// @lib: esnext
+
+declare const items: AsyncIterable<number>;
+declare function getPairs(): AsyncIterable<{ key: string; value: number; extra: boolean }>;
+declare function log(...args: any[]): void;
+
+async function sum() {
+    let total = 0;
+    for await (const item of items) {
+        total += item;
+    }
+    return total;
+}
+
+async function assignToExisting() {
+    let item: number;
+    for await (item of items) {
+        log(item);
+    }
+}
+
+async function labeledAndNested() {
+    outer: for await (const x of items) {
+        for (let i = 0; i < x; i++) {
+            for await (const y of items) {
+                if (y > i) continue outer;
+                if (y < 0) break outer;
+            }
+        }
+    }
+}
+
+async function withObjectRest() {
+    for await (const { key, ...rest } of getPairs()) {
+        log(key, rest);
+    }
+}
+
+async function* numbers(start = 0, ...more: number[]): AsyncGenerator<number, number, boolean | undefined> {
+    const first = await Promise.resolve(start);
+    const stop = yield first;
+    if (stop) return -1;
+    yield* more;
+    yield* items;
+    for await (const x of items) yield x;
+    try {
+        yield await Promise.resolve(first + 1);
+    }
+    finally {
+        log("cleanup");
+    }
+    return first;
+}
+
+async function* noReturnValue() {
+    yield;
+    return;
+}
+
+class Base {
+    get name() { return "base"; }
+    async *values(): AsyncGenerator<string> {}
+}
+
+class Derived extends Base {
+    async *values() {
+        const key = "values";
+        yield* super[key]();
+        yield* super.values();
+        yield super.name;
+        const inner = async () => super.name;
+        yield await inner();
+    }
+}
+
+const obj = {
+    async *[Symbol.asyncIterator]() {
+        yield 1;
+    },
+    async *gen() {
+        yield this;
+    },
+};
+
+const expr = async function* named() {
+    const sync = function* () { yield 1; };
+    yield* sync();
+};
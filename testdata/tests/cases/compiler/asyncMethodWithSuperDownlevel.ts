@@ -0,0 +1,44 @@
+// @target: es2016
+
+class A {
+    x() {
+        return 1;
+    }
+    get y() {
+        return 2;
+    }
+    set y(value: number) {
+    }
+}
+
+class B extends A {
+    async simple() {
+        const a = super.x();
+        const b = super["x"]();
+        const c = super.y;
+        return a + b + c;
+    }
+
+    async assignment() {
+        super.y = 1;
+        super["y"] = 2;
+        ({ f: super.y } = { f: 3 });
+    }
+
+    arrow() {
+        const before = super.y;
+        const f = async () => super.x() + before;
+        return f;
+    }
+
+    notAsync() {
+        return super.x();
+    }
+}
+
+const o = {
+    __proto__: new A(),
+    async method() {
+        return super.x();
+    },
+};
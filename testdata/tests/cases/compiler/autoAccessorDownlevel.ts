@@ -0,0 +1,21 @@
+// @target: es2015, es2022, esnext
+
+declare function key(): "k";
+
+class C {
+    accessor a = 1;
+    accessor #b = 2;
+    static accessor c: string;
+    static accessor #d = 3;
+    accessor ["e"] = 4;
+    accessor [key()] = 5;
+
+    test() {
+        this.a = this.#b;
+        C.c = String(C.#d);
+    }
+}
+
+const Expression = class {
+    static accessor x = 1;
+};
@@ -0,0 +1,50 @@
+// @target: es2015, es2021, es2022, esnext
+// @useDefineForClassFields: true, false
+
+declare function key1(): "k1";
+declare function key2(): "k2";
+declare function key3(): "k3";
+declare const sym: unique symbol;
+
+class Base {
+    a = 1;
+    b: number;
+    ["c"] = 2;
+    [key1()] = 3;
+    [sym]: string;
+    static d = 4;
+    static e: number;
+    static [key2()] = this.d;
+
+    constructor(public p: number, readonly q = 2) {
+        console.log(this.a);
+    }
+}
+
+class Derived extends Base {
+    f = this.a + 1;
+    static g = super.d;
+}
+
+class DerivedWithConstructor extends Base {
+    /** comment */
+    h = "h";
+
+    constructor() {
+        "use strict";
+        console.log("before");
+        super(1);
+        console.log("after");
+    }
+}
+
+class Keys {
+    [key1()] = 1;
+    [key2()]: number;
+    [key3()]() {}
+}
+
+const Expression = class {
+    static x = 1;
+    y = 2;
+};
@@ -0,0 +1,41 @@
+// @target: es2015, es2021, es2022
+
+class A {
+    static a = 1;
+    static m() { return 2; }
+}
+
+class B extends A {
+    static b = 3;
+    static c = super.a;
+    static {
+        this.b = this.c;
+        super.a;
+        super["a"] = 4;
+        super.a += 5;
+        const x = super.m();
+        const f = () => this.b;
+        function g(this: any) { return this; }
+    }
+}
+
+function outer() {
+    return class {
+        static foo = 1;
+        static {
+            const c = class {
+                static bar = 2;
+                static {
+                    // comment
+                    var temp = this.bar;
+                }
+            };
+        }
+    };
+}
+
+export default class {
+    static {
+        console.log("default");
+    }
+}
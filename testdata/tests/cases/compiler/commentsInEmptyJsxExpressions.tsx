@@ -0,0 +1,14 @@
+// @jsx: preserve
+// @target: es2017
+
+declare namespace JSX { interface IntrinsicElements { div: any } }
+
+export const el = <div>
+    {/* block */}
+    {
+        // line 1
+    }
+    {// line 2
+    }
+    {}
+</div>;
@@ -0,0 +1,40 @@
+// @target: es2017
+// @module: commonjs
+// @sourceMap: true
+
+/** doc for x */
+export const x = 1,
+    /** doc for y */
+    y = 2;
+
+export namespace N {
+    /** inner var */
+    export let v = 1,
+        /** doc for w */
+        w = 2;
+}
+
+declare const a: unknown;
+
+export function f() {
+    return (// comment
+        a as number
+    );
+}
+
+export function g() {
+    return (
+        /* block comment */ a as number
+    );
+}
+
+export const obj = {
+    key: /** trailing colon */ 1,
+};
+
+/** enum doc */
+export enum E {
+    /** member doc */
+    A,
+    B = 2,
+}
@@ -0,0 +1,49 @@
+// @target: es5
+// @downlevelIteration: true
+// @lib: esnext
+
+declare const iterable: Iterable<number>;
+declare function use(...args: any[]): void;
+declare class Box {
+    constructor(...values: number[]);
+}
+
+for (const x of iterable) {
+    use(x);
+}
+
+label: for (const [a, b] of new Map<string, number>()) {
+    for (const c of iterable) {
+        if (c) continue label;
+        use(a, b, c);
+    }
+}
+
+const spread = [...iterable, 1, ...[2, 3]];
+use(...iterable);
+use(1, ...iterable, 2);
+const o = { method(...args: number[]) { return args; } };
+o.method(...iterable);
+new Box(...iterable, 1);
+
+const [first, second = 2, ...rest] = iterable;
+let p: number, q: number;
+[p, q] = [q, p];
+
+function params([a, b]: number[], c = a) {
+    return a + b + c;
+}
+
+try {
+    use();
+}
+catch ([e1, e2]) {
+    use(e1, e2);
+}
+
+function* gen() {
+    yield* iterable;
+    for (const x of iterable) {
+        yield x;
+    }
+}
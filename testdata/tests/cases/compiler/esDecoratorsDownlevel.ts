@@ -0,0 +1,48 @@
+// @target: es2015, es2022, esnext
+
+declare function dec(value: any, context: DecoratorContext): any;
+declare const decorators: { bound: typeof dec };
+declare function key(): "k";
+
+@dec
+class C {
+    @dec method() {}
+    @dec get x() { return 1; }
+    @dec set x(value: number) {}
+    @dec accessor y = 2;
+    @dec field = 3;
+    @dec static staticField = 4;
+    @dec static staticMethod() {}
+    @dec #privateMethod() {}
+    @dec get #privateGetter() { return 5; }
+    @dec set #privateSetter(value: number) {}
+    @dec accessor #privateAccessor = 6;
+    @dec static accessor #staticPrivateAccessor = 7;
+    @decorators.bound ["computed"] = 8;
+    undecorated = 9;
+    @dec [key()]() {}
+}
+
+class D extends C {
+    @dec field = 10;
+
+    constructor() {
+        super();
+        console.log(this.field);
+    }
+}
+
+function addInitializer(value: any, context: DecoratorContext) {
+    context.addInitializer(function () {
+        console.log("initialized");
+    });
+    (context.metadata as any)[String(context.name)] = true;
+}
+
+export const Expression = @addInitializer class {
+    @addInitializer static x = 1;
+};
+
+export default @dec class {
+    static y = this;
+}
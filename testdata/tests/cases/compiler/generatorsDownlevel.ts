@@ -0,0 +1,109 @@
+// @target: es5
+// @lib: esnext
+
+function* simple() {
+    yield 1;
+    const x = yield 2;
+    return x;
+}
+
+function* empty() {
+}
+
+function* loops(n: number) {
+    for (let i = 0; i < n; i++) {
+        if (i % 2) continue;
+        yield i;
+    }
+    let j = 0;
+    while (j < n) {
+        yield j++;
+    }
+    do {
+        yield j--;
+    } while (j > 0);
+    for (var key in { a: 1, b: 2 }) {
+        yield key;
+    }
+    for (const item of [1, 2, 3]) {
+        yield item;
+    }
+}
+
+function* tryCatchFinally() {
+    try {
+        yield 1;
+    }
+    catch (e) {
+        yield e;
+    }
+    finally {
+        yield 3;
+    }
+}
+
+function* expressions(a: any, b: any) {
+    const arr = [a, yield, b];
+    const obj = { a, b: yield, c: 2 };
+    const call = a.method(b, yield);
+    const cond = a ? yield 1 : yield 2;
+    const logical = a && (yield 3);
+    a.prop += yield 4;
+    const created = new a.Ctor(b, yield);
+    return [arr, obj, call, cond, logical, created];
+}
+
+function* delegate() {
+    yield* simple();
+    return yield* [1, 2];
+}
+
+function* labeledAndSwitch(x: number) {
+    outer: for (let i = 0; i < x; i++) {
+        for (let j = 0; j < x; j++) {
+            if (j > i) continue outer;
+            if (j === 5) break outer;
+            yield [i, j];
+        }
+    }
+    switch (x) {
+        case 1:
+            yield "one";
+            break;
+        case yield "test":
+            return;
+        default:
+            yield "other";
+    }
+}
+
+function* hoisted() {
+    yield inner();
+    function inner() {
+        return 1;
+    }
+    class C {
+        method() {
+            return 2;
+        }
+    }
+    yield new C();
+}
+
+const obj = {
+    *method() {
+        yield this;
+    }
+};
+
+class Derived extends Object {
+    *method() {
+        yield super.toString();
+    }
+}
+
+async function asyncFunction() {
+    await Promise.resolve(1);
+    const x = await Promise.resolve(2);
+    return x;
+}
@@ -0,0 +1,58 @@
+// @target: es5
+// @lib: esnext, dom
+
+function* shadowedConst() {
+    const x = 1;
+    {
+        const x = yield 0;
+        console.log(x);
+    }
+    return x;
+}
+
+function* shadowedCatch() {
+    let e = "outer";
+    try {
+        yield 0;
+        throw new Error();
+    }
+    catch (e) {
+        yield e;
+    }
+    return e;
+}
+
+function* shadowedInSiblingBlocks() {
+    {
+        let x = yield 1;
+        const f = () => x;
+        yield f();
+    }
+    {
+        let { x } = { x: yield 2 };
+        yield { x };
+    }
+}
+
+const x = "global";
+function* shadowedOuterReference() {
+    if (x) {
+        const x = yield 0;
+        yield x;
+    }
+    return x;
+}
+
+const g = shadowedConst();
+g.next();
+console.log(g.next(42).value); // 1
+
+const h = shadowedCatch();
+h.next();
+h.next();
+console.log(h.next().value); // "outer"
+
+const o = shadowedOuterReference();
+o.next();
+o.next("inner");
+console.log(o.next().value); // "global"
@@ -0,0 +1,66 @@
+// @isolatedDeclarations: true
+// @declaration: true
+// @strict: true
+// @target: es2022
+
+const value = 1;
+const key = "k" + value;
+
+export function noReturn() {}
+export function singleReturn() { return 0; }
+export const arrow = () => "S";
+export function multipleReturns(x: boolean) { if (x) return 1; return 2; }
+export async function asyncReturn() { return 1; }
+export function parameterDefaults(cb = function () {}, n = 1, v = value) {}
+
+export const fromCall = Math.random();
+export const nothing = undefined;
+export const empty = null;
+export let template = `a${value}`;
+export const constTemplate = `a${value}`;
+export let mutableArray = [1, 2, 3];
+export const constArray = [1, "a"] as const;
+export const spreadArray = [1, ...[2]] as const;
+export const asserted = value as number;
+
+export const obj = {
+    value,
+    ...{ a: 1 },
+    [key]: 1,
+    method() { return 1; },
+    badMethod() { return value; },
+    get x() { return 1; },
+    prop: value,
+};
+
+export class C {
+    field = 1;
+    readonly readonlyTemplate = `x${value}`;
+    other = value;
+    private hidden = value;
+    [key] = 1;
+    get accessor() { return this.field; }
+    set accessor(v) {}
+    set setterOnly(v) {}
+    method() {}
+}
+
+export const Cls = class {};
+export const { a, b } = { a: 1, b: 2 };
+
+export enum E {
+    A = value,
+    B = 2,
+}
+
+export function expando(): void {}
+expando.prop = 1;
+expando.prop = 2;
+expando.other = "";
+
+export default value + 1;
+
+function mixin<T extends new (...args: any[]) => object>(base: T) { return base; }
+export class Base {}
+export class Derived extends mixin(Base) {}
+export const classes = [class {}] as const;
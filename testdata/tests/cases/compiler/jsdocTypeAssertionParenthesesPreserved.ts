@@ -0,0 +1,11 @@
+// @allowJs: true
+// @checkJs: true
+// @outDir: ./out
+// @filename: jsdocTypeAssertionParenthesesPreserved.js
+
+/** @typedef {string & {__nominal: true}} MyNominal */
+const variable = {
+    key: /** @type {MyNominal} */('value'),
+};
+const key = /** @type {any} */ (variable).key;
+const satisfied = /** @satisfies {{ key: string }} */ ({ key: 'value' });
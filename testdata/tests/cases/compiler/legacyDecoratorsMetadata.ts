@@ -0,0 +1,81 @@
+// @target: es2015, es2022
+// @module: commonjs, esnext
+// @experimentalDecorators: true
+// @emitDecoratorMetadata: true
+// @strictNullChecks: true
+
+// @filename: services.ts
+export class Logger {
+    log(message: string) {}
+}
+
+export class Clock {
+    now() { return Date.now(); }
+}
+
+export interface Options {
+    verbose: boolean;
+}
+
+// @filename: main.ts
+import { Clock, Logger, Options } from "./services";
+
+declare function Injectable(): ClassDecorator;
+declare function Inject(token: string): ParameterDecorator;
+declare const Input: PropertyDecorator;
+declare const Method: MethodDecorator;
+declare const Accessor: MethodDecorator;
+
+declare const key: string;
+
+@Injectable()
+export class Service {
+    static instance?: Service;
+
+    @Input
+    name: string | undefined;
+
+    @Input
+    count: number | null = 0;
+
+    @Input
+    options: Options | undefined;
+
+    @Input
+    started: Date = new Date();
+
+    @Input
+    [key]: bigint;
+
+    constructor(private logger: Logger, clock: Clock, @Inject("config") config: Options, ...rest: string[]) {}
+
+    @Method
+    run(@Inject("arg") value: number, flag?: boolean): Promise<void> {
+        return Promise.resolve();
+    }
+
+    @Method
+    async runAsync() {}
+
+    @Accessor
+    get value(): string { return ""; }
+    set value(v: string) {}
+
+    @Method
+    static create(): Service {
+        return Service.instance ??= new Service(new Logger(), undefined!, { verbose: false });
+    }
+}
+
+export class Plain {
+    @Input
+    map: Map<string, Service> | undefined;
+
+    @Method
+    method(@Inject("value") value: "a" | "b", callback: () => void) {}
+}
+
+@Injectable()
+export default class {
+    constructor(service: Service) {}
+}
@@ -0,0 +1,65 @@
+// @declaration: true
+// @stripInternal: true
+
+// @filename: a.ts
+/** Public class */
+export class C {
+    /** Public method */
+    method(): void {}
+    /** @internal */
+    internalMethod(): void {}
+    /** @internal */
+    internalProp = 1;
+    constructor(
+        /** Public parameter property */
+        public x: number,
+        /** @internal */
+        public y: number,
+    ) {}
+}
+
+/** @internal */
+export class InternalClass {}
+
+/** Public interface */
+export interface I {
+    /** Public member */
+    a: number;
+    /** @internal */
+    b: string;
+}
+
+/** Public enum */
+export enum E {
+    /** Public member */
+    A,
+    /** @internal */
+    B,
+    C,
+}
+
+/** Public namespace */
+export namespace N {
+    /** Public value */
+    export const visible = 1;
+    /** @internal */
+    export const hidden = 2;
+    /** @internal */
+    export namespace Inner {
+        export const z = 3;
+    }
+}
+
+/** Public function */
+export function f(a: number): number;
+/** @internal */
+export function f(a: string): string;
+export function f(a: any) { return a; }
+
+/** @internal */
+export { C as InternalAlias };
+export { C as PublicAlias };
+
+// @filename: b.ts
+/** The default export */
+export default 1 + 1;